@@ -2,6 +2,7 @@ package main
 
 import (
 	"runtime"
+	_ "time/tzdata" // zona waktu user tetap bisa dimuat walau image tidak punya tzdata
 
 	"github.com/sirupsen/logrus"
 	"github.com/snykk/transaction-api/cmd/api/server"
@@ -11,20 +11,34 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
 	"github.com/sirupsen/logrus"
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	V1Usecase "github.com/snykk/transaction-api/internal/business/usecases/v1"
 	"github.com/snykk/transaction-api/internal/config"
 	"github.com/snykk/transaction-api/internal/constants"
 	"github.com/snykk/transaction-api/internal/datasources/caches"
+	"github.com/snykk/transaction-api/internal/datasources/publishers"
+	V1PostgresRepository "github.com/snykk/transaction-api/internal/datasources/repositories/postgres/v1"
+	"github.com/snykk/transaction-api/internal/datasources/streams"
 	"github.com/snykk/transaction-api/internal/http/middlewares"
 	"github.com/snykk/transaction-api/internal/http/routes"
+	"github.com/snykk/transaction-api/internal/schedulers"
 	"github.com/snykk/transaction-api/internal/utils"
 	"github.com/snykk/transaction-api/pkg/jwt"
 	"github.com/snykk/transaction-api/pkg/logger"
 	"github.com/snykk/transaction-api/pkg/mailer"
+	"github.com/snykk/transaction-api/pkg/money"
+	"github.com/snykk/transaction-api/pkg/sse"
+	"github.com/snykk/transaction-api/pkg/webhook"
 )
 
 type App struct {
-	HttpServer *http.Server
+	HttpServer          *http.Server
+	TransferScheduler   *schedulers.TransferScheduler
+	HoldExpiryScheduler *schedulers.HoldExpiryScheduler
+	OutboxRelay         *schedulers.OutboxRelay
+	WebhookDispatcher   *schedulers.WebhookDispatcher
 }
 
 func NewApp() (*App, error) {
@@ -47,6 +61,21 @@ func NewApp() (*App, error) {
 		panic(err)
 	}
 
+	// client redis untuk publisher event dan stream wallet antar instance
+	redisClient := redis.NewClient(&redis.Options{
+		Addr:     config.AppConfig.REDISHost,
+		Password: config.AppConfig.REDISPassword,
+	})
+
+	// stream wallet real-time, event dari satu instance diteruskan ke koneksi di semua instance
+	walletStream := streams.NewRedisWalletStream(
+		redisClient,
+		config.AppConfig.WalletStreamChannel,
+		int64(config.AppConfig.WalletStreamHistoryLength),
+		time.Duration(config.AppConfig.WalletStreamRetention)*time.Minute,
+	)
+	walletStream.Start()
+
 	// mailer
 	mailerService := mailer.NewOTPMailer(config.AppConfig.OTPEmail, config.AppConfig.OTPPassword)
 
@@ -58,29 +87,106 @@ func NewApp() (*App, error) {
 	// only user with valid admin token can access endpoint
 	adminMiddleware := middlewares.NewAuthMiddleware(jwtService, true)
 
+	// idempotency middleware
+	// request dengan header Idempotency-Key yang sama akan mendapat response pertama
+	idempotencyMiddleware := middlewares.NewIdempotencyMiddleware(V1PostgresRepository.NewIdempotencyRepository(conn), time.Duration(config.AppConfig.IdempotencyTTL)*time.Hour)
+
+	// limit transaksi global, dapat di-override per user oleh admin
+	defaultLimits := defaultUserLimits()
+
 	// API Routes
 	api := router.Group("api")
 	api.GET("/", routes.RootHandler)
 	routes.NewUsersRoute(api, conn, jwtService, redisCache, ristrettoCache, authMiddleware, mailerService).Routes()
 	routes.NewProductsRoute(api, conn, ristrettoCache, authMiddleware, adminMiddleware).Routes()
-	routes.NewWalletRoute(api, conn, ristrettoCache, authMiddleware, adminMiddleware).Routes()
-	routes.NewTransactionRoute(api, conn, ristrettoCache, authMiddleware, adminMiddleware).Routes()
+	routes.NewWalletRoute(api, conn, ristrettoCache, walletStream, time.Duration(config.AppConfig.WalletStreamHeartbeat)*time.Second, authMiddleware, adminMiddleware).Routes()
+	routes.NewTransactionRoute(api, conn, ristrettoCache, defaultLimits, authMiddleware, adminMiddleware, idempotencyMiddleware).Routes()
+	routes.NewOrderRoute(api, conn, ristrettoCache, defaultLimits, authMiddleware, idempotencyMiddleware).Routes()
+	routes.NewStatementRoute(api, conn, authMiddleware).Routes()
+	routes.NewLimitRoute(api, conn, defaultLimits, authMiddleware, adminMiddleware).Routes()
+	routes.NewFeeRuleRoute(api, conn, authMiddleware, adminMiddleware).Routes()
+	routes.NewVoucherRoute(api, conn, authMiddleware, adminMiddleware).Routes()
+	routes.NewRewardRoute(api, conn, ristrettoCache, authMiddleware, adminMiddleware, idempotencyMiddleware).Routes()
+	routes.NewReconciliationRoute(api, conn, authMiddleware, adminMiddleware).Routes()
+
+	// transfer terjadwal, usecase dipakai bersama oleh route dan scheduler
+	scheduleUsecase := V1Usecase.NewScheduleUsecase(V1PostgresRepository.NewScheduleRepository(conn, defaultLimits), config.AppConfig.ScheduleMaxRetries, time.Duration(config.AppConfig.ScheduleRetryInterval)*time.Minute)
+	routes.NewScheduleRoute(api, conn, scheduleUsecase, authMiddleware).Routes()
+
+	// scheduler berjalan di background selama server hidup
+	transferScheduler := schedulers.NewTransferScheduler(scheduleUsecase, ristrettoCache, time.Duration(config.AppConfig.SchedulerInterval)*time.Second)
+	transferScheduler.Start()
+
+	// hold dana, usecase dipakai bersama oleh route dan sweeper hold kedaluwarsa
+	holdUsecase := V1Usecase.NewHoldUsecase(
+		V1PostgresRepository.NewHoldRepository(conn, defaultLimits),
+		V1PostgresRepository.NewFeeRuleRepository(conn),
+		V1PostgresRepository.NewProductRepository(conn),
+		time.Duration(config.AppConfig.HoldDefaultTTL)*time.Minute,
+		time.Duration(config.AppConfig.HoldMaxTTL)*time.Minute,
+	)
+	routes.NewHoldRoute(api, conn, holdUsecase, ristrettoCache, authMiddleware, idempotencyMiddleware).Routes()
+
+	holdExpiryScheduler := schedulers.NewHoldExpiryScheduler(holdUsecase, ristrettoCache, time.Duration(config.AppConfig.HoldSweepInterval)*time.Second)
+	holdExpiryScheduler.Start()
+
+	// webhook, usecase dipakai bersama oleh route, subscriber outbox, dan dispatcher
+	webhookUsecase := V1Usecase.NewWebhookUsecase(
+		V1PostgresRepository.NewWebhookRepository(conn),
+		webhook.NewSender(time.Duration(config.AppConfig.WebhookTimeout)*time.Second, config.AppConfig.WebhookAllowPrivateNetwork),
+		config.AppConfig.WebhookMaxAttempts,
+		time.Duration(config.AppConfig.WebhookRetryInterval)*time.Second,
+		time.Duration(config.AppConfig.WebhookMaxRetryDelay)*time.Second,
+		time.Duration(config.AppConfig.WebhookLease)*time.Second,
+	)
+	routes.NewWebhookRoute(api, conn, webhookUsecase, authMiddleware).Routes()
+
+	webhookDispatcher := schedulers.NewWebhookDispatcher(webhookUsecase, time.Duration(config.AppConfig.WebhookDispatchInterval)*time.Second)
+	webhookDispatcher.Start()
+
+	// subscriber in-process selalu aktif apa pun publisher eksternalnya
+	inProcessPublisher := publishers.NewInProcessPublisher()
+	inProcessPublisher.Subscribe(constants.EventSubscribeAll, func(ctx context.Context, event V1Domains.EventDomain) error {
+		_, err := webhookUsecase.Enqueue(ctx, event)
+		return err
+	})
+	inProcessPublisher.Subscribe(constants.EventSubscribeAll, publishers.WalletStreamSubscriber(walletStream))
+
+	// relay outbox meneruskan event transaksi yang sudah commit ke publisher
+	outboxUsecase := V1Usecase.NewOutboxUsecase(
+		V1PostgresRepository.NewOutboxRepository(conn),
+		newEventPublisher(redisClient, inProcessPublisher),
+		time.Duration(config.AppConfig.OutboxLease)*time.Second,
+		time.Duration(config.AppConfig.OutboxRetryInterval)*time.Second,
+		time.Duration(config.AppConfig.OutboxMaxRetryDelay)*time.Second,
+	)
+	outboxRelay := schedulers.NewOutboxRelay(outboxUsecase, time.Duration(config.AppConfig.OutboxRelayInterval)*time.Second)
+	outboxRelay.Start()
 
 	// we can add web pages if needed
 	// web := router.Group("web")
 	// ...
 
 	// setup http server
+	// stream SSE memperpanjang WriteTimeout sendiri lewat sse.Handler
 	server := &http.Server{
 		Addr:           fmt.Sprintf(":%d", config.AppConfig.Port),
-		Handler:        router,
+		Handler:        sse.Handler(router),
 		ReadTimeout:    10 * time.Second,
 		WriteTimeout:   10 * time.Second,
 		MaxHeaderBytes: 1 << 20,
 	}
 
+	// Shutdown menunggu request aktif selesai, stream wallet ditutup lebih dulu
+	// agar client SSE dan websocket reconnect ke instance lain
+	server.RegisterOnShutdown(walletStream.Stop)
+
 	return &App{
-		HttpServer: server,
+		HttpServer:          server,
+		TransferScheduler:   transferScheduler,
+		HoldExpiryScheduler: holdExpiryScheduler,
+		OutboxRelay:         outboxRelay,
+		WebhookDispatcher:   webhookDispatcher,
 	}, nil
 }
 
@@ -107,6 +213,12 @@ func (a *App) Run() (err error) {
 		return fmt.Errorf("error when shutdown server: %v", err)
 	}
 
+	// tunggu transfer terjadwal, sweeper hold, relay outbox, dan dispatcher webhook yang sedang berjalan selesai
+	a.TransferScheduler.Stop()
+	a.HoldExpiryScheduler.Stop()
+	a.OutboxRelay.Stop()
+	a.WebhookDispatcher.Stop()
+
 	// catching ctx.Done(). timeout of 5 seconds.
 	<-ctx.Done()
 	logger.Info("timeout of 5 seconds.", logrus.Fields{constants.LoggerCategory: constants.LoggerCategoryServer})
@@ -114,6 +226,36 @@ func (a *App) Run() (err error) {
 	return
 }
 
+// defaultUserLimits membaca limit global dari config, nilainya sudah divalidasi saat config dimuat
+func defaultUserLimits() V1Domains.UserLimitDomain {
+	maxSingleWithdrawal := money.MustParse(config.AppConfig.LimitMaxSingleWithdrawal)
+	dailyWithdrawal := money.MustParse(config.AppConfig.LimitDailyWithdrawal)
+	monthlyWithdrawal := money.MustParse(config.AppConfig.LimitMonthlyWithdrawal)
+	dailyPurchase := money.MustParse(config.AppConfig.LimitDailyPurchase)
+	hourlyTransactions := config.AppConfig.LimitHourlyTransactions
+
+	return V1Domains.UserLimitDomain{
+		MaxSingleWithdrawal: &maxSingleWithdrawal,
+		DailyWithdrawal:     &dailyWithdrawal,
+		MonthlyWithdrawal:   &monthlyWithdrawal,
+		DailyPurchase:       &dailyPurchase,
+		HourlyTransactions:  &hourlyTransactions,
+	}
+}
+
+// newEventPublisher memilih publisher event outbox sesuai config, nilainya sudah divalidasi saat config dimuat.
+// Subscriber in-process seperti webhook selalu ikut menerima event.
+func newEventPublisher(redisClient *redis.Client, inProcessPublisher *publishers.InProcessPublisher) V1Domains.EventPublisher {
+	switch config.AppConfig.OutboxPublisher {
+	case constants.EventPublisherRedis:
+		return publishers.NewFanOutPublisher(publishers.NewRedisStreamPublisher(redisClient, config.AppConfig.OutboxRedisStream), inProcessPublisher)
+	case constants.EventPublisherInProcess:
+		return inProcessPublisher
+	default:
+		return publishers.NewFanOutPublisher(publishers.NewLogPublisher(), inProcessPublisher)
+	}
+}
+
 func setupRouter() *gin.Engine {
 	// set the runtime mode
 	var mode = gin.ReleaseMode
@@ -1 +1,138 @@
 package main
+
+import (
+	"context"
+	"flag"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+	_ "time/tzdata" // zona waktu user tetap bisa dimuat walau image tidak punya tzdata
+
+	"github.com/sirupsen/logrus"
+	V1Usecase "github.com/snykk/transaction-api/internal/business/usecases/v1"
+	"github.com/snykk/transaction-api/internal/config"
+	"github.com/snykk/transaction-api/internal/constants"
+	V1PostgresRepository "github.com/snykk/transaction-api/internal/datasources/repositories/postgres/v1"
+	"github.com/snykk/transaction-api/internal/utils"
+	"github.com/snykk/transaction-api/pkg/logger"
+	"github.com/snykk/transaction-api/pkg/mailer"
+)
+
+var (
+	once bool
+)
+
+// job adalah pekerjaan background yang dijalankan berulang dengan jeda tetap
+type job struct {
+	name     string
+	interval time.Duration
+	run      func(ctx context.Context) error
+}
+
+func init() {
+	if err := config.InitializeAppConfig(); err != nil {
+		logger.Fatal(err.Error(), logrus.Fields{constants.LoggerCategory: constants.LoggerCategoryConfig})
+	}
+	logger.Info("configuration loaded", logrus.Fields{constants.LoggerCategory: constants.LoggerCategoryConfig})
+}
+
+func main() {
+	flag.BoolVar(&once, "once", false, "run every job once and exit")
+	flag.Parse()
+
+	db, err := utils.SetupPostgresConnection()
+	if err != nil {
+		logger.Panic(err.Error(), logrus.Fields{constants.LoggerCategory: constants.LoggerCategoryCron})
+	}
+	defer db.Close()
+
+	// mailer statement bersifat opsional
+	var statementMailer mailer.StatementMailer
+	if config.AppConfig.StatementEmailEnabled {
+		statementMailer = mailer.NewStatementMailer(config.AppConfig.OTPEmail, config.AppConfig.OTPPassword)
+	}
+	statementUsecase := V1Usecase.NewStatementUsecase(V1PostgresRepository.NewStatementRepository(db), statementMailer, config.AppConfig.Currency)
+	reconciliationUsecase := V1Usecase.NewReconciliationUsecase(V1PostgresRepository.NewReconciliationRepository(db))
+	rewardUsecase := V1Usecase.NewRewardUsecase(V1PostgresRepository.NewRewardRepository(db))
+
+	jobs := []job{
+		{
+			name:     "monthly_statement",
+			interval: time.Duration(config.AppConfig.StatementJobInterval) * time.Minute,
+			run: func(ctx context.Context) error {
+				generated, err := statementUsecase.GenerateMonthly(ctx, time.Now())
+				logger.InfoF("%d monthly statement(s) generated", logrus.Fields{constants.LoggerCategory: constants.LoggerCategoryCron}, generated)
+				return err
+			},
+		},
+		{
+			name:     "balance_reconciliation",
+			interval: time.Duration(config.AppConfig.ReconciliationJobInterval) * time.Minute,
+			run: func(ctx context.Context) error {
+				run, err := reconciliationUsecase.Run(ctx, constants.ReconciliationTriggerCron)
+				logger.InfoF("%d wallet(s) reconciled, %d discrepancy(ies) found", logrus.Fields{constants.LoggerCategory: constants.LoggerCategoryCron}, run.WalletsChecked, run.Discrepancies)
+				return err
+			},
+		},
+		{
+			name:     "points_expiry",
+			interval: time.Duration(config.AppConfig.PointsExpiryJobInterval) * time.Minute,
+			run: func(ctx context.Context) error {
+				expired, err := rewardUsecase.ExpirePoints(ctx, time.Now())
+				logger.InfoF("%d point lot(s) expired", logrus.Fields{constants.LoggerCategory: constants.LoggerCategoryCron}, len(expired))
+				return err
+			},
+		},
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if once {
+		for _, j := range jobs {
+			runJob(ctx, j)
+		}
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, j := range jobs {
+		wg.Add(1)
+		go func(j job) {
+			defer wg.Done()
+			scheduleJob(ctx, j)
+		}(j)
+	}
+
+	logger.Info("cron started", logrus.Fields{constants.LoggerCategory: constants.LoggerCategoryCron})
+	wg.Wait()
+	logger.Info("cron exiting", logrus.Fields{constants.LoggerCategory: constants.LoggerCategoryCron})
+}
+
+// scheduleJob menjalankan job saat start lalu setiap interval sampai ctx dibatalkan
+func scheduleJob(ctx context.Context, j job) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		runJob(ctx, j)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func runJob(ctx context.Context, j job) {
+	fields := logrus.Fields{constants.LoggerCategory: constants.LoggerCategoryCron, "job": j.name}
+
+	start := time.Now()
+	if err := j.run(ctx); err != nil {
+		logger.ErrorF("job failed after %s: %v", fields, time.Since(start), err)
+		return
+	}
+	logger.InfoF("job finished in %s", fields, time.Since(start))
+}
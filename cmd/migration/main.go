@@ -7,6 +7,9 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/sirupsen/logrus"
@@ -71,6 +74,15 @@ func migrate(db *sqlx.DB, action string) (err error) {
 		return errors.New("error when get files name")
 	}
 
+	// Urutkan berdasarkan nomor di depan nama file, bukan urutan leksikal (10_ harus setelah 9_).
+	// Migrasi down dijalankan terbalik agar tabel yang dibuat belakangan dibongkar lebih dulu.
+	sort.SliceStable(files, func(i, j int) bool {
+		if action == "down" {
+			return migrationNumber(files[i]) > migrationNumber(files[j])
+		}
+		return migrationNumber(files[i]) < migrationNumber(files[j])
+	})
+
 	for _, file := range files {
 		logger.Info("Executing migration", logrus.Fields{constants.LoggerCategory: constants.LoggerCategoryMigration, constants.LoggerFile: file})
 		data, err := ioutil.ReadFile(file)
@@ -89,3 +101,14 @@ func migrate(db *sqlx.DB, action string) (err error) {
 
 	return
 }
+
+// migrationNumber mengambil nomor urut dari nama file migrasi, misal 10 dari 10_create_tables_user_limits.up.sql
+func migrationNumber(file string) int {
+	prefix, _, _ := strings.Cut(filepath.Base(file), "_")
+	number, err := strconv.Atoi(prefix)
+	if err != nil {
+		return 0
+	}
+
+	return number
+}
@@ -0,0 +1,16 @@
+-- override batas transaksi per user, kolom null berarti memakai default global dari config
+-- dan nilai 0 berarti tanpa batas
+CREATE TABLE user_limits (
+    user_id uuid PRIMARY KEY REFERENCES users(user_id) ON DELETE CASCADE,
+    max_single_withdrawal DECIMAL(15, 2) CHECK (max_single_withdrawal >= 0),
+    daily_withdrawal DECIMAL(15, 2) CHECK (daily_withdrawal >= 0), -- total withdraw per hari kalender pada zona waktu user
+    monthly_withdrawal DECIMAL(15, 2) CHECK (monthly_withdrawal >= 0), -- total withdraw per bulan kalender pada zona waktu user
+    daily_purchase DECIMAL(15, 2) CHECK (daily_purchase >= 0), -- total pembelian per hari kalender pada zona waktu user
+    hourly_transactions INT CHECK (hourly_transactions >= 0), -- jumlah transaksi yang dibuat user dalam 60 menit terakhir
+    updated_by uuid REFERENCES users(user_id) ON DELETE SET NULL, -- admin yang terakhir mengubah
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- menghitung pemakaian limit per wallet dan tipe transaksi dalam rentang waktu
+CREATE INDEX idx_transactions_wallet_type_created_at ON transactions (wallet_id, transaction_type, created_at);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_transactions_wallet_type_created_at;
+DROP TABLE IF EXISTS user_limits CASCADE;
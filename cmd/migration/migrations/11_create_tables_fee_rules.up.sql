@@ -0,0 +1,30 @@
+-- aturan biaya transaksi, semua aturan aktif yang cocok dijumlahkan menjadi fee transaksi
+CREATE TABLE fee_rules (
+    fee_rule_id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL, -- ditampilkan pada fee_breakdown transaksi
+    transaction_type VARCHAR(20) NOT NULL CHECK (transaction_type IN ('withdraw', 'purchase')),
+    product_id INT REFERENCES products(product_id) ON DELETE CASCADE, -- null berarti berlaku untuk semua produk
+    fee_type VARCHAR(20) NOT NULL CHECK (fee_type IN ('flat', 'percentage', 'tiered')),
+    flat_amount DECIMAL(15, 2) CHECK (flat_amount >= 0), -- untuk fee_type flat
+    rate_bps INT CHECK (rate_bps >= 0), -- untuk fee_type percentage, dalam basis poin (150 = 1.5%)
+    tiers JSONB, -- untuk fee_type tiered: [{"up_to": "100000.00", "flat_amount": "0.00", "rate_bps": 100}, ...]
+    min_fee DECIMAL(15, 2) CHECK (min_fee >= 0),
+    max_fee DECIMAL(15, 2) CHECK (max_fee >= 0),
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (product_id IS NULL OR transaction_type = 'purchase'),
+    CHECK (min_fee IS NULL OR max_fee IS NULL OR min_fee <= max_fee)
+);
+
+CREATE INDEX idx_fee_rules_transaction_type ON fee_rules (transaction_type, product_id) WHERE is_active;
+
+-- fee ditagih di luar amount, rinciannya disimpan sebagai snapshot aturan saat transaksi
+ALTER TABLE transactions
+    ADD COLUMN fee DECIMAL(15, 2) NOT NULL DEFAULT 0 CHECK (fee >= 0),
+    ADD COLUMN fee_breakdown JSONB;
+
+-- akun sistem penampung pendapatan fee
+ALTER TABLE ledger_entries DROP CONSTRAINT ledger_entries_account_check;
+ALTER TABLE ledger_entries ADD CONSTRAINT ledger_entries_account_check
+    CHECK (account IN ('wallet', 'system_cash', 'system_revenue', 'system_fee'));
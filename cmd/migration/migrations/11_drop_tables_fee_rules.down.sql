@@ -0,0 +1,10 @@
+ALTER TABLE IF EXISTS ledger_entries
+    DROP CONSTRAINT IF EXISTS ledger_entries_account_check,
+    ADD CONSTRAINT ledger_entries_account_check
+    CHECK (account IN ('wallet', 'system_cash', 'system_revenue'));
+
+ALTER TABLE IF EXISTS transactions
+    DROP COLUMN IF EXISTS fee_breakdown,
+    DROP COLUMN IF EXISTS fee;
+
+DROP TABLE IF EXISTS fee_rules;
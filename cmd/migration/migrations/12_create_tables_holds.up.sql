@@ -0,0 +1,28 @@
+-- dana yang sedang ditahan oleh hold aktif, saldo tersedia = balance - held_amount
+ALTER TABLE wallets
+    ADD COLUMN held_amount DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    ADD CONSTRAINT wallets_held_amount_check CHECK (held_amount >= 0 AND held_amount <= balance);
+
+-- hold menahan dana tanpa mengubah saldo ledger sampai di-capture, di-void, atau kedaluwarsa
+CREATE TABLE holds (
+    hold_id uuid PRIMARY KEY,
+    wallet_id uuid NOT NULL REFERENCES wallets(wallet_id) ON DELETE CASCADE,
+    transaction_type VARCHAR(20) NOT NULL CHECK (transaction_type IN ('withdraw', 'purchase')), -- tipe transaksi saat di-capture
+    product_id INT REFERENCES products(product_id) ON DELETE SET NULL, -- hanya untuk hold pembelian
+    quantity INT CHECK (quantity > 0),
+    unit_price DECIMAL(15, 2) CHECK (unit_price >= 0), -- snapshot harga produk saat hold dibuat
+    amount DECIMAL(15, 2) NOT NULL CHECK (amount > 0), -- nominal maksimum yang bisa di-capture
+    fee DECIMAL(15, 2) NOT NULL DEFAULT 0 CHECK (fee >= 0), -- perkiraan fee yang ikut ditahan
+    status VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'captured', 'voided', 'expired')),
+    captured_amount DECIMAL(15, 2) CHECK (captured_amount > 0),
+    transaction_id uuid REFERENCES transactions(transaction_id) ON DELETE SET NULL, -- transaksi hasil capture
+    expires_at TIMESTAMPTZ NOT NULL,
+    closed_at TIMESTAMPTZ, -- waktu hold di-capture, di-void, atau kedaluwarsa
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (transaction_type = 'purchase' OR (quantity IS NULL AND unit_price IS NULL))
+);
+
+-- sweeper hanya mencari hold aktif yang sudah kedaluwarsa
+CREATE INDEX idx_holds_expires_at ON holds(expires_at) WHERE status = 'active';
+CREATE INDEX idx_holds_wallet_id ON holds(wallet_id, created_at DESC);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS holds;
+
+ALTER TABLE IF EXISTS wallets
+    DROP CONSTRAINT IF EXISTS wallets_held_amount_check,
+    DROP COLUMN IF EXISTS held_amount;
@@ -0,0 +1,18 @@
+-- status wallet yang dibekukan admin, frozen memblokir dana keluar dan masuk
+ALTER TABLE wallets
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'active',
+    ADD CONSTRAINT wallets_status_check CHECK (status IN ('active', 'frozen_outgoing', 'frozen_incoming', 'frozen'));
+
+-- jejak audit setiap freeze dan unfreeze wallet, siapa yang melakukan dan alasannya
+CREATE TABLE wallet_status_events (
+    event_id uuid PRIMARY KEY,
+    wallet_id uuid NOT NULL REFERENCES wallets(wallet_id) ON DELETE CASCADE,
+    action VARCHAR(10) NOT NULL CHECK (action IN ('freeze', 'unfreeze')),
+    previous_status VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    reason TEXT NOT NULL,
+    actor_id uuid REFERENCES users(user_id) ON DELETE SET NULL, -- admin yang melakukan perubahan
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_wallet_status_events_wallet_id ON wallet_status_events(wallet_id, created_at DESC);
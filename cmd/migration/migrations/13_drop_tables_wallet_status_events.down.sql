@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS wallet_status_events;
+
+ALTER TABLE IF EXISTS wallets
+    DROP CONSTRAINT IF EXISTS wallets_status_check,
+    DROP COLUMN IF EXISTS status;
@@ -0,0 +1,12 @@
+-- user bisa memiliki beberapa wallet (pocket) bernama, tepat satu di antaranya menjadi default
+ALTER TABLE wallets
+    ADD COLUMN name VARCHAR(50) NOT NULL DEFAULT 'main',
+    ADD COLUMN is_default BOOLEAN NOT NULL DEFAULT false;
+
+-- wallet yang sudah ada adalah satu-satunya wallet user, jadikan default
+UPDATE wallets SET is_default = true;
+
+ALTER TABLE wallets ADD CONSTRAINT wallets_user_id_name_key UNIQUE (user_id, name);
+
+-- endpoint tanpa wallet_id memakai wallet default user
+CREATE UNIQUE INDEX idx_wallets_default ON wallets(user_id) WHERE is_default;
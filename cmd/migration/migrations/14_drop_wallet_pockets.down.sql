@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_wallets_default;
+
+ALTER TABLE IF EXISTS wallets
+    DROP CONSTRAINT IF EXISTS wallets_user_id_name_key,
+    DROP COLUMN IF EXISTS is_default,
+    DROP COLUMN IF EXISTS name;
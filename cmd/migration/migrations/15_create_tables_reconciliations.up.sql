@@ -0,0 +1,25 @@
+-- satu kali eksekusi rekonsiliasi saldo wallet terhadap riwayat transaksi
+CREATE TABLE reconciliation_runs (
+    run_id uuid PRIMARY KEY,
+    trigger VARCHAR(10) NOT NULL CHECK (trigger IN ('cli', 'cron')), -- asal eksekusi
+    status VARCHAR(20) NOT NULL DEFAULT 'running' CHECK (status IN ('running', 'completed', 'failed')),
+    wallets_checked INT NOT NULL DEFAULT 0,
+    discrepancies INT NOT NULL DEFAULT 0,
+    error_message TEXT, -- diisi jika status failed
+    started_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    finished_at TIMESTAMP
+);
+
+CREATE INDEX idx_reconciliation_runs_started_at ON reconciliation_runs(started_at DESC);
+
+-- wallet yang saldonya tidak sama dengan saldo hasil perhitungan ulang dari transaksi
+CREATE TABLE reconciliation_items (
+    item_id uuid PRIMARY KEY,
+    run_id uuid NOT NULL REFERENCES reconciliation_runs(run_id) ON DELETE CASCADE,
+    wallet_id uuid NOT NULL REFERENCES wallets(wallet_id) ON DELETE CASCADE,
+    recorded_balance DECIMAL(15, 2) NOT NULL, -- wallets.balance saat diperiksa
+    expected_balance DECIMAL(15, 2) NOT NULL, -- hasil perhitungan ulang dari transactions
+    difference DECIMAL(15, 2) NOT NULL, -- recorded_balance - expected_balance
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (run_id, wallet_id)
+);
@@ -0,0 +1,8 @@
+-- data opsional dari klien: memo, reference milik sistem klien, dan metadata bebas
+ALTER TABLE transactions
+    ADD COLUMN description VARCHAR(255),
+    ADD COLUMN external_reference VARCHAR(64), -- unik per user, dijaga oleh transaksi serializable di aplikasi
+    ADD COLUMN metadata JSONB;
+
+-- pencarian riwayat berdasarkan reference dan pengecekan reference yang sudah dipakai
+CREATE INDEX idx_transactions_external_reference ON transactions(external_reference) WHERE external_reference IS NOT NULL;
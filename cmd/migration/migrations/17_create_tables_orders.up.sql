@@ -0,0 +1,23 @@
+-- order mengelompokkan beberapa item pembelian yang dibayar dengan satu debit wallet
+CREATE TABLE orders (
+    order_id uuid PRIMARY KEY,
+    user_id uuid NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    wallet_id uuid NOT NULL REFERENCES wallets(wallet_id) ON DELETE CASCADE,
+    transaction_id uuid NOT NULL UNIQUE REFERENCES transactions(transaction_id) ON DELETE CASCADE, -- transaksi purchase yang mendebit wallet
+    total_amount DECIMAL(15, 2) NOT NULL CHECK (total_amount > 0), -- jumlah subtotal seluruh item, belum termasuk fee
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_orders_user_id ON orders(user_id, created_at DESC);
+
+-- item order menyimpan snapshot nama dan harga satuan produk saat dibeli
+CREATE TABLE order_items (
+    order_item_id uuid PRIMARY KEY,
+    order_id uuid NOT NULL REFERENCES orders(order_id) ON DELETE CASCADE,
+    product_id INT REFERENCES products(product_id) ON DELETE SET NULL,
+    product_name VARCHAR(255) NOT NULL,
+    quantity INT NOT NULL CHECK (quantity > 0),
+    unit_price DECIMAL(15, 2) NOT NULL CHECK (unit_price >= 0),
+    subtotal DECIMAL(15, 2) NOT NULL CHECK (subtotal >= 0),
+    UNIQUE (order_id, product_id)
+);
@@ -0,0 +1,38 @@
+-- voucher diskon untuk pembelian, berlaku untuk semua produk atau satu produk tertentu
+CREATE TABLE vouchers (
+    voucher_id SERIAL PRIMARY KEY,
+    code VARCHAR(32) NOT NULL UNIQUE, -- selalu disimpan dalam huruf besar
+    discount_type VARCHAR(20) NOT NULL CHECK (discount_type IN ('percentage', 'fixed')),
+    amount DECIMAL(15, 2) CHECK (amount > 0), -- untuk discount_type fixed
+    rate_bps INT CHECK (rate_bps > 0 AND rate_bps <= 10000), -- untuk discount_type percentage, dalam basis poin (1000 = 10%)
+    max_discount DECIMAL(15, 2) CHECK (max_discount > 0), -- batas diskon percentage
+    min_spend DECIMAL(15, 2) NOT NULL DEFAULT 0 CHECK (min_spend >= 0), -- dihitung dari nominal produk yang memenuhi syarat
+    product_id INT REFERENCES products(product_id) ON DELETE CASCADE, -- null berarti berlaku untuk semua produk
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ, -- null berarti tidak kedaluwarsa
+    max_redemptions INT CHECK (max_redemptions > 0), -- batas pemakaian total, null berarti tanpa batas
+    max_redemptions_per_user INT CHECK (max_redemptions_per_user > 0),
+    redemption_count INT NOT NULL DEFAULT 0,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT vouchers_redemption_count_check CHECK (redemption_count >= 0 AND (max_redemptions IS NULL OR redemption_count <= max_redemptions)),
+    CHECK (ends_at IS NULL OR ends_at > starts_at)
+);
+
+-- setiap pemakaian voucher, dipakai untuk menghitung batas pemakaian per user
+CREATE TABLE voucher_redemptions (
+    redemption_id uuid PRIMARY KEY,
+    voucher_id INT NOT NULL REFERENCES vouchers(voucher_id) ON DELETE CASCADE,
+    user_id uuid NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    transaction_id uuid NOT NULL UNIQUE REFERENCES transactions(transaction_id) ON DELETE CASCADE,
+    discount DECIMAL(15, 2) NOT NULL CHECK (discount > 0),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_voucher_redemptions_voucher_user ON voucher_redemptions(voucher_id, user_id);
+
+-- amount pembelian adalah nominal setelah diskon, diskon dan kode voucher disimpan sebagai snapshot
+ALTER TABLE transactions
+    ADD COLUMN discount DECIMAL(15, 2) NOT NULL DEFAULT 0 CHECK (discount >= 0),
+    ADD COLUMN voucher_code VARCHAR(32);
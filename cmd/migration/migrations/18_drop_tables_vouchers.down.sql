@@ -0,0 +1,6 @@
+ALTER TABLE IF EXISTS transactions
+    DROP COLUMN IF EXISTS voucher_code,
+    DROP COLUMN IF EXISTS discount;
+
+DROP TABLE IF EXISTS voucher_redemptions;
+DROP TABLE IF EXISTS vouchers;
@@ -0,0 +1,70 @@
+-- kategori produk, dipakai aturan reward yang berlaku untuk satu kategori
+ALTER TABLE products ADD COLUMN category VARCHAR(50);
+
+-- saldo poin loyalitas, rinciannya ada di point_entries
+ALTER TABLE wallets ADD COLUMN points_balance DECIMAL(15, 2) NOT NULL DEFAULT 0 CHECK (points_balance >= 0);
+
+-- aturan reward pembelian, untuk setiap item hanya aturan paling spesifik per reward_type yang dipakai:
+-- produk, lalu kategori, lalu aturan global
+CREATE TABLE reward_rules (
+    reward_rule_id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    reward_type VARCHAR(20) NOT NULL CHECK (reward_type IN ('points', 'cashback')),
+    product_id INT REFERENCES products(product_id) ON DELETE CASCADE,
+    category VARCHAR(50),
+    rate_bps INT NOT NULL CHECK (rate_bps > 0 AND rate_bps <= 10000), -- dari nominal yang dibayar dengan saldo, dalam basis poin
+    monthly_cap DECIMAL(15, 2) CHECK (monthly_cap > 0), -- batas reward per user per bulan kalender UTC, null berarti tanpa batas
+    points_expiry_days INT CHECK (points_expiry_days > 0), -- hanya untuk reward_type points
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (product_id IS NULL OR category IS NULL),
+    CHECK ((reward_type = 'points') = (points_expiry_days IS NOT NULL))
+);
+
+-- reward yang sudah diberikan per pembelian, dipakai untuk menghitung batas bulanan
+CREATE TABLE reward_grants (
+    reward_grant_id uuid PRIMARY KEY,
+    reward_rule_id INT REFERENCES reward_rules(reward_rule_id) ON DELETE SET NULL,
+    user_id uuid NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    transaction_id uuid NOT NULL REFERENCES transactions(transaction_id) ON DELETE CASCADE, -- transaksi purchase
+    reward_type VARCHAR(20) NOT NULL CHECK (reward_type IN ('points', 'cashback')),
+    amount DECIMAL(15, 2) NOT NULL CHECK (amount > 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_reward_grants_rule_user ON reward_grants(reward_rule_id, user_id, created_at);
+
+-- mutasi poin wallet. Entry earn adalah lot poin yang dipakai FIFO berdasarkan expires_at,
+-- remaining berkurang saat poin ditukar, dikonversi, atau kedaluwarsa
+CREATE TABLE point_entries (
+    point_entry_id uuid PRIMARY KEY,
+    wallet_id uuid NOT NULL REFERENCES wallets(wallet_id) ON DELETE CASCADE,
+    entry_type VARCHAR(20) NOT NULL CHECK (entry_type IN ('earn', 'redeem', 'convert', 'expire')),
+    points DECIMAL(15, 2) NOT NULL CHECK (points <> 0), -- positif untuk earn, negatif untuk lainnya
+    remaining DECIMAL(15, 2) CHECK (remaining >= 0 AND remaining <= points),
+    expires_at TIMESTAMPTZ,
+    transaction_id uuid REFERENCES transactions(transaction_id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((entry_type = 'earn') = (remaining IS NOT NULL AND expires_at IS NOT NULL AND points > 0)),
+    CHECK (entry_type = 'earn' OR points < 0)
+);
+
+CREATE INDEX idx_point_entries_wallet ON point_entries(wallet_id, created_at);
+CREATE INDEX idx_point_entries_open_lots ON point_entries(expires_at) WHERE entry_type = 'earn' AND remaining > 0;
+
+-- poin yang dipakai serta reward yang didapat dari pembelian, amount pembelian adalah nominal yang dibayar dengan saldo
+ALTER TABLE transactions
+    ADD COLUMN points_redeemed DECIMAL(15, 2) NOT NULL DEFAULT 0 CHECK (points_redeemed >= 0),
+    ADD COLUMN points_earned DECIMAL(15, 2) NOT NULL DEFAULT 0 CHECK (points_earned >= 0),
+    ADD COLUMN cashback DECIMAL(15, 2) NOT NULL DEFAULT 0 CHECK (cashback >= 0); -- total transaksi cashback yang dibuat untuk pembelian ini
+
+-- cashback dan konversi poin menambah saldo wallet sebagai transaksi tersendiri
+ALTER TABLE transactions DROP CONSTRAINT transactions_transaction_type_check;
+ALTER TABLE transactions ADD CONSTRAINT transactions_transaction_type_check
+    CHECK (transaction_type IN ('deposit', 'withdraw', 'purchase', 'cashback', 'points_conversion'));
+
+-- akun sistem yang membiayai cashback dan poin yang ditukar
+ALTER TABLE ledger_entries DROP CONSTRAINT ledger_entries_account_check;
+ALTER TABLE ledger_entries ADD CONSTRAINT ledger_entries_account_check
+    CHECK (account IN ('wallet', 'system_cash', 'system_revenue', 'system_fee', 'system_rewards'));
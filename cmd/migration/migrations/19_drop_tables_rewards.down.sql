@@ -0,0 +1,21 @@
+ALTER TABLE IF EXISTS ledger_entries
+    DROP CONSTRAINT IF EXISTS ledger_entries_account_check,
+    ADD CONSTRAINT ledger_entries_account_check
+    CHECK (account IN ('wallet', 'system_cash', 'system_revenue', 'system_fee'));
+
+ALTER TABLE IF EXISTS transactions
+    DROP CONSTRAINT IF EXISTS transactions_transaction_type_check,
+    ADD CONSTRAINT transactions_transaction_type_check
+    CHECK (transaction_type IN ('deposit', 'withdraw', 'purchase'));
+
+ALTER TABLE IF EXISTS transactions
+    DROP COLUMN IF EXISTS cashback,
+    DROP COLUMN IF EXISTS points_earned,
+    DROP COLUMN IF EXISTS points_redeemed;
+
+DROP TABLE IF EXISTS point_entries;
+DROP TABLE IF EXISTS reward_grants;
+DROP TABLE IF EXISTS reward_rules;
+
+ALTER TABLE IF EXISTS wallets DROP COLUMN IF EXISTS points_balance;
+ALTER TABLE IF EXISTS products DROP COLUMN IF EXISTS category;
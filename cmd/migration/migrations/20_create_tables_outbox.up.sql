@@ -0,0 +1,20 @@
+-- event domain yang ditulis di transaksi database yang sama dengan perubahan saldo,
+-- relay mengirimnya ke publisher minimal sekali lalu menandainya sent
+CREATE TABLE outbox (
+    event_id uuid PRIMARY KEY,
+    event_type VARCHAR(100) NOT NULL, -- misal transaction.deposit.completed
+    aggregate_type VARCHAR(50) NOT NULL,
+    aggregate_id VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    version INT NOT NULL CHECK (version > 0), -- versi skema payload
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sent')),
+    attempts INT NOT NULL DEFAULT 0, -- jumlah percobaan kirim yang gagal
+    last_error TEXT,
+    available_at TIMESTAMPTZ NOT NULL, -- event baru boleh diambil relay setelah waktu ini, dipakai untuk lease dan backoff
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    sent_at TIMESTAMPTZ
+);
+
+-- relay hanya mencari event pending yang sudah waktunya dikirim
+CREATE INDEX idx_outbox_pending ON outbox(available_at, created_at) WHERE status = 'pending';
+CREATE INDEX idx_outbox_aggregate ON outbox(aggregate_type, aggregate_id, created_at);
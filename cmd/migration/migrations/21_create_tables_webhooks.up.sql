@@ -0,0 +1,48 @@
+-- endpoint webhook milik user, menerima event transaksi user tersebut
+CREATE TABLE webhook_endpoints (
+    webhook_id uuid PRIMARY KEY,
+    user_id uuid NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    url VARCHAR(2048) NOT NULL,
+    event_types TEXT[] NOT NULL CHECK (cardinality(event_types) > 0),
+    secret VARCHAR(255) NOT NULL, -- kunci HMAC-SHA256 untuk header signature
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_webhook_endpoints_user ON webhook_endpoints(user_id) WHERE is_active;
+
+-- satu pengiriman event ke satu endpoint, dicoba ulang dengan backoff sampai berhasil atau menjadi dead
+CREATE TABLE webhook_deliveries (
+    delivery_id uuid PRIMARY KEY,
+    webhook_id uuid NOT NULL REFERENCES webhook_endpoints(webhook_id) ON DELETE CASCADE,
+    event_id uuid, -- event outbox asal, kosong untuk pengiriman uji
+    event_type VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'delivered', 'dead')),
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL, -- dipakai untuk lease dan backoff
+    last_status_code INT,
+    last_error TEXT,
+    delivered_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- event outbox dikirim minimal sekali, satu event hanya menghasilkan satu pengiriman per endpoint
+CREATE UNIQUE INDEX idx_webhook_deliveries_event ON webhook_deliveries(webhook_id, event_id) WHERE event_id IS NOT NULL;
+CREATE INDEX idx_webhook_deliveries_pending ON webhook_deliveries(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX idx_webhook_deliveries_webhook ON webhook_deliveries(webhook_id, created_at DESC);
+
+-- log setiap percobaan kirim beserta response receiver
+CREATE TABLE webhook_delivery_attempts (
+    attempt_id uuid PRIMARY KEY,
+    delivery_id uuid NOT NULL REFERENCES webhook_deliveries(delivery_id) ON DELETE CASCADE,
+    attempt INT NOT NULL CHECK (attempt > 0),
+    status_code INT, -- kosong jika receiver tidak bisa dihubungi
+    error TEXT,
+    duration_ms INT NOT NULL,
+    attempted_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_webhook_delivery_attempts_delivery ON webhook_delivery_attempts(delivery_id, attempt);
@@ -0,0 +1,7 @@
+-- transfer antar wallet dicatat sebagai pasangan transaksi transfer_out dan transfer_in
+ALTER TABLE transactions
+    ADD COLUMN related_transaction_id uuid REFERENCES transactions(transaction_id) ON DELETE SET NULL; -- pasangan transaksi, misal transfer_out <-> transfer_in
+
+ALTER TABLE transactions DROP CONSTRAINT transactions_transaction_type_check;
+ALTER TABLE transactions ADD CONSTRAINT transactions_transaction_type_check
+    CHECK (transaction_type IN ('deposit', 'withdraw', 'purchase', 'cashback', 'points_conversion', 'transfer_out', 'transfer_in'));
@@ -0,0 +1,6 @@
+ALTER TABLE IF EXISTS transactions
+    DROP CONSTRAINT IF EXISTS transactions_transaction_type_check,
+    ADD CONSTRAINT transactions_transaction_type_check
+    CHECK (transaction_type IN ('deposit', 'withdraw', 'purchase', 'cashback', 'points_conversion'));
+
+ALTER TABLE IF EXISTS transactions DROP COLUMN IF EXISTS related_transaction_id;
@@ -0,0 +1,15 @@
+-- transaksi yang sudah ada dianggap selesai saat dibuat
+ALTER TABLE transactions
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'completed',
+    ADD COLUMN failure_reason TEXT, -- alasan jika transaksi gagal atau dibatalkan
+    ADD COLUMN completed_at TIMESTAMP,
+    ADD COLUMN failed_at TIMESTAMP,
+    ADD COLUMN cancelled_at TIMESTAMP,
+    ADD CONSTRAINT transactions_status_check CHECK (status IN ('pending', 'completed', 'failed', 'cancelled'));
+
+UPDATE transactions SET completed_at = created_at WHERE status = 'completed';
+
+-- akun sistem penampung dana withdraw yang masih pending
+ALTER TABLE ledger_entries DROP CONSTRAINT ledger_entries_account_check;
+ALTER TABLE ledger_entries ADD CONSTRAINT ledger_entries_account_check
+    CHECK (account IN ('wallet', 'system_cash', 'system_revenue', 'system_fee', 'system_rewards', 'system_clearing'));
@@ -0,0 +1,12 @@
+ALTER TABLE IF EXISTS ledger_entries
+    DROP CONSTRAINT IF EXISTS ledger_entries_account_check,
+    ADD CONSTRAINT ledger_entries_account_check
+    CHECK (account IN ('wallet', 'system_cash', 'system_revenue', 'system_fee', 'system_rewards'));
+
+ALTER TABLE IF EXISTS transactions
+    DROP CONSTRAINT IF EXISTS transactions_status_check,
+    DROP COLUMN IF EXISTS cancelled_at,
+    DROP COLUMN IF EXISTS failed_at,
+    DROP COLUMN IF EXISTS completed_at,
+    DROP COLUMN IF EXISTS failure_reason,
+    DROP COLUMN IF EXISTS status;
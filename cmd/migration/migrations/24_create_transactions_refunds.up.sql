@@ -0,0 +1,4 @@
+-- refund mengembalikan sebagian atau seluruh quantity pembelian, related_transaction_id menunjuk ke pembelian asalnya
+ALTER TABLE transactions DROP CONSTRAINT transactions_transaction_type_check;
+ALTER TABLE transactions ADD CONSTRAINT transactions_transaction_type_check
+    CHECK (transaction_type IN ('deposit', 'withdraw', 'purchase', 'cashback', 'points_conversion', 'transfer_out', 'transfer_in', 'refund'));
@@ -0,0 +1,4 @@
+ALTER TABLE IF EXISTS transactions
+    DROP CONSTRAINT IF EXISTS transactions_transaction_type_check,
+    ADD CONSTRAINT transactions_transaction_type_check
+    CHECK (transaction_type IN ('deposit', 'withdraw', 'purchase', 'cashback', 'points_conversion', 'transfer_out', 'transfer_in'));
@@ -0,0 +1,3 @@
+-- mendukung pagination cursor (created_at, transaction_id) per wallet maupun global
+CREATE INDEX idx_transactions_wallet_created_at ON transactions (wallet_id, created_at DESC, transaction_id DESC);
+CREATE INDEX idx_transactions_created_at ON transactions (created_at DESC, transaction_id DESC);
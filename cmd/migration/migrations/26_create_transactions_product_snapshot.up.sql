@@ -0,0 +1,9 @@
+ALTER TABLE transactions
+    ADD COLUMN product_name VARCHAR(255), -- snapshot nama produk saat transaksi
+    ADD COLUMN unit_price DECIMAL(15, 2) CHECK (unit_price >= 0); -- snapshot harga satuan saat transaksi
+
+-- snapshot transaksi lama diisi dari produk yang masih ada
+UPDATE transactions t
+SET product_name = p.name, unit_price = p.price
+FROM products p
+WHERE p.product_id = t.product_id;
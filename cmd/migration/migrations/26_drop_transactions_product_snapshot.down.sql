@@ -0,0 +1,3 @@
+ALTER TABLE IF EXISTS transactions
+    DROP COLUMN IF EXISTS unit_price,
+    DROP COLUMN IF EXISTS product_name;
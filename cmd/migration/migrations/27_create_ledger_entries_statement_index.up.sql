@@ -0,0 +1,3 @@
+-- juga dipakai untuk statement per periode
+DROP INDEX IF EXISTS idx_ledger_entries_wallet_id;
+CREATE INDEX idx_ledger_entries_wallet_id ON ledger_entries(wallet_id, created_at, entry_id);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_ledger_entries_wallet_id;
+CREATE INDEX IF NOT EXISTS idx_ledger_entries_wallet_id ON ledger_entries(wallet_id);
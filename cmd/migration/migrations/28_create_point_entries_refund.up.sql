@@ -0,0 +1,14 @@
+-- refund mengembalikan poin yang dipakai sebagai lot restore dan menarik poin hasil pembelian sebagai entry reverse.
+-- lot restore dipakai dan kedaluwarsa dengan cara yang sama seperti lot earn.
+ALTER TABLE point_entries
+    DROP CONSTRAINT point_entries_entry_type_check,
+    DROP CONSTRAINT point_entries_check,
+    DROP CONSTRAINT point_entries_check1,
+    ADD CONSTRAINT point_entries_entry_type_check CHECK (entry_type IN ('earn', 'redeem', 'convert', 'expire', 'restore', 'reverse')),
+    ADD CONSTRAINT point_entries_lot_check CHECK ((entry_type IN ('earn', 'restore')) = (remaining IS NOT NULL AND expires_at IS NOT NULL AND points > 0)),
+    ADD CONSTRAINT point_entries_points_sign_check CHECK (entry_type IN ('earn', 'restore') OR points < 0);
+
+DROP INDEX IF EXISTS idx_point_entries_open_lots;
+CREATE INDEX idx_point_entries_open_lots ON point_entries(expires_at) WHERE entry_type IN ('earn', 'restore') AND remaining > 0;
+
+-- pada transaksi refund, points_redeemed, points_earned, dan cashback berisi bagian pembelian yang dikembalikan atau ditarik
@@ -0,0 +1,12 @@
+DELETE FROM point_entries WHERE entry_type IN ('restore', 'reverse');
+
+DROP INDEX IF EXISTS idx_point_entries_open_lots;
+CREATE INDEX IF NOT EXISTS idx_point_entries_open_lots ON point_entries(expires_at) WHERE entry_type = 'earn' AND remaining > 0;
+
+ALTER TABLE IF EXISTS point_entries
+    DROP CONSTRAINT IF EXISTS point_entries_points_sign_check,
+    DROP CONSTRAINT IF EXISTS point_entries_lot_check,
+    DROP CONSTRAINT IF EXISTS point_entries_entry_type_check,
+    ADD CONSTRAINT point_entries_entry_type_check CHECK (entry_type IN ('earn', 'redeem', 'convert', 'expire')),
+    ADD CONSTRAINT point_entries_check CHECK ((entry_type = 'earn') = (remaining IS NOT NULL AND expires_at IS NOT NULL AND points > 0)),
+    ADD CONSTRAINT point_entries_check1 CHECK (entry_type = 'earn' OR points < 0);
@@ -0,0 +1,3 @@
+-- pemakaian voucher dilepas saat pembelian di-refund penuh, baris tetap disimpan sebagai riwayat
+-- dan tidak lagi dihitung dalam batas pemakaian per user
+ALTER TABLE voucher_redemptions ADD COLUMN released_at TIMESTAMP;
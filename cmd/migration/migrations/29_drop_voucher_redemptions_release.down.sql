@@ -0,0 +1 @@
+ALTER TABLE IF EXISTS voucher_redemptions DROP COLUMN IF EXISTS released_at;
@@ -0,0 +1,3 @@
+-- dana yang ditarik dari wallet saat refund: cashback dan poin hasil pembelian yang sudah terpakai.
+-- saldo wallet bertambah sebesar amount - clawback
+ALTER TABLE transactions ADD COLUMN clawback DECIMAL(15, 2) NOT NULL DEFAULT 0 CHECK (clawback >= 0);
@@ -0,0 +1 @@
+ALTER TABLE IF EXISTS transactions DROP COLUMN IF EXISTS clawback;
@@ -0,0 +1,30 @@
+CREATE TABLE ledger_entries (
+    entry_id BIGSERIAL PRIMARY KEY,
+    transaction_id uuid NOT NULL REFERENCES transactions(transaction_id) ON DELETE CASCADE, -- transaksi yang menghasilkan posting ini
+    account VARCHAR(30) NOT NULL CHECK (account IN ('wallet', 'system_cash', 'system_revenue')), -- akun yang diposting
+    wallet_id uuid REFERENCES wallets(wallet_id) ON DELETE CASCADE, -- hanya diisi untuk akun wallet
+    direction VARCHAR(6) NOT NULL CHECK (direction IN ('debit', 'credit')),
+    amount DECIMAL(15, 2) NOT NULL CHECK (amount > 0),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((account = 'wallet') = (wallet_id IS NOT NULL))
+);
+
+CREATE INDEX idx_ledger_entries_wallet_id ON ledger_entries(wallet_id);
+CREATE INDEX idx_ledger_entries_transaction_id ON ledger_entries(transaction_id);
+
+-- posting ulang transaksi yang sudah ada agar ledger langsung sesuai dengan saldo wallet.
+-- sebelum migrasi ini transaksi hanya berupa deposit, withdraw, dan purchase.
+INSERT INTO ledger_entries (transaction_id, account, wallet_id, direction, amount, created_at)
+SELECT t.transaction_id, p.account, p.wallet_id, p.direction, t.amount, t.created_at
+FROM transactions t
+CROSS JOIN LATERAL (
+    SELECT
+        'wallet' AS account,
+        t.wallet_id AS wallet_id,
+        CASE WHEN t.transaction_type = 'deposit' THEN 'credit' ELSE 'debit' END AS direction
+    UNION ALL
+    SELECT
+        CASE WHEN t.transaction_type = 'purchase' THEN 'system_revenue' ELSE 'system_cash' END,
+        NULL,
+        CASE WHEN t.transaction_type = 'deposit' THEN 'debit' ELSE 'credit' END
+) p;
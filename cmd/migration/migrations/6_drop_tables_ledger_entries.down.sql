@@ -0,0 +1 @@
+DROP TABLE IF EXISTS ledger_entries CASCADE;
\ No newline at end of file
@@ -0,0 +1,13 @@
+CREATE TABLE idempotency_keys (
+    user_id uuid NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    idempotency_key VARCHAR(255) NOT NULL,
+    request_hash CHAR(64) NOT NULL, -- sha256 dari method, path dan body request pertama
+    status VARCHAR(20) NOT NULL CHECK (status IN ('processing', 'completed')),
+    response_code INT,
+    response_body BYTEA,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (user_id, idempotency_key)
+);
+
+CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
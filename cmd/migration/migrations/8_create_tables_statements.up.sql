@@ -0,0 +1,28 @@
+-- zona waktu user, dipakai untuk menentukan akhir bulan statement
+ALTER TABLE users ADD COLUMN IF NOT EXISTS timezone VARCHAR(64) NOT NULL DEFAULT 'Asia/Jakarta';
+
+CREATE TABLE statements (
+    statement_id uuid PRIMARY KEY,
+    user_id uuid NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    wallet_id uuid NOT NULL REFERENCES wallets(wallet_id) ON DELETE CASCADE,
+    period DATE NOT NULL, -- hari pertama bulan statement pada zona waktu user
+    timezone VARCHAR(64) NOT NULL, -- zona waktu yang dipakai saat statement dibuat
+    period_start TIMESTAMPTZ NOT NULL,
+    period_end TIMESTAMPTZ NOT NULL, -- eksklusif
+    opening_balance DECIMAL(15, 2) NOT NULL,
+    closing_balance DECIMAL(15, 2) NOT NULL,
+    emailed_at TIMESTAMP, -- null jika ringkasan belum atau tidak dikirim lewat email
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (wallet_id, period) -- job aman dijalankan berulang kali
+);
+
+CREATE INDEX idx_statements_user_id ON statements(user_id, period DESC);
+
+CREATE TABLE statement_totals (
+    statement_id uuid NOT NULL REFERENCES statements(statement_id) ON DELETE CASCADE,
+    transaction_type VARCHAR(20) NOT NULL,
+    transaction_count INT NOT NULL CHECK (transaction_count > 0),
+    total_credit DECIMAL(15, 2) NOT NULL, -- total mutasi yang menambah saldo
+    total_debit DECIMAL(15, 2) NOT NULL, -- total mutasi yang mengurangi saldo
+    PRIMARY KEY (statement_id, transaction_type)
+);
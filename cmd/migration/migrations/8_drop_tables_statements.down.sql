@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS statement_totals CASCADE;
+DROP TABLE IF EXISTS statements CASCADE;
+ALTER TABLE IF EXISTS users DROP COLUMN IF EXISTS timezone;
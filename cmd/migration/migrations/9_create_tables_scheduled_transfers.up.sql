@@ -0,0 +1,39 @@
+CREATE TABLE scheduled_transfers (
+    schedule_id uuid PRIMARY KEY,
+    user_id uuid NOT NULL REFERENCES users(user_id) ON DELETE CASCADE, -- pengirim
+    recipient_user_id uuid NOT NULL REFERENCES users(user_id) ON DELETE CASCADE, -- penerima, ditentukan saat schedule dibuat
+    amount DECIMAL(15, 2) NOT NULL CHECK (amount > 0),
+    frequency VARCHAR(10) NOT NULL CHECK (frequency IN ('once', 'daily', 'weekly', 'monthly')),
+    start_at TIMESTAMPTZ NOT NULL, -- jadwal pertama, juga acuan tanggal untuk jadwal berikutnya
+    end_at TIMESTAMPTZ, -- opsional, jadwal setelah waktu ini tidak dijalankan
+    max_runs INT CHECK (max_runs > 0), -- opsional, jumlah maksimum jadwal
+    occurrence_count INT NOT NULL DEFAULT 0, -- jumlah jadwal yang sudah selesai diproses, berhasil maupun gagal
+    attempt INT NOT NULL DEFAULT 0, -- jumlah percobaan gagal untuk jadwal yang sedang berjalan
+    next_run_at TIMESTAMPTZ, -- null jika schedule tidak aktif lagi
+    status VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'paused', 'completed', 'failed', 'cancelled')),
+    last_error TEXT,
+    last_run_at TIMESTAMPTZ,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (end_at IS NULL OR end_at >= start_at)
+);
+
+-- scheduler hanya mencari schedule aktif yang sudah jatuh tempo
+CREATE INDEX idx_scheduled_transfers_due ON scheduled_transfers(next_run_at) WHERE status = 'active';
+CREATE INDEX idx_scheduled_transfers_user_id ON scheduled_transfers(user_id, created_at DESC);
+
+CREATE TABLE scheduled_transfer_runs (
+    run_id BIGSERIAL PRIMARY KEY,
+    schedule_id uuid NOT NULL REFERENCES scheduled_transfers(schedule_id) ON DELETE CASCADE,
+    occurrence INT NOT NULL, -- urutan jadwal, dimulai dari 1
+    scheduled_for TIMESTAMPTZ NOT NULL,
+    attempt INT NOT NULL, -- percobaan ke berapa untuk jadwal ini, dimulai dari 1
+    status VARCHAR(20) NOT NULL CHECK (status IN ('succeeded', 'failed')),
+    transaction_id uuid REFERENCES transactions(transaction_id) ON DELETE SET NULL, -- transaksi transfer_out jika berhasil
+    error TEXT, -- alasan gagal, misal insufficient balance
+    executed_at TIMESTAMPTZ NOT NULL
+);
+
+-- satu jadwal hanya boleh berhasil sekali
+CREATE UNIQUE INDEX idx_scheduled_transfer_runs_succeeded ON scheduled_transfer_runs(schedule_id, occurrence) WHERE status = 'succeeded';
+CREATE INDEX idx_scheduled_transfer_runs_schedule_id ON scheduled_transfer_runs(schedule_id, run_id DESC);
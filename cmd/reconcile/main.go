@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/sirupsen/logrus"
+	V1Usecase "github.com/snykk/transaction-api/internal/business/usecases/v1"
+	"github.com/snykk/transaction-api/internal/config"
+	"github.com/snykk/transaction-api/internal/constants"
+	V1PostgresRepository "github.com/snykk/transaction-api/internal/datasources/repositories/postgres/v1"
+	"github.com/snykk/transaction-api/internal/utils"
+	"github.com/snykk/transaction-api/pkg/logger"
+)
+
+var (
+	failOnDiscrepancy bool
+)
+
+func init() {
+	if err := config.InitializeAppConfig(); err != nil {
+		logger.Fatal(err.Error(), logrus.Fields{constants.LoggerCategory: constants.LoggerCategoryConfig})
+	}
+	logger.Info("configuration loaded", logrus.Fields{constants.LoggerCategory: constants.LoggerCategoryConfig})
+}
+
+// main menjalankan satu kali rekonsiliasi saldo wallet. Exit code 1 jika run gagal,
+// dan 2 jika -fail-on-discrepancy dipakai dan ada wallet yang saldonya selisih.
+func main() {
+	flag.BoolVar(&failOnDiscrepancy, "fail-on-discrepancy", false, "exit with code 2 when any wallet balance does not match its transactions")
+	flag.Parse()
+
+	fields := logrus.Fields{constants.LoggerCategory: constants.LoggerCategoryReconcile}
+
+	db, err := utils.SetupPostgresConnection()
+	if err != nil {
+		logger.Panic(err.Error(), fields)
+	}
+	defer db.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	reconciliationUsecase := V1Usecase.NewReconciliationUsecase(V1PostgresRepository.NewReconciliationRepository(db))
+
+	run, err := reconciliationUsecase.Run(ctx, constants.ReconciliationTriggerCLI)
+	if err != nil {
+		logger.ErrorF("reconciliation run %s failed after %d wallet(s): %v", fields, run.Id, run.WalletsChecked, err)
+		db.Close()
+		os.Exit(1)
+	}
+
+	logger.InfoF("reconciliation run %s finished: %d wallet(s) checked, %d discrepancy(ies) found", fields, run.Id, run.WalletsChecked, run.Discrepancies)
+	if failOnDiscrepancy && run.Discrepancies > 0 {
+		db.Close()
+		os.Exit(2)
+	}
+}
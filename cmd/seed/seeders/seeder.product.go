@@ -6,6 +6,7 @@ import (
 	"github.com/snykk/transaction-api/internal/datasources/records"
 	"github.com/snykk/transaction-api/pkg/helpers"
 	"github.com/snykk/transaction-api/pkg/logger"
+	"github.com/snykk/transaction-api/pkg/money"
 )
 
 var ProductData []records.Product
@@ -21,25 +22,25 @@ func init() {
 		{
 			Name:        "Keyboard",
 			Description: "lorem ipsum dolor sit amet",
-			Price:       20.90,
+			Price:       money.MustParse("20.90"),
 			Stock:       23,
 		},
 		{
 			Name:        "Mouse",
 			Description: "lorem ipsum dolor sit amet",
-			Price:       11.99,
+			Price:       money.MustParse("11.99"),
 			Stock:       100,
 		},
 		{
 			Name:        "Wifi Adapter",
 			Description: "lorem ipsum dolor sit amet",
-			Price:       29.99,
+			Price:       money.MustParse("29.99"),
 			Stock:       234,
 		},
 		{
 			Name:        "Computer Fan",
 			Description: "lorem ipsum dolor sit amet",
-			Price:       31.99,
+			Price:       money.MustParse("31.99"),
 			Stock:       200,
 		},
 	}
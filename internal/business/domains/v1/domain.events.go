@@ -0,0 +1,87 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/snykk/transaction-api/pkg/money"
+)
+
+// EventDomain adalah event domain di tabel outbox. Event dikirim minimal sekali,
+// sehingga consumer harus menganggap Id sebagai kunci deduplikasi.
+type EventDomain struct {
+	Id            string
+	EventType     string
+	AggregateType string
+	AggregateId   string
+	Payload       json.RawMessage
+	Version       int // versi skema payload
+	Status        string
+	Attempts      int // jumlah percobaan kirim yang gagal
+	LastError     *string
+	AvailableAt   time.Time
+	CreatedAt     time.Time
+	SentAt        *time.Time
+}
+
+// TransactionEventPayload adalah isi event perubahan saldo dari transaksi
+type TransactionEventPayload struct {
+	TransactionId     string      `json:"transaction_id"`
+	WalletId          string      `json:"wallet_id"`
+	UserId            string      `json:"user_id"`
+	TransactionType   string      `json:"transaction_type"`
+	Status            string      `json:"status"`
+	Amount            money.Money `json:"amount"`
+	Fee               money.Money `json:"fee"`
+	Clawback          money.Money `json:"clawback,omitempty"` // hanya pada refund, saldo bertambah sebesar Amount - Clawback
+	ProductId         *int        `json:"product_id,omitempty"`
+	Quantity          *int        `json:"quantity,omitempty"`
+	ExternalReference *string     `json:"external_reference,omitempty"`
+	OccurredAt        time.Time   `json:"occurred_at"`
+}
+
+// HoldEventPayload adalah isi event perubahan dana yang ditahan hold
+type HoldEventPayload struct {
+	HoldId          string       `json:"hold_id"`
+	WalletId        string       `json:"wallet_id"`
+	UserId          string       `json:"user_id"`
+	TransactionType string       `json:"transaction_type"`
+	Status          string       `json:"status"`
+	Amount          money.Money  `json:"amount"`
+	Fee             money.Money  `json:"fee"`
+	CapturedAmount  *money.Money `json:"captured_amount,omitempty"`
+	TransactionId   *string      `json:"transaction_id,omitempty"` // transaksi hasil capture
+	ExpiresAt       time.Time    `json:"expires_at"`
+	OccurredAt      time.Time    `json:"occurred_at"`
+}
+
+// PointsEventPayload adalah isi event perubahan saldo poin yang tidak membuat transaksi, misal poin kedaluwarsa
+type PointsEventPayload struct {
+	PointEntryId string      `json:"point_entry_id"`
+	WalletId     string      `json:"wallet_id"`
+	UserId       string      `json:"user_id"`
+	EntryType    string      `json:"entry_type"`
+	Points       money.Money `json:"points"`
+	OccurredAt   time.Time   `json:"occurred_at"`
+}
+
+// EventPublisher mengirim event ke luar proses atau ke subscriber di dalam proses.
+// Publish yang mengembalikan error akan diulang oleh relay.
+type EventPublisher interface {
+	Publish(ctx context.Context, event EventDomain) error
+}
+
+type OutboxUsecase interface {
+	// Relay mengirim satu batch event pending ke publisher, event yang gagal dijadwalkan ulang dengan backoff
+	Relay(ctx context.Context, now time.Time) (sent int, failed int, err error)
+}
+
+type OutboxRepository interface {
+	// ClaimPending mengambil paling banyak limit event pending yang available_at-nya sudah lewat
+	// lalu menundanya sampai now + lease, sehingga relay lain tidak mengirim event yang sama selama lease berlaku
+	ClaimPending(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]EventDomain, error)
+	MarkSent(ctx context.Context, eventId string, sentAt time.Time) error
+	// MarkFailed mencatat kegagalan kirim dan menjadwalkan percobaan berikutnya pada retryAt
+	MarkFailed(ctx context.Context, eventId string, reason string, retryAt time.Time) error
+}
@@ -0,0 +1,113 @@
+package v1
+
+import (
+	"context"
+	"time"
+
+	"github.com/snykk/transaction-api/internal/constants"
+	"github.com/snykk/transaction-api/pkg/money"
+)
+
+// FeeRuleDomain adalah satu aturan biaya untuk tipe transaksi tertentu, opsional hanya untuk satu produk.
+// Field yang dipakai bergantung pada FeeType: FlatAmount untuk flat, RateBps untuk percentage, Tiers untuk tiered.
+type FeeRuleDomain struct {
+	Id              int
+	Name            string
+	TransactionType string
+	ProductId       *int // nil berarti berlaku untuk semua produk
+	FeeType         string
+	FlatAmount      *money.Money
+	RateBps         *int
+	Tiers           []FeeTierDomain
+	MinFee          *money.Money
+	MaxFee          *money.Money
+	IsActive        bool
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// FeeTierDomain berlaku untuk amount sampai dengan UpTo, UpTo nil berarti tanpa batas atas.
+// Fee tier adalah FlatAmount ditambah RateBps dari seluruh amount.
+type FeeTierDomain struct {
+	UpTo       *money.Money
+	FlatAmount money.Money
+	RateBps    int
+}
+
+// FeeComponentDomain adalah bagian fee yang berasal dari satu aturan
+type FeeComponentDomain struct {
+	FeeRuleId int
+	Name      string
+	FeeType   string
+	Amount    money.Money
+}
+
+// Calculate menghitung fee aturan ini untuk amount, lalu menerapkan batas min dan max
+func (r FeeRuleDomain) Calculate(amount money.Money) money.Money {
+	var fee money.Money
+	switch r.FeeType {
+	case constants.FeeTypeFlat:
+		if r.FlatAmount != nil {
+			fee = *r.FlatAmount
+		}
+	case constants.FeeTypePercentage:
+		if r.RateBps != nil {
+			fee = amount.MulDiv(int64(*r.RateBps), constants.FeeBasisPointsPerUnit)
+		}
+	case constants.FeeTypeTiered:
+		// tier diurutkan dari batas terkecil, tier pertama yang memuat amount yang dipakai
+		for _, tier := range r.Tiers {
+			if tier.UpTo == nil || amount <= *tier.UpTo {
+				fee = tier.FlatAmount + amount.MulDiv(int64(tier.RateBps), constants.FeeBasisPointsPerUnit)
+				break
+			}
+		}
+	}
+
+	if r.MinFee != nil && fee < *r.MinFee {
+		fee = *r.MinFee
+	}
+	if r.MaxFee != nil && fee > *r.MaxFee {
+		fee = *r.MaxFee
+	}
+
+	return fee
+}
+
+// CalculateFees menjumlahkan fee dari semua aturan, aturan yang menghasilkan 0 tidak masuk rincian
+func CalculateFees(rules []FeeRuleDomain, amount money.Money) (total money.Money, breakdown []FeeComponentDomain) {
+	for _, rule := range rules {
+		fee := rule.Calculate(amount)
+		if fee.IsZero() {
+			continue
+		}
+
+		total += fee
+		breakdown = append(breakdown, FeeComponentDomain{
+			FeeRuleId: rule.Id,
+			Name:      rule.Name,
+			FeeType:   rule.FeeType,
+			Amount:    fee,
+		})
+	}
+
+	return total, breakdown
+}
+
+type FeeRuleUsecase interface {
+	GetAll(ctx context.Context) (outDoms []FeeRuleDomain, statusCode int, err error)
+	GetById(ctx context.Context, id int) (outDom FeeRuleDomain, statusCode int, err error)
+	Store(ctx context.Context, feeRuleDom *FeeRuleDomain) (outDom FeeRuleDomain, statusCode int, err error)
+	Update(ctx context.Context, id int, feeRuleDom *FeeRuleDomain) (outDom FeeRuleDomain, statusCode int, err error)
+	Delete(ctx context.Context, id int) (statusCode int, err error)
+}
+
+type FeeRuleRepository interface {
+	GetAll(ctx context.Context) ([]FeeRuleDomain, error)
+	GetById(ctx context.Context, id int) (FeeRuleDomain, error)
+	// GetApplicable mengembalikan aturan aktif untuk tipe transaksi, termasuk aturan khusus productId jika tidak nil
+	GetApplicable(ctx context.Context, transactionType string, productId *int) ([]FeeRuleDomain, error)
+	Store(ctx context.Context, feeRuleDom FeeRuleDomain) (FeeRuleDomain, error)
+	Update(ctx context.Context, feeRuleDom FeeRuleDomain) (FeeRuleDomain, error)
+	Delete(ctx context.Context, id int) error
+}
@@ -0,0 +1,62 @@
+package v1
+
+import (
+	"context"
+	"time"
+
+	"github.com/snykk/transaction-api/pkg/money"
+)
+
+// HoldDomain menahan dana wallet untuk withdraw atau pembelian yang belum final.
+// Dana yang ditahan (Amount + Fee) mengurangi saldo tersedia tetapi tidak mengubah saldo ledger.
+type HoldDomain struct {
+	Id              string
+	WalletId        string
+	UserId          string
+	TransactionType string // withdraw atau purchase, tipe transaksi yang dibuat saat capture
+	ProductId       *int   // hanya untuk hold pembelian
+	Quantity        *int
+	UnitPrice       *money.Money // snapshot harga produk saat hold dibuat
+	Amount          money.Money  // nominal maksimum yang bisa di-capture
+	Fee             money.Money  // perkiraan fee yang ikut ditahan
+	Status          string
+	CapturedAmount  *money.Money
+	TransactionId   *string
+	Transaction     *TransactionDomain // transaksi hasil capture, hanya diisi pada response capture
+	ExpiresAt       time.Time
+	ClosedAt        *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// HeldAmount adalah total dana yang ditahan hold ini dari saldo tersedia
+func (h HoldDomain) HeldAmount() money.Money {
+	return h.Amount + h.Fee
+}
+
+// HoldCaptureDomain berisi bagian hold yang di-capture, nil berarti capture penuh.
+// Hold withdraw di-capture berdasarkan Amount, hold pembelian berdasarkan Quantity.
+type HoldCaptureDomain struct {
+	Amount   *money.Money
+	Quantity *int
+}
+
+type HoldUsecase interface {
+	Store(ctx context.Context, holdDom *HoldDomain, expiresIn time.Duration) (outDom HoldDomain, statusCode int, err error)
+	GetById(ctx context.Context, holdId string, userId string) (outDom HoldDomain, statusCode int, err error)
+	Capture(ctx context.Context, holdId string, userId string, captureDom HoldCaptureDomain) (outDom HoldDomain, statusCode int, err error)
+	Void(ctx context.Context, holdId string, userId string) (outDom HoldDomain, statusCode int, err error)
+	// ExpireDue melepas hold aktif yang sudah melewati expires_at
+	ExpireDue(ctx context.Context, now time.Time) (expired []HoldDomain, err error)
+}
+
+type HoldRepository interface {
+	// Store menahan dana di wallet user, gagal dengan ErrInsufficientBalance jika saldo tersedia tidak cukup
+	Store(ctx context.Context, holdDom HoldDomain) (HoldDomain, error)
+	GetById(ctx context.Context, holdId string, userId string) (HoldDomain, error)
+	// Capture melepas hold lalu membuat transaksi withdraw atau pembelian dalam transaksi database yang sama
+	Capture(ctx context.Context, holdId string, userId string, transactionDom TransactionDomain) (HoldDomain, error)
+	Void(ctx context.Context, holdId string, userId string) (HoldDomain, error)
+	// ExpireDue melepas paling banyak limit hold aktif yang expires_at-nya sudah lewat
+	ExpireDue(ctx context.Context, now time.Time, limit int) ([]HoldDomain, error)
+}
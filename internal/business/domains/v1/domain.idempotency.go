@@ -0,0 +1,25 @@
+package v1
+
+import (
+	"context"
+	"time"
+)
+
+type IdempotencyKeyDomain struct {
+	UserId       string
+	Key          string
+	RequestHash  string
+	Status       string
+	ResponseCode int
+	ResponseBody []byte
+	CreatedAt    time.Time
+	ExpiresAt    time.Time
+}
+
+type IdempotencyRepository interface {
+	// Reserve mengklaim key untuk request baru. Jika key sudah dipakai dan belum kedaluwarsa,
+	// data yang tersimpan dikembalikan dengan reserved bernilai false.
+	Reserve(ctx context.Context, inDom IdempotencyKeyDomain) (outDom IdempotencyKeyDomain, reserved bool, err error)
+	Complete(ctx context.Context, inDom IdempotencyKeyDomain) error
+	Release(ctx context.Context, userId string, key string) error
+}
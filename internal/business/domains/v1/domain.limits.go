@@ -0,0 +1,62 @@
+package v1
+
+import (
+	"context"
+	"time"
+
+	"github.com/snykk/transaction-api/internal/constants"
+	"github.com/snykk/transaction-api/pkg/money"
+)
+
+// UserLimitDomain berisi batas transaksi seorang user. Sebagai override, field nil berarti
+// memakai default global. Nilai 0 berarti tanpa batas.
+type UserLimitDomain struct {
+	UserId              string
+	MaxSingleWithdrawal *money.Money
+	DailyWithdrawal     *money.Money
+	MonthlyWithdrawal   *money.Money
+	DailyPurchase       *money.Money
+	HourlyTransactions  *int
+	Overridden          []string // nama limit yang di-override untuk user ini, diisi oleh WithDefaults
+	UpdatedBy           *string  // admin yang terakhir mengubah override
+	UpdatedAt           *time.Time
+}
+
+// WithDefaults mengembalikan limit efektif: override user jika ada, selain itu default global
+func (l UserLimitDomain) WithDefaults(defaults UserLimitDomain) UserLimitDomain {
+	effective := l
+	effective.Overridden = []string{}
+
+	pickMoney := func(name string, override, fallback *money.Money) *money.Money {
+		if override == nil {
+			return fallback
+		}
+		effective.Overridden = append(effective.Overridden, name)
+		return override
+	}
+	effective.MaxSingleWithdrawal = pickMoney(constants.LimitMaxSingleWithdrawal, l.MaxSingleWithdrawal, defaults.MaxSingleWithdrawal)
+	effective.DailyWithdrawal = pickMoney(constants.LimitDailyWithdrawal, l.DailyWithdrawal, defaults.DailyWithdrawal)
+	effective.MonthlyWithdrawal = pickMoney(constants.LimitMonthlyWithdrawal, l.MonthlyWithdrawal, defaults.MonthlyWithdrawal)
+	effective.DailyPurchase = pickMoney(constants.LimitDailyPurchase, l.DailyPurchase, defaults.DailyPurchase)
+
+	effective.HourlyTransactions = defaults.HourlyTransactions
+	if l.HourlyTransactions != nil {
+		effective.HourlyTransactions = l.HourlyTransactions
+		effective.Overridden = append(effective.Overridden, constants.LimitHourlyTransactions)
+	}
+
+	return effective
+}
+
+type LimitUsecase interface {
+	// GetByUserId mengembalikan limit efektif user
+	GetByUserId(ctx context.Context, userId string) (outDom UserLimitDomain, statusCode int, err error)
+	// Update mengganti seluruh override user, field nil kembali memakai default global
+	Update(ctx context.Context, limitDom *UserLimitDomain) (outDom UserLimitDomain, statusCode int, err error)
+}
+
+type LimitRepository interface {
+	// GetByUserId mengembalikan override user, semua field nil jika user belum punya override
+	GetByUserId(ctx context.Context, userId string) (UserLimitDomain, error)
+	Upsert(ctx context.Context, limitDom UserLimitDomain) (UserLimitDomain, error)
+}
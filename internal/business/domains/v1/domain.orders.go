@@ -0,0 +1,57 @@
+package v1
+
+import (
+	"context"
+	"time"
+
+	"github.com/snykk/transaction-api/pkg/money"
+)
+
+// OrderDomain adalah pembelian beberapa produk sekaligus yang dibayar dengan satu transaksi purchase.
+// Pembelian satu produk lewat endpoint purchase juga dicatat sebagai order dengan satu item.
+type OrderDomain struct {
+	Id                string
+	UserId            string
+	WalletId          string // kosong berarti wallet default
+	TransactionId     string
+	Items             []OrderItemDomain
+	TotalAmount       money.Money // jumlah subtotal seluruh item sebelum diskon
+	VoucherCode       *string     // kode voucher yang ingin dipakai, opsional
+	Discount          money.Money // potongan voucher, yang didebit adalah TotalAmount dikurangi Discount
+	PointsRedeemed    money.Money // poin yang ingin dipakai membayar sebagian order
+	PointsEarned      money.Money
+	Cashback          money.Money
+	Fee               money.Money          // dibebankan di luar TotalAmount
+	FeeBreakdown      []FeeComponentDomain // rincian Fee per aturan, dijumlahkan dari semua item
+	Status            string               // status transaksi purchase
+	Description       *string
+	ExternalReference *string
+	Metadata          map[string]interface{}
+	Transaction       TransactionDomain // hanya diisi saat order dibuat
+	CreatedAt         time.Time
+}
+
+type OrderItemDomain struct {
+	Id          string
+	OrderId     string
+	ProductId   *int   // nil jika produk sudah dihapus
+	ProductName string // snapshot nama produk saat dibeli
+	Quantity    int
+	UnitPrice   money.Money // harga satuan saat dibeli, diisi usecase dari harga produk terkini
+	Subtotal    money.Money
+	PriceLocked bool // UnitPrice dari snapshot hold, tidak dibandingkan dengan harga produk terkini
+}
+
+type OrderUsecase interface {
+	Create(ctx context.Context, orderDom *OrderDomain) (domain OrderDomain, statusCode int, err error)
+	GetByUserId(ctx context.Context, userId string) (domains []OrderDomain, statusCode int, err error)
+	GetById(ctx context.Context, orderId string, userId string) (domain OrderDomain, statusCode int, err error)
+}
+
+type OrderRepository interface {
+	// Create memeriksa dan mengurangi stock semua item lalu mendebit wallet sebesar total dan fee dalam satu transaksi database,
+	// gagal dengan ErrProductPriceChanged jika harga produk berbeda dengan UnitPrice item
+	Create(ctx context.Context, orderDom OrderDomain) (OrderDomain, error)
+	GetByUserId(ctx context.Context, userId string) ([]OrderDomain, error)
+	GetById(ctx context.Context, orderId string, userId string) (OrderDomain, error)
+}
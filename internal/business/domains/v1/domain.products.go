@@ -3,13 +3,16 @@ package v1
 import (
 	"context"
 	"time"
+
+	"github.com/snykk/transaction-api/pkg/money"
 )
 
 type ProductDomain struct {
 	Id          int
 	Name        string
 	Description string
-	Price       float64
+	Category    *string // dipakai aturan reward per kategori
+	Price       money.Money
 	Stock       int
 	CreatedAt   time.Time
 	UpdatedAt   *time.Time
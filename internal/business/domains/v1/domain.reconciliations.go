@@ -0,0 +1,58 @@
+package v1
+
+import (
+	"context"
+	"time"
+
+	"github.com/snykk/transaction-api/pkg/money"
+)
+
+// ReconciliationRunDomain adalah satu kali eksekusi rekonsiliasi yang membandingkan wallets.balance
+// dengan saldo yang dihitung ulang dari transaksi: deposit, transfer masuk, dan refund menambah saldo,
+// sedangkan withdraw, pembelian, dan transfer keluar beserta fee-nya mengurangi saldo.
+type ReconciliationRunDomain struct {
+	Id             string
+	Trigger        string // cli atau cron
+	Status         string
+	WalletsChecked int
+	Discrepancies  int     // total wallet yang selisih, Items bisa dipotong untuk laporan
+	ErrorMessage   *string // diisi jika run gagal di tengah jalan
+	StartedAt      time.Time
+	FinishedAt     *time.Time
+	Items          []ReconciliationItemDomain
+}
+
+// ReconciliationItemDomain adalah satu wallet yang saldonya tidak sesuai dengan riwayat transaksinya
+type ReconciliationItemDomain struct {
+	Id              string
+	RunId           string
+	WalletId        string
+	UserId          string
+	RecordedBalance money.Money
+	ExpectedBalance money.Money
+	Difference      money.Money // RecordedBalance - ExpectedBalance
+	CreatedAt       time.Time
+}
+
+// ReconciliationBatchDomain adalah hasil rekonsiliasi satu batch wallet
+type ReconciliationBatchDomain struct {
+	WalletsChecked int
+	Discrepancies  int
+	LastWalletId   string // titik awal batch berikutnya
+}
+
+type ReconciliationUsecase interface {
+	// Run memeriksa semua wallet per batch dan mencatat hasilnya, dipakai oleh CLI dan cron
+	Run(ctx context.Context, trigger string) (outDom ReconciliationRunDomain, err error)
+	GetLatest(ctx context.Context) (outDom ReconciliationRunDomain, statusCode int, err error)
+}
+
+type ReconciliationRepository interface {
+	CreateRun(ctx context.Context, trigger string) (result ReconciliationRunDomain, err error)
+	// ReconcileBatch memeriksa maksimal limit wallet setelah afterWalletId, mencatat selisihnya,
+	// dan menambah penghitung run dalam satu statement sehingga saldo dan transaksi dibaca dari snapshot yang sama
+	ReconcileBatch(ctx context.Context, runId string, afterWalletId string, limit int) (result ReconciliationBatchDomain, err error)
+	FinishRun(ctx context.Context, runId string, status string, errorMessage *string) (result ReconciliationRunDomain, err error)
+	// GetLatestRun mengembalikan run terakhir beserta selisihnya, gagal dengan ErrReconciliationNotFound jika belum pernah ada
+	GetLatestRun(ctx context.Context, itemLimit int) (result ReconciliationRunDomain, err error)
+}
@@ -0,0 +1,65 @@
+package v1
+
+import (
+	"context"
+	"time"
+
+	"github.com/snykk/transaction-api/pkg/money"
+)
+
+// RewardRuleDomain memberi poin atau cashback sebesar RateBps dari nominal pembelian yang dibayar dengan saldo.
+// Cakupan aturan adalah satu produk, satu kategori, atau semua produk jika keduanya kosong.
+type RewardRuleDomain struct {
+	Id               int
+	Name             string
+	RewardType       string
+	ProductId        *int
+	Category         *string
+	RateBps          int
+	MonthlyCap       *money.Money // batas reward per user per bulan kalender UTC, nil berarti tanpa batas
+	PointsExpiryDays *int         // masa berlaku poin, hanya untuk reward_type points
+	IsActive         bool
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// PointEntryDomain adalah mutasi poin wallet. Entry earn adalah lot poin yang menyimpan sisa dan masa berlakunya,
+// entry lainnya bernilai negatif.
+type PointEntryDomain struct {
+	Id            string
+	WalletId      string
+	EntryType     string
+	Points        money.Money
+	Remaining     *money.Money // hanya untuk entry earn
+	ExpiresAt     *time.Time   // hanya untuk entry earn
+	TransactionId *string
+	CreatedAt     time.Time
+}
+
+type RewardUsecase interface {
+	GetAllRules(ctx context.Context) (outDoms []RewardRuleDomain, statusCode int, err error)
+	GetRuleById(ctx context.Context, id int) (outDom RewardRuleDomain, statusCode int, err error)
+	StoreRule(ctx context.Context, ruleDom *RewardRuleDomain) (outDom RewardRuleDomain, statusCode int, err error)
+	UpdateRule(ctx context.Context, id int, ruleDom *RewardRuleDomain) (outDom RewardRuleDomain, statusCode int, err error)
+	DeleteRule(ctx context.Context, id int) (statusCode int, err error)
+	GetPointEntries(ctx context.Context, userId string, walletId string) (outDoms []PointEntryDomain, statusCode int, err error)
+	// ConvertPoints menukar poin menjadi saldo wallet dengan nilai 1 poin = 1 satuan mata uang
+	ConvertPoints(ctx context.Context, userId string, walletId string, points money.Money) (outDom TransactionDomain, statusCode int, err error)
+	// ExpirePoints menghanguskan sisa lot poin yang sudah melewati expires_at
+	ExpirePoints(ctx context.Context, now time.Time) (expired []PointEntryDomain, err error)
+}
+
+type RewardRepository interface {
+	GetAllRules(ctx context.Context) ([]RewardRuleDomain, error)
+	GetRuleById(ctx context.Context, id int) (RewardRuleDomain, error)
+	StoreRule(ctx context.Context, ruleDom RewardRuleDomain) (RewardRuleDomain, error)
+	UpdateRule(ctx context.Context, ruleDom RewardRuleDomain) (RewardRuleDomain, error)
+	DeleteRule(ctx context.Context, id int) error
+	// GetPointEntries mengembalikan mutasi poin wallet milik user, walletId kosong berarti wallet default
+	GetPointEntries(ctx context.Context, userId string, walletId string) ([]PointEntryDomain, error)
+	// ConvertPoints memakai lot poin yang paling cepat kedaluwarsa lebih dulu lalu membuat transaksi points_conversion,
+	// gagal dengan ErrInsufficientPoints jika saldo poin tidak cukup
+	ConvertPoints(ctx context.Context, userId string, walletId string, points money.Money) (TransactionDomain, error)
+	// ExpirePoints menghanguskan paling banyak limit lot poin, mengembalikan entry expire yang dibuat
+	ExpirePoints(ctx context.Context, now time.Time, limit int) ([]PointEntryDomain, error)
+}
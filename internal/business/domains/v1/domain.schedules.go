@@ -0,0 +1,121 @@
+package v1
+
+import (
+	"context"
+	"time"
+
+	"github.com/snykk/transaction-api/internal/constants"
+	"github.com/snykk/transaction-api/pkg/money"
+)
+
+// ScheduleDomain adalah transfer terjadwal, sekali jalan (once) maupun berulang.
+// Jadwal ke-n dihitung dari StartAt sehingga tanggal tidak bergeser walau eksekusi terlambat.
+type ScheduleDomain struct {
+	Id                string
+	UserId            string
+	RecipientUserId   string
+	RecipientUsername string // hanya dipakai untuk mencari penerima saat schedule dibuat
+	RecipientEmail    string // hanya dipakai untuk mencari penerima saat schedule dibuat
+	Amount            money.Money
+	Frequency         string
+	StartAt           time.Time
+	EndAt             *time.Time
+	MaxRuns           *int
+	OccurrenceCount   int // jumlah jadwal yang sudah selesai diproses, berhasil maupun gagal
+	Attempt           int // jumlah percobaan gagal untuk jadwal yang sedang berjalan
+	NextRunAt         *time.Time
+	Status            string
+	LastError         *string
+	LastRunAt         *time.Time
+	Runs              []ScheduleRunDomain // riwayat eksekusi terbaru, hanya diisi pada detail
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+// ScheduleRunDomain adalah satu percobaan eksekusi sebuah jadwal
+type ScheduleRunDomain struct {
+	Id              int64
+	ScheduleId      string
+	UserId          string
+	RecipientUserId string
+	Occurrence      int // urutan jadwal, dimulai dari 1
+	ScheduledFor    time.Time
+	Attempt         int // percobaan ke berapa untuk jadwal ini, dimulai dari 1
+	Status          string
+	TransactionId   *string
+	Error           *string
+	Retryable       bool // kegagalan yang mungkin berhasil jika dicoba lagi, misal saldo belum cukup
+	ExecutedAt      time.Time
+}
+
+// ScheduleUpdateDomain berisi perubahan schedule, field nil berarti tidak diubah
+type ScheduleUpdateDomain struct {
+	Amount  *money.Money
+	EndAt   *time.Time
+	MaxRuns *int
+	Status  *string // active atau paused
+}
+
+// IsModifiable mengecek apakah schedule masih boleh diubah atau dibatalkan
+func (s ScheduleDomain) IsModifiable() bool {
+	return s.Status == constants.ScheduleStatusActive || s.Status == constants.ScheduleStatusPaused
+}
+
+// OccurrenceAt mengembalikan waktu jadwal ke-n (dimulai dari 0). Untuk frekuensi bulanan,
+// tanggal yang tidak ada di bulan tujuan dipindah ke akhir bulan, misal 31 Januari -> 29 Februari.
+func (s ScheduleDomain) OccurrenceAt(n int) time.Time {
+	switch s.Frequency {
+	case constants.ScheduleFrequencyDaily:
+		return s.StartAt.AddDate(0, 0, n)
+	case constants.ScheduleFrequencyWeekly:
+		return s.StartAt.AddDate(0, 0, 7*n)
+	case constants.ScheduleFrequencyMonthly:
+		year, month, day := s.StartAt.Date()
+		firstDay := time.Date(year, month+time.Month(n), 1, s.StartAt.Hour(), s.StartAt.Minute(), s.StartAt.Second(), s.StartAt.Nanosecond(), s.StartAt.Location())
+		if lastDay := firstDay.AddDate(0, 1, -1).Day(); day > lastDay {
+			day = lastDay
+		}
+		return firstDay.AddDate(0, 0, day-1)
+	default:
+		return s.StartAt
+	}
+}
+
+// NextOccurrence mengembalikan jadwal berikutnya setelah OccurrenceCount jadwal diproses,
+// atau nil jika schedule sudah mencapai end_at, max_runs, atau hanya sekali jalan
+func (s ScheduleDomain) NextOccurrence() *time.Time {
+	if s.Frequency == constants.ScheduleFrequencyOnce && s.OccurrenceCount > 0 {
+		return nil
+	}
+	if s.MaxRuns != nil && s.OccurrenceCount >= *s.MaxRuns {
+		return nil
+	}
+
+	next := s.OccurrenceAt(s.OccurrenceCount)
+	if s.EndAt != nil && next.After(*s.EndAt) {
+		return nil
+	}
+
+	return &next
+}
+
+type ScheduleUsecase interface {
+	Store(ctx context.Context, scheduleDom *ScheduleDomain) (outDom ScheduleDomain, statusCode int, err error)
+	GetAll(ctx context.Context, userId string) (outDoms []ScheduleDomain, statusCode int, err error)
+	GetById(ctx context.Context, scheduleId string, userId string) (outDom ScheduleDomain, statusCode int, err error)
+	Update(ctx context.Context, scheduleId string, userId string, updateDom ScheduleUpdateDomain) (outDom ScheduleDomain, statusCode int, err error)
+	Cancel(ctx context.Context, scheduleId string, userId string) (outDom ScheduleDomain, statusCode int, err error)
+	// RunDue mengeksekusi schedule yang sudah jatuh tempo, onRun dipanggil setelah setiap eksekusi
+	RunDue(ctx context.Context, now time.Time, onRun func(ScheduleRunDomain)) (executed int, err error)
+}
+
+type ScheduleRepository interface {
+	Store(ctx context.Context, scheduleDom ScheduleDomain) (ScheduleDomain, error)
+	GetByUserId(ctx context.Context, userId string) ([]ScheduleDomain, error)
+	GetById(ctx context.Context, scheduleId string, userId string) (ScheduleDomain, error)
+	// Update mengunci schedule lalu menyimpan hasil apply, error dari apply membatalkan perubahan
+	Update(ctx context.Context, scheduleId string, userId string, apply func(ScheduleDomain) (ScheduleDomain, error)) (ScheduleDomain, error)
+	// ExecuteNext mengunci satu schedule yang jatuh tempo (FOR UPDATE SKIP LOCKED), menjalankan transfernya,
+	// lalu menyimpan hasil apply dalam transaksi yang sama. Mengembalikan nil jika tidak ada yang jatuh tempo.
+	ExecuteNext(ctx context.Context, now time.Time, apply func(ScheduleDomain, ScheduleRunDomain) ScheduleDomain) (*ScheduleRunDomain, error)
+}
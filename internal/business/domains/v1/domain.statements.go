@@ -0,0 +1,91 @@
+package v1
+
+import (
+	"context"
+	"time"
+
+	"github.com/snykk/transaction-api/pkg/money"
+)
+
+// StatementDomain adalah rekening koran wallet milik user untuk periode [From, To).
+type StatementDomain struct {
+	UserId         string
+	WalletId       string // kosong berarti wallet default user
+	From           time.Time
+	To             time.Time // eksklusif
+	OpeningBalance money.Money
+	ClosingBalance money.Money
+}
+
+// StatementEntryDomain adalah satu mutasi saldo wallet yang diambil dari ledger.
+type StatementEntryDomain struct {
+	EntryId         int64
+	TransactionId   string
+	TransactionType string
+	Status          string
+	ProductName     *string
+	Quantity        *int
+	Direction       string // debit mengurangi saldo, credit menambah saldo
+	Amount          money.Money
+	Balance         money.Money // saldo berjalan setelah mutasi ini
+	PostedAt        time.Time
+}
+
+// StatementWriter menerima isi statement secara berurutan agar baris bisa langsung
+// dikirim ke client tanpa menampung semuanya di memori.
+type StatementWriter interface {
+	Begin(statement StatementDomain) error
+	Entry(entry StatementEntryDomain) error
+	End(statement StatementDomain) error
+}
+
+// MonthlyStatementDomain adalah ringkasan bulanan wallet yang dibuat oleh job statement.
+// Periode mengikuti zona waktu user, misal Period "2024-01" untuk Asia/Jakarta
+// mencakup [2024-01-01 00:00 +07:00, 2024-02-01 00:00 +07:00).
+type MonthlyStatementDomain struct {
+	Id             string
+	UserId         string
+	WalletId       string
+	Period         string // format constants.StatementPeriodLayout
+	Timezone       string
+	PeriodStart    time.Time
+	PeriodEnd      time.Time // eksklusif
+	OpeningBalance money.Money
+	ClosingBalance money.Money
+	Totals         []StatementTotalDomain
+	EmailedAt      *time.Time
+	CreatedAt      time.Time
+}
+
+// StatementTotalDomain adalah akumulasi mutasi satu tipe transaksi dalam satu statement bulanan.
+type StatementTotalDomain struct {
+	TransactionType string
+	Count           int
+	TotalCredit     money.Money
+	TotalDebit      money.Money
+}
+
+// StatementAccountDomain adalah wallet yang diperiksa oleh job statement beserta data pemiliknya.
+type StatementAccountDomain struct {
+	WalletId        string
+	UserId          string
+	Username        string
+	Email           string
+	Timezone        string
+	WalletCreatedAt time.Time
+	LastPeriod      *string // periode statement terakhir, nil jika belum pernah dibuat
+}
+
+type StatementUsecase interface {
+	GenerateMonthly(ctx context.Context, now time.Time) (generated int, err error)
+	GetAll(ctx context.Context, userId string) (outDoms []MonthlyStatementDomain, statusCode int, err error)
+	GetById(ctx context.Context, statementId string, userId string) (outDom MonthlyStatementDomain, statusCode int, err error)
+}
+
+type StatementRepository interface {
+	GetAccounts(ctx context.Context, afterWalletId string, limit int) (accounts []StatementAccountDomain, err error)
+	Generate(ctx context.Context, statementDom MonthlyStatementDomain) (result MonthlyStatementDomain, err error)
+	MarkEmailed(ctx context.Context, statementId string) (err error)
+	GetByUserId(ctx context.Context, userId string) (results []MonthlyStatementDomain, err error)
+	GetById(ctx context.Context, statementId string, userId string) (result MonthlyStatementDomain, err error)
+}
@@ -2,36 +2,177 @@ package v1
 
 import (
 	"context"
+	"encoding/base64"
+	"errors"
+	"slices"
+	"strings"
 	"time"
+
+	"github.com/snykk/transaction-api/internal/constants"
+	"github.com/snykk/transaction-api/pkg/money"
 )
 
 type TransactionDomain struct {
-	Id              string
-	WalletId        string
-	Wallet          WalletDomain
-	ProductId       *int // Nullable, karena transaksi deposit tidak melibatkan produk
-	Product         ProductDomain
-	Amount          float64
-	Quantity        *int
+	Id                   string
+	WalletId             string
+	Wallet               WalletDomain
+	ProductId            *int // Nullable, karena transaksi deposit tidak melibatkan produk
+	Product              ProductDomain
+	Amount               money.Money
+	Discount             money.Money          // potongan voucher, Amount sudah dikurangi Discount
+	VoucherCode          *string              // kode voucher yang dipakai pada pembelian
+	PointsRedeemed       money.Money          // poin yang dipakai membayar pembelian, Amount sudah dikurangi poin. Pada refund berisi poin yang dikembalikan
+	PointsEarned         money.Money          // poin loyalitas dari pembelian. Pada refund berisi poin yang ditarik kembali
+	Cashback             money.Money          // cashback dari pembelian, dikreditkan sebagai transaksi cashback. Pada refund berisi cashback yang ditarik kembali
+	Clawback             money.Money          // hanya pada refund: cashback dan kekurangan poin yang dipotong dari dana refund
+	Fee                  money.Money          // dibebankan di luar Amount
+	FeeBreakdown         []FeeComponentDomain // rincian Fee per aturan
+	Quantity             *int
+	PriceLocked          bool // Product.Price berasal dari snapshot hold dan dipakai walaupun harga produk sudah berubah
+	TransactionType      string
+	RelatedTransactionId *string // Nullable, diisi untuk transaksi berpasangan seperti transfer
+	Status               string
+	FailureReason        *string // Nullable, hanya diisi saat transaksi failed atau cancelled
+	CompletedAt          *time.Time
+	FailedAt             *time.Time
+	CancelledAt          *time.Time
+	Description          *string                // memo dari klien
+	ExternalReference    *string                // reference dari sistem klien, unik per user
+	Metadata             map[string]interface{} // objek JSON bebas dari klien
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+}
+
+// transactionStatusTransitions berisi perpindahan status yang diperbolehkan,
+// status yang tidak ada di map ini adalah status akhir
+var transactionStatusTransitions = map[string][]string{
+	constants.TransactionStatusPending: {
+		constants.TransactionStatusCompleted,
+		constants.TransactionStatusFailed,
+		constants.TransactionStatusCancelled,
+	},
+}
+
+// CanTransitionTransactionStatus mengecek apakah status transaksi boleh berpindah dari "from" ke "to"
+func CanTransitionTransactionStatus(from string, to string) bool {
+	for _, allowed := range transactionStatusTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// WalletBalanceEffect adalah perubahan saldo wallet oleh transaksi ini, dipakai rekonsiliasi untuk menghitung ulang saldo.
+// Dana masuk hanya dihitung jika completed dan dikurangi Clawback, dana keluar beserta fee-nya sudah memotong
+// saldo sejak pending, sedangkan yang failed atau cancelled sudah dikembalikan.
+func (t TransactionDomain) WalletBalanceEffect() money.Money {
+	switch {
+	case slices.Contains(constants.WalletCreditTransactionTypes, t.TransactionType) && t.Status == constants.TransactionStatusCompleted:
+		return t.Amount - t.Clawback
+	case slices.Contains(constants.WalletDebitTransactionTypes, t.TransactionType) &&
+		(t.Status == constants.TransactionStatusPending || t.Status == constants.TransactionStatusCompleted):
+		return -(t.Amount + t.Fee)
+	default:
+		return 0
+	}
+}
+
+// TransferDomain menyatakan perpindahan dana dari wallet pengirim ke wallet penerima.
+// Penerima bisa ditentukan lewat salah satu dari user id, username, atau email,
+// atau lewat RecipientWalletId untuk memindahkan dana antar pocket milik sendiri.
+type TransferDomain struct {
+	SenderUserId      string
+	SenderWalletId    string // kosong berarti wallet default pengirim
+	RecipientWalletId string // pocket tujuan milik pengirim sendiri
+	RecipientUserId   string
+	RecipientUsername string
+	RecipientEmail    string
+	Amount            money.Money
+	Description       *string                // disimpan di kedua sisi transfer
+	ExternalReference *string                // hanya disimpan di transfer_out milik pengirim
+	Metadata          map[string]interface{} // hanya disimpan di transfer_out milik pengirim
+	Outgoing          TransactionDomain      // transaksi transfer_out di wallet pengirim
+	Incoming          TransactionDomain      // transaksi transfer_in di wallet penerima
+}
+
+// TransactionFilterDomain berisi filter dan posisi halaman untuk daftar transaksi.
+// Field pointer bernilai nil berarti filter tersebut tidak dipakai.
+type TransactionFilterDomain struct {
+	UserId          string // kosong berarti semua user (khusus admin)
 	TransactionType string
-	// Status          string
-	// Description     string
+	From            *time.Time
+	To              *time.Time
+	MinAmount       *money.Money
+	MaxAmount       *money.Money
+	ProductId       *int
+	Reference       string // external reference persis, kosong berarti tidak difilter
+	Sort            string // asc atau desc berdasarkan created_at
+	Limit           int
+	Cursor          *TransactionCursor // nil untuk halaman pertama
+}
+
+// TransactionCursor menandai transaksi terakhir pada halaman sebelumnya.
+// Pasangan created_at dan id dipakai agar urutan tetap stabil walau created_at sama.
+type TransactionCursor struct {
 	CreatedAt time.Time
-	UpdatedAt time.Time
+	Id        string
+}
+
+type TransactionPageDomain struct {
+	Transactions []TransactionDomain
+	NextCursor   *TransactionCursor // nil jika tidak ada halaman berikutnya
+}
+
+// Encode mengubah cursor menjadi string opaque yang aman dipakai di query string
+func (c TransactionCursor) Encode() string {
+	raw := c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + c.Id
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeTransactionCursor membaca kembali cursor hasil Encode
+func DecodeTransactionCursor(encoded string) (TransactionCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return TransactionCursor{}, err
+	}
+
+	createdAt, id, found := strings.Cut(string(raw), "|")
+	if !found || id == "" {
+		return TransactionCursor{}, errors.New("malformed cursor")
+	}
+
+	parsedCreatedAt, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return TransactionCursor{}, err
+	}
+
+	return TransactionCursor{CreatedAt: parsedCreatedAt, Id: id}, nil
 }
 
 type TransactionUsecase interface {
-	GetAll(ctx context.Context) (domains []TransactionDomain, statusCode int, err error)
+	GetAll(ctx context.Context, filter TransactionFilterDomain) (page TransactionPageDomain, statusCode int, err error)
 	Deposit(ctx context.Context, transactionDom *TransactionDomain) (domain TransactionDomain, statusCode int, err error)
 	Withdraw(ctx context.Context, transactionDom *TransactionDomain) (domain TransactionDomain, statusCode int, err error)
 	Purchase(ctx context.Context, transactionData *TransactionDomain) (domain TransactionDomain, statusCode int, err error)
-	History(ctx context.Context, userId string) (domains []TransactionDomain, statusCode int, err error)
+	Transfer(ctx context.Context, transferDom *TransferDomain) (domain TransferDomain, statusCode int, err error)
+	UpdateStatus(ctx context.Context, transactionId string, status string, reason string) (domain TransactionDomain, statusCode int, err error)
+	Refund(ctx context.Context, transactionId string, quantity int) (domain TransactionDomain, statusCode int, err error)
+	History(ctx context.Context, filter TransactionFilterDomain) (page TransactionPageDomain, statusCode int, err error)
+	GetById(ctx context.Context, transactionId string, userId string, isAdmin bool) (domain TransactionDomain, statusCode int, err error)
+	Statement(ctx context.Context, statementDom *StatementDomain, writer StatementWriter) (statusCode int, err error)
 }
 
 type TransactionRepository interface {
-	GetAll(ctx context.Context) ([]TransactionDomain, error)
-	GetByUserId(ctx context.Context, userId string) ([]TransactionDomain, error)
+	GetAll(ctx context.Context, filter TransactionFilterDomain) (TransactionPageDomain, error)
+	GetByUserId(ctx context.Context, userId string, filter TransactionFilterDomain) (TransactionPageDomain, error)
+	GetById(ctx context.Context, transactionId string, userId string) (TransactionDomain, error)
+	// StreamStatement memanggil onOpening sekali dengan saldo awal, lalu onEntry untuk setiap mutasi secara berurutan
+	StreamStatement(ctx context.Context, statementDom StatementDomain, onOpening func(StatementDomain) error, onEntry func(StatementEntryDomain) error) error
 	Deposit(ctx context.Context, transactionDom TransactionDomain) (TransactionDomain, error)
 	Withdraw(ctx context.Context, transactionDom TransactionDomain) (TransactionDomain, error)
 	Purchase(ctx context.Context, trasanctionDom TransactionDomain) (TransactionDomain, error)
+	Transfer(ctx context.Context, transferDom TransferDomain) (TransferDomain, error)
+	UpdateStatus(ctx context.Context, transactionId string, status string, reason string) (TransactionDomain, error)
+	Refund(ctx context.Context, transactionId string, quantity int) (TransactionDomain, error)
 }
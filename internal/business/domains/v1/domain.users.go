@@ -13,6 +13,7 @@ type UserDomain struct {
 	Active    bool
 	Token     string
 	RoleID    int
+	Timezone  string
 	CreatedAt time.Time
 	UpdatedAt *time.Time
 	DeletedAt *time.Time
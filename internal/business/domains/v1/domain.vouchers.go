@@ -0,0 +1,73 @@
+package v1
+
+import (
+	"context"
+	"time"
+
+	"github.com/snykk/transaction-api/internal/constants"
+	"github.com/snykk/transaction-api/pkg/money"
+)
+
+// VoucherDomain adalah kode promo untuk pembelian. Field yang dipakai bergantung pada DiscountType:
+// Amount untuk fixed, RateBps dan MaxDiscount opsional untuk percentage.
+type VoucherDomain struct {
+	Id                    int
+	Code                  string
+	DiscountType          string
+	Amount                *money.Money
+	RateBps               *int
+	MaxDiscount           *money.Money
+	MinSpend              money.Money // dibandingkan dengan nominal produk yang memenuhi syarat
+	ProductId             *int        // nil berarti berlaku untuk semua produk
+	StartsAt              time.Time
+	EndsAt                *time.Time
+	MaxRedemptions        *int // nil berarti tanpa batas
+	MaxRedemptionsPerUser *int
+	RedemptionCount       int
+	IsActive              bool
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
+}
+
+// Discount menghitung diskon untuk nominal yang memenuhi syarat, tidak pernah melebihi nominal tersebut
+func (v VoucherDomain) Discount(eligible money.Money) money.Money {
+	var discount money.Money
+	switch v.DiscountType {
+	case constants.VoucherDiscountFixed:
+		if v.Amount != nil {
+			discount = *v.Amount
+		}
+	case constants.VoucherDiscountPercentage:
+		if v.RateBps != nil {
+			discount = eligible.MulDiv(int64(*v.RateBps), constants.FeeBasisPointsPerUnit)
+		}
+		if v.MaxDiscount != nil && discount > *v.MaxDiscount {
+			discount = *v.MaxDiscount
+		}
+	}
+
+	if discount > eligible {
+		discount = eligible
+	}
+
+	return discount
+}
+
+type VoucherUsecase interface {
+	GetAll(ctx context.Context) (outDoms []VoucherDomain, statusCode int, err error)
+	GetById(ctx context.Context, id int) (outDom VoucherDomain, statusCode int, err error)
+	Store(ctx context.Context, voucherDom *VoucherDomain) (outDom VoucherDomain, statusCode int, err error)
+	Update(ctx context.Context, id int, voucherDom *VoucherDomain) (outDom VoucherDomain, statusCode int, err error)
+	Delete(ctx context.Context, id int) (statusCode int, err error)
+}
+
+type VoucherRepository interface {
+	GetAll(ctx context.Context) ([]VoucherDomain, error)
+	GetById(ctx context.Context, id int) (VoucherDomain, error)
+	// Store gagal dengan ErrVoucherCodeTaken jika kode sudah dipakai voucher lain
+	Store(ctx context.Context, voucherDom VoucherDomain) (VoucherDomain, error)
+	// Update tidak mengubah redemption_count, gagal dengan ErrVoucherCapBelowRedemptions
+	// jika max_redemptions lebih kecil dari pemakaian yang sudah terjadi
+	Update(ctx context.Context, voucherDom VoucherDomain) (VoucherDomain, error)
+	Delete(ctx context.Context, id int) error
+}
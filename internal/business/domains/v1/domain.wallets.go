@@ -3,25 +3,80 @@ package v1
 import (
 	"context"
 	"time"
+
+	"github.com/snykk/transaction-api/internal/constants"
+	"github.com/snykk/transaction-api/pkg/money"
 )
 
 type WalletDomain struct {
-	Id        string
-	UserId    string
-	Balance   float64
-	User      UserDomain
-	CreatedAt time.Time
-	UpdatedAt *time.Time
+	Id               string
+	UserId           string
+	Name             string      // nama pocket, unik per user
+	IsDefault        bool        // wallet yang dipakai jika request tidak menyertakan wallet_id
+	Balance          money.Money // saldo ledger
+	AvailableBalance money.Money // saldo dikurangi dana yang ditahan hold aktif
+	PointsBalance    money.Money // poin loyalitas yang belum dipakai atau kedaluwarsa
+	Status           string      // active atau salah satu status frozen
+	User             UserDomain
+	CreatedAt        time.Time
+	UpdatedAt        *time.Time
+}
+
+// WalletStatusEventDomain adalah jejak audit freeze dan unfreeze wallet oleh admin
+type WalletStatusEventDomain struct {
+	Id             string
+	WalletId       string
+	Action         string // freeze atau unfreeze
+	PreviousStatus string
+	Status         string
+	Reason         string
+	ActorId        *string // admin yang melakukan perubahan, nil jika akunnya sudah dihapus
+	CreatedAt      time.Time
+}
+
+// walletFreezeStatuses memetakan arah freeze ke status wallet yang dihasilkan
+var walletFreezeStatuses = map[string]string{
+	constants.WalletFreezeOutgoing: constants.WalletStatusFrozenOutgoing,
+	constants.WalletFreezeIncoming: constants.WalletStatusFrozenIncoming,
+	constants.WalletFreezeBoth:     constants.WalletStatusFrozen,
+}
+
+// WalletStatusForFreeze mengembalikan status wallet untuk arah freeze, false jika arah tidak dikenal
+func WalletStatusForFreeze(direction string) (string, bool) {
+	status, ok := walletFreezeStatuses[direction]
+	return status, ok
 }
 
 type WalletUsecase interface {
 	GetAllWallets(ctx context.Context) (domains []WalletDomain, statusCode int, err error)
+	// Init membuat wallet default user, gagal dengan 409 jika user sudah memiliki wallet
 	Init(ctx context.Context, userId string) (domain WalletDomain, statusCode int, err error)
+	// GetWalletByUserId mengembalikan wallet default user
 	GetWalletByUserId(ctx context.Context, userId string) (domain WalletDomain, statusCode int, err error)
+	Create(ctx context.Context, userId string, name string) (domain WalletDomain, statusCode int, err error)
+	GetWalletsByUserId(ctx context.Context, userId string) (domains []WalletDomain, statusCode int, err error)
+	SetDefault(ctx context.Context, userId string, walletId string) (domain WalletDomain, statusCode int, err error)
+	Freeze(ctx context.Context, walletId string, direction string, reason string, actorId string) (domain WalletDomain, statusCode int, err error)
+	Unfreeze(ctx context.Context, walletId string, reason string, actorId string) (domain WalletDomain, statusCode int, err error)
+	GetStatusEvents(ctx context.Context, walletId string) (domains []WalletStatusEventDomain, statusCode int, err error)
 }
 
 type WalletRepository interface {
 	GetAllWallets(ctx context.Context) ([]WalletDomain, error)
+	// CreateWalletByUserId membuat wallet default bernama constants.WalletDefaultName
 	CreateWalletByUserId(ctx context.Context, userId string) (WalletDomain, error)
+	// CreateWallet membuat pocket baru, pocket pertama user otomatis menjadi default.
+	// Gagal dengan ErrWalletNameTaken jika nama sudah dipakai user yang sama.
+	CreateWallet(ctx context.Context, userId string, name string) (WalletDomain, error)
+	// GetWalletByUserId mengembalikan wallet default user
 	GetWalletByUserId(ctx context.Context, userId string) (WalletDomain, error)
+	// GetWalletsByUserId mengembalikan semua wallet user, wallet default lebih dulu
+	GetWalletsByUserId(ctx context.Context, userId string) ([]WalletDomain, error)
+	// SetDefault memindahkan tanda default ke wallet milik user, gagal dengan ErrWalletNotFound jika bukan miliknya
+	SetDefault(ctx context.Context, userId string, walletId string) (WalletDomain, error)
+	GetLedgerBalance(ctx context.Context, walletId string) (money.Money, error)
+	// UpdateStatus mengubah status wallet dan mencatat jejak auditnya dalam satu transaksi database,
+	// gagal dengan ErrWalletStatusUnchanged jika wallet sudah berada pada status tersebut
+	UpdateStatus(ctx context.Context, eventDom WalletStatusEventDomain) (WalletDomain, error)
+	GetStatusEvents(ctx context.Context, walletId string) ([]WalletStatusEventDomain, error)
 }
@@ -0,0 +1,88 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// WebhookEndpointDomain adalah URL milik user yang menerima event transaksi user tersebut.
+// Secret hanya ditampilkan saat endpoint dibuat atau secret diganti.
+type WebhookEndpointDomain struct {
+	Id         string
+	UserId     string
+	URL        string
+	EventTypes []string
+	Secret     string
+	IsActive   bool
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// WebhookDeliveryDomain adalah pengiriman satu event ke satu endpoint. Pengiriman yang gagal dicoba ulang
+// dengan backoff sampai berhasil, atau menjadi dead setelah percobaan maksimum habis.
+type WebhookDeliveryDomain struct {
+	Id             string
+	WebhookId      string
+	EventId        *string // event outbox asal, nil untuk pengiriman uji
+	EventType      string
+	Payload        json.RawMessage
+	Status         string
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastStatusCode *int
+	LastError      *string
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+
+	Webhook    WebhookEndpointDomain  // endpoint tujuan, diisi saat pengiriman akan dikirim
+	AttemptLog []WebhookAttemptDomain // hanya diisi saat detail pengiriman diambil
+}
+
+// WebhookAttemptDomain adalah log satu percobaan kirim, StatusCode nil berarti receiver tidak bisa dihubungi
+type WebhookAttemptDomain struct {
+	Id          string
+	DeliveryId  string
+	Attempt     int
+	StatusCode  *int
+	Error       *string
+	DurationMs  int
+	AttemptedAt time.Time
+}
+
+type WebhookUsecase interface {
+	Store(ctx context.Context, webhookDom *WebhookEndpointDomain) (WebhookEndpointDomain, int, error)
+	GetAll(ctx context.Context, userId string) ([]WebhookEndpointDomain, int, error)
+	GetById(ctx context.Context, userId string, webhookId string) (WebhookEndpointDomain, int, error)
+	Update(ctx context.Context, userId string, webhookId string, webhookDom *WebhookEndpointDomain) (WebhookEndpointDomain, int, error)
+	Delete(ctx context.Context, userId string, webhookId string) (int, error)
+	GetDeliveries(ctx context.Context, userId string, webhookId string) ([]WebhookDeliveryDomain, int, error)
+	GetDeliveryById(ctx context.Context, userId string, webhookId string, deliveryId string) (WebhookDeliveryDomain, int, error)
+	// Test mengirim event webhook.test langsung ke endpoint tanpa percobaan ulang
+	Test(ctx context.Context, userId string, webhookId string) (WebhookDeliveryDomain, int, error)
+	// Redeliver langsung mengirim ulang pengiriman apa pun statusnya
+	Redeliver(ctx context.Context, userId string, webhookId string, deliveryId string) (WebhookDeliveryDomain, int, error)
+	// Enqueue membuat pengiriman untuk setiap endpoint yang melanggan event, dipanggil oleh subscriber outbox
+	Enqueue(ctx context.Context, event EventDomain) (queued int, err error)
+	// DispatchDue mengirim satu batch pengiriman pending yang sudah waktunya
+	DispatchDue(ctx context.Context, now time.Time) (delivered int, failed int, err error)
+}
+
+type WebhookRepository interface {
+	Store(ctx context.Context, webhookDom WebhookEndpointDomain) (WebhookEndpointDomain, error)
+	GetAll(ctx context.Context, userId string) ([]WebhookEndpointDomain, error)
+	GetById(ctx context.Context, userId string, webhookId string) (WebhookEndpointDomain, error)
+	// Update mengganti secret hanya jika webhookDom.Secret tidak kosong
+	Update(ctx context.Context, webhookDom WebhookEndpointDomain) (WebhookEndpointDomain, error)
+	Delete(ctx context.Context, userId string, webhookId string) error
+	// Enqueue idempoten terhadap event.Id sehingga event outbox yang terkirim ulang tidak menggandakan pengiriman
+	Enqueue(ctx context.Context, event EventDomain, userId string, now time.Time) (int, error)
+	StoreDelivery(ctx context.Context, deliveryDom WebhookDeliveryDomain) (WebhookDeliveryDomain, error)
+	GetDeliveries(ctx context.Context, userId string, webhookId string, limit int) ([]WebhookDeliveryDomain, error)
+	GetDeliveryById(ctx context.Context, userId string, webhookId string, deliveryId string) (WebhookDeliveryDomain, error)
+	// ClaimDue menahan pengiriman selama lease agar tidak dikirim dispatcher lain secara bersamaan
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]WebhookDeliveryDomain, error)
+	// RecordAttempt mencatat log percobaan dan menyimpan status baru pengiriman
+	RecordAttempt(ctx context.Context, deliveryDom WebhookDeliveryDomain, attemptDom WebhookAttemptDomain) (WebhookDeliveryDomain, error)
+}
@@ -5,4 +5,59 @@ import "errors"
 var (
 	ErrAmountMustGreateThanZero    = errors.New("amount must be greater than zero")
 	ErrQuantityMustGreaterThanZero = errors.New("quantity must be greater than zero")
+	ErrRecipientRequired           = errors.New("recipient user_id, username or email is required")
+	ErrRecipientAmbiguous          = errors.New("to_wallet_id cannot be combined with a recipient user")
+	ErrFailureReasonRequired       = errors.New("failure_reason is required when status is failed")
+	ErrInvalidDateRange            = errors.New("from must not be after to")
+	ErrInvalidAmountRange          = errors.New("min_amount must not be greater than max_amount")
+	ErrScheduleStartInPast         = errors.New("start_at must be in the future")
+	ErrScheduleEndBeforeStart      = errors.New("end_at must not be before start_at")
+	ErrScheduleLimitForOnce        = errors.New("end_at and max_runs can only be set for recurring schedules")
+	ErrScheduleMaxRunsReached      = errors.New("max_runs must be greater than the number of runs already processed")
+	ErrScheduleNoRemainingRuns     = errors.New("schedule would have no remaining runs")
+	ErrScheduleNotModifiable       = errors.New("only active or paused schedules can be changed")
+	ErrLimitMustNotBeNegative      = errors.New("limits must not be negative")
+	ErrFeeTransactionTypeInvalid   = errors.New("fees can only be configured for withdraw or purchase transactions")
+	ErrFeeTypeInvalid              = errors.New("fee_type must be flat, percentage or tiered")
+	ErrFeeProductOnlyForPurchase   = errors.New("product_id can only be set for purchase fee rules")
+	ErrFeeFlatAmountRequired       = errors.New("flat_amount is required for flat fee rules")
+	ErrFeeRateRequired             = errors.New("rate_bps is required for percentage fee rules")
+	ErrFeeTiersRequired            = errors.New("tiers are required for tiered fee rules")
+	ErrFeeTiersNotAscending        = errors.New("tiers must be ordered by ascending up_to and only the last tier may omit up_to")
+	ErrFeeMustNotBeNegative        = errors.New("fee amounts and rates must not be negative")
+	ErrFeeMinGreaterThanMax        = errors.New("min_fee must not be greater than max_fee")
+	ErrHoldAmountRequired          = errors.New("amount is required for withdraw holds")
+	ErrHoldProductRequired         = errors.New("product_id and quantity are required for purchase holds")
+	ErrHoldExpiryOutOfRange        = errors.New("expires_in exceeds the maximum hold duration")
+	ErrHoldCaptureInvalid          = errors.New("withdraw holds are captured by amount and purchase holds by quantity")
+	ErrHoldCaptureExceeded         = errors.New("capture exceeds the held amount")
+	ErrFreezeDirectionInvalid      = errors.New("direction must be outgoing, incoming or both")
+	ErrWalletStatusReasonRequired  = errors.New("reason is required to freeze or unfreeze a wallet")
+	ErrWalletNameRequired          = errors.New("wallet name is required")
+	ErrMetadataTooLarge            = errors.New("metadata must not exceed 4096 bytes when encoded as JSON")
+	ErrMetadataTooManyKeys         = errors.New("metadata must not have more than 50 top-level keys")
+	ErrOrderItemsRequired          = errors.New("order must contain at least one item")
+	ErrOrderTooManyItems           = errors.New("order must not contain more than 50 items")
+	ErrOrderDuplicateProduct       = errors.New("each product can only appear once in an order")
+	ErrVoucherCodeInvalid          = errors.New("voucher code must be 3 to 32 letters, digits, dashes or underscores")
+	ErrVoucherTypeInvalid          = errors.New("discount_type must be percentage or fixed")
+	ErrVoucherAmountRequired       = errors.New("amount greater than 0 is required for fixed vouchers")
+	ErrVoucherRateRequired         = errors.New("rate_bps between 1 and 10000 is required for percentage vouchers")
+	ErrVoucherMaxDiscountInvalid   = errors.New("max_discount must be greater than 0 and only applies to percentage vouchers")
+	ErrVoucherMinSpendNegative     = errors.New("min_spend must not be negative")
+	ErrVoucherWindowInvalid        = errors.New("ends_at must be after starts_at")
+	ErrVoucherCapInvalid           = errors.New("redemption limits must be greater than 0")
+	ErrRewardTypeInvalid           = errors.New("reward_type must be points or cashback")
+	ErrRewardScopeAmbiguous        = errors.New("reward rules can target a product or a category, not both")
+	ErrRewardRateInvalid           = errors.New("rate_bps must be between 1 and 10000")
+	ErrRewardMonthlyCapInvalid     = errors.New("monthly_cap must be greater than 0")
+	ErrRewardExpiryRequired        = errors.New("points_expiry_days greater than 0 is required for points rules and not allowed for cashback rules")
+	ErrPointsMustBePositive        = errors.New("points must be greater than 0")
+	ErrPointsMustNotBeNegative     = errors.New("redeem_points must not be negative")
+	ErrWebhookURLInvalid           = errors.New("url must be an absolute http or https URL")
+	ErrWebhookURLNotAllowed        = errors.New("url must not point to a loopback, private, link-local, or unspecified address")
+	ErrWebhookURLUnresolvable      = errors.New("url host could not be resolved")
+	ErrWebhookEventTypesRequired   = errors.New("at least one event type is required")
+	ErrWebhookEventTypeInvalid     = errors.New("event_types contains an unsupported event type")
+	ErrWebhookSecretTooShort       = errors.New("secret must be at least 16 characters")
 )
@@ -0,0 +1,150 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+	"github.com/snykk/transaction-api/internal/utils"
+	"github.com/snykk/transaction-api/pkg/money"
+)
+
+type feeRuleUsecase struct {
+	repo V1Domains.FeeRuleRepository
+}
+
+func NewFeeRuleUsecase(repo V1Domains.FeeRuleRepository) V1Domains.FeeRuleUsecase {
+	return &feeRuleUsecase{
+		repo: repo,
+	}
+}
+
+func (uc *feeRuleUsecase) GetAll(ctx context.Context) (outDoms []V1Domains.FeeRuleDomain, statusCode int, err error) {
+	outDoms, err = uc.repo.GetAll(ctx)
+	if err != nil {
+		statusCode, _ = utils.MapDBError(err)
+		return nil, statusCode, err
+	}
+
+	return outDoms, http.StatusOK, nil
+}
+
+func (uc *feeRuleUsecase) GetById(ctx context.Context, id int) (outDom V1Domains.FeeRuleDomain, statusCode int, err error) {
+	outDom, err = uc.repo.GetById(ctx, id)
+	if err != nil {
+		statusCode, _ = utils.MapDBError(err)
+		return V1Domains.FeeRuleDomain{}, statusCode, err
+	}
+
+	return outDom, http.StatusOK, nil
+}
+
+func (uc *feeRuleUsecase) Store(ctx context.Context, feeRuleDom *V1Domains.FeeRuleDomain) (outDom V1Domains.FeeRuleDomain, statusCode int, err error) {
+	if err = validateFeeRule(feeRuleDom); err != nil {
+		return V1Domains.FeeRuleDomain{}, http.StatusBadRequest, err
+	}
+
+	outDom, err = uc.repo.Store(ctx, *feeRuleDom)
+	if err != nil {
+		statusCode, _ = utils.MapDBError(err)
+		return V1Domains.FeeRuleDomain{}, statusCode, err
+	}
+
+	return outDom, http.StatusCreated, nil
+}
+
+func (uc *feeRuleUsecase) Update(ctx context.Context, id int, feeRuleDom *V1Domains.FeeRuleDomain) (outDom V1Domains.FeeRuleDomain, statusCode int, err error) {
+	if err = validateFeeRule(feeRuleDom); err != nil {
+		return V1Domains.FeeRuleDomain{}, http.StatusBadRequest, err
+	}
+
+	feeRuleDom.Id = id
+	outDom, err = uc.repo.Update(ctx, *feeRuleDom)
+	if err != nil {
+		statusCode, _ = utils.MapDBError(err)
+		return V1Domains.FeeRuleDomain{}, statusCode, err
+	}
+
+	return outDom, http.StatusOK, nil
+}
+
+func (uc *feeRuleUsecase) Delete(ctx context.Context, id int) (statusCode int, err error) {
+	err = uc.repo.Delete(ctx, id)
+	if err != nil {
+		statusCode, _ = utils.MapDBError(err)
+		return statusCode, err
+	}
+
+	return http.StatusOK, nil
+}
+
+// validateFeeRule memeriksa konfigurasi aturan dan mengosongkan field yang tidak dipakai oleh fee_type-nya
+func validateFeeRule(feeRuleDom *V1Domains.FeeRuleDomain) error {
+	switch feeRuleDom.TransactionType {
+	case constants.TransactionTypeWithdraw:
+		if feeRuleDom.ProductId != nil {
+			return ErrFeeProductOnlyForPurchase
+		}
+	case constants.TransactionTypePurchase:
+	default:
+		return ErrFeeTransactionTypeInvalid
+	}
+
+	for _, amount := range []*money.Money{feeRuleDom.FlatAmount, feeRuleDom.MinFee, feeRuleDom.MaxFee} {
+		if amount != nil && amount.IsNegative() {
+			return ErrFeeMustNotBeNegative
+		}
+	}
+	if feeRuleDom.RateBps != nil && *feeRuleDom.RateBps < 0 {
+		return ErrFeeMustNotBeNegative
+	}
+	if feeRuleDom.MinFee != nil && feeRuleDom.MaxFee != nil && *feeRuleDom.MinFee > *feeRuleDom.MaxFee {
+		return ErrFeeMinGreaterThanMax
+	}
+
+	switch feeRuleDom.FeeType {
+	case constants.FeeTypeFlat:
+		if feeRuleDom.FlatAmount == nil {
+			return ErrFeeFlatAmountRequired
+		}
+		feeRuleDom.RateBps, feeRuleDom.Tiers = nil, nil
+	case constants.FeeTypePercentage:
+		if feeRuleDom.RateBps == nil {
+			return ErrFeeRateRequired
+		}
+		feeRuleDom.FlatAmount, feeRuleDom.Tiers = nil, nil
+	case constants.FeeTypeTiered:
+		if len(feeRuleDom.Tiers) == 0 {
+			return ErrFeeTiersRequired
+		}
+		if err := validateFeeTiers(feeRuleDom.Tiers); err != nil {
+			return err
+		}
+		feeRuleDom.FlatAmount, feeRuleDom.RateBps = nil, nil
+	default:
+		return ErrFeeTypeInvalid
+	}
+
+	return nil
+}
+
+// validateFeeTiers memastikan batas tier naik berurutan sehingga setiap amount masuk tepat ke satu tier
+func validateFeeTiers(tiers []V1Domains.FeeTierDomain) error {
+	for i, tier := range tiers {
+		if tier.FlatAmount.IsNegative() || tier.RateBps < 0 {
+			return ErrFeeMustNotBeNegative
+		}
+		if tier.UpTo == nil {
+			if i != len(tiers)-1 {
+				return ErrFeeTiersNotAscending
+			}
+			continue
+		}
+		if i > 0 && *tier.UpTo <= *tiers[i-1].UpTo {
+			return ErrFeeTiersNotAscending
+		}
+	}
+
+	return nil
+}
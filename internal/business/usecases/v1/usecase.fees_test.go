@@ -0,0 +1,190 @@
+package v1_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	V1Usecases "github.com/snykk/transaction-api/internal/business/usecases/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+	PostgresRepo "github.com/snykk/transaction-api/internal/datasources/repositories/postgres/v1"
+	"github.com/snykk/transaction-api/internal/mocks"
+	"github.com/snykk/transaction-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	feeRuleRepoMock *mocks.FeeRuleRepository
+	feeRuleUsecase  V1Domains.FeeRuleUsecase
+)
+
+func setupFeeRule(t *testing.T) {
+	feeRuleRepoMock = mocks.NewFeeRuleRepository(t)
+	feeRuleUsecase = V1Usecases.NewFeeRuleUsecase(feeRuleRepoMock)
+}
+
+func TestStoreFeeRule(t *testing.T) {
+	setupFeeRule(t)
+
+	t.Run("When Success Store Tiered Fee Rule", func(t *testing.T) {
+		upTo := money.FromMajor(100000)
+		rateBps := 25
+		feeRuleDom := V1Domains.FeeRuleDomain{
+			Name:            "withdraw tiered",
+			TransactionType: constants.TransactionTypeWithdraw,
+			FeeType:         constants.FeeTypeTiered,
+			RateBps:         &rateBps, // tidak dipakai oleh tiered, dikosongkan sebelum disimpan
+			Tiers: []V1Domains.FeeTierDomain{
+				{UpTo: &upTo, FlatAmount: money.FromMajor(2500)},
+				{RateBps: 10},
+			},
+			IsActive: true,
+		}
+
+		feeRuleRepoMock.Mock.On("Store", mock.Anything, mock.MatchedBy(func(f V1Domains.FeeRuleDomain) bool {
+			return f.RateBps == nil && len(f.Tiers) == 2
+		})).Return(V1Domains.FeeRuleDomain{Id: 1, Name: feeRuleDom.Name}, nil).Once()
+
+		result, statusCode, err := feeRuleUsecase.Store(context.Background(), &feeRuleDom)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, statusCode)
+		assert.Equal(t, 1, result.Id)
+	})
+
+	t.Run("When Failure", func(t *testing.T) {
+		productId := 1
+		rateBps := 100
+		minFee := money.FromMajor(10)
+		maxFee := money.FromMajor(5)
+		upTo1 := money.FromMajor(1000)
+		upTo2 := money.FromMajor(500)
+
+		testCases := []struct {
+			name       string
+			feeRuleDom V1Domains.FeeRuleDomain
+			err        error
+		}{
+			{
+				name:       "Product Rule For Withdraw",
+				feeRuleDom: V1Domains.FeeRuleDomain{TransactionType: constants.TransactionTypeWithdraw, ProductId: &productId, FeeType: constants.FeeTypePercentage, RateBps: &rateBps},
+				err:        V1Usecases.ErrFeeProductOnlyForPurchase,
+			},
+			{
+				name:       "Transaction Type Without Fee",
+				feeRuleDom: V1Domains.FeeRuleDomain{TransactionType: constants.TransactionTypeDeposit, FeeType: constants.FeeTypePercentage, RateBps: &rateBps},
+				err:        V1Usecases.ErrFeeTransactionTypeInvalid,
+			},
+			{
+				name:       "Flat Without Amount",
+				feeRuleDom: V1Domains.FeeRuleDomain{TransactionType: constants.TransactionTypePurchase, FeeType: constants.FeeTypeFlat},
+				err:        V1Usecases.ErrFeeFlatAmountRequired,
+			},
+			{
+				name:       "Percentage Without Rate",
+				feeRuleDom: V1Domains.FeeRuleDomain{TransactionType: constants.TransactionTypePurchase, FeeType: constants.FeeTypePercentage},
+				err:        V1Usecases.ErrFeeRateRequired,
+			},
+			{
+				name:       "Tiered Without Tiers",
+				feeRuleDom: V1Domains.FeeRuleDomain{TransactionType: constants.TransactionTypePurchase, FeeType: constants.FeeTypeTiered},
+				err:        V1Usecases.ErrFeeTiersRequired,
+			},
+			{
+				name: "Tiers Not Ascending",
+				feeRuleDom: V1Domains.FeeRuleDomain{TransactionType: constants.TransactionTypePurchase, FeeType: constants.FeeTypeTiered, Tiers: []V1Domains.FeeTierDomain{
+					{UpTo: &upTo1, RateBps: 100},
+					{UpTo: &upTo2, RateBps: 50},
+				}},
+				err: V1Usecases.ErrFeeTiersNotAscending,
+			},
+			{
+				name: "Open Tier Not Last",
+				feeRuleDom: V1Domains.FeeRuleDomain{TransactionType: constants.TransactionTypePurchase, FeeType: constants.FeeTypeTiered, Tiers: []V1Domains.FeeTierDomain{
+					{RateBps: 100},
+					{UpTo: &upTo1, RateBps: 50},
+				}},
+				err: V1Usecases.ErrFeeTiersNotAscending,
+			},
+			{
+				name:       "Min Greater Than Max",
+				feeRuleDom: V1Domains.FeeRuleDomain{TransactionType: constants.TransactionTypePurchase, FeeType: constants.FeeTypePercentage, RateBps: &rateBps, MinFee: &minFee, MaxFee: &maxFee},
+				err:        V1Usecases.ErrFeeMinGreaterThanMax,
+			},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, statusCode, err := feeRuleUsecase.Store(context.Background(), &tc.feeRuleDom)
+
+				assert.ErrorIs(t, err, tc.err)
+				assert.Equal(t, http.StatusBadRequest, statusCode)
+			})
+		}
+	})
+
+	t.Run("When Product Not Found", func(t *testing.T) {
+		productId := 999
+		flatAmount := money.FromMajor(1)
+		feeRuleDom := V1Domains.FeeRuleDomain{TransactionType: constants.TransactionTypePurchase, ProductId: &productId, FeeType: constants.FeeTypeFlat, FlatAmount: &flatAmount}
+
+		feeRuleRepoMock.Mock.On("Store", mock.Anything, mock.Anything).Return(V1Domains.FeeRuleDomain{}, PostgresRepo.ErrProductNotFound).Once()
+
+		_, statusCode, err := feeRuleUsecase.Store(context.Background(), &feeRuleDom)
+
+		assert.ErrorIs(t, err, PostgresRepo.ErrProductNotFound)
+		assert.Equal(t, http.StatusNotFound, statusCode)
+	})
+}
+
+func TestUpdateFeeRule(t *testing.T) {
+	setupFeeRule(t)
+
+	flatAmount := money.FromMajor(2)
+	feeRuleDom := V1Domains.FeeRuleDomain{Name: "purchase fee", TransactionType: constants.TransactionTypePurchase, FeeType: constants.FeeTypeFlat, FlatAmount: &flatAmount}
+
+	t.Run("When Success Update Fee Rule", func(t *testing.T) {
+		feeRuleRepoMock.Mock.On("Update", mock.Anything, mock.MatchedBy(func(f V1Domains.FeeRuleDomain) bool {
+			return f.Id == 7
+		})).Return(V1Domains.FeeRuleDomain{Id: 7, FlatAmount: &flatAmount}, nil).Once()
+
+		result, statusCode, err := feeRuleUsecase.Update(context.Background(), 7, &feeRuleDom)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, 7, result.Id)
+	})
+
+	t.Run("When Fee Rule Not Found", func(t *testing.T) {
+		feeRuleRepoMock.Mock.On("Update", mock.Anything, mock.Anything).Return(V1Domains.FeeRuleDomain{}, PostgresRepo.ErrFeeRuleNotFound).Once()
+
+		_, statusCode, err := feeRuleUsecase.Update(context.Background(), 8, &feeRuleDom)
+
+		assert.ErrorIs(t, err, PostgresRepo.ErrFeeRuleNotFound)
+		assert.Equal(t, http.StatusNotFound, statusCode)
+	})
+}
+
+func TestDeleteFeeRule(t *testing.T) {
+	setupFeeRule(t)
+
+	t.Run("When Success Delete Fee Rule", func(t *testing.T) {
+		feeRuleRepoMock.Mock.On("Delete", mock.Anything, 7).Return(nil).Once()
+
+		statusCode, err := feeRuleUsecase.Delete(context.Background(), 7)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+	})
+
+	t.Run("When Fee Rule Not Found", func(t *testing.T) {
+		feeRuleRepoMock.Mock.On("Delete", mock.Anything, 8).Return(PostgresRepo.ErrFeeRuleNotFound).Once()
+
+		statusCode, err := feeRuleUsecase.Delete(context.Background(), 8)
+
+		assert.ErrorIs(t, err, PostgresRepo.ErrFeeRuleNotFound)
+		assert.Equal(t, http.StatusNotFound, statusCode)
+	})
+}
@@ -0,0 +1,169 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+	V1PostgresRepository "github.com/snykk/transaction-api/internal/datasources/repositories/postgres/v1"
+	"github.com/snykk/transaction-api/internal/utils"
+)
+
+type holdUsecase struct {
+	repo        V1Domains.HoldRepository
+	feeRepo     V1Domains.FeeRuleRepository
+	productRepo V1Domains.ProductRepository
+	defaultTTL  time.Duration // masa berlaku hold jika expires_in tidak dikirim
+	maxTTL      time.Duration
+}
+
+func NewHoldUsecase(repo V1Domains.HoldRepository, feeRepo V1Domains.FeeRuleRepository, productRepo V1Domains.ProductRepository, defaultTTL time.Duration, maxTTL time.Duration) V1Domains.HoldUsecase {
+	return &holdUsecase{
+		repo:        repo,
+		feeRepo:     feeRepo,
+		productRepo: productRepo,
+		defaultTTL:  defaultTTL,
+		maxTTL:      maxTTL,
+	}
+}
+
+func (uc *holdUsecase) Store(ctx context.Context, holdDom *V1Domains.HoldDomain, expiresIn time.Duration) (outDom V1Domains.HoldDomain, statusCode int, err error) {
+	if expiresIn == 0 {
+		expiresIn = uc.defaultTTL
+	}
+	if expiresIn > uc.maxTTL {
+		return V1Domains.HoldDomain{}, http.StatusBadRequest, ErrHoldExpiryOutOfRange
+	}
+
+	switch holdDom.TransactionType {
+	case constants.TransactionTypeWithdraw:
+		if !holdDom.Amount.IsPositive() {
+			return V1Domains.HoldDomain{}, http.StatusBadRequest, ErrHoldAmountRequired
+		}
+		holdDom.ProductId, holdDom.Quantity = nil, nil
+	default:
+		if holdDom.ProductId == nil || holdDom.Quantity == nil {
+			return V1Domains.HoldDomain{}, http.StatusBadRequest, ErrHoldProductRequired
+		}
+
+		// Nominal hold pembelian mengikuti harga produk saat ini, harga ini juga yang dipakai saat capture
+		price, statusCode, err := currentProductPrice(ctx, uc.productRepo, *holdDom.ProductId)
+		if err != nil {
+			return V1Domains.HoldDomain{}, statusCode, err
+		}
+		holdDom.UnitPrice = &price
+		holdDom.Amount = price.MulInt(*holdDom.Quantity)
+	}
+
+	// Fee ikut ditahan agar capture penuh tidak gagal karena saldo tersedia kurang untuk membayar fee
+	feeDom := V1Domains.TransactionDomain{ProductId: holdDom.ProductId}
+	if err := applyFees(ctx, uc.feeRepo, &feeDom, holdDom.TransactionType, holdDom.Amount); err != nil {
+		statusCode, _ := utils.MapDBError(err)
+		return V1Domains.HoldDomain{}, statusCode, err
+	}
+	holdDom.Fee = feeDom.Fee
+
+	holdDom.Status = constants.HoldStatusActive
+	holdDom.ExpiresAt = time.Now().Add(expiresIn)
+
+	outDom, err = uc.repo.Store(ctx, *holdDom)
+	if err != nil {
+		statusCode, _ = utils.MapDBError(err)
+		return V1Domains.HoldDomain{}, statusCode, err
+	}
+
+	return outDom, http.StatusCreated, nil
+}
+
+func (uc *holdUsecase) GetById(ctx context.Context, holdId string, userId string) (outDom V1Domains.HoldDomain, statusCode int, err error) {
+	outDom, err = uc.repo.GetById(ctx, holdId, userId)
+	if err != nil {
+		statusCode, _ = utils.MapDBError(err)
+		return V1Domains.HoldDomain{}, statusCode, err
+	}
+
+	return outDom, http.StatusOK, nil
+}
+
+func (uc *holdUsecase) Capture(ctx context.Context, holdId string, userId string, captureDom V1Domains.HoldCaptureDomain) (outDom V1Domains.HoldDomain, statusCode int, err error) {
+	hold, err := uc.repo.GetById(ctx, holdId, userId)
+	if err != nil {
+		statusCode, _ = utils.MapDBError(err)
+		return V1Domains.HoldDomain{}, statusCode, err
+	}
+	if hold.Status != constants.HoldStatusActive {
+		return V1Domains.HoldDomain{}, http.StatusConflict, V1PostgresRepository.ErrHoldNotActive
+	}
+
+	// Tanpa amount atau quantity berarti capture penuh
+	var transactionDom V1Domains.TransactionDomain
+	switch hold.TransactionType {
+	case constants.TransactionTypeWithdraw:
+		if captureDom.Quantity != nil {
+			return V1Domains.HoldDomain{}, http.StatusBadRequest, ErrHoldCaptureInvalid
+		}
+		transactionDom.Amount = hold.Amount
+		if captureDom.Amount != nil {
+			transactionDom.Amount = *captureDom.Amount
+		}
+		if !transactionDom.Amount.IsPositive() {
+			return V1Domains.HoldDomain{}, http.StatusBadRequest, ErrAmountMustGreateThanZero
+		}
+		if transactionDom.Amount > hold.Amount {
+			return V1Domains.HoldDomain{}, http.StatusUnprocessableEntity, ErrHoldCaptureExceeded
+		}
+	default:
+		if captureDom.Amount != nil {
+			return V1Domains.HoldDomain{}, http.StatusBadRequest, ErrHoldCaptureInvalid
+		}
+		// produk yang sudah dihapus membuat product_id hold menjadi null
+		if hold.ProductId == nil {
+			return V1Domains.HoldDomain{}, http.StatusNotFound, V1PostgresRepository.ErrProductNotFound
+		}
+		quantity := *hold.Quantity
+		if captureDom.Quantity != nil {
+			quantity = *captureDom.Quantity
+		}
+		if quantity <= 0 {
+			return V1Domains.HoldDomain{}, http.StatusBadRequest, ErrQuantityMustGreaterThanZero
+		}
+		if quantity > *hold.Quantity {
+			return V1Domains.HoldDomain{}, http.StatusUnprocessableEntity, ErrHoldCaptureExceeded
+		}
+		transactionDom.ProductId = hold.ProductId
+		transactionDom.Quantity = &quantity
+		// harga dikunci saat hold dibuat, perubahan harga produk setelahnya tidak memengaruhi capture
+		transactionDom.Product.Price = *hold.UnitPrice
+		transactionDom.PriceLocked = true
+		transactionDom.Amount = hold.UnitPrice.MulInt(quantity)
+	}
+
+	if err := applyFees(ctx, uc.feeRepo, &transactionDom, hold.TransactionType, transactionDom.Amount); err != nil {
+		statusCode, _ := utils.MapDBError(err)
+		return V1Domains.HoldDomain{}, statusCode, err
+	}
+
+	outDom, err = uc.repo.Capture(ctx, holdId, userId, transactionDom)
+	if err != nil {
+		statusCode, _ = utils.MapDBError(err)
+		return V1Domains.HoldDomain{}, statusCode, err
+	}
+
+	return outDom, http.StatusCreated, nil
+}
+
+func (uc *holdUsecase) Void(ctx context.Context, holdId string, userId string) (outDom V1Domains.HoldDomain, statusCode int, err error) {
+	outDom, err = uc.repo.Void(ctx, holdId, userId)
+	if err != nil {
+		statusCode, _ = utils.MapDBError(err)
+		return V1Domains.HoldDomain{}, statusCode, err
+	}
+
+	return outDom, http.StatusOK, nil
+}
+
+func (uc *holdUsecase) ExpireDue(ctx context.Context, now time.Time) ([]V1Domains.HoldDomain, error) {
+	return uc.repo.ExpireDue(ctx, now, constants.HoldExpiryBatchSize)
+}
@@ -0,0 +1,278 @@
+package v1_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	V1Usecases "github.com/snykk/transaction-api/internal/business/usecases/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+	PostgresRepo "github.com/snykk/transaction-api/internal/datasources/repositories/postgres/v1"
+	"github.com/snykk/transaction-api/internal/mocks"
+	"github.com/snykk/transaction-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	holdRepoMock        *mocks.HoldRepository
+	holdFeeRepoMock     *mocks.FeeRuleRepository
+	holdProductRepoMock *mocks.ProductRepository
+	holdUsecase         V1Domains.HoldUsecase
+	withdrawHoldFromDB  V1Domains.HoldDomain
+	purchaseHoldFromDB  V1Domains.HoldDomain
+)
+
+func setupHold(t *testing.T) {
+	holdRepoMock = mocks.NewHoldRepository(t)
+	holdFeeRepoMock = mocks.NewFeeRuleRepository(t)
+	holdProductRepoMock = mocks.NewProductRepository(t)
+	holdUsecase = V1Usecases.NewHoldUsecase(holdRepoMock, holdFeeRepoMock, holdProductRepoMock, 15*time.Minute, 24*time.Hour)
+
+	productId, quantity, unitPrice := 1, 4, money.FromMajor(25)
+	withdrawHoldFromDB = V1Domains.HoldDomain{
+		Id:              "hhhh-oooo-llll",
+		WalletId:        "wwww-aaaa-llll",
+		UserId:          "aaaa-bbbb-cccc",
+		TransactionType: constants.TransactionTypeWithdraw,
+		Amount:          money.FromMajor(100),
+		Status:          constants.HoldStatusActive,
+		ExpiresAt:       time.Now().Add(15 * time.Minute),
+	}
+	purchaseHoldFromDB = V1Domains.HoldDomain{
+		Id:              "hhhh-oooo-pppp",
+		WalletId:        "wwww-aaaa-llll",
+		UserId:          "aaaa-bbbb-cccc",
+		TransactionType: constants.TransactionTypePurchase,
+		ProductId:       &productId,
+		Quantity:        &quantity,
+		UnitPrice:       &unitPrice,
+		Amount:          money.FromMajor(100),
+		Status:          constants.HoldStatusActive,
+		ExpiresAt:       time.Now().Add(15 * time.Minute),
+	}
+}
+
+func TestStoreHold(t *testing.T) {
+	setupHold(t)
+
+	t.Run("When Success Store Withdraw Hold With Default Expiry", func(t *testing.T) {
+		flatFee := money.FromMajor(2)
+		holdDom := V1Domains.HoldDomain{UserId: withdrawHoldFromDB.UserId, TransactionType: constants.TransactionTypeWithdraw, Amount: money.FromMajor(100)}
+
+		holdFeeRepoMock.Mock.On("GetApplicable", mock.Anything, constants.TransactionTypeWithdraw, (*int)(nil)).Return([]V1Domains.FeeRuleDomain{
+			{Id: 1, Name: "withdraw fee", FeeType: constants.FeeTypeFlat, FlatAmount: &flatFee},
+		}, nil).Once()
+		holdRepoMock.Mock.On("Store", mock.Anything, mock.MatchedBy(func(h V1Domains.HoldDomain) bool {
+			// fee ikut ditahan dan masa berlaku default 15 menit
+			expiresIn := time.Until(h.ExpiresAt)
+			return h.Status == constants.HoldStatusActive && h.Fee == flatFee && expiresIn > 14*time.Minute && expiresIn <= 15*time.Minute
+		})).Return(withdrawHoldFromDB, nil).Once()
+
+		result, statusCode, err := holdUsecase.Store(context.Background(), &holdDom, 0)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, statusCode)
+		assert.Equal(t, withdrawHoldFromDB, result)
+	})
+
+	t.Run("When Success Store Purchase Hold From Product Price", func(t *testing.T) {
+		productId, quantity := 1, 4
+		holdDom := V1Domains.HoldDomain{UserId: purchaseHoldFromDB.UserId, TransactionType: constants.TransactionTypePurchase, ProductId: &productId, Quantity: &quantity}
+
+		holdProductRepoMock.Mock.On("GetProductById", mock.Anything, productId).Return(V1Domains.ProductDomain{Id: productId, Price: money.FromMajor(25)}, nil).Once()
+		holdFeeRepoMock.Mock.On("GetApplicable", mock.Anything, constants.TransactionTypePurchase, &productId).Return(nil, nil).Once()
+		holdRepoMock.Mock.On("Store", mock.Anything, mock.MatchedBy(func(h V1Domains.HoldDomain) bool {
+			return h.Amount == money.FromMajor(100) && *h.UnitPrice == money.FromMajor(25) && h.Fee.IsZero()
+		})).Return(purchaseHoldFromDB, nil).Once()
+
+		result, statusCode, err := holdUsecase.Store(context.Background(), &holdDom, time.Hour)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, statusCode)
+		assert.Equal(t, purchaseHoldFromDB, result)
+	})
+
+	t.Run("When Failure Validation", func(t *testing.T) {
+		productId := 1
+		testCases := []struct {
+			name      string
+			holdDom   V1Domains.HoldDomain
+			expiresIn time.Duration
+			expected  error
+		}{
+			{"Withdraw Without Amount", V1Domains.HoldDomain{TransactionType: constants.TransactionTypeWithdraw}, 0, V1Usecases.ErrHoldAmountRequired},
+			{"Purchase Without Quantity", V1Domains.HoldDomain{TransactionType: constants.TransactionTypePurchase, ProductId: &productId}, 0, V1Usecases.ErrHoldProductRequired},
+			{"Expiry Too Long", V1Domains.HoldDomain{TransactionType: constants.TransactionTypeWithdraw, Amount: money.FromMajor(1)}, 48 * time.Hour, V1Usecases.ErrHoldExpiryOutOfRange},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				result, statusCode, err := holdUsecase.Store(context.Background(), &tc.holdDom, tc.expiresIn)
+
+				assert.Equal(t, tc.expected, err)
+				assert.Equal(t, http.StatusBadRequest, statusCode)
+				assert.Equal(t, V1Domains.HoldDomain{}, result)
+			})
+		}
+	})
+
+	t.Run("When Failure Insufficient Available Balance", func(t *testing.T) {
+		holdDom := V1Domains.HoldDomain{UserId: withdrawHoldFromDB.UserId, TransactionType: constants.TransactionTypeWithdraw, Amount: money.FromMajor(100)}
+
+		holdFeeRepoMock.Mock.On("GetApplicable", mock.Anything, constants.TransactionTypeWithdraw, (*int)(nil)).Return(nil, nil).Once()
+		holdRepoMock.Mock.On("Store", mock.Anything, mock.Anything).Return(V1Domains.HoldDomain{}, PostgresRepo.ErrInsufficientBalance).Once()
+
+		result, statusCode, err := holdUsecase.Store(context.Background(), &holdDom, 0)
+
+		assert.Equal(t, PostgresRepo.ErrInsufficientBalance, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, statusCode)
+		assert.Equal(t, V1Domains.HoldDomain{}, result)
+	})
+}
+
+func TestCaptureHold(t *testing.T) {
+	setupHold(t)
+
+	t.Run("When Success Partial Capture Withdraw Hold", func(t *testing.T) {
+		amount := money.FromMajor(60)
+		captured := withdrawHoldFromDB
+		captured.Status = constants.HoldStatusCaptured
+		captured.CapturedAmount = &amount
+
+		holdRepoMock.Mock.On("GetById", mock.Anything, withdrawHoldFromDB.Id, withdrawHoldFromDB.UserId).Return(withdrawHoldFromDB, nil).Once()
+		holdFeeRepoMock.Mock.On("GetApplicable", mock.Anything, constants.TransactionTypeWithdraw, (*int)(nil)).Return(nil, nil).Once()
+		holdRepoMock.Mock.On("Capture", mock.Anything, withdrawHoldFromDB.Id, withdrawHoldFromDB.UserId, mock.MatchedBy(func(tr V1Domains.TransactionDomain) bool {
+			return tr.Amount == amount
+		})).Return(captured, nil).Once()
+
+		result, statusCode, err := holdUsecase.Capture(context.Background(), withdrawHoldFromDB.Id, withdrawHoldFromDB.UserId, V1Domains.HoldCaptureDomain{Amount: &amount})
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, statusCode)
+		assert.Equal(t, captured, result)
+	})
+
+	t.Run("When Success Full Capture Purchase Hold", func(t *testing.T) {
+		captured := purchaseHoldFromDB
+		captured.Status = constants.HoldStatusCaptured
+
+		holdRepoMock.Mock.On("GetById", mock.Anything, purchaseHoldFromDB.Id, purchaseHoldFromDB.UserId).Return(purchaseHoldFromDB, nil).Once()
+		holdFeeRepoMock.Mock.On("GetApplicable", mock.Anything, constants.TransactionTypePurchase, purchaseHoldFromDB.ProductId).Return(nil, nil).Once()
+		holdRepoMock.Mock.On("Capture", mock.Anything, purchaseHoldFromDB.Id, purchaseHoldFromDB.UserId, mock.MatchedBy(func(tr V1Domains.TransactionDomain) bool {
+			// capture pembelian memakai harga saat hold dibuat
+			return *tr.Quantity == 4 && tr.Product.Price == money.FromMajor(25) && *tr.ProductId == 1
+		})).Return(captured, nil).Once()
+
+		result, statusCode, err := holdUsecase.Capture(context.Background(), purchaseHoldFromDB.Id, purchaseHoldFromDB.UserId, V1Domains.HoldCaptureDomain{})
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, statusCode)
+		assert.Equal(t, captured, result)
+	})
+
+	t.Run("When Success Capture Purchase Hold After Price Change", func(t *testing.T) {
+		quantity := 2
+		captured := purchaseHoldFromDB
+		captured.Status = constants.HoldStatusCaptured
+
+		holdRepoMock.Mock.On("GetById", mock.Anything, purchaseHoldFromDB.Id, purchaseHoldFromDB.UserId).Return(purchaseHoldFromDB, nil).Once()
+		holdFeeRepoMock.Mock.On("GetApplicable", mock.Anything, constants.TransactionTypePurchase, purchaseHoldFromDB.ProductId).Return(nil, nil).Once()
+		holdRepoMock.Mock.On("Capture", mock.Anything, purchaseHoldFromDB.Id, purchaseHoldFromDB.UserId, mock.MatchedBy(func(tr V1Domains.TransactionDomain) bool {
+			// harga dikunci sehingga repository tidak menolak capture walaupun harga produk sudah naik
+			return tr.PriceLocked && tr.Product.Price == money.FromMajor(25) && tr.Amount == money.FromMajor(50)
+		})).Return(captured, nil).Once()
+
+		result, statusCode, err := holdUsecase.Capture(context.Background(), purchaseHoldFromDB.Id, purchaseHoldFromDB.UserId, V1Domains.HoldCaptureDomain{Quantity: &quantity})
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, statusCode)
+		assert.Equal(t, captured, result)
+	})
+
+	t.Run("When Failure Capture Exceeds Hold", func(t *testing.T) {
+		amount := money.FromMajor(150)
+		holdRepoMock.Mock.On("GetById", mock.Anything, withdrawHoldFromDB.Id, withdrawHoldFromDB.UserId).Return(withdrawHoldFromDB, nil).Once()
+
+		result, statusCode, err := holdUsecase.Capture(context.Background(), withdrawHoldFromDB.Id, withdrawHoldFromDB.UserId, V1Domains.HoldCaptureDomain{Amount: &amount})
+
+		assert.Equal(t, V1Usecases.ErrHoldCaptureExceeded, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, statusCode)
+		assert.Equal(t, V1Domains.HoldDomain{}, result)
+	})
+
+	t.Run("When Failure Capture Purchase Hold By Amount", func(t *testing.T) {
+		amount := money.FromMajor(50)
+		holdRepoMock.Mock.On("GetById", mock.Anything, purchaseHoldFromDB.Id, purchaseHoldFromDB.UserId).Return(purchaseHoldFromDB, nil).Once()
+
+		_, statusCode, err := holdUsecase.Capture(context.Background(), purchaseHoldFromDB.Id, purchaseHoldFromDB.UserId, V1Domains.HoldCaptureDomain{Amount: &amount})
+
+		assert.Equal(t, V1Usecases.ErrHoldCaptureInvalid, err)
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+	})
+
+	t.Run("When Failure Hold Already Voided", func(t *testing.T) {
+		voided := withdrawHoldFromDB
+		voided.Status = constants.HoldStatusVoided
+		holdRepoMock.Mock.On("GetById", mock.Anything, withdrawHoldFromDB.Id, withdrawHoldFromDB.UserId).Return(voided, nil).Once()
+
+		_, statusCode, err := holdUsecase.Capture(context.Background(), withdrawHoldFromDB.Id, withdrawHoldFromDB.UserId, V1Domains.HoldCaptureDomain{})
+
+		assert.Equal(t, PostgresRepo.ErrHoldNotActive, err)
+		assert.Equal(t, http.StatusConflict, statusCode)
+	})
+
+	t.Run("When Failure Hold Expired Before Capture", func(t *testing.T) {
+		holdRepoMock.Mock.On("GetById", mock.Anything, withdrawHoldFromDB.Id, withdrawHoldFromDB.UserId).Return(withdrawHoldFromDB, nil).Once()
+		holdFeeRepoMock.Mock.On("GetApplicable", mock.Anything, constants.TransactionTypeWithdraw, (*int)(nil)).Return(nil, nil).Once()
+		holdRepoMock.Mock.On("Capture", mock.Anything, withdrawHoldFromDB.Id, withdrawHoldFromDB.UserId, mock.Anything).Return(V1Domains.HoldDomain{}, PostgresRepo.ErrHoldExpired).Once()
+
+		_, statusCode, err := holdUsecase.Capture(context.Background(), withdrawHoldFromDB.Id, withdrawHoldFromDB.UserId, V1Domains.HoldCaptureDomain{})
+
+		assert.Equal(t, PostgresRepo.ErrHoldExpired, err)
+		assert.Equal(t, http.StatusConflict, statusCode)
+	})
+}
+
+func TestVoidHold(t *testing.T) {
+	setupHold(t)
+
+	t.Run("When Success Void Hold", func(t *testing.T) {
+		voided := withdrawHoldFromDB
+		voided.Status = constants.HoldStatusVoided
+		holdRepoMock.Mock.On("Void", mock.Anything, withdrawHoldFromDB.Id, withdrawHoldFromDB.UserId).Return(voided, nil).Once()
+
+		result, statusCode, err := holdUsecase.Void(context.Background(), withdrawHoldFromDB.Id, withdrawHoldFromDB.UserId)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, constants.HoldStatusVoided, result.Status)
+	})
+
+	t.Run("When Failure Hold Not Found", func(t *testing.T) {
+		holdRepoMock.Mock.On("Void", mock.Anything, "unknown", withdrawHoldFromDB.UserId).Return(V1Domains.HoldDomain{}, PostgresRepo.ErrHoldNotFound).Once()
+
+		_, statusCode, err := holdUsecase.Void(context.Background(), "unknown", withdrawHoldFromDB.UserId)
+
+		assert.Equal(t, PostgresRepo.ErrHoldNotFound, err)
+		assert.Equal(t, http.StatusNotFound, statusCode)
+	})
+}
+
+func TestExpireDueHolds(t *testing.T) {
+	setupHold(t)
+
+	t.Run("When Success Expire Due Holds In Batches", func(t *testing.T) {
+		now := time.Now()
+		expired := withdrawHoldFromDB
+		expired.Status = constants.HoldStatusExpired
+		holdRepoMock.Mock.On("ExpireDue", mock.Anything, now, constants.HoldExpiryBatchSize).Return([]V1Domains.HoldDomain{expired}, nil).Once()
+
+		result, err := holdUsecase.ExpireDue(context.Background(), now)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []V1Domains.HoldDomain{expired}, result)
+	})
+}
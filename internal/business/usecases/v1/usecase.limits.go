@@ -0,0 +1,52 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/internal/utils"
+	"github.com/snykk/transaction-api/pkg/money"
+)
+
+type limitUsecase struct {
+	repo     V1Domains.LimitRepository
+	defaults V1Domains.UserLimitDomain // limit global dari config
+}
+
+func NewLimitUsecase(repo V1Domains.LimitRepository, defaults V1Domains.UserLimitDomain) V1Domains.LimitUsecase {
+	return &limitUsecase{
+		repo:     repo,
+		defaults: defaults,
+	}
+}
+
+func (uc *limitUsecase) GetByUserId(ctx context.Context, userId string) (outDom V1Domains.UserLimitDomain, statusCode int, err error) {
+	overrides, err := uc.repo.GetByUserId(ctx, userId)
+	if err != nil {
+		statusCode, _ = utils.MapDBError(err)
+		return V1Domains.UserLimitDomain{}, statusCode, err
+	}
+
+	return overrides.WithDefaults(uc.defaults), http.StatusOK, nil
+}
+
+func (uc *limitUsecase) Update(ctx context.Context, limitDom *V1Domains.UserLimitDomain) (outDom V1Domains.UserLimitDomain, statusCode int, err error) {
+	// Limit boleh 0 (tanpa batas) namun tidak boleh negatif
+	for _, amount := range []*money.Money{limitDom.MaxSingleWithdrawal, limitDom.DailyWithdrawal, limitDom.MonthlyWithdrawal, limitDom.DailyPurchase} {
+		if amount != nil && amount.IsNegative() {
+			return V1Domains.UserLimitDomain{}, http.StatusBadRequest, ErrLimitMustNotBeNegative
+		}
+	}
+	if limitDom.HourlyTransactions != nil && *limitDom.HourlyTransactions < 0 {
+		return V1Domains.UserLimitDomain{}, http.StatusBadRequest, ErrLimitMustNotBeNegative
+	}
+
+	overrides, err := uc.repo.Upsert(ctx, *limitDom)
+	if err != nil {
+		statusCode, _ = utils.MapDBError(err)
+		return V1Domains.UserLimitDomain{}, statusCode, err
+	}
+
+	return overrides.WithDefaults(uc.defaults), http.StatusOK, nil
+}
@@ -0,0 +1,131 @@
+package v1_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	V1Usecases "github.com/snykk/transaction-api/internal/business/usecases/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+	PostgresRepo "github.com/snykk/transaction-api/internal/datasources/repositories/postgres/v1"
+	"github.com/snykk/transaction-api/internal/mocks"
+	"github.com/snykk/transaction-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	limitRepoMock *mocks.LimitRepository
+	limitUsecase  V1Domains.LimitUsecase
+	limitDefaults V1Domains.UserLimitDomain
+)
+
+func setupLimit(t *testing.T) {
+	limitRepoMock = mocks.NewLimitRepository(t)
+
+	maxSingleWithdrawal := money.FromMajor(5000)
+	dailyWithdrawal := money.FromMajor(10000)
+	monthlyWithdrawal := money.FromMajor(100000)
+	dailyPurchase := money.FromMajor(0)
+	hourlyTransactions := 20
+	limitDefaults = V1Domains.UserLimitDomain{
+		MaxSingleWithdrawal: &maxSingleWithdrawal,
+		DailyWithdrawal:     &dailyWithdrawal,
+		MonthlyWithdrawal:   &monthlyWithdrawal,
+		DailyPurchase:       &dailyPurchase,
+		HourlyTransactions:  &hourlyTransactions,
+	}
+
+	limitUsecase = V1Usecases.NewLimitUsecase(limitRepoMock, limitDefaults)
+}
+
+func TestGetLimitByUserId(t *testing.T) {
+	setupLimit(t)
+
+	t.Run("When User Has No Override", func(t *testing.T) {
+		limitRepoMock.Mock.On("GetByUserId", mock.Anything, "aaaa-bbbb-cccc").Return(V1Domains.UserLimitDomain{UserId: "aaaa-bbbb-cccc"}, nil).Once()
+
+		result, statusCode, err := limitUsecase.GetByUserId(context.Background(), "aaaa-bbbb-cccc")
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, "aaaa-bbbb-cccc", result.UserId)
+		assert.Equal(t, money.FromMajor(10000), *result.DailyWithdrawal)
+		assert.Equal(t, 20, *result.HourlyTransactions)
+		assert.Empty(t, result.Overridden)
+	})
+
+	t.Run("When User Has Override", func(t *testing.T) {
+		dailyWithdrawal := money.FromMajor(2500)
+		hourlyTransactions := 0
+		limitRepoMock.Mock.On("GetByUserId", mock.Anything, "aaaa-bbbb-cccc").Return(V1Domains.UserLimitDomain{
+			UserId:             "aaaa-bbbb-cccc",
+			DailyWithdrawal:    &dailyWithdrawal,
+			HourlyTransactions: &hourlyTransactions,
+		}, nil).Once()
+
+		result, statusCode, err := limitUsecase.GetByUserId(context.Background(), "aaaa-bbbb-cccc")
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, money.FromMajor(2500), *result.DailyWithdrawal)
+		assert.Equal(t, money.FromMajor(5000), *result.MaxSingleWithdrawal)
+		assert.Equal(t, 0, *result.HourlyTransactions)
+		assert.Equal(t, []string{constants.LimitDailyWithdrawal, constants.LimitHourlyTransactions}, result.Overridden)
+	})
+
+	t.Run("When User Not Found", func(t *testing.T) {
+		limitRepoMock.Mock.On("GetByUserId", mock.Anything, "xxxx-yyyy-zzzz").Return(V1Domains.UserLimitDomain{}, PostgresRepo.ErrUserNotFound).Once()
+
+		_, statusCode, err := limitUsecase.GetByUserId(context.Background(), "xxxx-yyyy-zzzz")
+
+		assert.ErrorIs(t, err, PostgresRepo.ErrUserNotFound)
+		assert.Equal(t, http.StatusNotFound, statusCode)
+	})
+}
+
+func TestUpdateLimit(t *testing.T) {
+	setupLimit(t)
+
+	t.Run("When Success Update Limit", func(t *testing.T) {
+		monthlyWithdrawal := money.FromMajor(50000)
+		adminId := "admin-aaaa-bbbb"
+		limitDom := V1Domains.UserLimitDomain{
+			UserId:            "aaaa-bbbb-cccc",
+			MonthlyWithdrawal: &monthlyWithdrawal,
+			UpdatedBy:         &adminId,
+		}
+
+		limitRepoMock.Mock.On("Upsert", mock.Anything, limitDom).Return(limitDom, nil).Once()
+
+		result, statusCode, err := limitUsecase.Update(context.Background(), &limitDom)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, money.FromMajor(50000), *result.MonthlyWithdrawal)
+		assert.Equal(t, money.FromMajor(10000), *result.DailyWithdrawal)
+		assert.Equal(t, []string{constants.LimitMonthlyWithdrawal}, result.Overridden)
+	})
+
+	t.Run("When Limit Is Negative", func(t *testing.T) {
+		dailyPurchase := money.FromMajor(-1)
+		limitDom := V1Domains.UserLimitDomain{UserId: "aaaa-bbbb-cccc", DailyPurchase: &dailyPurchase}
+
+		_, statusCode, err := limitUsecase.Update(context.Background(), &limitDom)
+
+		assert.ErrorIs(t, err, V1Usecases.ErrLimitMustNotBeNegative)
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+	})
+
+	t.Run("When User Not Found", func(t *testing.T) {
+		limitDom := V1Domains.UserLimitDomain{UserId: "xxxx-yyyy-zzzz"}
+
+		limitRepoMock.Mock.On("Upsert", mock.Anything, limitDom).Return(V1Domains.UserLimitDomain{}, PostgresRepo.ErrUserNotFound).Once()
+
+		_, statusCode, err := limitUsecase.Update(context.Background(), &limitDom)
+
+		assert.ErrorIs(t, err, PostgresRepo.ErrUserNotFound)
+		assert.Equal(t, http.StatusNotFound, statusCode)
+	})
+}
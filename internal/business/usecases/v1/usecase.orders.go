@@ -0,0 +1,119 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+	"github.com/snykk/transaction-api/internal/utils"
+)
+
+type orderUsecase struct {
+	repo        V1Domains.OrderRepository
+	feeRepo     V1Domains.FeeRuleRepository
+	productRepo V1Domains.ProductRepository
+}
+
+func NewOrderUsecase(repo V1Domains.OrderRepository, feeRepo V1Domains.FeeRuleRepository, productRepo V1Domains.ProductRepository) V1Domains.OrderUsecase {
+	return &orderUsecase{
+		repo:        repo,
+		feeRepo:     feeRepo,
+		productRepo: productRepo,
+	}
+}
+
+func (uc *orderUsecase) Create(ctx context.Context, orderDom *V1Domains.OrderDomain) (outDom V1Domains.OrderDomain, statusCode int, err error) {
+	if len(orderDom.Items) == 0 {
+		return V1Domains.OrderDomain{}, http.StatusBadRequest, ErrOrderItemsRequired
+	}
+	if len(orderDom.Items) > constants.OrderMaxItems {
+		return V1Domains.OrderDomain{}, http.StatusBadRequest, ErrOrderTooManyItems
+	}
+	if orderDom.PointsRedeemed.IsNegative() {
+		return V1Domains.OrderDomain{}, http.StatusBadRequest, ErrPointsMustNotBeNegative
+	}
+	if err := validateTransactionMetadata(orderDom.Metadata); err != nil {
+		return V1Domains.OrderDomain{}, http.StatusBadRequest, err
+	}
+
+	seen := make(map[int]bool, len(orderDom.Items))
+	for _, item := range orderDom.Items {
+		if item.Quantity <= 0 {
+			return V1Domains.OrderDomain{}, http.StatusBadRequest, ErrQuantityMustGreaterThanZero
+		}
+		if seen[*item.ProductId] {
+			return V1Domains.OrderDomain{}, http.StatusBadRequest, ErrOrderDuplicateProduct
+		}
+		seen[*item.ProductId] = true
+	}
+
+	// Fee dihitung per item dari harga produk saat ini dengan aturan yang sama seperti pembelian satu produk,
+	// repository menolak order jika harga salah satu produk berubah sebelum transaksi dijalankan
+	orderDom.Fee, orderDom.FeeBreakdown = 0, nil
+	for i := range orderDom.Items {
+		item := &orderDom.Items[i]
+
+		item.UnitPrice, statusCode, err = currentProductPrice(ctx, uc.productRepo, *item.ProductId)
+		if err != nil {
+			return V1Domains.OrderDomain{}, statusCode, err
+		}
+
+		rules, err := uc.feeRepo.GetApplicable(ctx, constants.TransactionTypePurchase, item.ProductId)
+		if err != nil {
+			statusCode, _ := utils.MapDBError(err)
+			return V1Domains.OrderDomain{}, statusCode, err
+		}
+
+		fee, breakdown := V1Domains.CalculateFees(rules, item.UnitPrice.MulInt(item.Quantity))
+		orderDom.Fee += fee
+		orderDom.FeeBreakdown = mergeFeeBreakdown(orderDom.FeeBreakdown, breakdown)
+	}
+
+	outDom, err = uc.repo.Create(ctx, *orderDom)
+	if err != nil {
+		statusCode, _ = utils.MapDBError(err)
+		return V1Domains.OrderDomain{}, statusCode, err
+	}
+
+	return outDom, http.StatusCreated, nil
+}
+
+func (uc *orderUsecase) GetByUserId(ctx context.Context, userId string) (outDoms []V1Domains.OrderDomain, statusCode int, err error) {
+	outDoms, err = uc.repo.GetByUserId(ctx, userId)
+	if err != nil {
+		statusCode, _ = utils.MapDBError(err)
+		return nil, statusCode, err
+	}
+
+	return outDoms, http.StatusOK, nil
+}
+
+func (uc *orderUsecase) GetById(ctx context.Context, orderId string, userId string) (outDom V1Domains.OrderDomain, statusCode int, err error) {
+	outDom, err = uc.repo.GetById(ctx, orderId, userId)
+	if err != nil {
+		statusCode, _ = utils.MapDBError(err)
+		return V1Domains.OrderDomain{}, statusCode, err
+	}
+
+	return outDom, http.StatusOK, nil
+}
+
+// mergeFeeBreakdown menjumlahkan komponen fee dari aturan yang sama agar rincian order berisi satu baris per aturan
+func mergeFeeBreakdown(breakdown []V1Domains.FeeComponentDomain, components []V1Domains.FeeComponentDomain) []V1Domains.FeeComponentDomain {
+	for _, component := range components {
+		merged := false
+		for i := range breakdown {
+			if breakdown[i].FeeRuleId == component.FeeRuleId {
+				breakdown[i].Amount += component.Amount
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			breakdown = append(breakdown, component)
+		}
+	}
+
+	return breakdown
+}
@@ -0,0 +1,183 @@
+package v1_test
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"testing"
+	"time"
+
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	V1Usecases "github.com/snykk/transaction-api/internal/business/usecases/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+	PostgresRepo "github.com/snykk/transaction-api/internal/datasources/repositories/postgres/v1"
+	"github.com/snykk/transaction-api/internal/mocks"
+	"github.com/snykk/transaction-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	orderRepoMock        *mocks.OrderRepository
+	orderFeeRepoMock     *mocks.FeeRuleRepository
+	orderProductRepoMock *mocks.ProductRepository
+	orderUsecase         V1Domains.OrderUsecase
+	orderDataFromDB      V1Domains.OrderDomain
+)
+
+func setupOrder(t *testing.T) {
+	orderRepoMock = mocks.NewOrderRepository(t)
+	orderFeeRepoMock = mocks.NewFeeRuleRepository(t)
+	orderProductRepoMock = mocks.NewProductRepository(t)
+	orderUsecase = V1Usecases.NewOrderUsecase(orderRepoMock, orderFeeRepoMock, orderProductRepoMock)
+
+	productId1, productId2 := 1, 2
+	orderDataFromDB = V1Domains.OrderDomain{
+		Id:            "oooo-rrrr-dddd",
+		UserId:        "aaaa-bbbb-cccc",
+		WalletId:      "wwww-aaaa-llll",
+		TransactionId: "tttt-rrrr-xxxx",
+		Items: []V1Domains.OrderItemDomain{
+			{Id: "iiii-0001", ProductId: &productId1, ProductName: "Keyboard", Quantity: 2, UnitPrice: money.FromMajor(50), Subtotal: money.FromMajor(100)},
+			{Id: "iiii-0002", ProductId: &productId2, ProductName: "Mouse", Quantity: 1, UnitPrice: money.FromMajor(25), Subtotal: money.FromMajor(25)},
+		},
+		TotalAmount: money.FromMajor(125),
+		Status:      constants.TransactionStatusCompleted,
+		CreatedAt:   time.Now(),
+	}
+}
+
+func newOrderDom(items ...V1Domains.OrderItemDomain) *V1Domains.OrderDomain {
+	return &V1Domains.OrderDomain{UserId: orderDataFromDB.UserId, Items: items}
+}
+
+func TestCreateOrder(t *testing.T) {
+	setupOrder(t)
+
+	t.Run("When Success Create Order With Fees Per Item", func(t *testing.T) {
+		productId1, productId2 := 1, 2
+		rateBps, flatFee := 100, money.FromMajor(3)
+		platformFee := V1Domains.FeeRuleDomain{Id: 1, Name: "platform fee", FeeType: constants.FeeTypePercentage, RateBps: &rateBps}
+		mouseFee := V1Domains.FeeRuleDomain{Id: 2, Name: "mouse handling", ProductId: &productId2, FeeType: constants.FeeTypeFlat, FlatAmount: &flatFee}
+
+		orderProductRepoMock.Mock.On("GetProductById", mock.Anything, productId1).Return(V1Domains.ProductDomain{Id: productId1, Price: money.FromMajor(50)}, nil).Once()
+		orderProductRepoMock.Mock.On("GetProductById", mock.Anything, productId2).Return(V1Domains.ProductDomain{Id: productId2, Price: money.FromMajor(25)}, nil).Once()
+		orderFeeRepoMock.Mock.On("GetApplicable", mock.Anything, constants.TransactionTypePurchase, &productId1).Return([]V1Domains.FeeRuleDomain{platformFee}, nil).Once()
+		orderFeeRepoMock.Mock.On("GetApplicable", mock.Anything, constants.TransactionTypePurchase, &productId2).Return([]V1Domains.FeeRuleDomain{platformFee, mouseFee}, nil).Once()
+		orderRepoMock.Mock.On("Create", mock.Anything, mock.MatchedBy(func(o V1Domains.OrderDomain) bool {
+			// 1% dari 100 dan 1% dari 25 digabung menjadi satu komponen platform fee
+			return o.Items[0].UnitPrice == money.FromMajor(50) && o.Items[1].UnitPrice == money.FromMajor(25) &&
+				o.Fee == money.MustParse("4.25") && len(o.FeeBreakdown) == 2 &&
+				o.FeeBreakdown[0].Amount == money.MustParse("1.25") && o.FeeBreakdown[1].Amount == flatFee
+		})).Return(orderDataFromDB, nil).Once()
+
+		result, statusCode, err := orderUsecase.Create(context.Background(), newOrderDom(
+			V1Domains.OrderItemDomain{ProductId: &productId1, Quantity: 2},
+			V1Domains.OrderItemDomain{ProductId: &productId2, Quantity: 1},
+		))
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, statusCode)
+		assert.Equal(t, orderDataFromDB, result)
+	})
+
+	t.Run("When Failure Validation", func(t *testing.T) {
+		productId := 1
+		tooManyItems := make([]V1Domains.OrderItemDomain, constants.OrderMaxItems+1)
+		for i := range tooManyItems {
+			id := i + 1
+			tooManyItems[i] = V1Domains.OrderItemDomain{ProductId: &id, Quantity: 1}
+		}
+
+		testCases := []struct {
+			name     string
+			orderDom *V1Domains.OrderDomain
+			expected error
+		}{
+			{"Without Items", newOrderDom(), V1Usecases.ErrOrderItemsRequired},
+			{"Too Many Items", newOrderDom(tooManyItems...), V1Usecases.ErrOrderTooManyItems},
+			{"Invalid Quantity", newOrderDom(V1Domains.OrderItemDomain{ProductId: &productId, Quantity: 0}), V1Usecases.ErrQuantityMustGreaterThanZero},
+			{"Duplicate Product", newOrderDom(V1Domains.OrderItemDomain{ProductId: &productId, Quantity: 1}, V1Domains.OrderItemDomain{ProductId: &productId, Quantity: 2}), V1Usecases.ErrOrderDuplicateProduct},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, statusCode, err := orderUsecase.Create(context.Background(), tc.orderDom)
+
+				assert.Equal(t, tc.expected, err)
+				assert.Equal(t, http.StatusBadRequest, statusCode)
+			})
+		}
+	})
+
+	t.Run("When Failure Product Not Found", func(t *testing.T) {
+		productId := 99
+		orderProductRepoMock.Mock.On("GetProductById", mock.Anything, productId).Return(V1Domains.ProductDomain{}, sql.ErrNoRows).Once()
+
+		_, statusCode, err := orderUsecase.Create(context.Background(), newOrderDom(V1Domains.OrderItemDomain{ProductId: &productId, Quantity: 1}))
+
+		assert.Equal(t, PostgresRepo.ErrProductNotFound, err)
+		assert.Equal(t, http.StatusNotFound, statusCode)
+	})
+
+	t.Run("When Failure Insufficient Stock For One Item", func(t *testing.T) {
+		productId := 1
+		orderProductRepoMock.Mock.On("GetProductById", mock.Anything, productId).Return(V1Domains.ProductDomain{Id: productId, Price: money.FromMajor(50)}, nil).Once()
+		orderFeeRepoMock.Mock.On("GetApplicable", mock.Anything, constants.TransactionTypePurchase, &productId).Return(nil, nil).Once()
+		orderRepoMock.Mock.On("Create", mock.Anything, mock.AnythingOfType("v1.OrderDomain")).Return(V1Domains.OrderDomain{}, PostgresRepo.ErrInsufficientProductStock).Once()
+
+		_, statusCode, err := orderUsecase.Create(context.Background(), newOrderDom(V1Domains.OrderItemDomain{ProductId: &productId, Quantity: 1000}))
+
+		assert.Equal(t, PostgresRepo.ErrInsufficientProductStock, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, statusCode)
+	})
+
+	t.Run("When Failure Product Price Changed", func(t *testing.T) {
+		productId := 1
+		orderProductRepoMock.Mock.On("GetProductById", mock.Anything, productId).Return(V1Domains.ProductDomain{Id: productId, Price: money.FromMajor(50)}, nil).Once()
+		orderFeeRepoMock.Mock.On("GetApplicable", mock.Anything, constants.TransactionTypePurchase, &productId).Return(nil, nil).Once()
+		orderRepoMock.Mock.On("Create", mock.Anything, mock.AnythingOfType("v1.OrderDomain")).Return(V1Domains.OrderDomain{}, PostgresRepo.ErrProductPriceChanged).Once()
+
+		_, statusCode, err := orderUsecase.Create(context.Background(), newOrderDom(V1Domains.OrderItemDomain{ProductId: &productId, Quantity: 1}))
+
+		assert.Equal(t, PostgresRepo.ErrProductPriceChanged, err)
+		assert.Equal(t, http.StatusConflict, statusCode)
+	})
+}
+
+func TestGetOrders(t *testing.T) {
+	setupOrder(t)
+
+	t.Run("When Success Get Orders", func(t *testing.T) {
+		orderRepoMock.Mock.On("GetByUserId", mock.Anything, orderDataFromDB.UserId).Return([]V1Domains.OrderDomain{orderDataFromDB}, nil).Once()
+
+		result, statusCode, err := orderUsecase.GetByUserId(context.Background(), orderDataFromDB.UserId)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, []V1Domains.OrderDomain{orderDataFromDB}, result)
+	})
+}
+
+func TestGetOrderById(t *testing.T) {
+	setupOrder(t)
+
+	t.Run("When Success Get Order", func(t *testing.T) {
+		orderRepoMock.Mock.On("GetById", mock.Anything, orderDataFromDB.Id, orderDataFromDB.UserId).Return(orderDataFromDB, nil).Once()
+
+		result, statusCode, err := orderUsecase.GetById(context.Background(), orderDataFromDB.Id, orderDataFromDB.UserId)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, orderDataFromDB, result)
+	})
+
+	t.Run("When Failure Order Not Found", func(t *testing.T) {
+		orderRepoMock.Mock.On("GetById", mock.Anything, orderDataFromDB.Id, "zzzz-yyyy-xxxx").Return(V1Domains.OrderDomain{}, PostgresRepo.ErrOrderNotFound).Once()
+
+		_, statusCode, err := orderUsecase.GetById(context.Background(), orderDataFromDB.Id, "zzzz-yyyy-xxxx")
+
+		assert.Equal(t, PostgresRepo.ErrOrderNotFound, err)
+		assert.Equal(t, http.StatusNotFound, statusCode)
+	})
+}
@@ -0,0 +1,66 @@
+package v1
+
+import (
+	"context"
+	"time"
+
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+)
+
+type outboxUsecase struct {
+	repo          V1Domains.OutboxRepository
+	publisher     V1Domains.EventPublisher
+	lease         time.Duration // lama event diklaim satu relay sebelum boleh diambil relay lain
+	retryInterval time.Duration // jeda percobaan ulang pertama, berlipat dua setiap percobaan berikutnya
+	maxRetryDelay time.Duration // batas atas jeda percobaan ulang, event tidak pernah berhenti dicoba
+}
+
+func NewOutboxUsecase(repo V1Domains.OutboxRepository, publisher V1Domains.EventPublisher, lease time.Duration, retryInterval time.Duration, maxRetryDelay time.Duration) V1Domains.OutboxUsecase {
+	return &outboxUsecase{
+		repo:          repo,
+		publisher:     publisher,
+		lease:         lease,
+		retryInterval: retryInterval,
+		maxRetryDelay: maxRetryDelay,
+	}
+}
+
+// Relay menandai event sent hanya setelah publisher berhasil. Jika proses mati di antara keduanya,
+// event dikirim ulang setelah lease habis, sehingga pengiriman bersifat at-least-once.
+func (uc *outboxUsecase) Relay(ctx context.Context, now time.Time) (sent int, failed int, err error) {
+	events, err := uc.repo.ClaimPending(ctx, now, uc.lease, constants.OutboxRelayBatchSize)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, event := range events {
+		if err = uc.publisher.Publish(ctx, event); err != nil {
+			failed++
+			if err = uc.repo.MarkFailed(ctx, event.Id, err.Error(), time.Now().Add(uc.retryDelay(event.Attempts+1))); err != nil {
+				return sent, failed, err
+			}
+			continue
+		}
+
+		if err = uc.repo.MarkSent(ctx, event.Id, time.Now()); err != nil {
+			return sent, failed, err
+		}
+		sent++
+	}
+
+	return sent, failed, nil
+}
+
+// retryDelay menghitung jeda sebelum percobaan ke-attempt berikutnya, retryInterval * 2^(attempt-1) dibatasi maxRetryDelay
+func (uc *outboxUsecase) retryDelay(attempt int) time.Duration {
+	delay := uc.retryInterval
+	for i := 1; i < attempt && delay < uc.maxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > uc.maxRetryDelay {
+		delay = uc.maxRetryDelay
+	}
+
+	return delay
+}
@@ -0,0 +1,136 @@
+package v1_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	V1Usecases "github.com/snykk/transaction-api/internal/business/usecases/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+	"github.com/snykk/transaction-api/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	outboxRepoMock      *mocks.OutboxRepository
+	eventPublisherMock  *mocks.EventPublisher
+	outboxUsecase       V1Domains.OutboxUsecase
+	depositEventFromDB  V1Domains.EventDomain
+	purchaseEventFromDB V1Domains.EventDomain
+)
+
+func setupOutbox(t *testing.T) {
+	outboxRepoMock = mocks.NewOutboxRepository(t)
+	eventPublisherMock = mocks.NewEventPublisher(t)
+	outboxUsecase = V1Usecases.NewOutboxUsecase(outboxRepoMock, eventPublisherMock, time.Minute, 10*time.Second, time.Hour)
+
+	depositEventFromDB = V1Domains.EventDomain{
+		Id:            "eeee-vvvv-0001",
+		EventType:     constants.EventTransactionDepositCompleted,
+		AggregateType: constants.OutboxAggregateTransaction,
+		AggregateId:   "tttt-rrrr-0001",
+		Payload:       []byte(`{"transaction_id":"tttt-rrrr-0001"}`),
+		Version:       constants.TransactionEventVersion,
+		Status:        constants.OutboxStatusPending,
+		CreatedAt:     time.Now(),
+	}
+	purchaseEventFromDB = V1Domains.EventDomain{
+		Id:            "eeee-vvvv-0002",
+		EventType:     constants.EventTransactionPurchaseCompleted,
+		AggregateType: constants.OutboxAggregateTransaction,
+		AggregateId:   "tttt-rrrr-0002",
+		Payload:       []byte(`{"transaction_id":"tttt-rrrr-0002"}`),
+		Version:       constants.TransactionEventVersion,
+		Status:        constants.OutboxStatusPending,
+		Attempts:      3,
+		CreatedAt:     time.Now(),
+	}
+}
+
+func TestRelayOutbox(t *testing.T) {
+	setupOutbox(t)
+
+	t.Run("When Success Publish All Pending Events", func(t *testing.T) {
+		now := time.Now()
+		outboxRepoMock.Mock.On("ClaimPending", mock.Anything, now, time.Minute, constants.OutboxRelayBatchSize).Return([]V1Domains.EventDomain{depositEventFromDB, purchaseEventFromDB}, nil).Once()
+		eventPublisherMock.Mock.On("Publish", mock.Anything, depositEventFromDB).Return(nil).Once()
+		eventPublisherMock.Mock.On("Publish", mock.Anything, purchaseEventFromDB).Return(nil).Once()
+		outboxRepoMock.Mock.On("MarkSent", mock.Anything, depositEventFromDB.Id, mock.AnythingOfType("time.Time")).Return(nil).Once()
+		outboxRepoMock.Mock.On("MarkSent", mock.Anything, purchaseEventFromDB.Id, mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+		sent, failed, err := outboxUsecase.Relay(context.Background(), now)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 2, sent)
+		assert.Equal(t, 0, failed)
+	})
+
+	t.Run("When Publish Failed Event Is Rescheduled With Backoff", func(t *testing.T) {
+		now := time.Now()
+		outboxRepoMock.Mock.On("ClaimPending", mock.Anything, now, time.Minute, constants.OutboxRelayBatchSize).Return([]V1Domains.EventDomain{depositEventFromDB, purchaseEventFromDB}, nil).Once()
+		eventPublisherMock.Mock.On("Publish", mock.Anything, depositEventFromDB).Return(errors.New("broker unavailable")).Once()
+		eventPublisherMock.Mock.On("Publish", mock.Anything, purchaseEventFromDB).Return(errors.New("broker unavailable")).Once()
+		outboxRepoMock.Mock.On("MarkFailed", mock.Anything, depositEventFromDB.Id, "broker unavailable", mock.MatchedBy(func(retryAt time.Time) bool {
+			// percobaan pertama menunggu retry interval
+			delay := time.Until(retryAt)
+			return delay > 9*time.Second && delay <= 10*time.Second
+		})).Return(nil).Once()
+		outboxRepoMock.Mock.On("MarkFailed", mock.Anything, purchaseEventFromDB.Id, "broker unavailable", mock.MatchedBy(func(retryAt time.Time) bool {
+			// percobaan keempat menunggu 10s * 2^3
+			delay := time.Until(retryAt)
+			return delay > 79*time.Second && delay <= 80*time.Second
+		})).Return(nil).Once()
+
+		sent, failed, err := outboxUsecase.Relay(context.Background(), now)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 0, sent)
+		assert.Equal(t, 2, failed)
+	})
+
+	t.Run("When Backoff Reaches Maximum Retry Delay", func(t *testing.T) {
+		now := time.Now()
+		event := depositEventFromDB
+		event.Attempts = 20
+		outboxRepoMock.Mock.On("ClaimPending", mock.Anything, now, time.Minute, constants.OutboxRelayBatchSize).Return([]V1Domains.EventDomain{event}, nil).Once()
+		eventPublisherMock.Mock.On("Publish", mock.Anything, event).Return(errors.New("broker unavailable")).Once()
+		outboxRepoMock.Mock.On("MarkFailed", mock.Anything, event.Id, "broker unavailable", mock.MatchedBy(func(retryAt time.Time) bool {
+			delay := time.Until(retryAt)
+			return delay > 59*time.Minute && delay <= time.Hour
+		})).Return(nil).Once()
+
+		sent, failed, err := outboxUsecase.Relay(context.Background(), now)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 0, sent)
+		assert.Equal(t, 1, failed)
+	})
+
+	t.Run("When Failure Mark Sent", func(t *testing.T) {
+		now := time.Now()
+		outboxRepoMock.Mock.On("ClaimPending", mock.Anything, now, time.Minute, constants.OutboxRelayBatchSize).Return([]V1Domains.EventDomain{depositEventFromDB, purchaseEventFromDB}, nil).Once()
+		eventPublisherMock.Mock.On("Publish", mock.Anything, depositEventFromDB).Return(nil).Once()
+		outboxRepoMock.Mock.On("MarkSent", mock.Anything, depositEventFromDB.Id, mock.AnythingOfType("time.Time")).Return(errors.New("connection reset")).Once()
+
+		// event tetap pending dan akan dikirim ulang setelah lease habis
+		sent, failed, err := outboxUsecase.Relay(context.Background(), now)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, 0, sent)
+		assert.Equal(t, 0, failed)
+	})
+
+	t.Run("When Failure Claim Pending Events", func(t *testing.T) {
+		now := time.Now()
+		outboxRepoMock.Mock.On("ClaimPending", mock.Anything, now, time.Minute, constants.OutboxRelayBatchSize).Return(nil, errors.New("connection refused")).Once()
+
+		sent, failed, err := outboxUsecase.Relay(context.Background(), now)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, 0, sent)
+		assert.Equal(t, 0, failed)
+	})
+}
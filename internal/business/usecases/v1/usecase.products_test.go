@@ -13,6 +13,7 @@ import (
 	V1Usecases "github.com/snykk/transaction-api/internal/business/usecases/v1"
 	"github.com/snykk/transaction-api/internal/http/datatransfers/requests"
 	"github.com/snykk/transaction-api/internal/mocks"
+	"github.com/snykk/transaction-api/pkg/money"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -34,7 +35,7 @@ func setupProduct(t *testing.T) {
 			Id:          1,
 			Name:        "keyboard",
 			Description: "lorem ipsum dolor sit amet",
-			Price:       20.0,
+			Price:       money.FromMajor(20),
 			Stock:       234,
 			CreatedAt:   currentTime,
 			UpdatedAt:   &currentTime,
@@ -43,7 +44,7 @@ func setupProduct(t *testing.T) {
 			Id:          2,
 			Name:        "mouse",
 			Description: "lorem ipsum dolor sit amet",
-			Price:       29.0,
+			Price:       money.FromMajor(29),
 			Stock:       10,
 			CreatedAt:   currentTime,
 			UpdatedAt:   &currentTime,
@@ -58,7 +59,7 @@ func TestStoreProduct(t *testing.T) {
 
 	req := requests.ProductRequest{
 		Name:        "keyboard",
-		Price:       20.0,
+		Price:       money.FromMajor(20),
 		Stock:       234,
 		Description: "lorem ipsum dolor sit amet",
 	}
@@ -0,0 +1,67 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+	"github.com/snykk/transaction-api/internal/utils"
+)
+
+type reconciliationUsecase struct {
+	repo V1Domains.ReconciliationRepository
+}
+
+func NewReconciliationUsecase(repo V1Domains.ReconciliationRepository) V1Domains.ReconciliationUsecase {
+	return &reconciliationUsecase{
+		repo: repo,
+	}
+}
+
+// Run memproses wallet per batch sampai habis. Jika satu batch gagal, run ditandai failed
+// dengan hasil batch sebelumnya tetap tersimpan, lalu error dikembalikan ke pemanggil.
+func (uc *reconciliationUsecase) Run(ctx context.Context, trigger string) (outDom V1Domains.ReconciliationRunDomain, err error) {
+	run, err := uc.repo.CreateRun(ctx, trigger)
+	if err != nil {
+		return V1Domains.ReconciliationRunDomain{}, err
+	}
+
+	afterWalletId := ""
+	for {
+		batch, err := uc.repo.ReconcileBatch(ctx, run.Id, afterWalletId, constants.ReconciliationBatchSize)
+		if err != nil {
+			return uc.fail(ctx, run, err)
+		}
+
+		if batch.WalletsChecked < constants.ReconciliationBatchSize {
+			break
+		}
+		afterWalletId = batch.LastWalletId
+	}
+
+	return uc.repo.FinishRun(ctx, run.Id, constants.ReconciliationStatusCompleted, nil)
+}
+
+// fail tetap menutup run walau ctx sudah dibatalkan (misal proses menerima SIGTERM)
+// agar run tidak tertinggal dengan status running
+func (uc *reconciliationUsecase) fail(ctx context.Context, run V1Domains.ReconciliationRunDomain, cause error) (V1Domains.ReconciliationRunDomain, error) {
+	message := cause.Error()
+	failedRun, err := uc.repo.FinishRun(context.WithoutCancel(ctx), run.Id, constants.ReconciliationStatusFailed, &message)
+	if err != nil {
+		return run, errors.Join(cause, err)
+	}
+
+	return failedRun, cause
+}
+
+func (uc *reconciliationUsecase) GetLatest(ctx context.Context) (outDom V1Domains.ReconciliationRunDomain, statusCode int, err error) {
+	outDom, err = uc.repo.GetLatestRun(ctx, constants.ReconciliationReportItemLimit)
+	if err != nil {
+		statusCode, _ = utils.MapDBError(err)
+		return V1Domains.ReconciliationRunDomain{}, statusCode, err
+	}
+
+	return outDom, http.StatusOK, nil
+}
@@ -0,0 +1,178 @@
+package v1_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	V1Usecases "github.com/snykk/transaction-api/internal/business/usecases/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+	PostgresRepo "github.com/snykk/transaction-api/internal/datasources/repositories/postgres/v1"
+	"github.com/snykk/transaction-api/internal/mocks"
+	"github.com/snykk/transaction-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	reconciliationRepoMock *mocks.ReconciliationRepository
+	reconciliationUsecase  V1Domains.ReconciliationUsecase
+	reconciliationRun      V1Domains.ReconciliationRunDomain
+)
+
+func setupReconciliation(t *testing.T) {
+	reconciliationRepoMock = mocks.NewReconciliationRepository(t)
+	reconciliationUsecase = V1Usecases.NewReconciliationUsecase(reconciliationRepoMock)
+
+	reconciliationRun = V1Domains.ReconciliationRunDomain{
+		Id:        "rrrr-uuuu-nnnn",
+		Trigger:   constants.ReconciliationTriggerCron,
+		Status:    constants.ReconciliationStatusRunning,
+		StartedAt: time.Now(),
+	}
+}
+
+func TestRunReconciliation(t *testing.T) {
+	setupReconciliation(t)
+
+	t.Run("When Success Across Batches", func(t *testing.T) {
+		finishedRun := reconciliationRun
+		finishedRun.Status = constants.ReconciliationStatusCompleted
+		finishedRun.WalletsChecked = constants.ReconciliationBatchSize + 3
+		finishedRun.Discrepancies = 1
+
+		reconciliationRepoMock.Mock.On("CreateRun", mock.Anything, constants.ReconciliationTriggerCron).Return(reconciliationRun, nil).Once()
+		// Batch penuh berarti masih ada wallet berikutnya, batch berikutnya dimulai setelah wallet terakhir
+		reconciliationRepoMock.Mock.On("ReconcileBatch", mock.Anything, reconciliationRun.Id, "", constants.ReconciliationBatchSize).
+			Return(V1Domains.ReconciliationBatchDomain{WalletsChecked: constants.ReconciliationBatchSize, Discrepancies: 1, LastWalletId: "wallet-500"}, nil).Once()
+		reconciliationRepoMock.Mock.On("ReconcileBatch", mock.Anything, reconciliationRun.Id, "wallet-500", constants.ReconciliationBatchSize).
+			Return(V1Domains.ReconciliationBatchDomain{WalletsChecked: 3, LastWalletId: "wallet-503"}, nil).Once()
+		reconciliationRepoMock.Mock.On("FinishRun", mock.Anything, reconciliationRun.Id, constants.ReconciliationStatusCompleted, (*string)(nil)).Return(finishedRun, nil).Once()
+
+		result, err := reconciliationUsecase.Run(context.Background(), constants.ReconciliationTriggerCron)
+
+		assert.Nil(t, err)
+		assert.Equal(t, constants.ReconciliationStatusCompleted, result.Status)
+		assert.Equal(t, 1, result.Discrepancies)
+	})
+
+	t.Run("When Batch Fails", func(t *testing.T) {
+		batchErr := errors.New("connection reset")
+		failedRun := reconciliationRun
+		failedRun.Status = constants.ReconciliationStatusFailed
+
+		reconciliationRepoMock.Mock.On("CreateRun", mock.Anything, constants.ReconciliationTriggerCron).Return(reconciliationRun, nil).Once()
+		reconciliationRepoMock.Mock.On("ReconcileBatch", mock.Anything, reconciliationRun.Id, "", constants.ReconciliationBatchSize).
+			Return(V1Domains.ReconciliationBatchDomain{}, batchErr).Once()
+		// Run ditutup sebagai failed beserta pesan error-nya
+		reconciliationRepoMock.Mock.On("FinishRun", mock.Anything, reconciliationRun.Id, constants.ReconciliationStatusFailed, mock.MatchedBy(func(message *string) bool {
+			return message != nil && *message == "connection reset"
+		})).Return(failedRun, nil).Once()
+
+		result, err := reconciliationUsecase.Run(context.Background(), constants.ReconciliationTriggerCron)
+
+		assert.ErrorIs(t, err, batchErr)
+		assert.Equal(t, constants.ReconciliationStatusFailed, result.Status)
+	})
+
+	t.Run("When Run Cannot Be Created", func(t *testing.T) {
+		reconciliationRepoMock.Mock.On("CreateRun", mock.Anything, constants.ReconciliationTriggerCLI).Return(V1Domains.ReconciliationRunDomain{}, errors.New("insert failed")).Once()
+
+		_, err := reconciliationUsecase.Run(context.Background(), constants.ReconciliationTriggerCLI)
+
+		assert.NotNil(t, err)
+	})
+}
+
+func TestGetLatestReconciliation(t *testing.T) {
+	setupReconciliation(t)
+
+	t.Run("When Success", func(t *testing.T) {
+		reconciliationRepoMock.Mock.On("GetLatestRun", mock.Anything, constants.ReconciliationReportItemLimit).Return(reconciliationRun, nil).Once()
+
+		result, statusCode, err := reconciliationUsecase.GetLatest(context.Background())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, reconciliationRun.Id, result.Id)
+	})
+
+	t.Run("When No Run Yet", func(t *testing.T) {
+		reconciliationRepoMock.Mock.On("GetLatestRun", mock.Anything, constants.ReconciliationReportItemLimit).Return(V1Domains.ReconciliationRunDomain{}, PostgresRepo.ErrReconciliationNotFound).Once()
+
+		_, statusCode, err := reconciliationUsecase.GetLatest(context.Background())
+
+		assert.Equal(t, PostgresRepo.ErrReconciliationNotFound, err)
+		assert.Equal(t, http.StatusNotFound, statusCode)
+	})
+}
+
+func TestWalletBalanceEffect(t *testing.T) {
+	// expectedBalance menjumlahkan efek transaksi seperti yang dilakukan rekonsiliasi
+	expectedBalance := func(transactions []V1Domains.TransactionDomain) money.Money {
+		var balance money.Money
+		for _, transaction := range transactions {
+			balance += transaction.WalletBalanceEffect()
+		}
+		return balance
+	}
+	completed := func(transactionType string, amount string) V1Domains.TransactionDomain {
+		return V1Domains.TransactionDomain{TransactionType: transactionType, Status: constants.TransactionStatusCompleted, Amount: money.MustParse(amount)}
+	}
+
+	t.Run("When Refunded Cashback Purchase Has No Drift", func(t *testing.T) {
+		purchase := completed(constants.TransactionTypePurchase, "50.00")
+		purchase.Fee = money.MustParse("1.00")
+		purchase.Cashback = money.MustParse("5.00")
+		refund := completed(constants.TransactionTypeRefund, "50.00")
+		refund.Cashback = money.MustParse("5.00")
+		refund.Clawback = money.MustParse("5.00")
+
+		// saldo wallet: 100 - 51 + 5, lalu refund menambah 50 dan menarik cashback 5
+		recordedBalance := money.MustParse("99.00")
+		transactions := []V1Domains.TransactionDomain{
+			completed(constants.TransactionTypeDeposit, "100.00"),
+			purchase,
+			completed(constants.TransactionTypeCashback, "5.00"),
+			refund,
+		}
+
+		assert.Equal(t, recordedBalance, expectedBalance(transactions))
+	})
+
+	t.Run("When Refund Claws Back Converted Points Has No Drift", func(t *testing.T) {
+		purchase := completed(constants.TransactionTypePurchase, "50.00")
+		purchase.PointsEarned = money.MustParse("10.00")
+		refund := completed(constants.TransactionTypeRefund, "50.00")
+		refund.PointsEarned = money.MustParse("10.00")
+		refund.Clawback = money.MustParse("10.00")
+
+		// poin pembelian sudah dikonversi ke saldo sehingga kekurangannya dipotong dari dana refund
+		recordedBalance := money.MustParse("100.00")
+		transactions := []V1Domains.TransactionDomain{
+			completed(constants.TransactionTypeDeposit, "100.00"),
+			purchase,
+			completed(constants.TransactionTypePointsConversion, "10.00"),
+			refund,
+		}
+
+		assert.Equal(t, recordedBalance, expectedBalance(transactions))
+	})
+
+	t.Run("When Pending And Failed Transactions", func(t *testing.T) {
+		pendingWithdraw := completed(constants.TransactionTypeWithdraw, "20.00")
+		pendingWithdraw.Status = constants.TransactionStatusPending
+		pendingWithdraw.Fee = money.MustParse("2.00")
+		failedWithdraw := completed(constants.TransactionTypeWithdraw, "30.00")
+		failedWithdraw.Status = constants.TransactionStatusFailed
+		pendingDeposit := completed(constants.TransactionTypeDeposit, "40.00")
+		pendingDeposit.Status = constants.TransactionStatusPending
+
+		assert.Equal(t, money.MustParse("-22.00"), pendingWithdraw.WalletBalanceEffect())
+		assert.True(t, failedWithdraw.WalletBalanceEffect().IsZero())
+		assert.True(t, pendingDeposit.WalletBalanceEffect().IsZero())
+	})
+}
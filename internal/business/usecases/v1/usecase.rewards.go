@@ -0,0 +1,146 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+	"github.com/snykk/transaction-api/internal/utils"
+	"github.com/snykk/transaction-api/pkg/money"
+)
+
+type rewardUsecase struct {
+	repo V1Domains.RewardRepository
+}
+
+func NewRewardUsecase(repo V1Domains.RewardRepository) V1Domains.RewardUsecase {
+	return &rewardUsecase{
+		repo: repo,
+	}
+}
+
+func (uc *rewardUsecase) GetAllRules(ctx context.Context) (outDoms []V1Domains.RewardRuleDomain, statusCode int, err error) {
+	outDoms, err = uc.repo.GetAllRules(ctx)
+	if err != nil {
+		statusCode, _ = utils.MapDBError(err)
+		return nil, statusCode, err
+	}
+
+	return outDoms, http.StatusOK, nil
+}
+
+func (uc *rewardUsecase) GetRuleById(ctx context.Context, id int) (outDom V1Domains.RewardRuleDomain, statusCode int, err error) {
+	outDom, err = uc.repo.GetRuleById(ctx, id)
+	if err != nil {
+		statusCode, _ = utils.MapDBError(err)
+		return V1Domains.RewardRuleDomain{}, statusCode, err
+	}
+
+	return outDom, http.StatusOK, nil
+}
+
+func (uc *rewardUsecase) StoreRule(ctx context.Context, ruleDom *V1Domains.RewardRuleDomain) (outDom V1Domains.RewardRuleDomain, statusCode int, err error) {
+	if err = validateRewardRule(ruleDom); err != nil {
+		return V1Domains.RewardRuleDomain{}, http.StatusBadRequest, err
+	}
+
+	outDom, err = uc.repo.StoreRule(ctx, *ruleDom)
+	if err != nil {
+		statusCode, _ = utils.MapDBError(err)
+		return V1Domains.RewardRuleDomain{}, statusCode, err
+	}
+
+	return outDom, http.StatusCreated, nil
+}
+
+func (uc *rewardUsecase) UpdateRule(ctx context.Context, id int, ruleDom *V1Domains.RewardRuleDomain) (outDom V1Domains.RewardRuleDomain, statusCode int, err error) {
+	if err = validateRewardRule(ruleDom); err != nil {
+		return V1Domains.RewardRuleDomain{}, http.StatusBadRequest, err
+	}
+
+	ruleDom.Id = id
+	outDom, err = uc.repo.UpdateRule(ctx, *ruleDom)
+	if err != nil {
+		statusCode, _ = utils.MapDBError(err)
+		return V1Domains.RewardRuleDomain{}, statusCode, err
+	}
+
+	return outDom, http.StatusOK, nil
+}
+
+func (uc *rewardUsecase) DeleteRule(ctx context.Context, id int) (statusCode int, err error) {
+	err = uc.repo.DeleteRule(ctx, id)
+	if err != nil {
+		statusCode, _ = utils.MapDBError(err)
+		return statusCode, err
+	}
+
+	return http.StatusOK, nil
+}
+
+func (uc *rewardUsecase) GetPointEntries(ctx context.Context, userId string, walletId string) (outDoms []V1Domains.PointEntryDomain, statusCode int, err error) {
+	outDoms, err = uc.repo.GetPointEntries(ctx, userId, walletId)
+	if err != nil {
+		statusCode, _ = utils.MapDBError(err)
+		return nil, statusCode, err
+	}
+
+	return outDoms, http.StatusOK, nil
+}
+
+func (uc *rewardUsecase) ConvertPoints(ctx context.Context, userId string, walletId string, points money.Money) (outDom V1Domains.TransactionDomain, statusCode int, err error) {
+	if !points.IsPositive() {
+		return V1Domains.TransactionDomain{}, http.StatusBadRequest, ErrPointsMustBePositive
+	}
+
+	outDom, err = uc.repo.ConvertPoints(ctx, userId, walletId, points)
+	if err != nil {
+		statusCode, _ = utils.MapDBError(err)
+		return V1Domains.TransactionDomain{}, statusCode, err
+	}
+
+	return outDom, http.StatusCreated, nil
+}
+
+// ExpirePoints memproses lot poin kedaluwarsa per batch sampai tidak ada lagi yang tersisa
+func (uc *rewardUsecase) ExpirePoints(ctx context.Context, now time.Time) (expired []V1Domains.PointEntryDomain, err error) {
+	for {
+		batch, err := uc.repo.ExpirePoints(ctx, now, constants.PointExpiryBatchSize)
+		if err != nil {
+			return expired, err
+		}
+		expired = append(expired, batch...)
+
+		if len(batch) < constants.PointExpiryBatchSize {
+			return expired, nil
+		}
+	}
+}
+
+// validateRewardRule memeriksa cakupan, rate, batas bulanan, dan masa berlaku poin
+func validateRewardRule(ruleDom *V1Domains.RewardRuleDomain) error {
+	if ruleDom.RewardType != constants.RewardTypePoints && ruleDom.RewardType != constants.RewardTypeCashback {
+		return ErrRewardTypeInvalid
+	}
+
+	if ruleDom.ProductId != nil && ruleDom.Category != nil {
+		return ErrRewardScopeAmbiguous
+	}
+
+	if ruleDom.RateBps <= 0 || ruleDom.RateBps > constants.FeeBasisPointsPerUnit {
+		return ErrRewardRateInvalid
+	}
+	if ruleDom.MonthlyCap != nil && !ruleDom.MonthlyCap.IsPositive() {
+		return ErrRewardMonthlyCapInvalid
+	}
+
+	// Masa berlaku hanya bermakna untuk poin, cashback langsung menjadi saldo
+	hasExpiry := ruleDom.PointsExpiryDays != nil && *ruleDom.PointsExpiryDays > 0
+	if hasExpiry != (ruleDom.RewardType == constants.RewardTypePoints) {
+		return ErrRewardExpiryRequired
+	}
+
+	return nil
+}
@@ -0,0 +1,171 @@
+package v1_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	V1Usecases "github.com/snykk/transaction-api/internal/business/usecases/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+	PostgresRepo "github.com/snykk/transaction-api/internal/datasources/repositories/postgres/v1"
+	"github.com/snykk/transaction-api/internal/mocks"
+	"github.com/snykk/transaction-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	rewardRepoMock *mocks.RewardRepository
+	rewardUsecase  V1Domains.RewardUsecase
+)
+
+func setupReward(t *testing.T) {
+	rewardRepoMock = mocks.NewRewardRepository(t)
+	rewardUsecase = V1Usecases.NewRewardUsecase(rewardRepoMock)
+}
+
+func TestStoreRewardRule(t *testing.T) {
+	setupReward(t)
+
+	t.Run("When Success Store Points Rule", func(t *testing.T) {
+		category := "electronics"
+		expiryDays := 90
+		monthlyCap := money.FromMajor(500)
+		ruleDom := V1Domains.RewardRuleDomain{
+			Name:             "electronics points",
+			RewardType:       constants.RewardTypePoints,
+			Category:         &category,
+			RateBps:          200,
+			MonthlyCap:       &monthlyCap,
+			PointsExpiryDays: &expiryDays,
+			IsActive:         true,
+		}
+
+		rewardRepoMock.Mock.On("StoreRule", mock.Anything, ruleDom).Return(V1Domains.RewardRuleDomain{Id: 1, Name: ruleDom.Name}, nil).Once()
+
+		result, statusCode, err := rewardUsecase.StoreRule(context.Background(), &ruleDom)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, statusCode)
+		assert.Equal(t, 1, result.Id)
+	})
+
+	t.Run("When Failure Validation", func(t *testing.T) {
+		productId := 1
+		category := "electronics"
+		expiryDays := 30
+		zeroCap := money.Money(0)
+
+		testCases := []struct {
+			name     string
+			ruleDom  V1Domains.RewardRuleDomain
+			expected error
+		}{
+			{"Invalid Reward Type", V1Domains.RewardRuleDomain{RewardType: "voucher", RateBps: 100}, V1Usecases.ErrRewardTypeInvalid},
+			{"Product And Category", V1Domains.RewardRuleDomain{RewardType: constants.RewardTypeCashback, ProductId: &productId, Category: &category, RateBps: 100}, V1Usecases.ErrRewardScopeAmbiguous},
+			{"Rate Above 100 Percent", V1Domains.RewardRuleDomain{RewardType: constants.RewardTypeCashback, RateBps: 10001}, V1Usecases.ErrRewardRateInvalid},
+			{"Zero Monthly Cap", V1Domains.RewardRuleDomain{RewardType: constants.RewardTypeCashback, RateBps: 100, MonthlyCap: &zeroCap}, V1Usecases.ErrRewardMonthlyCapInvalid},
+			{"Points Without Expiry", V1Domains.RewardRuleDomain{RewardType: constants.RewardTypePoints, RateBps: 100}, V1Usecases.ErrRewardExpiryRequired},
+			{"Cashback With Expiry", V1Domains.RewardRuleDomain{RewardType: constants.RewardTypeCashback, RateBps: 100, PointsExpiryDays: &expiryDays}, V1Usecases.ErrRewardExpiryRequired},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, statusCode, err := rewardUsecase.StoreRule(context.Background(), &tc.ruleDom)
+
+				assert.Equal(t, tc.expected, err)
+				assert.Equal(t, http.StatusBadRequest, statusCode)
+			})
+		}
+	})
+
+	t.Run("When Failure Product Not Found", func(t *testing.T) {
+		productId := 99
+		ruleDom := V1Domains.RewardRuleDomain{RewardType: constants.RewardTypeCashback, ProductId: &productId, RateBps: 100}
+		rewardRepoMock.Mock.On("StoreRule", mock.Anything, ruleDom).Return(V1Domains.RewardRuleDomain{}, PostgresRepo.ErrProductNotFound).Once()
+
+		_, statusCode, err := rewardUsecase.StoreRule(context.Background(), &ruleDom)
+
+		assert.Equal(t, PostgresRepo.ErrProductNotFound, err)
+		assert.Equal(t, http.StatusNotFound, statusCode)
+	})
+}
+
+func TestUpdateRewardRule(t *testing.T) {
+	setupReward(t)
+
+	t.Run("When Failure Rule Not Found", func(t *testing.T) {
+		ruleDom := V1Domains.RewardRuleDomain{RewardType: constants.RewardTypeCashback, RateBps: 100}
+		rewardRepoMock.Mock.On("UpdateRule", mock.Anything, mock.MatchedBy(func(r V1Domains.RewardRuleDomain) bool {
+			return r.Id == 7
+		})).Return(V1Domains.RewardRuleDomain{}, PostgresRepo.ErrRewardRuleNotFound).Once()
+
+		_, statusCode, err := rewardUsecase.UpdateRule(context.Background(), 7, &ruleDom)
+
+		assert.Equal(t, PostgresRepo.ErrRewardRuleNotFound, err)
+		assert.Equal(t, http.StatusNotFound, statusCode)
+	})
+}
+
+func TestConvertPoints(t *testing.T) {
+	setupReward(t)
+
+	t.Run("When Success Convert Points", func(t *testing.T) {
+		points := money.FromMajor(25)
+		conversion := V1Domains.TransactionDomain{Id: "tttt-cccc-vvvv", WalletId: "wwww-aaaa-llll", Amount: points, PointsRedeemed: points, TransactionType: constants.TransactionTypePointsConversion}
+		rewardRepoMock.Mock.On("ConvertPoints", mock.Anything, "aaaa-bbbb-cccc", "", points).Return(conversion, nil).Once()
+
+		result, statusCode, err := rewardUsecase.ConvertPoints(context.Background(), "aaaa-bbbb-cccc", "", points)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, statusCode)
+		assert.Equal(t, conversion, result)
+	})
+
+	t.Run("When Failure Points Not Positive", func(t *testing.T) {
+		_, statusCode, err := rewardUsecase.ConvertPoints(context.Background(), "aaaa-bbbb-cccc", "", 0)
+
+		assert.Equal(t, V1Usecases.ErrPointsMustBePositive, err)
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+	})
+
+	t.Run("When Failure Insufficient Points", func(t *testing.T) {
+		points := money.FromMajor(1000)
+		rewardRepoMock.Mock.On("ConvertPoints", mock.Anything, "aaaa-bbbb-cccc", "", points).Return(V1Domains.TransactionDomain{}, PostgresRepo.ErrInsufficientPoints).Once()
+
+		_, statusCode, err := rewardUsecase.ConvertPoints(context.Background(), "aaaa-bbbb-cccc", "", points)
+
+		assert.Equal(t, PostgresRepo.ErrInsufficientPoints, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, statusCode)
+	})
+}
+
+func TestExpirePoints(t *testing.T) {
+	setupReward(t)
+	now := time.Now()
+
+	t.Run("When Success Expire In Batches", func(t *testing.T) {
+		fullBatch := make([]V1Domains.PointEntryDomain, constants.PointExpiryBatchSize)
+		lastBatch := []V1Domains.PointEntryDomain{{Id: "eeee-0001", EntryType: constants.PointEntryExpire, Points: money.FromMajor(-5)}}
+		rewardRepoMock.Mock.On("ExpirePoints", mock.Anything, now, constants.PointExpiryBatchSize).Return(fullBatch, nil).Once()
+		rewardRepoMock.Mock.On("ExpirePoints", mock.Anything, now, constants.PointExpiryBatchSize).Return(lastBatch, nil).Once()
+
+		expired, err := rewardUsecase.ExpirePoints(context.Background(), now)
+
+		assert.Nil(t, err)
+		assert.Len(t, expired, constants.PointExpiryBatchSize+1)
+	})
+
+	t.Run("When Failure Repository Error", func(t *testing.T) {
+		repoErr := errors.New("connection reset")
+		rewardRepoMock.Mock.On("ExpirePoints", mock.Anything, now, constants.PointExpiryBatchSize).Return(nil, repoErr).Once()
+
+		expired, err := rewardUsecase.ExpirePoints(context.Background(), now)
+
+		assert.Equal(t, repoErr, err)
+		assert.Empty(t, expired)
+	})
+}
@@ -0,0 +1,203 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+	"github.com/snykk/transaction-api/internal/utils"
+)
+
+type scheduleUsecase struct {
+	repo          V1Domains.ScheduleRepository
+	maxRetries    int           // percobaan ulang per jadwal setelah percobaan pertama gagal
+	retryInterval time.Duration // jeda percobaan ulang pertama, berlipat dua setiap percobaan berikutnya
+}
+
+func NewScheduleUsecase(repo V1Domains.ScheduleRepository, maxRetries int, retryInterval time.Duration) V1Domains.ScheduleUsecase {
+	return &scheduleUsecase{
+		repo:          repo,
+		maxRetries:    maxRetries,
+		retryInterval: retryInterval,
+	}
+}
+
+func (uc *scheduleUsecase) Store(ctx context.Context, scheduleDom *V1Domains.ScheduleDomain) (outDom V1Domains.ScheduleDomain, statusCode int, err error) {
+	if !scheduleDom.Amount.IsPositive() {
+		return V1Domains.ScheduleDomain{}, http.StatusBadRequest, ErrAmountMustGreateThanZero
+	}
+	if scheduleDom.RecipientUserId == "" && scheduleDom.RecipientUsername == "" && scheduleDom.RecipientEmail == "" {
+		return V1Domains.ScheduleDomain{}, http.StatusBadRequest, ErrRecipientRequired
+	}
+	if !scheduleDom.StartAt.After(time.Now()) {
+		return V1Domains.ScheduleDomain{}, http.StatusBadRequest, ErrScheduleStartInPast
+	}
+	if scheduleDom.Frequency == constants.ScheduleFrequencyOnce && (scheduleDom.EndAt != nil || scheduleDom.MaxRuns != nil) {
+		return V1Domains.ScheduleDomain{}, http.StatusBadRequest, ErrScheduleLimitForOnce
+	}
+	if scheduleDom.EndAt != nil && scheduleDom.EndAt.Before(scheduleDom.StartAt) {
+		return V1Domains.ScheduleDomain{}, http.StatusBadRequest, ErrScheduleEndBeforeStart
+	}
+
+	scheduleDom.Status = constants.ScheduleStatusActive
+	scheduleDom.NextRunAt = &scheduleDom.StartAt
+
+	outDom, err = uc.repo.Store(ctx, *scheduleDom)
+	if err != nil {
+		statusCode, _ = utils.MapDBError(err)
+		return V1Domains.ScheduleDomain{}, statusCode, err
+	}
+
+	return outDom, http.StatusCreated, nil
+}
+
+func (uc *scheduleUsecase) GetAll(ctx context.Context, userId string) (outDoms []V1Domains.ScheduleDomain, statusCode int, err error) {
+	outDoms, err = uc.repo.GetByUserId(ctx, userId)
+	if err != nil {
+		statusCode, _ = utils.MapDBError(err)
+		return nil, statusCode, err
+	}
+
+	return outDoms, http.StatusOK, nil
+}
+
+func (uc *scheduleUsecase) GetById(ctx context.Context, scheduleId string, userId string) (outDom V1Domains.ScheduleDomain, statusCode int, err error) {
+	outDom, err = uc.repo.GetById(ctx, scheduleId, userId)
+	if err != nil {
+		statusCode, _ = utils.MapDBError(err)
+		return V1Domains.ScheduleDomain{}, statusCode, err
+	}
+
+	return outDom, http.StatusOK, nil
+}
+
+func (uc *scheduleUsecase) Update(ctx context.Context, scheduleId string, userId string, updateDom V1Domains.ScheduleUpdateDomain) (outDom V1Domains.ScheduleDomain, statusCode int, err error) {
+	if updateDom.Amount != nil && !updateDom.Amount.IsPositive() {
+		return V1Domains.ScheduleDomain{}, http.StatusBadRequest, ErrAmountMustGreateThanZero
+	}
+
+	outDom, err = uc.repo.Update(ctx, scheduleId, userId, func(schedule V1Domains.ScheduleDomain) (V1Domains.ScheduleDomain, error) {
+		if !schedule.IsModifiable() {
+			return schedule, ErrScheduleNotModifiable
+		}
+		if schedule.Frequency == constants.ScheduleFrequencyOnce && (updateDom.EndAt != nil || updateDom.MaxRuns != nil) {
+			return schedule, ErrScheduleLimitForOnce
+		}
+		if updateDom.EndAt != nil && updateDom.EndAt.Before(schedule.StartAt) {
+			return schedule, ErrScheduleEndBeforeStart
+		}
+		if updateDom.MaxRuns != nil && *updateDom.MaxRuns <= schedule.OccurrenceCount {
+			return schedule, ErrScheduleMaxRunsReached
+		}
+
+		if updateDom.Amount != nil {
+			schedule.Amount = *updateDom.Amount
+		}
+		if updateDom.EndAt != nil {
+			schedule.EndAt = updateDom.EndAt
+		}
+		if updateDom.MaxRuns != nil {
+			schedule.MaxRuns = updateDom.MaxRuns
+		}
+		if updateDom.Status != nil {
+			schedule.Status = *updateDom.Status
+		}
+
+		// Jadwal yang sedang dicoba ulang tetap memakai waktu percobaan berikutnya
+		if schedule.Attempt == 0 {
+			schedule.NextRunAt = schedule.NextOccurrence()
+		}
+		if schedule.NextRunAt == nil {
+			return schedule, ErrScheduleNoRemainingRuns
+		}
+
+		return schedule, nil
+	})
+	if err != nil {
+		return V1Domains.ScheduleDomain{}, scheduleStatusCode(err), err
+	}
+
+	return outDom, http.StatusOK, nil
+}
+
+func (uc *scheduleUsecase) Cancel(ctx context.Context, scheduleId string, userId string) (outDom V1Domains.ScheduleDomain, statusCode int, err error) {
+	outDom, err = uc.repo.Update(ctx, scheduleId, userId, func(schedule V1Domains.ScheduleDomain) (V1Domains.ScheduleDomain, error) {
+		if !schedule.IsModifiable() {
+			return schedule, ErrScheduleNotModifiable
+		}
+
+		schedule.Status = constants.ScheduleStatusCancelled
+		schedule.NextRunAt = nil
+
+		return schedule, nil
+	})
+	if err != nil {
+		return V1Domains.ScheduleDomain{}, scheduleStatusCode(err), err
+	}
+
+	return outDom, http.StatusOK, nil
+}
+
+// scheduleStatusCode memetakan error validasi yang terjadi saat schedule dikunci maupun error repository
+func scheduleStatusCode(err error) int {
+	switch err {
+	case ErrScheduleNotModifiable:
+		return http.StatusConflict
+	case ErrScheduleLimitForOnce, ErrScheduleEndBeforeStart, ErrScheduleMaxRunsReached, ErrScheduleNoRemainingRuns:
+		return http.StatusBadRequest
+	}
+
+	statusCode, _ := utils.MapDBError(err)
+	return statusCode
+}
+
+func (uc *scheduleUsecase) RunDue(ctx context.Context, now time.Time, onRun func(V1Domains.ScheduleRunDomain)) (executed int, err error) {
+	for executed < constants.ScheduleBatchSize && ctx.Err() == nil {
+		run, err := uc.repo.ExecuteNext(ctx, now, uc.applyRun)
+		if err != nil {
+			return executed, err
+		}
+		if run == nil {
+			break
+		}
+
+		executed++
+		if onRun != nil {
+			onRun(*run)
+		}
+	}
+
+	return executed, nil
+}
+
+// applyRun menentukan keadaan schedule setelah satu eksekusi:
+//   - berhasil: lanjut ke jadwal berikutnya
+//   - gagal dan masih bisa dicoba: dicoba lagi setelah retryInterval * 2^(attempt-1)
+//   - gagal dan tidak bisa dicoba lagi: jadwal ini dilewati, schedule sekali jalan dianggap failed
+func (uc *scheduleUsecase) applyRun(schedule V1Domains.ScheduleDomain, run V1Domains.ScheduleRunDomain) V1Domains.ScheduleDomain {
+	schedule.LastRunAt = &run.ExecutedAt
+	schedule.LastError = run.Error
+
+	if run.Status == constants.ScheduleRunStatusFailed && run.Retryable && run.Attempt <= uc.maxRetries {
+		retryAt := run.ExecutedAt.Add(uc.retryInterval << (run.Attempt - 1))
+		schedule.Attempt = run.Attempt
+		schedule.NextRunAt = &retryAt
+		return schedule
+	}
+
+	schedule.Attempt = 0
+	schedule.OccurrenceCount++
+	schedule.NextRunAt = schedule.NextOccurrence()
+	if schedule.NextRunAt != nil {
+		return schedule
+	}
+
+	schedule.Status = constants.ScheduleStatusCompleted
+	if run.Status == constants.ScheduleRunStatusFailed && schedule.Frequency == constants.ScheduleFrequencyOnce {
+		schedule.Status = constants.ScheduleStatusFailed
+	}
+
+	return schedule
+}
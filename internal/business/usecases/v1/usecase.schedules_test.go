@@ -0,0 +1,325 @@
+package v1_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	V1Usecases "github.com/snykk/transaction-api/internal/business/usecases/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+	PostgresRepo "github.com/snykk/transaction-api/internal/datasources/repositories/postgres/v1"
+	"github.com/snykk/transaction-api/internal/mocks"
+	"github.com/snykk/transaction-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	scheduleRepoMock   *mocks.ScheduleRepository
+	scheduleUsecase    V1Domains.ScheduleUsecase
+	scheduleDataFromDB V1Domains.ScheduleDomain
+	scheduleStartAt    time.Time
+)
+
+func setupSchedule(t *testing.T) {
+	scheduleRepoMock = mocks.NewScheduleRepository(t)
+	scheduleUsecase = V1Usecases.NewScheduleUsecase(scheduleRepoMock, 2, 15*time.Minute)
+
+	scheduleStartAt = time.Date(2024, 1, 31, 9, 0, 0, 0, time.UTC)
+	scheduleDataFromDB = V1Domains.ScheduleDomain{
+		Id:              "ssss-cccc-hhhh",
+		UserId:          "aaaa-bbbb-cccc",
+		RecipientUserId: "dddd-eeee-ffff",
+		Amount:          money.FromMajor(100),
+		Frequency:       constants.ScheduleFrequencyMonthly,
+		StartAt:         scheduleStartAt,
+		NextRunAt:       &scheduleStartAt,
+		Status:          constants.ScheduleStatusActive,
+	}
+}
+
+// applyUpdate meniru repository.Update yang menjalankan apply terhadap schedule yang terkunci
+func applyUpdate(current V1Domains.ScheduleDomain) func(context.Context, string, string, func(V1Domains.ScheduleDomain) (V1Domains.ScheduleDomain, error)) (V1Domains.ScheduleDomain, error) {
+	return func(_ context.Context, _ string, _ string, apply func(V1Domains.ScheduleDomain) (V1Domains.ScheduleDomain, error)) (V1Domains.ScheduleDomain, error) {
+		updated, err := apply(current)
+		if err != nil {
+			return V1Domains.ScheduleDomain{}, err
+		}
+		return updated, nil
+	}
+}
+
+// executeWith meniru repository.ExecuteNext untuk satu run, hasil apply disimpan ke result
+func executeWith(current V1Domains.ScheduleDomain, run V1Domains.ScheduleRunDomain, result *V1Domains.ScheduleDomain) func(context.Context, time.Time, func(V1Domains.ScheduleDomain, V1Domains.ScheduleRunDomain) V1Domains.ScheduleDomain) (*V1Domains.ScheduleRunDomain, error) {
+	return func(_ context.Context, _ time.Time, apply func(V1Domains.ScheduleDomain, V1Domains.ScheduleRunDomain) V1Domains.ScheduleDomain) (*V1Domains.ScheduleRunDomain, error) {
+		*result = apply(current, run)
+		return &run, nil
+	}
+}
+
+func TestStoreSchedule(t *testing.T) {
+	setupSchedule(t)
+
+	t.Run("When Success Store Schedule", func(t *testing.T) {
+		startAt := time.Now().Add(time.Hour)
+		scheduleDom := V1Domains.ScheduleDomain{
+			UserId:            scheduleDataFromDB.UserId,
+			RecipientUsername: "john",
+			Amount:            money.FromMajor(100),
+			Frequency:         constants.ScheduleFrequencyWeekly,
+			StartAt:           startAt,
+		}
+
+		scheduleRepoMock.Mock.On("Store", mock.Anything, mock.MatchedBy(func(s V1Domains.ScheduleDomain) bool {
+			return s.Status == constants.ScheduleStatusActive && s.NextRunAt != nil && s.NextRunAt.Equal(startAt)
+		})).Return(scheduleDataFromDB, nil).Once()
+
+		result, statusCode, err := scheduleUsecase.Store(context.Background(), &scheduleDom)
+
+		assert.Nil(t, err, "Error should be nil")
+		assert.Equal(t, http.StatusCreated, statusCode, "Status code should be Created (201)")
+		assert.Equal(t, scheduleDataFromDB, result, "Schedule should match")
+	})
+
+	t.Run("When Failure | Invalid Request", func(t *testing.T) {
+		future := time.Now().Add(time.Hour)
+		past := time.Now().Add(-time.Hour)
+		maxRuns := 3
+
+		cases := []struct {
+			name        string
+			scheduleDom V1Domains.ScheduleDomain
+			expectedErr error
+		}{
+			{"Zero Amount", V1Domains.ScheduleDomain{RecipientUsername: "john", Frequency: constants.ScheduleFrequencyOnce, StartAt: future}, V1Usecases.ErrAmountMustGreateThanZero},
+			{"No Recipient", V1Domains.ScheduleDomain{Amount: money.FromMajor(10), Frequency: constants.ScheduleFrequencyOnce, StartAt: future}, V1Usecases.ErrRecipientRequired},
+			{"Start In Past", V1Domains.ScheduleDomain{RecipientUsername: "john", Amount: money.FromMajor(10), Frequency: constants.ScheduleFrequencyOnce, StartAt: past}, V1Usecases.ErrScheduleStartInPast},
+			{"Limit For Once", V1Domains.ScheduleDomain{RecipientUsername: "john", Amount: money.FromMajor(10), Frequency: constants.ScheduleFrequencyOnce, StartAt: future, MaxRuns: &maxRuns}, V1Usecases.ErrScheduleLimitForOnce},
+			{"End Before Start", V1Domains.ScheduleDomain{RecipientUsername: "john", Amount: money.FromMajor(10), Frequency: constants.ScheduleFrequencyDaily, StartAt: future, EndAt: &past}, V1Usecases.ErrScheduleEndBeforeStart},
+		}
+
+		for _, c := range cases {
+			result, statusCode, err := scheduleUsecase.Store(context.Background(), &c.scheduleDom)
+
+			assert.Equal(t, c.expectedErr, err, c.name)
+			assert.Equal(t, http.StatusBadRequest, statusCode, c.name)
+			assert.Equal(t, V1Domains.ScheduleDomain{}, result, c.name)
+		}
+	})
+
+	t.Run("When Failure | Recipient Not Found", func(t *testing.T) {
+		scheduleDom := V1Domains.ScheduleDomain{
+			RecipientUsername: "ghost",
+			Amount:            money.FromMajor(10),
+			Frequency:         constants.ScheduleFrequencyOnce,
+			StartAt:           time.Now().Add(time.Hour),
+		}
+
+		scheduleRepoMock.Mock.On("Store", mock.Anything, mock.AnythingOfType("v1.ScheduleDomain")).Return(V1Domains.ScheduleDomain{}, PostgresRepo.ErrRecipientNotFound).Once()
+
+		_, statusCode, err := scheduleUsecase.Store(context.Background(), &scheduleDom)
+
+		assert.Equal(t, PostgresRepo.ErrRecipientNotFound, err, "Error should be recipient not found")
+		assert.Equal(t, http.StatusNotFound, statusCode, "Status code should be Not Found (404)")
+	})
+}
+
+func TestUpdateSchedule(t *testing.T) {
+	setupSchedule(t)
+
+	t.Run("When Success Pause And Change Amount", func(t *testing.T) {
+		amount := money.FromMajor(250)
+		paused := constants.ScheduleStatusPaused
+
+		scheduleRepoMock.Mock.On("Update", mock.Anything, scheduleDataFromDB.Id, scheduleDataFromDB.UserId, mock.Anything).Return(applyUpdate(scheduleDataFromDB), nil).Once()
+
+		result, statusCode, err := scheduleUsecase.Update(context.Background(), scheduleDataFromDB.Id, scheduleDataFromDB.UserId, V1Domains.ScheduleUpdateDomain{Amount: &amount, Status: &paused})
+
+		assert.Nil(t, err, "Error should be nil")
+		assert.Equal(t, http.StatusOK, statusCode, "Status code should be OK (200)")
+		assert.Equal(t, amount, result.Amount, "Amount should be updated")
+		assert.Equal(t, constants.ScheduleStatusPaused, result.Status, "Status should be paused")
+		assert.True(t, result.NextRunAt.Equal(scheduleStartAt), "Next run should stay on the first occurrence")
+	})
+
+	t.Run("When Failure | Schedule Already Completed", func(t *testing.T) {
+		completed := scheduleDataFromDB
+		completed.Status = constants.ScheduleStatusCompleted
+		amount := money.FromMajor(250)
+
+		scheduleRepoMock.Mock.On("Update", mock.Anything, completed.Id, completed.UserId, mock.Anything).Return(applyUpdate(completed), nil).Once()
+
+		_, statusCode, err := scheduleUsecase.Update(context.Background(), completed.Id, completed.UserId, V1Domains.ScheduleUpdateDomain{Amount: &amount})
+
+		assert.Equal(t, V1Usecases.ErrScheduleNotModifiable, err, "Error should be not modifiable")
+		assert.Equal(t, http.StatusConflict, statusCode, "Status code should be Conflict (409)")
+	})
+
+	t.Run("When Failure | Max Runs Already Reached", func(t *testing.T) {
+		running := scheduleDataFromDB
+		running.OccurrenceCount = 3
+		maxRuns := 3
+
+		scheduleRepoMock.Mock.On("Update", mock.Anything, running.Id, running.UserId, mock.Anything).Return(applyUpdate(running), nil).Once()
+
+		_, statusCode, err := scheduleUsecase.Update(context.Background(), running.Id, running.UserId, V1Domains.ScheduleUpdateDomain{MaxRuns: &maxRuns})
+
+		assert.Equal(t, V1Usecases.ErrScheduleMaxRunsReached, err, "Error should be max runs reached")
+		assert.Equal(t, http.StatusBadRequest, statusCode, "Status code should be Bad Request (400)")
+	})
+
+	t.Run("When Failure | Schedule Not Found", func(t *testing.T) {
+		amount := money.FromMajor(250)
+
+		scheduleRepoMock.Mock.On("Update", mock.Anything, "unknown", scheduleDataFromDB.UserId, mock.Anything).Return(V1Domains.ScheduleDomain{}, PostgresRepo.ErrScheduleNotFound).Once()
+
+		_, statusCode, err := scheduleUsecase.Update(context.Background(), "unknown", scheduleDataFromDB.UserId, V1Domains.ScheduleUpdateDomain{Amount: &amount})
+
+		assert.Equal(t, PostgresRepo.ErrScheduleNotFound, err, "Error should be schedule not found")
+		assert.Equal(t, http.StatusNotFound, statusCode, "Status code should be Not Found (404)")
+	})
+}
+
+func TestCancelSchedule(t *testing.T) {
+	setupSchedule(t)
+
+	t.Run("When Success Cancel Schedule", func(t *testing.T) {
+		scheduleRepoMock.Mock.On("Update", mock.Anything, scheduleDataFromDB.Id, scheduleDataFromDB.UserId, mock.Anything).Return(applyUpdate(scheduleDataFromDB), nil).Once()
+
+		result, statusCode, err := scheduleUsecase.Cancel(context.Background(), scheduleDataFromDB.Id, scheduleDataFromDB.UserId)
+
+		assert.Nil(t, err, "Error should be nil")
+		assert.Equal(t, http.StatusOK, statusCode, "Status code should be OK (200)")
+		assert.Equal(t, constants.ScheduleStatusCancelled, result.Status, "Status should be cancelled")
+		assert.Nil(t, result.NextRunAt, "Cancelled schedule should have no next run")
+	})
+}
+
+func TestRunDueSchedules(t *testing.T) {
+	t.Run("When Success Advance To Next Month End", func(t *testing.T) {
+		setupSchedule(t)
+		transactionId := "tttt-rrrr-xxxx"
+		run := V1Domains.ScheduleRunDomain{ScheduleId: scheduleDataFromDB.Id, Occurrence: 1, ScheduledFor: scheduleStartAt, Attempt: 1, Status: constants.ScheduleRunStatusSucceeded, TransactionId: &transactionId, ExecutedAt: scheduleStartAt}
+		var updated V1Domains.ScheduleDomain
+		var notified []V1Domains.ScheduleRunDomain
+
+		scheduleRepoMock.Mock.On("ExecuteNext", mock.Anything, scheduleStartAt, mock.Anything).Return(executeWith(scheduleDataFromDB, run, &updated), nil).Once()
+		scheduleRepoMock.Mock.On("ExecuteNext", mock.Anything, scheduleStartAt, mock.Anything).Return(nil, nil).Once()
+
+		executed, err := scheduleUsecase.RunDue(context.Background(), scheduleStartAt, func(r V1Domains.ScheduleRunDomain) { notified = append(notified, r) })
+
+		assert.Nil(t, err, "Error should be nil")
+		assert.Equal(t, 1, executed, "One schedule should be executed")
+		assert.Equal(t, []V1Domains.ScheduleRunDomain{run}, notified, "onRun should receive the run")
+		assert.Equal(t, 1, updated.OccurrenceCount, "Occurrence count should advance")
+		assert.Equal(t, 0, updated.Attempt, "Attempt should reset")
+		// 31 Januari diikuti 29 Februari karena 2024 adalah tahun kabisat
+		assert.True(t, updated.NextRunAt.Equal(time.Date(2024, 2, 29, 9, 0, 0, 0, time.UTC)), "Next run should be clamped to month end")
+		assert.Equal(t, constants.ScheduleStatusActive, updated.Status, "Schedule should stay active")
+	})
+
+	t.Run("When Failure | Retry Insufficient Balance With Backoff", func(t *testing.T) {
+		setupSchedule(t)
+		current := scheduleDataFromDB
+		current.Attempt = 1
+		message := PostgresRepo.ErrInsufficientBalance.Error()
+		executedAt := scheduleStartAt.Add(15 * time.Minute)
+		run := V1Domains.ScheduleRunDomain{ScheduleId: current.Id, Occurrence: 1, ScheduledFor: scheduleStartAt, Attempt: 2, Status: constants.ScheduleRunStatusFailed, Error: &message, Retryable: true, ExecutedAt: executedAt}
+		var updated V1Domains.ScheduleDomain
+
+		scheduleRepoMock.Mock.On("ExecuteNext", mock.Anything, executedAt, mock.Anything).Return(executeWith(current, run, &updated), nil).Once()
+		scheduleRepoMock.Mock.On("ExecuteNext", mock.Anything, executedAt, mock.Anything).Return(nil, nil).Once()
+
+		executed, err := scheduleUsecase.RunDue(context.Background(), executedAt, nil)
+
+		assert.Nil(t, err, "Error should be nil")
+		assert.Equal(t, 1, executed, "One schedule should be executed")
+		assert.Equal(t, 2, updated.Attempt, "Attempt should be recorded")
+		assert.Equal(t, 0, updated.OccurrenceCount, "Occurrence should not advance while retrying")
+		assert.True(t, updated.NextRunAt.Equal(executedAt.Add(30*time.Minute)), "Second retry should wait twice the interval")
+		assert.Equal(t, &message, updated.LastError, "Last error should be recorded")
+	})
+
+	t.Run("When Failure | Skip Occurrence After Retries Exhausted", func(t *testing.T) {
+		setupSchedule(t)
+		current := scheduleDataFromDB
+		current.Attempt = 2
+		message := PostgresRepo.ErrInsufficientBalance.Error()
+		run := V1Domains.ScheduleRunDomain{ScheduleId: current.Id, Occurrence: 1, ScheduledFor: scheduleStartAt, Attempt: 3, Status: constants.ScheduleRunStatusFailed, Error: &message, Retryable: true, ExecutedAt: scheduleStartAt.Add(time.Hour)}
+		var updated V1Domains.ScheduleDomain
+
+		scheduleRepoMock.Mock.On("ExecuteNext", mock.Anything, mock.Anything, mock.Anything).Return(executeWith(current, run, &updated), nil).Once()
+		scheduleRepoMock.Mock.On("ExecuteNext", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Once()
+
+		_, err := scheduleUsecase.RunDue(context.Background(), run.ExecutedAt, nil)
+
+		assert.Nil(t, err, "Error should be nil")
+		assert.Equal(t, 1, updated.OccurrenceCount, "Failed occurrence should be skipped")
+		assert.Equal(t, 0, updated.Attempt, "Attempt should reset")
+		assert.Equal(t, constants.ScheduleStatusActive, updated.Status, "Recurring schedule should stay active")
+	})
+
+	t.Run("When Failure | Once Schedule Becomes Failed", func(t *testing.T) {
+		setupSchedule(t)
+		current := scheduleDataFromDB
+		current.Frequency = constants.ScheduleFrequencyOnce
+		message := PostgresRepo.ErrRecipientWalletNotFound.Error()
+		run := V1Domains.ScheduleRunDomain{ScheduleId: current.Id, Occurrence: 1, ScheduledFor: scheduleStartAt, Attempt: 1, Status: constants.ScheduleRunStatusFailed, Error: &message, ExecutedAt: scheduleStartAt}
+		var updated V1Domains.ScheduleDomain
+
+		scheduleRepoMock.Mock.On("ExecuteNext", mock.Anything, scheduleStartAt, mock.Anything).Return(executeWith(current, run, &updated), nil).Once()
+		scheduleRepoMock.Mock.On("ExecuteNext", mock.Anything, scheduleStartAt, mock.Anything).Return(nil, nil).Once()
+
+		_, err := scheduleUsecase.RunDue(context.Background(), scheduleStartAt, nil)
+
+		assert.Nil(t, err, "Error should be nil")
+		assert.Equal(t, constants.ScheduleStatusFailed, updated.Status, "Once schedule should be failed")
+		assert.Nil(t, updated.NextRunAt, "Failed schedule should have no next run")
+	})
+
+	t.Run("When Failure | Frozen Sender Does Not Block Other Schedules", func(t *testing.T) {
+		setupSchedule(t)
+		message := PostgresRepo.ErrWalletFrozenOutgoing.Error()
+		frozenRun := V1Domains.ScheduleRunDomain{ScheduleId: scheduleDataFromDB.Id, Occurrence: 1, ScheduledFor: scheduleStartAt, Attempt: 1, Status: constants.ScheduleRunStatusFailed, Error: &message, Retryable: true, ExecutedAt: scheduleStartAt}
+		var frozenUpdated V1Domains.ScheduleDomain
+
+		other := scheduleDataFromDB
+		other.Id = "oooo-tttt-hhhh"
+		otherRun := V1Domains.ScheduleRunDomain{ScheduleId: other.Id, Occurrence: 1, ScheduledFor: scheduleStartAt, Attempt: 1, Status: constants.ScheduleRunStatusSucceeded, ExecutedAt: scheduleStartAt}
+		var otherUpdated V1Domains.ScheduleDomain
+
+		// schedule yang gagal dicatat dan dijadwalkan ulang sehingga putaran yang sama lanjut ke schedule berikutnya
+		scheduleRepoMock.Mock.On("ExecuteNext", mock.Anything, scheduleStartAt, mock.Anything).Return(executeWith(scheduleDataFromDB, frozenRun, &frozenUpdated), nil).Once()
+		scheduleRepoMock.Mock.On("ExecuteNext", mock.Anything, scheduleStartAt, mock.Anything).Return(executeWith(other, otherRun, &otherUpdated), nil).Once()
+		scheduleRepoMock.Mock.On("ExecuteNext", mock.Anything, scheduleStartAt, mock.Anything).Return(nil, nil).Once()
+
+		executed, err := scheduleUsecase.RunDue(context.Background(), scheduleStartAt, nil)
+
+		assert.Nil(t, err, "Error should be nil")
+		assert.Equal(t, 2, executed, "Both schedules should be executed")
+		assert.True(t, frozenUpdated.NextRunAt.After(scheduleStartAt), "Frozen schedule should move forward")
+		assert.Equal(t, &message, frozenUpdated.LastError, "Frozen error should be recorded")
+		assert.Equal(t, 1, otherUpdated.OccurrenceCount, "Other schedule should run")
+	})
+
+	t.Run("When Success Complete After Max Runs", func(t *testing.T) {
+		setupSchedule(t)
+		current := scheduleDataFromDB
+		maxRuns := 2
+		current.MaxRuns = &maxRuns
+		current.OccurrenceCount = 1
+		run := V1Domains.ScheduleRunDomain{ScheduleId: current.Id, Occurrence: 2, ScheduledFor: scheduleStartAt, Attempt: 1, Status: constants.ScheduleRunStatusSucceeded, ExecutedAt: scheduleStartAt}
+		var updated V1Domains.ScheduleDomain
+
+		scheduleRepoMock.Mock.On("ExecuteNext", mock.Anything, scheduleStartAt, mock.Anything).Return(executeWith(current, run, &updated), nil).Once()
+		scheduleRepoMock.Mock.On("ExecuteNext", mock.Anything, scheduleStartAt, mock.Anything).Return(nil, nil).Once()
+
+		_, err := scheduleUsecase.RunDue(context.Background(), scheduleStartAt, nil)
+
+		assert.Nil(t, err, "Error should be nil")
+		assert.Equal(t, constants.ScheduleStatusCompleted, updated.Status, "Schedule should be completed")
+		assert.Nil(t, updated.NextRunAt, "Completed schedule should have no next run")
+	})
+}
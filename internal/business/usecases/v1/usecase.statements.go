@@ -0,0 +1,157 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+	V1PostgresRepository "github.com/snykk/transaction-api/internal/datasources/repositories/postgres/v1"
+	"github.com/snykk/transaction-api/internal/utils"
+	"github.com/snykk/transaction-api/pkg/mailer"
+)
+
+type statementUsecase struct {
+	repo     V1Domains.StatementRepository
+	mailer   mailer.StatementMailer // nil jika ringkasan tidak dikirim lewat email
+	currency string
+}
+
+func NewStatementUsecase(repo V1Domains.StatementRepository, mailer mailer.StatementMailer, currency string) V1Domains.StatementUsecase {
+	return &statementUsecase{
+		repo:     repo,
+		mailer:   mailer,
+		currency: currency,
+	}
+}
+
+// GenerateMonthly membuat statement untuk setiap bulan yang sudah berakhir pada zona waktu user
+// dan belum punya statement. Bulan yang terlewat (misal job sempat mati) ikut dibuat.
+// Kegagalan pada satu wallet tidak menghentikan wallet lainnya, semua error dikembalikan bersama.
+func (uc *statementUsecase) GenerateMonthly(ctx context.Context, now time.Time) (generated int, err error) {
+	var errs []error
+	afterWalletId := ""
+
+	for {
+		accounts, err := uc.repo.GetAccounts(ctx, afterWalletId, constants.StatementJobBatchSize)
+		if err != nil {
+			return generated, errors.Join(append(errs, err)...)
+		}
+
+		for _, account := range accounts {
+			count, err := uc.generateForAccount(ctx, account, now)
+			generated += count
+			if err != nil {
+				errs = append(errs, fmt.Errorf("wallet %s: %w", account.WalletId, err))
+			}
+		}
+
+		if len(accounts) < constants.StatementJobBatchSize {
+			break
+		}
+		afterWalletId = accounts[len(accounts)-1].WalletId
+	}
+
+	return generated, errors.Join(errs...)
+}
+
+func (uc *statementUsecase) generateForAccount(ctx context.Context, account V1Domains.StatementAccountDomain, now time.Time) (generated int, err error) {
+	if account.Timezone == "" {
+		account.Timezone = constants.DefaultTimezone
+	}
+	location, err := time.LoadLocation(account.Timezone)
+	if err != nil {
+		return 0, err
+	}
+
+	// Bulan pertama adalah bulan setelah statement terakhir, atau bulan wallet dibuat
+	periodStart := monthStart(account.WalletCreatedAt, location)
+	if account.LastPeriod != nil {
+		lastPeriod, err := time.ParseInLocation(constants.StatementPeriodLayout, *account.LastPeriod, location)
+		if err != nil {
+			return 0, err
+		}
+		periodStart = lastPeriod.AddDate(0, 1, 0)
+	}
+
+	currentMonth := monthStart(now, location)
+	for ; periodStart.Before(currentMonth); periodStart = periodStart.AddDate(0, 1, 0) {
+		statementDom, err := uc.repo.Generate(ctx, V1Domains.MonthlyStatementDomain{
+			UserId:      account.UserId,
+			WalletId:    account.WalletId,
+			Period:      periodStart.Format(constants.StatementPeriodLayout),
+			Timezone:    account.Timezone,
+			PeriodStart: periodStart,
+			PeriodEnd:   periodStart.AddDate(0, 1, 0),
+		})
+		if err != nil {
+			// Sudah dibuat oleh proses job lain
+			if errors.Is(err, V1PostgresRepository.ErrStatementAlreadyExists) {
+				continue
+			}
+			return generated, err
+		}
+		generated++
+
+		if uc.mailer == nil {
+			continue
+		}
+		if err = uc.mailer.SendStatement(uc.toStatementSummary(account, statementDom), account.Email); err != nil {
+			return generated, err
+		}
+		if err = uc.repo.MarkEmailed(ctx, statementDom.Id); err != nil {
+			return generated, err
+		}
+	}
+
+	return generated, nil
+}
+
+func (uc *statementUsecase) toStatementSummary(account V1Domains.StatementAccountDomain, statementDom V1Domains.MonthlyStatementDomain) mailer.StatementSummary {
+	summary := mailer.StatementSummary{
+		Username:       account.Username,
+		Period:         statementDom.Period,
+		Currency:       uc.currency,
+		OpeningBalance: statementDom.OpeningBalance.String(),
+		ClosingBalance: statementDom.ClosingBalance.String(),
+	}
+	for _, total := range statementDom.Totals {
+		summary.Totals = append(summary.Totals, mailer.StatementSummaryTotal{
+			TransactionType: total.TransactionType,
+			Count:           total.Count,
+			TotalCredit:     total.TotalCredit.String(),
+			TotalDebit:      total.TotalDebit.String(),
+		})
+	}
+
+	return summary
+}
+
+// monthStart mengembalikan tanggal 1 pukul 00:00 dari bulan t pada zona waktu location
+func monthStart(t time.Time, location *time.Location) time.Time {
+	t = t.In(location)
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, location)
+}
+
+func (uc *statementUsecase) GetAll(ctx context.Context, userId string) (outDoms []V1Domains.MonthlyStatementDomain, statusCode int, err error) {
+	outDoms, err = uc.repo.GetByUserId(ctx, userId)
+	if err != nil {
+		statusCode, _ = utils.MapDBError(err)
+		return nil, statusCode, err
+	}
+
+	return outDoms, http.StatusOK, nil
+}
+
+func (uc *statementUsecase) GetById(ctx context.Context, statementId string, userId string) (outDom V1Domains.MonthlyStatementDomain, statusCode int, err error) {
+	outDom, err = uc.repo.GetById(ctx, statementId, userId)
+	if err != nil {
+		statusCode, _ = utils.MapDBError(err)
+		return V1Domains.MonthlyStatementDomain{}, statusCode, err
+	}
+
+	return outDom, http.StatusOK, nil
+}
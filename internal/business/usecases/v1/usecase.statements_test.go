@@ -0,0 +1,206 @@
+package v1_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	V1Usecases "github.com/snykk/transaction-api/internal/business/usecases/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+	PostgresRepo "github.com/snykk/transaction-api/internal/datasources/repositories/postgres/v1"
+	"github.com/snykk/transaction-api/internal/mocks"
+	"github.com/snykk/transaction-api/pkg/mailer"
+	"github.com/snykk/transaction-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	statementRepoMock   *mocks.StatementRepository
+	statementMailerMock *mocks.StatementMailer
+	statementUsecase    V1Domains.StatementUsecase
+	statementAccount    V1Domains.StatementAccountDomain
+	statementDataFromDB V1Domains.MonthlyStatementDomain
+	jakarta             *time.Location
+)
+
+func setupStatement(t *testing.T) {
+	statementRepoMock = mocks.NewStatementRepository(t)
+	statementMailerMock = mocks.NewStatementMailer(t)
+	statementUsecase = V1Usecases.NewStatementUsecase(statementRepoMock, statementMailerMock, "IDR")
+
+	var err error
+	jakarta, err = time.LoadLocation(constants.DefaultTimezone)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	statementAccount = V1Domains.StatementAccountDomain{
+		WalletId:        "xxxx-yyyy-zzzz",
+		UserId:          "aaaa-bbbb-cccc",
+		Username:        "patrick",
+		Email:           "patrick@gmail.com",
+		Timezone:        constants.DefaultTimezone,
+		WalletCreatedAt: time.Date(2024, 1, 15, 10, 0, 0, 0, jakarta),
+	}
+
+	statementDataFromDB = V1Domains.MonthlyStatementDomain{
+		Id:             "ssss-tttt-uuuu",
+		UserId:         statementAccount.UserId,
+		WalletId:       statementAccount.WalletId,
+		Period:         "2024-01",
+		Timezone:       constants.DefaultTimezone,
+		PeriodStart:    time.Date(2024, 1, 1, 0, 0, 0, 0, jakarta),
+		PeriodEnd:      time.Date(2024, 2, 1, 0, 0, 0, 0, jakarta),
+		OpeningBalance: 0,
+		ClosingBalance: money.FromMajor(150),
+		Totals: []V1Domains.StatementTotalDomain{
+			{TransactionType: constants.TransactionTypeDeposit, Count: 1, TotalCredit: money.FromMajor(200)},
+			{TransactionType: constants.TransactionTypePurchase, Count: 1, TotalDebit: money.FromMajor(50)},
+		},
+	}
+}
+
+// periodIs mencocokkan argumen Generate berdasarkan periode dan batas waktunya
+func periodIs(period string, start time.Time) interface{} {
+	return mock.MatchedBy(func(statementDom V1Domains.MonthlyStatementDomain) bool {
+		return statementDom.Period == period && statementDom.PeriodStart.Equal(start) && statementDom.PeriodEnd.Equal(start.AddDate(0, 1, 0))
+	})
+}
+
+func TestGenerateMonthlyStatement(t *testing.T) {
+	t.Run("When Success Backfill Missed Months And Send Email", func(t *testing.T) {
+		setupStatement(t)
+		now := time.Date(2024, 3, 5, 8, 0, 0, 0, jakarta)
+
+		february := statementDataFromDB
+		february.Id = "vvvv-wwww-xxxx"
+		february.Period = "2024-02"
+		february.Totals = nil
+
+		statementRepoMock.Mock.On("GetAccounts", mock.Anything, "", constants.StatementJobBatchSize).Return([]V1Domains.StatementAccountDomain{statementAccount}, nil).Once()
+		statementRepoMock.Mock.On("Generate", mock.Anything, periodIs("2024-01", time.Date(2024, 1, 1, 0, 0, 0, 0, jakarta))).Return(statementDataFromDB, nil).Once()
+		statementRepoMock.Mock.On("Generate", mock.Anything, periodIs("2024-02", time.Date(2024, 2, 1, 0, 0, 0, 0, jakarta))).Return(february, nil).Once()
+		statementMailerMock.Mock.On("SendStatement", mailer.StatementSummary{
+			Username:       "patrick",
+			Period:         "2024-01",
+			Currency:       "IDR",
+			OpeningBalance: "0.00",
+			ClosingBalance: "150.00",
+			Totals: []mailer.StatementSummaryTotal{
+				{TransactionType: constants.TransactionTypeDeposit, Count: 1, TotalCredit: "200.00", TotalDebit: "0.00"},
+				{TransactionType: constants.TransactionTypePurchase, Count: 1, TotalCredit: "0.00", TotalDebit: "50.00"},
+			},
+		}, statementAccount.Email).Return(nil).Once()
+		statementMailerMock.Mock.On("SendStatement", mock.MatchedBy(func(summary mailer.StatementSummary) bool { return summary.Period == "2024-02" }), statementAccount.Email).Return(nil).Once()
+		statementRepoMock.Mock.On("MarkEmailed", mock.Anything, statementDataFromDB.Id).Return(nil).Once()
+		statementRepoMock.Mock.On("MarkEmailed", mock.Anything, february.Id).Return(nil).Once()
+
+		generated, err := statementUsecase.GenerateMonthly(context.Background(), now)
+
+		assert.Nil(t, err, "Error should be nil")
+		assert.Equal(t, 2, generated, "Both missed months should be generated")
+	})
+
+	t.Run("When Success Follow User Timezone", func(t *testing.T) {
+		setupStatement(t)
+		statementUsecase = V1Usecases.NewStatementUsecase(statementRepoMock, nil, "IDR")
+
+		// 2024-03-01 01:00 di Jakarta, namun masih 2024-02-29 di New York
+		now := time.Date(2024, 2, 29, 18, 0, 0, 0, time.UTC)
+		lastPeriod := "2024-01"
+
+		jakartaAccount := statementAccount
+		jakartaAccount.LastPeriod = &lastPeriod
+		newYorkAccount := statementAccount
+		newYorkAccount.WalletId = "yyyy-zzzz-aaaa"
+		newYorkAccount.Timezone = "America/New_York"
+		newYorkAccount.LastPeriod = &lastPeriod
+
+		statementRepoMock.Mock.On("GetAccounts", mock.Anything, "", constants.StatementJobBatchSize).Return([]V1Domains.StatementAccountDomain{jakartaAccount, newYorkAccount}, nil).Once()
+		statementRepoMock.Mock.On("Generate", mock.Anything, periodIs("2024-02", time.Date(2024, 2, 1, 0, 0, 0, 0, jakarta))).Return(statementDataFromDB, nil).Once()
+
+		generated, err := statementUsecase.GenerateMonthly(context.Background(), now)
+
+		assert.Nil(t, err, "Error should be nil")
+		assert.Equal(t, 1, generated, "Only the Jakarta wallet has a finished month")
+	})
+
+	t.Run("When Success Skip Existing Statement", func(t *testing.T) {
+		setupStatement(t)
+		now := time.Date(2024, 2, 10, 8, 0, 0, 0, jakarta)
+
+		statementRepoMock.Mock.On("GetAccounts", mock.Anything, "", constants.StatementJobBatchSize).Return([]V1Domains.StatementAccountDomain{statementAccount}, nil).Once()
+		statementRepoMock.Mock.On("Generate", mock.Anything, mock.AnythingOfType("v1.MonthlyStatementDomain")).Return(V1Domains.MonthlyStatementDomain{}, PostgresRepo.ErrStatementAlreadyExists).Once()
+
+		generated, err := statementUsecase.GenerateMonthly(context.Background(), now)
+
+		assert.Nil(t, err, "Error should be nil")
+		assert.Equal(t, 0, generated, "Nothing should be generated")
+	})
+
+	t.Run("When Failure Continue With Other Wallets", func(t *testing.T) {
+		setupStatement(t)
+		statementUsecase = V1Usecases.NewStatementUsecase(statementRepoMock, nil, "IDR")
+		now := time.Date(2024, 2, 10, 8, 0, 0, 0, jakarta)
+
+		brokenAccount := statementAccount
+		brokenAccount.WalletId = "broken-wallet"
+		dbErr := errors.New("connection reset")
+
+		statementRepoMock.Mock.On("GetAccounts", mock.Anything, "", constants.StatementJobBatchSize).Return([]V1Domains.StatementAccountDomain{brokenAccount, statementAccount}, nil).Once()
+		statementRepoMock.Mock.On("Generate", mock.Anything, mock.MatchedBy(func(statementDom V1Domains.MonthlyStatementDomain) bool {
+			return statementDom.WalletId == brokenAccount.WalletId
+		})).Return(V1Domains.MonthlyStatementDomain{}, dbErr).Once()
+		statementRepoMock.Mock.On("Generate", mock.Anything, mock.MatchedBy(func(statementDom V1Domains.MonthlyStatementDomain) bool {
+			return statementDom.WalletId == statementAccount.WalletId
+		})).Return(statementDataFromDB, nil).Once()
+
+		generated, err := statementUsecase.GenerateMonthly(context.Background(), now)
+
+		assert.ErrorIs(t, err, dbErr, "Error of the broken wallet should be returned")
+		assert.Contains(t, err.Error(), brokenAccount.WalletId, "Error should name the wallet")
+		assert.Equal(t, 1, generated, "Healthy wallet should still be generated")
+	})
+}
+
+func TestGetAllStatements(t *testing.T) {
+	setupStatement(t)
+
+	t.Run("When Success Get Statements", func(t *testing.T) {
+		statementRepoMock.Mock.On("GetByUserId", mock.Anything, statementAccount.UserId).Return([]V1Domains.MonthlyStatementDomain{statementDataFromDB}, nil).Once()
+
+		result, statusCode, err := statementUsecase.GetAll(context.Background(), statementAccount.UserId)
+
+		assert.Nil(t, err, "Error should be nil")
+		assert.Equal(t, http.StatusOK, statusCode, "Status code should be OK (200)")
+		assert.Equal(t, []V1Domains.MonthlyStatementDomain{statementDataFromDB}, result, "Statements should match")
+	})
+}
+
+func TestGetStatementById(t *testing.T) {
+	setupStatement(t)
+
+	t.Run("When Success Get Statement", func(t *testing.T) {
+		statementRepoMock.Mock.On("GetById", mock.Anything, statementDataFromDB.Id, statementAccount.UserId).Return(statementDataFromDB, nil).Once()
+
+		result, statusCode, err := statementUsecase.GetById(context.Background(), statementDataFromDB.Id, statementAccount.UserId)
+
+		assert.Nil(t, err, "Error should be nil")
+		assert.Equal(t, http.StatusOK, statusCode, "Status code should be OK (200)")
+		assert.Equal(t, statementDataFromDB, result, "Statement should match")
+	})
+
+	t.Run("When Failure | Statement Not Found", func(t *testing.T) {
+		statementRepoMock.Mock.On("GetById", mock.Anything, statementDataFromDB.Id, "other-user").Return(V1Domains.MonthlyStatementDomain{}, PostgresRepo.ErrStatementNotFound).Once()
+
+		result, statusCode, err := statementUsecase.GetById(context.Background(), statementDataFromDB.Id, "other-user")
+
+		assert.Equal(t, PostgresRepo.ErrStatementNotFound, err, "Error should match")
+		assert.Equal(t, http.StatusNotFound, statusCode, "Status code should be Not Found (404)")
+		assert.Equal(t, V1Domains.MonthlyStatementDomain{}, result, "Statement should be empty")
+	})
+}
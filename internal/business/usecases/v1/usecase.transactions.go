@@ -2,19 +2,29 @@ package v1
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
 	"net/http"
 
 	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+	V1PostgresRepository "github.com/snykk/transaction-api/internal/datasources/repositories/postgres/v1"
 	"github.com/snykk/transaction-api/internal/utils"
+	"github.com/snykk/transaction-api/pkg/money"
 )
 
 type transactionUsecase struct {
-	repo V1Domains.TransactionRepository
+	repo        V1Domains.TransactionRepository
+	feeRepo     V1Domains.FeeRuleRepository
+	productRepo V1Domains.ProductRepository // harga produk dibutuhkan untuk menghitung fee pembelian
 }
 
-func NewTransactionUsecase(repo V1Domains.TransactionRepository) V1Domains.TransactionUsecase {
+func NewTransactionUsecase(repo V1Domains.TransactionRepository, feeRepo V1Domains.FeeRuleRepository, productRepo V1Domains.ProductRepository) V1Domains.TransactionUsecase {
 	return &transactionUsecase{
 		repo,
+		feeRepo,
+		productRepo,
 	}
 }
 
@@ -23,6 +33,9 @@ func (txUC *transactionUsecase) Deposit(ctx context.Context, transactionDom *V1D
 	if transactionDom.Amount <= 0 {
 		return V1Domains.TransactionDomain{}, http.StatusBadRequest, ErrAmountMustGreateThanZero
 	}
+	if err := validateTransactionMetadata(transactionDom.Metadata); err != nil {
+		return V1Domains.TransactionDomain{}, http.StatusBadRequest, err
+	}
 
 	newTransactionDom, err := txUC.repo.Deposit(ctx, *transactionDom)
 	if err != nil {
@@ -39,6 +52,15 @@ func (txUC *transactionUsecase) Withdraw(ctx context.Context, transactionData *V
 	if transactionData.Amount <= 0 {
 		return V1Domains.TransactionDomain{}, http.StatusBadRequest, ErrAmountMustGreateThanZero
 	}
+	if err := validateTransactionMetadata(transactionData.Metadata); err != nil {
+		return V1Domains.TransactionDomain{}, http.StatusBadRequest, err
+	}
+
+	// Fee withdraw ditagih di luar amount dan ikut dipotong dari saldo
+	if err := applyFees(ctx, txUC.feeRepo, transactionData, constants.TransactionTypeWithdraw, transactionData.Amount); err != nil {
+		statusCode, _ := utils.MapDBError(err)
+		return V1Domains.TransactionDomain{}, statusCode, err
+	}
 
 	newTransactionDom, err := txUC.repo.Withdraw(ctx, *transactionData)
 	if err != nil {
@@ -46,8 +68,8 @@ func (txUC *transactionUsecase) Withdraw(ctx context.Context, transactionData *V
 		return V1Domains.TransactionDomain{}, statusCode, err
 	}
 
-	// Mengembalikan transaksi yang baru disimpan
-	return newTransactionDom, http.StatusCreated, nil
+	// Withdraw disimpan dengan status pending sampai diselesaikan admin
+	return newTransactionDom, http.StatusAccepted, nil
 }
 
 func (txUC *transactionUsecase) Purchase(ctx context.Context, transactionData *V1Domains.TransactionDomain) (domain V1Domains.TransactionDomain, statusCode int, err error) {
@@ -55,6 +77,24 @@ func (txUC *transactionUsecase) Purchase(ctx context.Context, transactionData *V
 	if *transactionData.Quantity <= 0 {
 		return V1Domains.TransactionDomain{}, http.StatusBadRequest, ErrQuantityMustGreaterThanZero
 	}
+	if transactionData.PointsRedeemed.IsNegative() {
+		return V1Domains.TransactionDomain{}, http.StatusBadRequest, ErrPointsMustNotBeNegative
+	}
+	if err := validateTransactionMetadata(transactionData.Metadata); err != nil {
+		return V1Domains.TransactionDomain{}, http.StatusBadRequest, err
+	}
+
+	// Harga produk saat ini menjadi dasar fee, repository menolak pembelian jika harga berubah sebelum transaksi dijalankan
+	price, statusCode, err := currentProductPrice(ctx, txUC.productRepo, *transactionData.ProductId)
+	if err != nil {
+		return V1Domains.TransactionDomain{}, statusCode, err
+	}
+	transactionData.Product.Price = price
+
+	if err := applyFees(ctx, txUC.feeRepo, transactionData, constants.TransactionTypePurchase, price.MulInt(*transactionData.Quantity)); err != nil {
+		statusCode, _ := utils.MapDBError(err)
+		return V1Domains.TransactionDomain{}, statusCode, err
+	}
 
 	newTransactionDom, err := txUC.repo.Purchase(ctx, *transactionData)
 	if err != nil {
@@ -66,24 +106,209 @@ func (txUC *transactionUsecase) Purchase(ctx context.Context, transactionData *V
 	return newTransactionDom, http.StatusCreated, nil
 }
 
-func (uc *transactionUsecase) History(ctx context.Context, userId string) ([]V1Domains.TransactionDomain, int, error) {
-	userTransactionHistoryDom, err := uc.repo.GetByUserId(ctx, userId)
+func (txUC *transactionUsecase) Transfer(ctx context.Context, transferDom *V1Domains.TransferDomain) (domain V1Domains.TransferDomain, statusCode int, err error) {
+	// Validasi jumlah transfer harus lebih dari 0
+	if transferDom.Amount <= 0 {
+		return V1Domains.TransferDomain{}, http.StatusBadRequest, ErrAmountMustGreateThanZero
+	}
+
+	// Penerima wajib ditentukan lewat salah satu identitas, atau pocket tujuan milik sendiri
+	hasRecipient := transferDom.RecipientUserId != "" || transferDom.RecipientUsername != "" || transferDom.RecipientEmail != ""
+	if !hasRecipient && transferDom.RecipientWalletId == "" {
+		return V1Domains.TransferDomain{}, http.StatusBadRequest, ErrRecipientRequired
+	}
+	if hasRecipient && transferDom.RecipientWalletId != "" {
+		return V1Domains.TransferDomain{}, http.StatusBadRequest, ErrRecipientAmbiguous
+	}
+	if err := validateTransactionMetadata(transferDom.Metadata); err != nil {
+		return V1Domains.TransferDomain{}, http.StatusBadRequest, err
+	}
+
+	newTransferDom, err := txUC.repo.Transfer(ctx, *transferDom)
+	if err != nil {
+		statusCode, _ := utils.MapDBError(err)
+		return V1Domains.TransferDomain{}, statusCode, err
+	}
+
+	return newTransferDom, http.StatusCreated, nil
+}
+
+func (txUC *transactionUsecase) UpdateStatus(ctx context.Context, transactionId string, status string, reason string) (domain V1Domains.TransactionDomain, statusCode int, err error) {
+	// Transaksi yang gagal wajib disertai alasan
+	if status == constants.TransactionStatusFailed && reason == "" {
+		return V1Domains.TransactionDomain{}, http.StatusBadRequest, ErrFailureReasonRequired
+	}
+
+	updatedTransactionDom, err := txUC.repo.UpdateStatus(ctx, transactionId, status, reason)
+	if err != nil {
+		statusCode, _ := utils.MapDBError(err)
+		return V1Domains.TransactionDomain{}, statusCode, err
+	}
+
+	return updatedTransactionDom, http.StatusOK, nil
+}
+
+func (txUC *transactionUsecase) Refund(ctx context.Context, transactionId string, quantity int) (domain V1Domains.TransactionDomain, statusCode int, err error) {
+	// quantity 0 berarti refund penuh, nilai negatif tidak valid
+	if quantity < 0 {
+		return V1Domains.TransactionDomain{}, http.StatusBadRequest, ErrQuantityMustGreaterThanZero
+	}
 
+	refundTransactionDom, err := txUC.repo.Refund(ctx, transactionId, quantity)
 	if err != nil {
 		statusCode, _ := utils.MapDBError(err)
-		return []V1Domains.TransactionDomain{}, statusCode, err
+		return V1Domains.TransactionDomain{}, statusCode, err
 	}
 
-	return userTransactionHistoryDom, http.StatusOK, nil
+	return refundTransactionDom, http.StatusCreated, nil
 }
 
-func (uc *transactionUsecase) GetAll(ctx context.Context) ([]V1Domains.TransactionDomain, int, error) {
-	transactionDom, err := uc.repo.GetAll(ctx)
+func (uc *transactionUsecase) History(ctx context.Context, filter V1Domains.TransactionFilterDomain) (V1Domains.TransactionPageDomain, int, error) {
+	if err := normalizeTransactionFilter(&filter); err != nil {
+		return V1Domains.TransactionPageDomain{}, http.StatusBadRequest, err
+	}
 
+	userTransactionHistoryPage, err := uc.repo.GetByUserId(ctx, filter.UserId, filter)
 	if err != nil {
 		statusCode, _ := utils.MapDBError(err)
-		return []V1Domains.TransactionDomain{}, statusCode, err
+		return V1Domains.TransactionPageDomain{}, statusCode, err
+	}
+
+	return userTransactionHistoryPage, http.StatusOK, nil
+}
+
+func (uc *transactionUsecase) GetAll(ctx context.Context, filter V1Domains.TransactionFilterDomain) (V1Domains.TransactionPageDomain, int, error) {
+	if err := normalizeTransactionFilter(&filter); err != nil {
+		return V1Domains.TransactionPageDomain{}, http.StatusBadRequest, err
+	}
+
+	transactionPage, err := uc.repo.GetAll(ctx, filter)
+	if err != nil {
+		statusCode, _ := utils.MapDBError(err)
+		return V1Domains.TransactionPageDomain{}, statusCode, err
+	}
+
+	return transactionPage, http.StatusOK, nil
+}
+
+func (uc *transactionUsecase) GetById(ctx context.Context, transactionId string, userId string, isAdmin bool) (V1Domains.TransactionDomain, int, error) {
+	// admin boleh melihat semua transaksi, user biasa hanya transaksi miliknya
+	ownerId := userId
+	if isAdmin {
+		ownerId = ""
+	}
+
+	transactionDom, err := uc.repo.GetById(ctx, transactionId, ownerId)
+	if err != nil {
+		statusCode, _ := utils.MapDBError(err)
+		return V1Domains.TransactionDomain{}, statusCode, err
 	}
 
 	return transactionDom, http.StatusOK, nil
 }
+
+func (uc *transactionUsecase) Statement(ctx context.Context, statementDom *V1Domains.StatementDomain, writer V1Domains.StatementWriter) (int, error) {
+	if !statementDom.From.Before(statementDom.To) {
+		return http.StatusBadRequest, ErrInvalidDateRange
+	}
+
+	var statement V1Domains.StatementDomain
+
+	err := uc.repo.StreamStatement(ctx, *statementDom,
+		func(opening V1Domains.StatementDomain) error {
+			statement = opening
+			statement.ClosingBalance = opening.OpeningBalance
+			return writer.Begin(statement)
+		},
+		func(entry V1Domains.StatementEntryDomain) error {
+			// saldo berjalan dihitung dari saldo awal ditambah setiap mutasi secara berurutan
+			if entry.Direction == constants.LedgerDirectionCredit {
+				statement.ClosingBalance += entry.Amount
+			} else {
+				statement.ClosingBalance -= entry.Amount
+			}
+			entry.Balance = statement.ClosingBalance
+			return writer.Entry(entry)
+		},
+	)
+	if err != nil {
+		statusCode, _ := utils.MapDBError(err)
+		return statusCode, err
+	}
+
+	if err := writer.End(statement); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	*statementDom = statement
+	return http.StatusOK, nil
+}
+
+// applyFees menghitung fee dari aturan yang berlaku dan menyimpannya pada transaksi
+func applyFees(ctx context.Context, feeRepo V1Domains.FeeRuleRepository, transactionData *V1Domains.TransactionDomain, transactionType string, amount money.Money) error {
+	rules, err := feeRepo.GetApplicable(ctx, transactionType, transactionData.ProductId)
+	if err != nil {
+		return err
+	}
+
+	transactionData.Fee, transactionData.FeeBreakdown = V1Domains.CalculateFees(rules, amount)
+	return nil
+}
+
+// currentProductPrice mengambil harga produk saat ini sebagai dasar perhitungan fee pembelian
+func currentProductPrice(ctx context.Context, productRepo V1Domains.ProductRepository, productId int) (money.Money, int, error) {
+	product, err := productRepo.GetProductById(ctx, productId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, http.StatusNotFound, V1PostgresRepository.ErrProductNotFound
+	}
+	if err != nil {
+		statusCode, _ := utils.MapDBError(err)
+		return 0, statusCode, err
+	}
+
+	return product.Price, http.StatusOK, nil
+}
+
+// normalizeTransactionFilter mengisi nilai default filter dan memvalidasi rentang yang saling bergantung
+func normalizeTransactionFilter(filter *V1Domains.TransactionFilterDomain) error {
+	if filter.Limit <= 0 {
+		filter.Limit = constants.TransactionPageDefaultLimit
+	}
+	if filter.Limit > constants.TransactionPageMaxLimit {
+		filter.Limit = constants.TransactionPageMaxLimit
+	}
+
+	if filter.Sort == "" {
+		filter.Sort = constants.TransactionSortDesc
+	}
+
+	if filter.From != nil && filter.To != nil && filter.From.After(*filter.To) {
+		return ErrInvalidDateRange
+	}
+
+	if filter.MinAmount != nil && filter.MaxAmount != nil && *filter.MinAmount > *filter.MaxAmount {
+		return ErrInvalidAmountRange
+	}
+
+	return nil
+}
+
+// validateTransactionMetadata membatasi metadata dari klien agar tidak membengkakkan baris transaksi
+func validateTransactionMetadata(metadata map[string]interface{}) error {
+	if metadata == nil {
+		return nil
+	}
+	if len(metadata) > constants.TransactionMetadataMaxKeys {
+		return ErrMetadataTooManyKeys
+	}
+
+	encoded, err := json.Marshal(metadata)
+	if err != nil {
+		return err
+	}
+	if len(encoded) > constants.TransactionMetadataMaxBytes {
+		return ErrMetadataTooLarge
+	}
+
+	return nil
+}
@@ -2,29 +2,38 @@ package v1_test
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
 	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
 	V1Usecases "github.com/snykk/transaction-api/internal/business/usecases/v1"
+	"github.com/snykk/transaction-api/internal/constants"
 	PostgresRepo "github.com/snykk/transaction-api/internal/datasources/repositories/postgres/v1"
 	"github.com/snykk/transaction-api/internal/http/datatransfers/requests"
 	"github.com/snykk/transaction-api/internal/mocks"
+	"github.com/snykk/transaction-api/pkg/money"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
 var (
-	transactionRepoMock    *mocks.TransactionRepository
-	transactionUsecase     V1Domains.TransactionUsecase
-	transactionsDataFromDB []V1Domains.TransactionDomain
-	transactionDataFromDB  V1Domains.TransactionDomain
+	transactionRepoMock        *mocks.TransactionRepository
+	transactionFeeRepoMock     *mocks.FeeRuleRepository
+	transactionProductRepoMock *mocks.ProductRepository
+	transactionUsecase         V1Domains.TransactionUsecase
+	transactionsDataFromDB     []V1Domains.TransactionDomain
+	transactionDataFromDB      V1Domains.TransactionDomain
 )
 
 func setupTransaction(t *testing.T) {
 	transactionRepoMock = mocks.NewTransactionRepository(t)
-	transactionUsecase = V1Usecases.NewTransactionUsecase(transactionRepoMock)
+	transactionFeeRepoMock = mocks.NewFeeRuleRepository(t)
+	transactionProductRepoMock = mocks.NewProductRepository(t)
+	transactionUsecase = V1Usecases.NewTransactionUsecase(transactionRepoMock, transactionFeeRepoMock, transactionProductRepoMock)
 
 	productId1 := 1
 	quantity1 := 200
@@ -40,7 +49,7 @@ func setupTransaction(t *testing.T) {
 			Wallet: V1Domains.WalletDomain{
 				Id:        "sdfas",
 				UserId:    "asdfsa",
-				Balance:   200,
+				Balance:   money.FromMajor(200),
 				CreatedAt: current_time,
 				User: V1Domains.UserDomain{
 					ID:        "asdfads",
@@ -54,7 +63,7 @@ func setupTransaction(t *testing.T) {
 			},
 			ProductId:       &productId1, // Nullable, karena transaksi deposit tidak melibatkan transaksi
 			Product:         V1Domains.ProductDomain{},
-			Amount:          200,
+			Amount:          money.FromMajor(200),
 			Quantity:        &quantity1,
 			TransactionType: "asdf",
 			CreatedAt:       current_time,
@@ -65,7 +74,7 @@ func setupTransaction(t *testing.T) {
 			Wallet: V1Domains.WalletDomain{
 				Id:        "sdfas",
 				UserId:    "asdfsa",
-				Balance:   200,
+				Balance:   money.FromMajor(200),
 				CreatedAt: current_time,
 				User: V1Domains.UserDomain{
 					ID:        "asdfads",
@@ -79,7 +88,7 @@ func setupTransaction(t *testing.T) {
 			},
 			ProductId:       &productId2, // Nullable, karena transaksi deposit tidak melibatkan transaksi
 			Product:         V1Domains.ProductDomain{},
-			Amount:          200,
+			Amount:          money.FromMajor(200),
 			Quantity:        &quantity2,
 			TransactionType: "asdf",
 			CreatedAt:       current_time,
@@ -122,7 +131,7 @@ func TestDepositTransaction(t *testing.T) {
 
 	t.Run("When Failure | Invalid Amount", func(t *testing.T) {
 		req := requests.TransactionDepositOrWithdrawRequest{
-			Amount: -200,
+			Amount: money.FromMajor(-200),
 		}
 
 		// Memanggil method Deposit
@@ -137,6 +146,50 @@ func TestDepositTransaction(t *testing.T) {
 
 	})
 
+	t.Run("When Failure | Metadata Too Many Keys", func(t *testing.T) {
+		metadata := map[string]interface{}{}
+		for i := 0; i <= constants.TransactionMetadataMaxKeys; i++ {
+			metadata[fmt.Sprintf("key_%d", i)] = i
+		}
+		req := requests.TransactionDepositOrWithdrawRequest{
+			Amount:   money.FromMajor(200),
+			Metadata: metadata,
+		}
+
+		_, statusCode, err := transactionUsecase.Deposit(context.Background(), req.ToDomain())
+
+		assert.Equal(t, V1Usecases.ErrMetadataTooManyKeys, err)
+		assert.Equal(t, http.StatusBadRequest, statusCode, "Status code should be Bad Request (400)")
+	})
+
+	t.Run("When Failure | Metadata Too Large", func(t *testing.T) {
+		req := requests.TransactionDepositOrWithdrawRequest{
+			Amount:   money.FromMajor(200),
+			Metadata: map[string]interface{}{"note": strings.Repeat("a", constants.TransactionMetadataMaxBytes)},
+		}
+
+		_, statusCode, err := transactionUsecase.Deposit(context.Background(), req.ToDomain())
+
+		assert.Equal(t, V1Usecases.ErrMetadataTooLarge, err)
+		assert.Equal(t, http.StatusBadRequest, statusCode, "Status code should be Bad Request (400)")
+	})
+
+	t.Run("When Failure | External Reference Taken", func(t *testing.T) {
+		req := requests.TransactionDepositOrWithdrawRequest{
+			Amount:            money.FromMajor(200),
+			ExternalReference: "INV-001",
+		}
+
+		expectedDomain := mock.MatchedBy(func(dom V1Domains.TransactionDomain) bool {
+			return dom.ExternalReference != nil && *dom.ExternalReference == "INV-001"
+		})
+		transactionRepoMock.Mock.On("Deposit", mock.Anything, expectedDomain).Return(V1Domains.TransactionDomain{}, PostgresRepo.ErrExternalReferenceTaken).Once()
+
+		_, statusCode, err := transactionUsecase.Deposit(context.Background(), req.ToDomain())
+
+		assert.Equal(t, PostgresRepo.ErrExternalReferenceTaken, err)
+		assert.Equal(t, http.StatusConflict, statusCode, "Status code should be Conflict (409)")
+	})
 }
 
 func TestWithdrawTransaction(t *testing.T) {
@@ -148,6 +201,7 @@ func TestWithdrawTransaction(t *testing.T) {
 
 	t.Run("When Success Transaction Withdraw", func(t *testing.T) {
 		// Mock repository untuk mengembalikan data transaksi yang berhasil disimpan
+		transactionFeeRepoMock.Mock.On("GetApplicable", mock.Anything, constants.TransactionTypeWithdraw, (*int)(nil)).Return([]V1Domains.FeeRuleDomain{}, nil).Once()
 		transactionRepoMock.Mock.On("Withdraw", mock.Anything, mock.AnythingOfType("v1.TransactionDomain")).Return(transactionDataFromDB, nil).Once()
 
 		// Memanggil method Withdraw
@@ -155,7 +209,7 @@ func TestWithdrawTransaction(t *testing.T) {
 
 		// Assertions
 		assert.Nil(t, err, "Error should be nil")
-		assert.Equal(t, http.StatusCreated, statusCode, "Status code should be Created (201)")
+		assert.Equal(t, http.StatusAccepted, statusCode, "Status code should be Accepted (202)")
 
 		// Pastikan hasilnya sesuai dengan data yang dimock
 		assert.Equal(t, transactionDataFromDB.Id, result.Id, "Transaction ID should match")
@@ -172,7 +226,7 @@ func TestWithdrawTransaction(t *testing.T) {
 
 	t.Run("When Failure | Invalid Amount", func(t *testing.T) {
 		req := requests.TransactionDepositOrWithdrawRequest{
-			Amount: -200,
+			Amount: money.FromMajor(-200),
 		}
 
 		// Memanggil method Deposit
@@ -187,6 +241,63 @@ func TestWithdrawTransaction(t *testing.T) {
 
 	})
 
+	t.Run("When Failure | Limit Exceeded", func(t *testing.T) {
+		resetAt := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+		limitErr := &PostgresRepo.LimitExceededError{Limit: constants.LimitDailyWithdrawal, Max: "1000.00", ResetAt: &resetAt}
+		transactionFeeRepoMock.Mock.On("GetApplicable", mock.Anything, constants.TransactionTypeWithdraw, (*int)(nil)).Return([]V1Domains.FeeRuleDomain{}, nil).Once()
+		transactionRepoMock.Mock.On("Withdraw", mock.Anything, mock.AnythingOfType("v1.TransactionDomain")).Return(V1Domains.TransactionDomain{}, limitErr).Once()
+
+		_, statusCode, err := transactionUsecase.Withdraw(context.Background(), req.ToDomain())
+
+		assert.ErrorIs(t, err, PostgresRepo.ErrLimitExceeded)
+		assert.Equal(t, http.StatusUnprocessableEntity, statusCode, "Status code should be Unprocessable Entity (422)")
+		assert.Contains(t, err.Error(), constants.LimitDailyWithdrawal)
+		assert.Contains(t, err.Error(), "resets at 2024-02-01T00:00:00Z")
+	})
+
+	t.Run("When Success | Fee Charged", func(t *testing.T) {
+		rateBps := 100
+		minFee := money.MustParse("2.50")
+		flatAmount := money.MustParse("1.00")
+		rules := []V1Domains.FeeRuleDomain{
+			{Id: 1, Name: "withdraw fee", TransactionType: constants.TransactionTypeWithdraw, FeeType: constants.FeeTypePercentage, RateBps: &rateBps, MinFee: &minFee},
+			{Id: 2, Name: "bank fee", TransactionType: constants.TransactionTypeWithdraw, FeeType: constants.FeeTypeFlat, FlatAmount: &flatAmount},
+		}
+		transactionFeeRepoMock.Mock.On("GetApplicable", mock.Anything, constants.TransactionTypeWithdraw, (*int)(nil)).Return(rules, nil).Once()
+
+		// 1% dari 200 adalah 2.00, naik ke min_fee 2.50, ditambah flat 1.00
+		transactionRepoMock.Mock.On("Withdraw", mock.Anything, mock.MatchedBy(func(d V1Domains.TransactionDomain) bool {
+			return d.Fee == money.MustParse("3.50") && len(d.FeeBreakdown) == 2 && d.FeeBreakdown[0].Amount == minFee && d.FeeBreakdown[1].FeeRuleId == 2
+		})).Return(transactionDataFromDB, nil).Once()
+
+		_, statusCode, err := transactionUsecase.Withdraw(context.Background(), req.ToDomain())
+
+		assert.Nil(t, err, "Error should be nil")
+		assert.Equal(t, http.StatusAccepted, statusCode, "Status code should be Accepted (202)")
+	})
+
+	t.Run("When Success | Tiered Fee Capped", func(t *testing.T) {
+		upTo := money.FromMajor(100)
+		maxFee := money.MustParse("5.00")
+		rules := []V1Domains.FeeRuleDomain{
+			{Id: 4, Name: "tiered fee", TransactionType: constants.TransactionTypeWithdraw, FeeType: constants.FeeTypeTiered, MaxFee: &maxFee, Tiers: []V1Domains.FeeTierDomain{
+				{UpTo: &upTo, FlatAmount: money.FromMajor(1)},
+				{FlatAmount: money.FromMajor(2), RateBps: 200},
+			}},
+		}
+		transactionFeeRepoMock.Mock.On("GetApplicable", mock.Anything, constants.TransactionTypeWithdraw, (*int)(nil)).Return(rules, nil).Once()
+
+		// 200 masuk tier kedua: 2.00 + 2% x 200 = 6.00, dibatasi max_fee 5.00
+		transactionRepoMock.Mock.On("Withdraw", mock.Anything, mock.MatchedBy(func(d V1Domains.TransactionDomain) bool {
+			return d.Fee == maxFee && len(d.FeeBreakdown) == 1 && d.FeeBreakdown[0].FeeType == constants.FeeTypeTiered
+		})).Return(transactionDataFromDB, nil).Once()
+
+		_, statusCode, err := transactionUsecase.Withdraw(context.Background(), req.ToDomain())
+
+		assert.Nil(t, err, "Error should be nil")
+		assert.Equal(t, http.StatusAccepted, statusCode, "Status code should be Accepted (202)")
+	})
+
 }
 
 func TestPurchaseTransaction(t *testing.T) {
@@ -198,8 +309,17 @@ func TestPurchaseTransaction(t *testing.T) {
 			Quantity:  200,
 		}
 
+		// Fee 0.5% dihitung dari harga produk saat ini dikali quantity
+		rateBps := 50
+		transactionProductRepoMock.Mock.On("GetProductById", mock.Anything, 1).Return(V1Domains.ProductDomain{Id: 1, Price: money.MustParse("1.50")}, nil).Once()
+		transactionFeeRepoMock.Mock.On("GetApplicable", mock.Anything, constants.TransactionTypePurchase, mock.MatchedBy(func(productId *int) bool {
+			return productId != nil && *productId == 1
+		})).Return([]V1Domains.FeeRuleDomain{{Id: 3, Name: "service fee", FeeType: constants.FeeTypePercentage, RateBps: &rateBps}}, nil).Once()
+
 		// Mock repository untuk mengembalikan data transaksi yang berhasil disimpan
-		transactionRepoMock.Mock.On("Purchase", mock.Anything, mock.AnythingOfType("v1.TransactionDomain")).Return(transactionDataFromDB, nil).Once()
+		transactionRepoMock.Mock.On("Purchase", mock.Anything, mock.MatchedBy(func(d V1Domains.TransactionDomain) bool {
+			return d.Product.Price == money.MustParse("1.50") && d.Fee == money.MustParse("1.50") && len(d.FeeBreakdown) == 1
+		})).Return(transactionDataFromDB, nil).Once()
 
 		// Memanggil method Purchase
 		result, statusCode, err := transactionUsecase.Purchase(context.Background(), req.ToDomain())
@@ -223,7 +343,106 @@ func TestPurchaseTransaction(t *testing.T) {
 		assert.NotNil(t, result.UpdatedAt, "UpdatedAt should not be nil")
 	})
 
+	t.Run("When Success Transaction Purchase With Voucher", func(t *testing.T) {
+		req := requests.TransactionPurchaseRequest{
+			ProductId:   1,
+			Quantity:    2,
+			VoucherCode: "hemat10",
+		}
+
+		discounted := transactionDataFromDB
+		discounted.Discount = money.FromMajor(1)
+		voucherCode := "HEMAT10"
+		discounted.VoucherCode = &voucherCode
+
+		transactionProductRepoMock.Mock.On("GetProductById", mock.Anything, 1).Return(V1Domains.ProductDomain{Id: 1, Price: money.FromMajor(5)}, nil).Once()
+		transactionFeeRepoMock.Mock.On("GetApplicable", mock.Anything, constants.TransactionTypePurchase, mock.Anything).Return([]V1Domains.FeeRuleDomain{}, nil).Once()
+		// Kode voucher diteruskan apa adanya, validasi dan normalisasi dilakukan repository di dalam transaksi database
+		transactionRepoMock.Mock.On("Purchase", mock.Anything, mock.MatchedBy(func(d V1Domains.TransactionDomain) bool {
+			return d.VoucherCode != nil && *d.VoucherCode == "hemat10"
+		})).Return(discounted, nil).Once()
+
+		result, statusCode, err := transactionUsecase.Purchase(context.Background(), req.ToDomain())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, statusCode)
+		assert.Equal(t, money.FromMajor(1), result.Discount)
+		assert.Equal(t, &voucherCode, result.VoucherCode)
+	})
+
+	t.Run("When Success Transaction Purchase With Points", func(t *testing.T) {
+		req := requests.TransactionPurchaseRequest{
+			ProductId:    1,
+			Quantity:     2,
+			RedeemPoints: money.FromMajor(3),
+		}
+
+		withPoints := transactionDataFromDB
+		withPoints.PointsRedeemed = money.FromMajor(3)
+		withPoints.PointsEarned = money.MustParse("0.07")
+
+		transactionProductRepoMock.Mock.On("GetProductById", mock.Anything, 1).Return(V1Domains.ProductDomain{Id: 1, Price: money.FromMajor(5)}, nil).Once()
+		transactionFeeRepoMock.Mock.On("GetApplicable", mock.Anything, constants.TransactionTypePurchase, mock.Anything).Return([]V1Domains.FeeRuleDomain{}, nil).Once()
+		transactionRepoMock.Mock.On("Purchase", mock.Anything, mock.MatchedBy(func(d V1Domains.TransactionDomain) bool {
+			return d.PointsRedeemed == money.FromMajor(3)
+		})).Return(withPoints, nil).Once()
+
+		result, statusCode, err := transactionUsecase.Purchase(context.Background(), req.ToDomain())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, statusCode)
+		assert.Equal(t, money.FromMajor(3), result.PointsRedeemed)
+		assert.Equal(t, money.MustParse("0.07"), result.PointsEarned)
+	})
+
 	t.Run("When Failure", func(t *testing.T) {
+		t.Run("Negative Points", func(t *testing.T) {
+			req := requests.TransactionPurchaseRequest{
+				ProductId:    1,
+				Quantity:     2,
+				RedeemPoints: money.FromMajor(-1),
+			}
+
+			_, statusCode, err := transactionUsecase.Purchase(context.Background(), req.ToDomain())
+
+			assert.Equal(t, V1Usecases.ErrPointsMustNotBeNegative, err)
+			assert.Equal(t, http.StatusBadRequest, statusCode)
+		})
+
+		t.Run("Insufficient Points", func(t *testing.T) {
+			req := requests.TransactionPurchaseRequest{
+				ProductId:    1,
+				Quantity:     2,
+				RedeemPoints: money.FromMajor(500),
+			}
+
+			transactionProductRepoMock.Mock.On("GetProductById", mock.Anything, 1).Return(V1Domains.ProductDomain{Id: 1, Price: money.FromMajor(5)}, nil).Once()
+			transactionFeeRepoMock.Mock.On("GetApplicable", mock.Anything, constants.TransactionTypePurchase, mock.Anything).Return([]V1Domains.FeeRuleDomain{}, nil).Once()
+			transactionRepoMock.Mock.On("Purchase", mock.Anything, mock.AnythingOfType("v1.TransactionDomain")).Return(V1Domains.TransactionDomain{}, PostgresRepo.ErrInsufficientPoints).Once()
+
+			_, statusCode, err := transactionUsecase.Purchase(context.Background(), req.ToDomain())
+
+			assert.Equal(t, PostgresRepo.ErrInsufficientPoints, err)
+			assert.Equal(t, http.StatusUnprocessableEntity, statusCode)
+		})
+
+		t.Run("Voucher Fully Redeemed", func(t *testing.T) {
+			req := requests.TransactionPurchaseRequest{
+				ProductId:   1,
+				Quantity:    2,
+				VoucherCode: "HEMAT10",
+			}
+
+			transactionProductRepoMock.Mock.On("GetProductById", mock.Anything, 1).Return(V1Domains.ProductDomain{Id: 1, Price: money.FromMajor(5)}, nil).Once()
+			transactionFeeRepoMock.Mock.On("GetApplicable", mock.Anything, constants.TransactionTypePurchase, mock.Anything).Return([]V1Domains.FeeRuleDomain{}, nil).Once()
+			transactionRepoMock.Mock.On("Purchase", mock.Anything, mock.AnythingOfType("v1.TransactionDomain")).Return(V1Domains.TransactionDomain{}, PostgresRepo.ErrVoucherFullyRedeemed).Once()
+
+			_, statusCode, err := transactionUsecase.Purchase(context.Background(), req.ToDomain())
+
+			assert.Equal(t, PostgresRepo.ErrVoucherFullyRedeemed, err)
+			assert.Equal(t, http.StatusConflict, statusCode)
+		})
+
 		t.Run("Invalid Quantity", func(t *testing.T) {
 			req := requests.TransactionPurchaseRequest{
 				ProductId: 1,
@@ -247,7 +466,7 @@ func TestPurchaseTransaction(t *testing.T) {
 				Quantity:  200,
 			}
 
-			transactionRepoMock.Mock.On("Purchase", mock.Anything, mock.AnythingOfType("v1.TransactionDomain")).Return(V1Domains.TransactionDomain{}, PostgresRepo.ErrProductNotFound).Once()
+			transactionProductRepoMock.Mock.On("GetProductById", mock.Anything, 999).Return(V1Domains.ProductDomain{}, sql.ErrNoRows).Once()
 
 			// Memanggil method Deposit
 			result, statusCode, err := transactionUsecase.Purchase(context.Background(), req.ToDomain())
@@ -266,6 +485,8 @@ func TestPurchaseTransaction(t *testing.T) {
 				Quantity:  999,
 			}
 
+			transactionProductRepoMock.Mock.On("GetProductById", mock.Anything, 1).Return(V1Domains.ProductDomain{Id: 1, Price: money.FromMajor(1)}, nil).Once()
+			transactionFeeRepoMock.Mock.On("GetApplicable", mock.Anything, constants.TransactionTypePurchase, mock.Anything).Return([]V1Domains.FeeRuleDomain{}, nil).Once()
 			transactionRepoMock.Mock.On("Purchase", mock.Anything, mock.AnythingOfType("v1.TransactionDomain")).Return(V1Domains.TransactionDomain{}, PostgresRepo.ErrInsufficientProductStock).Once()
 
 			// Memanggil method Deposit
@@ -285,6 +506,8 @@ func TestPurchaseTransaction(t *testing.T) {
 				Quantity:  200,
 			}
 
+			transactionProductRepoMock.Mock.On("GetProductById", mock.Anything, 1).Return(V1Domains.ProductDomain{Id: 1, Price: money.FromMajor(1)}, nil).Once()
+			transactionFeeRepoMock.Mock.On("GetApplicable", mock.Anything, constants.TransactionTypePurchase, mock.Anything).Return([]V1Domains.FeeRuleDomain{}, nil).Once()
 			transactionRepoMock.Mock.On("Purchase", mock.Anything, mock.AnythingOfType("v1.TransactionDomain")).Return(V1Domains.TransactionDomain{}, PostgresRepo.ErrInsufficientBalance).Once()
 
 			// Memanggil method Deposit
@@ -298,6 +521,491 @@ func TestPurchaseTransaction(t *testing.T) {
 			assert.Equal(t, err, PostgresRepo.ErrInsufficientBalance, "Error message should match")
 		})
 
+		t.Run("Product Price Changed", func(t *testing.T) {
+			req := requests.TransactionPurchaseRequest{
+				ProductId: 1,
+				Quantity:  2,
+			}
+
+			transactionProductRepoMock.Mock.On("GetProductById", mock.Anything, 1).Return(V1Domains.ProductDomain{Id: 1, Price: money.FromMajor(1)}, nil).Once()
+			transactionFeeRepoMock.Mock.On("GetApplicable", mock.Anything, constants.TransactionTypePurchase, mock.Anything).Return([]V1Domains.FeeRuleDomain{}, nil).Once()
+			transactionRepoMock.Mock.On("Purchase", mock.Anything, mock.AnythingOfType("v1.TransactionDomain")).Return(V1Domains.TransactionDomain{}, PostgresRepo.ErrProductPriceChanged).Once()
+
+			_, statusCode, err := transactionUsecase.Purchase(context.Background(), req.ToDomain())
+
+			assert.ErrorIs(t, err, PostgresRepo.ErrProductPriceChanged)
+			assert.Equal(t, http.StatusConflict, statusCode, "Status code should be Conflict (409)")
+		})
+
+	})
+
+}
+
+func TestTransferTransaction(t *testing.T) {
+	setupTransaction(t)
+
+	t.Run("When Success Transaction Transfer", func(t *testing.T) {
+		req := requests.TransactionTransferRequest{
+			RecipientUsername: "spongebob",
+			Amount:            transactionDataFromDB.Amount,
+		}
+
+		transferDataFromDB := V1Domains.TransferDomain{
+			SenderUserId:      transactionDataFromDB.Wallet.UserId,
+			RecipientUserId:   "recipient-user-id",
+			RecipientUsername: "spongebob",
+			Amount:            transactionDataFromDB.Amount,
+			Outgoing:          transactionDataFromDB,
+			Incoming:          transactionsDataFromDB[1],
+		}
+
+		// Mock repository untuk mengembalikan data transfer yang berhasil disimpan
+		transactionRepoMock.Mock.On("Transfer", mock.Anything, mock.AnythingOfType("v1.TransferDomain")).Return(transferDataFromDB, nil).Once()
+
+		// Memanggil method Transfer
+		trDom := req.ToDomain()
+		trDom.SenderUserId = transactionDataFromDB.Wallet.UserId
+		result, statusCode, err := transactionUsecase.Transfer(context.Background(), trDom)
+
+		// Assertions
+		assert.Nil(t, err, "Error should be nil")
+		assert.Equal(t, http.StatusCreated, statusCode, "Status code should be Created (201)")
+		assert.Equal(t, transferDataFromDB.RecipientUserId, result.RecipientUserId, "Recipient user id should match")
+		assert.Equal(t, transferDataFromDB.Outgoing.Id, result.Outgoing.Id, "Outgoing transaction ID should match")
+		assert.Equal(t, transferDataFromDB.Incoming.Id, result.Incoming.Id, "Incoming transaction ID should match")
+	})
+
+	t.Run("When Success Transfer Between Own Pockets", func(t *testing.T) {
+		req := requests.TransactionTransferRequest{
+			ToWalletId: "yyyy-zzzz-aaaa",
+			Amount:     transactionDataFromDB.Amount,
+		}
+
+		// Tanpa penerima, transfer diteruskan ke repository dengan wallet tujuan milik pengirim sendiri
+		transactionRepoMock.Mock.On("Transfer", mock.Anything, mock.MatchedBy(func(tr V1Domains.TransferDomain) bool {
+			return tr.RecipientWalletId == "yyyy-zzzz-aaaa" && tr.RecipientUsername == "" && tr.RecipientUserId == ""
+		})).Return(V1Domains.TransferDomain{Outgoing: transactionDataFromDB, Incoming: transactionsDataFromDB[1]}, nil).Once()
+
+		_, statusCode, err := transactionUsecase.Transfer(context.Background(), req.ToDomain())
+
+		assert.Nil(t, err, "Error should be nil")
+		assert.Equal(t, http.StatusCreated, statusCode, "Status code should be Created (201)")
+	})
+
+	t.Run("When Failure", func(t *testing.T) {
+		t.Run("Invalid Amount", func(t *testing.T) {
+			req := requests.TransactionTransferRequest{
+				RecipientUsername: "spongebob",
+				Amount:            money.FromMajor(-200),
+			}
+
+			result, statusCode, err := transactionUsecase.Transfer(context.Background(), req.ToDomain())
+
+			assert.NotNil(t, err, "Error should not be nil")
+			assert.Equal(t, http.StatusBadRequest, statusCode, "Status code should be Bad Request (400)")
+			assert.Equal(t, "", result.Outgoing.Id, "Transaction ID should be blank string on failure")
+			assert.Equal(t, err, V1Usecases.ErrAmountMustGreateThanZero, "Error message should match")
+		})
+
+		t.Run("Recipient Not Specified", func(t *testing.T) {
+			req := requests.TransactionTransferRequest{
+				Amount: money.FromMajor(200),
+			}
+
+			result, statusCode, err := transactionUsecase.Transfer(context.Background(), req.ToDomain())
+
+			assert.NotNil(t, err, "Error should not be nil")
+			assert.Equal(t, http.StatusBadRequest, statusCode, "Status code should be Bad Request (400)")
+			assert.Equal(t, "", result.Outgoing.Id, "Transaction ID should be blank string on failure")
+			assert.Equal(t, err, V1Usecases.ErrRecipientRequired, "Error message should match")
+		})
+
+		t.Run("Recipient Not Found", func(t *testing.T) {
+			req := requests.TransactionTransferRequest{
+				RecipientEmail: "nobody@gmail.com",
+				Amount:         money.FromMajor(200),
+			}
+
+			transactionRepoMock.Mock.On("Transfer", mock.Anything, mock.AnythingOfType("v1.TransferDomain")).Return(V1Domains.TransferDomain{}, PostgresRepo.ErrRecipientNotFound).Once()
+
+			result, statusCode, err := transactionUsecase.Transfer(context.Background(), req.ToDomain())
+
+			assert.NotNil(t, err, "Error should not be nil")
+			assert.Equal(t, http.StatusNotFound, statusCode, "Status code should be Not Found (404)")
+			assert.Equal(t, "", result.Outgoing.Id, "Transaction ID should be blank string on failure")
+			assert.Equal(t, err, PostgresRepo.ErrRecipientNotFound, "Error message should match")
+		})
+
+		t.Run("Transfer To Self", func(t *testing.T) {
+			req := requests.TransactionTransferRequest{
+				RecipientUserId: transactionDataFromDB.Wallet.UserId,
+				Amount:          money.FromMajor(200),
+			}
+
+			transactionRepoMock.Mock.On("Transfer", mock.Anything, mock.AnythingOfType("v1.TransferDomain")).Return(V1Domains.TransferDomain{}, PostgresRepo.ErrSelfTransfer).Once()
+
+			result, statusCode, err := transactionUsecase.Transfer(context.Background(), req.ToDomain())
+
+			assert.NotNil(t, err, "Error should not be nil")
+			assert.Equal(t, http.StatusBadRequest, statusCode, "Status code should be Bad Request (400)")
+			assert.Equal(t, "", result.Outgoing.Id, "Transaction ID should be blank string on failure")
+			assert.Equal(t, err, PostgresRepo.ErrSelfTransfer, "Error message should match")
+		})
+
+		t.Run("Recipient Ambiguous", func(t *testing.T) {
+			req := requests.TransactionTransferRequest{
+				RecipientUsername: "spongebob",
+				ToWalletId:        "yyyy-zzzz-aaaa",
+				Amount:            money.FromMajor(200),
+			}
+
+			_, statusCode, err := transactionUsecase.Transfer(context.Background(), req.ToDomain())
+
+			assert.Equal(t, http.StatusBadRequest, statusCode, "Status code should be Bad Request (400)")
+			assert.Equal(t, err, V1Usecases.ErrRecipientAmbiguous, "Error message should match")
+		})
+
+		t.Run("Same Pocket", func(t *testing.T) {
+			req := requests.TransactionTransferRequest{
+				WalletId:   "xxxx-yyyy-zzzz",
+				ToWalletId: "xxxx-yyyy-zzzz",
+				Amount:     money.FromMajor(200),
+			}
+
+			transactionRepoMock.Mock.On("Transfer", mock.Anything, mock.AnythingOfType("v1.TransferDomain")).Return(V1Domains.TransferDomain{}, PostgresRepo.ErrSameWalletTransfer).Once()
+
+			_, statusCode, err := transactionUsecase.Transfer(context.Background(), req.ToDomain())
+
+			assert.Equal(t, http.StatusBadRequest, statusCode, "Status code should be Bad Request (400)")
+			assert.Equal(t, err, PostgresRepo.ErrSameWalletTransfer, "Error message should match")
+		})
+
+		t.Run("Insufficient Ballance", func(t *testing.T) {
+			req := requests.TransactionTransferRequest{
+				RecipientUsername: "spongebob",
+				Amount:            money.FromMajor(999999),
+			}
+
+			transactionRepoMock.Mock.On("Transfer", mock.Anything, mock.AnythingOfType("v1.TransferDomain")).Return(V1Domains.TransferDomain{}, PostgresRepo.ErrInsufficientBalance).Once()
+
+			result, statusCode, err := transactionUsecase.Transfer(context.Background(), req.ToDomain())
+
+			assert.NotNil(t, err, "Error should not be nil")
+			assert.Equal(t, http.StatusUnprocessableEntity, statusCode, "Status code should be Unprocessable Entity (422)")
+			assert.Equal(t, "", result.Outgoing.Id, "Transaction ID should be blank string on failure")
+			assert.Equal(t, err, PostgresRepo.ErrInsufficientBalance, "Error message should match")
+		})
+	})
+}
+
+func TestUpdateStatusTransaction(t *testing.T) {
+	setupTransaction(t)
+
+	t.Run("When Success Update Status", func(t *testing.T) {
+		completedTransaction := transactionDataFromDB
+		completedTransaction.Status = constants.TransactionStatusCompleted
+
+		transactionRepoMock.Mock.On("UpdateStatus", mock.Anything, transactionDataFromDB.Id, constants.TransactionStatusCompleted, "").Return(completedTransaction, nil).Once()
+
+		result, statusCode, err := transactionUsecase.UpdateStatus(context.Background(), transactionDataFromDB.Id, constants.TransactionStatusCompleted, "")
+
+		assert.Nil(t, err, "Error should be nil")
+		assert.Equal(t, http.StatusOK, statusCode, "Status code should be OK (200)")
+		assert.Equal(t, transactionDataFromDB.Id, result.Id, "Transaction ID should match")
+		assert.Equal(t, constants.TransactionStatusCompleted, result.Status, "Transaction status should match")
+	})
+
+	t.Run("When Failure", func(t *testing.T) {
+		t.Run("Failure Reason Required", func(t *testing.T) {
+			result, statusCode, err := transactionUsecase.UpdateStatus(context.Background(), transactionDataFromDB.Id, constants.TransactionStatusFailed, "")
+
+			assert.NotNil(t, err, "Error should not be nil")
+			assert.Equal(t, http.StatusBadRequest, statusCode, "Status code should be Bad Request (400)")
+			assert.Equal(t, "", result.Id, "Transaction ID should be blank string on failure")
+			assert.Equal(t, err, V1Usecases.ErrFailureReasonRequired, "Error message should match")
+		})
+
+		t.Run("Transaction Not Found", func(t *testing.T) {
+			transactionRepoMock.Mock.On("UpdateStatus", mock.Anything, "unknown", constants.TransactionStatusCancelled, "").Return(V1Domains.TransactionDomain{}, PostgresRepo.ErrTransactionNotFound).Once()
+
+			result, statusCode, err := transactionUsecase.UpdateStatus(context.Background(), "unknown", constants.TransactionStatusCancelled, "")
+
+			assert.NotNil(t, err, "Error should not be nil")
+			assert.Equal(t, http.StatusNotFound, statusCode, "Status code should be Not Found (404)")
+			assert.Equal(t, "", result.Id, "Transaction ID should be blank string on failure")
+			assert.Equal(t, err, PostgresRepo.ErrTransactionNotFound, "Error message should match")
+		})
+
+		t.Run("Invalid Status Transition", func(t *testing.T) {
+			transactionRepoMock.Mock.On("UpdateStatus", mock.Anything, transactionDataFromDB.Id, constants.TransactionStatusCancelled, "").Return(V1Domains.TransactionDomain{}, PostgresRepo.ErrInvalidStatusTransition).Once()
+
+			result, statusCode, err := transactionUsecase.UpdateStatus(context.Background(), transactionDataFromDB.Id, constants.TransactionStatusCancelled, "")
+
+			assert.NotNil(t, err, "Error should not be nil")
+			assert.Equal(t, http.StatusConflict, statusCode, "Status code should be Conflict (409)")
+			assert.Equal(t, "", result.Id, "Transaction ID should be blank string on failure")
+			assert.Equal(t, err, PostgresRepo.ErrInvalidStatusTransition, "Error message should match")
+		})
+	})
+}
+
+func TestRefundTransaction(t *testing.T) {
+	setupTransaction(t)
+
+	t.Run("When Success Transaction Refund", func(t *testing.T) {
+		refundTransaction := transactionDataFromDB
+		refundTransaction.TransactionType = constants.TransactionTypeRefund
+		refundTransaction.RelatedTransactionId = &transactionsDataFromDB[1].Id
+
+		transactionRepoMock.Mock.On("Refund", mock.Anything, transactionsDataFromDB[1].Id, 0).Return(refundTransaction, nil).Once()
+
+		result, statusCode, err := transactionUsecase.Refund(context.Background(), transactionsDataFromDB[1].Id, 0)
+
+		assert.Nil(t, err, "Error should be nil")
+		assert.Equal(t, http.StatusCreated, statusCode, "Status code should be Created (201)")
+		assert.Equal(t, constants.TransactionTypeRefund, result.TransactionType, "Transaction type should be refund")
+		assert.Equal(t, transactionsDataFromDB[1].Id, *result.RelatedTransactionId, "Refund should be linked to the purchase")
+	})
+
+	t.Run("When Success Partial Refund Reverses Rewards", func(t *testing.T) {
+		quantity := 1
+		refundTransaction := transactionDataFromDB
+		refundTransaction.TransactionType = constants.TransactionTypeRefund
+		refundTransaction.RelatedTransactionId = &transactionsDataFromDB[1].Id
+		refundTransaction.Quantity = &quantity
+		refundTransaction.Amount = money.FromMajor(40)
+		refundTransaction.PointsRedeemed = money.FromMajor(10)
+		refundTransaction.PointsEarned = money.FromMajor(2)
+		refundTransaction.Cashback = money.FromMajor(4)
+
+		transactionRepoMock.Mock.On("Refund", mock.Anything, transactionsDataFromDB[1].Id, quantity).Return(refundTransaction, nil).Once()
+
+		result, statusCode, err := transactionUsecase.Refund(context.Background(), transactionsDataFromDB[1].Id, quantity)
+
+		assert.Nil(t, err, "Error should be nil")
+		assert.Equal(t, http.StatusCreated, statusCode, "Status code should be Created (201)")
+		assert.Equal(t, money.FromMajor(10), result.PointsRedeemed, "Redeemed points should be restored")
+		assert.Equal(t, money.FromMajor(2), result.PointsEarned, "Earned points should be reversed")
+		assert.Equal(t, money.FromMajor(4), result.Cashback, "Cashback should be reversed")
 	})
 
+	t.Run("When Failure", func(t *testing.T) {
+		t.Run("Invalid Quantity", func(t *testing.T) {
+			result, statusCode, err := transactionUsecase.Refund(context.Background(), transactionDataFromDB.Id, -1)
+
+			assert.NotNil(t, err, "Error should not be nil")
+			assert.Equal(t, http.StatusBadRequest, statusCode, "Status code should be Bad Request (400)")
+			assert.Equal(t, "", result.Id, "Transaction ID should be blank string on failure")
+			assert.Equal(t, err, V1Usecases.ErrQuantityMustGreaterThanZero, "Error message should match")
+		})
+
+		t.Run("Not A Purchase", func(t *testing.T) {
+			transactionRepoMock.Mock.On("Refund", mock.Anything, transactionDataFromDB.Id, 0).Return(V1Domains.TransactionDomain{}, PostgresRepo.ErrTransactionNotRefundable).Once()
+
+			result, statusCode, err := transactionUsecase.Refund(context.Background(), transactionDataFromDB.Id, 0)
+
+			assert.NotNil(t, err, "Error should not be nil")
+			assert.Equal(t, http.StatusUnprocessableEntity, statusCode, "Status code should be Unprocessable Entity (422)")
+			assert.Equal(t, "", result.Id, "Transaction ID should be blank string on failure")
+			assert.Equal(t, err, PostgresRepo.ErrTransactionNotRefundable, "Error message should match")
+		})
+
+		t.Run("Quantity Exceeded", func(t *testing.T) {
+			transactionRepoMock.Mock.On("Refund", mock.Anything, transactionDataFromDB.Id, 999).Return(V1Domains.TransactionDomain{}, PostgresRepo.ErrRefundQuantityExceeded).Once()
+
+			result, statusCode, err := transactionUsecase.Refund(context.Background(), transactionDataFromDB.Id, 999)
+
+			assert.NotNil(t, err, "Error should not be nil")
+			assert.Equal(t, http.StatusUnprocessableEntity, statusCode, "Status code should be Unprocessable Entity (422)")
+			assert.Equal(t, "", result.Id, "Transaction ID should be blank string on failure")
+			assert.Equal(t, err, PostgresRepo.ErrRefundQuantityExceeded, "Error message should match")
+		})
+
+		t.Run("Amount Rounds To Zero", func(t *testing.T) {
+			transactionRepoMock.Mock.On("Refund", mock.Anything, transactionDataFromDB.Id, 1).Return(V1Domains.TransactionDomain{}, PostgresRepo.ErrRefundAmountTooSmall).Once()
+
+			result, statusCode, err := transactionUsecase.Refund(context.Background(), transactionDataFromDB.Id, 1)
+
+			assert.NotNil(t, err, "Error should not be nil")
+			assert.Equal(t, http.StatusUnprocessableEntity, statusCode, "Status code should be Unprocessable Entity (422)")
+			assert.Equal(t, "", result.Id, "Transaction ID should be blank string on failure")
+			assert.Equal(t, err, PostgresRepo.ErrRefundAmountTooSmall, "Error message should match")
+		})
+
+		t.Run("Already Refunded", func(t *testing.T) {
+			transactionRepoMock.Mock.On("Refund", mock.Anything, transactionDataFromDB.Id, 1).Return(V1Domains.TransactionDomain{}, PostgresRepo.ErrTransactionAlreadyRefunded).Once()
+
+			result, statusCode, err := transactionUsecase.Refund(context.Background(), transactionDataFromDB.Id, 1)
+
+			assert.NotNil(t, err, "Error should not be nil")
+			assert.Equal(t, http.StatusConflict, statusCode, "Status code should be Conflict (409)")
+			assert.Equal(t, "", result.Id, "Transaction ID should be blank string on failure")
+			assert.Equal(t, err, PostgresRepo.ErrTransactionAlreadyRefunded, "Error message should match")
+		})
+	})
+}
+
+func TestHistoryTransaction(t *testing.T) {
+	setupTransaction(t)
+
+	t.Run("When Success Apply Default Page Size And Sort", func(t *testing.T) {
+		nextCursor := &V1Domains.TransactionCursor{CreatedAt: transactionDataFromDB.CreatedAt, Id: transactionDataFromDB.Id}
+		pageFromDB := V1Domains.TransactionPageDomain{Transactions: transactionsDataFromDB, NextCursor: nextCursor}
+
+		expectedFilter := V1Domains.TransactionFilterDomain{
+			UserId: transactionDataFromDB.Wallet.UserId,
+			Sort:   constants.TransactionSortDesc,
+			Limit:  constants.TransactionPageDefaultLimit,
+		}
+		transactionRepoMock.Mock.On("GetByUserId", mock.Anything, transactionDataFromDB.Wallet.UserId, expectedFilter).Return(pageFromDB, nil).Once()
+
+		result, statusCode, err := transactionUsecase.History(context.Background(), V1Domains.TransactionFilterDomain{UserId: transactionDataFromDB.Wallet.UserId})
+
+		assert.Nil(t, err, "Error should be nil")
+		assert.Equal(t, http.StatusOK, statusCode, "Status code should be OK (200)")
+		assert.Equal(t, len(transactionsDataFromDB), len(result.Transactions), "Page size should match")
+		assert.Equal(t, nextCursor, result.NextCursor, "Next cursor should match")
+	})
+
+	t.Run("When Success Cap Page Size", func(t *testing.T) {
+		expectedFilter := V1Domains.TransactionFilterDomain{
+			UserId: transactionDataFromDB.Wallet.UserId,
+			Sort:   constants.TransactionSortAsc,
+			Limit:  constants.TransactionPageMaxLimit,
+		}
+		transactionRepoMock.Mock.On("GetByUserId", mock.Anything, transactionDataFromDB.Wallet.UserId, expectedFilter).Return(V1Domains.TransactionPageDomain{}, nil).Once()
+
+		_, statusCode, err := transactionUsecase.History(context.Background(), V1Domains.TransactionFilterDomain{UserId: transactionDataFromDB.Wallet.UserId, Sort: constants.TransactionSortAsc, Limit: 1000})
+
+		assert.Nil(t, err, "Error should be nil")
+		assert.Equal(t, http.StatusOK, statusCode, "Status code should be OK (200)")
+	})
+
+	t.Run("When Failure", func(t *testing.T) {
+		t.Run("Invalid Date Range", func(t *testing.T) {
+			from := time.Now()
+			to := from.Add(-time.Hour)
+
+			_, statusCode, err := transactionUsecase.History(context.Background(), V1Domains.TransactionFilterDomain{From: &from, To: &to})
+
+			assert.Equal(t, http.StatusBadRequest, statusCode, "Status code should be Bad Request (400)")
+			assert.Equal(t, err, V1Usecases.ErrInvalidDateRange, "Error message should match")
+		})
+
+		t.Run("Invalid Amount Range", func(t *testing.T) {
+			minAmount := money.FromMajor(100)
+			maxAmount := money.FromMajor(10)
+
+			_, statusCode, err := transactionUsecase.GetAll(context.Background(), V1Domains.TransactionFilterDomain{MinAmount: &minAmount, MaxAmount: &maxAmount})
+
+			assert.Equal(t, http.StatusBadRequest, statusCode, "Status code should be Bad Request (400)")
+			assert.Equal(t, err, V1Usecases.ErrInvalidAmountRange, "Error message should match")
+		})
+	})
+}
+
+func TestGetByIdTransaction(t *testing.T) {
+	setupTransaction(t)
+
+	t.Run("When Success Owner", func(t *testing.T) {
+		transactionRepoMock.Mock.On("GetById", mock.Anything, transactionDataFromDB.Id, transactionDataFromDB.Wallet.UserId).Return(transactionDataFromDB, nil).Once()
+
+		result, statusCode, err := transactionUsecase.GetById(context.Background(), transactionDataFromDB.Id, transactionDataFromDB.Wallet.UserId, false)
+
+		assert.Nil(t, err, "Error should be nil")
+		assert.Equal(t, http.StatusOK, statusCode, "Status code should be OK (200)")
+		assert.Equal(t, transactionDataFromDB.Id, result.Id, "Transaction ID should match")
+		assert.Equal(t, transactionDataFromDB.Wallet, result.Wallet, "Transaction wallet should match")
+	})
+
+	t.Run("When Success Admin Is Not Restricted To Owner", func(t *testing.T) {
+		transactionRepoMock.Mock.On("GetById", mock.Anything, transactionDataFromDB.Id, "").Return(transactionDataFromDB, nil).Once()
+
+		result, statusCode, err := transactionUsecase.GetById(context.Background(), transactionDataFromDB.Id, "admin-user-id", true)
+
+		assert.Nil(t, err, "Error should be nil")
+		assert.Equal(t, http.StatusOK, statusCode, "Status code should be OK (200)")
+		assert.Equal(t, transactionDataFromDB.Id, result.Id, "Transaction ID should match")
+	})
+
+	t.Run("When Failure | Other User Transaction", func(t *testing.T) {
+		transactionRepoMock.Mock.On("GetById", mock.Anything, transactionDataFromDB.Id, "other-user-id").Return(V1Domains.TransactionDomain{}, PostgresRepo.ErrTransactionNotFound).Once()
+
+		result, statusCode, err := transactionUsecase.GetById(context.Background(), transactionDataFromDB.Id, "other-user-id", false)
+
+		assert.NotNil(t, err, "Error should not be nil")
+		assert.Equal(t, http.StatusNotFound, statusCode, "Status code should be Not Found (404)")
+		assert.Equal(t, "", result.Id, "Transaction ID should be blank string on failure")
+		assert.Equal(t, err, PostgresRepo.ErrTransactionNotFound, "Error message should match")
+	})
+}
+
+// recordingStatementWriter menyimpan semua yang ditulis usecase untuk diperiksa
+type recordingStatementWriter struct {
+	begin   V1Domains.StatementDomain
+	entries []V1Domains.StatementEntryDomain
+	end     V1Domains.StatementDomain
+}
+
+func (w *recordingStatementWriter) Begin(statement V1Domains.StatementDomain) error {
+	w.begin = statement
+	return nil
+}
+
+func (w *recordingStatementWriter) Entry(entry V1Domains.StatementEntryDomain) error {
+	w.entries = append(w.entries, entry)
+	return nil
+}
+
+func (w *recordingStatementWriter) End(statement V1Domains.StatementDomain) error {
+	w.end = statement
+	return nil
+}
+
+func TestStatementTransaction(t *testing.T) {
+	setupTransaction(t)
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	t.Run("When Success Compute Running Balance", func(t *testing.T) {
+		streamStatement := func(ctx context.Context, statementDom V1Domains.StatementDomain, onOpening func(V1Domains.StatementDomain) error, onEntry func(V1Domains.StatementEntryDomain) error) error {
+			statementDom.WalletId = transactionDataFromDB.WalletId
+			statementDom.OpeningBalance = money.FromMajor(100)
+			if err := onOpening(statementDom); err != nil {
+				return err
+			}
+			for _, entry := range []V1Domains.StatementEntryDomain{
+				{EntryId: 1, TransactionType: constants.TransactionTypeDeposit, Direction: constants.LedgerDirectionCredit, Amount: money.FromMajor(50)},
+				{EntryId: 2, TransactionType: constants.TransactionTypePurchase, Direction: constants.LedgerDirectionDebit, Amount: money.MustParse("20.90")},
+			} {
+				if err := onEntry(entry); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		transactionRepoMock.Mock.On("StreamStatement", mock.Anything, mock.AnythingOfType("v1.StatementDomain"), mock.Anything, mock.Anything).Return(streamStatement).Once()
+
+		writer := &recordingStatementWriter{}
+		statementDom := &V1Domains.StatementDomain{UserId: transactionDataFromDB.Wallet.UserId, From: from, To: to}
+		statusCode, err := transactionUsecase.Statement(context.Background(), statementDom, writer)
+
+		assert.Nil(t, err, "Error should be nil")
+		assert.Equal(t, http.StatusOK, statusCode, "Status code should be OK (200)")
+		assert.Equal(t, money.FromMajor(100), writer.begin.OpeningBalance, "Opening balance should match")
+		assert.Equal(t, money.FromMajor(150), writer.entries[0].Balance, "Balance after deposit should match")
+		assert.Equal(t, money.MustParse("129.10"), writer.entries[1].Balance, "Balance after purchase should match")
+		assert.Equal(t, money.MustParse("129.10"), writer.end.ClosingBalance, "Closing balance should match")
+		assert.Equal(t, writer.end, *statementDom, "Statement should be updated with balances")
+	})
+
+	t.Run("When Failure | Invalid Period", func(t *testing.T) {
+		writer := &recordingStatementWriter{}
+		statusCode, err := transactionUsecase.Statement(context.Background(), &V1Domains.StatementDomain{From: to, To: from}, writer)
+
+		assert.Equal(t, http.StatusBadRequest, statusCode, "Status code should be Bad Request (400)")
+		assert.Equal(t, err, V1Usecases.ErrInvalidDateRange, "Error message should match")
+		assert.Empty(t, writer.entries, "Nothing should be written")
+	})
 }
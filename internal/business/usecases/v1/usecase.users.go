@@ -34,6 +34,10 @@ func (userUC *userUsecase) Store(ctx context.Context, inDom *V1Domains.UserDomai
 		return V1Domains.UserDomain{}, http.StatusInternalServerError, err
 	}
 
+	if inDom.Timezone == "" {
+		inDom.Timezone = constants.DefaultTimezone
+	}
+
 	inDom.CreatedAt = time.Now().In(constants.GMT7)
 	fmt.Println(time.Now().In(constants.GMT7))
 	err = userUC.repo.Store(ctx, inDom)
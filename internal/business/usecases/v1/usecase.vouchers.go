@@ -0,0 +1,134 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+	"github.com/snykk/transaction-api/internal/utils"
+)
+
+// voucherCodePattern membatasi kode voucher agar mudah diketik dan aman dipakai di URL
+var voucherCodePattern = regexp.MustCompile(`^[A-Z0-9_-]{3,32}$`)
+
+type voucherUsecase struct {
+	repo V1Domains.VoucherRepository
+}
+
+func NewVoucherUsecase(repo V1Domains.VoucherRepository) V1Domains.VoucherUsecase {
+	return &voucherUsecase{
+		repo: repo,
+	}
+}
+
+func (uc *voucherUsecase) GetAll(ctx context.Context) (outDoms []V1Domains.VoucherDomain, statusCode int, err error) {
+	outDoms, err = uc.repo.GetAll(ctx)
+	if err != nil {
+		statusCode, _ = utils.MapDBError(err)
+		return nil, statusCode, err
+	}
+
+	return outDoms, http.StatusOK, nil
+}
+
+func (uc *voucherUsecase) GetById(ctx context.Context, id int) (outDom V1Domains.VoucherDomain, statusCode int, err error) {
+	outDom, err = uc.repo.GetById(ctx, id)
+	if err != nil {
+		statusCode, _ = utils.MapDBError(err)
+		return V1Domains.VoucherDomain{}, statusCode, err
+	}
+
+	return outDom, http.StatusOK, nil
+}
+
+func (uc *voucherUsecase) Store(ctx context.Context, voucherDom *V1Domains.VoucherDomain) (outDom V1Domains.VoucherDomain, statusCode int, err error) {
+	if err = validateVoucher(voucherDom); err != nil {
+		return V1Domains.VoucherDomain{}, http.StatusBadRequest, err
+	}
+
+	outDom, err = uc.repo.Store(ctx, *voucherDom)
+	if err != nil {
+		statusCode, _ = utils.MapDBError(err)
+		return V1Domains.VoucherDomain{}, statusCode, err
+	}
+
+	return outDom, http.StatusCreated, nil
+}
+
+func (uc *voucherUsecase) Update(ctx context.Context, id int, voucherDom *V1Domains.VoucherDomain) (outDom V1Domains.VoucherDomain, statusCode int, err error) {
+	if err = validateVoucher(voucherDom); err != nil {
+		return V1Domains.VoucherDomain{}, http.StatusBadRequest, err
+	}
+
+	voucherDom.Id = id
+	outDom, err = uc.repo.Update(ctx, *voucherDom)
+	if err != nil {
+		statusCode, _ = utils.MapDBError(err)
+		return V1Domains.VoucherDomain{}, statusCode, err
+	}
+
+	return outDom, http.StatusOK, nil
+}
+
+func (uc *voucherUsecase) Delete(ctx context.Context, id int) (statusCode int, err error) {
+	err = uc.repo.Delete(ctx, id)
+	if err != nil {
+		statusCode, _ = utils.MapDBError(err)
+		return statusCode, err
+	}
+
+	return http.StatusOK, nil
+}
+
+// validateVoucher menormalkan kode menjadi huruf besar, memeriksa konfigurasi diskon,
+// dan mengosongkan field yang tidak dipakai oleh discount_type-nya
+func validateVoucher(voucherDom *V1Domains.VoucherDomain) error {
+	voucherDom.Code = strings.ToUpper(strings.TrimSpace(voucherDom.Code))
+	if !voucherCodePattern.MatchString(voucherDom.Code) {
+		return ErrVoucherCodeInvalid
+	}
+
+	switch voucherDom.DiscountType {
+	case constants.VoucherDiscountFixed:
+		if voucherDom.Amount == nil || !voucherDom.Amount.IsPositive() {
+			return ErrVoucherAmountRequired
+		}
+		if voucherDom.MaxDiscount != nil {
+			return ErrVoucherMaxDiscountInvalid
+		}
+		voucherDom.RateBps = nil
+	case constants.VoucherDiscountPercentage:
+		if voucherDom.RateBps == nil || *voucherDom.RateBps <= 0 || *voucherDom.RateBps > constants.FeeBasisPointsPerUnit {
+			return ErrVoucherRateRequired
+		}
+		if voucherDom.MaxDiscount != nil && !voucherDom.MaxDiscount.IsPositive() {
+			return ErrVoucherMaxDiscountInvalid
+		}
+		voucherDom.Amount = nil
+	default:
+		return ErrVoucherTypeInvalid
+	}
+
+	if voucherDom.MinSpend.IsNegative() {
+		return ErrVoucherMinSpendNegative
+	}
+	for _, limit := range []*int{voucherDom.MaxRedemptions, voucherDom.MaxRedemptionsPerUser} {
+		if limit != nil && *limit <= 0 {
+			return ErrVoucherCapInvalid
+		}
+	}
+
+	// Voucher tanpa starts_at langsung berlaku
+	if voucherDom.StartsAt.IsZero() {
+		voucherDom.StartsAt = time.Now()
+	}
+	if voucherDom.EndsAt != nil && !voucherDom.EndsAt.After(voucherDom.StartsAt) {
+		return ErrVoucherWindowInvalid
+	}
+
+	return nil
+}
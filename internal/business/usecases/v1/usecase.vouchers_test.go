@@ -0,0 +1,176 @@
+package v1_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	V1Usecases "github.com/snykk/transaction-api/internal/business/usecases/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+	PostgresRepo "github.com/snykk/transaction-api/internal/datasources/repositories/postgres/v1"
+	"github.com/snykk/transaction-api/internal/mocks"
+	"github.com/snykk/transaction-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	voucherRepoMock *mocks.VoucherRepository
+	voucherUsecase  V1Domains.VoucherUsecase
+)
+
+func setupVoucher(t *testing.T) {
+	voucherRepoMock = mocks.NewVoucherRepository(t)
+	voucherUsecase = V1Usecases.NewVoucherUsecase(voucherRepoMock)
+}
+
+func TestStoreVoucher(t *testing.T) {
+	setupVoucher(t)
+
+	t.Run("When Success Store Percentage Voucher", func(t *testing.T) {
+		rateBps := 1000
+		amount := money.FromMajor(5)
+		voucherDom := V1Domains.VoucherDomain{
+			Code:         " hemat-10 ",
+			DiscountType: constants.VoucherDiscountPercentage,
+			Amount:       &amount, // tidak dipakai oleh percentage, dikosongkan sebelum disimpan
+			RateBps:      &rateBps,
+			IsActive:     true,
+		}
+
+		voucherRepoMock.Mock.On("Store", mock.Anything, mock.MatchedBy(func(v V1Domains.VoucherDomain) bool {
+			return v.Code == "HEMAT-10" && v.Amount == nil && !v.StartsAt.IsZero()
+		})).Return(V1Domains.VoucherDomain{Id: 1, Code: "HEMAT-10"}, nil).Once()
+
+		result, statusCode, err := voucherUsecase.Store(context.Background(), &voucherDom)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, statusCode)
+		assert.Equal(t, 1, result.Id)
+	})
+
+	t.Run("When Failure", func(t *testing.T) {
+		rateBps := 1000
+		zeroRate := 0
+		amount := money.FromMajor(5)
+		zeroCap := 0
+		startsAt := time.Now()
+		endsAt := startsAt.Add(-time.Hour)
+
+		testCases := []struct {
+			name       string
+			voucherDom V1Domains.VoucherDomain
+			err        error
+		}{
+			{
+				name:       "Invalid Code",
+				voucherDom: V1Domains.VoucherDomain{Code: "no spaces", DiscountType: constants.VoucherDiscountFixed, Amount: &amount},
+				err:        V1Usecases.ErrVoucherCodeInvalid,
+			},
+			{
+				name:       "Invalid Discount Type",
+				voucherDom: V1Domains.VoucherDomain{Code: "PROMO", DiscountType: "cashback"},
+				err:        V1Usecases.ErrVoucherTypeInvalid,
+			},
+			{
+				name:       "Fixed Without Amount",
+				voucherDom: V1Domains.VoucherDomain{Code: "PROMO", DiscountType: constants.VoucherDiscountFixed},
+				err:        V1Usecases.ErrVoucherAmountRequired,
+			},
+			{
+				name:       "Fixed With Max Discount",
+				voucherDom: V1Domains.VoucherDomain{Code: "PROMO", DiscountType: constants.VoucherDiscountFixed, Amount: &amount, MaxDiscount: &amount},
+				err:        V1Usecases.ErrVoucherMaxDiscountInvalid,
+			},
+			{
+				name:       "Percentage Without Rate",
+				voucherDom: V1Domains.VoucherDomain{Code: "PROMO", DiscountType: constants.VoucherDiscountPercentage, RateBps: &zeroRate},
+				err:        V1Usecases.ErrVoucherRateRequired,
+			},
+			{
+				name:       "Zero Redemption Cap",
+				voucherDom: V1Domains.VoucherDomain{Code: "PROMO", DiscountType: constants.VoucherDiscountPercentage, RateBps: &rateBps, MaxRedemptionsPerUser: &zeroCap},
+				err:        V1Usecases.ErrVoucherCapInvalid,
+			},
+			{
+				name:       "Ends Before Starts",
+				voucherDom: V1Domains.VoucherDomain{Code: "PROMO", DiscountType: constants.VoucherDiscountPercentage, RateBps: &rateBps, StartsAt: startsAt, EndsAt: &endsAt},
+				err:        V1Usecases.ErrVoucherWindowInvalid,
+			},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, statusCode, err := voucherUsecase.Store(context.Background(), &tc.voucherDom)
+
+				assert.ErrorIs(t, err, tc.err)
+				assert.Equal(t, http.StatusBadRequest, statusCode)
+			})
+		}
+	})
+
+	t.Run("When Code Already Taken", func(t *testing.T) {
+		amount := money.FromMajor(5)
+		voucherDom := V1Domains.VoucherDomain{Code: "PROMO", DiscountType: constants.VoucherDiscountFixed, Amount: &amount}
+
+		voucherRepoMock.Mock.On("Store", mock.Anything, mock.Anything).Return(V1Domains.VoucherDomain{}, PostgresRepo.ErrVoucherCodeTaken).Once()
+
+		_, statusCode, err := voucherUsecase.Store(context.Background(), &voucherDom)
+
+		assert.ErrorIs(t, err, PostgresRepo.ErrVoucherCodeTaken)
+		assert.Equal(t, http.StatusConflict, statusCode)
+	})
+}
+
+func TestUpdateVoucher(t *testing.T) {
+	setupVoucher(t)
+
+	amount := money.FromMajor(5)
+	maxRedemptions := 10
+	voucherDom := V1Domains.VoucherDomain{Code: "PROMO", DiscountType: constants.VoucherDiscountFixed, Amount: &amount, MaxRedemptions: &maxRedemptions}
+
+	t.Run("When Success Update Voucher", func(t *testing.T) {
+		voucherRepoMock.Mock.On("Update", mock.Anything, mock.MatchedBy(func(v V1Domains.VoucherDomain) bool {
+			return v.Id == 7
+		})).Return(V1Domains.VoucherDomain{Id: 7, Code: "PROMO"}, nil).Once()
+
+		result, statusCode, err := voucherUsecase.Update(context.Background(), 7, &voucherDom)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, 7, result.Id)
+	})
+
+	t.Run("When Cap Below Redemptions", func(t *testing.T) {
+		voucherRepoMock.Mock.On("Update", mock.Anything, mock.Anything).Return(V1Domains.VoucherDomain{}, PostgresRepo.ErrVoucherCapBelowRedemptions).Once()
+
+		_, statusCode, err := voucherUsecase.Update(context.Background(), 7, &voucherDom)
+
+		assert.ErrorIs(t, err, PostgresRepo.ErrVoucherCapBelowRedemptions)
+		assert.Equal(t, http.StatusConflict, statusCode)
+	})
+
+	t.Run("When Voucher Not Found", func(t *testing.T) {
+		voucherRepoMock.Mock.On("Update", mock.Anything, mock.Anything).Return(V1Domains.VoucherDomain{}, PostgresRepo.ErrVoucherNotFound).Once()
+
+		_, statusCode, err := voucherUsecase.Update(context.Background(), 8, &voucherDom)
+
+		assert.ErrorIs(t, err, PostgresRepo.ErrVoucherNotFound)
+		assert.Equal(t, http.StatusNotFound, statusCode)
+	})
+}
+
+func TestDeleteVoucher(t *testing.T) {
+	setupVoucher(t)
+
+	t.Run("When Success Delete Voucher", func(t *testing.T) {
+		voucherRepoMock.Mock.On("Delete", mock.Anything, 7).Return(nil).Once()
+
+		statusCode, err := voucherUsecase.Delete(context.Background(), 7)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+	})
+}
@@ -5,8 +5,10 @@ import (
 	"database/sql"
 	"errors"
 	"net/http"
+	"strings"
 
 	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/internal/constants"
 	"github.com/snykk/transaction-api/internal/utils"
 )
 
@@ -63,3 +65,88 @@ func (walletUC *walletUsecase) GetWalletByUserId(ctx context.Context, userId str
 
 	return walletDom, http.StatusOK, nil
 }
+
+func (walletUC *walletUsecase) Create(ctx context.Context, userId string, name string) (V1Domains.WalletDomain, int, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return V1Domains.WalletDomain{}, http.StatusBadRequest, ErrWalletNameRequired
+	}
+
+	walletDom, err := walletUC.repo.CreateWallet(ctx, userId, name)
+	if err != nil {
+		statusCode, _ := utils.MapDBError(err)
+		return V1Domains.WalletDomain{}, statusCode, err
+	}
+
+	return walletDom, http.StatusCreated, nil
+}
+
+func (walletUC *walletUsecase) GetWalletsByUserId(ctx context.Context, userId string) ([]V1Domains.WalletDomain, int, error) {
+	wallets, err := walletUC.repo.GetWalletsByUserId(ctx, userId)
+	if err != nil {
+		statusCode, _ := utils.MapDBError(err)
+		return nil, statusCode, err
+	}
+
+	return wallets, http.StatusOK, nil
+}
+
+func (walletUC *walletUsecase) SetDefault(ctx context.Context, userId string, walletId string) (V1Domains.WalletDomain, int, error) {
+	walletDom, err := walletUC.repo.SetDefault(ctx, userId, walletId)
+	if err != nil {
+		statusCode, _ := utils.MapDBError(err)
+		return V1Domains.WalletDomain{}, statusCode, err
+	}
+
+	return walletDom, http.StatusOK, nil
+}
+
+func (walletUC *walletUsecase) Freeze(ctx context.Context, walletId string, direction string, reason string, actorId string) (V1Domains.WalletDomain, int, error) {
+	status, ok := V1Domains.WalletStatusForFreeze(direction)
+	if !ok {
+		return V1Domains.WalletDomain{}, http.StatusBadRequest, ErrFreezeDirectionInvalid
+	}
+
+	return walletUC.updateStatus(ctx, V1Domains.WalletStatusEventDomain{
+		WalletId: walletId,
+		Action:   constants.WalletStatusActionFreeze,
+		Status:   status,
+		Reason:   reason,
+		ActorId:  &actorId,
+	})
+}
+
+func (walletUC *walletUsecase) Unfreeze(ctx context.Context, walletId string, reason string, actorId string) (V1Domains.WalletDomain, int, error) {
+	return walletUC.updateStatus(ctx, V1Domains.WalletStatusEventDomain{
+		WalletId: walletId,
+		Action:   constants.WalletStatusActionUnfreeze,
+		Status:   constants.WalletStatusActive,
+		Reason:   reason,
+		ActorId:  &actorId,
+	})
+}
+
+// updateStatus menyimpan perubahan status wallet beserta jejak auditnya, alasan wajib diisi
+func (walletUC *walletUsecase) updateStatus(ctx context.Context, eventDom V1Domains.WalletStatusEventDomain) (V1Domains.WalletDomain, int, error) {
+	if strings.TrimSpace(eventDom.Reason) == "" {
+		return V1Domains.WalletDomain{}, http.StatusBadRequest, ErrWalletStatusReasonRequired
+	}
+
+	walletDom, err := walletUC.repo.UpdateStatus(ctx, eventDom)
+	if err != nil {
+		statusCode, _ := utils.MapDBError(err)
+		return V1Domains.WalletDomain{}, statusCode, err
+	}
+
+	return walletDom, http.StatusOK, nil
+}
+
+func (walletUC *walletUsecase) GetStatusEvents(ctx context.Context, walletId string) ([]V1Domains.WalletStatusEventDomain, int, error) {
+	events, err := walletUC.repo.GetStatusEvents(ctx, walletId)
+	if err != nil {
+		statusCode, _ := utils.MapDBError(err)
+		return nil, statusCode, err
+	}
+
+	return events, http.StatusOK, nil
+}
@@ -11,6 +11,8 @@ import (
 
 	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
 	V1Usecases "github.com/snykk/transaction-api/internal/business/usecases/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+	PostgresRepo "github.com/snykk/transaction-api/internal/datasources/repositories/postgres/v1"
 	"github.com/snykk/transaction-api/internal/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -219,3 +221,210 @@ func TestGetWalletByUserId(t *testing.T) {
 		assert.Equal(t, V1Domains.WalletDomain{}, result, "Result should be empty")
 	})
 }
+
+func TestCreateWallet(t *testing.T) {
+	setupWallet(t)
+
+	t.Run("When Success Create Pocket", func(t *testing.T) {
+		pocket := walletDataFromDB
+		pocket.Name = "savings"
+
+		// Nama di-trim sebelum diteruskan ke repository
+		walletRepoMock.Mock.On("CreateWallet", mock.Anything, "aaaa-bbbb-cccc", "savings").Return(pocket, nil).Once()
+
+		result, statusCode, err := walletUsecase.Create(context.Background(), "aaaa-bbbb-cccc", "  savings ")
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, statusCode)
+		assert.Equal(t, "savings", result.Name)
+	})
+
+	t.Run("When Name Is Blank", func(t *testing.T) {
+		result, statusCode, err := walletUsecase.Create(context.Background(), "aaaa-bbbb-cccc", "   ")
+
+		assert.Equal(t, V1Usecases.ErrWalletNameRequired, err)
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		assert.Equal(t, V1Domains.WalletDomain{}, result)
+	})
+
+	t.Run("When Name Already Taken", func(t *testing.T) {
+		walletRepoMock.Mock.On("CreateWallet", mock.Anything, "aaaa-bbbb-cccc", "main").Return(V1Domains.WalletDomain{}, PostgresRepo.ErrWalletNameTaken).Once()
+
+		_, statusCode, err := walletUsecase.Create(context.Background(), "aaaa-bbbb-cccc", "main")
+
+		assert.Equal(t, PostgresRepo.ErrWalletNameTaken, err)
+		assert.Equal(t, http.StatusConflict, statusCode)
+	})
+}
+
+func TestGetWalletsByUserId(t *testing.T) {
+	setupWallet(t)
+
+	t.Run("When Success Get Pockets", func(t *testing.T) {
+		walletRepoMock.Mock.On("GetWalletsByUserId", mock.Anything, "aaaa-bbbb-cccc").Return(walletsDataFromDB, nil).Once()
+
+		result, statusCode, err := walletUsecase.GetWalletsByUserId(context.Background(), "aaaa-bbbb-cccc")
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Len(t, result, len(walletsDataFromDB))
+	})
+
+	t.Run("When Repository Fails", func(t *testing.T) {
+		walletRepoMock.Mock.On("GetWalletsByUserId", mock.Anything, "aaaa-bbbb-cccc").Return(nil, errors.New("get wallets failed")).Once()
+
+		result, statusCode, err := walletUsecase.GetWalletsByUserId(context.Background(), "aaaa-bbbb-cccc")
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, statusCode)
+		assert.Nil(t, result)
+	})
+}
+
+func TestSetDefault(t *testing.T) {
+	setupWallet(t)
+
+	t.Run("When Success Set Default", func(t *testing.T) {
+		pocket := walletDataFromDB
+		pocket.IsDefault = true
+
+		walletRepoMock.Mock.On("SetDefault", mock.Anything, "aaaa-bbbb-cccc", walletDataFromDB.Id).Return(pocket, nil).Once()
+
+		result, statusCode, err := walletUsecase.SetDefault(context.Background(), "aaaa-bbbb-cccc", walletDataFromDB.Id)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.True(t, result.IsDefault)
+	})
+
+	t.Run("When Wallet Belongs To Another User", func(t *testing.T) {
+		walletRepoMock.Mock.On("SetDefault", mock.Anything, "aaaa-bbbb-cccc", "yyyy-zzzz-aaaa").Return(V1Domains.WalletDomain{}, PostgresRepo.ErrWalletNotFound).Once()
+
+		_, statusCode, err := walletUsecase.SetDefault(context.Background(), "aaaa-bbbb-cccc", "yyyy-zzzz-aaaa")
+
+		assert.Equal(t, PostgresRepo.ErrWalletNotFound, err)
+		assert.Equal(t, http.StatusNotFound, statusCode)
+	})
+}
+
+func TestFreeze(t *testing.T) {
+	setupWallet(t)
+
+	t.Run("When Success Freeze Outgoing", func(t *testing.T) {
+		frozenWallet := walletDataFromDB
+		frozenWallet.Status = constants.WalletStatusFrozenOutgoing
+
+		// Arah outgoing dipetakan ke status frozen_outgoing dan admin dicatat sebagai pelaku
+		walletRepoMock.Mock.On("UpdateStatus", mock.Anything, mock.MatchedBy(func(e V1Domains.WalletStatusEventDomain) bool {
+			return e.WalletId == walletDataFromDB.Id &&
+				e.Action == constants.WalletStatusActionFreeze &&
+				e.Status == constants.WalletStatusFrozenOutgoing &&
+				e.Reason == "suspicious activity" &&
+				e.ActorId != nil && *e.ActorId == "admin-id"
+		})).Return(frozenWallet, nil).Once()
+
+		result, statusCode, err := walletUsecase.Freeze(context.Background(), walletDataFromDB.Id, constants.WalletFreezeOutgoing, "suspicious activity", "admin-id")
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, constants.WalletStatusFrozenOutgoing, result.Status)
+	})
+
+	t.Run("When Direction Is Invalid", func(t *testing.T) {
+		result, statusCode, err := walletUsecase.Freeze(context.Background(), walletDataFromDB.Id, "sideways", "suspicious activity", "admin-id")
+
+		assert.Equal(t, V1Usecases.ErrFreezeDirectionInvalid, err)
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		assert.Equal(t, V1Domains.WalletDomain{}, result)
+	})
+
+	t.Run("When Reason Is Blank", func(t *testing.T) {
+		_, statusCode, err := walletUsecase.Freeze(context.Background(), walletDataFromDB.Id, constants.WalletFreezeBoth, "   ", "admin-id")
+
+		assert.Equal(t, V1Usecases.ErrWalletStatusReasonRequired, err)
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+	})
+
+	t.Run("When Wallet Already Frozen", func(t *testing.T) {
+		walletRepoMock.Mock.On("UpdateStatus", mock.Anything, mock.Anything).Return(V1Domains.WalletDomain{}, PostgresRepo.ErrWalletStatusUnchanged).Once()
+
+		_, statusCode, err := walletUsecase.Freeze(context.Background(), walletDataFromDB.Id, constants.WalletFreezeBoth, "court order", "admin-id")
+
+		assert.Equal(t, PostgresRepo.ErrWalletStatusUnchanged, err)
+		assert.Equal(t, http.StatusConflict, statusCode)
+	})
+
+	t.Run("When Wallet Not Found", func(t *testing.T) {
+		walletRepoMock.Mock.On("UpdateStatus", mock.Anything, mock.Anything).Return(V1Domains.WalletDomain{}, PostgresRepo.ErrWalletNotFound).Once()
+
+		_, statusCode, err := walletUsecase.Freeze(context.Background(), walletDataFromDB.Id, constants.WalletFreezeIncoming, "court order", "admin-id")
+
+		assert.Equal(t, PostgresRepo.ErrWalletNotFound, err)
+		assert.Equal(t, http.StatusNotFound, statusCode)
+	})
+}
+
+func TestUnfreeze(t *testing.T) {
+	setupWallet(t)
+
+	t.Run("When Success Unfreeze", func(t *testing.T) {
+		activeWallet := walletDataFromDB
+		activeWallet.Status = constants.WalletStatusActive
+
+		walletRepoMock.Mock.On("UpdateStatus", mock.Anything, mock.MatchedBy(func(e V1Domains.WalletStatusEventDomain) bool {
+			return e.Action == constants.WalletStatusActionUnfreeze && e.Status == constants.WalletStatusActive
+		})).Return(activeWallet, nil).Once()
+
+		result, statusCode, err := walletUsecase.Unfreeze(context.Background(), walletDataFromDB.Id, "investigation closed", "admin-id")
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, constants.WalletStatusActive, result.Status)
+	})
+
+	t.Run("When Wallet Is Not Frozen", func(t *testing.T) {
+		walletRepoMock.Mock.On("UpdateStatus", mock.Anything, mock.Anything).Return(V1Domains.WalletDomain{}, PostgresRepo.ErrWalletStatusUnchanged).Once()
+
+		_, statusCode, err := walletUsecase.Unfreeze(context.Background(), walletDataFromDB.Id, "investigation closed", "admin-id")
+
+		assert.Equal(t, PostgresRepo.ErrWalletStatusUnchanged, err)
+		assert.Equal(t, http.StatusConflict, statusCode)
+	})
+}
+
+func TestGetStatusEvents(t *testing.T) {
+	setupWallet(t)
+
+	t.Run("When Success Get Status Events", func(t *testing.T) {
+		actorId := "admin-id"
+		events := []V1Domains.WalletStatusEventDomain{
+			{
+				Id:             "eeee-1111",
+				WalletId:       walletDataFromDB.Id,
+				Action:         constants.WalletStatusActionFreeze,
+				PreviousStatus: constants.WalletStatusActive,
+				Status:         constants.WalletStatusFrozen,
+				Reason:         "court order",
+				ActorId:        &actorId,
+				CreatedAt:      time.Now(),
+			},
+		}
+		walletRepoMock.Mock.On("GetStatusEvents", mock.Anything, walletDataFromDB.Id).Return(events, nil).Once()
+
+		result, statusCode, err := walletUsecase.GetStatusEvents(context.Background(), walletDataFromDB.Id)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, events, result)
+	})
+
+	t.Run("When Wallet Not Found", func(t *testing.T) {
+		walletRepoMock.Mock.On("GetStatusEvents", mock.Anything, walletDataFromDB.Id).Return(nil, PostgresRepo.ErrWalletNotFound).Once()
+
+		result, statusCode, err := walletUsecase.GetStatusEvents(context.Background(), walletDataFromDB.Id)
+
+		assert.Equal(t, PostgresRepo.ErrWalletNotFound, err)
+		assert.Equal(t, http.StatusNotFound, statusCode)
+		assert.Nil(t, result)
+	})
+}
@@ -0,0 +1,345 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+	"github.com/snykk/transaction-api/internal/utils"
+	"github.com/snykk/transaction-api/pkg/webhook"
+)
+
+type webhookUsecase struct {
+	repo          V1Domains.WebhookRepository
+	sender        webhook.Sender
+	maxAttempts   int           // percobaan kirim sebelum pengiriman menjadi dead
+	retryInterval time.Duration // jeda percobaan ulang pertama, berlipat dua setiap percobaan berikutnya
+	maxRetryDelay time.Duration // batas atas jeda percobaan ulang
+	lease         time.Duration // lama pengiriman diklaim satu dispatcher
+}
+
+func NewWebhookUsecase(repo V1Domains.WebhookRepository, sender webhook.Sender, maxAttempts int, retryInterval time.Duration, maxRetryDelay time.Duration, lease time.Duration) V1Domains.WebhookUsecase {
+	return &webhookUsecase{
+		repo:          repo,
+		sender:        sender,
+		maxAttempts:   maxAttempts,
+		retryInterval: retryInterval,
+		maxRetryDelay: maxRetryDelay,
+		lease:         lease,
+	}
+}
+
+// webhookEnvelope adalah body yang diterima receiver, id sama dengan header X-Webhook-Id
+type webhookEnvelope struct {
+	Id        string          `json:"id"`
+	EventId   *string         `json:"event_id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// webhookTestPayload adalah data event webhook.test
+type webhookTestPayload struct {
+	WebhookId string    `json:"webhook_id"`
+	Message   string    `json:"message"`
+	SentAt    time.Time `json:"sent_at"`
+}
+
+func (uc *webhookUsecase) Store(ctx context.Context, webhookDom *V1Domains.WebhookEndpointDomain) (outDom V1Domains.WebhookEndpointDomain, statusCode int, err error) {
+	if err = uc.validateWebhook(ctx, webhookDom); err != nil {
+		return V1Domains.WebhookEndpointDomain{}, http.StatusBadRequest, err
+	}
+
+	// secret dibuat server jika user tidak menentukannya sendiri
+	if webhookDom.Secret == "" {
+		webhookDom.Secret, err = webhook.GenerateSecret()
+		if err != nil {
+			return V1Domains.WebhookEndpointDomain{}, http.StatusInternalServerError, err
+		}
+	}
+
+	outDom, err = uc.repo.Store(ctx, *webhookDom)
+	if err != nil {
+		statusCode, _ = utils.MapDBError(err)
+		return V1Domains.WebhookEndpointDomain{}, statusCode, err
+	}
+
+	return outDom, http.StatusCreated, nil
+}
+
+func (uc *webhookUsecase) GetAll(ctx context.Context, userId string) (outDoms []V1Domains.WebhookEndpointDomain, statusCode int, err error) {
+	outDoms, err = uc.repo.GetAll(ctx, userId)
+	if err != nil {
+		statusCode, _ = utils.MapDBError(err)
+		return nil, statusCode, err
+	}
+
+	return outDoms, http.StatusOK, nil
+}
+
+func (uc *webhookUsecase) GetById(ctx context.Context, userId string, webhookId string) (outDom V1Domains.WebhookEndpointDomain, statusCode int, err error) {
+	outDom, err = uc.repo.GetById(ctx, userId, webhookId)
+	if err != nil {
+		statusCode, _ = utils.MapDBError(err)
+		return V1Domains.WebhookEndpointDomain{}, statusCode, err
+	}
+
+	return outDom, http.StatusOK, nil
+}
+
+func (uc *webhookUsecase) Update(ctx context.Context, userId string, webhookId string, webhookDom *V1Domains.WebhookEndpointDomain) (outDom V1Domains.WebhookEndpointDomain, statusCode int, err error) {
+	if err = uc.validateWebhook(ctx, webhookDom); err != nil {
+		return V1Domains.WebhookEndpointDomain{}, http.StatusBadRequest, err
+	}
+
+	webhookDom.Id = webhookId
+	webhookDom.UserId = userId
+	outDom, err = uc.repo.Update(ctx, *webhookDom)
+	if err != nil {
+		statusCode, _ = utils.MapDBError(err)
+		return V1Domains.WebhookEndpointDomain{}, statusCode, err
+	}
+
+	return outDom, http.StatusOK, nil
+}
+
+func (uc *webhookUsecase) Delete(ctx context.Context, userId string, webhookId string) (statusCode int, err error) {
+	err = uc.repo.Delete(ctx, userId, webhookId)
+	if err != nil {
+		statusCode, _ = utils.MapDBError(err)
+		return statusCode, err
+	}
+
+	return http.StatusOK, nil
+}
+
+func (uc *webhookUsecase) GetDeliveries(ctx context.Context, userId string, webhookId string) (outDoms []V1Domains.WebhookDeliveryDomain, statusCode int, err error) {
+	outDoms, err = uc.repo.GetDeliveries(ctx, userId, webhookId, constants.WebhookDeliveryLogLimit)
+	if err != nil {
+		statusCode, _ = utils.MapDBError(err)
+		return nil, statusCode, err
+	}
+
+	return outDoms, http.StatusOK, nil
+}
+
+func (uc *webhookUsecase) GetDeliveryById(ctx context.Context, userId string, webhookId string, deliveryId string) (outDom V1Domains.WebhookDeliveryDomain, statusCode int, err error) {
+	outDom, err = uc.repo.GetDeliveryById(ctx, userId, webhookId, deliveryId)
+	if err != nil {
+		statusCode, _ = utils.MapDBError(err)
+		return V1Domains.WebhookDeliveryDomain{}, statusCode, err
+	}
+
+	return outDom, http.StatusOK, nil
+}
+
+func (uc *webhookUsecase) Test(ctx context.Context, userId string, webhookId string) (outDom V1Domains.WebhookDeliveryDomain, statusCode int, err error) {
+	webhookDom, err := uc.repo.GetById(ctx, userId, webhookId)
+	if err != nil {
+		statusCode, _ = utils.MapDBError(err)
+		return V1Domains.WebhookDeliveryDomain{}, statusCode, err
+	}
+
+	now := time.Now()
+	payload, err := json.Marshal(webhookTestPayload{
+		WebhookId: webhookDom.Id,
+		Message:   "this is a test event from transaction-api",
+		SentAt:    now,
+	})
+	if err != nil {
+		return V1Domains.WebhookDeliveryDomain{}, http.StatusInternalServerError, err
+	}
+
+	// next_attempt_at diisi lease agar dispatcher tidak ikut mengirim sebelum hasilnya tercatat
+	deliveryDom, err := uc.repo.StoreDelivery(ctx, V1Domains.WebhookDeliveryDomain{
+		WebhookId:     webhookDom.Id,
+		EventType:     constants.EventWebhookTest,
+		Payload:       payload,
+		Status:        constants.WebhookDeliveryStatusPending,
+		NextAttemptAt: now.Add(uc.lease),
+		Webhook:       webhookDom,
+	})
+	if err != nil {
+		statusCode, _ = utils.MapDBError(err)
+		return V1Domains.WebhookDeliveryDomain{}, statusCode, err
+	}
+
+	// hasil kirim uji langsung dilaporkan ke user, sehingga tidak dicoba ulang
+	outDom, err = uc.deliver(ctx, deliveryDom, false)
+	if err != nil {
+		statusCode, _ = utils.MapDBError(err)
+		return V1Domains.WebhookDeliveryDomain{}, statusCode, err
+	}
+
+	return outDom, http.StatusOK, nil
+}
+
+func (uc *webhookUsecase) Redeliver(ctx context.Context, userId string, webhookId string, deliveryId string) (outDom V1Domains.WebhookDeliveryDomain, statusCode int, err error) {
+	deliveryDom, err := uc.repo.GetDeliveryById(ctx, userId, webhookId, deliveryId)
+	if err != nil {
+		statusCode, _ = utils.MapDBError(err)
+		return V1Domains.WebhookDeliveryDomain{}, statusCode, err
+	}
+
+	// pengiriman yang gagal lagi mengikuti jadwal percobaan ulang biasa selama jatah percobaannya masih ada
+	outDom, err = uc.deliver(ctx, deliveryDom, deliveryDom.EventType != constants.EventWebhookTest)
+	if err != nil {
+		statusCode, _ = utils.MapDBError(err)
+		return V1Domains.WebhookDeliveryDomain{}, statusCode, err
+	}
+
+	return outDom, http.StatusOK, nil
+}
+
+func (uc *webhookUsecase) Enqueue(ctx context.Context, event V1Domains.EventDomain) (queued int, err error) {
+	// endpoint webhook dimiliki user, sehingga hanya event yang menyebut user_id yang diteruskan
+	var owner struct {
+		UserId string `json:"user_id"`
+	}
+	if err = json.Unmarshal(event.Payload, &owner); err != nil {
+		return 0, err
+	}
+	if owner.UserId == "" {
+		return 0, nil
+	}
+
+	return uc.repo.Enqueue(ctx, event, owner.UserId, time.Now())
+}
+
+// DispatchDue mengirim pengiriman satu per satu, lease harus cukup untuk satu batch penuh yang semuanya timeout
+func (uc *webhookUsecase) DispatchDue(ctx context.Context, now time.Time) (delivered int, failed int, err error) {
+	deliveries, err := uc.repo.ClaimDue(ctx, now, uc.lease, constants.WebhookDispatchBatchSize)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, deliveryDom := range deliveries {
+		result, err := uc.deliver(ctx, deliveryDom, true)
+		if err != nil {
+			return delivered, failed, err
+		}
+
+		if result.Status == constants.WebhookDeliveryStatusDelivered {
+			delivered++
+		} else {
+			failed++
+		}
+	}
+
+	return delivered, failed, nil
+}
+
+// deliver melakukan satu percobaan kirim lalu mencatat hasilnya. Jika retry false atau percobaan sudah habis,
+// pengiriman yang gagal langsung menjadi dead.
+func (uc *webhookUsecase) deliver(ctx context.Context, deliveryDom V1Domains.WebhookDeliveryDomain, retry bool) (V1Domains.WebhookDeliveryDomain, error) {
+	body, err := json.Marshal(webhookEnvelope{
+		Id:        deliveryDom.Id,
+		EventId:   deliveryDom.EventId,
+		Type:      deliveryDom.EventType,
+		CreatedAt: deliveryDom.CreatedAt,
+		Data:      deliveryDom.Payload,
+	})
+	if err != nil {
+		return V1Domains.WebhookDeliveryDomain{}, err
+	}
+
+	attemptedAt := time.Now()
+	result, sendErr := uc.sender.Send(ctx, deliveryDom.Webhook.URL, deliveryDom.Webhook.Secret, webhook.Message{
+		Id:    deliveryDom.Id,
+		Event: deliveryDom.EventType,
+		Body:  body,
+	})
+
+	deliveryDom.Attempts++
+	attemptDom := V1Domains.WebhookAttemptDomain{
+		DeliveryId:  deliveryDom.Id,
+		Attempt:     deliveryDom.Attempts,
+		DurationMs:  int(result.Duration.Milliseconds()),
+		AttemptedAt: attemptedAt,
+	}
+	if result.StatusCode != 0 {
+		attemptDom.StatusCode = &result.StatusCode
+	}
+	deliveryDom.LastStatusCode = attemptDom.StatusCode
+
+	if sendErr == nil {
+		deliveryDom.Status = constants.WebhookDeliveryStatusDelivered
+		deliveryDom.DeliveredAt = &attemptedAt
+		deliveryDom.LastError = nil
+		deliveryDom.NextAttemptAt = attemptedAt
+	} else {
+		reason := sendErr.Error()
+		attemptDom.Error = &reason
+		deliveryDom.LastError = &reason
+
+		if retry && deliveryDom.Attempts < uc.maxAttempts {
+			deliveryDom.Status = constants.WebhookDeliveryStatusPending
+			deliveryDom.NextAttemptAt = attemptedAt.Add(uc.retryDelay(deliveryDom.Attempts))
+		} else {
+			deliveryDom.Status = constants.WebhookDeliveryStatusDead
+			deliveryDom.NextAttemptAt = attemptedAt
+		}
+	}
+
+	return uc.repo.RecordAttempt(ctx, deliveryDom, attemptDom)
+}
+
+// retryDelay menghitung jeda setelah percobaan ke-attempt gagal, retryInterval * 2^(attempt-1) dibatasi maxRetryDelay
+func (uc *webhookUsecase) retryDelay(attempt int) time.Duration {
+	delay := uc.retryInterval
+	for i := 1; i < attempt && delay < uc.maxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > uc.maxRetryDelay {
+		delay = uc.maxRetryDelay
+	}
+
+	return delay
+}
+
+// validateWebhook memeriksa URL tujuan, tipe event yang dilanggan, dan panjang secret.
+// URL yang mengarah ke jaringan internal ditolak agar webhook tidak bisa dipakai menjangkau layanan internal.
+func (uc *webhookUsecase) validateWebhook(ctx context.Context, webhookDom *V1Domains.WebhookEndpointDomain) error {
+	target, err := url.Parse(webhookDom.URL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return ErrWebhookURLInvalid
+	}
+
+	err = uc.sender.ValidateDestination(ctx, webhookDom.URL)
+	if errors.Is(err, webhook.ErrDestinationNotAllowed) {
+		return ErrWebhookURLNotAllowed
+	}
+	if err != nil {
+		return ErrWebhookURLUnresolvable
+	}
+
+	if len(webhookDom.EventTypes) == 0 {
+		return ErrWebhookEventTypesRequired
+	}
+	for _, eventType := range webhookDom.EventTypes {
+		if !isWebhookEventType(eventType) {
+			return ErrWebhookEventTypeInvalid
+		}
+	}
+
+	if webhookDom.Secret != "" && len(webhookDom.Secret) < constants.WebhookSecretMinLength {
+		return ErrWebhookSecretTooShort
+	}
+
+	return nil
+}
+
+func isWebhookEventType(eventType string) bool {
+	for _, supported := range constants.WebhookEventTypes {
+		if eventType == supported {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,367 @@
+package v1_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	V1Usecases "github.com/snykk/transaction-api/internal/business/usecases/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+	PostgresRepo "github.com/snykk/transaction-api/internal/datasources/repositories/postgres/v1"
+	"github.com/snykk/transaction-api/internal/mocks"
+	"github.com/snykk/transaction-api/pkg/webhook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	webhookRepoMock     *mocks.WebhookRepository
+	webhookUsecase      V1Domains.WebhookUsecase
+	webhookFromDB       V1Domains.WebhookEndpointDomain
+	webhookReceiver     *httptest.Server
+	webhookReceived     []*http.Request
+	webhookReceivedBody [][]byte
+	webhookReceiverCode int
+)
+
+const webhookUserId = "aaaa-bbbb-cccc"
+
+func setupWebhook(t *testing.T) {
+	// receiver lokal yang mencatat setiap request, status response bisa diatur per subtest
+	webhookReceived, webhookReceivedBody, webhookReceiverCode = nil, nil, http.StatusOK
+	webhookReceiver = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		webhookReceived = append(webhookReceived, r)
+		webhookReceivedBody = append(webhookReceivedBody, body)
+		w.WriteHeader(webhookReceiverCode)
+	}))
+	t.Cleanup(webhookReceiver.Close)
+
+	webhookRepoMock = mocks.NewWebhookRepository(t)
+	// receiver berjalan di loopback sehingga jaringan private diizinkan
+	webhookUsecase = V1Usecases.NewWebhookUsecase(webhookRepoMock, webhook.NewSender(time.Second, true), 3, 30*time.Second, time.Hour, 10*time.Minute)
+
+	webhookFromDB = V1Domains.WebhookEndpointDomain{
+		Id:         "wwww-hhhh-0001",
+		UserId:     webhookUserId,
+		URL:        webhookReceiver.URL,
+		EventTypes: []string{constants.EventTransactionDepositCompleted},
+		Secret:     "whsec_local_receiver_secret",
+		IsActive:   true,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+}
+
+// echoRecordedAttempt mengembalikan pengiriman apa adanya seperti yang disimpan repository
+func echoRecordedAttempt(ctx context.Context, deliveryDom V1Domains.WebhookDeliveryDomain, attemptDom V1Domains.WebhookAttemptDomain) V1Domains.WebhookDeliveryDomain {
+	return deliveryDom
+}
+
+func TestStoreWebhook(t *testing.T) {
+	setupWebhook(t)
+
+	t.Run("When Success Store Webhook With Generated Secret", func(t *testing.T) {
+		webhookDom := V1Domains.WebhookEndpointDomain{UserId: webhookUserId, URL: "https://partner.example.com/hooks", EventTypes: []string{constants.EventTransactionPurchaseCompleted}, IsActive: true}
+
+		webhookRepoMock.Mock.On("Store", mock.Anything, mock.MatchedBy(func(w V1Domains.WebhookEndpointDomain) bool {
+			return strings.HasPrefix(w.Secret, "whsec_") && len(w.Secret) > constants.WebhookSecretMinLength
+		})).Return(webhookFromDB, nil).Once()
+
+		result, statusCode, err := webhookUsecase.Store(context.Background(), &webhookDom)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, statusCode)
+		assert.Equal(t, webhookFromDB, result)
+	})
+
+	t.Run("When Success Store Webhook With Own Secret", func(t *testing.T) {
+		webhookDom := V1Domains.WebhookEndpointDomain{UserId: webhookUserId, URL: "http://localhost:9000/hooks", EventTypes: []string{constants.EventTransactionDepositCompleted}, Secret: "my-own-secret-1234", IsActive: true}
+
+		webhookRepoMock.Mock.On("Store", mock.Anything, webhookDom).Return(webhookFromDB, nil).Once()
+
+		_, statusCode, err := webhookUsecase.Store(context.Background(), &webhookDom)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, statusCode)
+	})
+
+	t.Run("When Failure URL Is Not Http", func(t *testing.T) {
+		webhookDom := V1Domains.WebhookEndpointDomain{UserId: webhookUserId, URL: "ftp://partner.example.com/hooks", EventTypes: []string{constants.EventTransactionDepositCompleted}}
+
+		_, statusCode, err := webhookUsecase.Store(context.Background(), &webhookDom)
+
+		assert.Equal(t, V1Usecases.ErrWebhookURLInvalid, err)
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+	})
+
+	t.Run("When Failure URL Points To Internal Address", func(t *testing.T) {
+		strictUsecase := V1Usecases.NewWebhookUsecase(webhookRepoMock, webhook.NewSender(time.Second, false), 3, 30*time.Second, time.Hour, 10*time.Minute)
+		webhookDom := V1Domains.WebhookEndpointDomain{UserId: webhookUserId, URL: "http://169.254.169.254/latest/meta-data", EventTypes: []string{constants.EventTransactionDepositCompleted}}
+
+		_, statusCode, err := strictUsecase.Store(context.Background(), &webhookDom)
+
+		assert.Equal(t, V1Usecases.ErrWebhookURLNotAllowed, err)
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+	})
+
+	t.Run("When Failure Event Type Is Not Supported", func(t *testing.T) {
+		webhookDom := V1Domains.WebhookEndpointDomain{UserId: webhookUserId, URL: "https://partner.example.com/hooks", EventTypes: []string{constants.EventWebhookTest}}
+
+		_, statusCode, err := webhookUsecase.Store(context.Background(), &webhookDom)
+
+		assert.Equal(t, V1Usecases.ErrWebhookEventTypeInvalid, err)
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+	})
+
+	t.Run("When Failure Secret Is Too Short", func(t *testing.T) {
+		webhookDom := V1Domains.WebhookEndpointDomain{UserId: webhookUserId, URL: "https://partner.example.com/hooks", EventTypes: []string{constants.EventTransactionDepositCompleted}, Secret: "short"}
+
+		_, statusCode, err := webhookUsecase.Store(context.Background(), &webhookDom)
+
+		assert.Equal(t, V1Usecases.ErrWebhookSecretTooShort, err)
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+	})
+}
+
+func TestUpdateWebhook(t *testing.T) {
+	setupWebhook(t)
+
+	t.Run("When Success Update Webhook", func(t *testing.T) {
+		webhookDom := V1Domains.WebhookEndpointDomain{URL: "https://partner.example.com/v2/hooks", EventTypes: constants.WebhookEventTypes, IsActive: false}
+
+		webhookRepoMock.Mock.On("Update", mock.Anything, mock.MatchedBy(func(w V1Domains.WebhookEndpointDomain) bool {
+			return w.Id == webhookFromDB.Id && w.UserId == webhookUserId && w.Secret == ""
+		})).Return(webhookFromDB, nil).Once()
+
+		_, statusCode, err := webhookUsecase.Update(context.Background(), webhookUserId, webhookFromDB.Id, &webhookDom)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+	})
+
+	t.Run("When Failure Webhook Not Found", func(t *testing.T) {
+		webhookDom := V1Domains.WebhookEndpointDomain{URL: "https://partner.example.com/hooks", EventTypes: []string{constants.EventTransactionDepositCompleted}}
+
+		webhookRepoMock.Mock.On("Update", mock.Anything, mock.Anything).Return(V1Domains.WebhookEndpointDomain{}, PostgresRepo.ErrWebhookNotFound).Once()
+
+		_, statusCode, err := webhookUsecase.Update(context.Background(), webhookUserId, "wwww-hhhh-9999", &webhookDom)
+
+		assert.Equal(t, PostgresRepo.ErrWebhookNotFound, err)
+		assert.Equal(t, http.StatusNotFound, statusCode)
+	})
+}
+
+func TestTestWebhook(t *testing.T) {
+	setupWebhook(t)
+
+	t.Run("When Receiver Accepts Signed Test Event", func(t *testing.T) {
+		webhookRepoMock.Mock.On("GetById", mock.Anything, webhookUserId, webhookFromDB.Id).Return(webhookFromDB, nil).Once()
+		webhookRepoMock.Mock.On("StoreDelivery", mock.Anything, mock.MatchedBy(func(d V1Domains.WebhookDeliveryDomain) bool {
+			// pengiriman uji ditahan dari dispatcher sampai hasilnya tercatat
+			return d.EventType == constants.EventWebhookTest && d.EventId == nil && time.Until(d.NextAttemptAt) > 9*time.Minute
+		})).Return(func(ctx context.Context, d V1Domains.WebhookDeliveryDomain) V1Domains.WebhookDeliveryDomain {
+			d.Id = "dddd-eeee-0001"
+			d.CreatedAt = time.Now()
+			return d
+		}, nil).Once()
+		webhookRepoMock.Mock.On("RecordAttempt", mock.Anything, mock.Anything, mock.MatchedBy(func(a V1Domains.WebhookAttemptDomain) bool {
+			return a.Attempt == 1 && *a.StatusCode == http.StatusOK && a.Error == nil
+		})).Return(echoRecordedAttempt, nil).Once()
+
+		result, statusCode, err := webhookUsecase.Test(context.Background(), webhookUserId, webhookFromDB.Id)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, constants.WebhookDeliveryStatusDelivered, result.Status)
+		assert.NotNil(t, result.DeliveredAt)
+
+		// receiver menerima body bertanda tangan yang bisa diverifikasi dengan secret endpoint
+		assert.Len(t, webhookReceived, 1)
+		received := webhookReceived[0]
+		assert.Equal(t, "dddd-eeee-0001", received.Header.Get(webhook.HeaderId))
+		assert.Equal(t, constants.EventWebhookTest, received.Header.Get(webhook.HeaderEvent))
+		assert.NoError(t, webhook.Verify(webhookFromDB.Secret, received.Header.Get(webhook.HeaderSignature), received.Header.Get(webhook.HeaderTimestamp), webhookReceivedBody[0], time.Minute, time.Now()))
+
+		var envelope map[string]interface{}
+		assert.NoError(t, json.Unmarshal(webhookReceivedBody[0], &envelope))
+		assert.Equal(t, "dddd-eeee-0001", envelope["id"])
+		assert.Equal(t, webhookFromDB.Id, envelope["data"].(map[string]interface{})["webhook_id"])
+	})
+
+	t.Run("When Receiver Rejects Test Event Is Not Retried", func(t *testing.T) {
+		webhookReceiverCode = http.StatusInternalServerError
+		webhookRepoMock.Mock.On("GetById", mock.Anything, webhookUserId, webhookFromDB.Id).Return(webhookFromDB, nil).Once()
+		webhookRepoMock.Mock.On("StoreDelivery", mock.Anything, mock.Anything).Return(func(ctx context.Context, d V1Domains.WebhookDeliveryDomain) V1Domains.WebhookDeliveryDomain {
+			d.Id = "dddd-eeee-0002"
+			return d
+		}, nil).Once()
+		webhookRepoMock.Mock.On("RecordAttempt", mock.Anything, mock.Anything, mock.MatchedBy(func(a V1Domains.WebhookAttemptDomain) bool {
+			return *a.StatusCode == http.StatusInternalServerError && a.Error != nil
+		})).Return(echoRecordedAttempt, nil).Once()
+
+		result, statusCode, err := webhookUsecase.Test(context.Background(), webhookUserId, webhookFromDB.Id)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, constants.WebhookDeliveryStatusDead, result.Status)
+		assert.Equal(t, http.StatusInternalServerError, *result.LastStatusCode)
+	})
+
+	t.Run("When Failure Webhook Not Found", func(t *testing.T) {
+		webhookRepoMock.Mock.On("GetById", mock.Anything, webhookUserId, "wwww-hhhh-9999").Return(V1Domains.WebhookEndpointDomain{}, PostgresRepo.ErrWebhookNotFound).Once()
+
+		_, statusCode, err := webhookUsecase.Test(context.Background(), webhookUserId, "wwww-hhhh-9999")
+
+		assert.Equal(t, PostgresRepo.ErrWebhookNotFound, err)
+		assert.Equal(t, http.StatusNotFound, statusCode)
+	})
+}
+
+func TestRedeliverWebhook(t *testing.T) {
+	setupWebhook(t)
+
+	eventId := "eeee-vvvv-0001"
+	deadDelivery := V1Domains.WebhookDeliveryDomain{
+		Id:        "dddd-eeee-0003",
+		WebhookId: webhookFromDB.Id,
+		EventId:   &eventId,
+		EventType: constants.EventTransactionDepositCompleted,
+		Payload:   []byte(`{"transaction_id":"tttt-rrrr-0001"}`),
+		Status:    constants.WebhookDeliveryStatusDead,
+		Attempts:  3,
+		Webhook:   webhookFromDB,
+	}
+
+	t.Run("When Success Redeliver Dead Delivery", func(t *testing.T) {
+		webhookRepoMock.Mock.On("GetDeliveryById", mock.Anything, webhookUserId, webhookFromDB.Id, deadDelivery.Id).Return(deadDelivery, nil).Once()
+		webhookRepoMock.Mock.On("RecordAttempt", mock.Anything, mock.Anything, mock.MatchedBy(func(a V1Domains.WebhookAttemptDomain) bool {
+			return a.Attempt == 4
+		})).Return(echoRecordedAttempt, nil).Once()
+
+		result, statusCode, err := webhookUsecase.Redeliver(context.Background(), webhookUserId, webhookFromDB.Id, deadDelivery.Id)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, constants.WebhookDeliveryStatusDelivered, result.Status)
+		assert.Nil(t, result.LastError)
+		assert.Equal(t, deadDelivery.Id, webhookReceived[0].Header.Get(webhook.HeaderId))
+	})
+
+	t.Run("When Redelivery Fails Without Remaining Attempts", func(t *testing.T) {
+		webhookReceiverCode = http.StatusServiceUnavailable
+		webhookRepoMock.Mock.On("GetDeliveryById", mock.Anything, webhookUserId, webhookFromDB.Id, deadDelivery.Id).Return(deadDelivery, nil).Once()
+		webhookRepoMock.Mock.On("RecordAttempt", mock.Anything, mock.Anything, mock.Anything).Return(echoRecordedAttempt, nil).Once()
+
+		result, statusCode, err := webhookUsecase.Redeliver(context.Background(), webhookUserId, webhookFromDB.Id, deadDelivery.Id)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, constants.WebhookDeliveryStatusDead, result.Status)
+		assert.Equal(t, 4, result.Attempts)
+	})
+
+	t.Run("When Failure Delivery Not Found", func(t *testing.T) {
+		webhookRepoMock.Mock.On("GetDeliveryById", mock.Anything, webhookUserId, webhookFromDB.Id, "dddd-eeee-9999").Return(V1Domains.WebhookDeliveryDomain{}, PostgresRepo.ErrWebhookDeliveryNotFound).Once()
+
+		_, statusCode, err := webhookUsecase.Redeliver(context.Background(), webhookUserId, webhookFromDB.Id, "dddd-eeee-9999")
+
+		assert.Equal(t, PostgresRepo.ErrWebhookDeliveryNotFound, err)
+		assert.Equal(t, http.StatusNotFound, statusCode)
+	})
+}
+
+func TestEnqueueWebhook(t *testing.T) {
+	setupWebhook(t)
+
+	t.Run("When Success Enqueue Event For Event Owner", func(t *testing.T) {
+		event := V1Domains.EventDomain{Id: "eeee-vvvv-0001", EventType: constants.EventTransactionDepositCompleted, Payload: []byte(`{"transaction_id":"tttt-rrrr-0001","user_id":"aaaa-bbbb-cccc"}`)}
+		webhookRepoMock.Mock.On("Enqueue", mock.Anything, event, webhookUserId, mock.AnythingOfType("time.Time")).Return(2, nil).Once()
+
+		queued, err := webhookUsecase.Enqueue(context.Background(), event)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 2, queued)
+	})
+
+	t.Run("When Event Has No Owner", func(t *testing.T) {
+		event := V1Domains.EventDomain{Id: "eeee-vvvv-0002", EventType: "product.updated", Payload: []byte(`{"product_id":1}`)}
+
+		queued, err := webhookUsecase.Enqueue(context.Background(), event)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 0, queued)
+	})
+}
+
+func TestDispatchDueWebhooks(t *testing.T) {
+	setupWebhook(t)
+
+	eventId := "eeee-vvvv-0001"
+	newDelivery := func(id string, attempts int) V1Domains.WebhookDeliveryDomain {
+		return V1Domains.WebhookDeliveryDomain{
+			Id:        id,
+			WebhookId: webhookFromDB.Id,
+			EventId:   &eventId,
+			EventType: constants.EventTransactionDepositCompleted,
+			Payload:   []byte(`{"transaction_id":"tttt-rrrr-0001"}`),
+			Status:    constants.WebhookDeliveryStatusPending,
+			Attempts:  attempts,
+			Webhook:   webhookFromDB,
+		}
+	}
+
+	t.Run("When Success Deliver Due Deliveries", func(t *testing.T) {
+		now := time.Now()
+		webhookRepoMock.Mock.On("ClaimDue", mock.Anything, now, 10*time.Minute, constants.WebhookDispatchBatchSize).Return([]V1Domains.WebhookDeliveryDomain{newDelivery("dddd-0001", 0), newDelivery("dddd-0002", 1)}, nil).Once()
+		webhookRepoMock.Mock.On("RecordAttempt", mock.Anything, mock.MatchedBy(func(d V1Domains.WebhookDeliveryDomain) bool {
+			return d.Status == constants.WebhookDeliveryStatusDelivered
+		}), mock.Anything).Return(echoRecordedAttempt, nil).Twice()
+
+		delivered, failed, err := webhookUsecase.DispatchDue(context.Background(), now)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 2, delivered)
+		assert.Equal(t, 0, failed)
+		assert.Len(t, webhookReceived, 2)
+	})
+
+	t.Run("When Receiver Fails Delivery Is Retried With Backoff Then Dead", func(t *testing.T) {
+		webhookReceiverCode = http.StatusBadGateway
+		now := time.Now()
+		webhookRepoMock.Mock.On("ClaimDue", mock.Anything, now, 10*time.Minute, constants.WebhookDispatchBatchSize).Return([]V1Domains.WebhookDeliveryDomain{newDelivery("dddd-0003", 1), newDelivery("dddd-0004", 2)}, nil).Once()
+		webhookRepoMock.Mock.On("RecordAttempt", mock.Anything, mock.MatchedBy(func(d V1Domains.WebhookDeliveryDomain) bool {
+			// percobaan kedua gagal menunggu 30s * 2
+			delay := time.Until(d.NextAttemptAt)
+			return d.Id == "dddd-0003" && d.Status == constants.WebhookDeliveryStatusPending && delay > 59*time.Second && delay <= time.Minute
+		}), mock.Anything).Return(echoRecordedAttempt, nil).Once()
+		webhookRepoMock.Mock.On("RecordAttempt", mock.Anything, mock.MatchedBy(func(d V1Domains.WebhookDeliveryDomain) bool {
+			// percobaan ketiga adalah percobaan terakhir
+			return d.Id == "dddd-0004" && d.Status == constants.WebhookDeliveryStatusDead && d.Attempts == 3
+		}), mock.Anything).Return(echoRecordedAttempt, nil).Once()
+
+		delivered, failed, err := webhookUsecase.DispatchDue(context.Background(), now)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 0, delivered)
+		assert.Equal(t, 2, failed)
+	})
+
+	t.Run("When Failure Claim Due Deliveries", func(t *testing.T) {
+		now := time.Now()
+		webhookRepoMock.Mock.On("ClaimDue", mock.Anything, now, 10*time.Minute, constants.WebhookDispatchBatchSize).Return(nil, errors.New("connection refused")).Once()
+
+		delivered, failed, err := webhookUsecase.DispatchDue(context.Background(), now)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, 0, delivered)
+		assert.Equal(t, 0, failed)
+	})
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"github.com/snykk/transaction-api/internal/constants"
+	"github.com/snykk/transaction-api/pkg/money"
 	"github.com/spf13/viper"
 )
 
@@ -26,6 +27,53 @@ type Config struct {
 	REDISHost     string `mapstructure:"REDIS_HOST"`
 	REDISPassword string `mapstructure:"REDIS_PASS"`
 	REDISExpired  int    `mapstructure:"REDIS_EXPIRED"`
+
+	IdempotencyTTL int `mapstructure:"IDEMPOTENCY_TTL"` // dalam jam
+
+	Currency string `mapstructure:"CURRENCY"` // kode ISO 4217 untuk statement, misal IDR
+
+	StatementJobInterval  int  `mapstructure:"STATEMENT_JOB_INTERVAL"`  // dalam menit
+	StatementEmailEnabled bool `mapstructure:"STATEMENT_EMAIL_ENABLED"` // kirim ringkasan statement bulanan lewat email
+
+	ReconciliationJobInterval int `mapstructure:"RECONCILIATION_JOB_INTERVAL"` // dalam menit
+
+	PointsExpiryJobInterval int `mapstructure:"POINTS_EXPIRY_JOB_INTERVAL"` // dalam menit
+
+	SchedulerInterval     int `mapstructure:"SCHEDULER_INTERVAL"`      // dalam detik
+	ScheduleMaxRetries    int `mapstructure:"SCHEDULE_MAX_RETRIES"`    // percobaan ulang per jadwal sebelum dianggap gagal
+	ScheduleRetryInterval int `mapstructure:"SCHEDULE_RETRY_INTERVAL"` // dalam menit, berlipat dua setiap percobaan ulang
+
+	HoldDefaultTTL    int `mapstructure:"HOLD_DEFAULT_TTL"`    // dalam menit, masa berlaku hold jika expires_in tidak dikirim
+	HoldMaxTTL        int `mapstructure:"HOLD_MAX_TTL"`        // dalam menit
+	HoldSweepInterval int `mapstructure:"HOLD_SWEEP_INTERVAL"` // dalam detik
+
+	OutboxPublisher     string `mapstructure:"OUTBOX_PUBLISHER"`       // log, inprocess, atau redis
+	OutboxRedisStream   string `mapstructure:"OUTBOX_REDIS_STREAM"`    // nama stream jika publisher redis
+	OutboxRelayInterval int    `mapstructure:"OUTBOX_RELAY_INTERVAL"`  // dalam detik
+	OutboxLease         int    `mapstructure:"OUTBOX_LEASE"`           // dalam detik, event dikirim ulang jika relay mati sebelum menandai sent
+	OutboxRetryInterval int    `mapstructure:"OUTBOX_RETRY_INTERVAL"`  // dalam detik, berlipat dua setiap percobaan ulang
+	OutboxMaxRetryDelay int    `mapstructure:"OUTBOX_MAX_RETRY_DELAY"` // dalam detik
+
+	WebhookDispatchInterval int `mapstructure:"WEBHOOK_DISPATCH_INTERVAL"` // dalam detik
+	WebhookTimeout          int `mapstructure:"WEBHOOK_TIMEOUT"`           // dalam detik, per percobaan kirim
+	WebhookLease            int `mapstructure:"WEBHOOK_LEASE"`             // dalam detik, harus cukup untuk satu batch pengiriman
+	WebhookMaxAttempts      int `mapstructure:"WEBHOOK_MAX_ATTEMPTS"`      // percobaan kirim sebelum pengiriman menjadi dead
+	WebhookRetryInterval    int `mapstructure:"WEBHOOK_RETRY_INTERVAL"`    // dalam detik, berlipat dua setiap percobaan ulang
+	WebhookMaxRetryDelay    int `mapstructure:"WEBHOOK_MAX_RETRY_DELAY"`   // dalam detik
+
+	WebhookAllowPrivateNetwork bool `mapstructure:"WEBHOOK_ALLOW_PRIVATE_NETWORK"` // hanya untuk pengembangan lokal, izinkan URL webhook ke alamat internal
+
+	WalletStreamChannel       string `mapstructure:"WALLET_STREAM_CHANNEL"`        // channel pub/sub redis, juga prefix riwayat event per user
+	WalletStreamHeartbeat     int    `mapstructure:"WALLET_STREAM_HEARTBEAT"`      // dalam detik
+	WalletStreamHistoryLength int    `mapstructure:"WALLET_STREAM_HISTORY_LENGTH"` // event per user yang bisa diputar ulang lewat Last-Event-ID
+	WalletStreamRetention     int    `mapstructure:"WALLET_STREAM_RETENTION"`      // dalam menit, riwayat user tanpa transaksi baru dibuang
+
+	// batas transaksi default untuk semua user, bisa di-override per user oleh admin. 0 berarti tanpa batas
+	LimitMaxSingleWithdrawal string `mapstructure:"LIMIT_MAX_SINGLE_WITHDRAWAL"`
+	LimitDailyWithdrawal     string `mapstructure:"LIMIT_DAILY_WITHDRAWAL"`
+	LimitMonthlyWithdrawal   string `mapstructure:"LIMIT_MONTHLY_WITHDRAWAL"`
+	LimitDailyPurchase       string `mapstructure:"LIMIT_DAILY_PURCHASE"`
+	LimitHourlyTransactions  int    `mapstructure:"LIMIT_HOURLY_TRANSACTIONS"`
 }
 
 func InitializeAppConfig() error {
@@ -36,6 +84,42 @@ func InitializeAppConfig() error {
 	viper.AddConfigPath("/")
 	viper.AllowEmptyEnv(true)
 	viper.AutomaticEnv()
+
+	// optional variables
+	viper.SetDefault("IDEMPOTENCY_TTL", 24)
+	viper.SetDefault("CURRENCY", "IDR")
+	viper.SetDefault("STATEMENT_JOB_INTERVAL", 60)
+	viper.SetDefault("STATEMENT_EMAIL_ENABLED", false)
+	viper.SetDefault("RECONCILIATION_JOB_INTERVAL", 1440)
+	viper.SetDefault("POINTS_EXPIRY_JOB_INTERVAL", 60)
+	viper.SetDefault("SCHEDULER_INTERVAL", 30)
+	viper.SetDefault("SCHEDULE_MAX_RETRIES", 3)
+	viper.SetDefault("SCHEDULE_RETRY_INTERVAL", 15)
+	viper.SetDefault("HOLD_DEFAULT_TTL", 15)
+	viper.SetDefault("HOLD_MAX_TTL", 10080)
+	viper.SetDefault("HOLD_SWEEP_INTERVAL", 60)
+	viper.SetDefault("OUTBOX_PUBLISHER", constants.EventPublisherLog)
+	viper.SetDefault("OUTBOX_REDIS_STREAM", "transaction-api:events")
+	viper.SetDefault("OUTBOX_RELAY_INTERVAL", 5)
+	viper.SetDefault("OUTBOX_LEASE", 60)
+	viper.SetDefault("OUTBOX_RETRY_INTERVAL", 10)
+	viper.SetDefault("OUTBOX_MAX_RETRY_DELAY", 3600)
+	viper.SetDefault("WEBHOOK_DISPATCH_INTERVAL", 5)
+	viper.SetDefault("WEBHOOK_TIMEOUT", 10)
+	viper.SetDefault("WEBHOOK_LEASE", 600)
+	viper.SetDefault("WEBHOOK_MAX_ATTEMPTS", 8)
+	viper.SetDefault("WEBHOOK_RETRY_INTERVAL", 30)
+	viper.SetDefault("WEBHOOK_MAX_RETRY_DELAY", 21600)
+	viper.SetDefault("WALLET_STREAM_CHANNEL", "transaction-api:wallet-stream")
+	viper.SetDefault("WALLET_STREAM_HEARTBEAT", 15)
+	viper.SetDefault("WALLET_STREAM_HISTORY_LENGTH", 1000)
+	viper.SetDefault("WALLET_STREAM_RETENTION", 1440)
+	viper.SetDefault("LIMIT_MAX_SINGLE_WITHDRAWAL", "0")
+	viper.SetDefault("LIMIT_DAILY_WITHDRAWAL", "0")
+	viper.SetDefault("LIMIT_MONTHLY_WITHDRAWAL", "0")
+	viper.SetDefault("LIMIT_DAILY_PURCHASE", "0")
+	viper.SetDefault("LIMIT_HOURLY_TRANSACTIONS", 0)
+
 	err := viper.ReadInConfig()
 	if err != nil {
 		return constants.ErrLoadConfig
@@ -51,6 +135,50 @@ func InitializeAppConfig() error {
 		return constants.ErrEmptyVar
 	}
 
+	// nominal limit harus berupa angka desimal yang valid
+	for _, limit := range []string{AppConfig.LimitMaxSingleWithdrawal, AppConfig.LimitDailyWithdrawal, AppConfig.LimitMonthlyWithdrawal, AppConfig.LimitDailyPurchase} {
+		if amount, err := money.Parse(limit); err != nil || amount.IsNegative() {
+			return constants.ErrParseConfig
+		}
+	}
+	if AppConfig.LimitHourlyTransactions < 0 {
+		return constants.ErrParseConfig
+	}
+
+	if AppConfig.ReconciliationJobInterval <= 0 {
+		return constants.ErrParseConfig
+	}
+	if AppConfig.PointsExpiryJobInterval <= 0 {
+		return constants.ErrParseConfig
+	}
+
+	// masa berlaku default hold tidak boleh melebihi batas maksimumnya
+	if AppConfig.HoldDefaultTTL <= 0 || AppConfig.HoldMaxTTL < AppConfig.HoldDefaultTTL || AppConfig.HoldSweepInterval <= 0 {
+		return constants.ErrParseConfig
+	}
+
+	switch AppConfig.OutboxPublisher {
+	case constants.EventPublisherLog, constants.EventPublisherInProcess:
+	case constants.EventPublisherRedis:
+		if AppConfig.OutboxRedisStream == "" {
+			return constants.ErrParseConfig
+		}
+	default:
+		return constants.ErrParseConfig
+	}
+	if AppConfig.OutboxRelayInterval <= 0 || AppConfig.OutboxLease <= 0 || AppConfig.OutboxRetryInterval <= 0 || AppConfig.OutboxMaxRetryDelay < AppConfig.OutboxRetryInterval {
+		return constants.ErrParseConfig
+	}
+
+	// lease lebih pendek dari timeout membuat pengiriman yang sedang berjalan diambil dispatcher lain
+	if AppConfig.WebhookDispatchInterval <= 0 || AppConfig.WebhookTimeout <= 0 || AppConfig.WebhookLease <= AppConfig.WebhookTimeout || AppConfig.WebhookMaxAttempts <= 0 || AppConfig.WebhookRetryInterval <= 0 || AppConfig.WebhookMaxRetryDelay < AppConfig.WebhookRetryInterval {
+		return constants.ErrParseConfig
+	}
+
+	if AppConfig.WalletStreamChannel == "" || AppConfig.WalletStreamHeartbeat <= 0 || AppConfig.WalletStreamHistoryLength <= 0 || AppConfig.WalletStreamRetention <= 0 {
+		return constants.ErrParseConfig
+	}
+
 	switch AppConfig.Environment {
 	case constants.EnvironmentDevelopment:
 		if AppConfig.DBPostgreDsn == "" {
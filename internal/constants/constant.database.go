@@ -0,0 +1,13 @@
+package constants
+
+import "time"
+
+const (
+	// percobaan ulang transaksi serializable yang gagal karena konflik (SQLSTATE 40001/40P01)
+	TxMaxRetries     = 5
+	TxRetryBaseDelay = 10 * time.Millisecond // jeda percobaan ulang pertama, berlipat dua setiap percobaan
+	TxRetryMaxDelay  = 500 * time.Millisecond
+
+	SQLStateSerializationFailure = "40001"
+	SQLStateDeadlockDetected     = "40P01"
+)
@@ -0,0 +1,16 @@
+package constants
+
+const (
+	FeeTypeFlat       = "flat"
+	FeeTypePercentage = "percentage"
+	FeeTypeTiered     = "tiered"
+
+	// FeeBasisPointsPerUnit adalah jumlah basis poin untuk 100%, rate_bps 150 berarti 1.5%
+	FeeBasisPointsPerUnit = 10000
+)
+
+// FeeTransactionTypes adalah tipe transaksi yang dapat dikenai fee
+var FeeTransactionTypes = []string{
+	TransactionTypeWithdraw,
+	TransactionTypePurchase,
+}
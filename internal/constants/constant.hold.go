@@ -0,0 +1,11 @@
+package constants
+
+const (
+	HoldStatusActive   = "active"
+	HoldStatusCaptured = "captured"
+	HoldStatusVoided   = "voided"
+	HoldStatusExpired  = "expired"
+
+	// jumlah maksimum hold kedaluwarsa yang dilepas sweeper dalam satu transaksi database
+	HoldExpiryBatchSize = 100
+)
@@ -0,0 +1,15 @@
+package constants
+
+import "time"
+
+const (
+	HeaderIdempotencyKey        = "Idempotency-Key"
+	HeaderIdempotentReplayed    = "Idempotent-Replayed"
+	IdempotencyKeyMaxLength     = 255
+	IdempotencyStatusProcessing = "processing"
+	IdempotencyStatusCompleted  = "completed"
+
+	// request yang masih berstatus processing lebih lama dari ini dianggap terhenti
+	// (misal server mati di tengah jalan) sehingga key boleh diklaim ulang
+	IdempotencyProcessingTimeout = time.Minute
+)
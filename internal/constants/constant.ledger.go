@@ -0,0 +1,16 @@
+package constants
+
+const (
+	LedgerAccountWallet        = "wallet"
+	LedgerAccountSystemCash    = "system_cash"
+	LedgerAccountSystemRevenue = "system_revenue"
+	// menampung dana withdraw yang masih pending
+	LedgerAccountSystemClearing = "system_clearing"
+	// menampung pendapatan fee transaksi
+	LedgerAccountSystemFee = "system_fee"
+	// membiayai cashback dan poin loyalitas yang ditukar menjadi saldo atau pembayaran
+	LedgerAccountSystemRewards = "system_rewards"
+
+	LedgerDirectionDebit  = "debit"
+	LedgerDirectionCredit = "credit"
+)
@@ -0,0 +1,18 @@
+package constants
+
+const (
+	// nama limit, dipakai pada pesan error dan response
+	LimitMaxSingleWithdrawal = "max_single_withdrawal"
+	LimitDailyWithdrawal     = "daily_withdrawal"
+	LimitMonthlyWithdrawal   = "monthly_withdrawal"
+	LimitDailyPurchase       = "daily_purchase"
+	LimitHourlyTransactions  = "hourly_transactions"
+)
+
+// LimitHourlyTransactionTypes adalah tipe transaksi yang dibuat oleh user sendiri dan dihitung pada limit per jam
+var LimitHourlyTransactionTypes = []string{
+	TransactionTypeDeposit,
+	TransactionTypeWithdraw,
+	TransactionTypePurchase,
+	TransactionTypeTransferOut,
+}
@@ -9,6 +9,11 @@ const (
 	LoggerCategoryMigration = "migration"
 	LoggerCategoryCORS      = "cors"
 	LoggerCategorySeeder    = "seeder"
+	LoggerCategoryCron      = "cron"
+	LoggerCategoryScheduler = "scheduler"
+	LoggerCategoryReconcile = "reconciliation"
+	LoggerCategoryEvent     = "event"
+	LoggerCategoryStream    = "stream"
 
 	LoggerFile = "file"
 )
@@ -0,0 +1,6 @@
+package constants
+
+const (
+	// jumlah maksimum item berbeda dalam satu order
+	OrderMaxItems = 50
+)
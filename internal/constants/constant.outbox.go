@@ -0,0 +1,48 @@
+package constants
+
+const (
+	OutboxStatusPending = "pending"
+	OutboxStatusSent    = "sent"
+
+	OutboxAggregateTransaction = "transaction"
+	OutboxAggregateHold        = "hold"
+	OutboxAggregatePoints      = "points"
+
+	// event perubahan saldo dari repository transaksi
+	EventTransactionDepositCompleted  = "transaction.deposit.completed"
+	EventTransactionWithdrawRequested = "transaction.withdraw.requested"
+	EventTransactionPurchaseCompleted = "transaction.purchase.completed"
+	EventTransactionTransferSent      = "transaction.transfer.sent"
+	EventTransactionTransferReceived  = "transaction.transfer.received"
+	EventTransactionRefundCompleted   = "transaction.refund.completed"
+	EventTransactionStatusUpdated     = "transaction.status.updated"
+	EventTransactionPointsConverted   = "transaction.points.converted"
+
+	// event dana yang ditahan hold, mengubah saldo tersedia tanpa mengubah saldo ledger
+	EventHoldPlaced   = "hold.placed"
+	EventHoldCaptured = "hold.captured"
+	EventHoldVoided   = "hold.voided"
+	EventHoldExpired  = "hold.expired"
+
+	// event saldo poin yang tidak membuat transaksi
+	EventPointsExpired = "points.expired"
+
+	// versi skema payload event, dinaikkan jika field payload berubah tidak kompatibel
+	TransactionEventVersion = 1
+	HoldEventVersion        = 1
+	PointsEventVersion      = 1
+
+	// jumlah maksimum event yang diambil relay dalam satu batch
+	OutboxRelayBatchSize = 100
+
+	// publisher event yang didukung
+	EventPublisherLog       = "log"
+	EventPublisherInProcess = "inprocess"
+	EventPublisherRedis     = "redis"
+
+	// EventSubscribeAll dipakai subscriber in-process untuk menerima semua tipe event
+	EventSubscribeAll = "*"
+
+	// panjang maksimum Redis Stream, entri lama dipangkas secara perkiraan
+	EventRedisStreamMaxLen = 100000
+)
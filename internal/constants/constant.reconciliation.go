@@ -0,0 +1,16 @@
+package constants
+
+const (
+	ReconciliationTriggerCLI  = "cli"
+	ReconciliationTriggerCron = "cron"
+
+	ReconciliationStatusRunning   = "running"
+	ReconciliationStatusCompleted = "completed"
+	ReconciliationStatusFailed    = "failed"
+
+	// jumlah wallet yang direkonsiliasi per batch
+	ReconciliationBatchSize = 500
+
+	// jumlah selisih yang ditampilkan pada laporan, total selisih tetap tercatat di run
+	ReconciliationReportItemLimit = 1000
+)
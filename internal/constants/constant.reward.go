@@ -0,0 +1,19 @@
+package constants
+
+const (
+	RewardTypePoints   = "points"
+	RewardTypeCashback = "cashback"
+
+	PointEntryEarn    = "earn"
+	PointEntryRedeem  = "redeem"
+	PointEntryConvert = "convert"
+	PointEntryExpire  = "expire"
+	PointEntryRestore = "restore" // lot poin yang dikembalikan karena pembelian yang memakainya di-refund
+	PointEntryReverse = "reverse" // poin hasil pembelian yang ditarik kembali karena pembelian di-refund
+
+	// masa berlaku lot poin yang dikembalikan oleh refund, dihitung dari waktu refund
+	RestoredPointsExpiryDays = 90
+
+	// jumlah maksimum lot poin kedaluwarsa yang diproses sweeper dalam satu transaksi database
+	PointExpiryBatchSize = 100
+)
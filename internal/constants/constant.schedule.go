@@ -0,0 +1,23 @@
+package constants
+
+const (
+	ScheduleFrequencyOnce    = "once"
+	ScheduleFrequencyDaily   = "daily"
+	ScheduleFrequencyWeekly  = "weekly"
+	ScheduleFrequencyMonthly = "monthly"
+
+	ScheduleStatusActive    = "active"
+	ScheduleStatusPaused    = "paused"
+	ScheduleStatusCompleted = "completed"
+	ScheduleStatusFailed    = "failed"
+	ScheduleStatusCancelled = "cancelled"
+
+	ScheduleRunStatusSucceeded = "succeeded"
+	ScheduleRunStatusFailed    = "failed"
+
+	// jumlah maksimum schedule yang dieksekusi scheduler dalam satu putaran
+	ScheduleBatchSize = 100
+
+	// jumlah riwayat eksekusi terbaru yang ditampilkan pada detail schedule
+	ScheduleRecentRunsLimit = 20
+)
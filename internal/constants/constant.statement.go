@@ -0,0 +1,22 @@
+package constants
+
+const (
+	StatementFormatCSV  = "csv"
+	StatementFormatOFX  = "ofx"
+	StatementFormatJSON = "json"
+
+	// tanggal pada query string statement, misal 2024-01-31
+	StatementDateLayout = "2006-01-02"
+
+	// periode statement bulanan, misal 2024-01
+	StatementPeriodLayout = "2006-01"
+
+	// jumlah wallet yang diproses job statement per batch
+	StatementJobBatchSize = 100
+
+	// jumlah statement bulanan terbaru yang ditampilkan, setara 10 tahun
+	StatementListLimit = 120
+
+	// BANKID pada OFX, wajib diisi walau aplikasi ini bukan bank
+	StatementOFXBankId = "TRANSACTIONAPI"
+)
@@ -0,0 +1,20 @@
+package constants
+
+import "time"
+
+const (
+	// event yang dikirim ke stream wallet user
+	WalletStreamEventTransaction = "transaction"
+	WalletStreamEventBalance     = "balance"
+
+	// jumlah event yang boleh tertahan per koneksi sebelum koneksi diputus
+	WalletStreamSubscriberBuffer = 64
+
+	// batas waktu satu kali tulis ke koneksi streaming, koneksi yang tidak terbaca dianggap mati
+	WalletStreamWriteTimeout = 10 * time.Second
+
+	// header dan query yang dipakai client untuk melanjutkan stream setelah reconnect.
+	// EventSource dan WebSocket di browser tidak bisa mengatur header sendiri saat koneksi pertama
+	HeaderLastEventId = "Last-Event-ID"
+	QueryLastEventId  = "last_event_id"
+)
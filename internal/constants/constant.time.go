@@ -2,6 +2,9 @@ package constants
 
 import "time"
 
+// DefaultTimezone adalah zona waktu user yang belum memilih zona waktu, setara dengan GMT7
+const DefaultTimezone = "Asia/Jakarta"
+
 var (
 	GMT7 = time.FixedZone("GMT+7", 7*60*60)
 )
@@ -1,6 +1,44 @@
 package constants
 
 const (
-	TransactionTypeWithdraw = "withdraw"
-	TransactionTypeDeposit  = "deposit"
+	TransactionTypeWithdraw    = "withdraw"
+	TransactionTypeDeposit     = "deposit"
+	TransactionTypePurchase    = "purchase"
+	TransactionTypeTransferOut = "transfer_out"
+	TransactionTypeTransferIn  = "transfer_in"
+	TransactionTypeRefund      = "refund"
+	// reward pembelian dan konversi poin yang menambah saldo wallet
+	TransactionTypeCashback         = "cashback"
+	TransactionTypePointsConversion = "points_conversion"
+
+	TransactionStatusPending   = "pending"
+	TransactionStatusCompleted = "completed"
+	TransactionStatusFailed    = "failed"
+	TransactionStatusCancelled = "cancelled"
+
+	TransactionSortAsc  = "asc"
+	TransactionSortDesc = "desc"
+
+	TransactionPageDefaultLimit = 20
+	TransactionPageMaxLimit     = 100
+
+	// batas metadata transaksi, ukuran dihitung dari hasil encode JSON
+	TransactionMetadataMaxBytes = 4096
+	TransactionMetadataMaxKeys  = 50
 )
+
+// WalletCreditTransactionTypes adalah tipe transaksi yang menambah saldo wallet setelah completed
+var WalletCreditTransactionTypes = []string{
+	TransactionTypeDeposit,
+	TransactionTypeTransferIn,
+	TransactionTypeRefund,
+	TransactionTypeCashback,
+	TransactionTypePointsConversion,
+}
+
+// WalletDebitTransactionTypes adalah tipe transaksi yang memotong saldo wallet sejak pending
+var WalletDebitTransactionTypes = []string{
+	TransactionTypeWithdraw,
+	TransactionTypePurchase,
+	TransactionTypeTransferOut,
+}
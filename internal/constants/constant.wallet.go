@@ -0,0 +1,19 @@
+package constants
+
+const (
+	// nama wallet default yang dibuat lewat /wallets/init
+	WalletDefaultName = "main"
+
+	WalletStatusActive         = "active"
+	WalletStatusFrozenOutgoing = "frozen_outgoing" // withdraw, pembelian, transfer keluar, dan hold diblokir
+	WalletStatusFrozenIncoming = "frozen_incoming" // deposit, transfer masuk, dan refund diblokir
+	WalletStatusFrozen         = "frozen"          // dana keluar dan masuk diblokir
+
+	// arah freeze yang dikirim admin
+	WalletFreezeOutgoing = "outgoing"
+	WalletFreezeIncoming = "incoming"
+	WalletFreezeBoth     = "both"
+
+	WalletStatusActionFreeze   = "freeze"
+	WalletStatusActionUnfreeze = "unfreeze"
+)
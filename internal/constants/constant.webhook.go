@@ -0,0 +1,26 @@
+package constants
+
+const (
+	WebhookDeliveryStatusPending   = "pending"
+	WebhookDeliveryStatusDelivered = "delivered"
+	WebhookDeliveryStatusDead      = "dead"
+
+	// event yang dikirim oleh endpoint uji, tidak bisa dilanggan
+	EventWebhookTest = "webhook.test"
+
+	// jumlah maksimum pengiriman yang diambil dispatcher dalam satu batch
+	WebhookDispatchBatchSize = 50
+
+	// jumlah pengiriman terbaru yang ditampilkan di log pengiriman endpoint
+	WebhookDeliveryLogLimit = 100
+
+	// panjang minimum secret yang ditentukan user, secret yang dibuat server selalu lebih panjang
+	WebhookSecretMinLength = 16
+)
+
+// WebhookEventTypes adalah event outbox yang bisa dilanggan endpoint webhook
+var WebhookEventTypes = []string{
+	EventTransactionDepositCompleted,
+	EventTransactionWithdrawRequested,
+	EventTransactionPurchaseCompleted,
+}
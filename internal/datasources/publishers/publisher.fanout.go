@@ -0,0 +1,30 @@
+package publishers
+
+import (
+	"context"
+
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+)
+
+// fanOutPublisher meneruskan event ke beberapa publisher. Jika salah satu gagal, event dikirim ulang
+// ke semua publisher, sehingga setiap publisher tetap harus tahan terhadap event ganda.
+type fanOutPublisher struct {
+	publishers []V1Domains.EventPublisher
+}
+
+func NewFanOutPublisher(publishers ...V1Domains.EventPublisher) V1Domains.EventPublisher {
+	return &fanOutPublisher{
+		publishers: publishers,
+	}
+}
+
+func (p *fanOutPublisher) Publish(ctx context.Context, event V1Domains.EventDomain) error {
+	var firstErr error
+	for _, publisher := range p.publishers {
+		if err := publisher.Publish(ctx, event); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
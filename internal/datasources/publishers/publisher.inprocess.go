@@ -0,0 +1,52 @@
+package publishers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+)
+
+// EventHandler memproses satu event, error membuat relay mengirim ulang event ke semua subscriber-nya
+type EventHandler func(ctx context.Context, event V1Domains.EventDomain) error
+
+// InProcessPublisher meneruskan event ke subscriber di dalam proses yang sama.
+// Karena event bisa dikirim ulang, handler harus idempoten terhadap event.Id.
+type InProcessPublisher struct {
+	mu       sync.RWMutex
+	handlers map[string][]EventHandler
+}
+
+func NewInProcessPublisher() *InProcessPublisher {
+	return &InProcessPublisher{
+		handlers: make(map[string][]EventHandler),
+	}
+}
+
+// Subscribe mendaftarkan handler untuk satu tipe event, atau semua event dengan constants.EventSubscribeAll
+func (p *InProcessPublisher) Subscribe(eventType string, handler EventHandler) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.handlers[eventType] = append(p.handlers[eventType], handler)
+}
+
+func (p *InProcessPublisher) Publish(ctx context.Context, event V1Domains.EventDomain) error {
+	p.mu.RLock()
+	handlers := make([]EventHandler, 0, len(p.handlers[event.EventType])+len(p.handlers[constants.EventSubscribeAll]))
+	handlers = append(handlers, p.handlers[event.EventType]...)
+	handlers = append(handlers, p.handlers[constants.EventSubscribeAll]...)
+	p.mu.RUnlock()
+
+	// semua handler tetap dipanggil walaupun ada yang gagal, error pertama dikembalikan ke relay
+	var firstErr error
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("subscriber failed for event %s: %w", event.Id, err)
+		}
+	}
+
+	return firstErr
+}
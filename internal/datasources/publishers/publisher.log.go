@@ -0,0 +1,23 @@
+package publishers
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+	"github.com/snykk/transaction-api/pkg/logger"
+)
+
+// logPublisher hanya menulis event ke log, dipakai saat belum ada consumer di luar API
+type logPublisher struct{}
+
+func NewLogPublisher() V1Domains.EventPublisher {
+	return &logPublisher{}
+}
+
+func (p *logPublisher) Publish(ctx context.Context, event V1Domains.EventDomain) error {
+	logger.InfoF("event %s %s published for %s %s: %s", logrus.Fields{constants.LoggerCategory: constants.LoggerCategoryEvent},
+		event.EventType, event.Id, event.AggregateType, event.AggregateId, event.Payload)
+	return nil
+}
@@ -0,0 +1,42 @@
+package publishers
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+)
+
+// redisStreamPublisher menambahkan event ke Redis Stream, consumer membaca dengan XREADGROUP
+// dan memakai field event_id untuk membuang event yang terkirim ulang
+type redisStreamPublisher struct {
+	client *redis.Client
+	stream string
+}
+
+func NewRedisStreamPublisher(client *redis.Client, stream string) V1Domains.EventPublisher {
+	return &redisStreamPublisher{
+		client: client,
+		stream: stream,
+	}
+}
+
+func (p *redisStreamPublisher) Publish(ctx context.Context, event V1Domains.EventDomain) error {
+	return p.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: p.stream,
+		MaxLen: constants.EventRedisStreamMaxLen,
+		Approx: true,
+		Values: map[string]interface{}{
+			"event_id":       event.Id,
+			"event_type":     event.EventType,
+			"aggregate_type": event.AggregateType,
+			"aggregate_id":   event.AggregateId,
+			"version":        strconv.Itoa(event.Version),
+			"payload":        string(event.Payload),
+			"created_at":     event.CreatedAt.Format(time.RFC3339Nano),
+		},
+	}).Err()
+}
@@ -0,0 +1,41 @@
+package publishers
+
+import (
+	"context"
+	"encoding/json"
+
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/internal/datasources/streams"
+)
+
+// walletStreamEvent adalah data yang diterima client stream wallet untuk satu event outbox
+type walletStreamEvent struct {
+	EventId   string          `json:"event_id"`
+	EventType string          `json:"event_type"`
+	Payload   json.RawMessage `json:"payload"`
+}
+
+// WalletStreamSubscriber meneruskan event outbox ke stream wallet pemiliknya, sehingga semua
+// perubahan saldo yang sudah commit ikut terkirim. Nama event stream mengikuti aggregate
+// (transaction, hold, points). Event bisa terkirim ulang, client mengenali duplikat dari event_id.
+func WalletStreamSubscriber(walletStream streams.WalletStream) EventHandler {
+	return func(ctx context.Context, event V1Domains.EventDomain) error {
+		var owner struct {
+			UserId string `json:"user_id"`
+		}
+		if err := json.Unmarshal(event.Payload, &owner); err != nil {
+			return err
+		}
+
+		// event tanpa pemilik wallet tidak punya stream tujuan
+		if owner.UserId == "" {
+			return nil
+		}
+
+		return walletStream.Publish(ctx, owner.UserId, event.AggregateType, walletStreamEvent{
+			EventId:   event.Id,
+			EventType: event.EventType,
+			Payload:   event.Payload,
+		})
+	}
+}
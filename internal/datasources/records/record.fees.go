@@ -0,0 +1,177 @@
+package records
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/pkg/money"
+)
+
+type FeeRule struct {
+	Id              int          `db:"fee_rule_id"`
+	Name            string       `db:"name"`
+	TransactionType string       `db:"transaction_type"`
+	ProductId       *int         `db:"product_id"`
+	FeeType         string       `db:"fee_type"`
+	FlatAmount      *money.Money `db:"flat_amount"`
+	RateBps         *int         `db:"rate_bps"`
+	Tiers           FeeTiers     `db:"tiers"`
+	MinFee          *money.Money `db:"min_fee"`
+	MaxFee          *money.Money `db:"max_fee"`
+	IsActive        bool         `db:"is_active"`
+	CreatedAt       time.Time    `db:"created_at"`
+	UpdatedAt       time.Time    `db:"updated_at"`
+}
+
+type FeeTier struct {
+	UpTo       *money.Money `json:"up_to"`
+	FlatAmount money.Money  `json:"flat_amount"`
+	RateBps    int          `json:"rate_bps"`
+}
+
+// FeeTiers disimpan sebagai JSONB, null untuk aturan yang bukan tiered
+type FeeTiers []FeeTier
+
+func (t *FeeTiers) Scan(src interface{}) error {
+	return scanJSON(src, t)
+}
+
+func (t FeeTiers) Value() (driver.Value, error) {
+	if t == nil {
+		return nil, nil
+	}
+	return json.Marshal(t)
+}
+
+type FeeComponent struct {
+	FeeRuleId int         `json:"fee_rule_id"`
+	Name      string      `json:"name"`
+	FeeType   string      `json:"fee_type"`
+	Amount    money.Money `json:"amount"`
+}
+
+// FeeBreakdown adalah snapshot rincian fee pada kolom transactions.fee_breakdown
+type FeeBreakdown []FeeComponent
+
+func (b *FeeBreakdown) Scan(src interface{}) error {
+	return scanJSON(src, b)
+}
+
+func (b FeeBreakdown) Value() (driver.Value, error) {
+	if b == nil {
+		return nil, nil
+	}
+	return json.Marshal(b)
+}
+
+// scanJSON membaca kolom JSON/JSONB ke dest, NULL membuat dest tetap bernilai nol
+func scanJSON(src interface{}, dest interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(v, dest)
+	case string:
+		return json.Unmarshal([]byte(v), dest)
+	default:
+		return fmt.Errorf("cannot scan %T into JSON column", src)
+	}
+}
+
+// Mapper
+func (f *FeeRule) ToV1Domain() V1Domains.FeeRuleDomain {
+	var tiers []V1Domains.FeeTierDomain
+	for _, tier := range f.Tiers {
+		tiers = append(tiers, V1Domains.FeeTierDomain{
+			UpTo:       tier.UpTo,
+			FlatAmount: tier.FlatAmount,
+			RateBps:    tier.RateBps,
+		})
+	}
+
+	return V1Domains.FeeRuleDomain{
+		Id:              f.Id,
+		Name:            f.Name,
+		TransactionType: f.TransactionType,
+		ProductId:       f.ProductId,
+		FeeType:         f.FeeType,
+		FlatAmount:      f.FlatAmount,
+		RateBps:         f.RateBps,
+		Tiers:           tiers,
+		MinFee:          f.MinFee,
+		MaxFee:          f.MaxFee,
+		IsActive:        f.IsActive,
+		CreatedAt:       f.CreatedAt,
+		UpdatedAt:       f.UpdatedAt,
+	}
+}
+
+func FromFeeRuleV1Domain(f *V1Domains.FeeRuleDomain) FeeRule {
+	var tiers FeeTiers
+	for _, tier := range f.Tiers {
+		tiers = append(tiers, FeeTier{
+			UpTo:       tier.UpTo,
+			FlatAmount: tier.FlatAmount,
+			RateBps:    tier.RateBps,
+		})
+	}
+
+	return FeeRule{
+		Id:              f.Id,
+		Name:            f.Name,
+		TransactionType: f.TransactionType,
+		ProductId:       f.ProductId,
+		FeeType:         f.FeeType,
+		FlatAmount:      f.FlatAmount,
+		RateBps:         f.RateBps,
+		Tiers:           tiers,
+		MinFee:          f.MinFee,
+		MaxFee:          f.MaxFee,
+		IsActive:        f.IsActive,
+		CreatedAt:       f.CreatedAt,
+		UpdatedAt:       f.UpdatedAt,
+	}
+}
+
+func ToArrayOfFeeRuleV1Domain(f *[]FeeRule) []V1Domains.FeeRuleDomain {
+	var result []V1Domains.FeeRuleDomain
+
+	for _, val := range *f {
+		result = append(result, val.ToV1Domain())
+	}
+
+	return result
+}
+
+func (b FeeBreakdown) ToV1Domain() []V1Domains.FeeComponentDomain {
+	var result []V1Domains.FeeComponentDomain
+
+	for _, component := range b {
+		result = append(result, V1Domains.FeeComponentDomain{
+			FeeRuleId: component.FeeRuleId,
+			Name:      component.Name,
+			FeeType:   component.FeeType,
+			Amount:    component.Amount,
+		})
+	}
+
+	return result
+}
+
+func FromFeeBreakdownV1Domain(components []V1Domains.FeeComponentDomain) FeeBreakdown {
+	var result FeeBreakdown
+
+	for _, component := range components {
+		result = append(result, FeeComponent{
+			FeeRuleId: component.FeeRuleId,
+			Name:      component.Name,
+			FeeType:   component.FeeType,
+			Amount:    component.Amount,
+		})
+	}
+
+	return result
+}
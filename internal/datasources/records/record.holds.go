@@ -0,0 +1,80 @@
+package records
+
+import (
+	"time"
+
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/pkg/money"
+)
+
+type Hold struct {
+	Id              string       `db:"hold_id"`
+	WalletId        string       `db:"wallet_id"`
+	UserId          string       `db:"user_id"` // dari tabel wallets
+	TransactionType string       `db:"transaction_type"`
+	ProductId       *int         `db:"product_id"`
+	Quantity        *int         `db:"quantity"`
+	UnitPrice       *money.Money `db:"unit_price"`
+	Amount          money.Money  `db:"amount"`
+	Fee             money.Money  `db:"fee"`
+	Status          string       `db:"status"`
+	CapturedAmount  *money.Money `db:"captured_amount"`
+	TransactionId   *string      `db:"transaction_id"`
+	ExpiresAt       time.Time    `db:"expires_at"`
+	ClosedAt        *time.Time   `db:"closed_at"`
+	CreatedAt       time.Time    `db:"created_at"`
+	UpdatedAt       time.Time    `db:"updated_at"`
+}
+
+// Mapper
+func (h *Hold) ToV1Domain() V1Domains.HoldDomain {
+	return V1Domains.HoldDomain{
+		Id:              h.Id,
+		WalletId:        h.WalletId,
+		UserId:          h.UserId,
+		TransactionType: h.TransactionType,
+		ProductId:       h.ProductId,
+		Quantity:        h.Quantity,
+		UnitPrice:       h.UnitPrice,
+		Amount:          h.Amount,
+		Fee:             h.Fee,
+		Status:          h.Status,
+		CapturedAmount:  h.CapturedAmount,
+		TransactionId:   h.TransactionId,
+		ExpiresAt:       h.ExpiresAt,
+		ClosedAt:        h.ClosedAt,
+		CreatedAt:       h.CreatedAt,
+		UpdatedAt:       h.UpdatedAt,
+	}
+}
+
+func FromHoldV1Domain(h *V1Domains.HoldDomain) Hold {
+	return Hold{
+		Id:              h.Id,
+		WalletId:        h.WalletId,
+		UserId:          h.UserId,
+		TransactionType: h.TransactionType,
+		ProductId:       h.ProductId,
+		Quantity:        h.Quantity,
+		UnitPrice:       h.UnitPrice,
+		Amount:          h.Amount,
+		Fee:             h.Fee,
+		Status:          h.Status,
+		CapturedAmount:  h.CapturedAmount,
+		TransactionId:   h.TransactionId,
+		ExpiresAt:       h.ExpiresAt,
+		ClosedAt:        h.ClosedAt,
+		CreatedAt:       h.CreatedAt,
+		UpdatedAt:       h.UpdatedAt,
+	}
+}
+
+func ToArrayOfHoldV1Domain(h *[]Hold) []V1Domains.HoldDomain {
+	var result []V1Domains.HoldDomain
+
+	for _, val := range *h {
+		result = append(result, val.ToV1Domain())
+	}
+
+	return result
+}
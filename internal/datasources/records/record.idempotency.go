@@ -0,0 +1,37 @@
+package records
+
+import (
+	"time"
+
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+)
+
+type IdempotencyKey struct {
+	UserId       string    `db:"user_id"`
+	Key          string    `db:"idempotency_key"`
+	RequestHash  string    `db:"request_hash"`
+	Status       string    `db:"status"`
+	ResponseCode *int      `db:"response_code"`
+	ResponseBody []byte    `db:"response_body"`
+	CreatedAt    time.Time `db:"created_at"`
+	ExpiresAt    time.Time `db:"expires_at"`
+}
+
+// Mapper
+func (i *IdempotencyKey) ToV1Domain() V1Domains.IdempotencyKeyDomain {
+	var responseCode int
+	if i.ResponseCode != nil {
+		responseCode = *i.ResponseCode
+	}
+
+	return V1Domains.IdempotencyKeyDomain{
+		UserId:       i.UserId,
+		Key:          i.Key,
+		RequestHash:  i.RequestHash,
+		Status:       i.Status,
+		ResponseCode: responseCode,
+		ResponseBody: i.ResponseBody,
+		CreatedAt:    i.CreatedAt,
+		ExpiresAt:    i.ExpiresAt,
+	}
+}
@@ -0,0 +1,47 @@
+package records
+
+import (
+	"time"
+
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/pkg/money"
+)
+
+// UserLimit adalah baris user_limits, kolom limit null berarti memakai default global
+type UserLimit struct {
+	UserId              string       `db:"user_id"`
+	MaxSingleWithdrawal *money.Money `db:"max_single_withdrawal"`
+	DailyWithdrawal     *money.Money `db:"daily_withdrawal"`
+	MonthlyWithdrawal   *money.Money `db:"monthly_withdrawal"`
+	DailyPurchase       *money.Money `db:"daily_purchase"`
+	HourlyTransactions  *int         `db:"hourly_transactions"`
+	UpdatedBy           *string      `db:"updated_by"`
+	UpdatedAt           *time.Time   `db:"updated_at"` // nil jika user belum punya override
+}
+
+// Mapper
+func (u *UserLimit) ToV1Domain() V1Domains.UserLimitDomain {
+	return V1Domains.UserLimitDomain{
+		UserId:              u.UserId,
+		MaxSingleWithdrawal: u.MaxSingleWithdrawal,
+		DailyWithdrawal:     u.DailyWithdrawal,
+		MonthlyWithdrawal:   u.MonthlyWithdrawal,
+		DailyPurchase:       u.DailyPurchase,
+		HourlyTransactions:  u.HourlyTransactions,
+		UpdatedBy:           u.UpdatedBy,
+		UpdatedAt:           u.UpdatedAt,
+	}
+}
+
+func FromUserLimitV1Domain(u *V1Domains.UserLimitDomain) UserLimit {
+	return UserLimit{
+		UserId:              u.UserId,
+		MaxSingleWithdrawal: u.MaxSingleWithdrawal,
+		DailyWithdrawal:     u.DailyWithdrawal,
+		MonthlyWithdrawal:   u.MonthlyWithdrawal,
+		DailyPurchase:       u.DailyPurchase,
+		HourlyTransactions:  u.HourlyTransactions,
+		UpdatedBy:           u.UpdatedBy,
+		UpdatedAt:           u.UpdatedAt,
+	}
+}
@@ -0,0 +1,85 @@
+package records
+
+import (
+	"time"
+
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/pkg/money"
+)
+
+// Order berisi kolom orders beserta kolom transaksi purchase yang membayarnya
+type Order struct {
+	Id                string       `db:"order_id"`
+	UserId            string       `db:"user_id"`
+	WalletId          string       `db:"wallet_id"`
+	TransactionId     string       `db:"transaction_id"`
+	TotalAmount       money.Money  `db:"total_amount"`
+	Discount          money.Money  `db:"discount"`
+	VoucherCode       *string      `db:"voucher_code"`
+	PointsRedeemed    money.Money  `db:"points_redeemed"`
+	PointsEarned      money.Money  `db:"points_earned"`
+	Cashback          money.Money  `db:"cashback"`
+	Fee               money.Money  `db:"fee"`
+	FeeBreakdown      FeeBreakdown `db:"fee_breakdown"`
+	Status            string       `db:"status"`
+	Description       *string      `db:"description"`
+	ExternalReference *string      `db:"external_reference"`
+	Metadata          Metadata     `db:"metadata"`
+	CreatedAt         time.Time    `db:"created_at"`
+}
+
+type OrderItem struct {
+	Id          string      `db:"order_item_id"`
+	OrderId     string      `db:"order_id"`
+	ProductId   *int        `db:"product_id"`
+	ProductName string      `db:"product_name"`
+	Quantity    int         `db:"quantity"`
+	UnitPrice   money.Money `db:"unit_price"`
+	Subtotal    money.Money `db:"subtotal"`
+}
+
+// Mapper
+func (o *Order) ToV1Domain(items []OrderItem) V1Domains.OrderDomain {
+	return V1Domains.OrderDomain{
+		Id:                o.Id,
+		UserId:            o.UserId,
+		WalletId:          o.WalletId,
+		TransactionId:     o.TransactionId,
+		Items:             ToArrayOfOrderItemV1Domain(items),
+		TotalAmount:       o.TotalAmount,
+		VoucherCode:       o.VoucherCode,
+		Discount:          o.Discount,
+		PointsRedeemed:    o.PointsRedeemed,
+		PointsEarned:      o.PointsEarned,
+		Cashback:          o.Cashback,
+		Fee:               o.Fee,
+		FeeBreakdown:      o.FeeBreakdown.ToV1Domain(),
+		Status:            o.Status,
+		Description:       o.Description,
+		ExternalReference: o.ExternalReference,
+		Metadata:          o.Metadata,
+		CreatedAt:         o.CreatedAt,
+	}
+}
+
+func (i *OrderItem) ToV1Domain() V1Domains.OrderItemDomain {
+	return V1Domains.OrderItemDomain{
+		Id:          i.Id,
+		OrderId:     i.OrderId,
+		ProductId:   i.ProductId,
+		ProductName: i.ProductName,
+		Quantity:    i.Quantity,
+		UnitPrice:   i.UnitPrice,
+		Subtotal:    i.Subtotal,
+	}
+}
+
+func ToArrayOfOrderItemV1Domain(items []OrderItem) []V1Domains.OrderItemDomain {
+	var result []V1Domains.OrderItemDomain
+
+	for _, val := range items {
+		result = append(result, val.ToV1Domain())
+	}
+
+	return result
+}
@@ -0,0 +1,51 @@
+package records
+
+import (
+	"encoding/json"
+	"time"
+
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+)
+
+type OutboxEvent struct {
+	Id            string     `db:"event_id"`
+	EventType     string     `db:"event_type"`
+	AggregateType string     `db:"aggregate_type"`
+	AggregateId   string     `db:"aggregate_id"`
+	Payload       []byte     `db:"payload"`
+	Version       int        `db:"version"`
+	Status        string     `db:"status"`
+	Attempts      int        `db:"attempts"`
+	LastError     *string    `db:"last_error"`
+	AvailableAt   time.Time  `db:"available_at"`
+	CreatedAt     time.Time  `db:"created_at"`
+	SentAt        *time.Time `db:"sent_at"`
+}
+
+// Mapper
+func (o *OutboxEvent) ToV1Domain() V1Domains.EventDomain {
+	return V1Domains.EventDomain{
+		Id:            o.Id,
+		EventType:     o.EventType,
+		AggregateType: o.AggregateType,
+		AggregateId:   o.AggregateId,
+		Payload:       json.RawMessage(o.Payload),
+		Version:       o.Version,
+		Status:        o.Status,
+		Attempts:      o.Attempts,
+		LastError:     o.LastError,
+		AvailableAt:   o.AvailableAt,
+		CreatedAt:     o.CreatedAt,
+		SentAt:        o.SentAt,
+	}
+}
+
+func ToArrayOfOutboxEventV1Domain(o *[]OutboxEvent) []V1Domains.EventDomain {
+	var result []V1Domains.EventDomain
+
+	for _, val := range *o {
+		result = append(result, val.ToV1Domain())
+	}
+
+	return result
+}
@@ -4,16 +4,18 @@ import (
 	"time"
 
 	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/pkg/money"
 )
 
 type Product struct {
-	Id          int        `db:"product_id"`
-	Name        string     `db:"name"`
-	Description string     `db:"description"`
-	Price       float64    `db:"price"`
-	Stock       int        `db:"stock"`
-	CreatedAt   time.Time  `db:"created_at"`
-	UpdatedAt   *time.Time `db:"updated_at"`
+	Id          int         `db:"product_id"`
+	Name        string      `db:"name"`
+	Description string      `db:"description"`
+	Category    *string     `db:"category"`
+	Price       money.Money `db:"price"`
+	Stock       int         `db:"stock"`
+	CreatedAt   time.Time   `db:"created_at"`
+	UpdatedAt   *time.Time  `db:"updated_at"`
 }
 
 // Mapper
@@ -22,6 +24,7 @@ func (p *Product) ToV1Domain() V1Domains.ProductDomain {
 		Id:          p.Id,
 		Name:        p.Name,
 		Description: p.Description,
+		Category:    p.Category,
 		Price:       p.Price,
 		Stock:       p.Stock,
 		CreatedAt:   p.CreatedAt,
@@ -34,6 +37,7 @@ func FromProductsV1Domain(p *V1Domains.ProductDomain) Product {
 		Id:          p.Id,
 		Name:        p.Name,
 		Description: p.Description,
+		Category:    p.Category,
 		Price:       p.Price,
 		Stock:       p.Stock,
 		CreatedAt:   p.CreatedAt,
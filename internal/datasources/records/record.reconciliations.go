@@ -0,0 +1,66 @@
+package records
+
+import (
+	"time"
+
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/pkg/money"
+)
+
+type ReconciliationRun struct {
+	Id             string     `db:"run_id"`
+	Trigger        string     `db:"trigger"`
+	Status         string     `db:"status"`
+	WalletsChecked int        `db:"wallets_checked"`
+	Discrepancies  int        `db:"discrepancies"`
+	ErrorMessage   *string    `db:"error_message"`
+	StartedAt      time.Time  `db:"started_at"`
+	FinishedAt     *time.Time `db:"finished_at"`
+}
+
+func (r *ReconciliationRun) ToV1Domain() V1Domains.ReconciliationRunDomain {
+	return V1Domains.ReconciliationRunDomain{
+		Id:             r.Id,
+		Trigger:        r.Trigger,
+		Status:         r.Status,
+		WalletsChecked: r.WalletsChecked,
+		Discrepancies:  r.Discrepancies,
+		ErrorMessage:   r.ErrorMessage,
+		StartedAt:      r.StartedAt,
+		FinishedAt:     r.FinishedAt,
+	}
+}
+
+type ReconciliationItem struct {
+	Id              string      `db:"item_id"`
+	RunId           string      `db:"run_id"`
+	WalletId        string      `db:"wallet_id"`
+	UserId          string      `db:"user_id"`
+	RecordedBalance money.Money `db:"recorded_balance"`
+	ExpectedBalance money.Money `db:"expected_balance"`
+	Difference      money.Money `db:"difference"`
+	CreatedAt       time.Time   `db:"created_at"`
+}
+
+func (i *ReconciliationItem) ToV1Domain() V1Domains.ReconciliationItemDomain {
+	return V1Domains.ReconciliationItemDomain{
+		Id:              i.Id,
+		RunId:           i.RunId,
+		WalletId:        i.WalletId,
+		UserId:          i.UserId,
+		RecordedBalance: i.RecordedBalance,
+		ExpectedBalance: i.ExpectedBalance,
+		Difference:      i.Difference,
+		CreatedAt:       i.CreatedAt,
+	}
+}
+
+func ToArrayOfReconciliationItemV1Domain(i *[]ReconciliationItem) []V1Domains.ReconciliationItemDomain {
+	var result []V1Domains.ReconciliationItemDomain
+
+	for _, val := range *i {
+		result = append(result, val.ToV1Domain())
+	}
+
+	return result
+}
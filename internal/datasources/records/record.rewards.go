@@ -0,0 +1,99 @@
+package records
+
+import (
+	"time"
+
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/pkg/money"
+)
+
+type RewardRule struct {
+	Id               int          `db:"reward_rule_id"`
+	Name             string       `db:"name"`
+	RewardType       string       `db:"reward_type"`
+	ProductId        *int         `db:"product_id"`
+	Category         *string      `db:"category"`
+	RateBps          int          `db:"rate_bps"`
+	MonthlyCap       *money.Money `db:"monthly_cap"`
+	PointsExpiryDays *int         `db:"points_expiry_days"`
+	IsActive         bool         `db:"is_active"`
+	CreatedAt        time.Time    `db:"created_at"`
+	UpdatedAt        time.Time    `db:"updated_at"`
+}
+
+type PointEntry struct {
+	Id            string       `db:"point_entry_id"`
+	WalletId      string       `db:"wallet_id"`
+	EntryType     string       `db:"entry_type"`
+	Points        money.Money  `db:"points"`
+	Remaining     *money.Money `db:"remaining"`
+	ExpiresAt     *time.Time   `db:"expires_at"`
+	TransactionId *string      `db:"transaction_id"`
+	CreatedAt     time.Time    `db:"created_at"`
+}
+
+// Mapper
+func (r *RewardRule) ToV1Domain() V1Domains.RewardRuleDomain {
+	return V1Domains.RewardRuleDomain{
+		Id:               r.Id,
+		Name:             r.Name,
+		RewardType:       r.RewardType,
+		ProductId:        r.ProductId,
+		Category:         r.Category,
+		RateBps:          r.RateBps,
+		MonthlyCap:       r.MonthlyCap,
+		PointsExpiryDays: r.PointsExpiryDays,
+		IsActive:         r.IsActive,
+		CreatedAt:        r.CreatedAt,
+		UpdatedAt:        r.UpdatedAt,
+	}
+}
+
+func FromRewardRuleV1Domain(r *V1Domains.RewardRuleDomain) RewardRule {
+	return RewardRule{
+		Id:               r.Id,
+		Name:             r.Name,
+		RewardType:       r.RewardType,
+		ProductId:        r.ProductId,
+		Category:         r.Category,
+		RateBps:          r.RateBps,
+		MonthlyCap:       r.MonthlyCap,
+		PointsExpiryDays: r.PointsExpiryDays,
+		IsActive:         r.IsActive,
+		CreatedAt:        r.CreatedAt,
+		UpdatedAt:        r.UpdatedAt,
+	}
+}
+
+func ToArrayOfRewardRuleV1Domain(r *[]RewardRule) []V1Domains.RewardRuleDomain {
+	var result []V1Domains.RewardRuleDomain
+
+	for _, val := range *r {
+		result = append(result, val.ToV1Domain())
+	}
+
+	return result
+}
+
+func (p *PointEntry) ToV1Domain() V1Domains.PointEntryDomain {
+	return V1Domains.PointEntryDomain{
+		Id:            p.Id,
+		WalletId:      p.WalletId,
+		EntryType:     p.EntryType,
+		Points:        p.Points,
+		Remaining:     p.Remaining,
+		ExpiresAt:     p.ExpiresAt,
+		TransactionId: p.TransactionId,
+		CreatedAt:     p.CreatedAt,
+	}
+}
+
+func ToArrayOfPointEntryV1Domain(p *[]PointEntry) []V1Domains.PointEntryDomain {
+	var result []V1Domains.PointEntryDomain
+
+	for _, val := range *p {
+		result = append(result, val.ToV1Domain())
+	}
+
+	return result
+}
@@ -0,0 +1,116 @@
+package records
+
+import (
+	"time"
+
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/pkg/money"
+)
+
+type Schedule struct {
+	Id              string      `db:"schedule_id"`
+	UserId          string      `db:"user_id"`
+	RecipientUserId string      `db:"recipient_user_id"`
+	Amount          money.Money `db:"amount"`
+	Frequency       string      `db:"frequency"`
+	StartAt         time.Time   `db:"start_at"`
+	EndAt           *time.Time  `db:"end_at"`
+	MaxRuns         *int        `db:"max_runs"`
+	OccurrenceCount int         `db:"occurrence_count"`
+	Attempt         int         `db:"attempt"`
+	NextRunAt       *time.Time  `db:"next_run_at"`
+	Status          string      `db:"status"`
+	LastError       *string     `db:"last_error"`
+	LastRunAt       *time.Time  `db:"last_run_at"`
+	CreatedAt       time.Time   `db:"created_at"`
+	UpdatedAt       time.Time   `db:"updated_at"`
+}
+
+type ScheduleRun struct {
+	Id            int64     `db:"run_id"`
+	ScheduleId    string    `db:"schedule_id"`
+	Occurrence    int       `db:"occurrence"`
+	ScheduledFor  time.Time `db:"scheduled_for"`
+	Attempt       int       `db:"attempt"`
+	Status        string    `db:"status"`
+	TransactionId *string   `db:"transaction_id"`
+	Error         *string   `db:"error"`
+	ExecutedAt    time.Time `db:"executed_at"`
+}
+
+// Mapper
+func (s *Schedule) ToV1Domain() V1Domains.ScheduleDomain {
+	return V1Domains.ScheduleDomain{
+		Id:              s.Id,
+		UserId:          s.UserId,
+		RecipientUserId: s.RecipientUserId,
+		Amount:          s.Amount,
+		Frequency:       s.Frequency,
+		StartAt:         s.StartAt,
+		EndAt:           s.EndAt,
+		MaxRuns:         s.MaxRuns,
+		OccurrenceCount: s.OccurrenceCount,
+		Attempt:         s.Attempt,
+		NextRunAt:       s.NextRunAt,
+		Status:          s.Status,
+		LastError:       s.LastError,
+		LastRunAt:       s.LastRunAt,
+		CreatedAt:       s.CreatedAt,
+		UpdatedAt:       s.UpdatedAt,
+	}
+}
+
+func FromScheduleV1Domain(s *V1Domains.ScheduleDomain) Schedule {
+	return Schedule{
+		Id:              s.Id,
+		UserId:          s.UserId,
+		RecipientUserId: s.RecipientUserId,
+		Amount:          s.Amount,
+		Frequency:       s.Frequency,
+		StartAt:         s.StartAt,
+		EndAt:           s.EndAt,
+		MaxRuns:         s.MaxRuns,
+		OccurrenceCount: s.OccurrenceCount,
+		Attempt:         s.Attempt,
+		NextRunAt:       s.NextRunAt,
+		Status:          s.Status,
+		LastError:       s.LastError,
+		LastRunAt:       s.LastRunAt,
+		CreatedAt:       s.CreatedAt,
+		UpdatedAt:       s.UpdatedAt,
+	}
+}
+
+func (r *ScheduleRun) ToV1Domain() V1Domains.ScheduleRunDomain {
+	return V1Domains.ScheduleRunDomain{
+		Id:            r.Id,
+		ScheduleId:    r.ScheduleId,
+		Occurrence:    r.Occurrence,
+		ScheduledFor:  r.ScheduledFor,
+		Attempt:       r.Attempt,
+		Status:        r.Status,
+		TransactionId: r.TransactionId,
+		Error:         r.Error,
+		ExecutedAt:    r.ExecutedAt,
+	}
+}
+
+func ToArrayOfScheduleV1Domain(s *[]Schedule) []V1Domains.ScheduleDomain {
+	var result []V1Domains.ScheduleDomain
+
+	for _, val := range *s {
+		result = append(result, val.ToV1Domain())
+	}
+
+	return result
+}
+
+func ToArrayOfScheduleRunV1Domain(r *[]ScheduleRun) []V1Domains.ScheduleRunDomain {
+	var result []V1Domains.ScheduleRunDomain
+
+	for _, val := range *r {
+		result = append(result, val.ToV1Domain())
+	}
+
+	return result
+}
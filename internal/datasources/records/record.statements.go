@@ -0,0 +1,122 @@
+package records
+
+import (
+	"time"
+
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+	"github.com/snykk/transaction-api/pkg/money"
+)
+
+type StatementEntry struct {
+	EntryId         int64       `db:"entry_id"`
+	TransactionId   string      `db:"transaction_id"`
+	TransactionType string      `db:"transaction_type"`
+	Status          string      `db:"status"`
+	ProductName     *string     `db:"product_name"`
+	Quantity        *int        `db:"quantity"`
+	Direction       string      `db:"direction"`
+	Amount          money.Money `db:"amount"`
+	PostedAt        time.Time   `db:"posted_at"`
+}
+
+// Mapper
+func (s *StatementEntry) ToV1Domain() V1Domains.StatementEntryDomain {
+	return V1Domains.StatementEntryDomain{
+		EntryId:         s.EntryId,
+		TransactionId:   s.TransactionId,
+		TransactionType: s.TransactionType,
+		Status:          s.Status,
+		ProductName:     s.ProductName,
+		Quantity:        s.Quantity,
+		Direction:       s.Direction,
+		Amount:          s.Amount,
+		PostedAt:        s.PostedAt,
+	}
+}
+
+type MonthlyStatement struct {
+	Id             string      `db:"statement_id"`
+	UserId         string      `db:"user_id"`
+	WalletId       string      `db:"wallet_id"`
+	Period         time.Time   `db:"period"`
+	Timezone       string      `db:"timezone"`
+	PeriodStart    time.Time   `db:"period_start"`
+	PeriodEnd      time.Time   `db:"period_end"`
+	OpeningBalance money.Money `db:"opening_balance"`
+	ClosingBalance money.Money `db:"closing_balance"`
+	EmailedAt      *time.Time  `db:"emailed_at"`
+	CreatedAt      time.Time   `db:"created_at"`
+}
+
+type StatementTotal struct {
+	StatementId     string      `db:"statement_id"`
+	TransactionType string      `db:"transaction_type"`
+	Count           int         `db:"transaction_count"`
+	TotalCredit     money.Money `db:"total_credit"`
+	TotalDebit      money.Money `db:"total_debit"`
+}
+
+type StatementAccount struct {
+	WalletId        string     `db:"wallet_id"`
+	UserId          string     `db:"user_id"`
+	Username        string     `db:"username"`
+	Email           string     `db:"email"`
+	Timezone        string     `db:"timezone"`
+	WalletCreatedAt time.Time  `db:"wallet_created_at"`
+	LastPeriod      *time.Time `db:"last_period"`
+}
+
+// Mapper
+func (s *MonthlyStatement) ToV1Domain() V1Domains.MonthlyStatementDomain {
+	return V1Domains.MonthlyStatementDomain{
+		Id:             s.Id,
+		UserId:         s.UserId,
+		WalletId:       s.WalletId,
+		Period:         s.Period.Format(constants.StatementPeriodLayout),
+		Timezone:       s.Timezone,
+		PeriodStart:    s.PeriodStart,
+		PeriodEnd:      s.PeriodEnd,
+		OpeningBalance: s.OpeningBalance,
+		ClosingBalance: s.ClosingBalance,
+		EmailedAt:      s.EmailedAt,
+		CreatedAt:      s.CreatedAt,
+	}
+}
+
+func (s *StatementTotal) ToV1Domain() V1Domains.StatementTotalDomain {
+	return V1Domains.StatementTotalDomain{
+		TransactionType: s.TransactionType,
+		Count:           s.Count,
+		TotalCredit:     s.TotalCredit,
+		TotalDebit:      s.TotalDebit,
+	}
+}
+
+func (s *StatementAccount) ToV1Domain() V1Domains.StatementAccountDomain {
+	var lastPeriod *string
+	if s.LastPeriod != nil {
+		period := s.LastPeriod.Format(constants.StatementPeriodLayout)
+		lastPeriod = &period
+	}
+
+	return V1Domains.StatementAccountDomain{
+		WalletId:        s.WalletId,
+		UserId:          s.UserId,
+		Username:        s.Username,
+		Email:           s.Email,
+		Timezone:        s.Timezone,
+		WalletCreatedAt: s.WalletCreatedAt,
+		LastPeriod:      lastPeriod,
+	}
+}
+
+func ToArrayOfStatementTotalV1Domain(totals *[]StatementTotal) []V1Domains.StatementTotalDomain {
+	var result []V1Domains.StatementTotalDomain
+
+	for _, val := range *totals {
+		result = append(result, val.ToV1Domain())
+	}
+
+	return result
+}
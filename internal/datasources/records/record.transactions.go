@@ -1,53 +1,135 @@
 package records
 
 import (
+	"database/sql/driver"
+	"encoding/json"
 	"time"
 
 	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/pkg/money"
 )
 
 type Transaction struct {
-	Id              string    `db:"transaction_id"`
-	WalletId        string    `db:"wallet_id"`
-	Wallet          Wallet    `db:"wallet"`
-	ProductId       *int      `db:"product_id"` // Nullable, karena transaksi deposit tidak melibatkan produk
-	Product         Product   `db:"product"`
-	Amount          float64   `db:"amount"`
-	Quantity        *int      `db:"quantity"` // Nullable, karena transaksi deposit tidak melibatkan quantity
-	TransactionType string    `db:"transaction_type"`
-	CreatedAt       time.Time `db:"created_at"`
-	UpdatedAt       time.Time `db:"updated_at"`
+	Id                   string       `db:"transaction_id"`
+	WalletId             string       `db:"wallet_id"`
+	Wallet               Wallet       `db:"wallet"`
+	ProductId            *int         `db:"product_id"` // Nullable, karena transaksi deposit tidak melibatkan produk
+	Product              Product      `db:"product"`
+	Amount               money.Money  `db:"amount"`
+	Discount             money.Money  `db:"discount"`
+	VoucherCode          *string      `db:"voucher_code"`
+	PointsRedeemed       money.Money  `db:"points_redeemed"`
+	PointsEarned         money.Money  `db:"points_earned"`
+	Cashback             money.Money  `db:"cashback"`
+	Clawback             money.Money  `db:"clawback"`
+	Fee                  money.Money  `db:"fee"`
+	FeeBreakdown         FeeBreakdown `db:"fee_breakdown"`
+	Quantity             *int         `db:"quantity"`     // Nullable, karena transaksi deposit tidak melibatkan quantity
+	ProductName          *string      `db:"product_name"` // snapshot produk saat transaksi, nullable untuk transaksi non-pembelian
+	UnitPrice            *money.Money `db:"unit_price"`
+	TransactionType      string       `db:"transaction_type"`
+	RelatedTransactionId *string      `db:"related_transaction_id"` // Nullable, hanya untuk transaksi berpasangan
+	Status               string       `db:"status"`
+	FailureReason        *string      `db:"failure_reason"`
+	CompletedAt          *time.Time   `db:"completed_at"`
+	FailedAt             *time.Time   `db:"failed_at"`
+	CancelledAt          *time.Time   `db:"cancelled_at"`
+	Description          *string      `db:"description"`
+	ExternalReference    *string      `db:"external_reference"`
+	Metadata             Metadata     `db:"metadata"`
+	CreatedAt            time.Time    `db:"created_at"`
+	UpdatedAt            time.Time    `db:"updated_at"`
 }
 
 // Mapper
 func (p *Transaction) ToV1Domain() V1Domains.TransactionDomain {
+	// snapshot nama dan harga saat transaksi lebih diutamakan daripada data produk terkini
+	product := p.Product.ToV1Domain()
+	if p.ProductId != nil {
+		product.Id = *p.ProductId
+	}
+	if p.ProductName != nil {
+		product.Name = *p.ProductName
+	}
+	if p.UnitPrice != nil {
+		product.Price = *p.UnitPrice
+	}
+
 	return V1Domains.TransactionDomain{
-		Id:              p.Id,
-		WalletId:        p.WalletId,
-		Wallet:          p.Wallet.ToV1Domain(),
-		ProductId:       p.ProductId,
-		Product:         p.Product.ToV1Domain(),
-		Amount:          p.Amount,
-		Quantity:        p.Quantity,
-		TransactionType: p.TransactionType,
-		CreatedAt:       p.CreatedAt,
-		UpdatedAt:       p.UpdatedAt,
+		Id:                   p.Id,
+		WalletId:             p.WalletId,
+		Wallet:               p.Wallet.ToV1Domain(),
+		ProductId:            p.ProductId,
+		Product:              product,
+		Amount:               p.Amount,
+		Discount:             p.Discount,
+		VoucherCode:          p.VoucherCode,
+		PointsRedeemed:       p.PointsRedeemed,
+		PointsEarned:         p.PointsEarned,
+		Cashback:             p.Cashback,
+		Clawback:             p.Clawback,
+		Fee:                  p.Fee,
+		FeeBreakdown:         p.FeeBreakdown.ToV1Domain(),
+		Quantity:             p.Quantity,
+		TransactionType:      p.TransactionType,
+		RelatedTransactionId: p.RelatedTransactionId,
+		Status:               p.Status,
+		FailureReason:        p.FailureReason,
+		CompletedAt:          p.CompletedAt,
+		FailedAt:             p.FailedAt,
+		CancelledAt:          p.CancelledAt,
+		Description:          p.Description,
+		ExternalReference:    p.ExternalReference,
+		Metadata:             p.Metadata,
+		CreatedAt:            p.CreatedAt,
+		UpdatedAt:            p.UpdatedAt,
 	}
 }
 
 func FromTransactionV1Domain(p *V1Domains.TransactionDomain) Transaction {
 	return Transaction{
-		Id:              p.Id,
-		WalletId:        p.WalletId,
-		Wallet:          FromWalletV1Domain(&p.Wallet),
-		ProductId:       p.ProductId,
-		Product:         FromProductsV1Domain(&p.Product),
-		Amount:          p.Amount,
-		Quantity:        p.Quantity,
-		TransactionType: p.TransactionType,
-		CreatedAt:       p.CreatedAt,
-		UpdatedAt:       p.UpdatedAt,
+		Id:                   p.Id,
+		WalletId:             p.WalletId,
+		Wallet:               FromWalletV1Domain(&p.Wallet),
+		ProductId:            p.ProductId,
+		Product:              FromProductsV1Domain(&p.Product),
+		Amount:               p.Amount,
+		Discount:             p.Discount,
+		VoucherCode:          p.VoucherCode,
+		PointsRedeemed:       p.PointsRedeemed,
+		PointsEarned:         p.PointsEarned,
+		Cashback:             p.Cashback,
+		Clawback:             p.Clawback,
+		Fee:                  p.Fee,
+		FeeBreakdown:         FromFeeBreakdownV1Domain(p.FeeBreakdown),
+		Quantity:             p.Quantity,
+		TransactionType:      p.TransactionType,
+		RelatedTransactionId: p.RelatedTransactionId,
+		Status:               p.Status,
+		FailureReason:        p.FailureReason,
+		CompletedAt:          p.CompletedAt,
+		FailedAt:             p.FailedAt,
+		CancelledAt:          p.CancelledAt,
+		Description:          p.Description,
+		ExternalReference:    p.ExternalReference,
+		Metadata:             p.Metadata,
+		CreatedAt:            p.CreatedAt,
+		UpdatedAt:            p.UpdatedAt,
+	}
+}
+
+// Metadata disimpan sebagai JSONB, null jika klien tidak mengirim metadata
+type Metadata map[string]interface{}
+
+func (m *Metadata) Scan(src interface{}) error {
+	return scanJSON(src, m)
+}
+
+func (m Metadata) Value() (driver.Value, error) {
+	if m == nil {
+		return nil, nil
 	}
+	return json.Marshal(m)
 }
 
 func ToArrayOfTransactionV1Domain(u *[]Transaction) []V1Domains.TransactionDomain {
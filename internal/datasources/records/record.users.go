@@ -13,6 +13,7 @@ type Users struct {
 	Password  string     `db:"password"`
 	Active    bool       `db:"active"`
 	RoleId    int        `db:"role_id"`
+	Timezone  string     `db:"timezone"`
 	CreatedAt time.Time  `db:"created_at"`
 	UpdatedAt *time.Time `db:"updated_at"`
 	DeletedAt *time.Time `db:"deleted_at"`
@@ -27,6 +28,7 @@ func (u *Users) ToV1Domain() V1Domains.UserDomain {
 		Password:  u.Password,
 		Active:    u.Active,
 		RoleID:    u.RoleId,
+		Timezone:  u.Timezone,
 		CreatedAt: u.CreatedAt,
 		UpdatedAt: u.UpdatedAt,
 	}
@@ -40,6 +42,7 @@ func FromUsersV1Domain(u *V1Domains.UserDomain) Users {
 		Password:  u.Password,
 		Active:    u.Active,
 		RoleId:    u.RoleID,
+		Timezone:  u.Timezone,
 		CreatedAt: u.CreatedAt,
 		UpdatedAt: u.UpdatedAt,
 	}
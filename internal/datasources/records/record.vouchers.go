@@ -0,0 +1,80 @@
+package records
+
+import (
+	"time"
+
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/pkg/money"
+)
+
+type Voucher struct {
+	Id                    int          `db:"voucher_id"`
+	Code                  string       `db:"code"`
+	DiscountType          string       `db:"discount_type"`
+	Amount                *money.Money `db:"amount"`
+	RateBps               *int         `db:"rate_bps"`
+	MaxDiscount           *money.Money `db:"max_discount"`
+	MinSpend              money.Money  `db:"min_spend"`
+	ProductId             *int         `db:"product_id"`
+	StartsAt              time.Time    `db:"starts_at"`
+	EndsAt                *time.Time   `db:"ends_at"`
+	MaxRedemptions        *int         `db:"max_redemptions"`
+	MaxRedemptionsPerUser *int         `db:"max_redemptions_per_user"`
+	RedemptionCount       int          `db:"redemption_count"`
+	IsActive              bool         `db:"is_active"`
+	CreatedAt             time.Time    `db:"created_at"`
+	UpdatedAt             time.Time    `db:"updated_at"`
+}
+
+// Mapper
+func (v *Voucher) ToV1Domain() V1Domains.VoucherDomain {
+	return V1Domains.VoucherDomain{
+		Id:                    v.Id,
+		Code:                  v.Code,
+		DiscountType:          v.DiscountType,
+		Amount:                v.Amount,
+		RateBps:               v.RateBps,
+		MaxDiscount:           v.MaxDiscount,
+		MinSpend:              v.MinSpend,
+		ProductId:             v.ProductId,
+		StartsAt:              v.StartsAt,
+		EndsAt:                v.EndsAt,
+		MaxRedemptions:        v.MaxRedemptions,
+		MaxRedemptionsPerUser: v.MaxRedemptionsPerUser,
+		RedemptionCount:       v.RedemptionCount,
+		IsActive:              v.IsActive,
+		CreatedAt:             v.CreatedAt,
+		UpdatedAt:             v.UpdatedAt,
+	}
+}
+
+func FromVoucherV1Domain(v *V1Domains.VoucherDomain) Voucher {
+	return Voucher{
+		Id:                    v.Id,
+		Code:                  v.Code,
+		DiscountType:          v.DiscountType,
+		Amount:                v.Amount,
+		RateBps:               v.RateBps,
+		MaxDiscount:           v.MaxDiscount,
+		MinSpend:              v.MinSpend,
+		ProductId:             v.ProductId,
+		StartsAt:              v.StartsAt,
+		EndsAt:                v.EndsAt,
+		MaxRedemptions:        v.MaxRedemptions,
+		MaxRedemptionsPerUser: v.MaxRedemptionsPerUser,
+		RedemptionCount:       v.RedemptionCount,
+		IsActive:              v.IsActive,
+		CreatedAt:             v.CreatedAt,
+		UpdatedAt:             v.UpdatedAt,
+	}
+}
+
+func ToArrayOfVoucherV1Domain(v *[]Voucher) []V1Domains.VoucherDomain {
+	var result []V1Domains.VoucherDomain
+
+	for _, val := range *v {
+		result = append(result, val.ToV1Domain())
+	}
+
+	return result
+}
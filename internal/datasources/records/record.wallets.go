@@ -4,36 +4,67 @@ import (
 	"time"
 
 	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+	"github.com/snykk/transaction-api/pkg/money"
 )
 
 type Wallet struct {
-	Id        string     `db:"wallet_id"`
-	UserId    string     `db:"user_id"`
-	Balance   float64    `db:"balance"`
-	User      Users      `db:"user"`
-	CreatedAt time.Time  `db:"created_at"`
-	UpdatedAt *time.Time `db:"updated_at"`
+	Id            string      `db:"wallet_id"`
+	UserId        string      `db:"user_id"`
+	Name          string      `db:"name"`
+	IsDefault     bool        `db:"is_default"`
+	Balance       money.Money `db:"balance"`
+	HeldAmount    money.Money `db:"held_amount"` // total hold aktif
+	PointsBalance money.Money `db:"points_balance"`
+	Status        string      `db:"status"`
+	User          Users       `db:"user"`
+	CreatedAt     time.Time   `db:"created_at"`
+	UpdatedAt     *time.Time  `db:"updated_at"`
+}
+
+// AvailableBalance adalah saldo yang boleh dipakai withdraw, pembelian, transfer, dan hold baru
+func (p *Wallet) AvailableBalance() money.Money {
+	return p.Balance - p.HeldAmount
+}
+
+// BlocksOutgoing bernilai true jika wallet dibekukan untuk dana keluar
+func (p *Wallet) BlocksOutgoing() bool {
+	return p.Status == constants.WalletStatusFrozenOutgoing || p.Status == constants.WalletStatusFrozen
+}
+
+// BlocksIncoming bernilai true jika wallet dibekukan untuk dana masuk
+func (p *Wallet) BlocksIncoming() bool {
+	return p.Status == constants.WalletStatusFrozenIncoming || p.Status == constants.WalletStatusFrozen
 }
 
 // Mapper
 func (p *Wallet) ToV1Domain() V1Domains.WalletDomain {
 	return V1Domains.WalletDomain{
-		Id:        p.Id,
-		UserId:    p.UserId,
-		Balance:   p.Balance,
-		User:      p.User.ToV1Domain(),
-		CreatedAt: p.CreatedAt,
-		UpdatedAt: p.UpdatedAt,
+		Id:               p.Id,
+		UserId:           p.UserId,
+		Name:             p.Name,
+		IsDefault:        p.IsDefault,
+		Balance:          p.Balance,
+		AvailableBalance: p.AvailableBalance(),
+		PointsBalance:    p.PointsBalance,
+		Status:           p.Status,
+		User:             p.User.ToV1Domain(),
+		CreatedAt:        p.CreatedAt,
+		UpdatedAt:        p.UpdatedAt,
 	}
 }
 
 func FromWalletV1Domain(p *V1Domains.WalletDomain) Wallet {
 	return Wallet{
-		Id:        p.Id,
-		UserId:    p.UserId,
-		Balance:   p.Balance,
-		CreatedAt: p.CreatedAt,
-		UpdatedAt: p.UpdatedAt,
+		Id:         p.Id,
+		UserId:     p.UserId,
+		Name:       p.Name,
+		IsDefault:  p.IsDefault,
+		Balance:    p.Balance,
+		HeldAmount: p.Balance - p.AvailableBalance,
+		Status:     p.Status,
+		CreatedAt:  p.CreatedAt,
+		UpdatedAt:  p.UpdatedAt,
 	}
 }
 
@@ -46,3 +77,37 @@ func ToArrayOfWalletV1Domain(u *[]Wallet) []V1Domains.WalletDomain {
 
 	return result
 }
+
+type WalletStatusEvent struct {
+	Id             string    `db:"event_id"`
+	WalletId       string    `db:"wallet_id"`
+	Action         string    `db:"action"`
+	PreviousStatus string    `db:"previous_status"`
+	Status         string    `db:"status"`
+	Reason         string    `db:"reason"`
+	ActorId        *string   `db:"actor_id"`
+	CreatedAt      time.Time `db:"created_at"`
+}
+
+func (e *WalletStatusEvent) ToV1Domain() V1Domains.WalletStatusEventDomain {
+	return V1Domains.WalletStatusEventDomain{
+		Id:             e.Id,
+		WalletId:       e.WalletId,
+		Action:         e.Action,
+		PreviousStatus: e.PreviousStatus,
+		Status:         e.Status,
+		Reason:         e.Reason,
+		ActorId:        e.ActorId,
+		CreatedAt:      e.CreatedAt,
+	}
+}
+
+func ToArrayOfWalletStatusEventV1Domain(e *[]WalletStatusEvent) []V1Domains.WalletStatusEventDomain {
+	var result []V1Domains.WalletStatusEventDomain
+
+	for _, val := range *e {
+		result = append(result, val.ToV1Domain())
+	}
+
+	return result
+}
@@ -0,0 +1,129 @@
+package records
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/lib/pq"
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+)
+
+type WebhookEndpoint struct {
+	Id         string         `db:"webhook_id"`
+	UserId     string         `db:"user_id"`
+	URL        string         `db:"url"`
+	EventTypes pq.StringArray `db:"event_types"`
+	Secret     string         `db:"secret"`
+	IsActive   bool           `db:"is_active"`
+	CreatedAt  time.Time      `db:"created_at"`
+	UpdatedAt  time.Time      `db:"updated_at"`
+}
+
+type WebhookDelivery struct {
+	Id             string     `db:"delivery_id"`
+	WebhookId      string     `db:"webhook_id"`
+	EventId        *string    `db:"event_id"`
+	EventType      string     `db:"event_type"`
+	Payload        []byte     `db:"payload"`
+	Status         string     `db:"status"`
+	Attempts       int        `db:"attempts"`
+	NextAttemptAt  time.Time  `db:"next_attempt_at"`
+	LastStatusCode *int       `db:"last_status_code"`
+	LastError      *string    `db:"last_error"`
+	DeliveredAt    *time.Time `db:"delivered_at"`
+	CreatedAt      time.Time  `db:"created_at"`
+	UpdatedAt      time.Time  `db:"updated_at"`
+
+	// endpoint tujuan, hanya terisi jika query ikut mengambil kolom endpoint
+	URL    string `db:"url"`
+	Secret string `db:"secret"`
+}
+
+type WebhookAttempt struct {
+	Id          string    `db:"attempt_id"`
+	DeliveryId  string    `db:"delivery_id"`
+	Attempt     int       `db:"attempt"`
+	StatusCode  *int      `db:"status_code"`
+	Error       *string   `db:"error"`
+	DurationMs  int       `db:"duration_ms"`
+	AttemptedAt time.Time `db:"attempted_at"`
+}
+
+// Mapper
+func (w *WebhookEndpoint) ToV1Domain() V1Domains.WebhookEndpointDomain {
+	return V1Domains.WebhookEndpointDomain{
+		Id:         w.Id,
+		UserId:     w.UserId,
+		URL:        w.URL,
+		EventTypes: []string(w.EventTypes),
+		Secret:     w.Secret,
+		IsActive:   w.IsActive,
+		CreatedAt:  w.CreatedAt,
+		UpdatedAt:  w.UpdatedAt,
+	}
+}
+
+func ToArrayOfWebhookEndpointV1Domain(w *[]WebhookEndpoint) []V1Domains.WebhookEndpointDomain {
+	var result []V1Domains.WebhookEndpointDomain
+
+	for _, val := range *w {
+		result = append(result, val.ToV1Domain())
+	}
+
+	return result
+}
+
+func (d *WebhookDelivery) ToV1Domain() V1Domains.WebhookDeliveryDomain {
+	return V1Domains.WebhookDeliveryDomain{
+		Id:             d.Id,
+		WebhookId:      d.WebhookId,
+		EventId:        d.EventId,
+		EventType:      d.EventType,
+		Payload:        json.RawMessage(d.Payload),
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		NextAttemptAt:  d.NextAttemptAt,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		DeliveredAt:    d.DeliveredAt,
+		CreatedAt:      d.CreatedAt,
+		UpdatedAt:      d.UpdatedAt,
+		Webhook: V1Domains.WebhookEndpointDomain{
+			Id:     d.WebhookId,
+			URL:    d.URL,
+			Secret: d.Secret,
+		},
+	}
+}
+
+func ToArrayOfWebhookDeliveryV1Domain(d *[]WebhookDelivery) []V1Domains.WebhookDeliveryDomain {
+	var result []V1Domains.WebhookDeliveryDomain
+
+	for _, val := range *d {
+		result = append(result, val.ToV1Domain())
+	}
+
+	return result
+}
+
+func (a *WebhookAttempt) ToV1Domain() V1Domains.WebhookAttemptDomain {
+	return V1Domains.WebhookAttemptDomain{
+		Id:          a.Id,
+		DeliveryId:  a.DeliveryId,
+		Attempt:     a.Attempt,
+		StatusCode:  a.StatusCode,
+		Error:       a.Error,
+		DurationMs:  a.DurationMs,
+		AttemptedAt: a.AttemptedAt,
+	}
+}
+
+func ToArrayOfWebhookAttemptV1Domain(a *[]WebhookAttempt) []V1Domains.WebhookAttemptDomain {
+	var result []V1Domains.WebhookAttemptDomain
+
+	for _, val := range *a {
+		result = append(result, val.ToV1Domain())
+	}
+
+	return result
+}
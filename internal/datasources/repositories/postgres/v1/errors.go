@@ -1,10 +1,80 @@
 package v1
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
 
 // Error custom untuk kondisi bisnis
 var (
-	ErrInsufficientBalance      = errors.New("insufficient balance")
-	ErrInsufficientProductStock = errors.New("insufficient product stock")
-	ErrProductNotFound          = errors.New("product not found")
+	ErrInsufficientBalance         = errors.New("insufficient balance")
+	ErrInsufficientProductStock    = errors.New("insufficient product stock")
+	ErrProductNotFound             = errors.New("product not found")
+	ErrRecipientNotFound           = errors.New("recipient not found")
+	ErrRecipientWalletNotFound     = errors.New("recipient does not have a wallet")
+	ErrSelfTransfer                = errors.New("cannot transfer to your own wallet")
+	ErrUnbalancedLedgerPosting     = errors.New("ledger posting is not balanced")
+	ErrLedgerBalanceMismatch       = errors.New("wallet balance does not match ledger")
+	ErrIdempotencyKeyUnavailable   = errors.New("idempotency key could not be reserved")
+	ErrTransactionNotFound         = errors.New("transaction not found")
+	ErrInvalidStatusTransition     = errors.New("transaction status cannot be changed from its current status")
+	ErrTransactionNotRefundable    = errors.New("only completed purchase transactions can be refunded")
+	ErrRefundQuantityExceeded      = errors.New("refund quantity exceeds the remaining purchased quantity")
+	ErrTransactionAlreadyRefunded  = errors.New("transaction has already been fully refunded")
+	ErrRefundAmountTooSmall        = errors.New("refund amount rounds to zero, refund more items at once")
+	ErrStatementNotFound           = errors.New("statement not found")
+	ErrStatementAlreadyExists      = errors.New("statement for this period already exists")
+	ErrScheduleNotFound            = errors.New("schedule not found")
+	ErrUserNotFound                = errors.New("user not found")
+	ErrLimitExceeded               = errors.New("transaction limit exceeded")
+	ErrFeeRuleNotFound             = errors.New("fee rule not found")
+	ErrProductPriceChanged         = errors.New("product price has changed, please retry the purchase")
+	ErrHoldNotFound                = errors.New("hold not found")
+	ErrHoldNotActive               = errors.New("hold has already been captured, voided or expired")
+	ErrHoldExpired                 = errors.New("hold has expired")
+	ErrWalletNotFound              = errors.New("wallet not found")
+	ErrWalletFrozenOutgoing        = errors.New("wallet is frozen for outgoing transactions")
+	ErrWalletFrozenIncoming        = errors.New("wallet is frozen for incoming transactions")
+	ErrRecipientWalletFrozen       = errors.New("recipient wallet cannot receive funds")
+	ErrWalletStatusUnchanged       = errors.New("wallet already has the requested status")
+	ErrWalletNameTaken             = errors.New("you already have a wallet with this name")
+	ErrSameWalletTransfer          = errors.New("source and destination wallet must be different")
+	ErrReconciliationNotFound      = errors.New("no reconciliation run found")
+	ErrExternalReferenceTaken      = errors.New("external_reference has already been used")
+	ErrOrderNotFound               = errors.New("order not found")
+	ErrMultiItemOrderNotRefundable = errors.New("purchases from multi-item orders cannot be refunded by quantity")
+	ErrVoucherNotFound             = errors.New("voucher not found")
+	ErrVoucherCodeTaken            = errors.New("voucher code already exists")
+	ErrVoucherCapBelowRedemptions  = errors.New("max_redemptions cannot be lower than the redemptions already made")
+	ErrVoucherInvalid              = errors.New("voucher code is invalid or not currently active")
+	ErrVoucherNotApplicable        = errors.New("voucher does not apply to any purchased product")
+	ErrVoucherMinSpendNotMet       = errors.New("purchase does not meet the voucher minimum spend")
+	ErrVoucherUserLimitReached     = errors.New("you have reached the redemption limit for this voucher")
+	ErrVoucherFullyRedeemed        = errors.New("voucher has reached its redemption limit")
+	ErrVoucherCoversFullAmount     = errors.New("voucher discount cannot cover the full purchase amount")
+	ErrRewardRuleNotFound          = errors.New("reward rule not found")
+	ErrInsufficientPoints          = errors.New("insufficient points balance")
+	ErrPointsCoverFullAmount       = errors.New("points cannot cover the full purchase amount")
+	ErrWebhookNotFound             = errors.New("webhook not found")
+	ErrWebhookDeliveryNotFound     = errors.New("webhook delivery not found")
 )
+
+// LimitExceededError menjelaskan limit mana yang terlampaui dan kapan limit tersebut reset.
+// errors.Is(err, ErrLimitExceeded) bernilai true untuk error ini.
+type LimitExceededError struct {
+	Limit   string     // nama limit, misal daily_withdrawal
+	Max     string     // nilai batas, nominal uang atau jumlah transaksi
+	ResetAt *time.Time // nil untuk batas per transaksi yang tidak pernah reset
+}
+
+func (e *LimitExceededError) Error() string {
+	if e.ResetAt == nil {
+		return fmt.Sprintf("%s limit of %s exceeded", e.Limit, e.Max)
+	}
+	return fmt.Sprintf("%s limit of %s exceeded, resets at %s", e.Limit, e.Max, e.ResetAt.Format(time.RFC3339))
+}
+
+func (e *LimitExceededError) Is(target error) bool {
+	return target == ErrLimitExceeded
+}
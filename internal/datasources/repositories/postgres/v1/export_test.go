@@ -0,0 +1,18 @@
+package v1
+
+import "time"
+
+// Helper internal yang dibuka untuk test black-box
+type LedgerPosting = ledgerPosting
+
+var (
+	ValidateLedgerPostings = validateLedgerPostings
+	DebitWallet            = debitWallet
+	CreditWallet           = creditWallet
+	DebitSystem            = debitSystem
+	CreditSystem           = creditSystem
+)
+
+func (e *TxExecutor) Backoff(retry int) time.Duration {
+	return e.backoff(retry)
+}
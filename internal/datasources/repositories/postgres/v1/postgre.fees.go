@@ -0,0 +1,149 @@
+package v1
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/internal/datasources/records"
+)
+
+const feeRuleColumns = `
+	fee_rule_id, name, transaction_type, product_id, fee_type, flat_amount, rate_bps, tiers,
+	min_fee, max_fee, is_active, created_at, updated_at
+`
+
+type postgreFeeRuleRepository struct {
+	conn *sqlx.DB
+}
+
+func NewFeeRuleRepository(conn *sqlx.DB) V1Domains.FeeRuleRepository {
+	return &postgreFeeRuleRepository{
+		conn: conn,
+	}
+}
+
+func (r *postgreFeeRuleRepository) GetAll(ctx context.Context) ([]V1Domains.FeeRuleDomain, error) {
+	query := `SELECT ` + feeRuleColumns + ` FROM fee_rules ORDER BY fee_rule_id`
+
+	var feeRules []records.FeeRule
+	err := r.conn.SelectContext(ctx, &feeRules, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return records.ToArrayOfFeeRuleV1Domain(&feeRules), nil
+}
+
+func (r *postgreFeeRuleRepository) GetById(ctx context.Context, id int) (V1Domains.FeeRuleDomain, error) {
+	query := `SELECT ` + feeRuleColumns + ` FROM fee_rules WHERE fee_rule_id = $1`
+
+	var feeRule records.FeeRule
+	err := r.conn.GetContext(ctx, &feeRule, query, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = ErrFeeRuleNotFound
+		}
+		return V1Domains.FeeRuleDomain{}, err
+	}
+
+	return feeRule.ToV1Domain(), nil
+}
+
+func (r *postgreFeeRuleRepository) GetApplicable(ctx context.Context, transactionType string, productId *int) ([]V1Domains.FeeRuleDomain, error) {
+	// aturan umum selalu berlaku, aturan khusus produk hanya untuk produk tersebut
+	query := `
+		SELECT ` + feeRuleColumns + `
+		FROM fee_rules
+		WHERE is_active AND transaction_type = $1 AND (product_id IS NULL OR product_id = $2)
+		ORDER BY product_id NULLS FIRST, fee_rule_id
+	`
+
+	var feeRules []records.FeeRule
+	err := r.conn.SelectContext(ctx, &feeRules, query, transactionType, productId)
+	if err != nil {
+		return nil, err
+	}
+
+	return records.ToArrayOfFeeRuleV1Domain(&feeRules), nil
+}
+
+func (r *postgreFeeRuleRepository) Store(ctx context.Context, feeRuleDom V1Domains.FeeRuleDomain) (V1Domains.FeeRuleDomain, error) {
+	query := `
+		INSERT INTO fee_rules (name, transaction_type, product_id, fee_type, flat_amount, rate_bps, tiers, min_fee, max_fee, is_active)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING ` + feeRuleColumns
+
+	feeRule := records.FromFeeRuleV1Domain(&feeRuleDom)
+	err := r.conn.GetContext(ctx, &feeRule, query,
+		feeRule.Name,
+		feeRule.TransactionType,
+		feeRule.ProductId,
+		feeRule.FeeType,
+		feeRule.FlatAmount,
+		feeRule.RateBps,
+		feeRule.Tiers,
+		feeRule.MinFee,
+		feeRule.MaxFee,
+		feeRule.IsActive,
+	)
+	if err != nil {
+		// foreign key product_id gagal berarti produk tidak ada
+		if SQLState(err) == "23503" {
+			err = ErrProductNotFound
+		}
+		return V1Domains.FeeRuleDomain{}, err
+	}
+
+	return feeRule.ToV1Domain(), nil
+}
+
+func (r *postgreFeeRuleRepository) Update(ctx context.Context, feeRuleDom V1Domains.FeeRuleDomain) (V1Domains.FeeRuleDomain, error) {
+	query := `
+		UPDATE fee_rules
+		SET name = $1, transaction_type = $2, product_id = $3, fee_type = $4, flat_amount = $5, rate_bps = $6,
+			tiers = $7, min_fee = $8, max_fee = $9, is_active = $10, updated_at = CURRENT_TIMESTAMP
+		WHERE fee_rule_id = $11
+		RETURNING ` + feeRuleColumns
+
+	feeRule := records.FromFeeRuleV1Domain(&feeRuleDom)
+	err := r.conn.GetContext(ctx, &feeRule, query,
+		feeRule.Name,
+		feeRule.TransactionType,
+		feeRule.ProductId,
+		feeRule.FeeType,
+		feeRule.FlatAmount,
+		feeRule.RateBps,
+		feeRule.Tiers,
+		feeRule.MinFee,
+		feeRule.MaxFee,
+		feeRule.IsActive,
+		feeRule.Id,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			err = ErrFeeRuleNotFound
+		case SQLState(err) == "23503":
+			err = ErrProductNotFound
+		}
+		return V1Domains.FeeRuleDomain{}, err
+	}
+
+	return feeRule.ToV1Domain(), nil
+}
+
+func (r *postgreFeeRuleRepository) Delete(ctx context.Context, id int) error {
+	query := `DELETE FROM fee_rules WHERE fee_rule_id = $1`
+	res, err := r.conn.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return ErrFeeRuleNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,310 @@
+package v1
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+	"github.com/snykk/transaction-api/internal/datasources/records"
+	"github.com/snykk/transaction-api/pkg/money"
+)
+
+const holdColumns = `
+	h.hold_id, h.wallet_id, h.transaction_type, h.product_id, h.quantity, h.unit_price, h.amount, h.fee, h.status,
+	h.captured_amount, h.transaction_id, h.expires_at, h.closed_at, h.created_at, h.updated_at
+`
+
+type postgreHoldRepository struct {
+	conn       *sqlx.DB
+	txExecutor *TxExecutor
+	limits     limitChecker
+}
+
+// defaultLimits adalah batas transaksi global, capture tunduk pada limit yang sama dengan withdraw dan pembelian langsung
+func NewHoldRepository(conn *sqlx.DB, defaultLimits V1Domains.UserLimitDomain) V1Domains.HoldRepository {
+	return &postgreHoldRepository{
+		conn:       conn,
+		txExecutor: NewTxExecutor(conn, DefaultTxRetryPolicy),
+		limits:     limitChecker{defaults: defaultLimits},
+	}
+}
+
+func (r *postgreHoldRepository) Store(ctx context.Context, holdDom V1Domains.HoldDomain) (result V1Domains.HoldDomain, err error) {
+	_, err = r.txExecutor.RunSerializable(ctx, "hold", func(tx *sqlx.Tx) (err error) {
+		result, err = executeStoreHold(ctx, tx, holdDom)
+		if err != nil {
+			return err
+		}
+
+		// event ditulis di transaksi yang sama sehingga ikut batal jika hold gagal dibuat
+		return recordHoldEvent(ctx, tx, constants.EventHoldPlaced, result)
+	})
+
+	return result, err
+}
+
+// executeStoreHold menambah dana yang ditahan di wallet user lalu mencatat hold-nya
+func executeStoreHold(ctx context.Context, tx *sqlx.Tx, holdDom V1Domains.HoldDomain) (result V1Domains.HoldDomain, err error) {
+	wallet, err := getUserWallet(ctx, tx, holdDom.UserId, holdDom.WalletId)
+	if err != nil {
+		return V1Domains.HoldDomain{}, err
+	}
+
+	// Hold menahan dana yang nantinya keluar, wallet yang dibekukan untuk dana keluar tidak boleh membuat hold
+	err = ensureOutgoingAllowed(wallet)
+	if err != nil {
+		return V1Domains.HoldDomain{}, err
+	}
+
+	// Hold hanya boleh memakai saldo yang belum ditahan hold lain
+	if wallet.AvailableBalance() < holdDom.HeldAmount() {
+		return V1Domains.HoldDomain{}, ErrInsufficientBalance
+	}
+
+	err = adjustHeldAmount(ctx, tx, wallet.Id, holdDom.HeldAmount())
+	if err != nil {
+		return V1Domains.HoldDomain{}, err
+	}
+
+	queryCreateHold := `
+		INSERT INTO holds AS h (hold_id, wallet_id, transaction_type, product_id, quantity, unit_price, amount, fee, status, expires_at)
+		VALUES (uuid_generate_v4(), $1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING ` + holdColumns
+	holdRecord := records.FromHoldV1Domain(&holdDom)
+	err = tx.GetContext(ctx, &holdRecord, queryCreateHold,
+		wallet.Id,
+		holdRecord.TransactionType,
+		holdRecord.ProductId,
+		holdRecord.Quantity,
+		holdRecord.UnitPrice,
+		holdRecord.Amount,
+		holdRecord.Fee,
+		constants.HoldStatusActive,
+		holdRecord.ExpiresAt,
+	)
+	if err != nil {
+		// foreign key product_id gagal berarti produk tidak ada
+		if SQLState(err) == "23503" {
+			err = ErrProductNotFound
+		}
+		return V1Domains.HoldDomain{}, err
+	}
+	holdRecord.UserId = wallet.UserId
+
+	return holdRecord.ToV1Domain(), nil
+}
+
+func (r *postgreHoldRepository) GetById(ctx context.Context, holdId string, userId string) (V1Domains.HoldDomain, error) {
+	query := `
+		SELECT ` + holdColumns + `, w.user_id
+		FROM holds h
+		INNER JOIN wallets w ON h.wallet_id = w.wallet_id
+		WHERE h.hold_id = $1 AND w.user_id = $2
+	`
+
+	var holdRecord records.Hold
+	err := r.conn.GetContext(ctx, &holdRecord, query, holdId, userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = ErrHoldNotFound
+		}
+		return V1Domains.HoldDomain{}, err
+	}
+
+	return holdRecord.ToV1Domain(), nil
+}
+
+func (r *postgreHoldRepository) Capture(ctx context.Context, holdId string, userId string, transactionDom V1Domains.TransactionDomain) (result V1Domains.HoldDomain, err error) {
+	_, err = r.txExecutor.RunSerializable(ctx, "hold_capture", func(tx *sqlx.Tx) (err error) {
+		result, err = executeCaptureHold(ctx, tx, r.limits, holdId, userId, transactionDom)
+		if err != nil {
+			return err
+		}
+
+		// Capture menghasilkan transaksi withdraw atau pembelian biasa, event-nya sama dengan transaksi langsung
+		eventType := constants.EventTransactionPurchaseCompleted
+		if result.TransactionType == constants.TransactionTypeWithdraw {
+			eventType = constants.EventTransactionWithdrawRequested
+		}
+		err = recordTransactionEvent(ctx, tx, eventType, userId, *result.Transaction)
+		if err != nil {
+			return err
+		}
+
+		return recordHoldEvent(ctx, tx, constants.EventHoldCaptured, result)
+	})
+
+	return result, err
+}
+
+// executeCaptureHold melepas seluruh dana hold lalu menjalankan withdraw atau pembelian sebesar bagian yang di-capture,
+// sisa dana yang tidak di-capture kembali ke saldo tersedia
+func executeCaptureHold(ctx context.Context, tx *sqlx.Tx, limits limitChecker, holdId string, userId string, transactionDom V1Domains.TransactionDomain) (result V1Domains.HoldDomain, err error) {
+	holdRecord, err := lockActiveHold(ctx, tx, holdId, userId)
+	if err != nil {
+		return V1Domains.HoldDomain{}, err
+	}
+
+	// Hold yang belum disapu sweeper tetap tidak boleh di-capture setelah kedaluwarsa
+	now := time.Now()
+	if !holdRecord.ExpiresAt.After(now) {
+		return V1Domains.HoldDomain{}, ErrHoldExpired
+	}
+
+	hold := holdRecord.ToV1Domain()
+	err = adjustHeldAmount(ctx, tx, hold.WalletId, -hold.HeldAmount())
+	if err != nil {
+		return V1Domains.HoldDomain{}, err
+	}
+
+	// Transaksi hasil capture selalu memakai wallet tempat dana ditahan
+	transactionDom.Wallet.UserId = userId
+	transactionDom.WalletId = hold.WalletId
+	var transaction V1Domains.TransactionDomain
+	switch hold.TransactionType {
+	case constants.TransactionTypeWithdraw:
+		transaction, err = executeWithdraw(ctx, tx, limits, transactionDom)
+	default:
+		transaction, err = executePurchase(ctx, tx, limits, transactionDom)
+	}
+	if err != nil {
+		return V1Domains.HoldDomain{}, err
+	}
+
+	closedRecord, err := closeHold(ctx, tx, holdRecord, constants.HoldStatusCaptured, now, &transaction.Amount, &transaction.Id)
+	if err != nil {
+		return V1Domains.HoldDomain{}, err
+	}
+
+	result = closedRecord.ToV1Domain()
+	result.Transaction = &transaction
+
+	return result, nil
+}
+
+func (r *postgreHoldRepository) Void(ctx context.Context, holdId string, userId string) (result V1Domains.HoldDomain, err error) {
+	_, err = r.txExecutor.RunSerializable(ctx, "hold_void", func(tx *sqlx.Tx) (err error) {
+		holdRecord, err := lockActiveHold(ctx, tx, holdId, userId)
+		if err != nil {
+			return err
+		}
+
+		closedRecord, err := releaseHold(ctx, tx, holdRecord, constants.HoldStatusVoided, time.Now())
+		if err != nil {
+			return err
+		}
+
+		result = closedRecord.ToV1Domain()
+		return recordHoldEvent(ctx, tx, constants.EventHoldVoided, result)
+	})
+
+	return result, err
+}
+
+func (r *postgreHoldRepository) ExpireDue(ctx context.Context, now time.Time, limit int) (result []V1Domains.HoldDomain, err error) {
+	_, err = r.txExecutor.RunSerializable(ctx, "hold_expiry", func(tx *sqlx.Tx) (err error) {
+		result = nil
+
+		// SKIP LOCKED membuat sweeper di beberapa instance tidak mengambil hold yang sama
+		// dan tidak menunggu hold yang sedang di-capture atau di-void
+		queryGetDue := `
+			SELECT ` + holdColumns + `, w.user_id
+			FROM holds h
+			INNER JOIN wallets w ON h.wallet_id = w.wallet_id
+			WHERE h.status = $1 AND h.expires_at <= $2
+			ORDER BY h.expires_at
+			LIMIT $3
+			FOR UPDATE OF h SKIP LOCKED
+		`
+		var dueRecords []records.Hold
+		err = tx.SelectContext(ctx, &dueRecords, queryGetDue, constants.HoldStatusActive, now, limit)
+		if err != nil {
+			return err
+		}
+
+		for _, holdRecord := range dueRecords {
+			expiredRecord, err := releaseHold(ctx, tx, holdRecord, constants.HoldStatusExpired, now)
+			if err != nil {
+				return err
+			}
+
+			expired := expiredRecord.ToV1Domain()
+			err = recordHoldEvent(ctx, tx, constants.EventHoldExpired, expired)
+			if err != nil {
+				return err
+			}
+			result = append(result, expired)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// lockActiveHold mengunci hold milik user dan memastikan statusnya masih active
+func lockActiveHold(ctx context.Context, tx *sqlx.Tx, holdId string, userId string) (records.Hold, error) {
+	queryGetHold := `
+		SELECT ` + holdColumns + `, w.user_id
+		FROM holds h
+		INNER JOIN wallets w ON h.wallet_id = w.wallet_id
+		WHERE h.hold_id = $1 AND w.user_id = $2
+		FOR UPDATE OF h
+	`
+	var holdRecord records.Hold
+	err := tx.GetContext(ctx, &holdRecord, queryGetHold, holdId, userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = ErrHoldNotFound
+		}
+		return records.Hold{}, err
+	}
+
+	if holdRecord.Status != constants.HoldStatusActive {
+		return records.Hold{}, ErrHoldNotActive
+	}
+
+	return holdRecord, nil
+}
+
+// releaseHold mengembalikan seluruh dana hold ke saldo tersedia lalu menutup hold dengan status void atau expired
+func releaseHold(ctx context.Context, tx *sqlx.Tx, holdRecord records.Hold, status string, now time.Time) (records.Hold, error) {
+	err := adjustHeldAmount(ctx, tx, holdRecord.WalletId, -(holdRecord.Amount + holdRecord.Fee))
+	if err != nil {
+		return records.Hold{}, err
+	}
+
+	return closeHold(ctx, tx, holdRecord, status, now, nil, nil)
+}
+
+// closeHold mengubah status hold yang sudah tidak aktif, dana yang ditahan harus sudah dilepas oleh pemanggil
+func closeHold(ctx context.Context, tx *sqlx.Tx, holdRecord records.Hold, status string, now time.Time, capturedAmount *money.Money, transactionId *string) (records.Hold, error) {
+	queryCloseHold := `
+		UPDATE holds AS h
+		SET status = $1, captured_amount = $2, transaction_id = $3, closed_at = $4, updated_at = CURRENT_TIMESTAMP
+		WHERE h.hold_id = $5
+		RETURNING ` + holdColumns
+	userId := holdRecord.UserId
+	err := tx.GetContext(ctx, &holdRecord, queryCloseHold, status, capturedAmount, transactionId, now, holdRecord.Id)
+	if err != nil {
+		return records.Hold{}, err
+	}
+	holdRecord.UserId = userId
+
+	return holdRecord, nil
+}
+
+// adjustHeldAmount menambah (delta positif) atau melepas (delta negatif) dana yang ditahan di wallet,
+// saldo ledger tidak berubah sehingga tidak ada posting ledger
+func adjustHeldAmount(ctx context.Context, tx *sqlx.Tx, walletId string, delta money.Money) error {
+	queryUpdateHeld := `
+		UPDATE wallets SET held_amount = held_amount + $1, updated_at = $2
+		WHERE wallet_id = $3
+	`
+	_, err := tx.ExecContext(ctx, queryUpdateHeld, delta, time.Now(), walletId)
+	return err
+}
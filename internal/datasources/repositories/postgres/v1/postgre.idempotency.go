@@ -0,0 +1,86 @@
+package v1
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+	"github.com/snykk/transaction-api/internal/datasources/records"
+)
+
+type postgreIdempotencyRepository struct {
+	conn *sqlx.DB
+}
+
+func NewIdempotencyRepository(conn *sqlx.DB) V1Domains.IdempotencyRepository {
+	return &postgreIdempotencyRepository{
+		conn: conn,
+	}
+}
+
+func (r *postgreIdempotencyRepository) Reserve(ctx context.Context, inDom V1Domains.IdempotencyKeyDomain) (V1Domains.IdempotencyKeyDomain, bool, error) {
+	// Klaim key baru, atau ambil alih key yang sudah kedaluwarsa / terhenti saat processing
+	queryReserve := `
+		INSERT INTO idempotency_keys (user_id, idempotency_key, request_hash, status, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (user_id, idempotency_key) DO UPDATE
+		SET request_hash = EXCLUDED.request_hash,
+			status = EXCLUDED.status,
+			response_code = NULL,
+			response_body = NULL,
+			created_at = EXCLUDED.created_at,
+			expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at < $5
+			OR (idempotency_keys.status = $4 AND idempotency_keys.created_at < $7)
+		RETURNING user_id, idempotency_key, request_hash, status, response_code, response_body, created_at, expires_at
+	`
+	queryGet := `
+		SELECT user_id, idempotency_key, request_hash, status, response_code, response_body, created_at, expires_at
+		FROM idempotency_keys
+		WHERE user_id = $1 AND idempotency_key = $2
+	`
+
+	// Key bisa saja dilepas oleh request lain di antara kedua query, jadi coba ulang beberapa kali
+	for attempt := 0; attempt < 3; attempt++ {
+		now := time.Now()
+
+		var record records.IdempotencyKey
+		err := r.conn.GetContext(ctx, &record, queryReserve, inDom.UserId, inDom.Key, inDom.RequestHash, constants.IdempotencyStatusProcessing, now, inDom.ExpiresAt, now.Add(-constants.IdempotencyProcessingTimeout))
+		if err == nil {
+			return record.ToV1Domain(), true, nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return V1Domains.IdempotencyKeyDomain{}, false, err
+		}
+
+		err = r.conn.GetContext(ctx, &record, queryGet, inDom.UserId, inDom.Key)
+		if err == nil {
+			return record.ToV1Domain(), false, nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return V1Domains.IdempotencyKeyDomain{}, false, err
+		}
+	}
+
+	return V1Domains.IdempotencyKeyDomain{}, false, ErrIdempotencyKeyUnavailable
+}
+
+func (r *postgreIdempotencyRepository) Complete(ctx context.Context, inDom V1Domains.IdempotencyKeyDomain) error {
+	query := `
+		UPDATE idempotency_keys
+		SET status = $1, response_code = $2, response_body = $3
+		WHERE user_id = $4 AND idempotency_key = $5 AND request_hash = $6
+	`
+	_, err := r.conn.ExecContext(ctx, query, constants.IdempotencyStatusCompleted, inDom.ResponseCode, inDom.ResponseBody, inDom.UserId, inDom.Key, inDom.RequestHash)
+	return err
+}
+
+func (r *postgreIdempotencyRepository) Release(ctx context.Context, userId string, key string) error {
+	query := `DELETE FROM idempotency_keys WHERE user_id = $1 AND idempotency_key = $2 AND status = $3`
+	_, err := r.conn.ExecContext(ctx, query, userId, key, constants.IdempotencyStatusProcessing)
+	return err
+}
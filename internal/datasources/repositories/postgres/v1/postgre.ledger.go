@@ -0,0 +1,107 @@
+package v1
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/snykk/transaction-api/internal/constants"
+	"github.com/snykk/transaction-api/pkg/money"
+)
+
+// ledgerPosting adalah satu baris debit atau kredit pada tabel ledger_entries.
+// Saldo akun wallet bertambah saat dikredit dan berkurang saat didebit.
+type ledgerPosting struct {
+	Account   string
+	WalletId  *string
+	Direction string
+	Amount    money.Money
+}
+
+func debitWallet(walletId string, amount money.Money) ledgerPosting {
+	return ledgerPosting{Account: constants.LedgerAccountWallet, WalletId: &walletId, Direction: constants.LedgerDirectionDebit, Amount: amount}
+}
+
+func creditWallet(walletId string, amount money.Money) ledgerPosting {
+	return ledgerPosting{Account: constants.LedgerAccountWallet, WalletId: &walletId, Direction: constants.LedgerDirectionCredit, Amount: amount}
+}
+
+func debitSystem(account string, amount money.Money) ledgerPosting {
+	return ledgerPosting{Account: account, Direction: constants.LedgerDirectionDebit, Amount: amount}
+}
+
+func creditSystem(account string, amount money.Money) ledgerPosting {
+	return ledgerPosting{Account: account, Direction: constants.LedgerDirectionCredit, Amount: amount}
+}
+
+// postLedgerEntries menulis posting untuk satu transaksi di dalam transaksi database yang sedang berjalan.
+// Total debit wajib sama dengan total kredit, jika tidak maka tidak ada yang ditulis.
+func postLedgerEntries(ctx context.Context, tx *sqlx.Tx, transactionId string, postings ...ledgerPosting) error {
+	if err := validateLedgerPostings(postings...); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO ledger_entries (transaction_id, account, wallet_id, direction, amount, created_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
+	`
+	for _, posting := range postings {
+		if _, err := tx.ExecContext(ctx, query, transactionId, posting.Account, posting.WalletId, posting.Direction, posting.Amount); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateLedgerPostings menolak kumpulan posting yang kosong atau total debitnya tidak sama dengan total kredit
+func validateLedgerPostings(postings ...ledgerPosting) error {
+	var totalDebit, totalCredit money.Money
+	for _, posting := range postings {
+		if posting.Direction == constants.LedgerDirectionDebit {
+			totalDebit += posting.Amount
+		} else {
+			totalCredit += posting.Amount
+		}
+	}
+	if len(postings) == 0 || totalDebit != totalCredit {
+		return ErrUnbalancedLedgerPosting
+	}
+
+	return nil
+}
+
+// ledgerBalance menghitung ulang saldo wallet dari ledger: total kredit dikurangi total debit.
+func ledgerBalance(ctx context.Context, q sqlx.QueryerContext, walletId string) (balance money.Money, err error) {
+	query := `
+		SELECT COALESCE(SUM(CASE WHEN direction = 'credit' THEN amount ELSE -amount END), 0)
+		FROM ledger_entries
+		WHERE account = 'wallet' AND wallet_id = $1
+	`
+	err = sqlx.GetContext(ctx, q, &balance, query, walletId)
+	return
+}
+
+// verifyWalletAgainstLedger memastikan kolom wallets.balance sama persis dengan saldo hasil ledger.
+// Perbandingan dilakukan di database terhadap nilai DECIMAL aslinya.
+func verifyWalletAgainstLedger(ctx context.Context, tx *sqlx.Tx, walletIds ...string) error {
+	query := `
+		SELECT w.balance = COALESCE((
+			SELECT SUM(CASE WHEN le.direction = 'credit' THEN le.amount ELSE -le.amount END)
+			FROM ledger_entries le
+			WHERE le.account = 'wallet' AND le.wallet_id = w.wallet_id
+		), 0)
+		FROM wallets w
+		WHERE w.wallet_id = $1
+	`
+	for _, walletId := range walletIds {
+		var inSync bool
+		if err := tx.GetContext(ctx, &inSync, query, walletId); err != nil {
+			return err
+		}
+		if !inSync {
+			return ErrLedgerBalanceMismatch
+		}
+	}
+
+	return nil
+}
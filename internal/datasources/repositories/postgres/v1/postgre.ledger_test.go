@@ -0,0 +1,84 @@
+package v1_test
+
+import (
+	"testing"
+
+	"github.com/snykk/transaction-api/internal/constants"
+	PostgresRepo "github.com/snykk/transaction-api/internal/datasources/repositories/postgres/v1"
+	"github.com/snykk/transaction-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateLedgerPostings(t *testing.T) {
+	walletId := "wallet-1"
+	recipientWalletId := "wallet-2"
+
+	tests := []struct {
+		name        string
+		postings    []PostgresRepo.LedgerPosting
+		expectedErr error
+	}{
+		{
+			"Deposit",
+			[]PostgresRepo.LedgerPosting{
+				PostgresRepo.DebitSystem(constants.LedgerAccountSystemCash, money.FromMajor(100)),
+				PostgresRepo.CreditWallet(walletId, money.FromMajor(100)),
+			},
+			nil,
+		},
+		{
+			"Withdraw With Fee",
+			[]PostgresRepo.LedgerPosting{
+				PostgresRepo.DebitWallet(walletId, money.FromMinorUnits(10250)),
+				PostgresRepo.CreditSystem(constants.LedgerAccountSystemCash, money.FromMajor(100)),
+				PostgresRepo.CreditSystem(constants.LedgerAccountSystemFee, money.FromMinorUnits(250)),
+			},
+			nil,
+		},
+		{
+			"Transfer Through Clearing",
+			[]PostgresRepo.LedgerPosting{
+				PostgresRepo.DebitWallet(walletId, money.FromMajor(50)),
+				PostgresRepo.CreditSystem(constants.LedgerAccountSystemClearing, money.FromMajor(50)),
+				PostgresRepo.DebitSystem(constants.LedgerAccountSystemClearing, money.FromMajor(50)),
+				PostgresRepo.CreditWallet(recipientWalletId, money.FromMajor(50)),
+			},
+			nil,
+		},
+		{
+			"Debit Exceeds Credit",
+			[]PostgresRepo.LedgerPosting{
+				PostgresRepo.DebitWallet(walletId, money.FromMinorUnits(10001)),
+				PostgresRepo.CreditSystem(constants.LedgerAccountSystemRevenue, money.FromMajor(100)),
+			},
+			PostgresRepo.ErrUnbalancedLedgerPosting,
+		},
+		{
+			"Credit Exceeds Debit",
+			[]PostgresRepo.LedgerPosting{
+				PostgresRepo.DebitSystem(constants.LedgerAccountSystemCash, money.FromMajor(100)),
+				PostgresRepo.CreditWallet(walletId, money.FromMajor(100)),
+				PostgresRepo.CreditSystem(constants.LedgerAccountSystemFee, money.FromMinorUnits(1)),
+			},
+			PostgresRepo.ErrUnbalancedLedgerPosting,
+		},
+		{
+			"Single Side Only",
+			[]PostgresRepo.LedgerPosting{
+				PostgresRepo.CreditWallet(walletId, money.FromMajor(100)),
+			},
+			PostgresRepo.ErrUnbalancedLedgerPosting,
+		},
+		{
+			"Empty",
+			nil,
+			PostgresRepo.ErrUnbalancedLedgerPosting,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expectedErr, PostgresRepo.ValidateLedgerPostings(test.postings...))
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package v1
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+	"github.com/snykk/transaction-api/internal/datasources/records"
+	"github.com/snykk/transaction-api/pkg/money"
+)
+
+const userLimitColumns = `
+	u.user_id, l.max_single_withdrawal, l.daily_withdrawal, l.monthly_withdrawal, l.daily_purchase,
+	l.hourly_transactions, l.updated_by, l.updated_at
+`
+
+type postgreLimitRepository struct {
+	conn *sqlx.DB
+}
+
+func NewLimitRepository(conn *sqlx.DB) V1Domains.LimitRepository {
+	return &postgreLimitRepository{
+		conn: conn,
+	}
+}
+
+func (r *postgreLimitRepository) GetByUserId(ctx context.Context, userId string) (V1Domains.UserLimitDomain, error) {
+	query := `SELECT ` + userLimitColumns + ` FROM users u LEFT JOIN user_limits l ON l.user_id = u.user_id WHERE u.user_id = $1`
+
+	var limitRecord records.UserLimit
+	err := r.conn.GetContext(ctx, &limitRecord, query, userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = ErrUserNotFound
+		}
+		return V1Domains.UserLimitDomain{}, err
+	}
+
+	return limitRecord.ToV1Domain(), nil
+}
+
+func (r *postgreLimitRepository) Upsert(ctx context.Context, limitDom V1Domains.UserLimitDomain) (V1Domains.UserLimitDomain, error) {
+	query := `
+		INSERT INTO user_limits (user_id, max_single_withdrawal, daily_withdrawal, monthly_withdrawal, daily_purchase, hourly_transactions, updated_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (user_id) DO UPDATE SET
+			max_single_withdrawal = EXCLUDED.max_single_withdrawal,
+			daily_withdrawal = EXCLUDED.daily_withdrawal,
+			monthly_withdrawal = EXCLUDED.monthly_withdrawal,
+			daily_purchase = EXCLUDED.daily_purchase,
+			hourly_transactions = EXCLUDED.hourly_transactions,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = CURRENT_TIMESTAMP
+		RETURNING user_id, max_single_withdrawal, daily_withdrawal, monthly_withdrawal, daily_purchase, hourly_transactions, updated_by, updated_at
+	`
+
+	limitRecord := records.FromUserLimitV1Domain(&limitDom)
+	err := r.conn.GetContext(ctx, &limitRecord, query,
+		limitRecord.UserId,
+		limitRecord.MaxSingleWithdrawal,
+		limitRecord.DailyWithdrawal,
+		limitRecord.MonthlyWithdrawal,
+		limitRecord.DailyPurchase,
+		limitRecord.HourlyTransactions,
+		limitRecord.UpdatedBy,
+	)
+	if err != nil {
+		// foreign key user_id gagal berarti user tidak ada
+		if SQLState(err) == "23503" {
+			err = ErrUserNotFound
+		}
+		return V1Domains.UserLimitDomain{}, err
+	}
+
+	return limitRecord.ToV1Domain(), nil
+}
+
+// limitChecker memeriksa batas transaksi user. Dipanggil di dalam transaksi serializable yang sama
+// dengan perubahan saldo sehingga transaksi yang berjalan bersamaan tidak bisa sama-sama lolos.
+type limitChecker struct {
+	defaults V1Domains.UserLimitDomain
+}
+
+// check memastikan transaksi baru milik wallet tidak melampaui limit efektif pemiliknya.
+// Pemakaian dihitung dari semua wallet user agar limit tidak bisa dihindari dengan berpindah pocket.
+// Batas harian dan bulanan mengikuti kalender pada zona waktu user.
+func (c limitChecker) check(ctx context.Context, tx *sqlx.Tx, wallet records.Wallet, transactionType string, amount money.Money) error {
+	queryGetLimits := `SELECT u.timezone, ` + userLimitColumns + ` FROM users u LEFT JOIN user_limits l ON l.user_id = u.user_id WHERE u.user_id = $1`
+	var userLimit struct {
+		Timezone string `db:"timezone"`
+		records.UserLimit
+	}
+	err := tx.GetContext(ctx, &userLimit, queryGetLimits, wallet.UserId)
+	if err != nil {
+		return err
+	}
+	limits := userLimit.ToV1Domain().WithDefaults(c.defaults)
+
+	location, err := time.LoadLocation(userLimit.Timezone)
+	if err != nil {
+		location, _ = time.LoadLocation(constants.DefaultTimezone)
+	}
+	now := time.Now().In(location)
+
+	if limit := limits.HourlyTransactions; limit != nil && *limit > 0 {
+		err = c.checkHourly(ctx, tx, wallet.UserId, *limit)
+		if err != nil {
+			return err
+		}
+	}
+
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, location)
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, location)
+
+	switch transactionType {
+	case constants.TransactionTypeWithdraw:
+		if limit := limits.MaxSingleWithdrawal; limit != nil && limit.IsPositive() && amount > *limit {
+			return &LimitExceededError{Limit: constants.LimitMaxSingleWithdrawal, Max: limit.String()}
+		}
+		err = c.checkTotal(ctx, tx, wallet.UserId, transactionType, amount, limits.DailyWithdrawal, constants.LimitDailyWithdrawal, dayStart, dayStart.AddDate(0, 0, 1))
+		if err != nil {
+			return err
+		}
+		return c.checkTotal(ctx, tx, wallet.UserId, transactionType, amount, limits.MonthlyWithdrawal, constants.LimitMonthlyWithdrawal, monthStart, monthStart.AddDate(0, 1, 0))
+	case constants.TransactionTypePurchase:
+		return c.checkTotal(ctx, tx, wallet.UserId, transactionType, amount, limits.DailyPurchase, constants.LimitDailyPurchase, dayStart, dayStart.AddDate(0, 0, 1))
+	}
+
+	return nil
+}
+
+// checkHourly menghitung transaksi dalam 60 menit terakhir, limit reset saat transaksi tertua keluar dari jendela
+func (c limitChecker) checkHourly(ctx context.Context, tx *sqlx.Tx, userId string, limit int) error {
+	// created_at disimpan sebagai waktu lokal sesi database, dikonversi ke timestamptz agar zonanya benar
+	query := `
+		SELECT COUNT(*) AS count, MIN(t.created_at)::timestamptz + INTERVAL '1 hour' AS reset_at
+		FROM transactions t
+		INNER JOIN wallets w ON w.wallet_id = t.wallet_id
+		WHERE w.user_id = $1 AND t.transaction_type = ANY($2) AND t.created_at > $3::timestamptz
+	`
+	var usage struct {
+		Count   int        `db:"count"`
+		ResetAt *time.Time `db:"reset_at"`
+	}
+	err := tx.GetContext(ctx, &usage, query, userId, pq.Array(constants.LimitHourlyTransactionTypes), time.Now().Add(-time.Hour))
+	if err != nil {
+		return err
+	}
+
+	if usage.Count >= limit {
+		return &LimitExceededError{Limit: constants.LimitHourlyTransactions, Max: strconv.Itoa(limit), ResetAt: usage.ResetAt}
+	}
+
+	return nil
+}
+
+// checkTotal menjumlahkan nominal transaksi sejak periodStart, transaksi yang gagal atau dibatalkan tidak dihitung
+func (c limitChecker) checkTotal(ctx context.Context, tx *sqlx.Tx, userId string, transactionType string, amount money.Money, limit *money.Money, name string, periodStart, resetAt time.Time) error {
+	if limit == nil || !limit.IsPositive() {
+		return nil
+	}
+
+	query := `
+		SELECT COALESCE(SUM(t.amount), 0)
+		FROM transactions t
+		INNER JOIN wallets w ON w.wallet_id = t.wallet_id
+		WHERE w.user_id = $1 AND t.transaction_type = $2 AND t.status NOT IN ($3, $4) AND t.created_at >= $5::timestamptz
+	`
+	var total money.Money
+	err := tx.GetContext(ctx, &total, query, userId, transactionType, constants.TransactionStatusFailed, constants.TransactionStatusCancelled, periodStart)
+	if err != nil {
+		return err
+	}
+
+	if total+amount > *limit {
+		return &LimitExceededError{Limit: name, Max: limit.String(), ResetAt: &resetAt}
+	}
+
+	return nil
+}
@@ -0,0 +1,383 @@
+package v1
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+	"github.com/snykk/transaction-api/internal/datasources/records"
+	"github.com/snykk/transaction-api/pkg/money"
+)
+
+// orderColumns membaca order beserta fee, status, dan detail dari transaksi purchase yang membayarnya
+const orderColumns = `
+	o.order_id, o.user_id, o.wallet_id, o.transaction_id, o.total_amount, o.created_at,
+	t.discount, t.voucher_code, t.points_redeemed, t.points_earned, t.cashback, t.fee, t.fee_breakdown, t.status, t.description, t.external_reference, t.metadata
+`
+
+type postgreOrderRepository struct {
+	conn       *sqlx.DB
+	txExecutor *TxExecutor
+	limits     limitChecker
+}
+
+// defaultLimits adalah batas transaksi global, order tunduk pada limit yang sama dengan pembelian langsung
+func NewOrderRepository(conn *sqlx.DB, defaultLimits V1Domains.UserLimitDomain) V1Domains.OrderRepository {
+	return &postgreOrderRepository{
+		conn:       conn,
+		txExecutor: NewTxExecutor(conn, DefaultTxRetryPolicy),
+		limits:     limitChecker{defaults: defaultLimits},
+	}
+}
+
+func (r *postgreOrderRepository) Create(ctx context.Context, orderDom V1Domains.OrderDomain) (result V1Domains.OrderDomain, err error) {
+	_, err = r.txExecutor.RunSerializable(ctx, "order", func(tx *sqlx.Tx) (err error) {
+		result, err = executeOrder(ctx, tx, r.limits, orderDom)
+		if err != nil {
+			return err
+		}
+
+		// event ditulis di transaksi yang sama sehingga ikut batal jika order gagal
+		return recordTransactionEvent(ctx, tx, constants.EventTransactionPurchaseCompleted, result.UserId, result.Transaction)
+	})
+
+	return result, err
+}
+
+// executeOrder membeli semua item order dengan satu debit wallet di dalam transaksi database milik pemanggil.
+// Stock semua item diperiksa sebelum ada yang dikurangi, sehingga order gagal seluruhnya jika satu item tidak tersedia.
+func executeOrder(ctx context.Context, tx *sqlx.Tx, limits limitChecker, orderDom V1Domains.OrderDomain) (result V1Domains.OrderDomain, err error) {
+	// Ambil wallet user yang dipilih, atau wallet default jika wallet_id tidak dikirim
+	wallet, err := getUserWallet(ctx, tx, orderDom.UserId, orderDom.WalletId)
+	if err != nil {
+		return V1Domains.OrderDomain{}, err
+	}
+
+	// Wallet yang dibekukan untuk dana keluar tidak boleh melakukan pembelian
+	err = ensureOutgoingAllowed(wallet)
+	if err != nil {
+		return V1Domains.OrderDomain{}, err
+	}
+
+	err = ensureReferenceAvailable(ctx, tx, wallet.UserId, orderDom.ExternalReference)
+	if err != nil {
+		return V1Domains.OrderDomain{}, err
+	}
+
+	// Ambil semua produk order sekaligus untuk mendapatkan harga dan stock
+	productIds := make([]int64, 0, len(orderDom.Items))
+	for _, item := range orderDom.Items {
+		productIds = append(productIds, int64(*item.ProductId))
+	}
+	queryGetProducts := `
+		SELECT product_id, name, price, stock, category
+		FROM products
+		WHERE product_id = ANY($1)
+	`
+	var productRecords []records.Product
+	err = tx.SelectContext(ctx, &productRecords, queryGetProducts, pq.Array(productIds))
+	if err != nil {
+		return V1Domains.OrderDomain{}, err
+	}
+	products := make(map[int]records.Product, len(productRecords))
+	categories := make(map[int]*string, len(productRecords))
+	for _, product := range productRecords {
+		products[product.Id] = product
+		categories[product.Id] = product.Category
+	}
+
+	// Validasi setiap item lalu hitung subtotal dari harga yang dipakai, perkalian dalam satuan sen selalu eksak
+	var totalAmount money.Money
+	items := make([]V1Domains.OrderItemDomain, 0, len(orderDom.Items))
+	for _, item := range orderDom.Items {
+		product, found := products[*item.ProductId]
+		if !found {
+			return V1Domains.OrderDomain{}, ErrProductNotFound
+		}
+
+		// Fee dihitung dari harga yang dilihat usecase, harga yang berubah sejak itu membuat fee tidak lagi valid.
+		// Harga yang dikunci hold tetap dipakai karena fee capture dihitung dari harga tersebut.
+		if !item.PriceLocked && product.Price != item.UnitPrice {
+			return V1Domains.OrderDomain{}, ErrProductPriceChanged
+		}
+		if product.Stock < item.Quantity {
+			return V1Domains.OrderDomain{}, ErrInsufficientProductStock
+		}
+
+		item.ProductName = product.Name
+		item.Subtotal = item.UnitPrice.MulInt(item.Quantity)
+		totalAmount += item.Subtotal
+		items = append(items, item)
+	}
+
+	// Terapkan voucher jika dikirim, redemption_count bertambah di transaksi yang sama sehingga ikut batal jika order gagal
+	now := time.Now()
+	var voucher V1Domains.VoucherDomain
+	var discount money.Money
+	if orderDom.VoucherCode != nil {
+		voucher, discount, err = redeemVoucher(ctx, tx, wallet.UserId, *orderDom.VoucherCode, items, now)
+		if err != nil {
+			return V1Domains.OrderDomain{}, err
+		}
+	}
+
+	// Nominal transaksi adalah total setelah diskon, fee tetap dihitung dari harga sebelum diskon
+	chargedAmount := totalAmount - discount
+	if !chargedAmount.IsPositive() {
+		return V1Domains.OrderDomain{}, ErrVoucherCoversFullAmount
+	}
+
+	// Poin yang dipakai mengurangi nominal yang dibayar dengan saldo, sisa pembayaran tidak boleh nol
+	paidAmount := chargedAmount - orderDom.PointsRedeemed
+	if orderDom.PointsRedeemed.IsPositive() {
+		if wallet.PointsBalance < orderDom.PointsRedeemed {
+			return V1Domains.OrderDomain{}, ErrInsufficientPoints
+		}
+		if !paidAmount.IsPositive() {
+			return V1Domains.OrderDomain{}, ErrPointsCoverFullAmount
+		}
+	}
+
+	// Validasi apakah saldo tersedia cukup untuk seluruh order beserta fee-nya
+	totalDebit := paidAmount + orderDom.Fee
+	if wallet.AvailableBalance() < totalDebit {
+		return V1Domains.OrderDomain{}, ErrInsufficientBalance
+	}
+
+	// Pastikan pembelian tidak melampaui limit user
+	err = limits.check(ctx, tx, wallet, constants.TransactionTypePurchase, paidAmount)
+	if err != nil {
+		return V1Domains.OrderDomain{}, err
+	}
+
+	// Update saldo wallet
+	queryUpdateBalance := `
+		UPDATE wallets SET balance = $1, updated_at = $2
+		WHERE wallet_id = $3
+	`
+	_, err = tx.ExecContext(ctx, queryUpdateBalance, wallet.Balance-totalDebit, now, wallet.Id)
+	if err != nil {
+		return V1Domains.OrderDomain{}, err
+	}
+
+	// Kurangi stock setiap produk setelah semua item dipastikan tersedia
+	queryUpdateProductStock := `
+		UPDATE products SET stock = stock - $1 WHERE product_id = $2
+	`
+	for _, item := range items {
+		_, err = tx.ExecContext(ctx, queryUpdateProductStock, item.Quantity, *item.ProductId)
+		if err != nil {
+			return V1Domains.OrderDomain{}, err
+		}
+	}
+
+	// Snapshot produk di transaksi hanya diisi untuk order satu item agar refund per quantity tetap bisa dipakai,
+	// rincian order dengan beberapa item tersimpan di order_items
+	var productId, quantity *int
+	var productName *string
+	var unitPrice *money.Money
+	if len(items) == 1 {
+		productId, quantity, productName, unitPrice = items[0].ProductId, &items[0].Quantity, &items[0].ProductName, &items[0].UnitPrice
+	}
+
+	// Kode voucher disimpan sesuai data voucher agar seragam walaupun klien mengirim huruf kecil
+	var voucherCode *string
+	if orderDom.VoucherCode != nil {
+		voucherCode = &voucher.Code
+	}
+
+	// Hitung reward sebelum transaksi dibuat agar totalnya ikut tersimpan di transaksi purchase,
+	// cashback dilewati jika wallet dibekukan untuk dana masuk
+	grants, err := calculateRewards(ctx, tx, wallet.UserId, items, categories, totalAmount, paidAmount, !wallet.BlocksIncoming(), now)
+	if err != nil {
+		return V1Domains.OrderDomain{}, err
+	}
+	var pointsEarned, cashback money.Money
+	for _, grant := range grants {
+		if grant.rule.RewardType == constants.RewardTypeCashback {
+			cashback += grant.amount
+		} else {
+			pointsEarned += grant.amount
+		}
+	}
+
+	// Buat transaksi purchase yang membayar order, amount adalah nominal yang didebit dari saldo
+	var newTransaction records.Transaction
+	queryCreateTransaction := `
+		INSERT INTO transactions (transaction_id, wallet_id, amount, discount, voucher_code, points_redeemed, points_earned, cashback, fee, fee_breakdown, transaction_type, status, completed_at, created_at,
+			product_id, quantity, product_name, unit_price, description, external_reference, metadata)
+		VALUES (uuid_generate_v4(), $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $12, $13, $14, $15, $16, $17, $18, $19)
+		RETURNING transaction_id, wallet_id, amount, discount, voucher_code, points_redeemed, points_earned, cashback, fee, fee_breakdown, transaction_type, status, completed_at, created_at,
+			product_id, quantity, product_name, unit_price, description, external_reference, metadata
+	`
+	err = tx.GetContext(ctx, &newTransaction, queryCreateTransaction, wallet.Id, paidAmount, discount, voucherCode, orderDom.PointsRedeemed, pointsEarned, cashback, orderDom.Fee, records.FromFeeBreakdownV1Domain(orderDom.FeeBreakdown),
+		constants.TransactionTypePurchase, constants.TransactionStatusCompleted, now, productId, quantity, productName, unitPrice, orderDom.Description, orderDom.ExternalReference, records.Metadata(orderDom.Metadata))
+	if err != nil {
+		return V1Domains.OrderDomain{}, err
+	}
+
+	if orderDom.PointsRedeemed.IsPositive() {
+		err = consumePoints(ctx, tx, wallet.Id, orderDom.PointsRedeemed, constants.PointEntryRedeem, newTransaction.Id, now)
+		if err != nil {
+			return V1Domains.OrderDomain{}, err
+		}
+	}
+
+	if voucherCode != nil {
+		err = recordVoucherRedemption(ctx, tx, voucher.Id, wallet.UserId, newTransaction.Id, discount, now)
+		if err != nil {
+			return V1Domains.OrderDomain{}, err
+		}
+	}
+
+	// Catat order beserta item-nya
+	var newOrder records.Order
+	queryCreateOrder := `
+		INSERT INTO orders (order_id, user_id, wallet_id, transaction_id, total_amount, created_at)
+		VALUES (uuid_generate_v4(), $1, $2, $3, $4, $5)
+		RETURNING order_id, user_id, wallet_id, transaction_id, total_amount, created_at
+	`
+	err = tx.GetContext(ctx, &newOrder, queryCreateOrder, wallet.UserId, wallet.Id, newTransaction.Id, totalAmount, now)
+	if err != nil {
+		return V1Domains.OrderDomain{}, err
+	}
+
+	queryCreateOrderItem := `
+		INSERT INTO order_items (order_item_id, order_id, product_id, product_name, quantity, unit_price, subtotal)
+		VALUES (uuid_generate_v4(), $1, $2, $3, $4, $5, $6)
+		RETURNING order_item_id, order_id, product_id, product_name, quantity, unit_price, subtotal
+	`
+	itemRecords := make([]records.OrderItem, len(items))
+	for i, item := range items {
+		err = tx.GetContext(ctx, &itemRecords[i], queryCreateOrderItem, newOrder.Id, item.ProductId, item.ProductName, item.Quantity, item.UnitPrice, item.Subtotal)
+		if err != nil {
+			return V1Domains.OrderDomain{}, err
+		}
+	}
+
+	// Posting ledger: wallet user dan akun reward untuk poin yang dipakai didebit, akun pendapatan dan akun fee dikredit
+	postings := []ledgerPosting{debitWallet(wallet.Id, totalDebit), creditSystem(constants.LedgerAccountSystemRevenue, chargedAmount)}
+	if orderDom.PointsRedeemed.IsPositive() {
+		postings = append(postings, debitSystem(constants.LedgerAccountSystemRewards, orderDom.PointsRedeemed))
+	}
+	if orderDom.Fee.IsPositive() {
+		postings = append(postings, creditSystem(constants.LedgerAccountSystemFee, orderDom.Fee))
+	}
+	err = postLedgerEntries(ctx, tx, newTransaction.Id, postings...)
+	if err != nil {
+		return V1Domains.OrderDomain{}, err
+	}
+
+	err = grantRewards(ctx, tx, wallet, newTransaction.Id, grants, now)
+	if err != nil {
+		return V1Domains.OrderDomain{}, err
+	}
+
+	// Pastikan saldo wallet tetap sesuai dengan ledger
+	err = verifyWalletAgainstLedger(ctx, tx, wallet.Id)
+	if err != nil {
+		return V1Domains.OrderDomain{}, err
+	}
+
+	newOrder.Discount = newTransaction.Discount
+	newOrder.VoucherCode = newTransaction.VoucherCode
+	newOrder.PointsRedeemed = newTransaction.PointsRedeemed
+	newOrder.PointsEarned = newTransaction.PointsEarned
+	newOrder.Cashback = newTransaction.Cashback
+	newOrder.Fee = newTransaction.Fee
+	newOrder.FeeBreakdown = newTransaction.FeeBreakdown
+	newOrder.Status = newTransaction.Status
+	newOrder.Description = newTransaction.Description
+	newOrder.ExternalReference = newTransaction.ExternalReference
+	newOrder.Metadata = newTransaction.Metadata
+
+	result = newOrder.ToV1Domain(itemRecords)
+	result.Transaction = newTransaction.ToV1Domain()
+
+	return result, nil
+}
+
+func (r *postgreOrderRepository) GetByUserId(ctx context.Context, userId string) ([]V1Domains.OrderDomain, error) {
+	query := `
+		SELECT ` + orderColumns + `
+		FROM orders o
+		INNER JOIN transactions t ON o.transaction_id = t.transaction_id
+		WHERE o.user_id = $1
+		ORDER BY o.created_at DESC, o.order_id DESC
+	`
+	var orderRecords []records.Order
+	err := r.conn.SelectContext(ctx, &orderRecords, query, userId)
+	if err != nil {
+		return nil, err
+	}
+	if len(orderRecords) == 0 {
+		return nil, nil
+	}
+
+	orderIds := make([]string, 0, len(orderRecords))
+	for _, order := range orderRecords {
+		orderIds = append(orderIds, order.Id)
+	}
+	itemsByOrder, err := r.getItems(ctx, orderIds)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]V1Domains.OrderDomain, 0, len(orderRecords))
+	for _, order := range orderRecords {
+		result = append(result, order.ToV1Domain(itemsByOrder[order.Id]))
+	}
+
+	return result, nil
+}
+
+func (r *postgreOrderRepository) GetById(ctx context.Context, orderId string, userId string) (V1Domains.OrderDomain, error) {
+	query := `
+		SELECT ` + orderColumns + `
+		FROM orders o
+		INNER JOIN transactions t ON o.transaction_id = t.transaction_id
+		WHERE o.order_id = $1 AND o.user_id = $2
+	`
+	var order records.Order
+	err := r.conn.GetContext(ctx, &order, query, orderId, userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = ErrOrderNotFound
+		}
+		return V1Domains.OrderDomain{}, err
+	}
+
+	itemsByOrder, err := r.getItems(ctx, []string{order.Id})
+	if err != nil {
+		return V1Domains.OrderDomain{}, err
+	}
+
+	return order.ToV1Domain(itemsByOrder[order.Id]), nil
+}
+
+// getItems mengambil item beberapa order sekaligus lalu mengelompokkannya per order
+func (r *postgreOrderRepository) getItems(ctx context.Context, orderIds []string) (map[string][]records.OrderItem, error) {
+	query := `
+		SELECT order_item_id, order_id, product_id, product_name, quantity, unit_price, subtotal
+		FROM order_items
+		WHERE order_id = ANY($1)
+		ORDER BY product_name, order_item_id
+	`
+	var itemRecords []records.OrderItem
+	err := r.conn.SelectContext(ctx, &itemRecords, query, pq.Array(orderIds))
+	if err != nil {
+		return nil, err
+	}
+
+	itemsByOrder := make(map[string][]records.OrderItem, len(orderIds))
+	for _, item := range itemRecords {
+		itemsByOrder[item.OrderId] = append(itemsByOrder[item.OrderId], item)
+	}
+
+	return itemsByOrder, nil
+}
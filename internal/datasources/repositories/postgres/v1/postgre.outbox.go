@@ -0,0 +1,145 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+	"github.com/snykk/transaction-api/internal/datasources/records"
+)
+
+const outboxColumns = `
+	event_id, event_type, aggregate_type, aggregate_id, payload, version, status, attempts, last_error,
+	available_at, created_at, sent_at
+`
+
+type postgreOutboxRepository struct {
+	conn       *sqlx.DB
+	txExecutor *TxExecutor
+}
+
+func NewOutboxRepository(conn *sqlx.DB) V1Domains.OutboxRepository {
+	return &postgreOutboxRepository{
+		conn:       conn,
+		txExecutor: NewTxExecutor(conn, DefaultTxRetryPolicy),
+	}
+}
+
+func (r *postgreOutboxRepository) ClaimPending(ctx context.Context, now time.Time, lease time.Duration, limit int) (result []V1Domains.EventDomain, err error) {
+	_, err = r.txExecutor.RunSerializable(ctx, "outbox_claim", func(tx *sqlx.Tx) (err error) {
+		// SKIP LOCKED membuat relay di beberapa instance tidak mengambil event yang sama,
+		// lease menjaga event tetap milik relay ini setelah transaksi klaim selesai
+		query := `
+			UPDATE outbox SET available_at = $1
+			WHERE event_id IN (
+				SELECT event_id
+				FROM outbox
+				WHERE status = $2 AND available_at <= $3
+				ORDER BY created_at, event_id
+				LIMIT $4
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING ` + outboxColumns
+		var eventRecords []records.OutboxEvent
+		err = tx.SelectContext(ctx, &eventRecords, query, now.Add(lease), constants.OutboxStatusPending, now, limit)
+		if err != nil {
+			return err
+		}
+
+		result = records.ToArrayOfOutboxEventV1Domain(&eventRecords)
+		return nil
+	})
+
+	return result, err
+}
+
+func (r *postgreOutboxRepository) MarkSent(ctx context.Context, eventId string, sentAt time.Time) error {
+	query := `UPDATE outbox SET status = $1, sent_at = $2, last_error = NULL WHERE event_id = $3`
+	_, err := r.conn.ExecContext(ctx, query, constants.OutboxStatusSent, sentAt, eventId)
+	return err
+}
+
+func (r *postgreOutboxRepository) MarkFailed(ctx context.Context, eventId string, reason string, retryAt time.Time) error {
+	query := `
+		UPDATE outbox SET attempts = attempts + 1, last_error = $1, available_at = $2
+		WHERE event_id = $3 AND status = $4
+	`
+	_, err := r.conn.ExecContext(ctx, query, reason, retryAt, eventId, constants.OutboxStatusPending)
+	return err
+}
+
+// recordEvent menulis event ke outbox di dalam transaksi database milik pemanggil,
+// sehingga event hanya ada jika perubahan yang dijelaskannya ikut tersimpan
+func recordEvent(ctx context.Context, tx *sqlx.Tx, eventType string, aggregateType string, aggregateId string, version int, payload interface{}) error {
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO outbox (event_id, event_type, aggregate_type, aggregate_id, payload, version, status, available_at, created_at)
+		VALUES (uuid_generate_v4(), $1, $2, $3, $4, $5, $6, $7, $7)
+	`
+	_, err = tx.ExecContext(ctx, query, eventType, aggregateType, aggregateId, encoded, version, constants.OutboxStatusPending, time.Now())
+	return err
+}
+
+// recordTransactionEvent menulis event perubahan saldo untuk transaksi yang baru dibuat
+func recordTransactionEvent(ctx context.Context, tx *sqlx.Tx, eventType string, userId string, transaction V1Domains.TransactionDomain) error {
+	occurredAt := transaction.CreatedAt
+	if transaction.CompletedAt != nil {
+		occurredAt = *transaction.CompletedAt
+	}
+
+	return recordEvent(ctx, tx, eventType, constants.OutboxAggregateTransaction, transaction.Id, constants.TransactionEventVersion, V1Domains.TransactionEventPayload{
+		TransactionId:     transaction.Id,
+		WalletId:          transaction.WalletId,
+		UserId:            userId,
+		TransactionType:   transaction.TransactionType,
+		Status:            transaction.Status,
+		Amount:            transaction.Amount,
+		Fee:               transaction.Fee,
+		Clawback:          transaction.Clawback,
+		ProductId:         transaction.ProductId,
+		Quantity:          transaction.Quantity,
+		ExternalReference: transaction.ExternalReference,
+		OccurredAt:        occurredAt,
+	})
+}
+
+// recordHoldEvent menulis event perubahan dana yang ditahan hold
+func recordHoldEvent(ctx context.Context, tx *sqlx.Tx, eventType string, hold V1Domains.HoldDomain) error {
+	occurredAt := hold.CreatedAt
+	if hold.ClosedAt != nil {
+		occurredAt = *hold.ClosedAt
+	}
+
+	return recordEvent(ctx, tx, eventType, constants.OutboxAggregateHold, hold.Id, constants.HoldEventVersion, V1Domains.HoldEventPayload{
+		HoldId:          hold.Id,
+		WalletId:        hold.WalletId,
+		UserId:          hold.UserId,
+		TransactionType: hold.TransactionType,
+		Status:          hold.Status,
+		Amount:          hold.Amount,
+		Fee:             hold.Fee,
+		CapturedAmount:  hold.CapturedAmount,
+		TransactionId:   hold.TransactionId,
+		ExpiresAt:       hold.ExpiresAt,
+		OccurredAt:      occurredAt,
+	})
+}
+
+// recordPointsEvent menulis event mutasi poin yang tidak terkait transaksi baru
+func recordPointsEvent(ctx context.Context, tx *sqlx.Tx, eventType string, userId string, entry V1Domains.PointEntryDomain) error {
+	return recordEvent(ctx, tx, eventType, constants.OutboxAggregatePoints, entry.Id, constants.PointsEventVersion, V1Domains.PointsEventPayload{
+		PointEntryId: entry.Id,
+		WalletId:     entry.WalletId,
+		UserId:       userId,
+		EntryType:    entry.EntryType,
+		Points:       entry.Points,
+		OccurredAt:   entry.CreatedAt,
+	})
+}
@@ -21,13 +21,13 @@ func NewProductRepository(conn *sqlx.DB) V1Domains.ProductRepository {
 
 func (r *postgreProductRepository) StoreProduct(ctx context.Context, p *V1Domains.ProductDomain) (V1Domains.ProductDomain, error) {
 	query := `
-		INSERT INTO products (name, description, price, stock, created_at)
-		VALUES ($1, $2, $3, $4, $5)
-		RETURNING product_id, name, description, price, stock, created_at, updated_at
+		INSERT INTO products (name, description, category, price, stock, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING product_id, name, description, category, price, stock, created_at, updated_at
 	`
 	var result records.Product
 	now := time.Now()
-	err := r.conn.GetContext(ctx, &result, query, p.Name, p.Description, p.Price, p.Stock, now)
+	err := r.conn.GetContext(ctx, &result, query, p.Name, p.Description, p.Category, p.Price, p.Stock, now)
 	if err != nil {
 		return V1Domains.ProductDomain{}, err
 	}
@@ -36,7 +36,7 @@ func (r *postgreProductRepository) StoreProduct(ctx context.Context, p *V1Domain
 }
 
 func (r *postgreProductRepository) GetAllProducts(ctx context.Context) ([]V1Domains.ProductDomain, error) {
-	query := `SELECT product_id, name, description, price, stock, created_at, updated_at FROM products`
+	query := `SELECT product_id, name, description, category, price, stock, created_at, updated_at FROM products`
 	var productsFromDB []records.Product
 	err := r.conn.SelectContext(ctx, &productsFromDB, query)
 	if err != nil {
@@ -52,7 +52,7 @@ func (r *postgreProductRepository) GetAllProducts(ctx context.Context) ([]V1Doma
 }
 
 func (r *postgreProductRepository) GetProductById(ctx context.Context, id int) (V1Domains.ProductDomain, error) {
-	query := `SELECT product_id, name, description, price, stock, created_at, updated_at FROM products WHERE product_id = $1`
+	query := `SELECT product_id, name, description, category, price, stock, created_at, updated_at FROM products WHERE product_id = $1`
 	var product records.Product
 	err := r.conn.GetContext(ctx, &product, query, id)
 	if err != nil {
@@ -65,11 +65,11 @@ func (r *postgreProductRepository) GetProductById(ctx context.Context, id int) (
 func (r *postgreProductRepository) UpdateProduct(ctx context.Context, p *V1Domains.ProductDomain) error {
 	query := `
 		UPDATE products
-		SET name = $1, description = $2, price = $3, stock = $4, updated_at = $5
-		WHERE product_id = $6
+		SET name = $1, description = $2, category = $3, price = $4, stock = $5, updated_at = $6
+		WHERE product_id = $7
 	`
 	now := time.Now()
-	_, err := r.conn.ExecContext(ctx, query, p.Name, p.Description, p.Price, p.Stock, now, p.Id)
+	_, err := r.conn.ExecContext(ctx, query, p.Name, p.Description, p.Category, p.Price, p.Stock, now, p.Id)
 	return err
 }
 
@@ -0,0 +1,154 @@
+package v1
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+	"github.com/snykk/transaction-api/internal/datasources/records"
+)
+
+const reconciliationRunColumns = `run_id, trigger, status, wallets_checked, discrepancies, error_message, started_at, finished_at`
+
+type postgreReconciliationRepository struct {
+	conn *sqlx.DB
+}
+
+func NewReconciliationRepository(conn *sqlx.DB) V1Domains.ReconciliationRepository {
+	return &postgreReconciliationRepository{
+		conn: conn,
+	}
+}
+
+func (r *postgreReconciliationRepository) CreateRun(ctx context.Context, trigger string) (V1Domains.ReconciliationRunDomain, error) {
+	query := `
+		INSERT INTO reconciliation_runs (run_id, trigger)
+		VALUES (uuid_generate_v4(), $1)
+		RETURNING ` + reconciliationRunColumns
+
+	var run records.ReconciliationRun
+	err := r.conn.GetContext(ctx, &run, query, trigger)
+	if err != nil {
+		return V1Domains.ReconciliationRunDomain{}, err
+	}
+
+	return run.ToV1Domain(), nil
+}
+
+func (r *postgreReconciliationRepository) ReconcileBatch(ctx context.Context, runId string, afterWalletId string, limit int) (V1Domains.ReconciliationBatchDomain, error) {
+	// Keyset pagination berdasarkan wallet_id agar tabel besar diproses sedikit demi sedikit.
+	// Saldo seharusnya dihitung ulang dari transaksi dengan aturan yang sama seperti TransactionDomain.WalletBalanceEffect:
+	// dana masuk hanya dihitung jika completed dan dikurangi clawback refund, dana keluar sudah memotong saldo sejak pending,
+	// sedangkan yang failed atau cancelled sudah dikembalikan.
+	// Semua dijalankan dalam satu statement sehingga wallets dan transactions dibaca dari snapshot yang sama
+	// tanpa perlu mengunci wallet yang sedang dipakai transaksi lain.
+	query := `
+		WITH batch AS (
+			SELECT wallet_id, balance
+			FROM wallets
+			WHERE $2 = '' OR wallet_id > NULLIF($2, '')::uuid
+			ORDER BY wallet_id
+			LIMIT $3
+		), computed AS (
+			SELECT
+				b.wallet_id,
+				b.balance AS recorded_balance,
+				COALESCE(SUM(CASE
+					WHEN t.transaction_type = ANY($4) AND t.status = 'completed' THEN t.amount - t.clawback
+					WHEN t.transaction_type = ANY($5) AND t.status IN ('pending', 'completed') THEN -(t.amount + t.fee)
+					ELSE 0
+				END), 0) AS expected_balance
+			FROM batch b
+			LEFT JOIN transactions t ON t.wallet_id = b.wallet_id
+			GROUP BY b.wallet_id, b.balance
+		), items AS (
+			INSERT INTO reconciliation_items (item_id, run_id, wallet_id, recorded_balance, expected_balance, difference)
+			SELECT uuid_generate_v4(), $1, wallet_id, recorded_balance, expected_balance, recorded_balance - expected_balance
+			FROM computed
+			WHERE recorded_balance <> expected_balance
+			RETURNING wallet_id
+		), counters AS (
+			UPDATE reconciliation_runs
+			SET wallets_checked = wallets_checked + (SELECT COUNT(*) FROM batch),
+				discrepancies = discrepancies + (SELECT COUNT(*) FROM items)
+			WHERE run_id = $1
+		)
+		SELECT
+			(SELECT COUNT(*) FROM batch) AS wallets_checked,
+			(SELECT COUNT(*) FROM items) AS discrepancies,
+			COALESCE((SELECT wallet_id::text FROM batch ORDER BY wallet_id DESC LIMIT 1), '') AS last_wallet_id
+	`
+
+	var batch struct {
+		WalletsChecked int    `db:"wallets_checked"`
+		Discrepancies  int    `db:"discrepancies"`
+		LastWalletId   string `db:"last_wallet_id"`
+	}
+	err := r.conn.GetContext(ctx, &batch, query, runId, afterWalletId, limit,
+		pq.Array(constants.WalletCreditTransactionTypes), pq.Array(constants.WalletDebitTransactionTypes))
+	if err != nil {
+		return V1Domains.ReconciliationBatchDomain{}, err
+	}
+
+	return V1Domains.ReconciliationBatchDomain{
+		WalletsChecked: batch.WalletsChecked,
+		Discrepancies:  batch.Discrepancies,
+		LastWalletId:   batch.LastWalletId,
+	}, nil
+}
+
+func (r *postgreReconciliationRepository) FinishRun(ctx context.Context, runId string, status string, errorMessage *string) (V1Domains.ReconciliationRunDomain, error) {
+	query := `
+		UPDATE reconciliation_runs
+		SET status = $1, error_message = $2, finished_at = CURRENT_TIMESTAMP
+		WHERE run_id = $3
+		RETURNING ` + reconciliationRunColumns
+
+	var run records.ReconciliationRun
+	err := r.conn.GetContext(ctx, &run, query, status, errorMessage, runId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = ErrReconciliationNotFound
+		}
+		return V1Domains.ReconciliationRunDomain{}, err
+	}
+
+	return run.ToV1Domain(), nil
+}
+
+func (r *postgreReconciliationRepository) GetLatestRun(ctx context.Context, itemLimit int) (V1Domains.ReconciliationRunDomain, error) {
+	queryGetRun := `SELECT ` + reconciliationRunColumns + ` FROM reconciliation_runs ORDER BY started_at DESC LIMIT 1`
+
+	var run records.ReconciliationRun
+	err := r.conn.GetContext(ctx, &run, queryGetRun)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = ErrReconciliationNotFound
+		}
+		return V1Domains.ReconciliationRunDomain{}, err
+	}
+
+	// selisih terbesar ditampilkan lebih dulu
+	queryGetItems := `
+		SELECT ri.item_id, ri.run_id, ri.wallet_id, w.user_id, ri.recorded_balance, ri.expected_balance, ri.difference, ri.created_at
+		FROM reconciliation_items ri
+		JOIN wallets w ON w.wallet_id = ri.wallet_id
+		WHERE ri.run_id = $1
+		ORDER BY ABS(ri.difference) DESC, ri.wallet_id
+		LIMIT $2
+	`
+	var items []records.ReconciliationItem
+	err = r.conn.SelectContext(ctx, &items, queryGetItems, run.Id, itemLimit)
+	if err != nil {
+		return V1Domains.ReconciliationRunDomain{}, err
+	}
+
+	result := run.ToV1Domain()
+	result.Items = records.ToArrayOfReconciliationItemV1Domain(&items)
+
+	return result, nil
+}
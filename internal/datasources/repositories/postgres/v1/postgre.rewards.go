@@ -0,0 +1,601 @@
+package v1
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+	"github.com/snykk/transaction-api/internal/datasources/records"
+	"github.com/snykk/transaction-api/pkg/money"
+)
+
+const rewardRuleColumns = `
+	reward_rule_id, name, reward_type, product_id, category, rate_bps, monthly_cap, points_expiry_days,
+	is_active, created_at, updated_at
+`
+
+const pointEntryColumns = `point_entry_id, wallet_id, entry_type, points, remaining, expires_at, transaction_id, created_at`
+
+type postgreRewardRepository struct {
+	conn       *sqlx.DB
+	txExecutor *TxExecutor
+}
+
+func NewRewardRepository(conn *sqlx.DB) V1Domains.RewardRepository {
+	return &postgreRewardRepository{
+		conn:       conn,
+		txExecutor: NewTxExecutor(conn, DefaultTxRetryPolicy),
+	}
+}
+
+func (r *postgreRewardRepository) GetAllRules(ctx context.Context) ([]V1Domains.RewardRuleDomain, error) {
+	query := `SELECT ` + rewardRuleColumns + ` FROM reward_rules ORDER BY reward_rule_id`
+
+	var rules []records.RewardRule
+	err := r.conn.SelectContext(ctx, &rules, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return records.ToArrayOfRewardRuleV1Domain(&rules), nil
+}
+
+func (r *postgreRewardRepository) GetRuleById(ctx context.Context, id int) (V1Domains.RewardRuleDomain, error) {
+	query := `SELECT ` + rewardRuleColumns + ` FROM reward_rules WHERE reward_rule_id = $1`
+
+	var rule records.RewardRule
+	err := r.conn.GetContext(ctx, &rule, query, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = ErrRewardRuleNotFound
+		}
+		return V1Domains.RewardRuleDomain{}, err
+	}
+
+	return rule.ToV1Domain(), nil
+}
+
+func (r *postgreRewardRepository) StoreRule(ctx context.Context, ruleDom V1Domains.RewardRuleDomain) (V1Domains.RewardRuleDomain, error) {
+	query := `
+		INSERT INTO reward_rules (name, reward_type, product_id, category, rate_bps, monthly_cap, points_expiry_days, is_active)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING ` + rewardRuleColumns
+
+	rule := records.FromRewardRuleV1Domain(&ruleDom)
+	err := r.conn.GetContext(ctx, &rule, query,
+		rule.Name,
+		rule.RewardType,
+		rule.ProductId,
+		rule.Category,
+		rule.RateBps,
+		rule.MonthlyCap,
+		rule.PointsExpiryDays,
+		rule.IsActive,
+	)
+	if err != nil {
+		if SQLState(err) == "23503" {
+			// foreign key product_id gagal berarti produk tidak ada
+			err = ErrProductNotFound
+		}
+		return V1Domains.RewardRuleDomain{}, err
+	}
+
+	return rule.ToV1Domain(), nil
+}
+
+func (r *postgreRewardRepository) UpdateRule(ctx context.Context, ruleDom V1Domains.RewardRuleDomain) (V1Domains.RewardRuleDomain, error) {
+	query := `
+		UPDATE reward_rules
+		SET name = $1, reward_type = $2, product_id = $3, category = $4, rate_bps = $5, monthly_cap = $6,
+			points_expiry_days = $7, is_active = $8, updated_at = CURRENT_TIMESTAMP
+		WHERE reward_rule_id = $9
+		RETURNING ` + rewardRuleColumns
+
+	rule := records.FromRewardRuleV1Domain(&ruleDom)
+	err := r.conn.GetContext(ctx, &rule, query,
+		rule.Name,
+		rule.RewardType,
+		rule.ProductId,
+		rule.Category,
+		rule.RateBps,
+		rule.MonthlyCap,
+		rule.PointsExpiryDays,
+		rule.IsActive,
+		rule.Id,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			err = ErrRewardRuleNotFound
+		case SQLState(err) == "23503":
+			err = ErrProductNotFound
+		}
+		return V1Domains.RewardRuleDomain{}, err
+	}
+
+	return rule.ToV1Domain(), nil
+}
+
+func (r *postgreRewardRepository) DeleteRule(ctx context.Context, id int) error {
+	query := `DELETE FROM reward_rules WHERE reward_rule_id = $1`
+	res, err := r.conn.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return ErrRewardRuleNotFound
+	}
+
+	return nil
+}
+
+func (r *postgreRewardRepository) GetPointEntries(ctx context.Context, userId string, walletId string) ([]V1Domains.PointEntryDomain, error) {
+	queryGetWallet := `SELECT wallet_id FROM wallets WHERE ` + userWalletCondition
+	var ownedWalletId string
+	err := r.conn.GetContext(ctx, &ownedWalletId, queryGetWallet, userId, walletId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = ErrWalletNotFound
+		}
+		return nil, err
+	}
+
+	query := `
+		SELECT ` + pointEntryColumns + `
+		FROM point_entries
+		WHERE wallet_id = $1
+		ORDER BY created_at DESC, point_entry_id
+	`
+	var entries []records.PointEntry
+	err = r.conn.SelectContext(ctx, &entries, query, ownedWalletId)
+	if err != nil {
+		return nil, err
+	}
+
+	return records.ToArrayOfPointEntryV1Domain(&entries), nil
+}
+
+func (r *postgreRewardRepository) ConvertPoints(ctx context.Context, userId string, walletId string, points money.Money) (result V1Domains.TransactionDomain, err error) {
+	_, err = r.txExecutor.RunSerializable(ctx, "points_conversion", func(tx *sqlx.Tx) (err error) {
+		wallet, err := getUserWallet(ctx, tx, userId, walletId)
+		if err != nil {
+			return err
+		}
+
+		// Poin yang dikonversi menjadi dana masuk, sehingga wallet tidak boleh dibekukan untuk dana masuk
+		err = ensureIncomingAllowed(wallet)
+		if err != nil {
+			return err
+		}
+
+		if wallet.PointsBalance < points {
+			return ErrInsufficientPoints
+		}
+
+		now := time.Now()
+		var newTransaction records.Transaction
+		queryCreateTransaction := `
+			INSERT INTO transactions (transaction_id, wallet_id, amount, points_redeemed, transaction_type, status, completed_at, created_at)
+			VALUES (uuid_generate_v4(), $1, $2, $2, $3, $4, $5, $5)
+			RETURNING transaction_id, wallet_id, amount, points_redeemed, transaction_type, status, completed_at, created_at
+		`
+		err = tx.GetContext(ctx, &newTransaction, queryCreateTransaction, wallet.Id, points, constants.TransactionTypePointsConversion, constants.TransactionStatusCompleted, now)
+		if err != nil {
+			return err
+		}
+
+		err = consumePoints(ctx, tx, wallet.Id, points, constants.PointEntryConvert, newTransaction.Id, now)
+		if err != nil {
+			return err
+		}
+
+		queryUpdateBalance := `
+			UPDATE wallets SET balance = balance + $1, updated_at = $2
+			WHERE wallet_id = $3
+		`
+		_, err = tx.ExecContext(ctx, queryUpdateBalance, points, now, wallet.Id)
+		if err != nil {
+			return err
+		}
+
+		// Posting ledger: akun reward sistem didebit, wallet user dikredit
+		err = postLedgerEntries(ctx, tx, newTransaction.Id, debitSystem(constants.LedgerAccountSystemRewards, points), creditWallet(wallet.Id, points))
+		if err != nil {
+			return err
+		}
+
+		err = verifyWalletAgainstLedger(ctx, tx, wallet.Id)
+		if err != nil {
+			return err
+		}
+
+		result = newTransaction.ToV1Domain()
+		return recordTransactionEvent(ctx, tx, constants.EventTransactionPointsConverted, wallet.UserId, result)
+	})
+
+	return result, err
+}
+
+func (r *postgreRewardRepository) ExpirePoints(ctx context.Context, now time.Time, limit int) (result []V1Domains.PointEntryDomain, err error) {
+	_, err = r.txExecutor.RunSerializable(ctx, "points_expiry", func(tx *sqlx.Tx) (err error) {
+		result = nil
+
+		// SKIP LOCKED membuat sweeper di beberapa instance tidak mengambil lot yang sama
+		// dan tidak menunggu lot yang sedang dipakai pembelian atau konversi
+		queryGetDue := `
+			SELECT ` + pointEntryColumns + `
+			FROM point_entries
+			WHERE entry_type = ANY($1) AND remaining > 0 AND expires_at <= $2
+			ORDER BY expires_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		`
+		var dueLots []records.PointEntry
+		err = tx.SelectContext(ctx, &dueLots, queryGetDue, pq.Array(pointLotTypes), now, limit)
+		if err != nil {
+			return err
+		}
+
+		queryCloseLot := `UPDATE point_entries SET remaining = 0 WHERE point_entry_id = $1`
+		queryGetOwner := `SELECT user_id FROM wallets WHERE wallet_id = $1`
+		for _, lot := range dueLots {
+			_, err = tx.ExecContext(ctx, queryCloseLot, lot.Id)
+			if err != nil {
+				return err
+			}
+
+			record, err := recordPointEntry(ctx, tx, lot.WalletId, constants.PointEntryExpire, -*lot.Remaining, lot.TransactionId, now)
+			if err != nil {
+				return err
+			}
+
+			var userId string
+			err = tx.GetContext(ctx, &userId, queryGetOwner, lot.WalletId)
+			if err != nil {
+				return err
+			}
+
+			entry := record.ToV1Domain()
+			err = recordPointsEvent(ctx, tx, constants.EventPointsExpired, userId, entry)
+			if err != nil {
+				return err
+			}
+			result = append(result, entry)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// rewardGrant adalah reward satu aturan untuk satu pembelian, sudah dipotong batas bulanan
+type rewardGrant struct {
+	rule   V1Domains.RewardRuleDomain
+	amount money.Money
+}
+
+// calculateRewards menghitung reward pembelian dari aturan paling spesifik per item dan reward_type.
+// Reward hanya dihitung dari bagian yang dibayar dengan saldo, sehingga diskon voucher dan poin yang dipakai tidak menghasilkan reward.
+func calculateRewards(ctx context.Context, tx *sqlx.Tx, userId string, items []V1Domains.OrderItemDomain, categories map[int]*string,
+	totalAmount money.Money, paidAmount money.Money, includeCashback bool, now time.Time) ([]rewardGrant, error) {
+	productIds := make([]int64, 0, len(items))
+	categoryNames := make([]string, 0, len(items))
+	for _, item := range items {
+		productIds = append(productIds, int64(*item.ProductId))
+		if category := categories[*item.ProductId]; category != nil {
+			categoryNames = append(categoryNames, *category)
+		}
+	}
+
+	queryGetRules := `
+		SELECT ` + rewardRuleColumns + `
+		FROM reward_rules
+		WHERE is_active AND (product_id IS NULL OR product_id = ANY($1)) AND (category IS NULL OR category = ANY($2))
+		ORDER BY reward_rule_id
+	`
+	var ruleRecords []records.RewardRule
+	err := tx.SelectContext(ctx, &ruleRecords, queryGetRules, pq.Array(productIds), pq.Array(categoryNames))
+	if err != nil {
+		return nil, err
+	}
+	if len(ruleRecords) == 0 {
+		return nil, nil
+	}
+
+	// Jumlahkan reward per aturan, urutan grants mengikuti aturan yang pertama kali terpakai
+	var grants []rewardGrant
+	grantIndex := make(map[int]int)
+	for _, item := range items {
+		eligible := item.Subtotal.MulDiv(paidAmount.MinorUnits(), totalAmount.MinorUnits())
+		for _, rewardType := range []string{constants.RewardTypePoints, constants.RewardTypeCashback} {
+			if rewardType == constants.RewardTypeCashback && !includeCashback {
+				continue
+			}
+
+			rule, found := mostSpecificRewardRule(ruleRecords, rewardType, *item.ProductId, categories[*item.ProductId])
+			if !found {
+				continue
+			}
+
+			amount := eligible.MulDiv(int64(rule.RateBps), constants.FeeBasisPointsPerUnit)
+			idx, exists := grantIndex[rule.Id]
+			if !exists {
+				idx = len(grants)
+				grantIndex[rule.Id] = idx
+				grants = append(grants, rewardGrant{rule: rule})
+			}
+			grants[idx].amount += amount
+		}
+	}
+
+	// Potong reward dengan sisa batas bulanan aturan, reward yang habis terpotong tidak dicatat
+	monthStart := time.Date(now.UTC().Year(), now.UTC().Month(), 1, 0, 0, 0, 0, time.UTC)
+	queryGetGranted := `
+		SELECT COALESCE(SUM(amount), 0)
+		FROM reward_grants
+		WHERE reward_rule_id = $1 AND user_id = $2 AND created_at >= $3
+	`
+	result := make([]rewardGrant, 0, len(grants))
+	for _, grant := range grants {
+		if grant.rule.MonthlyCap != nil {
+			var granted money.Money
+			err = tx.GetContext(ctx, &granted, queryGetGranted, grant.rule.Id, userId, monthStart)
+			if err != nil {
+				return nil, err
+			}
+			if remaining := *grant.rule.MonthlyCap - granted; grant.amount > remaining {
+				grant.amount = remaining
+			}
+		}
+		if grant.amount.IsPositive() {
+			result = append(result, grant)
+		}
+	}
+
+	return result, nil
+}
+
+// mostSpecificRewardRule memilih aturan produk, lalu kategori, lalu aturan global.
+// ruleRecords sudah terurut berdasarkan id sehingga aturan dengan id terkecil menang jika sama spesifik.
+func mostSpecificRewardRule(ruleRecords []records.RewardRule, rewardType string, productId int, category *string) (V1Domains.RewardRuleDomain, bool) {
+	best, bestRank := -1, -1
+	for i, rule := range ruleRecords {
+		if rule.RewardType != rewardType {
+			continue
+		}
+
+		rank := 0
+		switch {
+		case rule.ProductId != nil:
+			if *rule.ProductId != productId {
+				continue
+			}
+			rank = 2
+		case rule.Category != nil:
+			if category == nil || *rule.Category != *category {
+				continue
+			}
+			rank = 1
+		}
+
+		if rank > bestRank {
+			best, bestRank = i, rank
+		}
+	}
+	if best < 0 {
+		return V1Domains.RewardRuleDomain{}, false
+	}
+
+	return ruleRecords[best].ToV1Domain(), true
+}
+
+// grantRewards mencatat reward pembelian. Poin masuk sebagai lot baru di wallet,
+// cashback dijumlahkan menjadi satu transaksi cashback yang terhubung ke transaksi pembelian.
+func grantRewards(ctx context.Context, tx *sqlx.Tx, wallet records.Wallet, purchaseId string, grants []rewardGrant, now time.Time) error {
+	queryCreateGrant := `
+		INSERT INTO reward_grants (reward_grant_id, reward_rule_id, user_id, transaction_id, reward_type, amount, created_at)
+		VALUES (uuid_generate_v4(), $1, $2, $3, $4, $5, $6)
+	`
+	queryCreateLot := `
+		INSERT INTO point_entries (point_entry_id, wallet_id, entry_type, points, remaining, expires_at, transaction_id, created_at)
+		VALUES (uuid_generate_v4(), $1, $2, $3, $3, $4, $5, $6)
+	`
+	queryUpdatePoints := `
+		UPDATE wallets SET points_balance = points_balance + $1, updated_at = $2
+		WHERE wallet_id = $3
+	`
+
+	var cashback money.Money
+	for _, grant := range grants {
+		_, err := tx.ExecContext(ctx, queryCreateGrant, grant.rule.Id, wallet.UserId, purchaseId, grant.rule.RewardType, grant.amount, now)
+		if err != nil {
+			return err
+		}
+
+		if grant.rule.RewardType == constants.RewardTypeCashback {
+			cashback += grant.amount
+			continue
+		}
+
+		expiresAt := now.AddDate(0, 0, *grant.rule.PointsExpiryDays)
+		_, err = tx.ExecContext(ctx, queryCreateLot, wallet.Id, constants.PointEntryEarn, grant.amount, expiresAt, purchaseId, now)
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, queryUpdatePoints, grant.amount, now, wallet.Id)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !cashback.IsPositive() {
+		return nil
+	}
+
+	var cashbackId string
+	queryCreateCashback := `
+		INSERT INTO transactions (transaction_id, wallet_id, amount, transaction_type, status, related_transaction_id, completed_at, created_at)
+		VALUES (uuid_generate_v4(), $1, $2, $3, $4, $5, $6, $6)
+		RETURNING transaction_id
+	`
+	err := tx.GetContext(ctx, &cashbackId, queryCreateCashback, wallet.Id, cashback, constants.TransactionTypeCashback, constants.TransactionStatusCompleted, purchaseId, now)
+	if err != nil {
+		return err
+	}
+
+	queryUpdateBalance := `
+		UPDATE wallets SET balance = balance + $1, updated_at = $2
+		WHERE wallet_id = $3
+	`
+	_, err = tx.ExecContext(ctx, queryUpdateBalance, cashback, now, wallet.Id)
+	if err != nil {
+		return err
+	}
+
+	// Posting ledger: akun reward sistem didebit, wallet user dikredit
+	return postLedgerEntries(ctx, tx, cashbackId, debitSystem(constants.LedgerAccountSystemRewards, cashback), creditWallet(wallet.Id, cashback))
+}
+
+// pointLotTypes adalah entry poin yang memiliki sisa dan masa berlaku
+var pointLotTypes = []string{constants.PointEntryEarn, constants.PointEntryRestore}
+
+// lockPointLots mengunci lot poin wallet yang belum kedaluwarsa mulai dari yang paling cepat kedaluwarsa.
+// Lot dari transactionId didahulukan jika diisi.
+func lockPointLots(ctx context.Context, tx *sqlx.Tx, walletId string, transactionId *string, now time.Time) ([]records.PointEntry, error) {
+	queryGetLots := `
+		SELECT ` + pointEntryColumns + `
+		FROM point_entries
+		WHERE wallet_id = $1 AND entry_type = ANY($2) AND remaining > 0 AND expires_at > $3
+		ORDER BY transaction_id IS NOT DISTINCT FROM $4 DESC, expires_at, point_entry_id
+		FOR UPDATE
+	`
+	var lots []records.PointEntry
+	err := tx.SelectContext(ctx, &lots, queryGetLots, walletId, pq.Array(pointLotTypes), now, transactionId)
+	if err != nil {
+		return nil, err
+	}
+
+	return lots, nil
+}
+
+// takeFromLots mengurangi sisa lot secara berurutan sampai points terpenuhi atau lot habis,
+// lalu mengembalikan jumlah poin yang berhasil diambil
+func takeFromLots(ctx context.Context, tx *sqlx.Tx, lots []records.PointEntry, points money.Money) (money.Money, error) {
+	queryUpdateLot := `UPDATE point_entries SET remaining = remaining - $1 WHERE point_entry_id = $2`
+	var taken money.Money
+	for _, lot := range lots {
+		left := points - taken
+		if !left.IsPositive() {
+			break
+		}
+
+		used := *lot.Remaining
+		if used > left {
+			used = left
+		}
+		_, err := tx.ExecContext(ctx, queryUpdateLot, used, lot.Id)
+		if err != nil {
+			return 0, err
+		}
+		taken += used
+	}
+
+	return taken, nil
+}
+
+// consumePoints memakai lot poin wallet yang belum kedaluwarsa mulai dari yang paling cepat kedaluwarsa,
+// lalu mencatat mutasinya dan mengurangi saldo poin wallet
+func consumePoints(ctx context.Context, tx *sqlx.Tx, walletId string, points money.Money, entryType string, transactionId string, now time.Time) error {
+	lots, err := lockPointLots(ctx, tx, walletId, nil, now)
+	if err != nil {
+		return err
+	}
+
+	// Saldo poin bisa masih memuat lot yang sudah kedaluwarsa tetapi belum disapu sweeper
+	var available money.Money
+	for _, lot := range lots {
+		available += *lot.Remaining
+	}
+	if available < points {
+		return ErrInsufficientPoints
+	}
+
+	_, err = takeFromLots(ctx, tx, lots, points)
+	if err != nil {
+		return err
+	}
+
+	_, err = recordPointEntry(ctx, tx, walletId, entryType, -points, &transactionId, now)
+	return err
+}
+
+// restorePoints mengembalikan poin yang dipakai pembelian sebagai lot baru milik transaksi refund
+func restorePoints(ctx context.Context, tx *sqlx.Tx, walletId string, points money.Money, refundId string, now time.Time) error {
+	queryCreateLot := `
+		INSERT INTO point_entries (point_entry_id, wallet_id, entry_type, points, remaining, expires_at, transaction_id, created_at)
+		VALUES (uuid_generate_v4(), $1, $2, $3, $3, $4, $5, $6)
+	`
+	expiresAt := now.AddDate(0, 0, constants.RestoredPointsExpiryDays)
+	_, err := tx.ExecContext(ctx, queryCreateLot, walletId, constants.PointEntryRestore, points, expiresAt, refundId, now)
+	if err != nil {
+		return err
+	}
+
+	queryUpdatePoints := `
+		UPDATE wallets SET points_balance = points_balance + $1, updated_at = $2
+		WHERE wallet_id = $3
+	`
+	_, err = tx.ExecContext(ctx, queryUpdatePoints, points, now, walletId)
+	return err
+}
+
+// reversePoints menarik kembali poin hasil pembelian, lot dari pembelian itu sendiri didahulukan.
+// Poin yang sudah terpakai tidak bisa ditarik, jumlah yang berhasil ditarik dikembalikan ke pemanggil.
+func reversePoints(ctx context.Context, tx *sqlx.Tx, walletId string, points money.Money, purchaseId string, refundId string, now time.Time) (money.Money, error) {
+	lots, err := lockPointLots(ctx, tx, walletId, &purchaseId, now)
+	if err != nil {
+		return 0, err
+	}
+
+	reversed, err := takeFromLots(ctx, tx, lots, points)
+	if err != nil || !reversed.IsPositive() {
+		return reversed, err
+	}
+
+	_, err = recordPointEntry(ctx, tx, walletId, constants.PointEntryReverse, -reversed, &refundId, now)
+	if err != nil {
+		return 0, err
+	}
+
+	return reversed, nil
+}
+
+// recordPointEntry mencatat mutasi poin keluar dan mengurangi saldo poin wallet sebesar nilainya
+func recordPointEntry(ctx context.Context, tx *sqlx.Tx, walletId string, entryType string, points money.Money, transactionId *string, now time.Time) (records.PointEntry, error) {
+	var entry records.PointEntry
+	queryCreateEntry := `
+		INSERT INTO point_entries (point_entry_id, wallet_id, entry_type, points, transaction_id, created_at)
+		VALUES (uuid_generate_v4(), $1, $2, $3, $4, $5)
+		RETURNING ` + pointEntryColumns
+	err := tx.GetContext(ctx, &entry, queryCreateEntry, walletId, entryType, points, transactionId, now)
+	if err != nil {
+		return records.PointEntry{}, err
+	}
+
+	queryUpdatePoints := `
+		UPDATE wallets SET points_balance = points_balance + $1, updated_at = $2
+		WHERE wallet_id = $3
+	`
+	_, err = tx.ExecContext(ctx, queryUpdatePoints, points, now, walletId)
+	if err != nil {
+		return records.PointEntry{}, err
+	}
+
+	return entry, nil
+}
@@ -0,0 +1,291 @@
+package v1
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+	"github.com/snykk/transaction-api/internal/datasources/records"
+)
+
+const scheduleColumns = `
+	schedule_id, user_id, recipient_user_id, amount, frequency, start_at, end_at, max_runs,
+	occurrence_count, attempt, next_run_at, status, last_error, last_run_at, created_at, updated_at
+`
+
+type postgreScheduleRepository struct {
+	conn       *sqlx.DB
+	txExecutor *TxExecutor
+	limits     limitChecker
+}
+
+// defaultLimits adalah batas transaksi global, transfer terjadwal tunduk pada limit yang sama dengan transfer langsung
+func NewScheduleRepository(conn *sqlx.DB, defaultLimits V1Domains.UserLimitDomain) V1Domains.ScheduleRepository {
+	return &postgreScheduleRepository{
+		conn:       conn,
+		txExecutor: NewTxExecutor(conn, DefaultTxRetryPolicy),
+		limits:     limitChecker{defaults: defaultLimits},
+	}
+}
+
+func (r *postgreScheduleRepository) Store(ctx context.Context, scheduleDom V1Domains.ScheduleDomain) (V1Domains.ScheduleDomain, error) {
+	// Penerima ditentukan sekarang agar perubahan username atau email tidak mengubah tujuan transfer
+	recipientUserId, err := findRecipientUserId(ctx, r.conn, scheduleDom.UserId, scheduleDom.RecipientUserId, scheduleDom.RecipientUsername, scheduleDom.RecipientEmail)
+	if err != nil {
+		return V1Domains.ScheduleDomain{}, err
+	}
+
+	// Wallet default kedua user harus sudah ada saat schedule dibuat, eksekusi schedule selalu memakai wallet default
+	queryGetWalletId := `SELECT wallet_id FROM wallets WHERE ` + userWalletCondition
+	var walletId string
+	err = r.conn.GetContext(ctx, &walletId, queryGetWalletId, scheduleDom.UserId, "")
+	if err != nil {
+		return V1Domains.ScheduleDomain{}, err
+	}
+	err = r.conn.GetContext(ctx, &walletId, queryGetWalletId, recipientUserId, "")
+	if errors.Is(err, sql.ErrNoRows) {
+		return V1Domains.ScheduleDomain{}, ErrRecipientWalletNotFound
+	}
+	if err != nil {
+		return V1Domains.ScheduleDomain{}, err
+	}
+
+	query := `
+		INSERT INTO scheduled_transfers (schedule_id, user_id, recipient_user_id, amount, frequency, start_at, end_at, max_runs, next_run_at, status)
+		VALUES (uuid_generate_v4(), $1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING ` + scheduleColumns
+
+	var scheduleRecord records.Schedule
+	err = r.conn.GetContext(ctx, &scheduleRecord, query,
+		scheduleDom.UserId,
+		recipientUserId,
+		scheduleDom.Amount,
+		scheduleDom.Frequency,
+		scheduleDom.StartAt,
+		scheduleDom.EndAt,
+		scheduleDom.MaxRuns,
+		scheduleDom.NextRunAt,
+		scheduleDom.Status,
+	)
+	if err != nil {
+		return V1Domains.ScheduleDomain{}, err
+	}
+
+	return scheduleRecord.ToV1Domain(), nil
+}
+
+func (r *postgreScheduleRepository) GetByUserId(ctx context.Context, userId string) ([]V1Domains.ScheduleDomain, error) {
+	query := `SELECT ` + scheduleColumns + ` FROM scheduled_transfers WHERE user_id = $1 ORDER BY created_at DESC`
+
+	var scheduleRecords []records.Schedule
+	err := r.conn.SelectContext(ctx, &scheduleRecords, query, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return records.ToArrayOfScheduleV1Domain(&scheduleRecords), nil
+}
+
+func (r *postgreScheduleRepository) GetById(ctx context.Context, scheduleId string, userId string) (V1Domains.ScheduleDomain, error) {
+	query := `SELECT ` + scheduleColumns + ` FROM scheduled_transfers WHERE schedule_id = $1 AND user_id = $2`
+
+	var scheduleRecord records.Schedule
+	err := r.conn.GetContext(ctx, &scheduleRecord, query, scheduleId, userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = ErrScheduleNotFound
+		}
+		return V1Domains.ScheduleDomain{}, err
+	}
+
+	queryGetRuns := `
+		SELECT run_id, schedule_id, occurrence, scheduled_for, attempt, status, transaction_id, error, executed_at
+		FROM scheduled_transfer_runs
+		WHERE schedule_id = $1
+		ORDER BY run_id DESC
+		LIMIT $2
+	`
+	var runRecords []records.ScheduleRun
+	err = r.conn.SelectContext(ctx, &runRecords, queryGetRuns, scheduleId, constants.ScheduleRecentRunsLimit)
+	if err != nil {
+		return V1Domains.ScheduleDomain{}, err
+	}
+
+	result := scheduleRecord.ToV1Domain()
+	result.Runs = records.ToArrayOfScheduleRunV1Domain(&runRecords)
+
+	return result, nil
+}
+
+func (r *postgreScheduleRepository) Update(ctx context.Context, scheduleId string, userId string, apply func(V1Domains.ScheduleDomain) (V1Domains.ScheduleDomain, error)) (result V1Domains.ScheduleDomain, err error) {
+	tx, err := r.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return V1Domains.ScheduleDomain{}, err
+	}
+
+	// Pastikan transaksi di-rollback jika terjadi error atau panic
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p) // Panic diteruskan setelah rollback
+		} else if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	// Lock schedule agar perubahan tidak bertabrakan dengan scheduler yang sedang mengeksekusinya
+	queryGetSchedule := `SELECT ` + scheduleColumns + ` FROM scheduled_transfers WHERE schedule_id = $1 AND user_id = $2 FOR UPDATE`
+	var scheduleRecord records.Schedule
+	err = tx.GetContext(ctx, &scheduleRecord, queryGetSchedule, scheduleId, userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = ErrScheduleNotFound
+		}
+		return V1Domains.ScheduleDomain{}, err
+	}
+
+	updated, err := apply(scheduleRecord.ToV1Domain())
+	if err != nil {
+		return V1Domains.ScheduleDomain{}, err
+	}
+	updatedRecord := records.FromScheduleV1Domain(&updated)
+
+	queryUpdateSchedule := `
+		UPDATE scheduled_transfers
+		SET amount = $1, end_at = $2, max_runs = $3, next_run_at = $4, status = $5, updated_at = CURRENT_TIMESTAMP
+		WHERE schedule_id = $6
+		RETURNING ` + scheduleColumns
+	err = tx.GetContext(ctx, &scheduleRecord, queryUpdateSchedule,
+		updatedRecord.Amount,
+		updatedRecord.EndAt,
+		updatedRecord.MaxRuns,
+		updatedRecord.NextRunAt,
+		updatedRecord.Status,
+		scheduleId,
+	)
+	if err != nil {
+		return V1Domains.ScheduleDomain{}, err
+	}
+
+	return scheduleRecord.ToV1Domain(), nil
+}
+
+func (r *postgreScheduleRepository) ExecuteNext(ctx context.Context, now time.Time, apply func(V1Domains.ScheduleDomain, V1Domains.ScheduleRunDomain) V1Domains.ScheduleDomain) (run *V1Domains.ScheduleRunDomain, err error) {
+	_, err = r.txExecutor.RunSerializable(ctx, "scheduled_transfer", func(tx *sqlx.Tx) (err error) {
+		run, err = executeNextSchedule(ctx, tx, r.limits, now, apply)
+		return err
+	})
+
+	return run, err
+}
+
+// executeNextSchedule mengeksekusi satu schedule yang jatuh tempo di dalam transaksi database milik pemanggil
+func executeNextSchedule(ctx context.Context, tx *sqlx.Tx, limits limitChecker, now time.Time, apply func(V1Domains.ScheduleDomain, V1Domains.ScheduleRunDomain) V1Domains.ScheduleDomain) (run *V1Domains.ScheduleRunDomain, err error) {
+	// SKIP LOCKED membuat beberapa instance scheduler bisa berjalan bersamaan tanpa
+	// mengambil schedule yang sama, sehingga setiap jadwal hanya dieksekusi sekali
+	queryGetDue := `
+		SELECT ` + scheduleColumns + `
+		FROM scheduled_transfers
+		WHERE status = $1 AND next_run_at <= $2
+		ORDER BY next_run_at
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED
+	`
+	var scheduleRecord records.Schedule
+	err = tx.GetContext(ctx, &scheduleRecord, queryGetDue, constants.ScheduleStatusActive, now)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	schedule := scheduleRecord.ToV1Domain()
+
+	run = &V1Domains.ScheduleRunDomain{
+		ScheduleId:      schedule.Id,
+		UserId:          schedule.UserId,
+		RecipientUserId: schedule.RecipientUserId,
+		Occurrence:      schedule.OccurrenceCount + 1,
+		ScheduledFor:    *schedule.NextRunAt,
+		Attempt:         schedule.Attempt + 1,
+		ExecutedAt:      now,
+	}
+
+	// Savepoint agar kegagalan transfer bisa dibatalkan tanpa kehilangan lock dan catatan kegagalannya
+	_, err = tx.ExecContext(ctx, `SAVEPOINT scheduled_transfer`)
+	if err != nil {
+		return nil, err
+	}
+
+	transfer, transferErr := executeTransfer(ctx, tx, limits, V1Domains.TransferDomain{
+		SenderUserId:    schedule.UserId,
+		RecipientUserId: schedule.RecipientUserId,
+		Amount:          schedule.Amount,
+	})
+	switch {
+	case transferErr == nil:
+		run.Status = constants.ScheduleRunStatusSucceeded
+		run.TransactionId = &transfer.Outgoing.Id
+	case isScheduledTransferFailure(transferErr):
+		_, err = tx.ExecContext(ctx, `ROLLBACK TO SAVEPOINT scheduled_transfer`)
+		if err != nil {
+			return nil, err
+		}
+		message := transferErr.Error()
+		run.Status = constants.ScheduleRunStatusFailed
+		run.Error = &message
+		// saldo bisa bertambah, limit akan reset, dan wallet bisa dicairkan admin,
+		// kegagalan lain tidak akan berubah jika dicoba lagi
+		run.Retryable = errors.Is(transferErr, ErrInsufficientBalance) ||
+			errors.Is(transferErr, ErrLimitExceeded) ||
+			errors.Is(transferErr, ErrWalletFrozenOutgoing) ||
+			errors.Is(transferErr, ErrRecipientWalletFrozen)
+	default:
+		// Error database, schedule tidak berubah dan akan diambil lagi pada putaran berikutnya
+		return nil, transferErr
+	}
+
+	queryInsertRun := `
+		INSERT INTO scheduled_transfer_runs (schedule_id, occurrence, scheduled_for, attempt, status, transaction_id, error, executed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING run_id
+	`
+	err = tx.GetContext(ctx, &run.Id, queryInsertRun, run.ScheduleId, run.Occurrence, run.ScheduledFor, run.Attempt, run.Status, run.TransactionId, run.Error, run.ExecutedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := apply(schedule, *run)
+	queryUpdateSchedule := `
+		UPDATE scheduled_transfers
+		SET occurrence_count = $1, attempt = $2, next_run_at = $3, status = $4, last_error = $5, last_run_at = $6, updated_at = CURRENT_TIMESTAMP
+		WHERE schedule_id = $7
+	`
+	_, err = tx.ExecContext(ctx, queryUpdateSchedule, updated.OccurrenceCount, updated.Attempt, updated.NextRunAt, updated.Status, updated.LastError, updated.LastRunAt, schedule.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return run, nil
+}
+
+// isScheduledTransferFailure memisahkan kegagalan bisnis yang dicatat pada riwayat eksekusi
+// dari error database yang membatalkan seluruh eksekusi
+func isScheduledTransferFailure(err error) bool {
+	return errors.Is(err, ErrInsufficientBalance) ||
+		errors.Is(err, ErrLimitExceeded) ||
+		errors.Is(err, ErrRecipientNotFound) ||
+		errors.Is(err, ErrRecipientWalletNotFound) ||
+		errors.Is(err, ErrSelfTransfer) ||
+		errors.Is(err, ErrWalletFrozenOutgoing) ||
+		errors.Is(err, ErrRecipientWalletFrozen) ||
+		errors.Is(err, ErrWalletNotFound) || // wallet pengirim sudah tidak ada
+		errors.Is(err, ErrSameWalletTransfer) ||
+		errors.Is(err, sql.ErrNoRows) // wallet terhapus di antara pencarian dan lock
+}
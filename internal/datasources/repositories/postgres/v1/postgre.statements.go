@@ -0,0 +1,232 @@
+package v1
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+	"github.com/snykk/transaction-api/internal/datasources/records"
+	"github.com/snykk/transaction-api/pkg/money"
+)
+
+// walletLedgerEntriesSource memetakan posting ledger wallet ke transaksi milik wallet tersebut.
+// Kaki transfer diposting pada transaksi transfer_out, sehingga untuk wallet penerima
+// dipakai transaksi transfer_in miliknya sendiri (alias own).
+const walletLedgerEntriesSource = `
+	FROM ledger_entries le
+	JOIN transactions t ON t.transaction_id = le.transaction_id
+	LEFT JOIN transactions own
+		ON t.wallet_id <> le.wallet_id AND own.related_transaction_id = t.transaction_id AND own.wallet_id = le.wallet_id
+`
+
+type postgreStatementRepository struct {
+	conn *sqlx.DB
+}
+
+func NewStatementRepository(conn *sqlx.DB) V1Domains.StatementRepository {
+	return &postgreStatementRepository{
+		conn: conn,
+	}
+}
+
+func (r *postgreStatementRepository) GetAccounts(ctx context.Context, afterWalletId string, limit int) (accounts []V1Domains.StatementAccountDomain, err error) {
+	// Keyset pagination berdasarkan wallet_id agar job tidak memuat semua wallet sekaligus.
+	// created_at disimpan sebagai waktu lokal sesi database, dikonversi ke timestamptz agar zonanya benar.
+	query := `
+		SELECT
+			w.wallet_id,
+			w.user_id,
+			u.username,
+			u.email,
+			u.timezone,
+			w.created_at::timestamptz AS wallet_created_at,
+			MAX(s.period) AS last_period
+		FROM wallets w
+		JOIN users u ON u.user_id = w.user_id
+		LEFT JOIN statements s ON s.wallet_id = w.wallet_id
+		WHERE u.deleted_at IS NULL AND ($1 = '' OR w.wallet_id > NULLIF($1, '')::uuid)
+		GROUP BY w.wallet_id, u.user_id
+		ORDER BY w.wallet_id
+		LIMIT $2
+	`
+
+	var accountRecords []records.StatementAccount
+	err = r.conn.SelectContext(ctx, &accountRecords, query, afterWalletId, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts = make([]V1Domains.StatementAccountDomain, 0, len(accountRecords))
+	for _, account := range accountRecords {
+		accounts = append(accounts, account.ToV1Domain())
+	}
+
+	return accounts, nil
+}
+
+func (r *postgreStatementRepository) Generate(ctx context.Context, statementDom V1Domains.MonthlyStatementDomain) (result V1Domains.MonthlyStatementDomain, err error) {
+	// Saldo dan total dihitung dari snapshot yang sama agar konsisten satu sama lain
+	txOptions := &sql.TxOptions{
+		Isolation: sql.LevelRepeatableRead,
+		ReadOnly:  false,
+	}
+
+	tx, err := r.conn.BeginTxx(ctx, txOptions)
+	if err != nil {
+		return V1Domains.MonthlyStatementDomain{}, err
+	}
+
+	// Pastikan transaksi di-rollback jika terjadi error atau panic
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p) // Panic diteruskan setelah rollback
+		} else if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	queryGetBalances := `
+		SELECT
+			COALESCE(SUM(CASE WHEN le.created_at < $2 THEN
+				CASE WHEN le.direction = 'credit' THEN le.amount ELSE -le.amount END
+			END), 0) AS opening_balance,
+			COALESCE(SUM(CASE WHEN le.direction = 'credit' THEN le.amount ELSE -le.amount END), 0) AS closing_balance
+		FROM ledger_entries le
+		WHERE le.account = 'wallet' AND le.wallet_id = $1 AND le.created_at < $3
+	`
+	var balances struct {
+		OpeningBalance money.Money `db:"opening_balance"`
+		ClosingBalance money.Money `db:"closing_balance"`
+	}
+	err = tx.GetContext(ctx, &balances, queryGetBalances, statementDom.WalletId, statementDom.PeriodStart, statementDom.PeriodEnd)
+	if err != nil {
+		return V1Domains.MonthlyStatementDomain{}, err
+	}
+
+	queryGetTotals := `
+		SELECT
+			COALESCE(own.transaction_type, t.transaction_type) AS transaction_type,
+			COUNT(DISTINCT COALESCE(own.transaction_id, t.transaction_id)) AS transaction_count,
+			COALESCE(SUM(le.amount) FILTER (WHERE le.direction = 'credit'), 0) AS total_credit,
+			COALESCE(SUM(le.amount) FILTER (WHERE le.direction = 'debit'), 0) AS total_debit
+	` + walletLedgerEntriesSource + `
+		WHERE le.account = 'wallet' AND le.wallet_id = $1 AND le.created_at >= $2 AND le.created_at < $3
+		GROUP BY 1
+		ORDER BY 1
+	`
+	var totalRecords []records.StatementTotal
+	err = tx.SelectContext(ctx, &totalRecords, queryGetTotals, statementDom.WalletId, statementDom.PeriodStart, statementDom.PeriodEnd)
+	if err != nil {
+		return V1Domains.MonthlyStatementDomain{}, err
+	}
+
+	// Statement yang sudah ada tidak ditimpa, sehingga job aman dijalankan ulang
+	queryInsertStatement := `
+		INSERT INTO statements (statement_id, user_id, wallet_id, period, timezone, period_start, period_end, opening_balance, closing_balance)
+		VALUES (uuid_generate_v4(), $1, $2, $3::date, $4, $5, $6, $7, $8)
+		ON CONFLICT (wallet_id, period) DO NOTHING
+		RETURNING statement_id, user_id, wallet_id, period, timezone, period_start, period_end, opening_balance, closing_balance, emailed_at, created_at
+	`
+	var statementRecord records.MonthlyStatement
+	err = tx.GetContext(ctx, &statementRecord, queryInsertStatement,
+		statementDom.UserId,
+		statementDom.WalletId,
+		statementDom.Period+"-01",
+		statementDom.Timezone,
+		statementDom.PeriodStart,
+		statementDom.PeriodEnd,
+		balances.OpeningBalance,
+		balances.ClosingBalance,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = ErrStatementAlreadyExists
+		}
+		return V1Domains.MonthlyStatementDomain{}, err
+	}
+
+	queryInsertTotal := `
+		INSERT INTO statement_totals (statement_id, transaction_type, transaction_count, total_credit, total_debit)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+	for _, total := range totalRecords {
+		_, err = tx.ExecContext(ctx, queryInsertTotal, statementRecord.Id, total.TransactionType, total.Count, total.TotalCredit, total.TotalDebit)
+		if err != nil {
+			return V1Domains.MonthlyStatementDomain{}, err
+		}
+	}
+
+	result = statementRecord.ToV1Domain()
+	result.Totals = records.ToArrayOfStatementTotalV1Domain(&totalRecords)
+
+	return result, nil
+}
+
+func (r *postgreStatementRepository) MarkEmailed(ctx context.Context, statementId string) (err error) {
+	_, err = r.conn.ExecContext(ctx, `UPDATE statements SET emailed_at = CURRENT_TIMESTAMP WHERE statement_id = $1`, statementId)
+
+	return
+}
+
+func (r *postgreStatementRepository) GetByUserId(ctx context.Context, userId string) (results []V1Domains.MonthlyStatementDomain, err error) {
+	query := `
+		SELECT statement_id, user_id, wallet_id, period, timezone, period_start, period_end, opening_balance, closing_balance, emailed_at, created_at
+		FROM statements
+		WHERE user_id = $1
+		ORDER BY period DESC
+		LIMIT $2
+	`
+
+	var statementRecords []records.MonthlyStatement
+	err = r.conn.SelectContext(ctx, &statementRecords, query, userId, constants.StatementListLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	results = make([]V1Domains.MonthlyStatementDomain, 0, len(statementRecords))
+	for _, statement := range statementRecords {
+		results = append(results, statement.ToV1Domain())
+	}
+
+	return results, nil
+}
+
+func (r *postgreStatementRepository) GetById(ctx context.Context, statementId string, userId string) (result V1Domains.MonthlyStatementDomain, err error) {
+	query := `
+		SELECT statement_id, user_id, wallet_id, period, timezone, period_start, period_end, opening_balance, closing_balance, emailed_at, created_at
+		FROM statements
+		WHERE statement_id = $1 AND user_id = $2
+	`
+
+	var statementRecord records.MonthlyStatement
+	err = r.conn.GetContext(ctx, &statementRecord, query, statementId, userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = ErrStatementNotFound
+		}
+		return V1Domains.MonthlyStatementDomain{}, err
+	}
+
+	queryGetTotals := `
+		SELECT statement_id, transaction_type, transaction_count, total_credit, total_debit
+		FROM statement_totals
+		WHERE statement_id = $1
+		ORDER BY transaction_type
+	`
+	var totalRecords []records.StatementTotal
+	err = r.conn.SelectContext(ctx, &totalRecords, queryGetTotals, statementId)
+	if err != nil {
+		return V1Domains.MonthlyStatementDomain{}, err
+	}
+
+	result = statementRecord.ToV1Domain()
+	result.Totals = records.ToArrayOfStatementTotalV1Domain(&totalRecords)
+
+	return result, nil
+}
@@ -3,97 +3,183 @@ package v1
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/jmoiron/sqlx"
 	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
 	"github.com/snykk/transaction-api/internal/constants"
 	"github.com/snykk/transaction-api/internal/datasources/records"
+	"github.com/snykk/transaction-api/pkg/money"
 )
 
 type postgreTransactionRepository struct {
-	conn *sqlx.DB
+	conn       *sqlx.DB
+	txExecutor *TxExecutor
+	limits     limitChecker
 }
 
-func NewTransactionRepository(conn *sqlx.DB) V1Domains.TransactionRepository {
+// defaultLimits adalah batas transaksi global yang berlaku jika user tidak punya override
+func NewTransactionRepository(conn *sqlx.DB, defaultLimits V1Domains.UserLimitDomain) V1Domains.TransactionRepository {
 	return &postgreTransactionRepository{
-		conn: conn,
+		conn:       conn,
+		txExecutor: NewTxExecutor(conn, DefaultTxRetryPolicy),
+		limits:     limitChecker{defaults: defaultLimits},
 	}
 }
 
-func (r *postgreTransactionRepository) GetByUserId(ctx context.Context, userId string) ([]V1Domains.TransactionDomain, error) {
-	query := `
+func (r *postgreTransactionRepository) GetByUserId(ctx context.Context, userId string, filter V1Domains.TransactionFilterDomain) (V1Domains.TransactionPageDomain, error) {
+	filter.UserId = userId
+	return r.selectTransactionPage(ctx, filter)
+}
+
+func (r *postgreTransactionRepository) GetAll(ctx context.Context, filter V1Domains.TransactionFilterDomain) (V1Domains.TransactionPageDomain, error) {
+	return r.selectTransactionPage(ctx, filter)
+}
+
+// selectTransactionPage menyusun query daftar transaksi dari filter lalu mengambil satu baris
+// lebih dari limit untuk mengetahui apakah masih ada halaman berikutnya.
+func (r *postgreTransactionRepository) selectTransactionPage(ctx context.Context, filter V1Domains.TransactionFilterDomain) (V1Domains.TransactionPageDomain, error) {
+	var conditions []string
+	var args []interface{}
+	addCondition := func(format string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(format, len(args)))
+	}
+
+	if filter.UserId != "" {
+		addCondition("w.user_id = $%d", filter.UserId)
+	}
+	if filter.TransactionType != "" {
+		addCondition("t.transaction_type = $%d", filter.TransactionType)
+	}
+	if filter.From != nil {
+		addCondition("t.created_at >= $%d", *filter.From)
+	}
+	if filter.To != nil {
+		addCondition("t.created_at <= $%d", *filter.To)
+	}
+	if filter.MinAmount != nil {
+		addCondition("t.amount >= $%d", *filter.MinAmount)
+	}
+	if filter.MaxAmount != nil {
+		addCondition("t.amount <= $%d", *filter.MaxAmount)
+	}
+	if filter.ProductId != nil {
+		addCondition("t.product_id = $%d", *filter.ProductId)
+	}
+	if filter.Reference != "" {
+		addCondition("t.external_reference = $%d", filter.Reference)
+	}
+
+	// Arah perbandingan cursor mengikuti urutan halaman
+	order, comparator := "DESC", "<"
+	if filter.Sort == constants.TransactionSortAsc {
+		order, comparator = "ASC", ">"
+	}
+	if filter.Cursor != nil {
+		args = append(args, filter.Cursor.CreatedAt, filter.Cursor.Id)
+		conditions = append(conditions, fmt.Sprintf("(t.created_at, t.transaction_id) %s ($%d::timestamp, $%d::uuid)", comparator, len(args)-1, len(args)))
+	}
+
+	whereClause := ""
+	if len(conditions) > 0 {
+		whereClause = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	args = append(args, filter.Limit+1)
+	query := fmt.Sprintf(`
 		SELECT 
 			t.transaction_id,
 			t.wallet_id,
+			t.product_id,
 			t.amount,
+			t.discount,
+			t.voucher_code,
+			t.points_redeemed,
+			t.points_earned,
+			t.cashback,
+			t.clawback,
+			t.fee,
+			t.fee_breakdown,
+			t.quantity,
+			t.product_name,
+			t.unit_price,
 			t.transaction_type,
+			t.related_transaction_id,
+			t.status,
+			t.failure_reason,
+			t.completed_at,
+			t.failed_at,
+			t.cancelled_at,
+			t.description,
+			t.external_reference,
+			t.metadata,
 			t.created_at
 		FROM 
 			transactions t
 		JOIN 
 			wallets w ON t.wallet_id = w.wallet_id
-		WHERE 
-			w.user_id = $1
+		%s
 		ORDER BY 
-			t.created_at DESC
-	`
-
-	fmt.Println(userId)
+			t.created_at %s, t.transaction_id %s
+		LIMIT $%d
+	`, whereClause, order, order, len(args))
 
 	var transactions []records.Transaction
-	err := r.conn.SelectContext(ctx, &transactions, query, userId)
+	err := r.conn.SelectContext(ctx, &transactions, query, args...)
 	if err != nil {
-		return nil, err
+		return V1Domains.TransactionPageDomain{}, err
+	}
+
+	page := V1Domains.TransactionPageDomain{}
+	if len(transactions) > filter.Limit {
+		transactions = transactions[:filter.Limit]
+		last := transactions[len(transactions)-1]
+		page.NextCursor = &V1Domains.TransactionCursor{CreatedAt: last.CreatedAt, Id: last.Id}
 	}
+	page.Transactions = records.ToArrayOfTransactionV1Domain(&transactions)
 
-	return records.ToArrayOfTransactionV1Domain(&transactions), nil
+	return page, nil
 }
 
-func (r *postgreTransactionRepository) GetAll(ctx context.Context) ([]V1Domains.TransactionDomain, error) {
-	query := `SELECT transaction_id, wallet_id, amount, transaction_type, stock, created_at FROM transactionsss`
-	var transactionRecord []records.Transaction
-	err := r.conn.SelectContext(ctx, &transactionRecord, query)
-	if err != nil {
-		return nil, err
-	}
+func (r *postgreTransactionRepository) Deposit(ctx context.Context, transactionDom V1Domains.TransactionDomain) (result V1Domains.TransactionDomain, err error) {
+	_, err = r.txExecutor.RunSerializable(ctx, "deposit", func(tx *sqlx.Tx) (err error) {
+		result, err = executeDeposit(ctx, tx, r.limits, transactionDom)
+		if err != nil {
+			return err
+		}
 
-	return records.ToArrayOfTransactionV1Domain(&transactionRecord), nil
+		// event ditulis di transaksi yang sama sehingga ikut batal jika transaksi gagal
+		return recordTransactionEvent(ctx, tx, constants.EventTransactionDepositCompleted, transactionDom.Wallet.UserId, result)
+	})
+
+	return result, err
 }
 
-func (r *postgreTransactionRepository) Deposit(ctx context.Context, transactionDom V1Domains.TransactionDomain) (V1Domains.TransactionDomain, error) {
-	// Mulai transaksi database
-	txOptions := &sql.TxOptions{
-		Isolation: sql.LevelSerializable, // Tingkat isolasi tertinggi
-		ReadOnly:  false,                 // Transaksi diperbolehkan melakukan perubahan data
+// executeDeposit menambah saldo wallet user di dalam transaksi database milik pemanggil
+func executeDeposit(ctx context.Context, tx *sqlx.Tx, limits limitChecker, transactionDom V1Domains.TransactionDomain) (result V1Domains.TransactionDomain, err error) {
+	// Ambil wallet user yang dipilih, atau wallet default jika wallet_id tidak dikirim
+	wallet, err := getUserWallet(ctx, tx, transactionDom.Wallet.UserId, transactionDom.WalletId)
+	if err != nil {
+		return V1Domains.TransactionDomain{}, err
 	}
 
-	tx, err := r.conn.BeginTxx(ctx, txOptions)
+	// Wallet yang dibekukan untuk dana masuk tidak boleh menerima deposit
+	err = ensureIncomingAllowed(wallet)
 	if err != nil {
 		return V1Domains.TransactionDomain{}, err
 	}
 
-	// Pastikan transaksi di-rollback jika terjadi error atau panic
-	defer func() {
-		if p := recover(); p != nil {
-			tx.Rollback()
-			panic(p) // Panic diteruskan setelah rollback
-		} else if err != nil {
-			tx.Rollback()
-		} else {
-			err = tx.Commit()
-		}
-	}()
+	// Pastikan user belum melampaui limit transaksinya
+	err = limits.check(ctx, tx, wallet, constants.TransactionTypeDeposit, transactionDom.Amount)
+	if err != nil {
+		return V1Domains.TransactionDomain{}, err
+	}
 
-	// Ambil data wallet berdasarkan userID
-	queryGetWallet := `
-		SELECT wallet_id, user_id, balance, created_at, updated_at
-		FROM wallets
-		WHERE user_id = $1
-	`
-	var wallet records.Wallet
-	err = tx.GetContext(ctx, &wallet, queryGetWallet, transactionDom.Wallet.UserId)
+	err = ensureReferenceAvailable(ctx, tx, wallet.UserId, transactionDom.ExternalReference)
 	if err != nil {
 		return V1Domains.TransactionDomain{}, err
 	}
@@ -114,12 +200,25 @@ func (r *postgreTransactionRepository) Deposit(ctx context.Context, transactionD
 	// Buat transaksi baru dan dapatkan semua data transaksi yang dihasilkan oleh database
 	var newTransaction records.Transaction
 	queryCreateTransaction := `
-		INSERT INTO transactions (transaction_id, wallet_id, amount, transaction_type, created_at)
-		VALUES (uuid_generate_v4(), $1, $2, $3, $4)
-		RETURNING transaction_id, wallet_id, amount, transaction_type, created_at
+		INSERT INTO transactions (transaction_id, wallet_id, amount, transaction_type, status, completed_at, created_at, description, external_reference, metadata)
+		VALUES (uuid_generate_v4(), $1, $2, $3, $4, $5, $5, $6, $7, $8)
+		RETURNING transaction_id, wallet_id, amount, transaction_type, status, completed_at, created_at, description, external_reference, metadata
 	`
-	err = tx.GetContext(ctx, &newTransaction, queryCreateTransaction, wallet.Id, transactionDom.Amount, constants.TransactionTypeDeposit, time.Now())
+	err = tx.GetContext(ctx, &newTransaction, queryCreateTransaction, wallet.Id, transactionDom.Amount, constants.TransactionTypeDeposit, constants.TransactionStatusCompleted, time.Now(),
+		transactionDom.Description, transactionDom.ExternalReference, records.Metadata(transactionDom.Metadata))
+
+	if err != nil {
+		return V1Domains.TransactionDomain{}, err
+	}
+
+	// Posting ledger: kas sistem didebit, wallet user dikredit
+	err = postLedgerEntries(ctx, tx, newTransaction.Id, debitSystem(constants.LedgerAccountSystemCash, transactionDom.Amount), creditWallet(wallet.Id, transactionDom.Amount))
+	if err != nil {
+		return V1Domains.TransactionDomain{}, err
+	}
 
+	// Pastikan saldo wallet tetap sesuai dengan ledger
+	err = verifyWalletAgainstLedger(ctx, tx, wallet.Id)
 	if err != nil {
 		return V1Domains.TransactionDomain{}, err
 	}
@@ -127,49 +226,53 @@ func (r *postgreTransactionRepository) Deposit(ctx context.Context, transactionD
 	return newTransaction.ToV1Domain(), nil
 }
 
-func (r *postgreTransactionRepository) Withdraw(ctx context.Context, transactionDom V1Domains.TransactionDomain) (V1Domains.TransactionDomain, error) {
-	// Mulai transaksi database
-	txOptions := &sql.TxOptions{
-		Isolation: sql.LevelSerializable, // Tingkat isolasi tertinggi
-		ReadOnly:  false,                 // Transaksi diperbolehkan melakukan perubahan data
+func (r *postgreTransactionRepository) Withdraw(ctx context.Context, transactionDom V1Domains.TransactionDomain) (result V1Domains.TransactionDomain, err error) {
+	_, err = r.txExecutor.RunSerializable(ctx, "withdraw", func(tx *sqlx.Tx) (err error) {
+		result, err = executeWithdraw(ctx, tx, r.limits, transactionDom)
+		if err != nil {
+			return err
+		}
+
+		// event ditulis di transaksi yang sama sehingga ikut batal jika transaksi gagal
+		return recordTransactionEvent(ctx, tx, constants.EventTransactionWithdrawRequested, transactionDom.Wallet.UserId, result)
+	})
+
+	return result, err
+}
+
+// executeWithdraw mengurangi saldo wallet user dan menahan dananya sampai withdraw diproses
+func executeWithdraw(ctx context.Context, tx *sqlx.Tx, limits limitChecker, transactionDom V1Domains.TransactionDomain) (result V1Domains.TransactionDomain, err error) {
+	// Ambil wallet user yang dipilih, atau wallet default jika wallet_id tidak dikirim
+	wallet, err := getUserWallet(ctx, tx, transactionDom.Wallet.UserId, transactionDom.WalletId)
+	if err != nil {
+		return V1Domains.TransactionDomain{}, err
 	}
 
-	tx, err := r.conn.BeginTxx(ctx, txOptions)
+	// Wallet yang dibekukan untuk dana keluar tidak boleh melakukan withdraw
+	err = ensureOutgoingAllowed(wallet)
 	if err != nil {
 		return V1Domains.TransactionDomain{}, err
 	}
 
-	// Pastikan transaksi di-rollback jika terjadi error atau panic
-	defer func() {
-		if p := recover(); p != nil {
-			tx.Rollback()
-			panic(p) // Panic diteruskan setelah rollback
-		} else if err != nil {
-			tx.Rollback()
-		} else {
-			err = tx.Commit()
-		}
-	}()
+	// Cek apakah saldo tersedia (di luar dana yang ditahan hold) mencukupi untuk withdraw beserta fee-nya
+	totalDebit := transactionDom.Amount + transactionDom.Fee
+	if wallet.AvailableBalance() < totalDebit {
+		return V1Domains.TransactionDomain{}, ErrInsufficientBalance
+	}
 
-	// Ambil data wallet berdasarkan userID
-	queryGetWallet := `
-		SELECT wallet_id, user_id, balance, created_at, updated_at
-		FROM wallets
-		WHERE user_id = $1
-	`
-	var wallet records.Wallet
-	err = tx.GetContext(ctx, &wallet, queryGetWallet, transactionDom.Wallet.UserId)
+	// Pastikan withdraw tidak melampaui limit user
+	err = limits.check(ctx, tx, wallet, constants.TransactionTypeWithdraw, transactionDom.Amount)
 	if err != nil {
 		return V1Domains.TransactionDomain{}, err
 	}
 
-	// Cek apakah saldo mencukupi untuk withdraw dan tidak akan menyebabkan balance menjadi negatif
-	if wallet.Balance < transactionDom.Amount {
-		return V1Domains.TransactionDomain{}, ErrInsufficientBalance
+	err = ensureReferenceAvailable(ctx, tx, wallet.UserId, transactionDom.ExternalReference)
+	if err != nil {
+		return V1Domains.TransactionDomain{}, err
 	}
 
 	// Hitung saldo baru setelah withdraw
-	newBalance := wallet.Balance - transactionDom.Amount
+	newBalance := wallet.Balance - totalDebit
 
 	// Update saldo wallet
 	queryUpdateBalance := `
@@ -184,11 +287,29 @@ func (r *postgreTransactionRepository) Withdraw(ctx context.Context, transaction
 	// Buat transaksi baru dan dapatkan semua data transaksi yang dihasilkan oleh database
 	var newTransaction records.Transaction
 	queryCreateTransaction := `
-		INSERT INTO transactions (transaction_id, wallet_id, amount, transaction_type, created_at)
-		VALUES (uuid_generate_v4(), $1, $2, $3, $4)
-		RETURNING transaction_id, wallet_id, amount, transaction_type, created_at
+		INSERT INTO transactions (transaction_id, wallet_id, amount, fee, fee_breakdown, transaction_type, status, created_at, description, external_reference, metadata)
+		VALUES (uuid_generate_v4(), $1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING transaction_id, wallet_id, amount, fee, fee_breakdown, transaction_type, status, created_at, description, external_reference, metadata
 	`
-	err = tx.GetContext(ctx, &newTransaction, queryCreateTransaction, wallet.Id, transactionDom.Amount, constants.TransactionTypeWithdraw, time.Now())
+	err = tx.GetContext(ctx, &newTransaction, queryCreateTransaction, wallet.Id, transactionDom.Amount, transactionDom.Fee, records.FromFeeBreakdownV1Domain(transactionDom.FeeBreakdown), constants.TransactionTypeWithdraw, constants.TransactionStatusPending, time.Now(),
+		transactionDom.Description, transactionDom.ExternalReference, records.Metadata(transactionDom.Metadata))
+	if err != nil {
+		return V1Domains.TransactionDomain{}, err
+	}
+
+	// Posting ledger: wallet user didebit, dana ditahan di akun kliring sampai withdraw diproses
+	// dan fee langsung masuk ke akun fee
+	postings := []ledgerPosting{debitWallet(wallet.Id, totalDebit), creditSystem(constants.LedgerAccountSystemClearing, transactionDom.Amount)}
+	if transactionDom.Fee.IsPositive() {
+		postings = append(postings, creditSystem(constants.LedgerAccountSystemFee, transactionDom.Fee))
+	}
+	err = postLedgerEntries(ctx, tx, newTransaction.Id, postings...)
+	if err != nil {
+		return V1Domains.TransactionDomain{}, err
+	}
+
+	// Pastikan saldo wallet tetap sesuai dengan ledger
+	err = verifyWalletAgainstLedger(ctx, tx, wallet.Id)
 	if err != nil {
 		return V1Domains.TransactionDomain{}, err
 	}
@@ -196,101 +317,758 @@ func (r *postgreTransactionRepository) Withdraw(ctx context.Context, transaction
 	return newTransaction.ToV1Domain(), nil
 }
 
-func (r *postgreTransactionRepository) Purchase(ctx context.Context, trasanctionDom V1Domains.TransactionDomain) (V1Domains.TransactionDomain, error) {
-	// Mulai transaksi database
-	txOptions := &sql.TxOptions{
-		Isolation: sql.LevelSerializable, // Tingkat isolasi tertinggi
-		ReadOnly:  false,                 // Transaksi diperbolehkan melakukan perubahan data
+func (r *postgreTransactionRepository) Purchase(ctx context.Context, trasanctionDom V1Domains.TransactionDomain) (result V1Domains.TransactionDomain, err error) {
+	_, err = r.txExecutor.RunSerializable(ctx, "purchase", func(tx *sqlx.Tx) (err error) {
+		result, err = executePurchase(ctx, tx, r.limits, trasanctionDom)
+		if err != nil {
+			return err
+		}
+
+		// event ditulis di transaksi yang sama sehingga ikut batal jika transaksi gagal
+		return recordTransactionEvent(ctx, tx, constants.EventTransactionPurchaseCompleted, trasanctionDom.Wallet.UserId, result)
+	})
+
+	return result, err
+}
+
+// executePurchase membeli satu produk dengan saldo wallet user, dicatat sebagai order dengan satu item
+func executePurchase(ctx context.Context, tx *sqlx.Tx, limits limitChecker, trasanctionDom V1Domains.TransactionDomain) (result V1Domains.TransactionDomain, err error) {
+	order, err := executeOrder(ctx, tx, limits, V1Domains.OrderDomain{
+		UserId:   trasanctionDom.Wallet.UserId,
+		WalletId: trasanctionDom.WalletId,
+		Items: []V1Domains.OrderItemDomain{{
+			ProductId:   trasanctionDom.ProductId,
+			Quantity:    *trasanctionDom.Quantity,
+			UnitPrice:   trasanctionDom.Product.Price,
+			PriceLocked: trasanctionDom.PriceLocked,
+		}},
+		VoucherCode:       trasanctionDom.VoucherCode,
+		PointsRedeemed:    trasanctionDom.PointsRedeemed,
+		Fee:               trasanctionDom.Fee,
+		FeeBreakdown:      trasanctionDom.FeeBreakdown,
+		Description:       trasanctionDom.Description,
+		ExternalReference: trasanctionDom.ExternalReference,
+		Metadata:          trasanctionDom.Metadata,
+	})
+	if err != nil {
+		return V1Domains.TransactionDomain{}, err
 	}
 
-	tx, err := r.conn.BeginTxx(ctx, txOptions)
+	return order.Transaction, nil
+}
+
+func (r *postgreTransactionRepository) Transfer(ctx context.Context, transferDom V1Domains.TransferDomain) (result V1Domains.TransferDomain, err error) {
+	_, err = r.txExecutor.RunSerializable(ctx, "transfer", func(tx *sqlx.Tx) (err error) {
+		result, err = executeTransfer(ctx, tx, r.limits, transferDom)
+		return err
+	})
+
+	return result, err
+}
+
+// ensureReferenceAvailable menolak external reference yang sudah dipakai user di wallet mana pun.
+// Pengecekan berjalan di dalam transaksi serializable, sehingga dua request bersamaan dengan reference
+// yang sama berakhir dengan serialization failure dan percobaan ulangnya melihat reference yang sudah tersimpan.
+func ensureReferenceAvailable(ctx context.Context, tx *sqlx.Tx, userId string, reference *string) error {
+	if reference == nil {
+		return nil
+	}
+
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM transactions t
+			JOIN wallets w ON w.wallet_id = t.wallet_id
+			WHERE t.external_reference = $1 AND w.user_id = $2
+		)
+	`
+	var taken bool
+	err := tx.GetContext(ctx, &taken, query, *reference, userId)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return ErrExternalReferenceTaken
+	}
+
+	return nil
+}
+
+// findRecipientUserId mencari user penerima berdasarkan user id, username, atau email
+// dan memastikan penerima bukan pengirim itu sendiri
+func findRecipientUserId(ctx context.Context, q sqlx.QueryerContext, senderUserId, userId, username, email string) (recipientUserId string, err error) {
+	switch {
+	case userId != "":
+		err = sqlx.GetContext(ctx, q, &recipientUserId, `SELECT user_id FROM users WHERE user_id = $1`, userId)
+	case username != "":
+		err = sqlx.GetContext(ctx, q, &recipientUserId, `SELECT user_id FROM users WHERE username = $1`, username)
+	default:
+		err = sqlx.GetContext(ctx, q, &recipientUserId, `SELECT user_id FROM users WHERE email = $1`, email)
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", ErrRecipientNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if recipientUserId == senderUserId {
+		return "", ErrSelfTransfer
+	}
+
+	return recipientUserId, nil
+}
+
+// executeTransfer memindahkan dana antar wallet di dalam transaksi database milik pemanggil,
+// dipakai oleh transfer langsung maupun transfer terjadwal
+func executeTransfer(ctx context.Context, tx *sqlx.Tx, limits limitChecker, transferDom V1Domains.TransferDomain) (result V1Domains.TransferDomain, err error) {
+	// Pindah dana antar pocket milik sendiri tidak perlu mencari user penerima
+	recipientUserId := transferDom.SenderUserId
+	if transferDom.RecipientWalletId == "" {
+		recipientUserId, err = findRecipientUserId(ctx, tx, transferDom.SenderUserId, transferDom.RecipientUserId, transferDom.RecipientUsername, transferDom.RecipientEmail)
+		if err != nil {
+			return V1Domains.TransferDomain{}, err
+		}
+	}
+
+	// Ambil wallet_id pengirim dan penerima tanpa lock terlebih dahulu,
+	// transfer ke user lain selalu masuk ke wallet default penerima
+	queryGetWalletId := `SELECT wallet_id FROM wallets WHERE ` + userWalletCondition
+	var senderWalletId, recipientWalletId string
+	err = tx.GetContext(ctx, &senderWalletId, queryGetWalletId, transferDom.SenderUserId, transferDom.SenderWalletId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return V1Domains.TransferDomain{}, ErrWalletNotFound
+	}
+	if err != nil {
+		return V1Domains.TransferDomain{}, err
+	}
+	err = tx.GetContext(ctx, &recipientWalletId, queryGetWalletId, recipientUserId, transferDom.RecipientWalletId)
+	if errors.Is(err, sql.ErrNoRows) {
+		if transferDom.RecipientWalletId != "" {
+			return V1Domains.TransferDomain{}, ErrWalletNotFound
+		}
+		return V1Domains.TransferDomain{}, ErrRecipientWalletNotFound
+	}
+	if err != nil {
+		return V1Domains.TransferDomain{}, err
+	}
+	if senderWalletId == recipientWalletId {
+		return V1Domains.TransferDomain{}, ErrSameWalletTransfer
+	}
+
+	// Lock kedua wallet dengan urutan wallet_id yang selalu sama agar transfer
+	// dua arah yang berjalan bersamaan tidak saling menunggu (deadlock)
+	queryLockWallets := `
+		SELECT ` + walletColumns + `
+		FROM wallets
+		WHERE wallet_id IN ($1, $2)
+		ORDER BY wallet_id
+		FOR UPDATE
+	`
+	var lockedWallets []records.Wallet
+	err = tx.SelectContext(ctx, &lockedWallets, queryLockWallets, senderWalletId, recipientWalletId)
+	if err != nil {
+		return V1Domains.TransferDomain{}, err
+	}
+
+	var senderWallet, recipientWallet records.Wallet
+	for _, wallet := range lockedWallets {
+		switch wallet.Id {
+		case senderWalletId:
+			senderWallet = wallet
+		case recipientWalletId:
+			recipientWallet = wallet
+		}
+	}
+	if senderWallet.Id == "" || recipientWallet.Id == "" {
+		return V1Domains.TransferDomain{}, sql.ErrNoRows
+	}
+
+	// Pengirim yang dibekukan terkunci, sedangkan penerima yang dibekukan membuat transfer ditolak
+	err = ensureOutgoingAllowed(senderWallet)
+	if err != nil {
+		return V1Domains.TransferDomain{}, err
+	}
+	if recipientWallet.BlocksIncoming() {
+		return V1Domains.TransferDomain{}, ErrRecipientWalletFrozen
+	}
+
+	// Cek apakah saldo tersedia pengirim mencukupi
+	if senderWallet.AvailableBalance() < transferDom.Amount {
+		return V1Domains.TransferDomain{}, ErrInsufficientBalance
+	}
+
+	// Pastikan pengirim belum melampaui limit transaksinya
+	err = limits.check(ctx, tx, senderWallet, constants.TransactionTypeTransferOut, transferDom.Amount)
+	if err != nil {
+		return V1Domains.TransferDomain{}, err
+	}
+
+	err = ensureReferenceAvailable(ctx, tx, transferDom.SenderUserId, transferDom.ExternalReference)
+	if err != nil {
+		return V1Domains.TransferDomain{}, err
+	}
+
+	// Debit wallet pengirim dan kredit wallet penerima
+	now := time.Now()
+	queryUpdateBalance := `
+		UPDATE wallets SET balance = $1, updated_at = $2
+		WHERE wallet_id = $3
+	`
+	_, err = tx.ExecContext(ctx, queryUpdateBalance, senderWallet.Balance-transferDom.Amount, now, senderWallet.Id)
+	if err != nil {
+		return V1Domains.TransferDomain{}, err
+	}
+	_, err = tx.ExecContext(ctx, queryUpdateBalance, recipientWallet.Balance+transferDom.Amount, now, recipientWallet.Id)
+	if err != nil {
+		return V1Domains.TransferDomain{}, err
+	}
+
+	// Catat dua transaksi yang saling terhubung: transfer_out untuk pengirim dan transfer_in untuk penerima.
+	// Memo terlihat di kedua sisi, reference dan metadata hanya milik pengirim.
+	queryCreateTransaction := `
+		INSERT INTO transactions (transaction_id, wallet_id, amount, transaction_type, related_transaction_id, status, completed_at, created_at, description, external_reference, metadata)
+		VALUES (uuid_generate_v4(), $1, $2, $3, $4, $5, $6, $6, $7, $8, $9)
+		RETURNING transaction_id, wallet_id, amount, transaction_type, related_transaction_id, status, completed_at, created_at, description, external_reference, metadata
+	`
+	var outgoing, incoming records.Transaction
+	err = tx.GetContext(ctx, &outgoing, queryCreateTransaction, senderWallet.Id, transferDom.Amount, constants.TransactionTypeTransferOut, nil, constants.TransactionStatusCompleted, now,
+		transferDom.Description, transferDom.ExternalReference, records.Metadata(transferDom.Metadata))
+	if err != nil {
+		return V1Domains.TransferDomain{}, err
+	}
+	err = tx.GetContext(ctx, &incoming, queryCreateTransaction, recipientWallet.Id, transferDom.Amount, constants.TransactionTypeTransferIn, outgoing.Id, constants.TransactionStatusCompleted, now,
+		transferDom.Description, nil, nil)
+	if err != nil {
+		return V1Domains.TransferDomain{}, err
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE transactions SET related_transaction_id = $1 WHERE transaction_id = $2`, incoming.Id, outgoing.Id)
+	if err != nil {
+		return V1Domains.TransferDomain{}, err
+	}
+	outgoing.RelatedTransactionId = &incoming.Id
+
+	// Posting ledger kedua sisi transfer pada transaksi transfer_out
+	err = postLedgerEntries(ctx, tx, outgoing.Id, debitWallet(senderWallet.Id, transferDom.Amount), creditWallet(recipientWallet.Id, transferDom.Amount))
+	if err != nil {
+		return V1Domains.TransferDomain{}, err
+	}
+
+	// Pastikan saldo kedua wallet tetap sesuai dengan ledger
+	err = verifyWalletAgainstLedger(ctx, tx, senderWallet.Id, recipientWallet.Id)
+	if err != nil {
+		return V1Domains.TransferDomain{}, err
+	}
+
+	senderWallet.Balance -= transferDom.Amount
+	recipientWallet.Balance += transferDom.Amount
+	outgoing.Wallet = senderWallet
+	incoming.Wallet = recipientWallet
+
+	result = transferDom
+	result.RecipientUserId = recipientUserId
+	result.Outgoing = outgoing.ToV1Domain()
+	result.Incoming = incoming.ToV1Domain()
+
+	// Event kedua sisi ditulis di sini agar transfer terjadwal juga tercatat di outbox
+	err = recordTransactionEvent(ctx, tx, constants.EventTransactionTransferSent, transferDom.SenderUserId, result.Outgoing)
+	if err != nil {
+		return V1Domains.TransferDomain{}, err
+	}
+	err = recordTransactionEvent(ctx, tx, constants.EventTransactionTransferReceived, recipientUserId, result.Incoming)
+	if err != nil {
+		return V1Domains.TransferDomain{}, err
+	}
+
+	return result, nil
+}
+
+func (r *postgreTransactionRepository) UpdateStatus(ctx context.Context, transactionId string, status string, reason string) (result V1Domains.TransactionDomain, err error) {
+	_, err = r.txExecutor.RunSerializable(ctx, "update_status", func(tx *sqlx.Tx) (err error) {
+		result, err = executeUpdateStatus(ctx, tx, transactionId, status, reason)
+		if err != nil {
+			return err
+		}
+
+		return recordTransactionEvent(ctx, tx, constants.EventTransactionStatusUpdated, result.Wallet.UserId, result)
+	})
+
+	return result, err
+}
+
+// executeUpdateStatus mengubah status transaksi dan menyelesaikan dana withdraw yang tertahan
+func executeUpdateStatus(ctx context.Context, tx *sqlx.Tx, transactionId string, status string, reason string) (result V1Domains.TransactionDomain, err error) {
+	// Lock baris transaksi agar status tidak diubah bersamaan
+	queryGetTransaction := `
+		SELECT transaction_id, wallet_id, amount, fee, transaction_type, status
+		FROM transactions
+		WHERE transaction_id = $1
+		FOR UPDATE
+	`
+	var current records.Transaction
+	err = tx.GetContext(ctx, &current, queryGetTransaction, transactionId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = ErrTransactionNotFound
+		}
+		return V1Domains.TransactionDomain{}, err
+	}
+
+	if !V1Domains.CanTransitionTransactionStatus(current.Status, status) {
+		return V1Domains.TransactionDomain{}, ErrInvalidStatusTransition
+	}
+
+	// Lock wallet pemilik transaksi, saldo bisa berubah jika withdraw dibatalkan
+	queryGetWallet := `
+		SELECT ` + walletColumns + `
+		FROM wallets
+		WHERE wallet_id = $1
+		FOR UPDATE
+	`
+	var wallet records.Wallet
+	err = tx.GetContext(ctx, &wallet, queryGetWallet, current.WalletId)
+	if err != nil {
+		return V1Domains.TransactionDomain{}, err
+	}
+
+	now := time.Now()
+	var failureReason *string
+	if reason != "" {
+		failureReason = &reason
+	}
+
+	queryUpdateStatus := `
+		UPDATE transactions
+		SET status = $1,
+			failure_reason = $2,
+			completed_at = CASE WHEN $1 = 'completed' THEN $3::timestamp ELSE completed_at END,
+			failed_at = CASE WHEN $1 = 'failed' THEN $3::timestamp ELSE failed_at END,
+			cancelled_at = CASE WHEN $1 = 'cancelled' THEN $3::timestamp ELSE cancelled_at END
+		WHERE transaction_id = $4
+		RETURNING transaction_id, wallet_id, product_id, amount, discount, voucher_code, points_redeemed, points_earned, cashback, fee, fee_breakdown, quantity, product_name, unit_price, transaction_type, related_transaction_id,
+			status, failure_reason, completed_at, failed_at, cancelled_at, description, external_reference, metadata, created_at
+	`
+	var updated records.Transaction
+	err = tx.GetContext(ctx, &updated, queryUpdateStatus, status, failureReason, now, current.Id)
 	if err != nil {
 		return V1Domains.TransactionDomain{}, err
 	}
 
-	// Pastikan transaksi di-rollback jika terjadi error atau panic
-	defer func() {
-		if p := recover(); p != nil {
-			tx.Rollback()
-			panic(p) // Panic diteruskan setelah rollback
-		} else if err != nil {
-			tx.Rollback()
-		} else {
-			err = tx.Commit()
+	// Withdraw yang pending menahan dana di akun kliring, selesaikan sesuai status barunya
+	if current.TransactionType == constants.TransactionTypeWithdraw {
+		switch status {
+		case constants.TransactionStatusCompleted:
+			// Dana tidak boleh dicairkan dari wallet yang dibekukan untuk dana keluar,
+			// withdraw tetap bisa digagalkan atau dibatalkan agar dananya kembali ke wallet
+			err = ensureOutgoingAllowed(wallet)
+			if err != nil {
+				return V1Domains.TransactionDomain{}, err
+			}
+
+			// dana benar-benar keluar: kliring didebit, kas sistem dikredit
+			err = postLedgerEntries(ctx, tx, current.Id, debitSystem(constants.LedgerAccountSystemClearing, current.Amount), creditSystem(constants.LedgerAccountSystemCash, current.Amount))
+			if err != nil {
+				return V1Domains.TransactionDomain{}, err
+			}
+		default:
+			// withdraw gagal atau dibatalkan: dana beserta fee-nya dikembalikan ke wallet
+			wallet.Balance += current.Amount + current.Fee
+			queryUpdateBalance := `
+				UPDATE wallets SET balance = $1, updated_at = $2
+				WHERE wallet_id = $3
+			`
+			_, err = tx.ExecContext(ctx, queryUpdateBalance, wallet.Balance, now, wallet.Id)
+			if err != nil {
+				return V1Domains.TransactionDomain{}, err
+			}
+
+			postings := []ledgerPosting{debitSystem(constants.LedgerAccountSystemClearing, current.Amount), creditWallet(wallet.Id, current.Amount+current.Fee)}
+			if current.Fee.IsPositive() {
+				postings = append(postings, debitSystem(constants.LedgerAccountSystemFee, current.Fee))
+			}
+			err = postLedgerEntries(ctx, tx, current.Id, postings...)
+			if err != nil {
+				return V1Domains.TransactionDomain{}, err
+			}
+
+			err = verifyWalletAgainstLedger(ctx, tx, wallet.Id)
+			if err != nil {
+				return V1Domains.TransactionDomain{}, err
+			}
+		}
+	}
+
+	updated.Wallet = wallet
+
+	return updated.ToV1Domain(), nil
+}
+
+func (r *postgreTransactionRepository) Refund(ctx context.Context, transactionId string, quantity int) (result V1Domains.TransactionDomain, err error) {
+	_, err = r.txExecutor.RunSerializable(ctx, "refund", func(tx *sqlx.Tx) (err error) {
+		result, err = executeRefund(ctx, tx, transactionId, quantity)
+		return err
+	})
+
+	return result, err
+}
+
+// executeRefund mengembalikan dana dan stock dari sebagian atau seluruh pembelian.
+// Poin yang dipakai dikembalikan, sedangkan cashback dan poin hasil pembelian ditarik kembali secara proporsional.
+func executeRefund(ctx context.Context, tx *sqlx.Tx, transactionId string, quantity int) (result V1Domains.TransactionDomain, err error) {
+	// Lock transaksi pembelian asal agar refund bersamaan diproses satu per satu
+	queryGetPurchase := `
+		SELECT transaction_id, wallet_id, product_id, amount, points_redeemed, points_earned, cashback, quantity, transaction_type, status, product_name, unit_price
+		FROM transactions
+		WHERE transaction_id = $1
+		FOR UPDATE
+	`
+	var purchase records.Transaction
+	err = tx.GetContext(ctx, &purchase, queryGetPurchase, transactionId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = ErrTransactionNotFound
 		}
-	}()
+		return V1Domains.TransactionDomain{}, err
+	}
+
+	if purchase.TransactionType != constants.TransactionTypePurchase || purchase.Status != constants.TransactionStatusCompleted {
+		return V1Domains.TransactionDomain{}, ErrTransactionNotRefundable
+	}
+
+	// Pembelian tanpa quantity adalah order dengan beberapa item, refund per quantity tidak berlaku
+	if purchase.Quantity == nil {
+		return V1Domains.TransactionDomain{}, ErrMultiItemOrderNotRefundable
+	}
+
+	// Produk sudah dihapus, stock tidak bisa dikembalikan
+	if purchase.ProductId == nil {
+		return V1Domains.TransactionDomain{}, ErrProductNotFound
+	}
+
+	// Hitung quantity, nominal, dan reward yang sudah pernah di-refund
+	queryGetRefunded := `
+		SELECT
+			COALESCE(SUM(quantity), 0) AS quantity,
+			COALESCE(SUM(amount), 0) AS amount,
+			COALESCE(SUM(points_redeemed), 0) AS points_redeemed,
+			COALESCE(SUM(points_earned), 0) AS points_earned,
+			COALESCE(SUM(cashback), 0) AS cashback
+		FROM transactions
+		WHERE related_transaction_id = $1 AND transaction_type = $2
+	`
+	var refunded struct {
+		Quantity       int         `db:"quantity"`
+		Amount         money.Money `db:"amount"`
+		PointsRedeemed money.Money `db:"points_redeemed"`
+		PointsEarned   money.Money `db:"points_earned"`
+		Cashback       money.Money `db:"cashback"`
+	}
+	err = tx.GetContext(ctx, &refunded, queryGetRefunded, purchase.Id, constants.TransactionTypeRefund)
+	if err != nil {
+		return V1Domains.TransactionDomain{}, err
+	}
+
+	remainingQuantity := *purchase.Quantity - refunded.Quantity
+	if remainingQuantity <= 0 {
+		return V1Domains.TransactionDomain{}, ErrTransactionAlreadyRefunded
+	}
+
+	// quantity 0 berarti refund penuh atas sisa pembelian
+	if quantity == 0 {
+		quantity = remainingQuantity
+	}
+	if quantity > remainingQuantity {
+		return V1Domains.TransactionDomain{}, ErrRefundQuantityExceeded
+	}
+
+	// Nominal refund dan reward mengikuti proporsi quantity, refund terakhir mengambil sisanya
+	// agar totalnya tidak pernah melebihi nilai pembelian karena pembulatan.
+	// Fee pembelian tidak ikut dikembalikan.
+	refundAmount := purchase.Amount.Share(quantity, remainingQuantity, *purchase.Quantity, refunded.Amount)
+	pointsRestored := purchase.PointsRedeemed.Share(quantity, remainingQuantity, *purchase.Quantity, refunded.PointsRedeemed)
+	pointsReversed := purchase.PointsEarned.Share(quantity, remainingQuantity, *purchase.Quantity, refunded.PointsEarned)
+	cashbackReversed := purchase.Cashback.Share(quantity, remainingQuantity, *purchase.Quantity, refunded.Cashback)
 
-	// Ambil data wallet berdasarkan userID
+	// Pembelian bernilai kecil dengan quantity besar bisa menghasilkan porsi 0 sen,
+	// ditolak di sini agar tidak menabrak CHECK (amount > 0) dan muncul sebagai 500
+	if !refundAmount.IsPositive() {
+		return V1Domains.TransactionDomain{}, ErrRefundAmountTooSmall
+	}
+
+	// Lock wallet pemilik pembelian
 	queryGetWallet := `
-		SELECT wallet_id, user_id, balance, created_at, updated_at
+		SELECT ` + walletColumns + `
 		FROM wallets
-		WHERE user_id = $1
+		WHERE wallet_id = $1
+		FOR UPDATE
 	`
 	var wallet records.Wallet
-	err = tx.GetContext(ctx, &wallet, queryGetWallet, trasanctionDom.Wallet.UserId)
+	err = tx.GetContext(ctx, &wallet, queryGetWallet, purchase.WalletId)
+	if err != nil {
+		return V1Domains.TransactionDomain{}, err
+	}
+
+	// Wallet yang dibekukan untuk dana masuk tidak boleh menerima refund
+	err = ensureIncomingAllowed(wallet)
 	if err != nil {
 		return V1Domains.TransactionDomain{}, err
 	}
 
-	// Ambil data produk berdasarkan productId untuk mendapatkan harga
-	queryGetProduct := `
-		SELECT product_id, price, stock
-		FROM products
-		WHERE product_id = $1
+	// Kembalikan stock produk
+	now := time.Now()
+	queryRestoreProductStock := `
+		UPDATE products SET stock = stock + $1, updated_at = $2
+		WHERE product_id = $3
 	`
-	var product records.Product
-	err = tx.GetContext(ctx, &product, queryGetProduct, trasanctionDom.ProductId)
+	res, err := tx.ExecContext(ctx, queryRestoreProductStock, quantity, now, *purchase.ProductId)
 	if err != nil {
+		return V1Domains.TransactionDomain{}, err
+	}
+	if affected, _ := res.RowsAffected(); affected == 0 {
 		return V1Domains.TransactionDomain{}, ErrProductNotFound
 	}
 
-	// Validasi apakah stock cukup
-	if product.Stock < *trasanctionDom.Quantity {
-		return V1Domains.TransactionDomain{}, ErrInsufficientProductStock
+	// Refund penuh melepas voucher pembelian. Refund sebagian tetap menghitung voucher sebagai terpakai,
+	// diskonnya ikut terbagi proporsional karena nominal refund dihitung dari nominal setelah diskon.
+	if quantity == remainingQuantity {
+		err = releaseVoucherRedemption(ctx, tx, purchase.Id, now)
+		if err != nil {
+			return V1Domains.TransactionDomain{}, err
+		}
 	}
 
-	// Hitung total price berdasarkan quantity
-	totalPrice := product.Price * float64(*trasanctionDom.Quantity)
+	// Buat transaksi refund yang terhubung ke pembelian asal, kolom reward berisi bagian yang dikembalikan atau ditarik
+	var refundTransaction records.Transaction
+	queryCreateTransaction := `
+		INSERT INTO transactions (transaction_id, wallet_id, amount, points_redeemed, points_earned, cashback, transaction_type, related_transaction_id, status, completed_at, created_at,
+			product_id, quantity, product_name, unit_price)
+		VALUES (uuid_generate_v4(), $1, $2, $3, $4, $5, $6, $7, $8, $9, $9, $10, $11, $12, $13)
+		RETURNING transaction_id, wallet_id, amount, points_redeemed, points_earned, cashback, transaction_type, related_transaction_id, status, completed_at, created_at,
+			product_id, quantity, product_name, unit_price
+	`
+	err = tx.GetContext(ctx, &refundTransaction, queryCreateTransaction, wallet.Id, refundAmount, pointsRestored, pointsReversed, cashbackReversed, constants.TransactionTypeRefund, purchase.Id,
+		constants.TransactionStatusCompleted, now, purchase.ProductId, quantity, purchase.ProductName, purchase.UnitPrice)
+	if err != nil {
+		return V1Domains.TransactionDomain{}, err
+	}
 
-	// Validasi apakah saldo cukup untuk pembelian
-	if wallet.Balance < totalPrice {
-		return V1Domains.TransactionDomain{}, ErrInsufficientBalance
+	if pointsRestored.IsPositive() {
+		err = restorePoints(ctx, tx, wallet.Id, pointsRestored, refundTransaction.Id, now)
+		if err != nil {
+			return V1Domains.TransactionDomain{}, err
+		}
+		wallet.PointsBalance += pointsRestored
 	}
 
-	// Hitung saldo baru setelah pembelian
-	newBalance := wallet.Balance - totalPrice
+	// Poin hasil pembelian yang sudah terpakai atau dikonversi dipotong dari dana refund dengan nilai 1 poin = 1 satuan uang
+	var pointsShortfall money.Money
+	if pointsReversed.IsPositive() {
+		reversed, err := reversePoints(ctx, tx, wallet.Id, pointsReversed, purchase.Id, refundTransaction.Id, now)
+		if err != nil {
+			return V1Domains.TransactionDomain{}, err
+		}
+		pointsShortfall = pointsReversed - reversed
+		wallet.PointsBalance -= reversed
+	}
 
-	// Update saldo wallet
+	// Cashback dan kekurangan poin ditarik dari wallet, saldo tersedia tidak boleh menjadi negatif.
+	// Nilainya disimpan di transaksi refund agar rekonsiliasi menghitung saldo bertambah sebesar amount - clawback
+	clawback := cashbackReversed + pointsShortfall
+	if wallet.AvailableBalance()+refundAmount < clawback {
+		return V1Domains.TransactionDomain{}, ErrInsufficientBalance
+	}
+	if clawback.IsPositive() {
+		queryUpdateClawback := `UPDATE transactions SET clawback = $1 WHERE transaction_id = $2`
+		_, err = tx.ExecContext(ctx, queryUpdateClawback, clawback, refundTransaction.Id)
+		if err != nil {
+			return V1Domains.TransactionDomain{}, err
+		}
+		refundTransaction.Clawback = clawback
+	}
+
+	// Kembalikan dana ke wallet
+	newBalance := wallet.Balance + refundAmount - clawback
 	queryUpdateBalance := `
 		UPDATE wallets SET balance = $1, updated_at = $2
 		WHERE wallet_id = $3
 	`
-	_, err = tx.ExecContext(ctx, queryUpdateBalance, newBalance, time.Now(), wallet.Id)
+	_, err = tx.ExecContext(ctx, queryUpdateBalance, newBalance, now, wallet.Id)
 	if err != nil {
 		return V1Domains.TransactionDomain{}, err
 	}
+	wallet.Balance = newBalance
 
-	// Kurangi stock produk setelah pembelian
-	newStock := product.Stock - *trasanctionDom.Quantity
-	queryUpdateProductStock := `
-		UPDATE products SET stock = $1 WHERE product_id = $2
-	`
-	_, err = tx.ExecContext(ctx, queryUpdateProductStock, newStock, product.Id)
+	// Posting ledger: kebalikan dari pembelian, akun pendapatan didebit sebesar bagian yang dibayar dengan saldo dan poin,
+	// wallet dan akun reward dikredit. Cashback dan kekurangan poin mengalir kembali dari wallet ke akun reward.
+	postings := []ledgerPosting{debitSystem(constants.LedgerAccountSystemRevenue, refundAmount+pointsRestored), creditWallet(wallet.Id, refundAmount)}
+	if pointsRestored.IsPositive() {
+		postings = append(postings, creditSystem(constants.LedgerAccountSystemRewards, pointsRestored))
+	}
+	if clawback.IsPositive() {
+		postings = append(postings, debitWallet(wallet.Id, clawback), creditSystem(constants.LedgerAccountSystemRewards, clawback))
+	}
+	err = postLedgerEntries(ctx, tx, refundTransaction.Id, postings...)
 	if err != nil {
 		return V1Domains.TransactionDomain{}, err
 	}
 
-	// Buat transaksi baru untuk pembelian dan dapatkan semua data transaksi yang dihasilkan oleh database
-	var newTransaction records.Transaction
-	queryCreateTransaction := `
-		INSERT INTO transactions (transaction_id, wallet_id, amount, transaction_type, created_at, product_id, quantity)
-		VALUES (uuid_generate_v4(), $1, $2, $3, $4, $5, $6)
-		RETURNING transaction_id, wallet_id, amount, transaction_type, created_at, product_id, quantity
+	// Pastikan saldo wallet tetap sesuai dengan ledger
+	err = verifyWalletAgainstLedger(ctx, tx, wallet.Id)
+	if err != nil {
+		return V1Domains.TransactionDomain{}, err
+	}
+
+	refundTransaction.Wallet = wallet
+	result = refundTransaction.ToV1Domain()
+
+	err = recordTransactionEvent(ctx, tx, constants.EventTransactionRefundCompleted, wallet.UserId, result)
+	if err != nil {
+		return V1Domains.TransactionDomain{}, err
+	}
+
+	return result, nil
+}
+
+func (r *postgreTransactionRepository) GetById(ctx context.Context, transactionId string, userId string) (V1Domains.TransactionDomain, error) {
+	// userId kosong berarti admin yang boleh melihat transaksi siapa pun,
+	// transaksi milik user lain diperlakukan sama seperti tidak ada
+	query := `
+		SELECT
+			t.transaction_id,
+			t.wallet_id,
+			t.product_id,
+			t.amount,
+			t.discount,
+			t.voucher_code,
+			t.points_redeemed,
+			t.points_earned,
+			t.cashback,
+			t.clawback,
+			t.fee,
+			t.fee_breakdown,
+			t.quantity,
+			t.product_name,
+			t.unit_price,
+			t.transaction_type,
+			t.related_transaction_id,
+			t.status,
+			t.failure_reason,
+			t.completed_at,
+			t.failed_at,
+			t.cancelled_at,
+			t.description,
+			t.external_reference,
+			t.metadata,
+			t.created_at,
+			w.wallet_id AS "wallet.wallet_id",
+			w.user_id AS "wallet.user_id",
+			w.name AS "wallet.name",
+			w.is_default AS "wallet.is_default",
+			w.balance AS "wallet.balance",
+			w.held_amount AS "wallet.held_amount",
+			w.points_balance AS "wallet.points_balance",
+			w.status AS "wallet.status",
+			w.created_at AS "wallet.created_at",
+			w.updated_at AS "wallet.updated_at",
+			COALESCE(p.description, '') AS "product.description"
+		FROM
+			transactions t
+		JOIN
+			wallets w ON t.wallet_id = w.wallet_id
+		LEFT JOIN
+			products p ON t.product_id = p.product_id
+		WHERE
+			t.transaction_id = $1 AND ($2 = '' OR w.user_id::text = $2)
 	`
-	err = tx.GetContext(ctx, &newTransaction, queryCreateTransaction, wallet.Id, totalPrice, "purchase", time.Now(), trasanctionDom.ProductId, trasanctionDom.Quantity)
+
+	var transaction records.Transaction
+	err := r.conn.GetContext(ctx, &transaction, query, transactionId, userId)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = ErrTransactionNotFound
+		}
 		return V1Domains.TransactionDomain{}, err
 	}
 
-	return newTransaction.ToV1Domain(), nil
+	return transaction.ToV1Domain(), nil
+}
+
+func (r *postgreTransactionRepository) StreamStatement(ctx context.Context, statementDom V1Domains.StatementDomain, onOpening func(V1Domains.StatementDomain) error, onEntry func(V1Domains.StatementEntryDomain) error) (err error) {
+	// Saldo awal dan mutasi dibaca dari snapshot yang sama agar saldo berjalan konsisten
+	txOptions := &sql.TxOptions{
+		Isolation: sql.LevelRepeatableRead,
+		ReadOnly:  true,
+	}
+
+	tx, err := r.conn.BeginTxx(ctx, txOptions)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Saldo awal adalah akumulasi ledger wallet sebelum awal periode,
+	// statement dibuat untuk wallet yang dipilih atau wallet default jika WalletId kosong
+	queryGetOpening := `
+		SELECT
+			w.wallet_id,
+			COALESCE(SUM(CASE WHEN le.direction = 'credit' THEN le.amount ELSE -le.amount END), 0) AS opening_balance
+		FROM wallets w
+		LEFT JOIN ledger_entries le
+			ON le.account = 'wallet' AND le.wallet_id = w.wallet_id AND le.created_at < $3
+		WHERE w.user_id = $1 AND (w.wallet_id = NULLIF($2, '')::uuid OR ($2 = '' AND w.is_default))
+		GROUP BY w.wallet_id
+	`
+	var opening struct {
+		WalletId       string      `db:"wallet_id"`
+		OpeningBalance money.Money `db:"opening_balance"`
+	}
+	err = tx.GetContext(ctx, &opening, queryGetOpening, statementDom.UserId, statementDom.WalletId, statementDom.From)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = ErrWalletNotFound
+		}
+		return err
+	}
+
+	statementDom.WalletId = opening.WalletId
+	statementDom.OpeningBalance = opening.OpeningBalance
+	if err = onOpening(statementDom); err != nil {
+		return err
+	}
+
+	// Satu baris per posting ledger wallet, lihat walletLedgerEntriesSource untuk kaki transfer
+	queryGetEntries := `
+		SELECT
+			le.entry_id,
+			COALESCE(own.transaction_id, t.transaction_id) AS transaction_id,
+			COALESCE(own.transaction_type, t.transaction_type) AS transaction_type,
+			COALESCE(own.status, t.status) AS status,
+			t.product_name,
+			t.quantity,
+			le.direction,
+			le.amount,
+			le.created_at AS posted_at
+	` + walletLedgerEntriesSource + `
+		WHERE le.account = 'wallet' AND le.wallet_id = $1 AND le.created_at >= $2 AND le.created_at < $3
+		ORDER BY le.created_at, le.entry_id
+	`
+	rows, err := tx.QueryxContext(ctx, queryGetEntries, statementDom.WalletId, statementDom.From, statementDom.To)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	// Baris dibaca satu per satu dari koneksi, tidak ditampung seluruhnya di memori
+	for rows.Next() {
+		var entry records.StatementEntry
+		if err = rows.StructScan(&entry); err != nil {
+			return err
+		}
+		if err = onEntry(entry.ToV1Domain()); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
 }
@@ -0,0 +1,163 @@
+package v1
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+	"github.com/snykk/transaction-api/internal/constants"
+	"github.com/snykk/transaction-api/pkg/logger"
+)
+
+// TxRetryPolicy mengatur percobaan ulang transaksi yang dibatalkan database karena konflik
+type TxRetryPolicy struct {
+	MaxRetries int
+	BaseDelay  time.Duration // jeda percobaan ulang pertama, berlipat dua setiap percobaan berikutnya
+	MaxDelay   time.Duration
+}
+
+var DefaultTxRetryPolicy = TxRetryPolicy{
+	MaxRetries: constants.TxMaxRetries,
+	BaseDelay:  constants.TxRetryBaseDelay,
+	MaxDelay:   constants.TxRetryMaxDelay,
+}
+
+// TxExecutor menjalankan closure di dalam transaksi serializable dan mengulanginya dari awal
+// jika database membatalkan transaksi karena serialization failure (40001) atau deadlock (40P01).
+// Closure harus aman dijalankan ulang: semua perubahan dilakukan lewat tx yang diberikan.
+type TxExecutor struct {
+	conn   *sqlx.DB
+	policy TxRetryPolicy
+}
+
+func NewTxExecutor(conn *sqlx.DB, policy TxRetryPolicy) *TxExecutor {
+	return &TxExecutor{
+		conn:   conn,
+		policy: policy,
+	}
+}
+
+// RunSerializable mengembalikan jumlah percobaan ulang yang dilakukan, termasuk saat akhirnya tetap gagal.
+// operation hanya dipakai sebagai label log.
+func (e *TxExecutor) RunSerializable(ctx context.Context, operation string, fn func(tx *sqlx.Tx) error) (retries int, err error) {
+	fields := logrus.Fields{constants.LoggerCategory: constants.LoggerCategoryDatabase, "operation": operation}
+
+	for {
+		err = e.runOnce(ctx, fn)
+		if err == nil || !IsRetryableTxError(err) {
+			break
+		}
+		if retries >= e.policy.MaxRetries {
+			logger.ErrorF("transaction gave up after %d retries: %v", fields, retries, err)
+			return retries, fmt.Errorf("%w (gave up after %d retries)", err, retries)
+		}
+
+		delay := e.backoff(retries)
+		retries++
+		logger.InfoF("transaction conflict (%s), retry %d in %s", fields, SQLState(err), retries, delay)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return retries, ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	if err == nil && retries > 0 {
+		logger.InfoF("transaction committed after %d retries", fields, retries)
+	}
+
+	return retries, err
+}
+
+func (e *TxExecutor) runOnce(ctx context.Context, fn func(tx *sqlx.Tx) error) (err error) {
+	// Mulai transaksi database
+	txOptions := &sql.TxOptions{
+		Isolation: sql.LevelSerializable, // Tingkat isolasi tertinggi
+		ReadOnly:  false,                 // Transaksi diperbolehkan melakukan perubahan data
+	}
+
+	tx, err := e.conn.BeginTxx(ctx, txOptions)
+	if err != nil {
+		return err
+	}
+
+	// Pastikan transaksi di-rollback jika terjadi error atau panic
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p) // Panic diteruskan setelah rollback
+		} else if err != nil {
+			tx.Rollback()
+		} else {
+			// serialization failure juga bisa baru terdeteksi saat commit
+			err = tx.Commit()
+		}
+	}()
+
+	return fn(tx)
+}
+
+// backoff menghitung jeda sebelum percobaan ulang ke-(retry+1) dengan jitter
+// agar transaksi yang bentrok tidak mencoba ulang pada saat yang sama
+func (e *TxExecutor) backoff(retry int) time.Duration {
+	delay := e.policy.MaxDelay
+	if retry < 32 && e.policy.BaseDelay<<retry < e.policy.MaxDelay {
+		delay = e.policy.BaseDelay << retry
+	}
+	if delay <= 0 {
+		return 0
+	}
+
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// SQLState mengembalikan kode SQLSTATE dari error PostgreSQL, baik dari driver lib/pq maupun pgconn.
+// Mengembalikan string kosong jika err bukan error dari database.
+func SQLState(err error) string {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return string(pqErr.Code)
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code
+	}
+
+	return ""
+}
+
+// SQLConstraint mengembalikan nama constraint yang dilanggar, string kosong jika tidak ada
+func SQLConstraint(err error) string {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Constraint
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.ConstraintName
+	}
+
+	return ""
+}
+
+// IsRetryableTxError mengecek apakah transaksi dibatalkan karena konflik dan aman diulang
+func IsRetryableTxError(err error) bool {
+	switch SQLState(err) {
+	case constants.SQLStateSerializationFailure, constants.SQLStateDeadlockDetected:
+		return true
+	}
+
+	return false
+}
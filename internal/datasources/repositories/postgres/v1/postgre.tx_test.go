@@ -0,0 +1,71 @@
+package v1_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/lib/pq"
+	PostgresRepo "github.com/snykk/transaction-api/internal/datasources/repositories/postgres/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsRetryableTxError(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    error
+		expected bool
+	}{
+		{"Serialization Failure", &pq.Error{Code: "40001"}, true},
+		{"Deadlock Detected", &pq.Error{Code: "40P01"}, true},
+		{"Serialization Failure From Pgconn", &pgconn.PgError{Code: "40001"}, true},
+		{"Wrapped Serialization Failure", fmt.Errorf("create transaction: %w", &pq.Error{Code: "40001"}), true},
+		{"Double Wrapped Deadlock", fmt.Errorf("outer: %w", fmt.Errorf("inner: %w", &pgconn.PgError{Code: "40P01"})), true},
+		{"Unique Violation", &pq.Error{Code: "23505"}, false},
+		{"Check Violation", &pgconn.PgError{Code: "23514"}, false},
+		{"Other Transaction Rollback", &pq.Error{Code: "40002"}, false},
+		{"Not A Database Error", errors.New("40001"), false},
+		{"Nil", nil, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, PostgresRepo.IsRetryableTxError(test.input))
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	executor := PostgresRepo.NewTxExecutor(nil, PostgresRepo.TxRetryPolicy{MaxRetries: 3, BaseDelay: 10 * time.Millisecond, MaxDelay: 500 * time.Millisecond})
+
+	tests := []struct {
+		name  string
+		retry int
+		min   time.Duration
+		max   time.Duration
+	}{
+		{"First Retry", 0, 5 * time.Millisecond, 10 * time.Millisecond},
+		{"Doubles Each Retry", 1, 10 * time.Millisecond, 20 * time.Millisecond},
+		{"Below Cap", 5, 160 * time.Millisecond, 320 * time.Millisecond},
+		{"Capped", 6, 250 * time.Millisecond, 500 * time.Millisecond},
+		{"Capped Without Overflow", 64, 250 * time.Millisecond, 500 * time.Millisecond},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// jitter acak, jadi diambil beberapa sampel
+			for i := 0; i < 100; i++ {
+				delay := executor.Backoff(test.retry)
+
+				assert.GreaterOrEqual(t, delay, test.min)
+				assert.LessOrEqual(t, delay, test.max)
+			}
+		})
+	}
+
+	t.Run("Zero Policy", func(t *testing.T) {
+		assert.Equal(t, time.Duration(0), PostgresRepo.NewTxExecutor(nil, PostgresRepo.TxRetryPolicy{}).Backoff(2))
+	})
+}
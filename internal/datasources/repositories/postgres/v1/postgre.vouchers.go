@@ -0,0 +1,248 @@
+package v1
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/internal/datasources/records"
+	"github.com/snykk/transaction-api/pkg/money"
+)
+
+const voucherColumns = `
+	voucher_id, code, discount_type, amount, rate_bps, max_discount, min_spend, product_id, starts_at, ends_at,
+	max_redemptions, max_redemptions_per_user, redemption_count, is_active, created_at, updated_at
+`
+
+type postgreVoucherRepository struct {
+	conn *sqlx.DB
+}
+
+func NewVoucherRepository(conn *sqlx.DB) V1Domains.VoucherRepository {
+	return &postgreVoucherRepository{
+		conn: conn,
+	}
+}
+
+func (r *postgreVoucherRepository) GetAll(ctx context.Context) ([]V1Domains.VoucherDomain, error) {
+	query := `SELECT ` + voucherColumns + ` FROM vouchers ORDER BY voucher_id`
+
+	var vouchers []records.Voucher
+	err := r.conn.SelectContext(ctx, &vouchers, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return records.ToArrayOfVoucherV1Domain(&vouchers), nil
+}
+
+func (r *postgreVoucherRepository) GetById(ctx context.Context, id int) (V1Domains.VoucherDomain, error) {
+	query := `SELECT ` + voucherColumns + ` FROM vouchers WHERE voucher_id = $1`
+
+	var voucher records.Voucher
+	err := r.conn.GetContext(ctx, &voucher, query, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = ErrVoucherNotFound
+		}
+		return V1Domains.VoucherDomain{}, err
+	}
+
+	return voucher.ToV1Domain(), nil
+}
+
+func (r *postgreVoucherRepository) Store(ctx context.Context, voucherDom V1Domains.VoucherDomain) (V1Domains.VoucherDomain, error) {
+	query := `
+		INSERT INTO vouchers (code, discount_type, amount, rate_bps, max_discount, min_spend, product_id, starts_at, ends_at,
+			max_redemptions, max_redemptions_per_user, is_active)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		RETURNING ` + voucherColumns
+
+	voucher := records.FromVoucherV1Domain(&voucherDom)
+	err := r.conn.GetContext(ctx, &voucher, query,
+		voucher.Code,
+		voucher.DiscountType,
+		voucher.Amount,
+		voucher.RateBps,
+		voucher.MaxDiscount,
+		voucher.MinSpend,
+		voucher.ProductId,
+		voucher.StartsAt,
+		voucher.EndsAt,
+		voucher.MaxRedemptions,
+		voucher.MaxRedemptionsPerUser,
+		voucher.IsActive,
+	)
+	if err != nil {
+		switch SQLState(err) {
+		case "23505":
+			err = ErrVoucherCodeTaken
+		case "23503":
+			// foreign key product_id gagal berarti produk tidak ada
+			err = ErrProductNotFound
+		}
+		return V1Domains.VoucherDomain{}, err
+	}
+
+	return voucher.ToV1Domain(), nil
+}
+
+func (r *postgreVoucherRepository) Update(ctx context.Context, voucherDom V1Domains.VoucherDomain) (V1Domains.VoucherDomain, error) {
+	query := `
+		UPDATE vouchers
+		SET code = $1, discount_type = $2, amount = $3, rate_bps = $4, max_discount = $5, min_spend = $6, product_id = $7,
+			starts_at = $8, ends_at = $9, max_redemptions = $10, max_redemptions_per_user = $11, is_active = $12, updated_at = CURRENT_TIMESTAMP
+		WHERE voucher_id = $13
+		RETURNING ` + voucherColumns
+
+	voucher := records.FromVoucherV1Domain(&voucherDom)
+	err := r.conn.GetContext(ctx, &voucher, query,
+		voucher.Code,
+		voucher.DiscountType,
+		voucher.Amount,
+		voucher.RateBps,
+		voucher.MaxDiscount,
+		voucher.MinSpend,
+		voucher.ProductId,
+		voucher.StartsAt,
+		voucher.EndsAt,
+		voucher.MaxRedemptions,
+		voucher.MaxRedemptionsPerUser,
+		voucher.IsActive,
+		voucher.Id,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			err = ErrVoucherNotFound
+		case SQLState(err) == "23505":
+			err = ErrVoucherCodeTaken
+		case SQLState(err) == "23503":
+			err = ErrProductNotFound
+		case SQLState(err) == "23514" && SQLConstraint(err) == "vouchers_redemption_count_check":
+			err = ErrVoucherCapBelowRedemptions
+		}
+		return V1Domains.VoucherDomain{}, err
+	}
+
+	return voucher.ToV1Domain(), nil
+}
+
+func (r *postgreVoucherRepository) Delete(ctx context.Context, id int) error {
+	query := `DELETE FROM vouchers WHERE voucher_id = $1`
+	res, err := r.conn.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return ErrVoucherNotFound
+	}
+
+	return nil
+}
+
+// redeemVoucher memvalidasi voucher untuk item pembelian lalu menambah redemption_count-nya,
+// mengembalikan voucher beserta diskon yang berlaku. Penambahan bersyarat pada satu UPDATE membuat
+// pembeli bersamaan tidak bisa melampaui batas pemakaian total, batas per user dijaga oleh transaksi serializable.
+func redeemVoucher(ctx context.Context, tx *sqlx.Tx, userId string, code string, items []V1Domains.OrderItemDomain, now time.Time) (V1Domains.VoucherDomain, money.Money, error) {
+	queryGetVoucher := `
+		SELECT ` + voucherColumns + `
+		FROM vouchers
+		WHERE code = UPPER($1) AND is_active AND starts_at <= $2 AND (ends_at IS NULL OR ends_at > $2)
+	`
+	var voucherRecord records.Voucher
+	err := tx.GetContext(ctx, &voucherRecord, queryGetVoucher, code, now)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = ErrVoucherInvalid
+		}
+		return V1Domains.VoucherDomain{}, 0, err
+	}
+	voucher := voucherRecord.ToV1Domain()
+
+	// Voucher khusus produk hanya menghitung subtotal produk tersebut
+	var eligible money.Money
+	for _, item := range items {
+		if voucher.ProductId == nil || *voucher.ProductId == *item.ProductId {
+			eligible += item.Subtotal
+		}
+	}
+	if eligible.IsZero() {
+		return V1Domains.VoucherDomain{}, 0, ErrVoucherNotApplicable
+	}
+	if eligible < voucher.MinSpend {
+		return V1Domains.VoucherDomain{}, 0, ErrVoucherMinSpendNotMet
+	}
+
+	// Persentase kecil dari nominal kecil bisa dibulatkan menjadi nol, voucher seperti ini tidak dianggap terpakai
+	discount := voucher.Discount(eligible)
+	if !discount.IsPositive() {
+		return V1Domains.VoucherDomain{}, 0, ErrVoucherNotApplicable
+	}
+
+	if voucher.MaxRedemptionsPerUser != nil {
+		queryCountUserRedemptions := `
+			SELECT COUNT(*) FROM voucher_redemptions WHERE voucher_id = $1 AND user_id = $2 AND released_at IS NULL
+		`
+		var redeemed int
+		err = tx.GetContext(ctx, &redeemed, queryCountUserRedemptions, voucher.Id, userId)
+		if err != nil {
+			return V1Domains.VoucherDomain{}, 0, err
+		}
+		if redeemed >= *voucher.MaxRedemptionsPerUser {
+			return V1Domains.VoucherDomain{}, 0, ErrVoucherUserLimitReached
+		}
+	}
+
+	queryIncrementRedemption := `
+		UPDATE vouchers SET redemption_count = redemption_count + 1, updated_at = $2
+		WHERE voucher_id = $1 AND (max_redemptions IS NULL OR redemption_count < max_redemptions)
+	`
+	res, err := tx.ExecContext(ctx, queryIncrementRedemption, voucher.Id, now)
+	if err != nil {
+		return V1Domains.VoucherDomain{}, 0, err
+	}
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return V1Domains.VoucherDomain{}, 0, ErrVoucherFullyRedeemed
+	}
+
+	return voucher, discount, nil
+}
+
+// recordVoucherRedemption mencatat pemakaian voucher oleh user pada transaksi pembelian
+func recordVoucherRedemption(ctx context.Context, tx *sqlx.Tx, voucherId int, userId string, transactionId string, discount money.Money, now time.Time) error {
+	query := `
+		INSERT INTO voucher_redemptions (redemption_id, voucher_id, user_id, transaction_id, discount, created_at)
+		VALUES (uuid_generate_v4(), $1, $2, $3, $4, $5)
+	`
+	_, err := tx.ExecContext(ctx, query, voucherId, userId, transactionId, discount, now)
+	return err
+}
+
+// releaseVoucherRedemption melepas pemakaian voucher oleh pembelian yang di-refund penuh
+// sehingga kuota total dan kuota per user bisa dipakai lagi. Pembelian tanpa voucher tidak diubah.
+func releaseVoucherRedemption(ctx context.Context, tx *sqlx.Tx, transactionId string, now time.Time) error {
+	queryReleaseRedemption := `
+		UPDATE voucher_redemptions SET released_at = $2
+		WHERE transaction_id = $1 AND released_at IS NULL
+		RETURNING voucher_id
+	`
+	var voucherId int
+	err := tx.GetContext(ctx, &voucherId, queryReleaseRedemption, transactionId, now)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	queryDecrementRedemption := `
+		UPDATE vouchers SET redemption_count = redemption_count - 1, updated_at = $2
+		WHERE voucher_id = $1 AND redemption_count > 0
+	`
+	_, err = tx.ExecContext(ctx, queryDecrementRedemption, voucherId, now)
+	return err
+}
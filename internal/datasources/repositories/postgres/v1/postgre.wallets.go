@@ -2,25 +2,36 @@ package v1
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"time"
 
 	"github.com/jmoiron/sqlx"
 	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/internal/constants"
 	"github.com/snykk/transaction-api/internal/datasources/records"
+	"github.com/snykk/transaction-api/pkg/money"
 )
 
+const walletColumns = `wallet_id, user_id, name, is_default, balance, held_amount, points_balance, status, created_at, updated_at`
+
+// userWalletCondition memilih wallet milik user $1, wallet_id $2 kosong berarti wallet default
+const userWalletCondition = `user_id = $1 AND (wallet_id = NULLIF($2, '')::uuid OR ($2 = '' AND is_default))`
+
 type postgreWalletRepository struct {
-	conn *sqlx.DB
+	conn       *sqlx.DB
+	txExecutor *TxExecutor
 }
 
 func NewWalletRepository(conn *sqlx.DB) V1Domains.WalletRepository {
 	return &postgreWalletRepository{
-		conn: conn,
+		conn:       conn,
+		txExecutor: NewTxExecutor(conn, DefaultTxRetryPolicy),
 	}
 }
 
 func (r *postgreWalletRepository) GetAllWallets(ctx context.Context) ([]V1Domains.WalletDomain, error) {
-	query := `SELECT wallet_id, user_id, balance, created_at, updated_at FROM wallets`
+	query := `SELECT ` + walletColumns + ` FROM wallets`
 	var walletFromDB []records.Wallet
 	err := r.conn.SelectContext(ctx, &walletFromDB, query)
 	if err != nil {
@@ -39,13 +50,13 @@ func (r *postgreWalletRepository) GetAllWallets(ctx context.Context) ([]V1Domain
 
 func (r *postgreWalletRepository) CreateWalletByUserId(ctx context.Context, userId string) (V1Domains.WalletDomain, error) {
 	query := `
-        INSERT INTO wallets (wallet_id, user_id, balance, created_at)
-        VALUES (uuid_generate_v4(), $1, 0, $2)
-        RETURNING wallet_id, user_id, balance, created_at, updated_at
+        INSERT INTO wallets (wallet_id, user_id, name, is_default, balance, created_at)
+        VALUES (uuid_generate_v4(), $1, $2, true, 0, $3)
+        RETURNING ` + walletColumns + `
     `
 	var result records.Wallet
 	now := time.Now()
-	err := r.conn.GetContext(ctx, &result, query, userId, now)
+	err := r.conn.GetContext(ctx, &result, query, userId, constants.WalletDefaultName, now)
 	if err != nil {
 		return V1Domains.WalletDomain{}, err
 	}
@@ -56,13 +67,13 @@ func (r *postgreWalletRepository) CreateWalletByUserId(ctx context.Context, user
 func (r *postgreWalletRepository) GetWalletByUserId(ctx context.Context, userId string) (V1Domains.WalletDomain, error) {
 	query := `
         SELECT 
-            w.wallet_id, w.user_id, w.balance, w.created_at, w.updated_at,
+            w.wallet_id, w.user_id, w.name, w.is_default, w.balance, w.held_amount, w.points_balance, w.status, w.created_at, w.updated_at,
 				u.user_id AS "user.user_id", u.username AS "user.username", u.email AS "user.email", 
 				u.password AS "user.password", u.active AS "user.active", u.role_id AS "user.role_id", 
 				u.created_at AS "user.created_at", u.updated_at AS "user.updated_at"
          FROM wallets w
         INNER JOIN users u ON w.user_id = u.user_id
-        WHERE w.user_id = $1
+        WHERE w.user_id = $1 AND w.is_default
     `
 
 	var result records.Wallet
@@ -73,3 +84,179 @@ func (r *postgreWalletRepository) GetWalletByUserId(ctx context.Context, userId
 
 	return result.ToV1Domain(), nil
 }
+
+func (r *postgreWalletRepository) CreateWallet(ctx context.Context, userId string, name string) (V1Domains.WalletDomain, error) {
+	// Pocket pertama user otomatis menjadi default agar endpoint tanpa wallet_id tetap bisa dipakai
+	query := `
+		INSERT INTO wallets (wallet_id, user_id, name, is_default, balance, created_at)
+		VALUES (uuid_generate_v4(), $1, $2, NOT EXISTS (SELECT 1 FROM wallets WHERE user_id = $1), 0, $3)
+		RETURNING ` + walletColumns
+	var result records.Wallet
+	err := r.conn.GetContext(ctx, &result, query, userId, name, time.Now())
+	if err != nil {
+		if SQLState(err) == "23505" && SQLConstraint(err) == "wallets_user_id_name_key" {
+			err = ErrWalletNameTaken
+		}
+		return V1Domains.WalletDomain{}, err
+	}
+
+	return result.ToV1Domain(), nil
+}
+
+func (r *postgreWalletRepository) GetWalletsByUserId(ctx context.Context, userId string) ([]V1Domains.WalletDomain, error) {
+	query := `SELECT ` + walletColumns + ` FROM wallets WHERE user_id = $1 ORDER BY is_default DESC, created_at, name`
+	var walletFromDB []records.Wallet
+	err := r.conn.SelectContext(ctx, &walletFromDB, query, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return records.ToArrayOfWalletV1Domain(&walletFromDB), nil
+}
+
+func (r *postgreWalletRepository) SetDefault(ctx context.Context, userId string, walletId string) (result V1Domains.WalletDomain, err error) {
+	_, err = r.txExecutor.RunSerializable(ctx, "wallet_default", func(tx *sqlx.Tx) (err error) {
+		wallet, err := getUserWallet(ctx, tx, userId, walletId)
+		if err != nil {
+			return err
+		}
+		if wallet.IsDefault {
+			result = wallet.ToV1Domain()
+			return nil
+		}
+
+		// Default lama dilepas lebih dulu karena index unik default diperiksa per baris
+		now := time.Now()
+		_, err = tx.ExecContext(ctx, `UPDATE wallets SET is_default = false, updated_at = $1 WHERE user_id = $2 AND is_default`, now, userId)
+		if err != nil {
+			return err
+		}
+
+		querySetDefault := `UPDATE wallets SET is_default = true, updated_at = $1 WHERE wallet_id = $2 RETURNING ` + walletColumns
+		err = tx.GetContext(ctx, &wallet, querySetDefault, now, wallet.Id)
+		if err != nil {
+			return err
+		}
+
+		result = wallet.ToV1Domain()
+		return nil
+	})
+
+	return result, err
+}
+
+func (r *postgreWalletRepository) GetLedgerBalance(ctx context.Context, walletId string) (money.Money, error) {
+	return ledgerBalance(ctx, r.conn, walletId)
+}
+
+func (r *postgreWalletRepository) UpdateStatus(ctx context.Context, eventDom V1Domains.WalletStatusEventDomain) (result V1Domains.WalletDomain, err error) {
+	_, err = r.txExecutor.RunSerializable(ctx, "wallet_status", func(tx *sqlx.Tx) (err error) {
+		// Lock wallet agar perubahan status tidak bersamaan dengan transaksi uang yang sedang berjalan
+		queryGetWallet := `
+			SELECT ` + walletColumns + `
+			FROM wallets
+			WHERE wallet_id = $1
+			FOR UPDATE
+		`
+		var wallet records.Wallet
+		err = tx.GetContext(ctx, &wallet, queryGetWallet, eventDom.WalletId)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				err = ErrWalletNotFound
+			}
+			return err
+		}
+
+		if wallet.Status == eventDom.Status {
+			return ErrWalletStatusUnchanged
+		}
+
+		queryUpdateStatus := `
+			UPDATE wallets SET status = $1, updated_at = $2
+			WHERE wallet_id = $3
+			RETURNING ` + walletColumns + `
+		`
+		previousStatus := wallet.Status
+		err = tx.GetContext(ctx, &wallet, queryUpdateStatus, eventDom.Status, time.Now(), wallet.Id)
+		if err != nil {
+			return err
+		}
+
+		queryCreateEvent := `
+			INSERT INTO wallet_status_events (event_id, wallet_id, action, previous_status, status, reason, actor_id)
+			VALUES (uuid_generate_v4(), $1, $2, $3, $4, $5, $6)
+		`
+		_, err = tx.ExecContext(ctx, queryCreateEvent, wallet.Id, eventDom.Action, previousStatus, eventDom.Status, eventDom.Reason, eventDom.ActorId)
+		if err != nil {
+			return err
+		}
+
+		result = wallet.ToV1Domain()
+		return nil
+	})
+
+	return result, err
+}
+
+func (r *postgreWalletRepository) GetStatusEvents(ctx context.Context, walletId string) ([]V1Domains.WalletStatusEventDomain, error) {
+	// Bedakan wallet yang tidak ada dengan wallet yang belum pernah dibekukan
+	var exists bool
+	err := r.conn.GetContext(ctx, &exists, `SELECT EXISTS (SELECT 1 FROM wallets WHERE wallet_id = $1)`, walletId)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrWalletNotFound
+	}
+
+	query := `
+		SELECT event_id, wallet_id, action, previous_status, status, reason, actor_id, created_at
+		FROM wallet_status_events
+		WHERE wallet_id = $1
+		ORDER BY created_at DESC
+	`
+	var eventsFromDB []records.WalletStatusEvent
+	err = r.conn.SelectContext(ctx, &eventsFromDB, query, walletId)
+	if err != nil {
+		return nil, err
+	}
+
+	return records.ToArrayOfWalletStatusEventV1Domain(&eventsFromDB), nil
+}
+
+// ensureOutgoingAllowed menolak dana keluar dari wallet yang dibekukan untuk transaksi keluar
+func ensureOutgoingAllowed(wallet records.Wallet) error {
+	if wallet.BlocksOutgoing() {
+		return ErrWalletFrozenOutgoing
+	}
+	return nil
+}
+
+// ensureIncomingAllowed menolak dana masuk ke wallet yang dibekukan untuk transaksi masuk
+func ensureIncomingAllowed(wallet records.Wallet) error {
+	if wallet.BlocksIncoming() {
+		return ErrWalletFrozenIncoming
+	}
+	return nil
+}
+
+// getUserWallet mengambil dan mengunci wallet milik user, walletId kosong berarti wallet default.
+// Wallet milik user lain diperlakukan sama dengan wallet yang tidak ada.
+func getUserWallet(ctx context.Context, tx *sqlx.Tx, userId string, walletId string) (records.Wallet, error) {
+	query := `
+		SELECT ` + walletColumns + `
+		FROM wallets
+		WHERE ` + userWalletCondition + `
+		FOR UPDATE
+	`
+	var wallet records.Wallet
+	err := tx.GetContext(ctx, &wallet, query, userId, walletId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = ErrWalletNotFound
+		}
+		return records.Wallet{}, err
+	}
+
+	return wallet, nil
+}
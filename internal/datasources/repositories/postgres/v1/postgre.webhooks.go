@@ -0,0 +1,286 @@
+package v1
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+	"github.com/snykk/transaction-api/internal/datasources/records"
+)
+
+const webhookColumns = `webhook_id, user_id, url, event_types, secret, is_active, created_at, updated_at`
+
+const webhookDeliveryColumns = `
+	d.delivery_id, d.webhook_id, d.event_id, d.event_type, d.payload, d.status, d.attempts, d.next_attempt_at,
+	d.last_status_code, d.last_error, d.delivered_at, d.created_at, d.updated_at
+`
+
+const webhookAttemptColumns = `attempt_id, delivery_id, attempt, status_code, error, duration_ms, attempted_at`
+
+type postgreWebhookRepository struct {
+	conn       *sqlx.DB
+	txExecutor *TxExecutor
+}
+
+func NewWebhookRepository(conn *sqlx.DB) V1Domains.WebhookRepository {
+	return &postgreWebhookRepository{
+		conn:       conn,
+		txExecutor: NewTxExecutor(conn, DefaultTxRetryPolicy),
+	}
+}
+
+func (r *postgreWebhookRepository) Store(ctx context.Context, webhookDom V1Domains.WebhookEndpointDomain) (V1Domains.WebhookEndpointDomain, error) {
+	query := `
+		INSERT INTO webhook_endpoints (webhook_id, user_id, url, event_types, secret, is_active)
+		VALUES (uuid_generate_v4(), $1, $2, $3, $4, $5)
+		RETURNING ` + webhookColumns
+
+	var webhook records.WebhookEndpoint
+	err := r.conn.GetContext(ctx, &webhook, query, webhookDom.UserId, webhookDom.URL, pq.Array(webhookDom.EventTypes), webhookDom.Secret, webhookDom.IsActive)
+	if err != nil {
+		return V1Domains.WebhookEndpointDomain{}, err
+	}
+
+	return webhook.ToV1Domain(), nil
+}
+
+func (r *postgreWebhookRepository) GetAll(ctx context.Context, userId string) ([]V1Domains.WebhookEndpointDomain, error) {
+	query := `SELECT ` + webhookColumns + ` FROM webhook_endpoints WHERE user_id = $1 ORDER BY created_at`
+
+	var webhooks []records.WebhookEndpoint
+	err := r.conn.SelectContext(ctx, &webhooks, query, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return records.ToArrayOfWebhookEndpointV1Domain(&webhooks), nil
+}
+
+func (r *postgreWebhookRepository) GetById(ctx context.Context, userId string, webhookId string) (V1Domains.WebhookEndpointDomain, error) {
+	query := `SELECT ` + webhookColumns + ` FROM webhook_endpoints WHERE webhook_id = $1 AND user_id = $2`
+
+	var webhook records.WebhookEndpoint
+	err := r.conn.GetContext(ctx, &webhook, query, webhookId, userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = ErrWebhookNotFound
+		}
+		return V1Domains.WebhookEndpointDomain{}, err
+	}
+
+	return webhook.ToV1Domain(), nil
+}
+
+func (r *postgreWebhookRepository) Update(ctx context.Context, webhookDom V1Domains.WebhookEndpointDomain) (V1Domains.WebhookEndpointDomain, error) {
+	query := `
+		UPDATE webhook_endpoints
+		SET url = $1, event_types = $2, is_active = $3, secret = COALESCE(NULLIF($4, ''), secret), updated_at = CURRENT_TIMESTAMP
+		WHERE webhook_id = $5 AND user_id = $6
+		RETURNING ` + webhookColumns
+
+	var webhook records.WebhookEndpoint
+	err := r.conn.GetContext(ctx, &webhook, query, webhookDom.URL, pq.Array(webhookDom.EventTypes), webhookDom.IsActive, webhookDom.Secret, webhookDom.Id, webhookDom.UserId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = ErrWebhookNotFound
+		}
+		return V1Domains.WebhookEndpointDomain{}, err
+	}
+
+	return webhook.ToV1Domain(), nil
+}
+
+func (r *postgreWebhookRepository) Delete(ctx context.Context, userId string, webhookId string) error {
+	query := `DELETE FROM webhook_endpoints WHERE webhook_id = $1 AND user_id = $2`
+	res, err := r.conn.ExecContext(ctx, query, webhookId, userId)
+	if err != nil {
+		return err
+	}
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+func (r *postgreWebhookRepository) Enqueue(ctx context.Context, event V1Domains.EventDomain, userId string, now time.Time) (int, error) {
+	// satu pengiriman untuk setiap endpoint aktif user yang melanggan tipe event ini,
+	// event yang sudah pernah diantrekan ke endpoint yang sama dilewati
+	query := `
+		INSERT INTO webhook_deliveries (delivery_id, webhook_id, event_id, event_type, payload, status, next_attempt_at, created_at, updated_at)
+		SELECT uuid_generate_v4(), webhook_id, $1, $2, $3, $4, $5, $5, $5
+		FROM webhook_endpoints
+		WHERE user_id = $6 AND is_active AND $2 = ANY(event_types)
+		ON CONFLICT (webhook_id, event_id) WHERE event_id IS NOT NULL DO NOTHING
+	`
+	res, err := r.conn.ExecContext(ctx, query, event.Id, event.EventType, []byte(event.Payload), constants.WebhookDeliveryStatusPending, now, userId)
+	if err != nil {
+		return 0, err
+	}
+
+	affected, _ := res.RowsAffected()
+	return int(affected), nil
+}
+
+func (r *postgreWebhookRepository) StoreDelivery(ctx context.Context, deliveryDom V1Domains.WebhookDeliveryDomain) (V1Domains.WebhookDeliveryDomain, error) {
+	query := `
+		INSERT INTO webhook_deliveries AS d (delivery_id, webhook_id, event_id, event_type, payload, status, next_attempt_at)
+		VALUES (uuid_generate_v4(), $1, $2, $3, $4, $5, $6)
+		RETURNING ` + webhookDeliveryColumns
+
+	var delivery records.WebhookDelivery
+	err := r.conn.GetContext(ctx, &delivery, query,
+		deliveryDom.WebhookId,
+		deliveryDom.EventId,
+		deliveryDom.EventType,
+		[]byte(deliveryDom.Payload),
+		deliveryDom.Status,
+		deliveryDom.NextAttemptAt,
+	)
+	if err != nil {
+		if SQLState(err) == "23503" {
+			// foreign key webhook_id gagal berarti endpoint sudah dihapus
+			err = ErrWebhookNotFound
+		}
+		return V1Domains.WebhookDeliveryDomain{}, err
+	}
+
+	result := delivery.ToV1Domain()
+	result.Webhook = deliveryDom.Webhook
+	return result, nil
+}
+
+func (r *postgreWebhookRepository) GetDeliveries(ctx context.Context, userId string, webhookId string, limit int) ([]V1Domains.WebhookDeliveryDomain, error) {
+	// endpoint milik user lain diperlakukan sama dengan endpoint yang tidak ada
+	if _, err := r.GetById(ctx, userId, webhookId); err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT ` + webhookDeliveryColumns + `
+		FROM webhook_deliveries d
+		WHERE d.webhook_id = $1
+		ORDER BY d.created_at DESC, d.delivery_id
+		LIMIT $2
+	`
+
+	var deliveries []records.WebhookDelivery
+	err := r.conn.SelectContext(ctx, &deliveries, query, webhookId, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return records.ToArrayOfWebhookDeliveryV1Domain(&deliveries), nil
+}
+
+func (r *postgreWebhookRepository) GetDeliveryById(ctx context.Context, userId string, webhookId string, deliveryId string) (V1Domains.WebhookDeliveryDomain, error) {
+	query := `
+		SELECT ` + webhookDeliveryColumns + `, w.url, w.secret
+		FROM webhook_deliveries d
+		INNER JOIN webhook_endpoints w ON d.webhook_id = w.webhook_id
+		WHERE d.delivery_id = $1 AND d.webhook_id = $2 AND w.user_id = $3
+	`
+
+	var delivery records.WebhookDelivery
+	err := r.conn.GetContext(ctx, &delivery, query, deliveryId, webhookId, userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = ErrWebhookDeliveryNotFound
+		}
+		return V1Domains.WebhookDeliveryDomain{}, err
+	}
+
+	queryAttempts := `SELECT ` + webhookAttemptColumns + ` FROM webhook_delivery_attempts WHERE delivery_id = $1 ORDER BY attempt`
+
+	var attempts []records.WebhookAttempt
+	err = r.conn.SelectContext(ctx, &attempts, queryAttempts, deliveryId)
+	if err != nil {
+		return V1Domains.WebhookDeliveryDomain{}, err
+	}
+
+	result := delivery.ToV1Domain()
+	result.AttemptLog = records.ToArrayOfWebhookAttemptV1Domain(&attempts)
+	return result, nil
+}
+
+func (r *postgreWebhookRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) (result []V1Domains.WebhookDeliveryDomain, err error) {
+	_, err = r.txExecutor.RunSerializable(ctx, "webhook_claim", func(tx *sqlx.Tx) (err error) {
+		// SKIP LOCKED membuat dispatcher di beberapa instance tidak mengambil pengiriman yang sama,
+		// lease menjaga pengiriman tetap milik dispatcher ini setelah transaksi klaim selesai.
+		// Pengiriman ke endpoint nonaktif ditahan sampai endpoint diaktifkan kembali.
+		query := `
+			UPDATE webhook_deliveries d SET next_attempt_at = $1
+			FROM webhook_endpoints w
+			WHERE d.webhook_id = w.webhook_id AND d.delivery_id IN (
+				SELECT pd.delivery_id
+				FROM webhook_deliveries pd
+				INNER JOIN webhook_endpoints pw ON pd.webhook_id = pw.webhook_id
+				WHERE pd.status = $2 AND pd.next_attempt_at <= $3 AND pw.is_active
+				ORDER BY pd.next_attempt_at, pd.delivery_id
+				LIMIT $4
+				FOR UPDATE OF pd SKIP LOCKED
+			)
+			RETURNING ` + webhookDeliveryColumns + `, w.url, w.secret`
+		var deliveries []records.WebhookDelivery
+		err = tx.SelectContext(ctx, &deliveries, query, now.Add(lease), constants.WebhookDeliveryStatusPending, now, limit)
+		if err != nil {
+			return err
+		}
+
+		result = records.ToArrayOfWebhookDeliveryV1Domain(&deliveries)
+		return nil
+	})
+
+	return result, err
+}
+
+func (r *postgreWebhookRepository) RecordAttempt(ctx context.Context, deliveryDom V1Domains.WebhookDeliveryDomain, attemptDom V1Domains.WebhookAttemptDomain) (result V1Domains.WebhookDeliveryDomain, err error) {
+	_, err = r.txExecutor.RunSerializable(ctx, "webhook_attempt", func(tx *sqlx.Tx) (err error) {
+		queryAttempt := `
+			INSERT INTO webhook_delivery_attempts (attempt_id, delivery_id, attempt, status_code, error, duration_ms, attempted_at)
+			VALUES (uuid_generate_v4(), $1, $2, $3, $4, $5, $6)
+		`
+		_, err = tx.ExecContext(ctx, queryAttempt, deliveryDom.Id, attemptDom.Attempt, attemptDom.StatusCode, attemptDom.Error, attemptDom.DurationMs, attemptDom.AttemptedAt)
+		if err != nil {
+			if SQLState(err) == "23503" {
+				// pengiriman ikut terhapus bersama endpoint-nya
+				err = ErrWebhookDeliveryNotFound
+			}
+			return err
+		}
+
+		query := `
+			UPDATE webhook_deliveries d
+			SET status = $1, attempts = $2, next_attempt_at = $3, last_status_code = $4, last_error = $5,
+				delivered_at = $6, updated_at = CURRENT_TIMESTAMP
+			WHERE d.delivery_id = $7
+			RETURNING ` + webhookDeliveryColumns
+		var delivery records.WebhookDelivery
+		err = tx.GetContext(ctx, &delivery, query,
+			deliveryDom.Status,
+			deliveryDom.Attempts,
+			deliveryDom.NextAttemptAt,
+			deliveryDom.LastStatusCode,
+			deliveryDom.LastError,
+			deliveryDom.DeliveredAt,
+			deliveryDom.Id,
+		)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				err = ErrWebhookDeliveryNotFound
+			}
+			return err
+		}
+
+		result = delivery.ToV1Domain()
+		result.Webhook = deliveryDom.Webhook
+		return nil
+	})
+
+	return result, err
+}
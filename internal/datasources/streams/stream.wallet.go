@@ -0,0 +1,321 @@
+package streams
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/sirupsen/logrus"
+	"github.com/snykk/transaction-api/internal/constants"
+	"github.com/snykk/transaction-api/pkg/logger"
+)
+
+var (
+	ErrInvalidLastEventId = errors.New("last event id is invalid")
+	ErrWalletStreamClosed = errors.New("wallet stream is closed")
+)
+
+// WalletEvent adalah perubahan wallet milik satu user. Id berasal dari Redis Stream
+// sehingga urut dan dapat dipakai client sebagai Last-Event-ID saat reconnect
+type WalletEvent struct {
+	Id     string          `json:"id"`
+	Type   string          `json:"type"`
+	UserId string          `json:"user_id"`
+	Data   json.RawMessage `json:"data"`
+}
+
+type WalletStream interface {
+	// Publish menyimpan event ke riwayat wallet user lalu menyebarkannya ke semua instance
+	Publish(ctx context.Context, userId string, eventType string, data interface{}) error
+	// Subscribe mengirim event setelah lastEventId lalu event baru milik user sampai ctx selesai.
+	// Channel ditutup jika ctx selesai, stream dihentikan, atau subscriber tertinggal terlalu jauh.
+	Subscribe(ctx context.Context, userId string, lastEventId string) (<-chan WalletEvent, error)
+}
+
+// RedisWalletStream menyimpan riwayat event per user di Redis Stream dan menyebarkan event baru
+// lewat satu channel pub/sub. Setiap instance hanya memegang satu koneksi pub/sub lalu
+// membagikan event ke subscriber lokal berdasarkan user.
+type RedisWalletStream struct {
+	client     *redis.Client
+	channel    string
+	historyLen int64
+	retention  time.Duration
+
+	mu          sync.Mutex
+	subscribers map[string]map[*walletSubscriber]struct{}
+	pubsub      *redis.PubSub
+	closed      bool
+	done        chan struct{}
+}
+
+type walletSubscriber struct {
+	events chan WalletEvent
+}
+
+func NewRedisWalletStream(client *redis.Client, channel string, historyLen int64, retention time.Duration) *RedisWalletStream {
+	return &RedisWalletStream{
+		client:      client,
+		channel:     channel,
+		historyLen:  historyLen,
+		retention:   retention,
+		subscribers: make(map[string]map[*walletSubscriber]struct{}),
+		done:        make(chan struct{}),
+	}
+}
+
+// Start berlangganan channel pub/sub, koneksi yang putus disambung ulang oleh client redis
+func (s *RedisWalletStream) Start() {
+	s.pubsub = s.client.Subscribe(context.Background(), s.channel)
+
+	go s.run()
+
+	logger.InfoF("wallet stream started, listening on %s", logrus.Fields{constants.LoggerCategory: constants.LoggerCategoryStream}, s.channel)
+}
+
+// Stop menutup semua subscriber sehingga koneksi streaming selesai dan client reconnect ke instance lain
+func (s *RedisWalletStream) Stop() {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return
+	}
+	s.closed = true
+	for userId, subscribers := range s.subscribers {
+		for subscriber := range subscribers {
+			close(subscriber.events)
+		}
+		delete(s.subscribers, userId)
+	}
+	s.mu.Unlock()
+
+	if s.pubsub != nil {
+		s.pubsub.Close()
+		<-s.done
+	}
+
+	logger.Info("wallet stream stopped", logrus.Fields{constants.LoggerCategory: constants.LoggerCategoryStream})
+}
+
+func (s *RedisWalletStream) Publish(ctx context.Context, userId string, eventType string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	key := s.historyKey(userId)
+	id, err := s.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: key,
+		MaxLen: s.historyLen,
+		Approx: true,
+		Values: map[string]interface{}{
+			"type": eventType,
+			"data": string(payload),
+		},
+	}).Result()
+	if err != nil {
+		return err
+	}
+
+	// riwayat user yang tidak aktif dibuang setelah masa retensi
+	if err := s.client.Expire(ctx, key, s.retention).Err(); err != nil {
+		return err
+	}
+
+	message, err := json.Marshal(WalletEvent{Id: id, Type: eventType, UserId: userId, Data: payload})
+	if err != nil {
+		return err
+	}
+
+	return s.client.Publish(ctx, s.channel, message).Err()
+}
+
+func (s *RedisWalletStream) Subscribe(ctx context.Context, userId string, lastEventId string) (<-chan WalletEvent, error) {
+	if lastEventId != "" {
+		if _, _, ok := parseStreamId(lastEventId); !ok {
+			return nil, ErrInvalidLastEventId
+		}
+	}
+
+	// subscriber didaftarkan sebelum membaca riwayat agar event yang masuk di antaranya tidak hilang
+	subscriber := &walletSubscriber{events: make(chan WalletEvent, constants.WalletStreamSubscriberBuffer)}
+	if err := s.add(userId, subscriber); err != nil {
+		return nil, err
+	}
+
+	var history []WalletEvent
+	if lastEventId != "" {
+		messages, err := s.client.XRange(ctx, s.historyKey(userId), lastEventId, "+").Result()
+		if err != nil {
+			s.remove(userId, subscriber)
+			return nil, err
+		}
+
+		for _, message := range messages {
+			if compareStreamId(message.ID, lastEventId) <= 0 {
+				continue
+			}
+			history = append(history, historyEvent(userId, message))
+		}
+	}
+
+	events := make(chan WalletEvent, constants.WalletStreamSubscriberBuffer)
+	go func() {
+		defer close(events)
+		defer s.remove(userId, subscriber)
+
+		lastId := lastEventId
+		forward := func(event WalletEvent) bool {
+			// event live yang sudah terkirim lewat riwayat dilewati
+			if lastId != "" && compareStreamId(event.Id, lastId) <= 0 {
+				return true
+			}
+
+			select {
+			case events <- event:
+				lastId = event.Id
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		for _, event := range history {
+			if !forward(event) {
+				return
+			}
+		}
+
+		for {
+			select {
+			case event, ok := <-subscriber.events:
+				if !ok || !forward(event) {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+func (s *RedisWalletStream) run() {
+	defer close(s.done)
+
+	for message := range s.pubsub.Channel() {
+		var event WalletEvent
+		if err := json.Unmarshal([]byte(message.Payload), &event); err != nil {
+			logger.ErrorF("failed to decode wallet event: %v", logrus.Fields{constants.LoggerCategory: constants.LoggerCategoryStream}, err)
+			continue
+		}
+
+		s.dispatch(event)
+	}
+}
+
+// dispatch tidak pernah menunggu subscriber, subscriber yang buffernya penuh diputus
+// dan client melanjutkan dari Last-Event-ID terakhir saat reconnect
+func (s *RedisWalletStream) dispatch(event WalletEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for subscriber := range s.subscribers[event.UserId] {
+		select {
+		case subscriber.events <- event:
+		default:
+			s.removeLocked(event.UserId, subscriber)
+		}
+	}
+}
+
+func (s *RedisWalletStream) add(userId string, subscriber *walletSubscriber) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return ErrWalletStreamClosed
+	}
+
+	if s.subscribers[userId] == nil {
+		s.subscribers[userId] = make(map[*walletSubscriber]struct{})
+	}
+	s.subscribers[userId][subscriber] = struct{}{}
+	return nil
+}
+
+func (s *RedisWalletStream) remove(userId string, subscriber *walletSubscriber) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.removeLocked(userId, subscriber)
+}
+
+func (s *RedisWalletStream) removeLocked(userId string, subscriber *walletSubscriber) {
+	subscribers, ok := s.subscribers[userId]
+	if !ok {
+		return
+	}
+	if _, ok := subscribers[subscriber]; !ok {
+		return
+	}
+
+	close(subscriber.events)
+	delete(subscribers, subscriber)
+	if len(subscribers) == 0 {
+		delete(s.subscribers, userId)
+	}
+}
+
+func (s *RedisWalletStream) historyKey(userId string) string {
+	return fmt.Sprintf("%s:%s", s.channel, userId)
+}
+
+func historyEvent(userId string, message redis.XMessage) WalletEvent {
+	eventType, _ := message.Values["type"].(string)
+	data, _ := message.Values["data"].(string)
+
+	return WalletEvent{Id: message.ID, Type: eventType, UserId: userId, Data: json.RawMessage(data)}
+}
+
+// parseStreamId memecah id Redis Stream berformat <milidetik>-<urutan>
+func parseStreamId(id string) (ms uint64, seq uint64, ok bool) {
+	msPart, seqPart, found := strings.Cut(id, "-")
+	if !found {
+		return 0, 0, false
+	}
+
+	ms, err := strconv.ParseUint(msPart, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	seq, err = strconv.ParseUint(seqPart, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return ms, seq, true
+}
+
+func compareStreamId(a string, b string) int {
+	aMs, aSeq, _ := parseStreamId(a)
+	bMs, bSeq, _ := parseStreamId(b)
+
+	switch {
+	case aMs < bMs:
+		return -1
+	case aMs > bMs:
+		return 1
+	case aSeq < bSeq:
+		return -1
+	case aSeq > bSeq:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -1,30 +1,172 @@
 package requests
 
 import (
+	"errors"
+	"fmt"
+	"time"
+
 	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/pkg/money"
 )
 
 type TransactionDepositOrWithdrawRequest struct {
-	Amount float64 `json:"amount" binding:"required,gt=0"` // price lebih besar dari 0
+	Amount            money.Money            `json:"amount" binding:"required,gt=0"`     // price lebih besar dari 0
+	WalletId          string                 `json:"wallet_id" binding:"omitempty,uuid"` // kosong berarti wallet default
+	Description       string                 `json:"description" binding:"max=255"`
+	ExternalReference string                 `json:"external_reference" binding:"omitempty,max=64,printascii"` // unik per user
+	Metadata          map[string]interface{} `json:"metadata"`                                                 // ukurannya divalidasi di usecase
 	// PaymentMethod string  `json:"payment_method" binding:"required,oneof=bank_transfer credit_card"`
-	// Description string `json:"description" binding:"required"`
 }
 
 func (w *TransactionDepositOrWithdrawRequest) ToDomain() *V1Domains.TransactionDomain {
 	return &V1Domains.TransactionDomain{
-		Amount: w.Amount,
-		// Description: w.Description,
+		WalletId:          w.WalletId,
+		Amount:            w.Amount,
+		Description:       optionalString(w.Description),
+		ExternalReference: optionalString(w.ExternalReference),
+		Metadata:          w.Metadata,
 	}
 }
 
 type TransactionPurchaseRequest struct {
-	ProductId int `json:"product_id" binding:"required"`    // price lebih besar dari 0
-	Quantity  int `json:"quantity" binding:"required,gt=0"` // price lebih besar dari 0
+	ProductId         int                    `json:"product_id" binding:"required"`                      // price lebih besar dari 0
+	Quantity          int                    `json:"quantity" binding:"required,gt=0"`                   // price lebih besar dari 0
+	WalletId          string                 `json:"wallet_id" binding:"omitempty,uuid"`                 // kosong berarti wallet default
+	VoucherCode       string                 `json:"voucher_code" binding:"omitempty,max=32,printascii"` // tidak peka huruf besar kecil
+	RedeemPoints      money.Money            `json:"redeem_points" binding:"gte=0"`                      // poin yang dipakai membayar sebagian pembelian
+	Description       string                 `json:"description" binding:"max=255"`
+	ExternalReference string                 `json:"external_reference" binding:"omitempty,max=64,printascii"` // unik per user
+	Metadata          map[string]interface{} `json:"metadata"`                                                 // ukurannya divalidasi di usecase
 }
 
 func (w *TransactionPurchaseRequest) ToDomain() *V1Domains.TransactionDomain {
 	return &V1Domains.TransactionDomain{
-		ProductId: &w.ProductId,
-		Quantity:  &w.Quantity,
+		WalletId:          w.WalletId,
+		ProductId:         &w.ProductId,
+		Quantity:          &w.Quantity,
+		VoucherCode:       optionalString(w.VoucherCode),
+		PointsRedeemed:    w.RedeemPoints,
+		Description:       optionalString(w.Description),
+		ExternalReference: optionalString(w.ExternalReference),
+		Metadata:          w.Metadata,
+	}
+}
+
+// TransactionTransferRequest mengirim dana ke user lain lewat recipient_*,
+// atau ke pocket lain milik sendiri lewat to_wallet_id
+type TransactionTransferRequest struct {
+	WalletId          string                 `json:"wallet_id" binding:"omitempty,uuid"` // wallet sumber, kosong berarti wallet default
+	ToWalletId        string                 `json:"to_wallet_id" binding:"omitempty,uuid"`
+	RecipientUserId   string                 `json:"recipient_user_id" binding:"omitempty,uuid"`
+	RecipientUsername string                 `json:"recipient_username"`
+	RecipientEmail    string                 `json:"recipient_email" binding:"omitempty,email"`
+	Amount            money.Money            `json:"amount" binding:"required,gt=0"` // jumlah transfer lebih besar dari 0
+	Description       string                 `json:"description" binding:"max=255"`
+	ExternalReference string                 `json:"external_reference" binding:"omitempty,max=64,printascii"` // unik per user
+	Metadata          map[string]interface{} `json:"metadata"`                                                 // ukurannya divalidasi di usecase
+}
+
+func (w *TransactionTransferRequest) ToDomain() *V1Domains.TransferDomain {
+	return &V1Domains.TransferDomain{
+		SenderWalletId:    w.WalletId,
+		RecipientWalletId: w.ToWalletId,
+		RecipientUserId:   w.RecipientUserId,
+		RecipientUsername: w.RecipientUsername,
+		RecipientEmail:    w.RecipientEmail,
+		Amount:            w.Amount,
+		Description:       optionalString(w.Description),
+		ExternalReference: optionalString(w.ExternalReference),
+		Metadata:          w.Metadata,
+	}
+}
+
+type TransactionStatusUpdateRequest struct {
+	Status        string `json:"status" binding:"required,oneof=completed failed cancelled"`
+	FailureReason string `json:"failure_reason" binding:"max=500"`
+}
+
+type TransactionRefundRequest struct {
+	Quantity int `json:"quantity" binding:"omitempty,gt=0"` // kosong berarti refund penuh
+}
+
+type TransactionUriRequest struct {
+	TransactionId string `uri:"id" binding:"required,uuid"`
+}
+
+// TransactionListQueryRequest adalah query string untuk daftar transaksi,
+// misal ?type=deposit&from=2024-01-01T00:00:00Z&min_amount=10.00&limit=20
+type TransactionListQueryRequest struct {
+	TransactionType string     `form:"type" binding:"omitempty,oneof=deposit withdraw purchase transfer_out transfer_in refund cashback points_conversion"`
+	From            *time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To              *time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	MinAmount       string     `form:"min_amount"`
+	MaxAmount       string     `form:"max_amount"`
+	ProductId       *int       `form:"product_id" binding:"omitempty,gt=0"`
+	Reference       string     `form:"reference" binding:"omitempty,max=64"` // external_reference persis
+	Sort            string     `form:"sort" binding:"omitempty,oneof=asc desc"`
+	Limit           int        `form:"limit" binding:"omitempty,min=1,max=100"`
+	Cursor          string     `form:"cursor"`
+}
+
+func (q *TransactionListQueryRequest) ToDomain() (*V1Domains.TransactionFilterDomain, error) {
+	filter := &V1Domains.TransactionFilterDomain{
+		TransactionType: q.TransactionType,
+		From:            q.From,
+		To:              q.To,
+		ProductId:       q.ProductId,
+		Reference:       q.Reference,
+		Sort:            q.Sort,
+		Limit:           q.Limit,
+	}
+
+	if q.MinAmount != "" {
+		minAmount, err := money.Parse(q.MinAmount)
+		if err != nil {
+			return nil, fmt.Errorf("min_amount: %w", err)
+		}
+		filter.MinAmount = &minAmount
+	}
+
+	if q.MaxAmount != "" {
+		maxAmount, err := money.Parse(q.MaxAmount)
+		if err != nil {
+			return nil, fmt.Errorf("max_amount: %w", err)
+		}
+		filter.MaxAmount = &maxAmount
+	}
+
+	if q.Cursor != "" {
+		cursor, err := V1Domains.DecodeTransactionCursor(q.Cursor)
+		if err != nil {
+			return nil, errors.New("cursor is invalid")
+		}
+		filter.Cursor = &cursor
+	}
+
+	return filter, nil
+}
+
+// TransactionStatementQueryRequest adalah query string untuk export statement,
+// from dan to berupa tanggal inklusif, misal ?from=2024-01-01&to=2024-01-31&format=csv
+type TransactionStatementQueryRequest struct {
+	From     time.Time `form:"from" binding:"required" time_format:"2006-01-02"`
+	To       time.Time `form:"to" binding:"required" time_format:"2006-01-02"`
+	Format   string    `form:"format" binding:"omitempty,oneof=csv ofx json"`
+	WalletId string    `form:"wallet_id" binding:"omitempty,uuid"` // kosong berarti wallet default
+}
+
+func (q *TransactionStatementQueryRequest) ToDomain() *V1Domains.StatementDomain {
+	return &V1Domains.StatementDomain{
+		WalletId: q.WalletId,
+		From:     q.From,
+		To:       q.To.AddDate(0, 0, 1), // tanggal akhir ikut dihitung penuh
+	}
+}
+
+// optionalString mengubah string kosong menjadi nil agar kolom nullable tetap NULL
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
 	}
+	return &value
 }
@@ -0,0 +1,60 @@
+package requests
+
+import (
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/pkg/money"
+)
+
+// FeeRuleRequest dipakai untuk membuat maupun mengganti aturan fee.
+// Field yang tidak dipakai oleh fee_type diabaikan.
+type FeeRuleRequest struct {
+	Name            string           `json:"name" binding:"required,max=100"`
+	TransactionType string           `json:"transaction_type" binding:"required,oneof=withdraw purchase"`
+	ProductId       *int             `json:"product_id" binding:"omitempty,gt=0"` // hanya untuk purchase
+	FeeType         string           `json:"fee_type" binding:"required,oneof=flat percentage tiered"`
+	FlatAmount      *money.Money     `json:"flat_amount" binding:"omitempty,gte=0"`
+	RateBps         *int             `json:"rate_bps" binding:"omitempty,gte=0,lte=10000"` // basis poin, 150 = 1.5%
+	Tiers           []FeeTierRequest `json:"tiers" binding:"omitempty,dive"`
+	MinFee          *money.Money     `json:"min_fee" binding:"omitempty,gte=0"`
+	MaxFee          *money.Money     `json:"max_fee" binding:"omitempty,gte=0"`
+	IsActive        *bool            `json:"is_active"` // default true
+}
+
+type FeeTierRequest struct {
+	UpTo       *money.Money `json:"up_to" binding:"omitempty,gt=0"` // kosong untuk tier terakhir
+	FlatAmount money.Money  `json:"flat_amount" binding:"gte=0"`
+	RateBps    int          `json:"rate_bps" binding:"gte=0,lte=10000"`
+}
+
+func (f *FeeRuleRequest) ToDomain() *V1Domains.FeeRuleDomain {
+	isActive := true
+	if f.IsActive != nil {
+		isActive = *f.IsActive
+	}
+
+	var tiers []V1Domains.FeeTierDomain
+	for _, tier := range f.Tiers {
+		tiers = append(tiers, V1Domains.FeeTierDomain{
+			UpTo:       tier.UpTo,
+			FlatAmount: tier.FlatAmount,
+			RateBps:    tier.RateBps,
+		})
+	}
+
+	return &V1Domains.FeeRuleDomain{
+		Name:            f.Name,
+		TransactionType: f.TransactionType,
+		ProductId:       f.ProductId,
+		FeeType:         f.FeeType,
+		FlatAmount:      f.FlatAmount,
+		RateBps:         f.RateBps,
+		Tiers:           tiers,
+		MinFee:          f.MinFee,
+		MaxFee:          f.MaxFee,
+		IsActive:        isActive,
+	}
+}
+
+type FeeRuleUriRequest struct {
+	Id int `uri:"id" binding:"required,gt=0"`
+}
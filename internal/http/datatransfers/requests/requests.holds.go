@@ -0,0 +1,49 @@
+package requests
+
+import (
+	"time"
+
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/pkg/money"
+)
+
+// HoldRequest memakai amount untuk hold withdraw, product_id dan quantity untuk hold pembelian
+type HoldRequest struct {
+	TransactionType string      `json:"transaction_type" binding:"required,oneof=withdraw purchase"`
+	Amount          money.Money `json:"amount" binding:"gte=0"`
+	ProductId       *int        `json:"product_id" binding:"omitempty,gt=0"`
+	Quantity        *int        `json:"quantity" binding:"omitempty,gt=0"`
+	ExpiresIn       int         `json:"expires_in" binding:"gte=0"`         // dalam detik, 0 berarti masa berlaku default
+	WalletId        string      `json:"wallet_id" binding:"omitempty,uuid"` // kosong berarti wallet default
+}
+
+func (h *HoldRequest) ToDomain() *V1Domains.HoldDomain {
+	return &V1Domains.HoldDomain{
+		WalletId:        h.WalletId,
+		TransactionType: h.TransactionType,
+		Amount:          h.Amount,
+		ProductId:       h.ProductId,
+		Quantity:        h.Quantity,
+	}
+}
+
+func (h *HoldRequest) ExpiresInDuration() time.Duration {
+	return time.Duration(h.ExpiresIn) * time.Second
+}
+
+// HoldCaptureRequest boleh kosong untuk capture penuh
+type HoldCaptureRequest struct {
+	Amount   *money.Money `json:"amount" binding:"omitempty,gt=0"`
+	Quantity *int         `json:"quantity" binding:"omitempty,gt=0"`
+}
+
+func (h *HoldCaptureRequest) ToDomain() V1Domains.HoldCaptureDomain {
+	return V1Domains.HoldCaptureDomain{
+		Amount:   h.Amount,
+		Quantity: h.Quantity,
+	}
+}
+
+type HoldUriRequest struct {
+	HoldId string `uri:"id" binding:"required,uuid"`
+}
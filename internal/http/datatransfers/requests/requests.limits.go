@@ -0,0 +1,30 @@
+package requests
+
+import (
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/pkg/money"
+)
+
+// LimitRequest mengganti seluruh override user, field yang tidak dikirim kembali memakai default global.
+// Nilai 0 berarti tanpa batas.
+type LimitRequest struct {
+	MaxSingleWithdrawal *money.Money `json:"max_single_withdrawal" binding:"omitempty,gte=0"`
+	DailyWithdrawal     *money.Money `json:"daily_withdrawal" binding:"omitempty,gte=0"`
+	MonthlyWithdrawal   *money.Money `json:"monthly_withdrawal" binding:"omitempty,gte=0"`
+	DailyPurchase       *money.Money `json:"daily_purchase" binding:"omitempty,gte=0"`
+	HourlyTransactions  *int         `json:"hourly_transactions" binding:"omitempty,gte=0"`
+}
+
+func (l *LimitRequest) ToDomain() *V1Domains.UserLimitDomain {
+	return &V1Domains.UserLimitDomain{
+		MaxSingleWithdrawal: l.MaxSingleWithdrawal,
+		DailyWithdrawal:     l.DailyWithdrawal,
+		MonthlyWithdrawal:   l.MonthlyWithdrawal,
+		DailyPurchase:       l.DailyPurchase,
+		HourlyTransactions:  l.HourlyTransactions,
+	}
+}
+
+type LimitUriRequest struct {
+	UserId string `uri:"user_id" binding:"required,uuid"`
+}
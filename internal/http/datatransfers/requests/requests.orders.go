@@ -0,0 +1,45 @@
+package requests
+
+import (
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/pkg/money"
+)
+
+type OrderRequest struct {
+	WalletId          string                 `json:"wallet_id" binding:"omitempty,uuid"` // kosong berarti wallet default
+	Items             []OrderItemRequest     `json:"items" binding:"required,min=1,max=50,dive"`
+	VoucherCode       string                 `json:"voucher_code" binding:"omitempty,max=32,printascii"` // tidak peka huruf besar kecil
+	RedeemPoints      money.Money            `json:"redeem_points" binding:"gte=0"`                      // poin yang dipakai membayar sebagian order
+	Description       string                 `json:"description" binding:"max=255"`
+	ExternalReference string                 `json:"external_reference" binding:"omitempty,max=64,printascii"` // unik per user
+	Metadata          map[string]interface{} `json:"metadata"`                                                 // ukurannya divalidasi di usecase
+}
+
+type OrderItemRequest struct {
+	ProductId int `json:"product_id" binding:"required"`
+	Quantity  int `json:"quantity" binding:"required,gt=0"`
+}
+
+func (o *OrderRequest) ToDomain() *V1Domains.OrderDomain {
+	items := make([]V1Domains.OrderItemDomain, 0, len(o.Items))
+	for i := range o.Items {
+		items = append(items, V1Domains.OrderItemDomain{
+			ProductId: &o.Items[i].ProductId,
+			Quantity:  o.Items[i].Quantity,
+		})
+	}
+
+	return &V1Domains.OrderDomain{
+		WalletId:          o.WalletId,
+		Items:             items,
+		VoucherCode:       optionalString(o.VoucherCode),
+		PointsRedeemed:    o.RedeemPoints,
+		Description:       optionalString(o.Description),
+		ExternalReference: optionalString(o.ExternalReference),
+		Metadata:          o.Metadata,
+	}
+}
+
+type OrderUriRequest struct {
+	OrderId string `uri:"id" binding:"required,uuid"`
+}
@@ -2,36 +2,40 @@ package requests
 
 import (
 	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/pkg/money"
 )
 
 type ProductRequest struct {
-	Name        string  `json:"name" binding:"required"`
-	Description string  `json:"description" binding:"required"`
-	Price       float64 `json:"price" binding:"required,gt=0"`  // price lebih besar dari 0
-	Stock       int     `json:"stock" binding:"required,gte=0"` // stock tidak negatif
-
+	Name        string      `json:"name" binding:"required"`
+	Description string      `json:"description" binding:"required"`
+	Category    string      `json:"category" binding:"max=50"`      // opsional
+	Price       money.Money `json:"price" binding:"required,gt=0"`  // price lebih besar dari 0
+	Stock       int         `json:"stock" binding:"required,gte=0"` // stock tidak negatif
 }
 
 func (productRequest *ProductRequest) ToDomain() *V1Domains.ProductDomain {
 	return &V1Domains.ProductDomain{
 		Name:        productRequest.Name,
 		Description: productRequest.Description,
+		Category:    optionalString(productRequest.Category),
 		Price:       productRequest.Price,
 		Stock:       productRequest.Stock,
 	}
 }
 
 type ProductUpdateRequest struct {
-	Name        string  `json:"name" binding:"required"`
-	Description string  `json:"description" binding:"required"`
-	Price       float64 `json:"price" binding:"required,gt=0"`  // price lebih besar dari 0
-	Stock       int     `json:"stock" binding:"required,gte=0"` // stock tidak negatif
+	Name        string      `json:"name" binding:"required"`
+	Description string      `json:"description" binding:"required"`
+	Category    string      `json:"category" binding:"max=50"`      // opsional
+	Price       money.Money `json:"price" binding:"required,gt=0"`  // price lebih besar dari 0
+	Stock       int         `json:"stock" binding:"required,gte=0"` // stock tidak negatif
 }
 
 func (p *ProductUpdateRequest) ToDomain() *V1Domains.ProductDomain {
 	return &V1Domains.ProductDomain{
 		Name:        p.Name,
 		Description: p.Description,
+		Category:    optionalString(p.Category),
 		Price:       p.Price,
 		Stock:       p.Stock,
 	}
@@ -0,0 +1,51 @@
+package requests
+
+import (
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/pkg/money"
+)
+
+// RewardRuleRequest dipakai untuk membuat maupun mengganti aturan reward.
+// product_id dan category kosong berarti aturan berlaku untuk semua produk.
+type RewardRuleRequest struct {
+	Name             string       `json:"name" binding:"required,max=100"`
+	RewardType       string       `json:"reward_type" binding:"required,oneof=points cashback"`
+	ProductId        *int         `json:"product_id" binding:"omitempty,gt=0"`
+	Category         string       `json:"category" binding:"max=50"`
+	RateBps          int          `json:"rate_bps" binding:"required,gt=0,lte=10000"`  // basis poin dari nominal yang dibayar, 100 = 1%
+	MonthlyCap       *money.Money `json:"monthly_cap" binding:"omitempty,gt=0"`        // kosong berarti tanpa batas
+	PointsExpiryDays *int         `json:"points_expiry_days" binding:"omitempty,gt=0"` // wajib untuk reward_type points
+	IsActive         *bool        `json:"is_active"`                                   // default true
+}
+
+func (r *RewardRuleRequest) ToDomain() *V1Domains.RewardRuleDomain {
+	isActive := true
+	if r.IsActive != nil {
+		isActive = *r.IsActive
+	}
+
+	return &V1Domains.RewardRuleDomain{
+		Name:             r.Name,
+		RewardType:       r.RewardType,
+		ProductId:        r.ProductId,
+		Category:         optionalString(r.Category),
+		RateBps:          r.RateBps,
+		MonthlyCap:       r.MonthlyCap,
+		PointsExpiryDays: r.PointsExpiryDays,
+		IsActive:         isActive,
+	}
+}
+
+type RewardRuleUriRequest struct {
+	Id int `uri:"id" binding:"required,gt=0"`
+}
+
+type PointEntriesQueryRequest struct {
+	WalletId string `form:"wallet_id" binding:"omitempty,uuid"` // kosong berarti wallet default
+}
+
+// PointConversionRequest menukar poin menjadi saldo wallet, 1 poin bernilai 1 satuan mata uang
+type PointConversionRequest struct {
+	WalletId string      `json:"wallet_id" binding:"omitempty,uuid"` // kosong berarti wallet default
+	Points   money.Money `json:"points" binding:"required,gt=0"`
+}
@@ -0,0 +1,53 @@
+package requests
+
+import (
+	"time"
+
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/pkg/money"
+)
+
+type ScheduleRequest struct {
+	RecipientUserId   string      `json:"recipient_user_id" binding:"omitempty,uuid"`
+	RecipientUsername string      `json:"recipient_username"`
+	RecipientEmail    string      `json:"recipient_email" binding:"omitempty,email"`
+	Amount            money.Money `json:"amount" binding:"required,gt=0"`
+	Frequency         string      `json:"frequency" binding:"required,oneof=once daily weekly monthly"`
+	StartAt           time.Time   `json:"start_at" binding:"required"` // RFC3339, misal 2024-01-31T09:00:00+07:00
+	EndAt             *time.Time  `json:"end_at"`                      // opsional, hanya untuk schedule berulang
+	MaxRuns           *int        `json:"max_runs" binding:"omitempty,gt=0"`
+}
+
+func (s *ScheduleRequest) ToDomain() *V1Domains.ScheduleDomain {
+	return &V1Domains.ScheduleDomain{
+		RecipientUserId:   s.RecipientUserId,
+		RecipientUsername: s.RecipientUsername,
+		RecipientEmail:    s.RecipientEmail,
+		Amount:            s.Amount,
+		Frequency:         s.Frequency,
+		StartAt:           s.StartAt,
+		EndAt:             s.EndAt,
+		MaxRuns:           s.MaxRuns,
+	}
+}
+
+// ScheduleUpdateRequest berisi field yang ingin diubah, field yang tidak dikirim tidak berubah
+type ScheduleUpdateRequest struct {
+	Amount  *money.Money `json:"amount" binding:"omitempty,gt=0"`
+	EndAt   *time.Time   `json:"end_at"`
+	MaxRuns *int         `json:"max_runs" binding:"omitempty,gt=0"`
+	Status  *string      `json:"status" binding:"omitempty,oneof=active paused"`
+}
+
+func (s *ScheduleUpdateRequest) ToDomain() V1Domains.ScheduleUpdateDomain {
+	return V1Domains.ScheduleUpdateDomain{
+		Amount:  s.Amount,
+		EndAt:   s.EndAt,
+		MaxRuns: s.MaxRuns,
+		Status:  s.Status,
+	}
+}
+
+type ScheduleUriRequest struct {
+	ScheduleId string `uri:"id" binding:"required,uuid"`
+}
@@ -0,0 +1,5 @@
+package requests
+
+type StatementUriRequest struct {
+	StatementId string `uri:"id" binding:"required,uuid"`
+}
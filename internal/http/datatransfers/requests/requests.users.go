@@ -9,6 +9,7 @@ type UserRequest struct {
 	Username string `json:"username" validate:"required"`
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required,min=8,containsany=!@#$%^&*()?"`
+	Timezone string `json:"timezone" validate:"omitempty,timezone"` // nama zona waktu IANA, misal Asia/Jakarta
 }
 
 // Mapping General Request to Domain User
@@ -17,6 +18,7 @@ func (user UserRequest) ToV1Domain() *V1Domains.UserDomain {
 		Username: user.Username,
 		Email:    user.Email,
 		Password: user.Password,
+		Timezone: user.Timezone,
 		RoleID:   2, // everyone who regis it's supposed to be users
 	}
 }
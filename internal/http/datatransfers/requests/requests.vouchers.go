@@ -0,0 +1,56 @@
+package requests
+
+import (
+	"time"
+
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/pkg/money"
+)
+
+// VoucherRequest dipakai untuk membuat maupun mengganti voucher.
+// Field yang tidak dipakai oleh discount_type diabaikan.
+type VoucherRequest struct {
+	Code                  string       `json:"code" binding:"required,max=32"` // disimpan dalam huruf besar
+	DiscountType          string       `json:"discount_type" binding:"required,oneof=percentage fixed"`
+	Amount                *money.Money `json:"amount" binding:"omitempty,gt=0"`             // hanya untuk fixed
+	RateBps               *int         `json:"rate_bps" binding:"omitempty,gt=0,lte=10000"` // basis poin, 1000 = 10%
+	MaxDiscount           *money.Money `json:"max_discount" binding:"omitempty,gt=0"`       // batas diskon percentage
+	MinSpend              money.Money  `json:"min_spend" binding:"gte=0"`
+	ProductId             *int         `json:"product_id" binding:"omitempty,gt=0"` // kosong berarti semua produk
+	StartsAt              *time.Time   `json:"starts_at"`                           // kosong berarti mulai sekarang
+	EndsAt                *time.Time   `json:"ends_at"`
+	MaxRedemptions        *int         `json:"max_redemptions" binding:"omitempty,gt=0"`
+	MaxRedemptionsPerUser *int         `json:"max_redemptions_per_user" binding:"omitempty,gt=0"`
+	IsActive              *bool        `json:"is_active"` // default true
+}
+
+func (v *VoucherRequest) ToDomain() *V1Domains.VoucherDomain {
+	isActive := true
+	if v.IsActive != nil {
+		isActive = *v.IsActive
+	}
+
+	var startsAt time.Time
+	if v.StartsAt != nil {
+		startsAt = *v.StartsAt
+	}
+
+	return &V1Domains.VoucherDomain{
+		Code:                  v.Code,
+		DiscountType:          v.DiscountType,
+		Amount:                v.Amount,
+		RateBps:               v.RateBps,
+		MaxDiscount:           v.MaxDiscount,
+		MinSpend:              v.MinSpend,
+		ProductId:             v.ProductId,
+		StartsAt:              startsAt,
+		EndsAt:                v.EndsAt,
+		MaxRedemptions:        v.MaxRedemptions,
+		MaxRedemptionsPerUser: v.MaxRedemptionsPerUser,
+		IsActive:              isActive,
+	}
+}
+
+type VoucherUriRequest struct {
+	Id int `uri:"id" binding:"required,gt=0"`
+}
@@ -0,0 +1,18 @@
+package requests
+
+type WalletRequest struct {
+	Name string `json:"name" binding:"required,max=50"`
+}
+
+type WalletFreezeRequest struct {
+	Direction string `json:"direction" binding:"required,oneof=outgoing incoming both"`
+	Reason    string `json:"reason" binding:"required,max=500"`
+}
+
+type WalletUnfreezeRequest struct {
+	Reason string `json:"reason" binding:"required,max=500"`
+}
+
+type WalletUriRequest struct {
+	WalletId string `uri:"id" binding:"required,uuid"`
+}
@@ -0,0 +1,38 @@
+package requests
+
+import (
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+)
+
+// WebhookRequest dipakai untuk membuat maupun mengganti endpoint webhook.
+// Secret kosong berarti dibuat server saat create dan tidak diubah saat update.
+type WebhookRequest struct {
+	URL        string   `json:"url" binding:"required,url,max=2048"`
+	EventTypes []string `json:"event_types" binding:"required,min=1,dive,required"`
+	Secret     string   `json:"secret" binding:"max=255"`
+	IsActive   *bool    `json:"is_active"` // default true
+}
+
+func (r *WebhookRequest) ToDomain(userId string) *V1Domains.WebhookEndpointDomain {
+	isActive := true
+	if r.IsActive != nil {
+		isActive = *r.IsActive
+	}
+
+	return &V1Domains.WebhookEndpointDomain{
+		UserId:     userId,
+		URL:        r.URL,
+		EventTypes: r.EventTypes,
+		Secret:     r.Secret,
+		IsActive:   isActive,
+	}
+}
+
+type WebhookUriRequest struct {
+	WebhookId string `uri:"id" binding:"required,uuid"`
+}
+
+type WebhookDeliveryUriRequest struct {
+	WebhookId  string `uri:"id" binding:"required,uuid"`
+	DeliveryId string `uri:"delivery_id" binding:"required,uuid"`
+}
@@ -0,0 +1,66 @@
+package responses
+
+import (
+	"time"
+
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/pkg/money"
+)
+
+type FeeRuleResponse struct {
+	Id              int               `json:"fee_rule_id"`
+	Name            string            `json:"name"`
+	TransactionType string            `json:"transaction_type"`
+	ProductId       *int              `json:"product_id"`
+	FeeType         string            `json:"fee_type"`
+	FlatAmount      *money.Money      `json:"flat_amount,omitempty"`
+	RateBps         *int              `json:"rate_bps,omitempty"`
+	Tiers           []FeeTierResponse `json:"tiers,omitempty"`
+	MinFee          *money.Money      `json:"min_fee"`
+	MaxFee          *money.Money      `json:"max_fee"`
+	IsActive        bool              `json:"is_active"`
+	CreatedAt       time.Time         `json:"created_at"`
+	UpdatedAt       time.Time         `json:"updated_at"`
+}
+
+type FeeTierResponse struct {
+	UpTo       *money.Money `json:"up_to"`
+	FlatAmount money.Money  `json:"flat_amount"`
+	RateBps    int          `json:"rate_bps"`
+}
+
+func FromFeeRuleDomainV1(f V1Domains.FeeRuleDomain) FeeRuleResponse {
+	response := FeeRuleResponse{
+		Id:              f.Id,
+		Name:            f.Name,
+		TransactionType: f.TransactionType,
+		ProductId:       f.ProductId,
+		FeeType:         f.FeeType,
+		FlatAmount:      f.FlatAmount,
+		RateBps:         f.RateBps,
+		MinFee:          f.MinFee,
+		MaxFee:          f.MaxFee,
+		IsActive:        f.IsActive,
+		CreatedAt:       f.CreatedAt,
+		UpdatedAt:       f.UpdatedAt,
+	}
+	for _, tier := range f.Tiers {
+		response.Tiers = append(response.Tiers, FeeTierResponse{
+			UpTo:       tier.UpTo,
+			FlatAmount: tier.FlatAmount,
+			RateBps:    tier.RateBps,
+		})
+	}
+
+	return response
+}
+
+func ToFeeRuleResponseList(domains []V1Domains.FeeRuleDomain) []FeeRuleResponse {
+	var result []FeeRuleResponse
+
+	for _, val := range domains {
+		result = append(result, FromFeeRuleDomainV1(val))
+	}
+
+	return result
+}
@@ -0,0 +1,53 @@
+package responses
+
+import (
+	"time"
+
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/pkg/money"
+)
+
+type HoldResponse struct {
+	Id              string               `json:"hold_id"`
+	WalletId        string               `json:"wallet_id"`
+	TransactionType string               `json:"transaction_type"`
+	ProductId       *int                 `json:"product_id,omitempty"`
+	Quantity        *int                 `json:"quantity,omitempty"`
+	UnitPrice       *money.Money         `json:"unit_price,omitempty"`
+	Amount          money.Money          `json:"amount"`
+	Fee             money.Money          `json:"fee"`
+	Status          string               `json:"status"`
+	CapturedAmount  *money.Money         `json:"captured_amount"`
+	TransactionId   *string              `json:"transaction_id"`
+	Transaction     *TransactionResponse `json:"transaction,omitempty"`
+	ExpiresAt       time.Time            `json:"expires_at"`
+	ClosedAt        *time.Time           `json:"closed_at"`
+	CreatedAt       time.Time            `json:"created_at"`
+	UpdatedAt       time.Time            `json:"updated_at"`
+}
+
+func FromHoldDomainV1(h V1Domains.HoldDomain) HoldResponse {
+	response := HoldResponse{
+		Id:              h.Id,
+		WalletId:        h.WalletId,
+		TransactionType: h.TransactionType,
+		ProductId:       h.ProductId,
+		Quantity:        h.Quantity,
+		UnitPrice:       h.UnitPrice,
+		Amount:          h.Amount,
+		Fee:             h.Fee,
+		Status:          h.Status,
+		CapturedAmount:  h.CapturedAmount,
+		TransactionId:   h.TransactionId,
+		ExpiresAt:       h.ExpiresAt,
+		ClosedAt:        h.ClosedAt,
+		CreatedAt:       h.CreatedAt,
+		UpdatedAt:       h.UpdatedAt,
+	}
+	if h.Transaction != nil {
+		transaction := FromTransactionDomainV1(*h.Transaction)
+		response.Transaction = &transaction
+	}
+
+	return response
+}
@@ -0,0 +1,50 @@
+package responses
+
+import (
+	"time"
+
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/pkg/money"
+)
+
+// LimitResponse berisi limit efektif, nilai 0 berarti tanpa batas
+type LimitResponse struct {
+	UserId              string      `json:"user_id"`
+	MaxSingleWithdrawal money.Money `json:"max_single_withdrawal"`
+	DailyWithdrawal     money.Money `json:"daily_withdrawal"`
+	MonthlyWithdrawal   money.Money `json:"monthly_withdrawal"`
+	DailyPurchase       money.Money `json:"daily_purchase"`
+	HourlyTransactions  int         `json:"hourly_transactions"`
+	Overridden          []string    `json:"overridden"`
+	UpdatedBy           *string     `json:"updated_by"`
+	UpdatedAt           *time.Time  `json:"updated_at"`
+}
+
+func FromUserLimitDomainV1(l V1Domains.UserLimitDomain) LimitResponse {
+	response := LimitResponse{
+		UserId:     l.UserId,
+		Overridden: l.Overridden,
+		UpdatedBy:  l.UpdatedBy,
+		UpdatedAt:  l.UpdatedAt,
+	}
+	if response.Overridden == nil {
+		response.Overridden = []string{}
+	}
+	if l.MaxSingleWithdrawal != nil {
+		response.MaxSingleWithdrawal = *l.MaxSingleWithdrawal
+	}
+	if l.DailyWithdrawal != nil {
+		response.DailyWithdrawal = *l.DailyWithdrawal
+	}
+	if l.MonthlyWithdrawal != nil {
+		response.MonthlyWithdrawal = *l.MonthlyWithdrawal
+	}
+	if l.DailyPurchase != nil {
+		response.DailyPurchase = *l.DailyPurchase
+	}
+	if l.HourlyTransactions != nil {
+		response.HourlyTransactions = *l.HourlyTransactions
+	}
+
+	return response
+}
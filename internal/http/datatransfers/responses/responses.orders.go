@@ -0,0 +1,91 @@
+package responses
+
+import (
+	"time"
+
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/pkg/money"
+)
+
+type OrderResponse struct {
+	Id                string                 `json:"order_id"`
+	WalletId          string                 `json:"wallet_id"`
+	TransactionId     string                 `json:"transaction_id"`
+	Items             []OrderItemResponse    `json:"items"`
+	TotalAmount       money.Money            `json:"total_amount"` // sebelum diskon
+	Discount          money.Money            `json:"discount"`
+	VoucherCode       *string                `json:"voucher_code,omitempty"`
+	PointsRedeemed    money.Money            `json:"points_redeemed"`
+	PointsEarned      money.Money            `json:"points_earned"`
+	Cashback          money.Money            `json:"cashback"`
+	Fee               money.Money            `json:"fee"`
+	FeeBreakdown      []FeeComponentResponse `json:"fee_breakdown,omitempty"`
+	Status            string                 `json:"status"`
+	Description       *string                `json:"description,omitempty"`
+	ExternalReference *string                `json:"external_reference,omitempty"`
+	Metadata          map[string]interface{} `json:"metadata,omitempty"`
+	CreatedAt         time.Time              `json:"created_at"`
+}
+
+// OrderItemResponse menampilkan nama dan harga satuan saat dibeli, bukan data produk terkini
+type OrderItemResponse struct {
+	Id          string      `json:"order_item_id"`
+	ProductId   *int        `json:"product_id"`
+	ProductName string      `json:"product_name"`
+	Quantity    int         `json:"quantity"`
+	UnitPrice   money.Money `json:"unit_price"`
+	Subtotal    money.Money `json:"subtotal"`
+}
+
+func FromOrderDomainV1(o V1Domains.OrderDomain) OrderResponse {
+	response := OrderResponse{
+		Id:                o.Id,
+		WalletId:          o.WalletId,
+		TransactionId:     o.TransactionId,
+		Items:             []OrderItemResponse{},
+		TotalAmount:       o.TotalAmount,
+		Discount:          o.Discount,
+		VoucherCode:       o.VoucherCode,
+		PointsRedeemed:    o.PointsRedeemed,
+		PointsEarned:      o.PointsEarned,
+		Cashback:          o.Cashback,
+		Fee:               o.Fee,
+		Status:            o.Status,
+		Description:       o.Description,
+		ExternalReference: o.ExternalReference,
+		Metadata:          o.Metadata,
+		CreatedAt:         o.CreatedAt,
+	}
+
+	for _, item := range o.Items {
+		response.Items = append(response.Items, OrderItemResponse{
+			Id:          item.Id,
+			ProductId:   item.ProductId,
+			ProductName: item.ProductName,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice,
+			Subtotal:    item.Subtotal,
+		})
+	}
+
+	for _, component := range o.FeeBreakdown {
+		response.FeeBreakdown = append(response.FeeBreakdown, FeeComponentResponse{
+			FeeRuleId: component.FeeRuleId,
+			Name:      component.Name,
+			FeeType:   component.FeeType,
+			Amount:    component.Amount,
+		})
+	}
+
+	return response
+}
+
+func ToOrderResponseList(domains []V1Domains.OrderDomain) []OrderResponse {
+	var result []OrderResponse
+
+	for _, val := range domains {
+		result = append(result, FromOrderDomainV1(val))
+	}
+
+	return result
+}
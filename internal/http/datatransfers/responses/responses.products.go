@@ -4,16 +4,18 @@ import (
 	"time"
 
 	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/pkg/money"
 )
 
 type ProductResponse struct {
-	Id          int        `json:"product_id"`
-	Name        string     `json:"name"`
-	Description string     `json:"description"`
-	Price       float64    `json:"price"`
-	Stock       int        `json:"stock"`
-	CreatedAt   time.Time  `json:"created_at"`
-	UpdatedAt   *time.Time `json:"updated_at"`
+	Id          int         `json:"product_id"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Category    *string     `json:"category"`
+	Price       money.Money `json:"price"`
+	Stock       int         `json:"stock"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   *time.Time  `json:"updated_at"`
 }
 
 func FromProductDomainV1(b V1Domains.ProductDomain) ProductResponse {
@@ -21,6 +23,7 @@ func FromProductDomainV1(b V1Domains.ProductDomain) ProductResponse {
 		Id:          b.Id,
 		Name:        b.Name,
 		Description: b.Description,
+		Category:    b.Category,
 		Price:       b.Price,
 		Stock:       b.Stock,
 		CreatedAt:   b.CreatedAt,
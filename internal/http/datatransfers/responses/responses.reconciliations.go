@@ -0,0 +1,53 @@
+package responses
+
+import (
+	"time"
+
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/pkg/money"
+)
+
+type ReconciliationRunResponse struct {
+	Id             string                       `json:"run_id"`
+	Trigger        string                       `json:"trigger"`
+	Status         string                       `json:"status"`
+	WalletsChecked int                          `json:"wallets_checked"`
+	Discrepancies  int                          `json:"discrepancies"`
+	ErrorMessage   *string                      `json:"error_message"`
+	StartedAt      time.Time                    `json:"started_at"`
+	FinishedAt     *time.Time                   `json:"finished_at"`
+	Items          []ReconciliationItemResponse `json:"items"`
+}
+
+type ReconciliationItemResponse struct {
+	WalletId        string      `json:"wallet_id"`
+	UserId          string      `json:"user_id"`
+	RecordedBalance money.Money `json:"recorded_balance"`
+	ExpectedBalance money.Money `json:"expected_balance"`
+	Difference      money.Money `json:"difference"`
+}
+
+func FromReconciliationRunDomainV1(r V1Domains.ReconciliationRunDomain) ReconciliationRunResponse {
+	response := ReconciliationRunResponse{
+		Id:             r.Id,
+		Trigger:        r.Trigger,
+		Status:         r.Status,
+		WalletsChecked: r.WalletsChecked,
+		Discrepancies:  r.Discrepancies,
+		ErrorMessage:   r.ErrorMessage,
+		StartedAt:      r.StartedAt,
+		FinishedAt:     r.FinishedAt,
+		Items:          []ReconciliationItemResponse{}, // selalu array agar klien tidak perlu memeriksa null
+	}
+	for _, item := range r.Items {
+		response.Items = append(response.Items, ReconciliationItemResponse{
+			WalletId:        item.WalletId,
+			UserId:          item.UserId,
+			RecordedBalance: item.RecordedBalance,
+			ExpectedBalance: item.ExpectedBalance,
+			Difference:      item.Difference,
+		})
+	}
+
+	return response
+}
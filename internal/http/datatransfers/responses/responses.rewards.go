@@ -0,0 +1,82 @@
+package responses
+
+import (
+	"time"
+
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/pkg/money"
+)
+
+type RewardRuleResponse struct {
+	Id               int          `json:"reward_rule_id"`
+	Name             string       `json:"name"`
+	RewardType       string       `json:"reward_type"`
+	ProductId        *int         `json:"product_id"`
+	Category         *string      `json:"category"`
+	RateBps          int          `json:"rate_bps"`
+	MonthlyCap       *money.Money `json:"monthly_cap"`
+	PointsExpiryDays *int         `json:"points_expiry_days,omitempty"`
+	IsActive         bool         `json:"is_active"`
+	CreatedAt        time.Time    `json:"created_at"`
+	UpdatedAt        time.Time    `json:"updated_at"`
+}
+
+type PointEntryResponse struct {
+	Id            string       `json:"point_entry_id"`
+	WalletId      string       `json:"wallet_id"`
+	EntryType     string       `json:"entry_type"`
+	Points        money.Money  `json:"points"`
+	Remaining     *money.Money `json:"remaining,omitempty"`
+	ExpiresAt     *time.Time   `json:"expires_at,omitempty"`
+	TransactionId *string      `json:"transaction_id,omitempty"`
+	CreatedAt     time.Time    `json:"created_at"`
+}
+
+func FromRewardRuleDomainV1(r V1Domains.RewardRuleDomain) RewardRuleResponse {
+	return RewardRuleResponse{
+		Id:               r.Id,
+		Name:             r.Name,
+		RewardType:       r.RewardType,
+		ProductId:        r.ProductId,
+		Category:         r.Category,
+		RateBps:          r.RateBps,
+		MonthlyCap:       r.MonthlyCap,
+		PointsExpiryDays: r.PointsExpiryDays,
+		IsActive:         r.IsActive,
+		CreatedAt:        r.CreatedAt,
+		UpdatedAt:        r.UpdatedAt,
+	}
+}
+
+func ToRewardRuleResponseList(domains []V1Domains.RewardRuleDomain) []RewardRuleResponse {
+	var result []RewardRuleResponse
+
+	for _, val := range domains {
+		result = append(result, FromRewardRuleDomainV1(val))
+	}
+
+	return result
+}
+
+func FromPointEntryDomainV1(p V1Domains.PointEntryDomain) PointEntryResponse {
+	return PointEntryResponse{
+		Id:            p.Id,
+		WalletId:      p.WalletId,
+		EntryType:     p.EntryType,
+		Points:        p.Points,
+		Remaining:     p.Remaining,
+		ExpiresAt:     p.ExpiresAt,
+		TransactionId: p.TransactionId,
+		CreatedAt:     p.CreatedAt,
+	}
+}
+
+func ToPointEntryResponseList(domains []V1Domains.PointEntryDomain) []PointEntryResponse {
+	var result []PointEntryResponse
+
+	for _, val := range domains {
+		result = append(result, FromPointEntryDomainV1(val))
+	}
+
+	return result
+}
@@ -0,0 +1,82 @@
+package responses
+
+import (
+	"time"
+
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/pkg/money"
+)
+
+type ScheduleResponse struct {
+	Id              string                `json:"schedule_id"`
+	RecipientUserId string                `json:"recipient_user_id"`
+	Amount          money.Money           `json:"amount"`
+	Frequency       string                `json:"frequency"`
+	StartAt         time.Time             `json:"start_at"`
+	EndAt           *time.Time            `json:"end_at"`
+	MaxRuns         *int                  `json:"max_runs"`
+	OccurrenceCount int                   `json:"occurrence_count"`
+	Attempt         int                   `json:"attempt"`
+	NextRunAt       *time.Time            `json:"next_run_at"`
+	Status          string                `json:"status"`
+	LastError       *string               `json:"last_error"`
+	LastRunAt       *time.Time            `json:"last_run_at"`
+	Runs            []ScheduleRunResponse `json:"runs,omitempty"`
+	CreatedAt       time.Time             `json:"created_at"`
+	UpdatedAt       time.Time             `json:"updated_at"`
+}
+
+type ScheduleRunResponse struct {
+	Id            int64     `json:"run_id"`
+	Occurrence    int       `json:"occurrence"`
+	ScheduledFor  time.Time `json:"scheduled_for"`
+	Attempt       int       `json:"attempt"`
+	Status        string    `json:"status"`
+	TransactionId *string   `json:"transaction_id"`
+	Error         *string   `json:"error"`
+	ExecutedAt    time.Time `json:"executed_at"`
+}
+
+func FromScheduleDomainV1(s V1Domains.ScheduleDomain) ScheduleResponse {
+	response := ScheduleResponse{
+		Id:              s.Id,
+		RecipientUserId: s.RecipientUserId,
+		Amount:          s.Amount,
+		Frequency:       s.Frequency,
+		StartAt:         s.StartAt,
+		EndAt:           s.EndAt,
+		MaxRuns:         s.MaxRuns,
+		OccurrenceCount: s.OccurrenceCount,
+		Attempt:         s.Attempt,
+		NextRunAt:       s.NextRunAt,
+		Status:          s.Status,
+		LastError:       s.LastError,
+		LastRunAt:       s.LastRunAt,
+		CreatedAt:       s.CreatedAt,
+		UpdatedAt:       s.UpdatedAt,
+	}
+	for _, run := range s.Runs {
+		response.Runs = append(response.Runs, ScheduleRunResponse{
+			Id:            run.Id,
+			Occurrence:    run.Occurrence,
+			ScheduledFor:  run.ScheduledFor,
+			Attempt:       run.Attempt,
+			Status:        run.Status,
+			TransactionId: run.TransactionId,
+			Error:         run.Error,
+			ExecutedAt:    run.ExecutedAt,
+		})
+	}
+
+	return response
+}
+
+func ToScheduleResponseList(domains []V1Domains.ScheduleDomain) []ScheduleResponse {
+	var result []ScheduleResponse
+
+	for _, val := range domains {
+		result = append(result, FromScheduleDomainV1(val))
+	}
+
+	return result
+}
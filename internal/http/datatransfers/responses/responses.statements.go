@@ -0,0 +1,294 @@
+package responses
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+	"github.com/snykk/transaction-api/pkg/money"
+)
+
+// StatementEncoder menulis statement ke io.Writer dalam satu format tertentu.
+type StatementEncoder interface {
+	V1Domains.StatementWriter
+	ContentType() string
+	FileExtension() string
+}
+
+// NewStatementEncoder mengembalikan encoder sesuai format, format yang tidak dikenal memakai csv
+func NewStatementEncoder(format string, w io.Writer, currency string) StatementEncoder {
+	switch format {
+	case constants.StatementFormatOFX:
+		return &ofxStatementEncoder{w: w, currency: currency}
+	case constants.StatementFormatJSON:
+		return &jsonStatementEncoder{w: w}
+	default:
+		return &csvStatementEncoder{w: csv.NewWriter(w)}
+	}
+}
+
+type StatementEntryResponse struct {
+	EntryId         int64       `json:"entry_id"`
+	TransactionId   string      `json:"transaction_id"`
+	TransactionType string      `json:"transaction_type"`
+	Status          string      `json:"status"`
+	ProductName     *string     `json:"product_name,omitempty"`
+	Quantity        *int        `json:"quantity,omitempty"`
+	Direction       string      `json:"direction"`
+	Amount          money.Money `json:"amount"`
+	Balance         money.Money `json:"balance"`
+	PostedAt        time.Time   `json:"posted_at"`
+}
+
+func FromStatementEntryDomainV1(e V1Domains.StatementEntryDomain) StatementEntryResponse {
+	return StatementEntryResponse{
+		EntryId:         e.EntryId,
+		TransactionId:   e.TransactionId,
+		TransactionType: e.TransactionType,
+		Status:          e.Status,
+		ProductName:     e.ProductName,
+		Quantity:        e.Quantity,
+		Direction:       e.Direction,
+		Amount:          e.Amount,
+		Balance:         e.Balance,
+		PostedAt:        e.PostedAt,
+	}
+}
+
+// csvStatementEncoder menulis baris saldo awal, satu baris per mutasi, lalu baris saldo akhir
+type csvStatementEncoder struct {
+	w *csv.Writer
+}
+
+func (e *csvStatementEncoder) ContentType() string   { return "text/csv; charset=utf-8" }
+func (e *csvStatementEncoder) FileExtension() string { return "csv" }
+
+func (e *csvStatementEncoder) Begin(statement V1Domains.StatementDomain) error {
+	if err := e.w.Write([]string{"posted_at", "transaction_id", "transaction_type", "status", "description", "debit", "credit", "balance"}); err != nil {
+		return err
+	}
+	if err := e.w.Write([]string{statement.From.Format(time.RFC3339), "", "", "", "Opening balance", "", "", statement.OpeningBalance.String()}); err != nil {
+		return err
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func (e *csvStatementEncoder) Entry(entry V1Domains.StatementEntryDomain) error {
+	debit, credit := "", entry.Amount.String()
+	if entry.Direction == constants.LedgerDirectionDebit {
+		debit, credit = credit, ""
+	}
+
+	if err := e.w.Write([]string{
+		entry.PostedAt.Format(time.RFC3339),
+		entry.TransactionId,
+		entry.TransactionType,
+		entry.Status,
+		statementEntryDescription(entry),
+		debit,
+		credit,
+		entry.Balance.String(),
+	}); err != nil {
+		return err
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func (e *csvStatementEncoder) End(statement V1Domains.StatementDomain) error {
+	if err := e.w.Write([]string{statement.To.Format(time.RFC3339), "", "", "", "Closing balance", "", "", statement.ClosingBalance.String()}); err != nil {
+		return err
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// jsonStatementEncoder menulis satu objek JSON secara bertahap, entries ditulis satu per satu
+type jsonStatementEncoder struct {
+	w       io.Writer
+	entries int
+}
+
+func (e *jsonStatementEncoder) ContentType() string   { return "application/json; charset=utf-8" }
+func (e *jsonStatementEncoder) FileExtension() string { return "json" }
+
+func (e *jsonStatementEncoder) Begin(statement V1Domains.StatementDomain) error {
+	header, err := json.Marshal(map[string]interface{}{
+		"wallet_id":       statement.WalletId,
+		"from":            statement.From,
+		"to":              statement.To,
+		"opening_balance": statement.OpeningBalance,
+	})
+	if err != nil {
+		return err
+	}
+
+	// buang kurung tutup agar field entries bisa disambung setelahnya
+	_, err = fmt.Fprintf(e.w, `%s,"entries":[`, header[:len(header)-1])
+	return err
+}
+
+func (e *jsonStatementEncoder) Entry(entry V1Domains.StatementEntryDomain) error {
+	row, err := json.Marshal(FromStatementEntryDomainV1(entry))
+	if err != nil {
+		return err
+	}
+
+	if e.entries > 0 {
+		if _, err = io.WriteString(e.w, ","); err != nil {
+			return err
+		}
+	}
+	e.entries++
+
+	_, err = e.w.Write(row)
+	return err
+}
+
+func (e *jsonStatementEncoder) End(statement V1Domains.StatementDomain) error {
+	closingBalance, err := json.Marshal(statement.ClosingBalance)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(e.w, `],"closing_balance":%s}`, closingBalance)
+	return err
+}
+
+// ofxStatementEncoder menulis OFX 2.2 (XML). Saldo awal dimasukkan ke BALLIST karena
+// OFX hanya punya LEDGERBAL untuk saldo akhir.
+type ofxStatementEncoder struct {
+	w        io.Writer
+	currency string
+}
+
+const ofxDateLayout = "20060102150405"
+
+func (e *ofxStatementEncoder) ContentType() string   { return "application/x-ofx" }
+func (e *ofxStatementEncoder) FileExtension() string { return "ofx" }
+
+func (e *ofxStatementEncoder) Begin(statement V1Domains.StatementDomain) error {
+	_, err := fmt.Fprintf(e.w, `<?xml version="1.0" encoding="UTF-8"?>
+<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>
+<OFX>
+<BANKMSGSRSV1>
+<STMTTRNRS>
+<TRNUID>0</TRNUID>
+<STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>
+<STMTRS>
+<CURDEF>%s</CURDEF>
+<BANKACCTFROM><BANKID>%s</BANKID><ACCTID>%s</ACCTID><ACCTTYPE>CHECKING</ACCTTYPE></BANKACCTFROM>
+<BANKTRANLIST>
+<DTSTART>%s</DTSTART>
+<DTEND>%s</DTEND>
+`, ofxEscape(e.currency), constants.StatementOFXBankId, ofxEscape(statement.WalletId), statement.From.UTC().Format(ofxDateLayout), statement.To.UTC().Format(ofxDateLayout))
+	return err
+}
+
+func (e *ofxStatementEncoder) Entry(entry V1Domains.StatementEntryDomain) error {
+	trnType, amount := "CREDIT", entry.Amount
+	if entry.Direction == constants.LedgerDirectionDebit {
+		trnType, amount = "DEBIT", -entry.Amount
+	}
+
+	_, err := fmt.Fprintf(e.w, "<STMTTRN><TRNTYPE>%s</TRNTYPE><DTPOSTED>%s</DTPOSTED><TRNAMT>%s</TRNAMT><FITID>%s</FITID><NAME>%s</NAME><MEMO>%s</MEMO></STMTTRN>\n",
+		trnType,
+		entry.PostedAt.UTC().Format(ofxDateLayout),
+		amount.String(),
+		strconv.FormatInt(entry.EntryId, 10),
+		ofxEscape(entry.TransactionType),
+		ofxEscape(fmt.Sprintf("%s; transaction %s; balance %s", statementEntryDescription(entry), entry.TransactionId, entry.Balance.String())),
+	)
+	return err
+}
+
+func (e *ofxStatementEncoder) End(statement V1Domains.StatementDomain) error {
+	_, err := fmt.Fprintf(e.w, `</BANKTRANLIST>
+<LEDGERBAL><BALAMT>%s</BALAMT><DTASOF>%s</DTASOF></LEDGERBAL>
+<BALLIST><BAL><NAME>Opening balance</NAME><DESC>Balance at %s</DESC><BALTYPE>DOLLAR</BALTYPE><VALUE>%s</VALUE></BAL></BALLIST>
+</STMTRS>
+</STMTTRNRS>
+</BANKMSGSRSV1>
+</OFX>
+`, statement.ClosingBalance.String(), statement.To.UTC().Format(ofxDateLayout), statement.From.UTC().Format(ofxDateLayout), statement.OpeningBalance.String())
+	return err
+}
+
+func ofxEscape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+// statementEntryDescription membuat keterangan singkat untuk satu mutasi
+func statementEntryDescription(entry V1Domains.StatementEntryDomain) string {
+	description := entry.TransactionType
+	if entry.ProductName != nil && entry.Quantity != nil {
+		description = fmt.Sprintf("%s %dx %s", description, *entry.Quantity, *entry.ProductName)
+	}
+	return description
+}
+
+type MonthlyStatementResponse struct {
+	Id             string                   `json:"statement_id"`
+	WalletId       string                   `json:"wallet_id"`
+	Period         string                   `json:"period"`
+	Timezone       string                   `json:"timezone"`
+	PeriodStart    time.Time                `json:"period_start"`
+	PeriodEnd      time.Time                `json:"period_end"`
+	OpeningBalance money.Money              `json:"opening_balance"`
+	ClosingBalance money.Money              `json:"closing_balance"`
+	Totals         []StatementTotalResponse `json:"totals,omitempty"`
+	EmailedAt      *time.Time               `json:"emailed_at"`
+	CreatedAt      time.Time                `json:"created_at"`
+}
+
+type StatementTotalResponse struct {
+	TransactionType string      `json:"transaction_type"`
+	Count           int         `json:"count"`
+	TotalCredit     money.Money `json:"total_credit"`
+	TotalDebit      money.Money `json:"total_debit"`
+}
+
+func FromMonthlyStatementDomainV1(s V1Domains.MonthlyStatementDomain) MonthlyStatementResponse {
+	response := MonthlyStatementResponse{
+		Id:             s.Id,
+		WalletId:       s.WalletId,
+		Period:         s.Period,
+		Timezone:       s.Timezone,
+		PeriodStart:    s.PeriodStart,
+		PeriodEnd:      s.PeriodEnd,
+		OpeningBalance: s.OpeningBalance,
+		ClosingBalance: s.ClosingBalance,
+		EmailedAt:      s.EmailedAt,
+		CreatedAt:      s.CreatedAt,
+	}
+	for _, total := range s.Totals {
+		response.Totals = append(response.Totals, StatementTotalResponse{
+			TransactionType: total.TransactionType,
+			Count:           total.Count,
+			TotalCredit:     total.TotalCredit,
+			TotalDebit:      total.TotalDebit,
+		})
+	}
+
+	return response
+}
+
+func ToMonthlyStatementResponseList(domains []V1Domains.MonthlyStatementDomain) []MonthlyStatementResponse {
+	var result []MonthlyStatementResponse
+
+	for _, val := range domains {
+		result = append(result, FromMonthlyStatementDomainV1(val))
+	}
+
+	return result
+}
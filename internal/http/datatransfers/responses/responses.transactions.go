@@ -4,32 +4,125 @@ import (
 	"time"
 
 	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/pkg/money"
 )
 
 type TransactionResponse struct {
-	Id              string                   `json:"transaction_id"`
-	WalletId        string                   `json:"wallet_id"`
-	Wallet          *V1Domains.WalletDomain  `json:"wallet,omitempty"`
-	ProductId       *int                     `json:"product_id,omitempty"`
-	Product         *V1Domains.ProductDomain `json:"product,omitempty"`
-	Amount          float64                  `json:"amount"`
-	Quantity        *int                     `json:"quantity,omitempty"`
-	TransactionType string                   `json:"transaction_type"`
-	CreatedAt       time.Time                `json:"created_at"`
-	UpdatedAt       *time.Time               `json:"updated_at,omitempty"`
+	Id                   string                      `json:"transaction_id"`
+	WalletId             string                      `json:"wallet_id"`
+	Wallet               *WalletResponse             `json:"wallet,omitempty"`
+	ProductId            *int                        `json:"product_id,omitempty"`
+	Product              *TransactionProductResponse `json:"product,omitempty"`
+	Amount               money.Money                 `json:"amount"`
+	Discount             *money.Money                `json:"discount,omitempty"` // hanya untuk pembelian dengan voucher
+	VoucherCode          *string                     `json:"voucher_code,omitempty"`
+	PointsRedeemed       *money.Money                `json:"points_redeemed,omitempty"` // poin yang dipakai pembelian atau dikonversi
+	PointsEarned         *money.Money                `json:"points_earned,omitempty"`
+	Cashback             *money.Money                `json:"cashback,omitempty"` // cashback pembelian, dikreditkan lewat transaksi cashback tersendiri
+	Clawback             *money.Money                `json:"clawback,omitempty"` // dana refund yang ditarik kembali karena cashback dan poin pembelian
+	Fee                  money.Money                 `json:"fee"`
+	FeeBreakdown         []FeeComponentResponse      `json:"fee_breakdown,omitempty"`
+	Quantity             *int                        `json:"quantity,omitempty"`
+	TransactionType      string                      `json:"transaction_type"`
+	RelatedTransactionId *string                     `json:"related_transaction_id,omitempty"`
+	Status               string                      `json:"status"`
+	FailureReason        *string                     `json:"failure_reason,omitempty"`
+	CompletedAt          *time.Time                  `json:"completed_at,omitempty"`
+	FailedAt             *time.Time                  `json:"failed_at,omitempty"`
+	CancelledAt          *time.Time                  `json:"cancelled_at,omitempty"`
+	Description          *string                     `json:"description,omitempty"`
+	ExternalReference    *string                     `json:"external_reference,omitempty"`
+	Metadata             map[string]interface{}      `json:"metadata,omitempty"`
+	CreatedAt            time.Time                   `json:"created_at"`
+	UpdatedAt            *time.Time                  `json:"updated_at,omitempty"`
+}
+
+// TransactionProductResponse adalah snapshot produk saat transaksi terjadi,
+// harga yang ditampilkan adalah harga satuan saat itu, bukan harga produk terkini
+type TransactionProductResponse struct {
+	Id          int         `json:"product_id"`
+	Name        string      `json:"name"`
+	Description string      `json:"description,omitempty"`
+	UnitPrice   money.Money `json:"unit_price"`
+}
+
+// FeeComponentResponse adalah bagian fee dari satu aturan, dijumlahkan menjadi field fee
+type FeeComponentResponse struct {
+	FeeRuleId int         `json:"fee_rule_id"`
+	Name      string      `json:"name"`
+	FeeType   string      `json:"fee_type"`
+	Amount    money.Money `json:"amount"`
 }
 
 func FromTransactionDomainV1(b V1Domains.TransactionDomain) TransactionResponse {
-	return TransactionResponse{
-		Id:              b.Id,
-		WalletId:        b.WalletId,
-		ProductId:       b.ProductId,
-		Amount:          b.Amount,
-		Quantity:        b.Quantity,
-		TransactionType: b.TransactionType,
-		CreatedAt:       b.CreatedAt,
-		UpdatedAt:       &b.UpdatedAt,
+	response := TransactionResponse{
+		Id:                   b.Id,
+		WalletId:             b.WalletId,
+		ProductId:            b.ProductId,
+		Amount:               b.Amount,
+		VoucherCode:          b.VoucherCode,
+		Fee:                  b.Fee,
+		Quantity:             b.Quantity,
+		TransactionType:      b.TransactionType,
+		RelatedTransactionId: b.RelatedTransactionId,
+		Status:               b.Status,
+		FailureReason:        b.FailureReason,
+		CompletedAt:          b.CompletedAt,
+		FailedAt:             b.FailedAt,
+		CancelledAt:          b.CancelledAt,
+		Description:          b.Description,
+		ExternalReference:    b.ExternalReference,
+		Metadata:             b.Metadata,
+		CreatedAt:            b.CreatedAt,
+		UpdatedAt:            &b.UpdatedAt,
+	}
+
+	if b.Discount.IsPositive() {
+		discount := b.Discount
+		response.Discount = &discount
+	}
+	if b.PointsRedeemed.IsPositive() {
+		pointsRedeemed := b.PointsRedeemed
+		response.PointsRedeemed = &pointsRedeemed
+	}
+	if b.PointsEarned.IsPositive() {
+		pointsEarned := b.PointsEarned
+		response.PointsEarned = &pointsEarned
 	}
+	if b.Cashback.IsPositive() {
+		cashback := b.Cashback
+		response.Cashback = &cashback
+	}
+	if b.Clawback.IsPositive() {
+		clawback := b.Clawback
+		response.Clawback = &clawback
+	}
+
+	for _, component := range b.FeeBreakdown {
+		response.FeeBreakdown = append(response.FeeBreakdown, FeeComponentResponse{
+			FeeRuleId: component.FeeRuleId,
+			Name:      component.Name,
+			FeeType:   component.FeeType,
+			Amount:    component.Amount,
+		})
+	}
+
+	// wallet dan produk hanya ditampilkan jika query mengisinya
+	if b.Wallet.Id != "" {
+		wallet := FromWalletDomainV1(b.Wallet)
+		wallet.UserId = b.Wallet.UserId
+		response.Wallet = &wallet
+	}
+	if b.ProductId != nil && b.Product.Name != "" {
+		response.Product = &TransactionProductResponse{
+			Id:          b.Product.Id,
+			Name:        b.Product.Name,
+			Description: b.Product.Description,
+			UnitPrice:   b.Product.Price,
+		}
+	}
+
+	return response
 }
 
 func ToTransactionResponseList(domains []V1Domains.TransactionDomain) []TransactionResponse {
@@ -41,3 +134,40 @@ func ToTransactionResponseList(domains []V1Domains.TransactionDomain) []Transact
 
 	return result
 }
+
+type TransactionPageResponse struct {
+	Transactions []TransactionResponse `json:"transactions"`
+	NextCursor   *string               `json:"next_cursor"`
+}
+
+func FromTransactionPageDomainV1(page V1Domains.TransactionPageDomain) TransactionPageResponse {
+	result := TransactionPageResponse{
+		Transactions: ToTransactionResponseList(page.Transactions),
+	}
+
+	// halaman kosong tetap dikirim sebagai array, bukan null
+	if result.Transactions == nil {
+		result.Transactions = []TransactionResponse{}
+	}
+
+	if page.NextCursor != nil {
+		nextCursor := page.NextCursor.Encode()
+		result.NextCursor = &nextCursor
+	}
+
+	return result
+}
+
+type TransferResponse struct {
+	Transaction       TransactionResponse `json:"transaction"`
+	RecipientUserId   string              `json:"recipient_user_id"`
+	RecipientWalletId string              `json:"recipient_wallet_id"`
+}
+
+func FromTransferDomainV1(b V1Domains.TransferDomain) TransferResponse {
+	return TransferResponse{
+		Transaction:       FromTransactionDomainV1(b.Outgoing),
+		RecipientUserId:   b.RecipientUserId,
+		RecipientWalletId: b.Incoming.WalletId,
+	}
+}
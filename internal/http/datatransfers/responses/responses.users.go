@@ -12,6 +12,7 @@ type UserResponse struct {
 	Email     string     `json:"email"`
 	Password  string     `json:"password,omitempty"`
 	RoleId    int        `json:"role_id"`
+	Timezone  string     `json:"timezone,omitempty"`
 	Token     string     `json:"token,omitempty"`
 	CreatedAt time.Time  `json:"created_at"`
 	UpdatedAt *time.Time `json:"updated_at"`
@@ -24,6 +25,7 @@ func (u *UserResponse) ToV1Domain() V1Domains.UserDomain {
 		Password:  u.Password,
 		Email:     u.Email,
 		RoleID:    u.RoleId,
+		Timezone:  u.Timezone,
 		CreatedAt: u.CreatedAt,
 		UpdatedAt: u.UpdatedAt,
 	}
@@ -37,6 +39,7 @@ func FromUserDomainV1(u V1Domains.UserDomain) UserResponse {
 		Password:  u.Password,
 		Token:     u.Token,
 		RoleId:    u.RoleID,
+		Timezone:  u.Timezone,
 		CreatedAt: u.CreatedAt,
 		UpdatedAt: u.UpdatedAt,
 	}
@@ -0,0 +1,58 @@
+package responses
+
+import (
+	"time"
+
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/pkg/money"
+)
+
+type VoucherResponse struct {
+	Id                    int          `json:"voucher_id"`
+	Code                  string       `json:"code"`
+	DiscountType          string       `json:"discount_type"`
+	Amount                *money.Money `json:"amount,omitempty"`
+	RateBps               *int         `json:"rate_bps,omitempty"`
+	MaxDiscount           *money.Money `json:"max_discount,omitempty"`
+	MinSpend              money.Money  `json:"min_spend"`
+	ProductId             *int         `json:"product_id"`
+	StartsAt              time.Time    `json:"starts_at"`
+	EndsAt                *time.Time   `json:"ends_at"`
+	MaxRedemptions        *int         `json:"max_redemptions"`
+	MaxRedemptionsPerUser *int         `json:"max_redemptions_per_user"`
+	RedemptionCount       int          `json:"redemption_count"`
+	IsActive              bool         `json:"is_active"`
+	CreatedAt             time.Time    `json:"created_at"`
+	UpdatedAt             time.Time    `json:"updated_at"`
+}
+
+func FromVoucherDomainV1(v V1Domains.VoucherDomain) VoucherResponse {
+	return VoucherResponse{
+		Id:                    v.Id,
+		Code:                  v.Code,
+		DiscountType:          v.DiscountType,
+		Amount:                v.Amount,
+		RateBps:               v.RateBps,
+		MaxDiscount:           v.MaxDiscount,
+		MinSpend:              v.MinSpend,
+		ProductId:             v.ProductId,
+		StartsAt:              v.StartsAt,
+		EndsAt:                v.EndsAt,
+		MaxRedemptions:        v.MaxRedemptions,
+		MaxRedemptionsPerUser: v.MaxRedemptionsPerUser,
+		RedemptionCount:       v.RedemptionCount,
+		IsActive:              v.IsActive,
+		CreatedAt:             v.CreatedAt,
+		UpdatedAt:             v.UpdatedAt,
+	}
+}
+
+func ToVoucherResponseList(domains []V1Domains.VoucherDomain) []VoucherResponse {
+	var result []VoucherResponse
+
+	for _, val := range domains {
+		result = append(result, FromVoucherDomainV1(val))
+	}
+
+	return result
+}
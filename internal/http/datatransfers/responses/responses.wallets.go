@@ -4,23 +4,34 @@ import (
 	"time"
 
 	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/pkg/money"
 )
 
 type WalletResponse struct {
-	Id        string     `json:"wallet_id"`
-	UserId    string     `json:"user_id,omitempty"`
-	Balance   float64    `json:"balance"`
-	CreatedAt time.Time  `json:"created_at"`
-	UpdatedAt *time.Time `json:"updated_at"`
+	Id               string      `json:"wallet_id"`
+	UserId           string      `json:"user_id,omitempty"`
+	Name             string      `json:"name"`
+	IsDefault        bool        `json:"is_default"`
+	Balance          money.Money `json:"balance"`
+	AvailableBalance money.Money `json:"available_balance"`
+	PointsBalance    money.Money `json:"points_balance"`
+	Status           string      `json:"status"`
+	CreatedAt        time.Time   `json:"created_at"`
+	UpdatedAt        *time.Time  `json:"updated_at"`
 }
 
 func FromWalletDomainV1(b V1Domains.WalletDomain) WalletResponse {
 	return WalletResponse{
-		Id:        b.Id,
-		UserId:    b.User.ID,
-		Balance:   b.Balance,
-		CreatedAt: b.CreatedAt,
-		UpdatedAt: b.UpdatedAt,
+		Id:               b.Id,
+		UserId:           b.User.ID,
+		Name:             b.Name,
+		IsDefault:        b.IsDefault,
+		Balance:          b.Balance,
+		AvailableBalance: b.AvailableBalance,
+		PointsBalance:    b.PointsBalance,
+		Status:           b.Status,
+		CreatedAt:        b.CreatedAt,
+		UpdatedAt:        b.UpdatedAt,
 	}
 }
 
@@ -33,3 +44,37 @@ func ToWalletResponseList(domains []V1Domains.WalletDomain) []WalletResponse {
 
 	return result
 }
+
+type WalletStatusEventResponse struct {
+	Id             string    `json:"event_id"`
+	WalletId       string    `json:"wallet_id"`
+	Action         string    `json:"action"`
+	PreviousStatus string    `json:"previous_status"`
+	Status         string    `json:"status"`
+	Reason         string    `json:"reason"`
+	ActorId        *string   `json:"actor_id"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+func FromWalletStatusEventDomainV1(e V1Domains.WalletStatusEventDomain) WalletStatusEventResponse {
+	return WalletStatusEventResponse{
+		Id:             e.Id,
+		WalletId:       e.WalletId,
+		Action:         e.Action,
+		PreviousStatus: e.PreviousStatus,
+		Status:         e.Status,
+		Reason:         e.Reason,
+		ActorId:        e.ActorId,
+		CreatedAt:      e.CreatedAt,
+	}
+}
+
+func ToWalletStatusEventResponseList(domains []V1Domains.WalletStatusEventDomain) []WalletStatusEventResponse {
+	var result []WalletStatusEventResponse
+
+	for _, val := range domains {
+		result = append(result, FromWalletStatusEventDomainV1(val))
+	}
+
+	return result
+}
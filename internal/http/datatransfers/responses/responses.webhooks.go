@@ -0,0 +1,113 @@
+package responses
+
+import (
+	"encoding/json"
+	"time"
+
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+)
+
+type WebhookResponse struct {
+	Id         string    `json:"webhook_id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	Secret     string    `json:"secret,omitempty"` // hanya dikirim saat endpoint dibuat atau secret diganti
+	IsActive   bool      `json:"is_active"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type WebhookDeliveryResponse struct {
+	Id             string                   `json:"delivery_id"`
+	WebhookId      string                   `json:"webhook_id"`
+	EventId        *string                  `json:"event_id"`
+	EventType      string                   `json:"event_type"`
+	Payload        json.RawMessage          `json:"payload,omitempty"`
+	Status         string                   `json:"status"`
+	Attempts       int                      `json:"attempts"`
+	NextAttemptAt  *time.Time               `json:"next_attempt_at,omitempty"` // hanya untuk pengiriman pending
+	LastStatusCode *int                     `json:"last_status_code"`
+	LastError      *string                  `json:"last_error"`
+	DeliveredAt    *time.Time               `json:"delivered_at"`
+	CreatedAt      time.Time                `json:"created_at"`
+	AttemptLog     []WebhookAttemptResponse `json:"attempt_log,omitempty"`
+}
+
+type WebhookAttemptResponse struct {
+	Attempt     int       `json:"attempt"`
+	StatusCode  *int      `json:"status_code"`
+	Error       *string   `json:"error"`
+	DurationMs  int       `json:"duration_ms"`
+	AttemptedAt time.Time `json:"attempted_at"`
+}
+
+// FromWebhookDomainV1 tidak pernah menyertakan secret
+func FromWebhookDomainV1(w V1Domains.WebhookEndpointDomain) WebhookResponse {
+	return WebhookResponse{
+		Id:         w.Id,
+		URL:        w.URL,
+		EventTypes: w.EventTypes,
+		IsActive:   w.IsActive,
+		CreatedAt:  w.CreatedAt,
+		UpdatedAt:  w.UpdatedAt,
+	}
+}
+
+// FromWebhookDomainWithSecretV1 dipakai sekali saat secret dibuat atau diganti
+func FromWebhookDomainWithSecretV1(w V1Domains.WebhookEndpointDomain) WebhookResponse {
+	result := FromWebhookDomainV1(w)
+	result.Secret = w.Secret
+	return result
+}
+
+func ToWebhookResponseList(domains []V1Domains.WebhookEndpointDomain) []WebhookResponse {
+	var result []WebhookResponse
+
+	for _, val := range domains {
+		result = append(result, FromWebhookDomainV1(val))
+	}
+
+	return result
+}
+
+func FromWebhookDeliveryDomainV1(d V1Domains.WebhookDeliveryDomain) WebhookDeliveryResponse {
+	result := WebhookDeliveryResponse{
+		Id:             d.Id,
+		WebhookId:      d.WebhookId,
+		EventId:        d.EventId,
+		EventType:      d.EventType,
+		Payload:        d.Payload,
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		DeliveredAt:    d.DeliveredAt,
+		CreatedAt:      d.CreatedAt,
+	}
+	if d.Status == constants.WebhookDeliveryStatusPending {
+		result.NextAttemptAt = &d.NextAttemptAt
+	}
+
+	for _, attempt := range d.AttemptLog {
+		result.AttemptLog = append(result.AttemptLog, WebhookAttemptResponse{
+			Attempt:     attempt.Attempt,
+			StatusCode:  attempt.StatusCode,
+			Error:       attempt.Error,
+			DurationMs:  attempt.DurationMs,
+			AttemptedAt: attempt.AttemptedAt,
+		})
+	}
+
+	return result
+}
+
+func ToWebhookDeliveryResponseList(domains []V1Domains.WebhookDeliveryDomain) []WebhookDeliveryResponse {
+	var result []WebhookDeliveryResponse
+
+	for _, val := range domains {
+		result = append(result, FromWebhookDeliveryDomainV1(val))
+	}
+
+	return result
+}
@@ -0,0 +1,123 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/internal/http/datatransfers/requests"
+	"github.com/snykk/transaction-api/internal/http/datatransfers/responses"
+)
+
+// FeeRuleHandler khusus admin, tidak memakai cache karena aturan fee dibaca langsung saat transaksi
+type FeeRuleHandler struct {
+	feeRuleUsecase V1Domains.FeeRuleUsecase
+}
+
+func NewFeeRuleHandler(feeRuleUsecase V1Domains.FeeRuleUsecase) FeeRuleHandler {
+	return FeeRuleHandler{
+		feeRuleUsecase: feeRuleUsecase,
+	}
+}
+
+func (c *FeeRuleHandler) Store(ctx *gin.Context) {
+	var feeRuleRequest requests.FeeRuleRequest
+
+	if err := ctx.ShouldBindJSON(&feeRuleRequest); err != nil {
+		NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	feeRuleDom, statusCode, err := c.feeRuleUsecase.Store(ctxx, feeRuleRequest.ToDomain())
+	if err != nil {
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	NewSuccessResponse(ctx, statusCode, "fee rule created successfully", map[string]interface{}{
+		"fee_rule": responses.FromFeeRuleDomainV1(feeRuleDom),
+	})
+}
+
+func (c *FeeRuleHandler) GetAll(ctx *gin.Context) {
+	ctxx := ctx.Request.Context()
+	feeRuleDoms, statusCode, err := c.feeRuleUsecase.GetAll(ctxx)
+	if err != nil {
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	feeRuleResponses := responses.ToFeeRuleResponseList(feeRuleDoms)
+	if feeRuleResponses == nil {
+		NewSuccessResponse(ctx, statusCode, "fee rule data is empty", []int{})
+		return
+	}
+
+	NewSuccessResponse(ctx, statusCode, "fee rules fetched successfully", map[string]interface{}{
+		"fee_rules": feeRuleResponses,
+	})
+}
+
+func (c *FeeRuleHandler) GetById(ctx *gin.Context) {
+	var uriRequest requests.FeeRuleUriRequest
+	if err := ctx.ShouldBindUri(&uriRequest); err != nil {
+		NewErrorResponse(ctx, http.StatusNotFound, "fee rule not found")
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	feeRuleDom, statusCode, err := c.feeRuleUsecase.GetById(ctxx, uriRequest.Id)
+	if err != nil {
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	NewSuccessResponse(ctx, statusCode, "fee rule fetched successfully", map[string]interface{}{
+		"fee_rule": responses.FromFeeRuleDomainV1(feeRuleDom),
+	})
+}
+
+func (c *FeeRuleHandler) Update(ctx *gin.Context) {
+	var uriRequest requests.FeeRuleUriRequest
+	var feeRuleRequest requests.FeeRuleRequest
+
+	if err := ctx.ShouldBindUri(&uriRequest); err != nil {
+		NewErrorResponse(ctx, http.StatusNotFound, "fee rule not found")
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&feeRuleRequest); err != nil {
+		NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	feeRuleDom, statusCode, err := c.feeRuleUsecase.Update(ctxx, uriRequest.Id, feeRuleRequest.ToDomain())
+	if err != nil {
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	NewSuccessResponse(ctx, statusCode, "fee rule updated successfully", map[string]interface{}{
+		"fee_rule": responses.FromFeeRuleDomainV1(feeRuleDom),
+	})
+}
+
+func (c *FeeRuleHandler) Delete(ctx *gin.Context) {
+	var uriRequest requests.FeeRuleUriRequest
+	if err := ctx.ShouldBindUri(&uriRequest); err != nil {
+		NewErrorResponse(ctx, http.StatusNotFound, "fee rule not found")
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	statusCode, err := c.feeRuleUsecase.Delete(ctxx, uriRequest.Id)
+	if err != nil {
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	NewSuccessResponse(ctx, statusCode, fmt.Sprintf("fee rule with id %d deleted successfully", uriRequest.Id), nil)
+}
@@ -0,0 +1,190 @@
+package v1_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	V1Usecases "github.com/snykk/transaction-api/internal/business/usecases/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+	PostgresRepo "github.com/snykk/transaction-api/internal/datasources/repositories/postgres/v1"
+	V1Handlers "github.com/snykk/transaction-api/internal/http/handlers/v1"
+	"github.com/snykk/transaction-api/internal/mocks"
+	"github.com/snykk/transaction-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	feeRuleRepoMock *mocks.FeeRuleRepository
+	feeRuleUsecase  V1Domains.FeeRuleUsecase
+	feeRuleHandler  V1Handlers.FeeRuleHandler
+	sFeeRule        *gin.Engine
+)
+
+func setupFeeRule(t *testing.T) {
+	// Initialize mock dependencies
+	feeRuleRepoMock = mocks.NewFeeRuleRepository(t)
+	feeRuleUsecase = V1Usecases.NewFeeRuleUsecase(feeRuleRepoMock)
+	feeRuleHandler = V1Handlers.NewFeeRuleHandler(feeRuleUsecase)
+
+	// Setup Gin engine, middleware admin diuji terpisah
+	sFeeRule = gin.Default()
+	sFeeRule.GET(constants.EndpointV1+"/fees", feeRuleHandler.GetAll)
+	sFeeRule.POST(constants.EndpointV1+"/fees", feeRuleHandler.Store)
+	sFeeRule.GET(constants.EndpointV1+"/fees/:id", feeRuleHandler.GetById)
+	sFeeRule.PUT(constants.EndpointV1+"/fees/:id", feeRuleHandler.Update)
+	sFeeRule.DELETE(constants.EndpointV1+"/fees/:id", feeRuleHandler.Delete)
+}
+
+func TestStoreFeeRule(t *testing.T) {
+	setupFeeRule(t)
+
+	t.Run("Success - Create Tiered Fee Rule", func(t *testing.T) {
+		reqBody := `{"name":"withdraw tiered","transaction_type":"withdraw","fee_type":"tiered","max_fee":"25000.00",
+			"tiers":[{"up_to":"1000000.00","flat_amount":"2500.00","rate_bps":0},{"flat_amount":"0.00","rate_bps":10}]}`
+
+		upTo := money.FromMajor(1000000)
+		maxFee := money.FromMajor(25000)
+		feeRuleFromDB := V1Domains.FeeRuleDomain{
+			Id:              1,
+			Name:            "withdraw tiered",
+			TransactionType: constants.TransactionTypeWithdraw,
+			FeeType:         constants.FeeTypeTiered,
+			Tiers: []V1Domains.FeeTierDomain{
+				{UpTo: &upTo, FlatAmount: money.FromMajor(2500)},
+				{RateBps: 10},
+			},
+			MaxFee:    &maxFee,
+			IsActive:  true,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		}
+
+		// Set up mock expectations
+		feeRuleRepoMock.Mock.On("Store", mock.Anything, mock.MatchedBy(func(f V1Domains.FeeRuleDomain) bool {
+			return f.IsActive && len(f.Tiers) == 2 && f.Tiers[1].UpTo == nil && *f.MaxFee == maxFee
+		})).Return(feeRuleFromDB, nil).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, constants.EndpointV1+"/fees", strings.NewReader(reqBody))
+		r.Header.Set("Content-Type", "application/json")
+
+		// Serve request
+		sFeeRule.ServeHTTP(w, r)
+		body := w.Body.String()
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusCreated, w.Result().StatusCode)
+		assert.Contains(t, body, "fee rule created successfully")
+		assert.Contains(t, body, `"fee_type":"tiered"`)
+		assert.Contains(t, body, `{"up_to":"1000000.00","flat_amount":"2500.00","rate_bps":0}`)
+	})
+
+	t.Run("Failure - Unsupported Transaction Type", func(t *testing.T) {
+		reqBody := `{"name":"deposit fee","transaction_type":"deposit","fee_type":"flat","flat_amount":"1.00"}`
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, constants.EndpointV1+"/fees", strings.NewReader(reqBody))
+		r.Header.Set("Content-Type", "application/json")
+
+		// Serve request
+		sFeeRule.ServeHTTP(w, r)
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), "TransactionType")
+	})
+
+	t.Run("Failure - Percentage Without Rate", func(t *testing.T) {
+		reqBody := `{"name":"purchase fee","transaction_type":"purchase","fee_type":"percentage"}`
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, constants.EndpointV1+"/fees", strings.NewReader(reqBody))
+		r.Header.Set("Content-Type", "application/json")
+
+		// Serve request
+		sFeeRule.ServeHTTP(w, r)
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), V1Usecases.ErrFeeRateRequired.Error())
+	})
+}
+
+func TestGetFeeRule(t *testing.T) {
+	setupFeeRule(t)
+
+	t.Run("Success - Get All Fee Rules", func(t *testing.T) {
+		rateBps := 150
+		feeRuleRepoMock.Mock.On("GetAll", mock.Anything).Return([]V1Domains.FeeRuleDomain{
+			{Id: 1, Name: "purchase fee", TransactionType: constants.TransactionTypePurchase, FeeType: constants.FeeTypePercentage, RateBps: &rateBps, IsActive: true},
+		}, nil).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, constants.EndpointV1+"/fees", nil)
+
+		// Serve request
+		sFeeRule.ServeHTTP(w, r)
+		body := w.Body.String()
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, "fee rules fetched successfully")
+		assert.Contains(t, body, `"rate_bps":150`)
+	})
+
+	t.Run("Failure - Fee Rule Not Found", func(t *testing.T) {
+		feeRuleRepoMock.Mock.On("GetById", mock.Anything, 99).Return(V1Domains.FeeRuleDomain{}, PostgresRepo.ErrFeeRuleNotFound).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, constants.EndpointV1+"/fees/99", nil)
+
+		// Serve request
+		sFeeRule.ServeHTTP(w, r)
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), "fee rule not found")
+	})
+}
+
+func TestDeleteFeeRule(t *testing.T) {
+	setupFeeRule(t)
+
+	t.Run("Success - Delete Fee Rule", func(t *testing.T) {
+		feeRuleRepoMock.Mock.On("Delete", mock.Anything, 1).Return(nil).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodDelete, constants.EndpointV1+"/fees/1", nil)
+
+		// Serve request
+		sFeeRule.ServeHTTP(w, r)
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), "fee rule with id 1 deleted successfully")
+	})
+
+	t.Run("Failure - Invalid Id", func(t *testing.T) {
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodDelete, constants.EndpointV1+"/fees/abc", nil)
+
+		// Serve request
+		sFeeRule.ServeHTTP(w, r)
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+	})
+}
@@ -0,0 +1,147 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+	"github.com/snykk/transaction-api/internal/datasources/caches"
+	"github.com/snykk/transaction-api/internal/http/datatransfers/requests"
+	"github.com/snykk/transaction-api/internal/http/datatransfers/responses"
+	"github.com/snykk/transaction-api/pkg/jwt"
+)
+
+type HoldHandler struct {
+	holdUsecase    V1Domains.HoldUsecase
+	ristrettoCache caches.RistrettoCache
+}
+
+func NewHoldHandler(holdUsecase V1Domains.HoldUsecase, ristrettoCache caches.RistrettoCache) HoldHandler {
+	return HoldHandler{
+		holdUsecase:    holdUsecase,
+		ristrettoCache: ristrettoCache,
+	}
+}
+
+func (c *HoldHandler) Store(ctx *gin.Context) {
+	var holdRequest requests.HoldRequest
+
+	// get authenticated user from context
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(jwt.JwtCustomClaim)
+
+	if err := ctx.ShouldBindJSON(&holdRequest); err != nil {
+		NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	holdDom := holdRequest.ToDomain()
+	holdDom.UserId = userClaims.UserID
+
+	ctxx := ctx.Request.Context()
+	outDom, statusCode, err := c.holdUsecase.Store(ctxx, holdDom, holdRequest.ExpiresInDuration())
+	if err != nil {
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	// saldo tersedia wallet berubah
+	c.invalidateWallet(outDom.WalletId, userClaims.UserID)
+
+	NewSuccessResponse(ctx, statusCode, "hold placed successfully", map[string]interface{}{
+		"hold": responses.FromHoldDomainV1(outDom),
+	})
+}
+
+func (c *HoldHandler) GetById(ctx *gin.Context) {
+	// get authenticated user from context
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(jwt.JwtCustomClaim)
+
+	// id yang bukan uuid tidak mungkin ada di database
+	var uriRequest requests.HoldUriRequest
+	if err := ctx.ShouldBindUri(&uriRequest); err != nil {
+		NewErrorResponse(ctx, http.StatusNotFound, "hold not found")
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	holdDom, statusCode, err := c.holdUsecase.GetById(ctxx, uriRequest.HoldId, userClaims.UserID)
+	if err != nil {
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	NewSuccessResponse(ctx, statusCode, "hold fetched successfully", map[string]interface{}{
+		"hold": responses.FromHoldDomainV1(holdDom),
+	})
+}
+
+func (c *HoldHandler) Capture(ctx *gin.Context) {
+	var uriRequest requests.HoldUriRequest
+	var captureRequest requests.HoldCaptureRequest
+
+	// get authenticated user from context
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(jwt.JwtCustomClaim)
+
+	// id yang bukan uuid tidak mungkin ada di database
+	if err := ctx.ShouldBindUri(&uriRequest); err != nil {
+		NewErrorResponse(ctx, http.StatusNotFound, "hold not found")
+		return
+	}
+
+	// body kosong berarti capture penuh
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&captureRequest); err != nil {
+			NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	ctxx := ctx.Request.Context()
+	holdDom, statusCode, err := c.holdUsecase.Capture(ctxx, uriRequest.HoldId, userClaims.UserID, captureRequest.ToDomain())
+	if err != nil {
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	c.invalidateWallet(holdDom.WalletId, userClaims.UserID)
+	go c.ristrettoCache.Del("transactions")
+	go c.ristrettoCache.Del(fmt.Sprintf("transaction_history/user_id:%s", userClaims.UserID))
+	if holdDom.ProductId != nil {
+		go c.ristrettoCache.Del("products", fmt.Sprintf("product/product_id:%d", *holdDom.ProductId))
+	}
+
+	NewSuccessResponse(ctx, statusCode, "hold captured successfully", map[string]interface{}{
+		"hold": responses.FromHoldDomainV1(holdDom),
+	})
+}
+
+func (c *HoldHandler) Void(ctx *gin.Context) {
+	// get authenticated user from context
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(jwt.JwtCustomClaim)
+
+	// id yang bukan uuid tidak mungkin ada di database
+	var uriRequest requests.HoldUriRequest
+	if err := ctx.ShouldBindUri(&uriRequest); err != nil {
+		NewErrorResponse(ctx, http.StatusNotFound, "hold not found")
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	holdDom, statusCode, err := c.holdUsecase.Void(ctxx, uriRequest.HoldId, userClaims.UserID)
+	if err != nil {
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	c.invalidateWallet(holdDom.WalletId, userClaims.UserID)
+
+	NewSuccessResponse(ctx, statusCode, "hold voided successfully", map[string]interface{}{
+		"hold": responses.FromHoldDomainV1(holdDom),
+	})
+}
+
+func (c *HoldHandler) invalidateWallet(walletId string, userId string) {
+	go c.ristrettoCache.Del("wallets", fmt.Sprintf("wallet/wallet_id:%s", walletId), fmt.Sprintf("wallet/user_id:%s", userId))
+}
@@ -0,0 +1,227 @@
+package v1_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	dgriJWT "github.com/dgrijalva/jwt-go"
+	"github.com/gin-gonic/gin"
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	V1Usecases "github.com/snykk/transaction-api/internal/business/usecases/v1"
+	"github.com/snykk/transaction-api/internal/config"
+	"github.com/snykk/transaction-api/internal/constants"
+	PostgresRepo "github.com/snykk/transaction-api/internal/datasources/repositories/postgres/v1"
+	V1Handlers "github.com/snykk/transaction-api/internal/http/handlers/v1"
+	"github.com/snykk/transaction-api/internal/mocks"
+	"github.com/snykk/transaction-api/pkg/jwt"
+	"github.com/snykk/transaction-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	holdRepoMock       *mocks.HoldRepository
+	holdFeeRepoMock    *mocks.FeeRuleRepository
+	ristrettoHoldMock  *mocks.RistrettoCache
+	holdUsecase        V1Domains.HoldUsecase
+	holdHandler        V1Handlers.HoldHandler
+	sHold              *gin.Engine
+	withdrawHoldFromDB V1Domains.HoldDomain
+)
+
+func setupHold(t *testing.T) {
+	// Initialize mock dependencies
+	holdRepoMock = mocks.NewHoldRepository(t)
+	holdFeeRepoMock = mocks.NewFeeRuleRepository(t)
+	ristrettoHoldMock = mocks.NewRistrettoCache(t)
+	holdUsecase = V1Usecases.NewHoldUsecase(holdRepoMock, holdFeeRepoMock, mocks.NewProductRepository(t), 15*time.Minute, 24*time.Hour)
+	holdHandler = V1Handlers.NewHoldHandler(holdUsecase, ristrettoHoldMock)
+
+	withdrawHoldFromDB = V1Domains.HoldDomain{
+		Id:              "0b6f1a9e-3c1d-4f2a-9a53-2d8f2f1c7e10",
+		WalletId:        "wwww-aaaa-llll",
+		UserId:          "aaaa-bbbb-cccc",
+		TransactionType: constants.TransactionTypeWithdraw,
+		Amount:          money.MustParse("150.00"),
+		Fee:             money.MustParse("1.50"),
+		Status:          constants.HoldStatusActive,
+		ExpiresAt:       time.Now().Add(15 * time.Minute),
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+
+	// Setup Gin engine with middleware for authentication
+	sHold = gin.Default()
+	sHold.Use(lazyAuthCommonHold)
+	sHold.POST(constants.EndpointV1+"/holds", holdHandler.Store)
+	sHold.GET(constants.EndpointV1+"/holds/:id", holdHandler.GetById)
+	sHold.POST(constants.EndpointV1+"/holds/:id/capture", holdHandler.Capture)
+	sHold.POST(constants.EndpointV1+"/holds/:id/void", holdHandler.Void)
+}
+
+// Mock lazy authentication
+func lazyAuthCommonHold(ctx *gin.Context) {
+	jwtClaims := jwt.JwtCustomClaim{
+		UserID:  withdrawHoldFromDB.UserId,
+		IsAdmin: false,
+		Email:   "patrick@gmail.com",
+		StandardClaims: dgriJWT.StandardClaims{
+			ExpiresAt: time.Now().Add(time.Hour * time.Duration(config.AppConfig.JWTExpired)).Unix(),
+			Issuer:    "patrick",
+			IssuedAt:  time.Now().Unix(),
+		},
+	}
+	ctx.Set(constants.CtxAuthenticatedUserKey, jwtClaims)
+}
+
+func TestStoreHold(t *testing.T) {
+	setupHold(t)
+
+	t.Run("Success - Place Withdraw Hold", func(t *testing.T) {
+		reqBody := `{"transaction_type":"withdraw","amount":"150.00","expires_in":900}`
+
+		// Set up mock expectations
+		holdFeeRepoMock.Mock.On("GetApplicable", mock.Anything, constants.TransactionTypeWithdraw, (*int)(nil)).Return(nil, nil).Once()
+		holdRepoMock.Mock.On("Store", mock.Anything, mock.MatchedBy(func(h V1Domains.HoldDomain) bool {
+			return h.UserId == withdrawHoldFromDB.UserId && h.Amount == withdrawHoldFromDB.Amount
+		})).Return(withdrawHoldFromDB, nil).Once()
+		ristrettoHoldMock.On("Del", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string")).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, constants.EndpointV1+"/holds", strings.NewReader(reqBody))
+		r.Header.Set("Content-Type", "application/json")
+
+		// Serve request
+		sHold.ServeHTTP(w, r)
+		body := w.Body.String()
+
+		// invalidasi cache berjalan di goroutine, beri waktu sebelum ekspektasi mock diperiksa
+		time.Sleep(50 * time.Millisecond)
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusCreated, w.Result().StatusCode)
+		assert.Contains(t, body, "hold placed successfully")
+		assert.Contains(t, body, `"amount":"150.00"`)
+		assert.Contains(t, body, `"fee":"1.50"`)
+		assert.Contains(t, body, `"status":"active"`)
+	})
+
+	t.Run("Failure - Invalid Transaction Type", func(t *testing.T) {
+		reqBody := `{"transaction_type":"transfer_out","amount":"10.00"}`
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, constants.EndpointV1+"/holds", strings.NewReader(reqBody))
+		r.Header.Set("Content-Type", "application/json")
+
+		// Serve request
+		sHold.ServeHTTP(w, r)
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), "TransactionType")
+	})
+}
+
+func TestCaptureHold(t *testing.T) {
+	setupHold(t)
+
+	t.Run("Success - Full Capture Without Body", func(t *testing.T) {
+		transactionId := "5d1b8a7c-52a8-4c47-8f8e-7c2b0e0b3a11"
+		captured := withdrawHoldFromDB
+		captured.Status = constants.HoldStatusCaptured
+		captured.CapturedAmount = &withdrawHoldFromDB.Amount
+		captured.TransactionId = &transactionId
+		captured.Transaction = &V1Domains.TransactionDomain{
+			Id:              transactionId,
+			WalletId:        withdrawHoldFromDB.WalletId,
+			Amount:          withdrawHoldFromDB.Amount,
+			TransactionType: constants.TransactionTypeWithdraw,
+			Status:          constants.TransactionStatusPending,
+		}
+
+		// Set up mock expectations
+		holdRepoMock.Mock.On("GetById", mock.Anything, withdrawHoldFromDB.Id, withdrawHoldFromDB.UserId).Return(withdrawHoldFromDB, nil).Once()
+		holdFeeRepoMock.Mock.On("GetApplicable", mock.Anything, constants.TransactionTypeWithdraw, (*int)(nil)).Return(nil, nil).Once()
+		holdRepoMock.Mock.On("Capture", mock.Anything, withdrawHoldFromDB.Id, withdrawHoldFromDB.UserId, mock.MatchedBy(func(tr V1Domains.TransactionDomain) bool {
+			return tr.Amount == withdrawHoldFromDB.Amount
+		})).Return(captured, nil).Once()
+		// ekspektasi satu key didaftarkan lebih dulu karena AnythingOfType juga cocok dengan argumen yang tidak ada
+		ristrettoHoldMock.On("Del", mock.AnythingOfType("string")).Twice()
+		ristrettoHoldMock.On("Del", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string")).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, constants.EndpointV1+"/holds/"+withdrawHoldFromDB.Id+"/capture", nil)
+
+		// Serve request
+		sHold.ServeHTTP(w, r)
+		body := w.Body.String()
+
+		// invalidasi cache berjalan di goroutine, beri waktu sebelum ekspektasi mock diperiksa
+		time.Sleep(50 * time.Millisecond)
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusCreated, w.Result().StatusCode)
+		assert.Contains(t, body, "hold captured successfully")
+		assert.Contains(t, body, `"status":"captured"`)
+		assert.Contains(t, body, `"captured_amount":"150.00"`)
+		assert.Contains(t, body, `"transaction_id":"`+transactionId+`"`)
+	})
+
+	t.Run("Failure - Capture Exceeds Hold", func(t *testing.T) {
+		reqBody := `{"amount":"200.00"}`
+
+		// Set up mock expectations
+		holdRepoMock.Mock.On("GetById", mock.Anything, withdrawHoldFromDB.Id, withdrawHoldFromDB.UserId).Return(withdrawHoldFromDB, nil).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, constants.EndpointV1+"/holds/"+withdrawHoldFromDB.Id+"/capture", strings.NewReader(reqBody))
+		r.Header.Set("Content-Type", "application/json")
+
+		// Serve request
+		sHold.ServeHTTP(w, r)
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), V1Usecases.ErrHoldCaptureExceeded.Error())
+	})
+
+	t.Run("Failure - Invalid Hold Id", func(t *testing.T) {
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, constants.EndpointV1+"/holds/not-a-uuid/capture", nil)
+
+		// Serve request
+		sHold.ServeHTTP(w, r)
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), "hold not found")
+	})
+}
+
+func TestVoidHold(t *testing.T) {
+	setupHold(t)
+
+	t.Run("Failure - Hold Already Captured", func(t *testing.T) {
+		// Set up mock expectations
+		holdRepoMock.Mock.On("Void", mock.Anything, withdrawHoldFromDB.Id, withdrawHoldFromDB.UserId).Return(V1Domains.HoldDomain{}, PostgresRepo.ErrHoldNotActive).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, constants.EndpointV1+"/holds/"+withdrawHoldFromDB.Id+"/void", nil)
+
+		// Serve request
+		sHold.ServeHTTP(w, r)
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusConflict, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), PostgresRepo.ErrHoldNotActive.Error())
+	})
+}
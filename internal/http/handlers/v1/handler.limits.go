@@ -0,0 +1,93 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+	"github.com/snykk/transaction-api/internal/http/datatransfers/requests"
+	"github.com/snykk/transaction-api/internal/http/datatransfers/responses"
+	"github.com/snykk/transaction-api/pkg/jwt"
+)
+
+// LimitHandler tidak memakai cache agar perubahan limit oleh admin langsung terlihat
+type LimitHandler struct {
+	limitUsecase V1Domains.LimitUsecase
+}
+
+func NewLimitHandler(limitUsecase V1Domains.LimitUsecase) LimitHandler {
+	return LimitHandler{
+		limitUsecase: limitUsecase,
+	}
+}
+
+func (c *LimitHandler) GetOwn(ctx *gin.Context) {
+	// get authenticated user from context
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(jwt.JwtCustomClaim)
+
+	ctxx := ctx.Request.Context()
+	limitDom, statusCode, err := c.limitUsecase.GetByUserId(ctxx, userClaims.UserID)
+	if err != nil {
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	NewSuccessResponse(ctx, statusCode, "limits fetched successfully", map[string]interface{}{
+		"limits": responses.FromUserLimitDomainV1(limitDom),
+	})
+}
+
+func (c *LimitHandler) GetByUserId(ctx *gin.Context) {
+	// id yang bukan uuid tidak mungkin ada di database
+	var uriRequest requests.LimitUriRequest
+	if err := ctx.ShouldBindUri(&uriRequest); err != nil {
+		NewErrorResponse(ctx, http.StatusNotFound, "user not found")
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	limitDom, statusCode, err := c.limitUsecase.GetByUserId(ctxx, uriRequest.UserId)
+	if err != nil {
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	NewSuccessResponse(ctx, statusCode, "limits fetched successfully", map[string]interface{}{
+		"limits": responses.FromUserLimitDomainV1(limitDom),
+	})
+}
+
+func (c *LimitHandler) Update(ctx *gin.Context) {
+	var uriRequest requests.LimitUriRequest
+	var limitRequest requests.LimitRequest
+
+	// get authenticated admin from context
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(jwt.JwtCustomClaim)
+
+	// id yang bukan uuid tidak mungkin ada di database
+	if err := ctx.ShouldBindUri(&uriRequest); err != nil {
+		NewErrorResponse(ctx, http.StatusNotFound, "user not found")
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&limitRequest); err != nil {
+		NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	limitDom := limitRequest.ToDomain()
+	limitDom.UserId = uriRequest.UserId
+	limitDom.UpdatedBy = &userClaims.UserID
+
+	ctxx := ctx.Request.Context()
+	outDom, statusCode, err := c.limitUsecase.Update(ctxx, limitDom)
+	if err != nil {
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	NewSuccessResponse(ctx, statusCode, "limits updated successfully", map[string]interface{}{
+		"limits": responses.FromUserLimitDomainV1(outDom),
+	})
+}
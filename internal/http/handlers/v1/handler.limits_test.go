@@ -0,0 +1,172 @@
+package v1_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	dgriJWT "github.com/dgrijalva/jwt-go"
+	"github.com/gin-gonic/gin"
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	V1Usecases "github.com/snykk/transaction-api/internal/business/usecases/v1"
+	"github.com/snykk/transaction-api/internal/config"
+	"github.com/snykk/transaction-api/internal/constants"
+	PostgresRepo "github.com/snykk/transaction-api/internal/datasources/repositories/postgres/v1"
+	V1Handlers "github.com/snykk/transaction-api/internal/http/handlers/v1"
+	"github.com/snykk/transaction-api/internal/mocks"
+	"github.com/snykk/transaction-api/pkg/jwt"
+	"github.com/snykk/transaction-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	limitUserId  = "3f333df6-90a4-4fda-8dd3-9485d27cee36"
+	limitAdminId = "c56a4180-65aa-42ec-a945-5fd21dec0538"
+)
+
+var (
+	limitRepoMock *mocks.LimitRepository
+	limitUsecase  V1Domains.LimitUsecase
+	limitHandler  V1Handlers.LimitHandler
+	sLimit        *gin.Engine
+)
+
+func setupLimit(t *testing.T) {
+	// Initialize mock dependencies
+	limitRepoMock = mocks.NewLimitRepository(t)
+
+	dailyWithdrawal := money.MustParse("10000.00")
+	zero := money.Money(0)
+	hourlyTransactions := 20
+	limitUsecase = V1Usecases.NewLimitUsecase(limitRepoMock, V1Domains.UserLimitDomain{
+		MaxSingleWithdrawal: &zero,
+		DailyWithdrawal:     &dailyWithdrawal,
+		MonthlyWithdrawal:   &zero,
+		DailyPurchase:       &zero,
+		HourlyTransactions:  &hourlyTransactions,
+	})
+	limitHandler = V1Handlers.NewLimitHandler(limitUsecase)
+
+	// Setup Gin engine with middleware for authentication
+	sLimit = gin.Default()
+	sLimit.Use(lazyAuthAdminLimit)
+	sLimit.GET(constants.EndpointV1+"/limits", limitHandler.GetOwn)
+	sLimit.GET(constants.EndpointV1+"/limits/:user_id", limitHandler.GetByUserId)
+	sLimit.PUT(constants.EndpointV1+"/limits/:user_id", limitHandler.Update)
+}
+
+// Mock lazy authentication
+func lazyAuthAdminLimit(ctx *gin.Context) {
+	jwtClaims := jwt.JwtCustomClaim{
+		UserID:  limitAdminId,
+		IsAdmin: true,
+		Email:   "patrick@gmail.com",
+		StandardClaims: dgriJWT.StandardClaims{
+			ExpiresAt: time.Now().Add(time.Hour * time.Duration(config.AppConfig.JWTExpired)).Unix(),
+			Issuer:    "patrick",
+			IssuedAt:  time.Now().Unix(),
+		},
+	}
+	ctx.Set(constants.CtxAuthenticatedUserKey, jwtClaims)
+}
+
+func TestGetLimit(t *testing.T) {
+	setupLimit(t)
+
+	t.Run("Success - Get Own Limits", func(t *testing.T) {
+		limitRepoMock.Mock.On("GetByUserId", mock.Anything, limitAdminId).Return(V1Domains.UserLimitDomain{UserId: limitAdminId}, nil).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, constants.EndpointV1+"/limits", nil)
+
+		// Serve request
+		sLimit.ServeHTTP(w, r)
+		body := w.Body.String()
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, `"daily_withdrawal":"10000.00"`)
+		assert.Contains(t, body, `"max_single_withdrawal":"0.00"`)
+		assert.Contains(t, body, `"hourly_transactions":20`)
+		assert.Contains(t, body, `"overridden":[]`)
+	})
+
+	t.Run("Failure - User Not Found", func(t *testing.T) {
+		limitRepoMock.Mock.On("GetByUserId", mock.Anything, limitUserId).Return(V1Domains.UserLimitDomain{}, PostgresRepo.ErrUserNotFound).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, constants.EndpointV1+"/limits/"+limitUserId, nil)
+
+		// Serve request
+		sLimit.ServeHTTP(w, r)
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), "user not found")
+	})
+}
+
+func TestUpdateLimit(t *testing.T) {
+	setupLimit(t)
+
+	t.Run("Success - Update Limits", func(t *testing.T) {
+		reqBody := `{"daily_withdrawal":"2500.00","hourly_transactions":5}`
+
+		// Set up mock expectations
+		limitRepoMock.Mock.On("Upsert", mock.Anything, mock.MatchedBy(func(l V1Domains.UserLimitDomain) bool {
+			return l.UserId == limitUserId && *l.UpdatedBy == limitAdminId && *l.DailyWithdrawal == money.MustParse("2500.00") && l.MonthlyWithdrawal == nil
+		})).Return(func(_ context.Context, l V1Domains.UserLimitDomain) V1Domains.UserLimitDomain {
+			return l
+		}, nil).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPut, constants.EndpointV1+"/limits/"+limitUserId, strings.NewReader(reqBody))
+		r.Header.Set("Content-Type", "application/json")
+
+		// Serve request
+		sLimit.ServeHTTP(w, r)
+		body := w.Body.String()
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, "limits updated successfully")
+		assert.Contains(t, body, `"daily_withdrawal":"2500.00"`)
+		assert.Contains(t, body, `"hourly_transactions":5`)
+		assert.Contains(t, body, `"overridden":["daily_withdrawal","hourly_transactions"]`)
+	})
+
+	t.Run("Failure - Negative Limit", func(t *testing.T) {
+		reqBody := `{"daily_purchase":"-1.00"}`
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPut, constants.EndpointV1+"/limits/"+limitUserId, strings.NewReader(reqBody))
+		r.Header.Set("Content-Type", "application/json")
+
+		// Serve request
+		sLimit.ServeHTTP(w, r)
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+
+	t.Run("Failure - Invalid User Id", func(t *testing.T) {
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPut, constants.EndpointV1+"/limits/not-a-uuid", strings.NewReader(`{}`))
+		r.Header.Set("Content-Type", "application/json")
+
+		// Serve request
+		sLimit.ServeHTTP(w, r)
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+	})
+}
@@ -0,0 +1,108 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+	"github.com/snykk/transaction-api/internal/datasources/caches"
+	"github.com/snykk/transaction-api/internal/http/datatransfers/requests"
+	"github.com/snykk/transaction-api/internal/http/datatransfers/responses"
+	"github.com/snykk/transaction-api/pkg/jwt"
+)
+
+// OrderHandler tidak meng-cache daftar order, cache hanya dihapus karena order mengubah saldo dan stock
+type OrderHandler struct {
+	orderUsecase   V1Domains.OrderUsecase
+	ristrettoCache caches.RistrettoCache
+}
+
+func NewOrderHandler(orderUsecase V1Domains.OrderUsecase, ristrettoCache caches.RistrettoCache) OrderHandler {
+	return OrderHandler{
+		orderUsecase:   orderUsecase,
+		ristrettoCache: ristrettoCache,
+	}
+}
+
+func (c *OrderHandler) Store(ctx *gin.Context) {
+	var orderRequest requests.OrderRequest
+
+	// get authenticated user from context
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(jwt.JwtCustomClaim)
+
+	if err := ctx.ShouldBindJSON(&orderRequest); err != nil {
+		NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	orderDom := orderRequest.ToDomain()
+	orderDom.UserId = userClaims.UserID
+
+	ctxx := ctx.Request.Context()
+	outDom, statusCode, err := c.orderUsecase.Create(ctxx, orderDom)
+	if err != nil {
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	// invalidate cache saldo, riwayat transaksi, dan stock setiap produk yang dibeli
+	productKeys := []string{"products"}
+	for _, item := range outDom.Items {
+		productKeys = append(productKeys, fmt.Sprintf("product/product_id:%d", *item.ProductId))
+	}
+	go c.ristrettoCache.Del("transactions")
+	go c.ristrettoCache.Del("wallets", fmt.Sprintf("wallet/wallet_id:%s", outDom.WalletId), fmt.Sprintf("wallet/user_id:%s", userClaims.UserID))
+	go c.ristrettoCache.Del(productKeys...)
+	go c.ristrettoCache.Del(fmt.Sprintf("transaction_history/user_id:%s", userClaims.UserID))
+
+	NewSuccessResponse(ctx, statusCode, "order created successfully", map[string]interface{}{
+		"order": responses.FromOrderDomainV1(outDom),
+	})
+}
+
+func (c *OrderHandler) GetAll(ctx *gin.Context) {
+	// get authenticated user from context
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(jwt.JwtCustomClaim)
+
+	ctxx := ctx.Request.Context()
+	orderDoms, statusCode, err := c.orderUsecase.GetByUserId(ctxx, userClaims.UserID)
+	if err != nil {
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	orderResponses := responses.ToOrderResponseList(orderDoms)
+	if orderResponses == nil {
+		NewSuccessResponse(ctx, statusCode, "order data is empty", []int{})
+		return
+	}
+
+	NewSuccessResponse(ctx, statusCode, "orders fetched successfully", map[string]interface{}{
+		"orders": orderResponses,
+	})
+}
+
+func (c *OrderHandler) GetById(ctx *gin.Context) {
+	// get authenticated user from context
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(jwt.JwtCustomClaim)
+
+	// id yang bukan uuid tidak mungkin ada di database
+	var uriRequest requests.OrderUriRequest
+	if err := ctx.ShouldBindUri(&uriRequest); err != nil {
+		NewErrorResponse(ctx, http.StatusNotFound, "order not found")
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	orderDom, statusCode, err := c.orderUsecase.GetById(ctxx, uriRequest.OrderId, userClaims.UserID)
+	if err != nil {
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	NewSuccessResponse(ctx, statusCode, "order fetched successfully", map[string]interface{}{
+		"order": responses.FromOrderDomainV1(orderDom),
+	})
+}
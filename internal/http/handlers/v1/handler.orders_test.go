@@ -0,0 +1,260 @@
+package v1_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	dgriJWT "github.com/dgrijalva/jwt-go"
+	"github.com/gin-gonic/gin"
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	V1Usecases "github.com/snykk/transaction-api/internal/business/usecases/v1"
+	"github.com/snykk/transaction-api/internal/config"
+	"github.com/snykk/transaction-api/internal/constants"
+	PostgresRepo "github.com/snykk/transaction-api/internal/datasources/repositories/postgres/v1"
+	V1Handlers "github.com/snykk/transaction-api/internal/http/handlers/v1"
+	"github.com/snykk/transaction-api/internal/mocks"
+	"github.com/snykk/transaction-api/pkg/jwt"
+	"github.com/snykk/transaction-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	orderRepoMock        *mocks.OrderRepository
+	orderFeeRepoMock     *mocks.FeeRuleRepository
+	orderProductRepoMock *mocks.ProductRepository
+	ristrettoOrderMock   *mocks.RistrettoCache
+	orderUsecase         V1Domains.OrderUsecase
+	orderHandler         V1Handlers.OrderHandler
+	sOrder               *gin.Engine
+	orderDataFromDB      V1Domains.OrderDomain
+)
+
+func setupOrder(t *testing.T) {
+	// Initialize mock dependencies
+	orderRepoMock = mocks.NewOrderRepository(t)
+	orderFeeRepoMock = mocks.NewFeeRuleRepository(t)
+	orderProductRepoMock = mocks.NewProductRepository(t)
+	ristrettoOrderMock = mocks.NewRistrettoCache(t)
+	orderUsecase = V1Usecases.NewOrderUsecase(orderRepoMock, orderFeeRepoMock, orderProductRepoMock)
+	orderHandler = V1Handlers.NewOrderHandler(orderUsecase, ristrettoOrderMock)
+
+	productId1, productId2 := 1, 2
+	orderDataFromDB = V1Domains.OrderDomain{
+		Id:            "3f0c2b8e-6d3a-4c5e-9b1f-8a2d7e4c6b10",
+		UserId:        "aaaa-bbbb-cccc",
+		WalletId:      "wwww-aaaa-llll",
+		TransactionId: "tttt-rrrr-xxxx",
+		Items: []V1Domains.OrderItemDomain{
+			{Id: "iiii-0001", ProductId: &productId1, ProductName: "Keyboard", Quantity: 2, UnitPrice: money.MustParse("50.00"), Subtotal: money.MustParse("100.00")},
+			{Id: "iiii-0002", ProductId: &productId2, ProductName: "Mouse", Quantity: 1, UnitPrice: money.MustParse("25.00"), Subtotal: money.MustParse("25.00")},
+		},
+		TotalAmount: money.MustParse("125.00"),
+		Status:      constants.TransactionStatusCompleted,
+		CreatedAt:   time.Now(),
+	}
+
+	// Setup Gin engine with middleware for authentication
+	sOrder = gin.Default()
+	sOrder.Use(lazyAuthCommonOrder)
+	sOrder.POST(constants.EndpointV1+"/orders", orderHandler.Store)
+	sOrder.GET(constants.EndpointV1+"/orders", orderHandler.GetAll)
+	sOrder.GET(constants.EndpointV1+"/orders/:id", orderHandler.GetById)
+}
+
+// Mock lazy authentication
+func lazyAuthCommonOrder(ctx *gin.Context) {
+	jwtClaims := jwt.JwtCustomClaim{
+		UserID:  orderDataFromDB.UserId,
+		IsAdmin: false,
+		Email:   "patrick@gmail.com",
+		StandardClaims: dgriJWT.StandardClaims{
+			ExpiresAt: time.Now().Add(time.Hour * time.Duration(config.AppConfig.JWTExpired)).Unix(),
+			Issuer:    "patrick",
+			IssuedAt:  time.Now().Unix(),
+		},
+	}
+	ctx.Set(constants.CtxAuthenticatedUserKey, jwtClaims)
+}
+
+func TestStoreOrder(t *testing.T) {
+	setupOrder(t)
+
+	t.Run("Success - Create Multi Item Order", func(t *testing.T) {
+		reqBody := `{"items":[{"product_id":1,"quantity":2},{"product_id":2,"quantity":1}],"description":"perlengkapan kantor"}`
+
+		// Set up mock expectations
+		orderProductRepoMock.Mock.On("GetProductById", mock.Anything, 1).Return(V1Domains.ProductDomain{Id: 1, Price: money.MustParse("50.00")}, nil).Once()
+		orderProductRepoMock.Mock.On("GetProductById", mock.Anything, 2).Return(V1Domains.ProductDomain{Id: 2, Price: money.MustParse("25.00")}, nil).Once()
+		orderFeeRepoMock.Mock.On("GetApplicable", mock.Anything, constants.TransactionTypePurchase, mock.AnythingOfType("*int")).Return(nil, nil).Twice()
+		orderRepoMock.Mock.On("Create", mock.Anything, mock.MatchedBy(func(o V1Domains.OrderDomain) bool {
+			return o.UserId == orderDataFromDB.UserId && len(o.Items) == 2 && *o.Description == "perlengkapan kantor"
+		})).Return(orderDataFromDB, nil).Once()
+
+		ristrettoOrderMock.On("Del", mock.AnythingOfType("string")).Twice()
+		ristrettoOrderMock.On("Del", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string")).Twice()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, constants.EndpointV1+"/orders", strings.NewReader(reqBody))
+		r.Header.Set("Content-Type", "application/json")
+
+		// Serve request
+		sOrder.ServeHTTP(w, r)
+		body := w.Body.String()
+
+		// invalidasi cache berjalan di goroutine, beri waktu sebelum ekspektasi mock diperiksa
+		time.Sleep(50 * time.Millisecond)
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusCreated, w.Result().StatusCode)
+		assert.Contains(t, body, "order created successfully")
+		assert.Contains(t, body, `"total_amount":"125.00"`)
+		assert.Contains(t, body, `"product_name":"Keyboard","quantity":2,"unit_price":"50.00","subtotal":"100.00"`)
+	})
+
+	t.Run("Failure - Empty Items", func(t *testing.T) {
+		reqBody := `{"items":[]}`
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, constants.EndpointV1+"/orders", strings.NewReader(reqBody))
+		r.Header.Set("Content-Type", "application/json")
+
+		// Serve request
+		sOrder.ServeHTTP(w, r)
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), "Field validation for 'Items' failed on the 'min'")
+	})
+
+	t.Run("Failure - Invalid Item Quantity", func(t *testing.T) {
+		reqBody := `{"items":[{"product_id":1,"quantity":-1}]}`
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, constants.EndpointV1+"/orders", strings.NewReader(reqBody))
+		r.Header.Set("Content-Type", "application/json")
+
+		// Serve request
+		sOrder.ServeHTTP(w, r)
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), "Field validation for 'Quantity' failed on the 'gt'")
+	})
+
+	t.Run("Failure - Insufficient Stock", func(t *testing.T) {
+		reqBody := `{"items":[{"product_id":1,"quantity":1000}]}`
+
+		// Set up mock expectations
+		orderProductRepoMock.Mock.On("GetProductById", mock.Anything, 1).Return(V1Domains.ProductDomain{Id: 1, Price: money.MustParse("50.00")}, nil).Once()
+		orderFeeRepoMock.Mock.On("GetApplicable", mock.Anything, constants.TransactionTypePurchase, mock.AnythingOfType("*int")).Return(nil, nil).Once()
+		orderRepoMock.Mock.On("Create", mock.Anything, mock.AnythingOfType("v1.OrderDomain")).Return(V1Domains.OrderDomain{}, PostgresRepo.ErrInsufficientProductStock).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, constants.EndpointV1+"/orders", strings.NewReader(reqBody))
+		r.Header.Set("Content-Type", "application/json")
+
+		// Serve request
+		sOrder.ServeHTTP(w, r)
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), "insufficient product stock")
+	})
+}
+
+func TestGetOrders(t *testing.T) {
+	setupOrder(t)
+
+	t.Run("Success - List Own Orders", func(t *testing.T) {
+		// Set up mock expectations
+		orderRepoMock.Mock.On("GetByUserId", mock.Anything, orderDataFromDB.UserId).Return([]V1Domains.OrderDomain{orderDataFromDB}, nil).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, constants.EndpointV1+"/orders", nil)
+
+		// Serve request
+		sOrder.ServeHTTP(w, r)
+		body := w.Body.String()
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, "orders fetched successfully")
+		assert.Contains(t, body, `"order_id":"`+orderDataFromDB.Id+`"`)
+	})
+
+	t.Run("Success - No Orders", func(t *testing.T) {
+		// Set up mock expectations
+		orderRepoMock.Mock.On("GetByUserId", mock.Anything, orderDataFromDB.UserId).Return(nil, nil).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, constants.EndpointV1+"/orders", nil)
+
+		// Serve request
+		sOrder.ServeHTTP(w, r)
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), "order data is empty")
+	})
+}
+
+func TestGetOrderById(t *testing.T) {
+	setupOrder(t)
+
+	t.Run("Success - Own Order", func(t *testing.T) {
+		// Set up mock expectations
+		orderRepoMock.Mock.On("GetById", mock.Anything, orderDataFromDB.Id, orderDataFromDB.UserId).Return(orderDataFromDB, nil).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, constants.EndpointV1+"/orders/"+orderDataFromDB.Id, nil)
+
+		// Serve request
+		sOrder.ServeHTTP(w, r)
+		body := w.Body.String()
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, "order fetched successfully")
+		assert.Contains(t, body, `"product_name":"Mouse"`)
+	})
+
+	t.Run("Failure - Other User Order", func(t *testing.T) {
+		// Set up mock expectations
+		orderRepoMock.Mock.On("GetById", mock.Anything, orderDataFromDB.Id, orderDataFromDB.UserId).Return(V1Domains.OrderDomain{}, PostgresRepo.ErrOrderNotFound).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, constants.EndpointV1+"/orders/"+orderDataFromDB.Id, nil)
+
+		// Serve request
+		sOrder.ServeHTTP(w, r)
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), "order not found")
+	})
+
+	t.Run("Failure - Non UUID Order Id", func(t *testing.T) {
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, constants.EndpointV1+"/orders/123", nil)
+
+		// Serve request
+		sOrder.ServeHTTP(w, r)
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), "order not found")
+	})
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/snykk/transaction-api/internal/mocks"
 	"github.com/snykk/transaction-api/pkg/helpers"
 	"github.com/snykk/transaction-api/pkg/jwt"
+	"github.com/snykk/transaction-api/pkg/money"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -69,7 +70,7 @@ func setupProduct(t *testing.T) {
 			Id:          1,
 			Name:        "keyboard",
 			Description: "lorem ipsum dolor sit amet",
-			Price:       20.0,
+			Price:       money.FromMajor(20),
 			Stock:       234,
 			CreatedAt:   currentTime,
 			UpdatedAt:   &currentTime,
@@ -78,7 +79,7 @@ func setupProduct(t *testing.T) {
 			Id:          2,
 			Name:        "mouse",
 			Description: "lorem ipsum dolor sit amet",
-			Price:       29.0,
+			Price:       money.FromMajor(29),
 			Stock:       10,
 			CreatedAt:   currentTime,
 			UpdatedAt:   &currentTime,
@@ -213,7 +214,7 @@ func TestStoreProduct(t *testing.T) {
 		req := requests.ProductRequest{
 			Name:        "keyboard",
 			Description: "lorem ipsum dolor sit amet",
-			Price:       20.0,
+			Price:       money.FromMajor(20),
 			Stock:       234,
 		}
 
@@ -285,7 +286,7 @@ func TestUpdateProduct(t *testing.T) {
 		req := requests.ProductRequest{
 			Name:        "keyboard ye",
 			Description: "lorem ipsum dolor sit amet",
-			Price:       20.0,
+			Price:       money.FromMajor(20),
 			Stock:       234,
 		}
 
@@ -319,7 +320,7 @@ func TestUpdateProduct(t *testing.T) {
 		req := requests.ProductRequest{
 			Name:        "keyboard ye",
 			Description: "lorem ipsum dolor sit amet",
-			Price:       20.0,
+			Price:       money.FromMajor(20),
 			Stock:       234,
 		}
 
@@ -0,0 +1,32 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/internal/http/datatransfers/responses"
+)
+
+// ReconciliationHandler khusus admin, rekonsiliasi sendiri dijalankan lewat CLI atau cron
+type ReconciliationHandler struct {
+	reconciliationUsecase V1Domains.ReconciliationUsecase
+}
+
+func NewReconciliationHandler(reconciliationUsecase V1Domains.ReconciliationUsecase) ReconciliationHandler {
+	return ReconciliationHandler{
+		reconciliationUsecase: reconciliationUsecase,
+	}
+}
+
+// Latest menampilkan laporan run terakhir, termasuk run yang masih berjalan atau gagal
+func (c *ReconciliationHandler) Latest(ctx *gin.Context) {
+	ctxx := ctx.Request.Context()
+	runDom, statusCode, err := c.reconciliationUsecase.GetLatest(ctxx)
+	if err != nil {
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	NewSuccessResponse(ctx, statusCode, "reconciliation report fetched successfully", map[string]interface{}{
+		"reconciliation": responses.FromReconciliationRunDomainV1(runDom),
+	})
+}
@@ -0,0 +1,104 @@
+package v1_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	V1Usecases "github.com/snykk/transaction-api/internal/business/usecases/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+	PostgresRepo "github.com/snykk/transaction-api/internal/datasources/repositories/postgres/v1"
+	V1Handlers "github.com/snykk/transaction-api/internal/http/handlers/v1"
+	"github.com/snykk/transaction-api/internal/mocks"
+	"github.com/snykk/transaction-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	reconciliationRepoMock *mocks.ReconciliationRepository
+	reconciliationUsecase  V1Domains.ReconciliationUsecase
+	reconciliationHandler  V1Handlers.ReconciliationHandler
+	sReconciliation        *gin.Engine
+)
+
+func setupReconciliation(t *testing.T) {
+	// Initialize mock dependencies
+	reconciliationRepoMock = mocks.NewReconciliationRepository(t)
+	reconciliationUsecase = V1Usecases.NewReconciliationUsecase(reconciliationRepoMock)
+	reconciliationHandler = V1Handlers.NewReconciliationHandler(reconciliationUsecase)
+
+	// Setup Gin engine, middleware admin diuji terpisah
+	sReconciliation = gin.Default()
+	sReconciliation.GET(constants.EndpointV1+"/reconciliations/latest", reconciliationHandler.Latest)
+}
+
+func TestLatestReconciliation(t *testing.T) {
+	setupReconciliation(t)
+
+	t.Run("Success - Report With Discrepancy", func(t *testing.T) {
+		finishedAt := time.Now()
+		runFromDB := V1Domains.ReconciliationRunDomain{
+			Id:             "rrrr-uuuu-nnnn",
+			Trigger:        constants.ReconciliationTriggerCron,
+			Status:         constants.ReconciliationStatusCompleted,
+			WalletsChecked: 42,
+			Discrepancies:  1,
+			StartedAt:      finishedAt.Add(-time.Minute),
+			FinishedAt:     &finishedAt,
+			Items: []V1Domains.ReconciliationItemDomain{
+				{
+					WalletId:        "xxxx-yyyy-zzzz",
+					UserId:          "aaaa-bbbb-cccc",
+					RecordedBalance: money.FromMajor(150),
+					ExpectedBalance: money.FromMajor(100),
+					Difference:      money.FromMajor(50),
+				},
+			},
+		}
+
+		reconciliationRepoMock.Mock.On("GetLatestRun", mock.Anything, constants.ReconciliationReportItemLimit).Return(runFromDB, nil).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, constants.EndpointV1+"/reconciliations/latest", nil)
+
+		sReconciliation.ServeHTTP(w, r)
+
+		body := w.Body.String()
+
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, "reconciliation report fetched successfully")
+		assert.Contains(t, body, `"wallets_checked":42`)
+		assert.Contains(t, body, `"difference":"50.00"`)
+	})
+
+	t.Run("Success - Clean Run Has Empty Items", func(t *testing.T) {
+		reconciliationRepoMock.Mock.On("GetLatestRun", mock.Anything, constants.ReconciliationReportItemLimit).Return(V1Domains.ReconciliationRunDomain{
+			Id:     "rrrr-uuuu-nnnn",
+			Status: constants.ReconciliationStatusCompleted,
+		}, nil).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, constants.EndpointV1+"/reconciliations/latest", nil)
+
+		sReconciliation.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), `"items":[]`)
+	})
+
+	t.Run("Failure - No Run Yet", func(t *testing.T) {
+		reconciliationRepoMock.Mock.On("GetLatestRun", mock.Anything, constants.ReconciliationReportItemLimit).Return(V1Domains.ReconciliationRunDomain{}, PostgresRepo.ErrReconciliationNotFound).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, constants.EndpointV1+"/reconciliations/latest", nil)
+
+		sReconciliation.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), "no reconciliation run found")
+	})
+}
@@ -0,0 +1,184 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+	"github.com/snykk/transaction-api/internal/datasources/caches"
+	"github.com/snykk/transaction-api/internal/http/datatransfers/requests"
+	"github.com/snykk/transaction-api/internal/http/datatransfers/responses"
+	"github.com/snykk/transaction-api/pkg/jwt"
+)
+
+// RewardHandler melayani aturan reward untuk admin serta mutasi dan konversi poin untuk user
+type RewardHandler struct {
+	rewardUsecase  V1Domains.RewardUsecase
+	ristrettoCache caches.RistrettoCache
+}
+
+func NewRewardHandler(rewardUsecase V1Domains.RewardUsecase, ristrettoCache caches.RistrettoCache) RewardHandler {
+	return RewardHandler{
+		rewardUsecase:  rewardUsecase,
+		ristrettoCache: ristrettoCache,
+	}
+}
+
+func (c *RewardHandler) StoreRule(ctx *gin.Context) {
+	var ruleRequest requests.RewardRuleRequest
+
+	if err := ctx.ShouldBindJSON(&ruleRequest); err != nil {
+		NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	ruleDom, statusCode, err := c.rewardUsecase.StoreRule(ctxx, ruleRequest.ToDomain())
+	if err != nil {
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	NewSuccessResponse(ctx, statusCode, "reward rule created successfully", map[string]interface{}{
+		"reward_rule": responses.FromRewardRuleDomainV1(ruleDom),
+	})
+}
+
+func (c *RewardHandler) GetAllRules(ctx *gin.Context) {
+	ctxx := ctx.Request.Context()
+	ruleDoms, statusCode, err := c.rewardUsecase.GetAllRules(ctxx)
+	if err != nil {
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	ruleResponses := responses.ToRewardRuleResponseList(ruleDoms)
+	if ruleResponses == nil {
+		NewSuccessResponse(ctx, statusCode, "reward rule data is empty", []int{})
+		return
+	}
+
+	NewSuccessResponse(ctx, statusCode, "reward rules fetched successfully", map[string]interface{}{
+		"reward_rules": ruleResponses,
+	})
+}
+
+func (c *RewardHandler) GetRuleById(ctx *gin.Context) {
+	var uriRequest requests.RewardRuleUriRequest
+	if err := ctx.ShouldBindUri(&uriRequest); err != nil {
+		NewErrorResponse(ctx, http.StatusNotFound, "reward rule not found")
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	ruleDom, statusCode, err := c.rewardUsecase.GetRuleById(ctxx, uriRequest.Id)
+	if err != nil {
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	NewSuccessResponse(ctx, statusCode, "reward rule fetched successfully", map[string]interface{}{
+		"reward_rule": responses.FromRewardRuleDomainV1(ruleDom),
+	})
+}
+
+func (c *RewardHandler) UpdateRule(ctx *gin.Context) {
+	var uriRequest requests.RewardRuleUriRequest
+	var ruleRequest requests.RewardRuleRequest
+
+	if err := ctx.ShouldBindUri(&uriRequest); err != nil {
+		NewErrorResponse(ctx, http.StatusNotFound, "reward rule not found")
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&ruleRequest); err != nil {
+		NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	ruleDom, statusCode, err := c.rewardUsecase.UpdateRule(ctxx, uriRequest.Id, ruleRequest.ToDomain())
+	if err != nil {
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	NewSuccessResponse(ctx, statusCode, "reward rule updated successfully", map[string]interface{}{
+		"reward_rule": responses.FromRewardRuleDomainV1(ruleDom),
+	})
+}
+
+func (c *RewardHandler) DeleteRule(ctx *gin.Context) {
+	var uriRequest requests.RewardRuleUriRequest
+	if err := ctx.ShouldBindUri(&uriRequest); err != nil {
+		NewErrorResponse(ctx, http.StatusNotFound, "reward rule not found")
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	statusCode, err := c.rewardUsecase.DeleteRule(ctxx, uriRequest.Id)
+	if err != nil {
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	NewSuccessResponse(ctx, statusCode, fmt.Sprintf("reward rule with id %d deleted successfully", uriRequest.Id), nil)
+}
+
+func (c *RewardHandler) GetPointEntries(ctx *gin.Context) {
+	// get authenticated user from context
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(jwt.JwtCustomClaim)
+
+	var queryRequest requests.PointEntriesQueryRequest
+	if err := ctx.ShouldBindQuery(&queryRequest); err != nil {
+		NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	entryDoms, statusCode, err := c.rewardUsecase.GetPointEntries(ctxx, userClaims.UserID, queryRequest.WalletId)
+	if err != nil {
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	entryResponses := responses.ToPointEntryResponseList(entryDoms)
+	if entryResponses == nil {
+		NewSuccessResponse(ctx, statusCode, "point entry data is empty", []int{})
+		return
+	}
+
+	NewSuccessResponse(ctx, statusCode, "point entries fetched successfully", map[string]interface{}{
+		"point_entries": entryResponses,
+	})
+}
+
+func (c *RewardHandler) ConvertPoints(ctx *gin.Context) {
+	var conversionRequest requests.PointConversionRequest
+
+	// get authenticated user from context
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(jwt.JwtCustomClaim)
+
+	if err := ctx.ShouldBindJSON(&conversionRequest); err != nil {
+		NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	transactionDom, statusCode, err := c.rewardUsecase.ConvertPoints(ctxx, userClaims.UserID, conversionRequest.WalletId, conversionRequest.Points)
+	if err != nil {
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	// invalidate cache saldo, saldo poin, dan riwayat transaksi
+	go c.ristrettoCache.Del("transactions")
+	go c.ristrettoCache.Del("wallets", fmt.Sprintf("wallet/wallet_id:%s", transactionDom.WalletId), fmt.Sprintf("wallet/user_id:%s", userClaims.UserID))
+	go c.ristrettoCache.Del(fmt.Sprintf("transaction_history/user_id:%s", userClaims.UserID))
+
+	NewSuccessResponse(ctx, statusCode, "points converted successfully", map[string]interface{}{
+		"transaction": responses.FromTransactionDomainV1(transactionDom),
+	})
+}
@@ -0,0 +1,278 @@
+package v1_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	dgriJWT "github.com/dgrijalva/jwt-go"
+	"github.com/gin-gonic/gin"
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	V1Usecases "github.com/snykk/transaction-api/internal/business/usecases/v1"
+	"github.com/snykk/transaction-api/internal/config"
+	"github.com/snykk/transaction-api/internal/constants"
+	PostgresRepo "github.com/snykk/transaction-api/internal/datasources/repositories/postgres/v1"
+	V1Handlers "github.com/snykk/transaction-api/internal/http/handlers/v1"
+	"github.com/snykk/transaction-api/internal/mocks"
+	"github.com/snykk/transaction-api/pkg/jwt"
+	"github.com/snykk/transaction-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	rewardRepoMock      *mocks.RewardRepository
+	ristrettoRewardMock *mocks.RistrettoCache
+	rewardUsecase       V1Domains.RewardUsecase
+	rewardHandler       V1Handlers.RewardHandler
+	sReward             *gin.Engine
+)
+
+const rewardUserId = "aaaa-bbbb-cccc"
+
+func setupReward(t *testing.T) {
+	// Initialize mock dependencies
+	rewardRepoMock = mocks.NewRewardRepository(t)
+	ristrettoRewardMock = mocks.NewRistrettoCache(t)
+	rewardUsecase = V1Usecases.NewRewardUsecase(rewardRepoMock)
+	rewardHandler = V1Handlers.NewRewardHandler(rewardUsecase, ristrettoRewardMock)
+
+	// Setup Gin engine with middleware for authentication, middleware admin diuji terpisah
+	sReward = gin.Default()
+	sReward.Use(lazyAuthCommonReward)
+	sReward.GET(constants.EndpointV1+"/rewards/points", rewardHandler.GetPointEntries)
+	sReward.POST(constants.EndpointV1+"/rewards/points/convert", rewardHandler.ConvertPoints)
+	sReward.GET(constants.EndpointV1+"/rewards/rules", rewardHandler.GetAllRules)
+	sReward.POST(constants.EndpointV1+"/rewards/rules", rewardHandler.StoreRule)
+	sReward.GET(constants.EndpointV1+"/rewards/rules/:id", rewardHandler.GetRuleById)
+	sReward.PUT(constants.EndpointV1+"/rewards/rules/:id", rewardHandler.UpdateRule)
+	sReward.DELETE(constants.EndpointV1+"/rewards/rules/:id", rewardHandler.DeleteRule)
+}
+
+// Mock lazy authentication
+func lazyAuthCommonReward(ctx *gin.Context) {
+	jwtClaims := jwt.JwtCustomClaim{
+		UserID:  rewardUserId,
+		IsAdmin: false,
+		Email:   "patrick@gmail.com",
+		StandardClaims: dgriJWT.StandardClaims{
+			ExpiresAt: time.Now().Add(time.Hour * time.Duration(config.AppConfig.JWTExpired)).Unix(),
+			Issuer:    "patrick",
+			IssuedAt:  time.Now().Unix(),
+		},
+	}
+	ctx.Set(constants.CtxAuthenticatedUserKey, jwtClaims)
+}
+
+func TestStoreRewardRule(t *testing.T) {
+	setupReward(t)
+
+	t.Run("Success - Create Category Cashback Rule", func(t *testing.T) {
+		reqBody := `{"name":"cashback elektronik","reward_type":"cashback","category":"electronics","rate_bps":150,"monthly_cap":"50000.00"}`
+
+		category := "electronics"
+		monthlyCap := money.FromMajor(50000)
+		ruleFromDB := V1Domains.RewardRuleDomain{
+			Id:         1,
+			Name:       "cashback elektronik",
+			RewardType: constants.RewardTypeCashback,
+			Category:   &category,
+			RateBps:    150,
+			MonthlyCap: &monthlyCap,
+			IsActive:   true,
+			CreatedAt:  time.Now(),
+			UpdatedAt:  time.Now(),
+		}
+
+		// Set up mock expectations
+		rewardRepoMock.Mock.On("StoreRule", mock.Anything, mock.MatchedBy(func(r V1Domains.RewardRuleDomain) bool {
+			return r.IsActive && *r.Category == category && *r.MonthlyCap == monthlyCap && r.PointsExpiryDays == nil
+		})).Return(ruleFromDB, nil).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, constants.EndpointV1+"/rewards/rules", strings.NewReader(reqBody))
+		r.Header.Set("Content-Type", "application/json")
+
+		// Serve request
+		sReward.ServeHTTP(w, r)
+		body := w.Body.String()
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusCreated, w.Result().StatusCode)
+		assert.Contains(t, body, "reward rule created successfully")
+		assert.Contains(t, body, `"category":"electronics"`)
+		assert.Contains(t, body, `"monthly_cap":"50000.00"`)
+	})
+
+	t.Run("Failure - Points Without Expiry", func(t *testing.T) {
+		reqBody := `{"name":"poin belanja","reward_type":"points","rate_bps":100}`
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, constants.EndpointV1+"/rewards/rules", strings.NewReader(reqBody))
+		r.Header.Set("Content-Type", "application/json")
+
+		// Serve request
+		sReward.ServeHTTP(w, r)
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), V1Usecases.ErrRewardExpiryRequired.Error())
+	})
+}
+
+func TestGetRewardRule(t *testing.T) {
+	setupReward(t)
+
+	t.Run("Success - Get Empty Rules", func(t *testing.T) {
+		rewardRepoMock.Mock.On("GetAllRules", mock.Anything).Return(nil, nil).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, constants.EndpointV1+"/rewards/rules", nil)
+
+		// Serve request
+		sReward.ServeHTTP(w, r)
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), "reward rule data is empty")
+	})
+
+	t.Run("Failure - Rule Not Found", func(t *testing.T) {
+		rewardRepoMock.Mock.On("GetRuleById", mock.Anything, 99).Return(V1Domains.RewardRuleDomain{}, PostgresRepo.ErrRewardRuleNotFound).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, constants.EndpointV1+"/rewards/rules/99", nil)
+
+		// Serve request
+		sReward.ServeHTTP(w, r)
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), PostgresRepo.ErrRewardRuleNotFound.Error())
+	})
+}
+
+func TestDeleteRewardRule(t *testing.T) {
+	setupReward(t)
+
+	t.Run("Success - Delete Rule", func(t *testing.T) {
+		rewardRepoMock.Mock.On("DeleteRule", mock.Anything, 3).Return(nil).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodDelete, constants.EndpointV1+"/rewards/rules/3", nil)
+
+		// Serve request
+		sReward.ServeHTTP(w, r)
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), "reward rule with id 3 deleted successfully")
+	})
+}
+
+func TestGetPointEntries(t *testing.T) {
+	setupReward(t)
+
+	t.Run("Success - Get Point Entries Of Default Wallet", func(t *testing.T) {
+		remaining := money.FromMajor(7)
+		expiresAt := time.Date(2099, 1, 1, 0, 0, 0, 0, time.UTC)
+		purchaseId := "tttt-rrrr-xxxx"
+		rewardRepoMock.Mock.On("GetPointEntries", mock.Anything, rewardUserId, "").Return([]V1Domains.PointEntryDomain{
+			{Id: "pppp-0002", WalletId: "wwww-aaaa-llll", EntryType: constants.PointEntryRedeem, Points: money.FromMajor(-3), TransactionId: &purchaseId, CreatedAt: time.Now()},
+			{Id: "pppp-0001", WalletId: "wwww-aaaa-llll", EntryType: constants.PointEntryEarn, Points: money.FromMajor(10), Remaining: &remaining, ExpiresAt: &expiresAt, CreatedAt: time.Now()},
+		}, nil).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, constants.EndpointV1+"/rewards/points", nil)
+
+		// Serve request
+		sReward.ServeHTTP(w, r)
+		body := w.Body.String()
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, "point entries fetched successfully")
+		assert.Contains(t, body, `"entry_type":"redeem","points":"-3.00"`)
+		assert.Contains(t, body, `"remaining":"7.00","expires_at":"2099-01-01T00:00:00Z"`)
+	})
+
+	t.Run("Failure - Invalid Wallet Id", func(t *testing.T) {
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, constants.EndpointV1+"/rewards/points?wallet_id=bukan-uuid", nil)
+
+		// Serve request
+		sReward.ServeHTTP(w, r)
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+}
+
+func TestConvertPoints(t *testing.T) {
+	setupReward(t)
+
+	t.Run("Success - Convert Points To Balance", func(t *testing.T) {
+		reqBody := `{"points":"25.00"}`
+
+		points := money.FromMajor(25)
+		completedAt := time.Now()
+		rewardRepoMock.Mock.On("ConvertPoints", mock.Anything, rewardUserId, "", points).Return(V1Domains.TransactionDomain{
+			Id:              "tttt-cccc-vvvv",
+			WalletId:        "wwww-aaaa-llll",
+			Amount:          points,
+			PointsRedeemed:  points,
+			TransactionType: constants.TransactionTypePointsConversion,
+			Status:          constants.TransactionStatusCompleted,
+			CompletedAt:     &completedAt,
+			CreatedAt:       completedAt,
+		}, nil).Once()
+
+		ristrettoRewardMock.On("Del", mock.AnythingOfType("string")).Twice()
+		ristrettoRewardMock.On("Del", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string")).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, constants.EndpointV1+"/rewards/points/convert", strings.NewReader(reqBody))
+		r.Header.Set("Content-Type", "application/json")
+
+		// Serve request
+		sReward.ServeHTTP(w, r)
+		body := w.Body.String()
+
+		// invalidasi cache berjalan di goroutine, beri waktu sebelum ekspektasi mock diperiksa
+		time.Sleep(50 * time.Millisecond)
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusCreated, w.Result().StatusCode)
+		assert.Contains(t, body, "points converted successfully")
+		assert.Contains(t, body, `"points_redeemed":"25.00"`)
+		assert.Contains(t, body, `"transaction_type":"points_conversion"`)
+	})
+
+	t.Run("Failure - Insufficient Points", func(t *testing.T) {
+		reqBody := `{"points":"1000.00"}`
+
+		rewardRepoMock.Mock.On("ConvertPoints", mock.Anything, rewardUserId, "", money.FromMajor(1000)).Return(V1Domains.TransactionDomain{}, PostgresRepo.ErrInsufficientPoints).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, constants.EndpointV1+"/rewards/points/convert", strings.NewReader(reqBody))
+		r.Header.Set("Content-Type", "application/json")
+
+		// Serve request
+		sReward.ServeHTTP(w, r)
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), PostgresRepo.ErrInsufficientPoints.Error())
+	})
+}
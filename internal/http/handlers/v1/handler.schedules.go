@@ -0,0 +1,147 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+	"github.com/snykk/transaction-api/internal/http/datatransfers/requests"
+	"github.com/snykk/transaction-api/internal/http/datatransfers/responses"
+	"github.com/snykk/transaction-api/pkg/jwt"
+)
+
+// ScheduleHandler tidak memakai cache karena status schedule diubah oleh scheduler di background
+type ScheduleHandler struct {
+	scheduleUsecase V1Domains.ScheduleUsecase
+}
+
+func NewScheduleHandler(scheduleUsecase V1Domains.ScheduleUsecase) ScheduleHandler {
+	return ScheduleHandler{
+		scheduleUsecase: scheduleUsecase,
+	}
+}
+
+func (c *ScheduleHandler) Store(ctx *gin.Context) {
+	var scheduleRequest requests.ScheduleRequest
+
+	// get authenticated user from context
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(jwt.JwtCustomClaim)
+
+	if err := ctx.ShouldBindJSON(&scheduleRequest); err != nil {
+		NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	scheduleDom := scheduleRequest.ToDomain()
+	scheduleDom.UserId = userClaims.UserID
+
+	ctxx := ctx.Request.Context()
+	outDom, statusCode, err := c.scheduleUsecase.Store(ctxx, scheduleDom)
+	if err != nil {
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	NewSuccessResponse(ctx, statusCode, "schedule created successfully", map[string]interface{}{
+		"schedule": responses.FromScheduleDomainV1(outDom),
+	})
+}
+
+func (c *ScheduleHandler) GetAll(ctx *gin.Context) {
+	// get authenticated user from context
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(jwt.JwtCustomClaim)
+
+	ctxx := ctx.Request.Context()
+	scheduleDoms, statusCode, err := c.scheduleUsecase.GetAll(ctxx, userClaims.UserID)
+	if err != nil {
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	scheduleResponses := responses.ToScheduleResponseList(scheduleDoms)
+	if scheduleResponses == nil {
+		NewSuccessResponse(ctx, statusCode, "schedule data is empty", []int{})
+		return
+	}
+
+	NewSuccessResponse(ctx, statusCode, "schedules fetched successfully", map[string]interface{}{
+		"schedules": scheduleResponses,
+	})
+}
+
+func (c *ScheduleHandler) GetById(ctx *gin.Context) {
+	// get authenticated user from context
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(jwt.JwtCustomClaim)
+
+	// id yang bukan uuid tidak mungkin ada di database
+	var uriRequest requests.ScheduleUriRequest
+	if err := ctx.ShouldBindUri(&uriRequest); err != nil {
+		NewErrorResponse(ctx, http.StatusNotFound, "schedule not found")
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	scheduleDom, statusCode, err := c.scheduleUsecase.GetById(ctxx, uriRequest.ScheduleId, userClaims.UserID)
+	if err != nil {
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	NewSuccessResponse(ctx, statusCode, "schedule fetched successfully", map[string]interface{}{
+		"schedule": responses.FromScheduleDomainV1(scheduleDom),
+	})
+}
+
+func (c *ScheduleHandler) Update(ctx *gin.Context) {
+	var uriRequest requests.ScheduleUriRequest
+	var updateRequest requests.ScheduleUpdateRequest
+
+	// get authenticated user from context
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(jwt.JwtCustomClaim)
+
+	// id yang bukan uuid tidak mungkin ada di database
+	if err := ctx.ShouldBindUri(&uriRequest); err != nil {
+		NewErrorResponse(ctx, http.StatusNotFound, "schedule not found")
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&updateRequest); err != nil {
+		NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	scheduleDom, statusCode, err := c.scheduleUsecase.Update(ctxx, uriRequest.ScheduleId, userClaims.UserID, updateRequest.ToDomain())
+	if err != nil {
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	NewSuccessResponse(ctx, statusCode, "schedule updated successfully", map[string]interface{}{
+		"schedule": responses.FromScheduleDomainV1(scheduleDom),
+	})
+}
+
+func (c *ScheduleHandler) Cancel(ctx *gin.Context) {
+	// get authenticated user from context
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(jwt.JwtCustomClaim)
+
+	// id yang bukan uuid tidak mungkin ada di database
+	var uriRequest requests.ScheduleUriRequest
+	if err := ctx.ShouldBindUri(&uriRequest); err != nil {
+		NewErrorResponse(ctx, http.StatusNotFound, "schedule not found")
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	scheduleDom, statusCode, err := c.scheduleUsecase.Cancel(ctxx, uriRequest.ScheduleId, userClaims.UserID)
+	if err != nil {
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	NewSuccessResponse(ctx, statusCode, "schedule cancelled successfully", map[string]interface{}{
+		"schedule": responses.FromScheduleDomainV1(scheduleDom),
+	})
+}
@@ -0,0 +1,291 @@
+package v1_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	dgriJWT "github.com/dgrijalva/jwt-go"
+	"github.com/gin-gonic/gin"
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	V1Usecases "github.com/snykk/transaction-api/internal/business/usecases/v1"
+	"github.com/snykk/transaction-api/internal/config"
+	"github.com/snykk/transaction-api/internal/constants"
+	PostgresRepo "github.com/snykk/transaction-api/internal/datasources/repositories/postgres/v1"
+	V1Handlers "github.com/snykk/transaction-api/internal/http/handlers/v1"
+	"github.com/snykk/transaction-api/internal/mocks"
+	"github.com/snykk/transaction-api/pkg/jwt"
+	"github.com/snykk/transaction-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	scheduleRepoMock   *mocks.ScheduleRepository
+	scheduleUsecase    V1Domains.ScheduleUsecase
+	scheduleHandler    V1Handlers.ScheduleHandler
+	sSchedule          *gin.Engine
+	scheduleDataFromDB V1Domains.ScheduleDomain
+)
+
+func setupSchedule(t *testing.T) {
+	// Initialize mock dependencies
+	scheduleRepoMock = mocks.NewScheduleRepository(t)
+	scheduleUsecase = V1Usecases.NewScheduleUsecase(scheduleRepoMock, 3, 15*time.Minute)
+	scheduleHandler = V1Handlers.NewScheduleHandler(scheduleUsecase)
+
+	startAt := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	scheduleDataFromDB = V1Domains.ScheduleDomain{
+		Id:              "7c9e6679-7425-40de-944b-e07fc1f90ae7",
+		UserId:          "aaaa-bbbb-cccc",
+		RecipientUserId: "dddd-eeee-ffff",
+		Amount:          money.MustParse("125.50"),
+		Frequency:       constants.ScheduleFrequencyWeekly,
+		StartAt:         startAt,
+		NextRunAt:       &startAt,
+		Status:          constants.ScheduleStatusActive,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+
+	// Setup Gin engine with middleware for authentication
+	sSchedule = gin.Default()
+	sSchedule.Use(lazyAuthCommonSchedule)
+	sSchedule.POST(constants.EndpointV1+"/schedules", scheduleHandler.Store)
+	sSchedule.GET(constants.EndpointV1+"/schedules", scheduleHandler.GetAll)
+	sSchedule.GET(constants.EndpointV1+"/schedules/:id", scheduleHandler.GetById)
+	sSchedule.PUT(constants.EndpointV1+"/schedules/:id", scheduleHandler.Update)
+	sSchedule.DELETE(constants.EndpointV1+"/schedules/:id", scheduleHandler.Cancel)
+}
+
+// Mock lazy authentication
+func lazyAuthCommonSchedule(ctx *gin.Context) {
+	jwtClaims := jwt.JwtCustomClaim{
+		UserID:  scheduleDataFromDB.UserId,
+		IsAdmin: false,
+		Email:   "patrick@gmail.com",
+		StandardClaims: dgriJWT.StandardClaims{
+			ExpiresAt: time.Now().Add(time.Hour * time.Duration(config.AppConfig.JWTExpired)).Unix(),
+			Issuer:    "patrick",
+			IssuedAt:  time.Now().Unix(),
+		},
+	}
+	ctx.Set(constants.CtxAuthenticatedUserKey, jwtClaims)
+}
+
+func TestStoreSchedule(t *testing.T) {
+	setupSchedule(t)
+
+	t.Run("Success - Create Schedule", func(t *testing.T) {
+		reqBody := fmt.Sprintf(`{"recipient_username":"john","amount":"125.50","frequency":"weekly","start_at":%q}`, scheduleDataFromDB.StartAt.Format(time.RFC3339))
+
+		// Set up mock expectations
+		scheduleRepoMock.Mock.On("Store", mock.Anything, mock.MatchedBy(func(s V1Domains.ScheduleDomain) bool {
+			return s.UserId == scheduleDataFromDB.UserId && s.RecipientUsername == "john" && s.Amount == scheduleDataFromDB.Amount
+		})).Return(scheduleDataFromDB, nil).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, constants.EndpointV1+"/schedules", strings.NewReader(reqBody))
+		r.Header.Set("Content-Type", "application/json")
+
+		// Serve request
+		sSchedule.ServeHTTP(w, r)
+		body := w.Body.String()
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusCreated, w.Result().StatusCode)
+		assert.Contains(t, body, "schedule created successfully")
+		assert.Contains(t, body, `"amount":"125.50"`)
+		assert.Contains(t, body, `"status":"active"`)
+	})
+
+	t.Run("Failure - Invalid Frequency", func(t *testing.T) {
+		reqBody := `{"recipient_username":"john","amount":"10.00","frequency":"yearly","start_at":"2030-01-01T00:00:00Z"}`
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, constants.EndpointV1+"/schedules", strings.NewReader(reqBody))
+		r.Header.Set("Content-Type", "application/json")
+
+		// Serve request
+		sSchedule.ServeHTTP(w, r)
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), "Frequency")
+	})
+
+	t.Run("Failure - Start In Past", func(t *testing.T) {
+		reqBody := `{"recipient_username":"john","amount":"10.00","frequency":"once","start_at":"2020-01-01T00:00:00Z"}`
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, constants.EndpointV1+"/schedules", strings.NewReader(reqBody))
+		r.Header.Set("Content-Type", "application/json")
+
+		// Serve request
+		sSchedule.ServeHTTP(w, r)
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), V1Usecases.ErrScheduleStartInPast.Error())
+	})
+}
+
+func TestGetAllSchedules(t *testing.T) {
+	setupSchedule(t)
+
+	t.Run("Success - Get Schedules", func(t *testing.T) {
+		// Set up mock expectations
+		scheduleRepoMock.Mock.On("GetByUserId", mock.Anything, scheduleDataFromDB.UserId).Return([]V1Domains.ScheduleDomain{scheduleDataFromDB}, nil).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, constants.EndpointV1+"/schedules", nil)
+
+		// Serve request
+		sSchedule.ServeHTTP(w, r)
+		body := w.Body.String()
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, "schedules fetched successfully")
+		assert.Contains(t, body, scheduleDataFromDB.Id)
+	})
+}
+
+func TestGetScheduleById(t *testing.T) {
+	setupSchedule(t)
+
+	t.Run("Success - Get Schedule With Runs", func(t *testing.T) {
+		transactionId := "tttt-rrrr-xxxx"
+		withRuns := scheduleDataFromDB
+		withRuns.Runs = []V1Domains.ScheduleRunDomain{
+			{Id: 1, ScheduleId: withRuns.Id, Occurrence: 1, ScheduledFor: withRuns.StartAt, Attempt: 1, Status: constants.ScheduleRunStatusSucceeded, TransactionId: &transactionId, ExecutedAt: withRuns.StartAt},
+		}
+
+		// Set up mock expectations
+		scheduleRepoMock.Mock.On("GetById", mock.Anything, withRuns.Id, withRuns.UserId).Return(withRuns, nil).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, constants.EndpointV1+"/schedules/"+withRuns.Id, nil)
+
+		// Serve request
+		sSchedule.ServeHTTP(w, r)
+		body := w.Body.String()
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, "schedule fetched successfully")
+		assert.Contains(t, body, `"transaction_id":"tttt-rrrr-xxxx"`)
+	})
+
+	t.Run("Failure - Invalid Id", func(t *testing.T) {
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, constants.EndpointV1+"/schedules/not-a-uuid", nil)
+
+		// Serve request
+		sSchedule.ServeHTTP(w, r)
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), "schedule not found")
+	})
+
+	t.Run("Failure - Schedule Not Found", func(t *testing.T) {
+		// Set up mock expectations
+		scheduleRepoMock.Mock.On("GetById", mock.Anything, scheduleDataFromDB.Id, scheduleDataFromDB.UserId).Return(V1Domains.ScheduleDomain{}, PostgresRepo.ErrScheduleNotFound).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, constants.EndpointV1+"/schedules/"+scheduleDataFromDB.Id, nil)
+
+		// Serve request
+		sSchedule.ServeHTTP(w, r)
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), PostgresRepo.ErrScheduleNotFound.Error())
+	})
+}
+
+func TestUpdateSchedule(t *testing.T) {
+	setupSchedule(t)
+
+	t.Run("Success - Pause Schedule", func(t *testing.T) {
+		// Set up mock expectations
+		scheduleRepoMock.Mock.On("Update", mock.Anything, scheduleDataFromDB.Id, scheduleDataFromDB.UserId, mock.Anything).
+			Return(func(_ context.Context, _ string, _ string, apply func(V1Domains.ScheduleDomain) (V1Domains.ScheduleDomain, error)) (V1Domains.ScheduleDomain, error) {
+				return apply(scheduleDataFromDB)
+			}).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPut, constants.EndpointV1+"/schedules/"+scheduleDataFromDB.Id, strings.NewReader(`{"status":"paused"}`))
+		r.Header.Set("Content-Type", "application/json")
+
+		// Serve request
+		sSchedule.ServeHTTP(w, r)
+		body := w.Body.String()
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, "schedule updated successfully")
+		assert.Contains(t, body, `"status":"paused"`)
+	})
+
+	t.Run("Failure - Schedule Already Cancelled", func(t *testing.T) {
+		cancelled := scheduleDataFromDB
+		cancelled.Status = constants.ScheduleStatusCancelled
+
+		// Set up mock expectations
+		scheduleRepoMock.Mock.On("Update", mock.Anything, scheduleDataFromDB.Id, scheduleDataFromDB.UserId, mock.Anything).
+			Return(func(_ context.Context, _ string, _ string, apply func(V1Domains.ScheduleDomain) (V1Domains.ScheduleDomain, error)) (V1Domains.ScheduleDomain, error) {
+				return apply(cancelled)
+			}).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPut, constants.EndpointV1+"/schedules/"+scheduleDataFromDB.Id, strings.NewReader(`{"amount":"50.00"}`))
+		r.Header.Set("Content-Type", "application/json")
+
+		// Serve request
+		sSchedule.ServeHTTP(w, r)
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusConflict, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), V1Usecases.ErrScheduleNotModifiable.Error())
+	})
+}
+
+func TestCancelSchedule(t *testing.T) {
+	setupSchedule(t)
+
+	t.Run("Success - Cancel Schedule", func(t *testing.T) {
+		// Set up mock expectations
+		scheduleRepoMock.Mock.On("Update", mock.Anything, scheduleDataFromDB.Id, scheduleDataFromDB.UserId, mock.Anything).
+			Return(func(_ context.Context, _ string, _ string, apply func(V1Domains.ScheduleDomain) (V1Domains.ScheduleDomain, error)) (V1Domains.ScheduleDomain, error) {
+				return apply(scheduleDataFromDB)
+			}).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodDelete, constants.EndpointV1+"/schedules/"+scheduleDataFromDB.Id, nil)
+
+		// Serve request
+		sSchedule.ServeHTTP(w, r)
+		body := w.Body.String()
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, "schedule cancelled successfully")
+		assert.Contains(t, body, `"status":"cancelled"`)
+	})
+}
@@ -0,0 +1,69 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+	"github.com/snykk/transaction-api/internal/http/datatransfers/requests"
+	"github.com/snykk/transaction-api/internal/http/datatransfers/responses"
+	"github.com/snykk/transaction-api/pkg/jwt"
+)
+
+// StatementHandler tidak memakai cache karena statement dibuat oleh proses cron,
+// sehingga handler ini tidak punya kesempatan untuk menghapus cache yang basi.
+type StatementHandler struct {
+	statementUsecase V1Domains.StatementUsecase
+}
+
+func NewStatementHandler(statementUsecase V1Domains.StatementUsecase) StatementHandler {
+	return StatementHandler{
+		statementUsecase: statementUsecase,
+	}
+}
+
+func (c *StatementHandler) GetAll(ctx *gin.Context) {
+	// get authenticated user from context
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(jwt.JwtCustomClaim)
+
+	ctxx := ctx.Request.Context()
+	statementDoms, statusCode, err := c.statementUsecase.GetAll(ctxx, userClaims.UserID)
+	if err != nil {
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	statementResponses := responses.ToMonthlyStatementResponseList(statementDoms)
+	if statementResponses == nil {
+		NewSuccessResponse(ctx, statusCode, "statement data is empty", []int{})
+		return
+	}
+
+	NewSuccessResponse(ctx, statusCode, "statements fetched successfully", map[string]interface{}{
+		"statements": statementResponses,
+	})
+}
+
+func (c *StatementHandler) GetById(ctx *gin.Context) {
+	// get authenticated user from context
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(jwt.JwtCustomClaim)
+
+	// id yang bukan uuid tidak mungkin ada di database
+	var uriRequest requests.StatementUriRequest
+	if err := ctx.ShouldBindUri(&uriRequest); err != nil {
+		NewErrorResponse(ctx, http.StatusNotFound, "statement not found")
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	statementDom, statusCode, err := c.statementUsecase.GetById(ctxx, uriRequest.StatementId, userClaims.UserID)
+	if err != nil {
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	NewSuccessResponse(ctx, statusCode, "statement fetched successfully", map[string]interface{}{
+		"statement": responses.FromMonthlyStatementDomainV1(statementDom),
+	})
+}
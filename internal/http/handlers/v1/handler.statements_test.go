@@ -0,0 +1,177 @@
+package v1_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	dgriJWT "github.com/dgrijalva/jwt-go"
+	"github.com/gin-gonic/gin"
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	V1Usecases "github.com/snykk/transaction-api/internal/business/usecases/v1"
+	"github.com/snykk/transaction-api/internal/config"
+	"github.com/snykk/transaction-api/internal/constants"
+	PostgresRepo "github.com/snykk/transaction-api/internal/datasources/repositories/postgres/v1"
+	V1Handlers "github.com/snykk/transaction-api/internal/http/handlers/v1"
+	"github.com/snykk/transaction-api/internal/mocks"
+	"github.com/snykk/transaction-api/pkg/jwt"
+	"github.com/snykk/transaction-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	statementRepoMock   *mocks.StatementRepository
+	statementUsecase    V1Domains.StatementUsecase
+	statementHandler    V1Handlers.StatementHandler
+	sStatement          *gin.Engine
+	statementDataFromDB V1Domains.MonthlyStatementDomain
+)
+
+func setupStatement(t *testing.T) {
+	// Initialize mock dependencies
+	statementRepoMock = mocks.NewStatementRepository(t)
+	statementUsecase = V1Usecases.NewStatementUsecase(statementRepoMock, nil, "IDR")
+	statementHandler = V1Handlers.NewStatementHandler(statementUsecase)
+
+	jakarta := time.FixedZone("Asia/Jakarta", 7*60*60)
+	statementDataFromDB = V1Domains.MonthlyStatementDomain{
+		Id:             "3f1a0c5e-8d2b-4a57-9c1e-2b6f4e8d9a10",
+		UserId:         "aaaa-bbbb-cccc",
+		WalletId:       "xxxx-yyyy-zzzz",
+		Period:         "2024-01",
+		Timezone:       constants.DefaultTimezone,
+		PeriodStart:    time.Date(2024, 1, 1, 0, 0, 0, 0, jakarta),
+		PeriodEnd:      time.Date(2024, 2, 1, 0, 0, 0, 0, jakarta),
+		OpeningBalance: money.FromMajor(10),
+		ClosingBalance: money.MustParse("160.50"),
+		Totals: []V1Domains.StatementTotalDomain{
+			{TransactionType: constants.TransactionTypeDeposit, Count: 2, TotalCredit: money.MustParse("150.50")},
+		},
+		CreatedAt: time.Now(),
+	}
+
+	// Setup Gin engine with middleware for authentication
+	sStatement = gin.Default()
+	sStatement.Use(lazyAuthCommonStatement)
+	sStatement.GET(constants.EndpointV1+"/statements", statementHandler.GetAll)
+	sStatement.GET(constants.EndpointV1+"/statements/:id", statementHandler.GetById)
+}
+
+// Mock lazy authentication
+func lazyAuthCommonStatement(ctx *gin.Context) {
+	jwtClaims := jwt.JwtCustomClaim{
+		UserID:  statementDataFromDB.UserId,
+		IsAdmin: false,
+		Email:   "patrick@gmail.com",
+		StandardClaims: dgriJWT.StandardClaims{
+			ExpiresAt: time.Now().Add(time.Hour * time.Duration(config.AppConfig.JWTExpired)).Unix(),
+			Issuer:    "patrick",
+			IssuedAt:  time.Now().Unix(),
+		},
+	}
+	ctx.Set(constants.CtxAuthenticatedUserKey, jwtClaims)
+}
+
+func TestGetAllStatements(t *testing.T) {
+	setupStatement(t)
+
+	t.Run("Success - Get Statements", func(t *testing.T) {
+		// Set up mock expectations
+		statementRepoMock.Mock.On("GetByUserId", mock.Anything, statementDataFromDB.UserId).Return([]V1Domains.MonthlyStatementDomain{statementDataFromDB}, nil).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, constants.EndpointV1+"/statements", nil)
+
+		// Serve request
+		sStatement.ServeHTTP(w, r)
+		body := w.Body.String()
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, "statements fetched successfully")
+		assert.Contains(t, body, `"period":"2024-01"`)
+		assert.Contains(t, body, `"closing_balance":"160.50"`)
+	})
+
+	t.Run("Success - Empty Statements", func(t *testing.T) {
+		// Set up mock expectations
+		statementRepoMock.Mock.On("GetByUserId", mock.Anything, statementDataFromDB.UserId).Return([]V1Domains.MonthlyStatementDomain{}, nil).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, constants.EndpointV1+"/statements", nil)
+
+		// Serve request
+		sStatement.ServeHTTP(w, r)
+		body := w.Body.String()
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, "statement data is empty")
+	})
+}
+
+func TestGetStatementById(t *testing.T) {
+	setupStatement(t)
+
+	t.Run("Success - Get Statement With Totals", func(t *testing.T) {
+		// Set up mock expectations
+		statementRepoMock.Mock.On("GetById", mock.Anything, statementDataFromDB.Id, statementDataFromDB.UserId).Return(statementDataFromDB, nil).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, constants.EndpointV1+"/statements/"+statementDataFromDB.Id, nil)
+
+		// Serve request
+		sStatement.ServeHTTP(w, r)
+
+		var response struct {
+			Data struct {
+				Statement map[string]interface{} `json:"statement"`
+			} `json:"data"`
+		}
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+
+		// Assert the HTTP response
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Equal(t, statementDataFromDB.Id, response.Data.Statement["statement_id"])
+		assert.Equal(t, "10.00", response.Data.Statement["opening_balance"])
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"transaction_type": "deposit", "count": float64(2), "total_credit": "150.50", "total_debit": "0.00"},
+		}, response.Data.Statement["totals"])
+	})
+
+	t.Run("Failure - Statement Not Found", func(t *testing.T) {
+		// Set up mock expectations
+		statementRepoMock.Mock.On("GetById", mock.Anything, statementDataFromDB.Id, statementDataFromDB.UserId).Return(V1Domains.MonthlyStatementDomain{}, PostgresRepo.ErrStatementNotFound).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, constants.EndpointV1+"/statements/"+statementDataFromDB.Id, nil)
+
+		// Serve request
+		sStatement.ServeHTTP(w, r)
+		body := w.Body.String()
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+		assert.Contains(t, body, "statement not found")
+	})
+
+	t.Run("Failure - Invalid Statement Id", func(t *testing.T) {
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, constants.EndpointV1+"/statements/not-a-uuid", nil)
+
+		// Serve request
+		sStatement.ServeHTTP(w, r)
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+	})
+}
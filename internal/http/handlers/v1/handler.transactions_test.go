@@ -2,9 +2,12 @@ package v1_test
 
 import (
 	"bytes"
+	"context"
+	"database/sql"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -16,10 +19,12 @@ import (
 	"github.com/snykk/transaction-api/internal/constants"
 	PostgresRepo "github.com/snykk/transaction-api/internal/datasources/repositories/postgres/v1"
 	"github.com/snykk/transaction-api/internal/http/datatransfers/requests"
+	"github.com/snykk/transaction-api/internal/http/datatransfers/responses"
 	V1Handlers "github.com/snykk/transaction-api/internal/http/handlers/v1"
 	"github.com/snykk/transaction-api/internal/mocks"
 	"github.com/snykk/transaction-api/pkg/helpers"
 	"github.com/snykk/transaction-api/pkg/jwt"
+	"github.com/snykk/transaction-api/pkg/money"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -27,6 +32,8 @@ import (
 var (
 	jwtServiceTransactionMock *mocks.JWTService
 	transactionRepoMock       *mocks.TransactionRepository
+	transactionFeeRepoMock    *mocks.FeeRuleRepository
+	transactionProductRepo    *mocks.ProductRepository
 	transactionUsecase        V1Domains.TransactionUsecase
 	transactionHandler        V1Handlers.TransactionHandler
 	ristrettoTransactiontMock *mocks.RistrettoCache
@@ -40,7 +47,9 @@ func setupTransaction(t *testing.T) {
 	jwtServiceTransactionMock = mocks.NewJWTService(t)
 	ristrettoTransactiontMock = mocks.NewRistrettoCache(t)
 	transactionRepoMock = mocks.NewTransactionRepository(t)
-	transactionUsecase = V1Usecases.NewTransactionUsecase(transactionRepoMock)
+	transactionFeeRepoMock = mocks.NewFeeRuleRepository(t)
+	transactionProductRepo = mocks.NewProductRepository(t)
+	transactionUsecase = V1Usecases.NewTransactionUsecase(transactionRepoMock, transactionFeeRepoMock, transactionProductRepo)
 	transactionHandler = V1Handlers.NewTransactionHandler(transactionUsecase, ristrettoTransactiontMock)
 
 	productId1 := 1
@@ -57,7 +66,7 @@ func setupTransaction(t *testing.T) {
 			Wallet: V1Domains.WalletDomain{
 				Id:        "sdfas",
 				UserId:    "asdfsa",
-				Balance:   200,
+				Balance:   money.FromMajor(200),
 				CreatedAt: current_time,
 				User: V1Domains.UserDomain{
 					ID:        "asdfads",
@@ -71,7 +80,7 @@ func setupTransaction(t *testing.T) {
 			},
 			ProductId:       &productId1, // Nullable, karena transaksi deposit tidak melibatkan transaksi
 			Product:         V1Domains.ProductDomain{},
-			Amount:          200,
+			Amount:          money.FromMajor(200),
 			Quantity:        &quantity1,
 			TransactionType: "asdf",
 			CreatedAt:       current_time,
@@ -82,7 +91,7 @@ func setupTransaction(t *testing.T) {
 			Wallet: V1Domains.WalletDomain{
 				Id:        "sdfas",
 				UserId:    "asdfsa",
-				Balance:   200,
+				Balance:   money.FromMajor(200),
 				CreatedAt: current_time,
 				User: V1Domains.UserDomain{
 					ID:        "asdfads",
@@ -96,7 +105,7 @@ func setupTransaction(t *testing.T) {
 			},
 			ProductId:       &productId2, // Nullable, karena transaksi deposit tidak melibatkan transaksi
 			Product:         V1Domains.ProductDomain{},
-			Amount:          200,
+			Amount:          money.FromMajor(200),
 			Quantity:        &quantity2,
 			TransactionType: "asdf",
 			CreatedAt:       current_time,
@@ -153,7 +162,7 @@ func TestDeposit(t *testing.T) {
 
 	t.Run("Success - Deposit Transaction", func(t *testing.T) {
 		req := requests.TransactionDepositOrWithdrawRequest{
-			Amount: 200,
+			Amount: money.FromMajor(200),
 		}
 
 		reqBody, _ := json.Marshal(req)
@@ -182,7 +191,7 @@ func TestDeposit(t *testing.T) {
 
 	t.Run("Failure - Invalid Amount", func(t *testing.T) {
 		req := requests.TransactionDepositOrWithdrawRequest{
-			Amount: -200,
+			Amount: money.FromMajor(-200),
 		}
 		reqBody, _ := json.Marshal(req)
 
@@ -200,6 +209,71 @@ func TestDeposit(t *testing.T) {
 		assert.Contains(t, w.Result().Header.Get("Content-Type"), "application/json")
 		assert.Contains(t, body, "Field validation for 'Amount' failed on the 'gt'")
 	})
+
+	t.Run("Success - Deposit With Description, Reference And Metadata", func(t *testing.T) {
+		description := "gaji bulan januari"
+		reference := "INV-2024-001"
+		metadata := map[string]interface{}{"source": "payroll"}
+		req := requests.TransactionDepositOrWithdrawRequest{
+			Amount:            money.FromMajor(200),
+			Description:       description,
+			ExternalReference: reference,
+			Metadata:          metadata,
+		}
+		reqBody, _ := json.Marshal(req)
+
+		depositFromDB := transactionDataFromDB
+		depositFromDB.Description = &description
+		depositFromDB.ExternalReference = &reference
+		depositFromDB.Metadata = metadata
+
+		expectedDomain := mock.MatchedBy(func(dom V1Domains.TransactionDomain) bool {
+			return *dom.Description == description && *dom.ExternalReference == reference && dom.Metadata["source"] == "payroll"
+		})
+
+		// Set up mock expectations
+		transactionRepoMock.Mock.On("Deposit", mock.Anything, expectedDomain).Return(depositFromDB, nil).Once()
+
+		ristrettoTransactiontMock.On("Del", mock.AnythingOfType("string")).Once()
+		ristrettoTransactiontMock.On("Del", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string")).Once()
+		ristrettoTransactiontMock.On("Del", mock.AnythingOfType("string")).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, constants.EndpointV1+"/transactions/deposit", bytes.NewReader(reqBody))
+		r.Header.Set("Content-Type", "application/json")
+
+		// Serve request
+		sTransaction.ServeHTTP(w, r)
+		body := w.Body.String()
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusCreated, w.Result().StatusCode)
+		assert.Contains(t, body, `"description":"gaji bulan januari"`)
+		assert.Contains(t, body, `"external_reference":"INV-2024-001"`)
+		assert.Contains(t, body, `"metadata":{"source":"payroll"}`)
+	})
+
+	t.Run("Failure - Description Too Long", func(t *testing.T) {
+		req := requests.TransactionDepositOrWithdrawRequest{
+			Amount:      money.FromMajor(200),
+			Description: strings.Repeat("a", 256),
+		}
+		reqBody, _ := json.Marshal(req)
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, constants.EndpointV1+"/transactions/deposit", bytes.NewReader(reqBody))
+		r.Header.Set("Content-Type", "application/json")
+
+		// Serve request
+		sTransaction.ServeHTTP(w, r)
+		body := w.Body.String()
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+		assert.Contains(t, body, "Field validation for 'Description' failed on the 'max'")
+	})
 }
 
 func TestWithdraw(t *testing.T) {
@@ -212,13 +286,23 @@ func TestWithdraw(t *testing.T) {
 
 	t.Run("Success - Withdraw Transaction", func(t *testing.T) {
 		req := requests.TransactionDepositOrWithdrawRequest{
-			Amount: 200,
+			Amount: money.FromMajor(200),
 		}
 
 		reqBody, _ := json.Marshal(req)
 
+		flatAmount := money.FromMajor(2)
+		withdrawDataFromDB := transactionDataFromDB
+		withdrawDataFromDB.Fee = flatAmount
+		withdrawDataFromDB.FeeBreakdown = []V1Domains.FeeComponentDomain{{FeeRuleId: 1, Name: "withdraw fee", FeeType: constants.FeeTypeFlat, Amount: flatAmount}}
+
 		// Set up mock expectations
-		transactionRepoMock.Mock.On("Withdraw", mock.Anything, mock.AnythingOfType("v1.TransactionDomain")).Return(transactionDataFromDB, nil).Once()
+		transactionFeeRepoMock.Mock.On("GetApplicable", mock.Anything, constants.TransactionTypeWithdraw, (*int)(nil)).Return([]V1Domains.FeeRuleDomain{
+			{Id: 1, Name: "withdraw fee", TransactionType: constants.TransactionTypeWithdraw, FeeType: constants.FeeTypeFlat, FlatAmount: &flatAmount},
+		}, nil).Once()
+		transactionRepoMock.Mock.On("Withdraw", mock.Anything, mock.MatchedBy(func(d V1Domains.TransactionDomain) bool {
+			return d.Fee == flatAmount
+		})).Return(withdrawDataFromDB, nil).Once()
 
 		ristrettoTransactiontMock.On("Del", mock.AnythingOfType("string")).Once()
 		ristrettoTransactiontMock.On("Del", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string")).Once()
@@ -234,14 +318,16 @@ func TestWithdraw(t *testing.T) {
 		body := w.Body.String()
 
 		// Assert the HTTP response
-		assert.Equal(t, http.StatusCreated, w.Result().StatusCode)
+		assert.Equal(t, http.StatusAccepted, w.Result().StatusCode)
 		assert.Contains(t, w.Result().Header.Get("Content-Type"), "application/json")
-		assert.Contains(t, body, "withdraw completed successfully")
+		assert.Contains(t, body, "withdraw request submitted and is pending")
+		assert.Contains(t, body, `"fee":"2.00"`)
+		assert.Contains(t, body, `"fee_breakdown":[{"fee_rule_id":1,"name":"withdraw fee","fee_type":"flat","amount":"2.00"}]`)
 	})
 
 	t.Run("Failure - Invalid Amount", func(t *testing.T) {
 		req := requests.TransactionDepositOrWithdrawRequest{
-			Amount: -200,
+			Amount: money.FromMajor(-200),
 		}
 		reqBody, _ := json.Marshal(req)
 
@@ -259,6 +345,30 @@ func TestWithdraw(t *testing.T) {
 		assert.Contains(t, w.Result().Header.Get("Content-Type"), "application/json")
 		assert.Contains(t, body, "Field validation for 'Amount' failed on the 'gt'")
 	})
+
+	t.Run("Failure - Wallet Frozen For Outgoing", func(t *testing.T) {
+		req := requests.TransactionDepositOrWithdrawRequest{
+			Amount: money.FromMajor(200),
+		}
+		reqBody, _ := json.Marshal(req)
+
+		// Set up mock expectations
+		transactionFeeRepoMock.Mock.On("GetApplicable", mock.Anything, constants.TransactionTypeWithdraw, (*int)(nil)).Return(nil, nil).Once()
+		transactionRepoMock.Mock.On("Withdraw", mock.Anything, mock.AnythingOfType("v1.TransactionDomain")).Return(V1Domains.TransactionDomain{}, PostgresRepo.ErrWalletFrozenOutgoing).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, constants.EndpointV1+"/transactions/withdraw", bytes.NewReader(reqBody))
+		r.Header.Set("Content-Type", "application/json")
+
+		// Serve request
+		sTransaction.ServeHTTP(w, r)
+		body := w.Body.String()
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusLocked, w.Result().StatusCode)
+		assert.Contains(t, body, "wallet is frozen for outgoing transactions")
+	})
 }
 
 func TestWithPurchase(t *testing.T) {
@@ -278,6 +388,8 @@ func TestWithPurchase(t *testing.T) {
 		reqBody, _ := json.Marshal(req)
 
 		// Set up mock expectations
+		transactionProductRepo.Mock.On("GetProductById", mock.Anything, 1).Return(V1Domains.ProductDomain{Id: 1, Price: money.FromMajor(1)}, nil).Once()
+		transactionFeeRepoMock.Mock.On("GetApplicable", mock.Anything, constants.TransactionTypePurchase, mock.Anything).Return([]V1Domains.FeeRuleDomain{}, nil).Once()
 		transactionRepoMock.Mock.On("Purchase", mock.Anything, mock.AnythingOfType("v1.TransactionDomain")).Return(transactionDataFromDB, nil).Once()
 
 		ristrettoTransactiontMock.On("Del", mock.AnythingOfType("string")).Once()
@@ -300,6 +412,116 @@ func TestWithPurchase(t *testing.T) {
 		assert.Contains(t, body, "purchase successful")
 	})
 
+	t.Run("Success - Purchase With Voucher", func(t *testing.T) {
+		reqBody := `{"product_id":1,"quantity":2,"voucher_code":"hemat10"}`
+
+		discounted := transactionDataFromDB
+		discounted.Discount = money.FromMajor(1)
+		voucherCode := "HEMAT10"
+		discounted.VoucherCode = &voucherCode
+
+		// Set up mock expectations
+		transactionProductRepo.Mock.On("GetProductById", mock.Anything, 1).Return(V1Domains.ProductDomain{Id: 1, Price: money.FromMajor(5)}, nil).Once()
+		transactionFeeRepoMock.Mock.On("GetApplicable", mock.Anything, constants.TransactionTypePurchase, mock.Anything).Return([]V1Domains.FeeRuleDomain{}, nil).Once()
+		transactionRepoMock.Mock.On("Purchase", mock.Anything, mock.MatchedBy(func(d V1Domains.TransactionDomain) bool {
+			return d.VoucherCode != nil && *d.VoucherCode == "hemat10"
+		})).Return(discounted, nil).Once()
+
+		ristrettoTransactiontMock.On("Del", mock.AnythingOfType("string")).Once()
+		ristrettoTransactiontMock.On("Del", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string")).Once()
+		ristrettoTransactiontMock.On("Del", mock.AnythingOfType("string"), mock.AnythingOfType("string")).Once()
+		ristrettoTransactiontMock.On("Del", mock.AnythingOfType("string")).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, constants.EndpointV1+"/transactions/purchase", strings.NewReader(reqBody))
+		r.Header.Set("Content-Type", "application/json")
+
+		// Serve request
+		sTransaction.ServeHTTP(w, r)
+		body := w.Body.String()
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusCreated, w.Result().StatusCode)
+		assert.Contains(t, body, `"discount":"1.00"`)
+		assert.Contains(t, body, `"voucher_code":"HEMAT10"`)
+	})
+
+	t.Run("Success - Purchase With Points And Cashback", func(t *testing.T) {
+		reqBody := `{"product_id":1,"quantity":2,"redeem_points":"3.00"}`
+
+		withPoints := transactionDataFromDB
+		withPoints.PointsRedeemed = money.FromMajor(3)
+		withPoints.Cashback = money.MustParse("0.14")
+
+		// Set up mock expectations
+		transactionProductRepo.Mock.On("GetProductById", mock.Anything, 1).Return(V1Domains.ProductDomain{Id: 1, Price: money.FromMajor(5)}, nil).Once()
+		transactionFeeRepoMock.Mock.On("GetApplicable", mock.Anything, constants.TransactionTypePurchase, mock.Anything).Return([]V1Domains.FeeRuleDomain{}, nil).Once()
+		transactionRepoMock.Mock.On("Purchase", mock.Anything, mock.MatchedBy(func(d V1Domains.TransactionDomain) bool {
+			return d.PointsRedeemed == money.FromMajor(3)
+		})).Return(withPoints, nil).Once()
+
+		ristrettoTransactiontMock.On("Del", mock.AnythingOfType("string")).Once()
+		ristrettoTransactiontMock.On("Del", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string")).Once()
+		ristrettoTransactiontMock.On("Del", mock.AnythingOfType("string"), mock.AnythingOfType("string")).Once()
+		ristrettoTransactiontMock.On("Del", mock.AnythingOfType("string")).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, constants.EndpointV1+"/transactions/purchase", strings.NewReader(reqBody))
+		r.Header.Set("Content-Type", "application/json")
+
+		// Serve request
+		sTransaction.ServeHTTP(w, r)
+		body := w.Body.String()
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusCreated, w.Result().StatusCode)
+		assert.Contains(t, body, `"points_redeemed":"3.00"`)
+		assert.Contains(t, body, `"cashback":"0.14"`)
+		assert.NotContains(t, body, `"points_earned"`)
+	})
+
+	t.Run("Failure - Points Cover Full Amount", func(t *testing.T) {
+		reqBody := `{"product_id":1,"quantity":2,"redeem_points":"10.00"}`
+
+		transactionProductRepo.Mock.On("GetProductById", mock.Anything, 1).Return(V1Domains.ProductDomain{Id: 1, Price: money.FromMajor(5)}, nil).Once()
+		transactionFeeRepoMock.Mock.On("GetApplicable", mock.Anything, constants.TransactionTypePurchase, mock.Anything).Return([]V1Domains.FeeRuleDomain{}, nil).Once()
+		transactionRepoMock.Mock.On("Purchase", mock.Anything, mock.AnythingOfType("v1.TransactionDomain")).Return(V1Domains.TransactionDomain{}, PostgresRepo.ErrPointsCoverFullAmount).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, constants.EndpointV1+"/transactions/purchase", strings.NewReader(reqBody))
+		r.Header.Set("Content-Type", "application/json")
+
+		// Serve request
+		sTransaction.ServeHTTP(w, r)
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), PostgresRepo.ErrPointsCoverFullAmount.Error())
+	})
+
+	t.Run("Failure - Voucher Invalid", func(t *testing.T) {
+		reqBody := `{"product_id":1,"quantity":2,"voucher_code":"EXPIRED"}`
+
+		transactionProductRepo.Mock.On("GetProductById", mock.Anything, 1).Return(V1Domains.ProductDomain{Id: 1, Price: money.FromMajor(5)}, nil).Once()
+		transactionFeeRepoMock.Mock.On("GetApplicable", mock.Anything, constants.TransactionTypePurchase, mock.Anything).Return([]V1Domains.FeeRuleDomain{}, nil).Once()
+		transactionRepoMock.Mock.On("Purchase", mock.Anything, mock.AnythingOfType("v1.TransactionDomain")).Return(V1Domains.TransactionDomain{}, PostgresRepo.ErrVoucherInvalid).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, constants.EndpointV1+"/transactions/purchase", strings.NewReader(reqBody))
+		r.Header.Set("Content-Type", "application/json")
+
+		// Serve request
+		sTransaction.ServeHTTP(w, r)
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), PostgresRepo.ErrVoucherInvalid.Error())
+	})
+
 	t.Run("Failure - Invalid Quantity", func(t *testing.T) {
 		req := requests.TransactionPurchaseRequest{
 			ProductId: 1,
@@ -330,7 +552,7 @@ func TestWithPurchase(t *testing.T) {
 
 		reqBody, _ := json.Marshal(req)
 
-		transactionRepoMock.Mock.On("Purchase", mock.Anything, mock.AnythingOfType("v1.TransactionDomain")).Return(V1Domains.TransactionDomain{}, PostgresRepo.ErrProductNotFound).Once()
+		transactionProductRepo.Mock.On("GetProductById", mock.Anything, 999).Return(V1Domains.ProductDomain{}, sql.ErrNoRows).Once()
 
 		// Perform the HTTP request
 		w := httptest.NewRecorder()
@@ -355,6 +577,8 @@ func TestWithPurchase(t *testing.T) {
 
 		reqBody, _ := json.Marshal(req)
 
+		transactionProductRepo.Mock.On("GetProductById", mock.Anything, 1).Return(V1Domains.ProductDomain{Id: 1, Price: money.FromMajor(1)}, nil).Once()
+		transactionFeeRepoMock.Mock.On("GetApplicable", mock.Anything, constants.TransactionTypePurchase, mock.Anything).Return([]V1Domains.FeeRuleDomain{}, nil).Once()
 		transactionRepoMock.Mock.On("Purchase", mock.Anything, mock.AnythingOfType("v1.TransactionDomain")).Return(V1Domains.TransactionDomain{}, PostgresRepo.ErrInsufficientProductStock).Once()
 
 		// Perform the HTTP request
@@ -372,3 +596,608 @@ func TestWithPurchase(t *testing.T) {
 		assert.Contains(t, body, "insufficient product stock")
 	})
 }
+
+func TestTransfer(t *testing.T) {
+	setupTransaction(t)
+
+	sTransaction.Use(lazyAuthCommonTransaction)
+
+	// Define the route for testing
+	sTransaction.POST(constants.EndpointV1+"/transactions/transfer", transactionHandler.Transfer)
+
+	t.Run("Success - Transfer Transaction", func(t *testing.T) {
+		req := requests.TransactionTransferRequest{
+			RecipientUsername: "spongebob",
+			Amount:            money.FromMajor(200),
+		}
+
+		reqBody, _ := json.Marshal(req)
+
+		transferDataFromDB := V1Domains.TransferDomain{
+			SenderUserId:      transactionDataFromDB.Wallet.User.ID,
+			RecipientUserId:   "recipient-user-id",
+			RecipientUsername: "spongebob",
+			Amount:            money.FromMajor(200),
+			Outgoing:          transactionsDataFromDB[0],
+			Incoming:          transactionsDataFromDB[1],
+		}
+
+		// Set up mock expectations
+		transactionRepoMock.Mock.On("Transfer", mock.Anything, mock.AnythingOfType("v1.TransferDomain")).Return(transferDataFromDB, nil).Once()
+
+		ristrettoTransactiontMock.On("Del", mock.AnythingOfType("string")).Once()
+		ristrettoTransactiontMock.On("Del", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string")).Once()
+		ristrettoTransactiontMock.On("Del", mock.AnythingOfType("string"), mock.AnythingOfType("string")).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, constants.EndpointV1+"/transactions/transfer", bytes.NewReader(reqBody))
+		r.Header.Set("Content-Type", "application/json")
+
+		// Serve request
+		sTransaction.ServeHTTP(w, r)
+		body := w.Body.String()
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusCreated, w.Result().StatusCode)
+		assert.Contains(t, w.Result().Header.Get("Content-Type"), "application/json")
+		assert.Contains(t, body, "transfer completed successfully")
+		assert.Contains(t, body, "recipient-user-id")
+	})
+
+	t.Run("Failure - Invalid Recipient Email", func(t *testing.T) {
+		req := requests.TransactionTransferRequest{
+			RecipientEmail: "not-an-email",
+			Amount:         money.FromMajor(200),
+		}
+		reqBody, _ := json.Marshal(req)
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, constants.EndpointV1+"/transactions/transfer", bytes.NewReader(reqBody))
+		r.Header.Set("Content-Type", "application/json")
+
+		// Serve request
+		sTransaction.ServeHTTP(w, r)
+		body := w.Body.String()
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+		assert.Contains(t, w.Result().Header.Get("Content-Type"), "application/json")
+		assert.Contains(t, body, "Field validation for 'RecipientEmail' failed on the 'email'")
+	})
+
+	t.Run("Failure - Recipient Not Found", func(t *testing.T) {
+		req := requests.TransactionTransferRequest{
+			RecipientUsername: "nobody",
+			Amount:            money.FromMajor(200),
+		}
+		reqBody, _ := json.Marshal(req)
+
+		transactionRepoMock.Mock.On("Transfer", mock.Anything, mock.AnythingOfType("v1.TransferDomain")).Return(V1Domains.TransferDomain{}, PostgresRepo.ErrRecipientNotFound).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, constants.EndpointV1+"/transactions/transfer", bytes.NewReader(reqBody))
+		r.Header.Set("Content-Type", "application/json")
+
+		// Serve request
+		sTransaction.ServeHTTP(w, r)
+		body := w.Body.String()
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+		assert.Contains(t, w.Result().Header.Get("Content-Type"), "application/json")
+		assert.Contains(t, body, "recipient not found")
+	})
+
+	t.Run("Failure - Recipient Wallet Frozen", func(t *testing.T) {
+		req := requests.TransactionTransferRequest{
+			RecipientUsername: "spongebob",
+			Amount:            money.FromMajor(200),
+		}
+		reqBody, _ := json.Marshal(req)
+
+		transactionRepoMock.Mock.On("Transfer", mock.Anything, mock.AnythingOfType("v1.TransferDomain")).Return(V1Domains.TransferDomain{}, PostgresRepo.ErrRecipientWalletFrozen).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, constants.EndpointV1+"/transactions/transfer", bytes.NewReader(reqBody))
+		r.Header.Set("Content-Type", "application/json")
+
+		// Serve request
+		sTransaction.ServeHTTP(w, r)
+		body := w.Body.String()
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusForbidden, w.Result().StatusCode)
+		assert.Contains(t, body, "recipient wallet cannot receive funds")
+	})
+}
+
+func TestUpdateStatus(t *testing.T) {
+	setupTransaction(t)
+
+	// Define the route for testing
+	sTransaction.PUT(constants.EndpointV1+"/transactions/:id/status", transactionHandler.UpdateStatus)
+
+	transactionId := "5b0b5a8e-2f0c-4d8a-9a53-0c2f1c6f3f11"
+
+	t.Run("Success - Update Transaction Status", func(t *testing.T) {
+		req := requests.TransactionStatusUpdateRequest{
+			Status: constants.TransactionStatusCompleted,
+		}
+		reqBody, _ := json.Marshal(req)
+
+		completedTransaction := transactionDataFromDB
+		completedTransaction.Id = transactionId
+		completedTransaction.Status = constants.TransactionStatusCompleted
+
+		// Set up mock expectations
+		transactionRepoMock.Mock.On("UpdateStatus", mock.Anything, transactionId, constants.TransactionStatusCompleted, "").Return(completedTransaction, nil).Once()
+
+		ristrettoTransactiontMock.On("Del", mock.AnythingOfType("string")).Twice()
+		ristrettoTransactiontMock.On("Del", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string")).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPut, constants.EndpointV1+"/transactions/"+transactionId+"/status", bytes.NewReader(reqBody))
+		r.Header.Set("Content-Type", "application/json")
+
+		// Serve request
+		sTransaction.ServeHTTP(w, r)
+		body := w.Body.String()
+
+		// invalidasi cache berjalan di goroutine, beri waktu sebelum ekspektasi mock diperiksa
+		time.Sleep(50 * time.Millisecond)
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, w.Result().Header.Get("Content-Type"), "application/json")
+		assert.Contains(t, body, "transaction status updated successfully")
+		assert.Contains(t, body, constants.TransactionStatusCompleted)
+	})
+
+	t.Run("Failure - Invalid Status", func(t *testing.T) {
+		req := requests.TransactionStatusUpdateRequest{
+			Status: constants.TransactionStatusPending,
+		}
+		reqBody, _ := json.Marshal(req)
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPut, constants.EndpointV1+"/transactions/"+transactionId+"/status", bytes.NewReader(reqBody))
+		r.Header.Set("Content-Type", "application/json")
+
+		// Serve request
+		sTransaction.ServeHTTP(w, r)
+		body := w.Body.String()
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+		assert.Contains(t, body, "Field validation for 'Status' failed on the 'oneof'")
+	})
+
+	t.Run("Failure - Non UUID Transaction Id", func(t *testing.T) {
+		req := requests.TransactionStatusUpdateRequest{
+			Status: constants.TransactionStatusCancelled,
+		}
+		reqBody, _ := json.Marshal(req)
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPut, constants.EndpointV1+"/transactions/not-a-uuid/status", bytes.NewReader(reqBody))
+		r.Header.Set("Content-Type", "application/json")
+
+		// Serve request
+		sTransaction.ServeHTTP(w, r)
+		body := w.Body.String()
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+		assert.Contains(t, body, "transaction not found")
+	})
+
+	t.Run("Failure - Invalid Status Transition", func(t *testing.T) {
+		req := requests.TransactionStatusUpdateRequest{
+			Status: constants.TransactionStatusCancelled,
+		}
+		reqBody, _ := json.Marshal(req)
+
+		transactionRepoMock.Mock.On("UpdateStatus", mock.Anything, transactionId, constants.TransactionStatusCancelled, "").Return(V1Domains.TransactionDomain{}, PostgresRepo.ErrInvalidStatusTransition).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPut, constants.EndpointV1+"/transactions/"+transactionId+"/status", bytes.NewReader(reqBody))
+		r.Header.Set("Content-Type", "application/json")
+
+		// Serve request
+		sTransaction.ServeHTTP(w, r)
+		body := w.Body.String()
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusConflict, w.Result().StatusCode)
+		assert.Contains(t, body, "transaction status cannot be changed")
+	})
+}
+
+func TestRefund(t *testing.T) {
+	setupTransaction(t)
+
+	// Define the route for testing
+	sTransaction.POST(constants.EndpointV1+"/transactions/:id/refund", transactionHandler.Refund)
+
+	transactionId := "5b0b5a8e-2f0c-4d8a-9a53-0c2f1c6f3f11"
+
+	t.Run("Success - Full Refund Without Body", func(t *testing.T) {
+		refundTransaction := transactionDataFromDB
+		refundTransaction.TransactionType = constants.TransactionTypeRefund
+		refundTransaction.RelatedTransactionId = &transactionId
+
+		// Set up mock expectations
+		transactionRepoMock.Mock.On("Refund", mock.Anything, transactionId, 0).Return(refundTransaction, nil).Once()
+
+		ristrettoTransactiontMock.On("Del", mock.AnythingOfType("string")).Twice()
+		ristrettoTransactiontMock.On("Del", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string")).Once()
+		ristrettoTransactiontMock.On("Del", mock.AnythingOfType("string"), mock.AnythingOfType("string")).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, constants.EndpointV1+"/transactions/"+transactionId+"/refund", nil)
+		r.Header.Set("Content-Type", "application/json")
+
+		// Serve request
+		sTransaction.ServeHTTP(w, r)
+		body := w.Body.String()
+
+		// invalidasi cache berjalan di goroutine, beri waktu sebelum ekspektasi mock diperiksa
+		time.Sleep(50 * time.Millisecond)
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusCreated, w.Result().StatusCode)
+		assert.Contains(t, w.Result().Header.Get("Content-Type"), "application/json")
+		assert.Contains(t, body, "refund completed successfully")
+		assert.Contains(t, body, constants.TransactionTypeRefund)
+	})
+
+	t.Run("Failure - Invalid Quantity", func(t *testing.T) {
+		req := requests.TransactionRefundRequest{
+			Quantity: -1,
+		}
+		reqBody, _ := json.Marshal(req)
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, constants.EndpointV1+"/transactions/"+transactionId+"/refund", bytes.NewReader(reqBody))
+		r.Header.Set("Content-Type", "application/json")
+
+		// Serve request
+		sTransaction.ServeHTTP(w, r)
+		body := w.Body.String()
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+		assert.Contains(t, body, "Field validation for 'Quantity' failed on the 'gt'")
+	})
+
+	t.Run("Failure - Refund Quantity Exceeded", func(t *testing.T) {
+		req := requests.TransactionRefundRequest{
+			Quantity: 999,
+		}
+		reqBody, _ := json.Marshal(req)
+
+		transactionRepoMock.Mock.On("Refund", mock.Anything, transactionId, 999).Return(V1Domains.TransactionDomain{}, PostgresRepo.ErrRefundQuantityExceeded).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, constants.EndpointV1+"/transactions/"+transactionId+"/refund", bytes.NewReader(reqBody))
+		r.Header.Set("Content-Type", "application/json")
+
+		// Serve request
+		sTransaction.ServeHTTP(w, r)
+		body := w.Body.String()
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Result().StatusCode)
+		assert.Contains(t, body, "refund quantity exceeds the remaining purchased quantity")
+	})
+
+	t.Run("Failure - Already Refunded", func(t *testing.T) {
+		transactionRepoMock.Mock.On("Refund", mock.Anything, transactionId, 0).Return(V1Domains.TransactionDomain{}, PostgresRepo.ErrTransactionAlreadyRefunded).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, constants.EndpointV1+"/transactions/"+transactionId+"/refund", nil)
+		r.Header.Set("Content-Type", "application/json")
+
+		// Serve request
+		sTransaction.ServeHTTP(w, r)
+		body := w.Body.String()
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusConflict, w.Result().StatusCode)
+		assert.Contains(t, body, "transaction has already been fully refunded")
+	})
+}
+
+func TestHistory(t *testing.T) {
+	setupTransaction(t)
+
+	sTransaction.Use(lazyAuthCommonTransaction)
+
+	// Define the route for testing
+	sTransaction.GET(constants.EndpointV1+"/transactions/history", transactionHandler.History)
+
+	t.Run("Success - Filtered Page With Next Cursor", func(t *testing.T) {
+		nextCursor := V1Domains.TransactionCursor{CreatedAt: transactionDataFromDB.CreatedAt, Id: transactionDataFromDB.Id}
+		pageFromDB := V1Domains.TransactionPageDomain{Transactions: transactionsDataFromDB[:1], NextCursor: &nextCursor}
+
+		expectedFilter := mock.MatchedBy(func(filter V1Domains.TransactionFilterDomain) bool {
+			return filter.TransactionType == constants.TransactionTypeDeposit &&
+				*filter.MinAmount == money.MustParse("10.50") &&
+				filter.Limit == 1 &&
+				filter.Sort == constants.TransactionSortDesc
+		})
+
+		// Set up mock expectations, halaman dengan filter tidak menyentuh cache
+		transactionRepoMock.Mock.On("GetByUserId", mock.Anything, transactionDataFromDB.Wallet.User.ID, expectedFilter).Return(pageFromDB, nil).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, constants.EndpointV1+"/transactions/history?type=deposit&min_amount=10.50&limit=1", nil)
+
+		// Serve request
+		sTransaction.ServeHTTP(w, r)
+		body := w.Body.String()
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, "transaction history fetched successfully")
+		assert.Contains(t, body, `"next_cursor":"`+nextCursor.Encode()+`"`)
+	})
+
+	t.Run("Success - Search By External Reference", func(t *testing.T) {
+		pageFromDB := V1Domains.TransactionPageDomain{Transactions: transactionsDataFromDB[:1]}
+
+		expectedFilter := mock.MatchedBy(func(filter V1Domains.TransactionFilterDomain) bool {
+			return filter.Reference == "INV-2024-001"
+		})
+
+		// Set up mock expectations, halaman dengan filter tidak menyentuh cache
+		transactionRepoMock.Mock.On("GetByUserId", mock.Anything, transactionDataFromDB.Wallet.User.ID, expectedFilter).Return(pageFromDB, nil).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, constants.EndpointV1+"/transactions/history?reference=INV-2024-001", nil)
+
+		// Serve request
+		sTransaction.ServeHTTP(w, r)
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	})
+
+	t.Run("Success - Unfiltered First Page Is Cached", func(t *testing.T) {
+		pageFromDB := V1Domains.TransactionPageDomain{Transactions: transactionsDataFromDB[:1]}
+		cacheKey := "transaction_history/user_id:" + transactionDataFromDB.Wallet.User.ID
+
+		// Set up mock expectations
+		ristrettoTransactiontMock.On("Get", cacheKey).Return(nil).Once()
+		transactionRepoMock.Mock.On("GetByUserId", mock.Anything, transactionDataFromDB.Wallet.User.ID, mock.Anything).Return(pageFromDB, nil).Once()
+		ristrettoTransactiontMock.On("Set", cacheKey, mock.AnythingOfType("responses.TransactionPageResponse")).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, constants.EndpointV1+"/transactions/history", nil)
+
+		// Serve request
+		sTransaction.ServeHTTP(w, r)
+
+		// penyimpanan cache berjalan di goroutine, beri waktu sebelum ekspektasi mock diperiksa
+		time.Sleep(50 * time.Millisecond)
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	})
+
+	t.Run("Success - Unfiltered First Page From Cache", func(t *testing.T) {
+		cachedPage := responses.FromTransactionPageDomainV1(V1Domains.TransactionPageDomain{Transactions: transactionsDataFromDB[:1]})
+		cacheKey := "transaction_history/user_id:" + transactionDataFromDB.Wallet.User.ID
+
+		// Set up mock expectations
+		ristrettoTransactiontMock.On("Get", cacheKey).Return(cachedPage).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, constants.EndpointV1+"/transactions/history", nil)
+
+		// Serve request
+		sTransaction.ServeHTTP(w, r)
+		body := w.Body.String()
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, transactionsDataFromDB[0].Id)
+	})
+
+	t.Run("Failure - Invalid Cursor", func(t *testing.T) {
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, constants.EndpointV1+"/transactions/history?cursor=not-a-cursor", nil)
+
+		// Serve request
+		sTransaction.ServeHTTP(w, r)
+		body := w.Body.String()
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+		assert.Contains(t, body, "cursor is invalid")
+	})
+
+	t.Run("Failure - Invalid Transaction Type", func(t *testing.T) {
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, constants.EndpointV1+"/transactions/history?type=gift", nil)
+
+		// Serve request
+		sTransaction.ServeHTTP(w, r)
+		body := w.Body.String()
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+		assert.Contains(t, body, "Field validation for 'TransactionType' failed on the 'oneof'")
+	})
+}
+
+func TestGetById(t *testing.T) {
+	setupTransaction(t)
+
+	sTransaction.Use(lazyAuthCommonTransaction)
+
+	// Define the route for testing
+	sTransaction.GET(constants.EndpointV1+"/transactions/:id", transactionHandler.GetById)
+
+	transactionId := "5b0b5a8e-2f0c-4d8a-9a53-0c2f1c6f3f11"
+
+	t.Run("Success - Transaction With Product Snapshot", func(t *testing.T) {
+		purchaseTransaction := transactionDataFromDB
+		purchaseTransaction.Id = transactionId
+		purchaseTransaction.Product = V1Domains.ProductDomain{Id: *transactionDataFromDB.ProductId, Name: "Keyboard", Price: money.MustParse("20.90")}
+
+		// Set up mock expectations
+		transactionRepoMock.Mock.On("GetById", mock.Anything, transactionId, transactionDataFromDB.Wallet.User.ID).Return(purchaseTransaction, nil).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, constants.EndpointV1+"/transactions/"+transactionId, nil)
+
+		// Serve request
+		sTransaction.ServeHTTP(w, r)
+		body := w.Body.String()
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, "transaction fetched successfully")
+		assert.Contains(t, body, `"name":"Keyboard"`)
+		assert.Contains(t, body, `"unit_price":"20.90"`)
+		assert.Contains(t, body, `"wallet_id":"`+transactionDataFromDB.Wallet.Id+`"`)
+	})
+
+	t.Run("Failure - Other User Transaction", func(t *testing.T) {
+		transactionRepoMock.Mock.On("GetById", mock.Anything, transactionId, transactionDataFromDB.Wallet.User.ID).Return(V1Domains.TransactionDomain{}, PostgresRepo.ErrTransactionNotFound).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, constants.EndpointV1+"/transactions/"+transactionId, nil)
+
+		// Serve request
+		sTransaction.ServeHTTP(w, r)
+		body := w.Body.String()
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+		assert.Contains(t, body, "transaction not found")
+	})
+
+	t.Run("Failure - Non UUID Transaction Id", func(t *testing.T) {
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, constants.EndpointV1+"/transactions/12345", nil)
+
+		// Serve request
+		sTransaction.ServeHTTP(w, r)
+		body := w.Body.String()
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+		assert.Contains(t, body, "transaction not found")
+	})
+}
+
+func TestStatement(t *testing.T) {
+	setupTransaction(t)
+
+	sTransaction.Use(lazyAuthCommonTransaction)
+
+	// Define the route for testing
+	sTransaction.GET(constants.EndpointV1+"/transactions/statement", transactionHandler.Statement)
+
+	t.Run("Success - CSV Statement", func(t *testing.T) {
+		streamStatement := func(ctx context.Context, statementDom V1Domains.StatementDomain, onOpening func(V1Domains.StatementDomain) error, onEntry func(V1Domains.StatementEntryDomain) error) error {
+			statementDom.WalletId = transactionDataFromDB.WalletId
+			statementDom.OpeningBalance = money.FromMajor(10)
+			if err := onOpening(statementDom); err != nil {
+				return err
+			}
+			return onEntry(V1Domains.StatementEntryDomain{
+				EntryId:         1,
+				TransactionId:   transactionDataFromDB.Id,
+				TransactionType: constants.TransactionTypeDeposit,
+				Status:          constants.TransactionStatusCompleted,
+				Direction:       constants.LedgerDirectionCredit,
+				Amount:          money.MustParse("5.50"),
+				PostedAt:        statementDom.From,
+			})
+		}
+
+		// Set up mock expectations
+		transactionRepoMock.Mock.On("StreamStatement", mock.Anything, mock.AnythingOfType("v1.StatementDomain"), mock.Anything, mock.Anything).Return(streamStatement).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, constants.EndpointV1+"/transactions/statement?from=2024-01-01&to=2024-01-31&format=csv", nil)
+
+		// Serve request
+		sTransaction.ServeHTTP(w, r)
+		body := w.Body.String()
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, w.Result().Header.Get("Content-Type"), "text/csv")
+		assert.Equal(t, `attachment; filename="statement_2024-01-01_2024-01-31.csv"`, w.Result().Header.Get("Content-Disposition"))
+		assert.Contains(t, body, "Opening balance,,,10.00")
+		assert.Contains(t, body, transactionDataFromDB.Id+",deposit,completed,deposit,,5.50,15.50")
+		assert.Contains(t, body, "Closing balance,,,15.50")
+	})
+
+	t.Run("Success - JSON Statement", func(t *testing.T) {
+		streamStatement := func(ctx context.Context, statementDom V1Domains.StatementDomain, onOpening func(V1Domains.StatementDomain) error, onEntry func(V1Domains.StatementEntryDomain) error) error {
+			statementDom.OpeningBalance = money.FromMajor(10)
+			return onOpening(statementDom)
+		}
+
+		// Set up mock expectations
+		transactionRepoMock.Mock.On("StreamStatement", mock.Anything, mock.AnythingOfType("v1.StatementDomain"), mock.Anything, mock.Anything).Return(streamStatement).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, constants.EndpointV1+"/transactions/statement?from=2024-01-01&to=2024-01-31&format=json", nil)
+
+		// Serve request
+		sTransaction.ServeHTTP(w, r)
+
+		var statement map[string]interface{}
+		err := json.Unmarshal(w.Body.Bytes(), &statement)
+
+		// Assert the HTTP response
+		assert.Nil(t, err, "Statement should be valid JSON")
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Equal(t, "10.00", statement["opening_balance"])
+		assert.Equal(t, "10.00", statement["closing_balance"])
+		assert.Equal(t, []interface{}{}, statement["entries"])
+	})
+
+	t.Run("Failure - Missing Period", func(t *testing.T) {
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, constants.EndpointV1+"/transactions/statement?format=ofx", nil)
+
+		// Serve request
+		sTransaction.ServeHTTP(w, r)
+		body := w.Body.String()
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+		assert.Contains(t, body, "Field validation for 'From' failed on the 'required'")
+	})
+}
@@ -1,11 +1,14 @@
 package v1
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/internal/config"
 	"github.com/snykk/transaction-api/internal/constants"
 	"github.com/snykk/transaction-api/internal/datasources/caches"
 	"github.com/snykk/transaction-api/internal/http/datatransfers/requests"
@@ -26,32 +29,45 @@ func NewTransactionHandler(transactionUsecase V1Domains.TransactionUsecase, rist
 }
 
 func (c *TransactionHandler) GetAll(ctx *gin.Context) {
-	if val := c.ristrettoCache.Get("transactions"); val != nil {
-		NewSuccessResponse(ctx, http.StatusOK, "transaction data fetched successfully", map[string]interface{}{
-			"transactions": val,
-		})
+	var listQuery requests.TransactionListQueryRequest
+	if err := ctx.ShouldBindQuery(&listQuery); err != nil {
+		NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
+	filter, err := listQuery.ToDomain()
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	cacheable := isCacheableTransactionPage(ctx)
+	if cacheable {
+		if page, ok := c.ristrettoCache.Get("transactions").(responses.TransactionPageResponse); ok {
+			NewSuccessResponse(ctx, http.StatusOK, "transaction data fetched successfully", page)
+			return
+		}
+	}
+
 	ctxx := ctx.Request.Context()
-	listOfTransactinsDom, statusCode, err := c.transactionUsecase.GetAll(ctxx)
+	transactionPageDom, statusCode, err := c.transactionUsecase.GetAll(ctxx, *filter)
 	if err != nil {
 		NewErrorResponse(ctx, statusCode, err.Error())
 		return
 	}
 
-	transactionResponse := responses.ToTransactionResponseList(listOfTransactinsDom)
+	transactionPageResponse := responses.FromTransactionPageDomainV1(transactionPageDom)
 
-	if transactionResponse == nil {
-		NewSuccessResponse(ctx, statusCode, "transaction data is empty", []int{})
-		return
+	if cacheable {
+		go c.ristrettoCache.Set("transactions", transactionPageResponse)
 	}
 
-	go c.ristrettoCache.Set("transactions", transactionResponse)
+	if len(transactionPageResponse.Transactions) == 0 {
+		NewSuccessResponse(ctx, statusCode, "transaction data is empty", transactionPageResponse)
+		return
+	}
 
-	NewSuccessResponse(ctx, statusCode, "transaction data fetched successfullssy", map[string]interface{}{
-		"transactions": transactionResponse,
-	})
+	NewSuccessResponse(ctx, statusCode, "transaction data fetched successfully", transactionPageResponse)
 }
 
 func (c *TransactionHandler) Deposit(ctx *gin.Context) {
@@ -112,8 +128,8 @@ func (c *TransactionHandler) Withdraw(ctx *gin.Context) {
 	go c.ristrettoCache.Del("wallets", fmt.Sprintf("wallet/wallet_id:%s", transactionDom.WalletId), fmt.Sprintf("wallet/user_id:%s", userClaims.UserID))
 	go c.ristrettoCache.Del(fmt.Sprintf("transaction_history/user_id:%s", userClaims.UserID))
 
-	// Kirim respons sukses
-	NewSuccessResponse(ctx, statusCode, "withdraw completed successfully", map[string]interface{}{
+	// Kirim respons sukses, withdraw masih menunggu penyelesaian
+	NewSuccessResponse(ctx, statusCode, "withdraw request submitted and is pending", map[string]interface{}{
 		"transaction": responses.FromTransactionDomainV1(transactionDom),
 	})
 }
@@ -155,33 +171,252 @@ func (c *TransactionHandler) Purchase(ctx *gin.Context) {
 	})
 }
 
+func (c *TransactionHandler) Transfer(ctx *gin.Context) {
+	var transferRequest requests.TransactionTransferRequest
+
+	// get authenticated user from context
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(jwt.JwtCustomClaim)
+
+	if err := ctx.ShouldBindJSON(&transferRequest); err != nil {
+		NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	trDom := transferRequest.ToDomain()
+	trDom.SenderUserId = userClaims.UserID
+
+	ctxx := ctx.Request.Context()
+	transferDom, statusCode, err := c.transactionUsecase.Transfer(ctxx, trDom)
+	if err != nil {
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	// invalidate cache milik pengirim dan penerima
+	go c.ristrettoCache.Del("transactions")
+	go c.ristrettoCache.Del("wallets",
+		fmt.Sprintf("wallet/wallet_id:%s", transferDom.Outgoing.WalletId), fmt.Sprintf("wallet/user_id:%s", userClaims.UserID),
+		fmt.Sprintf("wallet/wallet_id:%s", transferDom.Incoming.WalletId), fmt.Sprintf("wallet/user_id:%s", transferDom.RecipientUserId),
+	)
+	go c.ristrettoCache.Del(fmt.Sprintf("transaction_history/user_id:%s", userClaims.UserID), fmt.Sprintf("transaction_history/user_id:%s", transferDom.RecipientUserId))
+
+	NewSuccessResponse(ctx, statusCode, "transfer completed successfully", map[string]interface{}{
+		"transfer": responses.FromTransferDomainV1(transferDom),
+	})
+}
+
+func (c *TransactionHandler) UpdateStatus(ctx *gin.Context) {
+	var uriRequest requests.TransactionUriRequest
+	var statusRequest requests.TransactionStatusUpdateRequest
+
+	// id yang bukan uuid tidak mungkin ada di database
+	if err := ctx.ShouldBindUri(&uriRequest); err != nil {
+		NewErrorResponse(ctx, http.StatusNotFound, "transaction not found")
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&statusRequest); err != nil {
+		NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	transactionDom, statusCode, err := c.transactionUsecase.UpdateStatus(ctxx, uriRequest.TransactionId, statusRequest.Status, statusRequest.FailureReason)
+	if err != nil {
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	go c.ristrettoCache.Del("transactions")
+	go c.ristrettoCache.Del("wallets", fmt.Sprintf("wallet/wallet_id:%s", transactionDom.WalletId), fmt.Sprintf("wallet/user_id:%s", transactionDom.Wallet.UserId))
+	go c.ristrettoCache.Del(fmt.Sprintf("transaction_history/user_id:%s", transactionDom.Wallet.UserId))
+
+	NewSuccessResponse(ctx, statusCode, "transaction status updated successfully", map[string]interface{}{
+		"transaction": responses.FromTransactionDomainV1(transactionDom),
+	})
+}
+
+func (c *TransactionHandler) Refund(ctx *gin.Context) {
+	var uriRequest requests.TransactionUriRequest
+	var refundRequest requests.TransactionRefundRequest
+
+	// id yang bukan uuid tidak mungkin ada di database
+	if err := ctx.ShouldBindUri(&uriRequest); err != nil {
+		NewErrorResponse(ctx, http.StatusNotFound, "transaction not found")
+		return
+	}
+
+	// body boleh kosong untuk refund penuh
+	if err := ctx.ShouldBindJSON(&refundRequest); err != nil && !errors.Is(err, io.EOF) {
+		NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	transactionDom, statusCode, err := c.transactionUsecase.Refund(ctxx, uriRequest.TransactionId, refundRequest.Quantity)
+	if err != nil {
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	go c.ristrettoCache.Del("transactions")
+	go c.ristrettoCache.Del("wallets", fmt.Sprintf("wallet/wallet_id:%s", transactionDom.WalletId), fmt.Sprintf("wallet/user_id:%s", transactionDom.Wallet.UserId))
+	go c.ristrettoCache.Del("products", fmt.Sprintf("product/product_id:%d", *transactionDom.ProductId))
+	go c.ristrettoCache.Del(fmt.Sprintf("transaction_history/user_id:%s", transactionDom.Wallet.UserId))
+
+	NewSuccessResponse(ctx, statusCode, "refund completed successfully", map[string]interface{}{
+		"transaction": responses.FromTransactionDomainV1(transactionDom),
+	})
+}
+
 func (c *TransactionHandler) History(ctx *gin.Context) {
 	// get authenticated user from context
 	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(jwt.JwtCustomClaim)
 
-	if val := c.ristrettoCache.Get(fmt.Sprintf("transaction_history/user_id:%s", userClaims.UserID)); val != nil {
-		NewSuccessResponse(ctx, http.StatusOK, "transaction data fetched successfully", map[string]interface{}{
-			"transactions": val,
-		})
+	var listQuery requests.TransactionListQueryRequest
+	if err := ctx.ShouldBindQuery(&listQuery); err != nil {
+		NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
+	filter, err := listQuery.ToDomain()
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	filter.UserId = userClaims.UserID
+
+	cacheKey := fmt.Sprintf("transaction_history/user_id:%s", userClaims.UserID)
+	cacheable := isCacheableTransactionPage(ctx)
+	if cacheable {
+		if page, ok := c.ristrettoCache.Get(cacheKey).(responses.TransactionPageResponse); ok {
+			NewSuccessResponse(ctx, http.StatusOK, "transaction history fetched successfully", page)
+			return
+		}
+	}
+
 	ctxx := ctx.Request.Context()
-	transactionDom, statusCode, err := c.transactionUsecase.History(ctxx, userClaims.UserID)
+	transactionPageDom, statusCode, err := c.transactionUsecase.History(ctxx, *filter)
 	if err != nil {
 		NewErrorResponse(ctx, statusCode, err.Error())
 		return
 	}
 
-	transactionHistoryResponse := responses.ToTransactionResponseList(transactionDom)
-	if transactionHistoryResponse == nil {
-		NewSuccessResponse(ctx, statusCode, "transaction history is empty", []int{})
+	transactionHistoryResponse := responses.FromTransactionPageDomainV1(transactionPageDom)
+
+	if cacheable {
+		go c.ristrettoCache.Set(cacheKey, transactionHistoryResponse)
+	}
+
+	if len(transactionHistoryResponse.Transactions) == 0 {
+		NewSuccessResponse(ctx, statusCode, "transaction history is empty", transactionHistoryResponse)
 		return
 	}
 
-	go c.ristrettoCache.Set(fmt.Sprintf("transaction_history/user_id:%s", userClaims.UserID), transactionHistoryResponse)
+	NewSuccessResponse(ctx, statusCode, "transaction history fetched successfully", transactionHistoryResponse)
+}
+
+func (c *TransactionHandler) GetById(ctx *gin.Context) {
+	// get authenticated user from context
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(jwt.JwtCustomClaim)
+
+	// id yang bukan uuid tidak mungkin ada di database
+	var uriRequest requests.TransactionUriRequest
+	if err := ctx.ShouldBindUri(&uriRequest); err != nil {
+		NewErrorResponse(ctx, http.StatusNotFound, "transaction not found")
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	transactionDom, statusCode, err := c.transactionUsecase.GetById(ctxx, uriRequest.TransactionId, userClaims.UserID, userClaims.IsAdmin)
+	if err != nil {
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
 
-	NewSuccessResponse(ctx, statusCode, "transaction history fetched successfully", map[string]interface{}{
-		"transactions": transactionHistoryResponse,
+	NewSuccessResponse(ctx, statusCode, "transaction fetched successfully", map[string]interface{}{
+		"transaction": responses.FromTransactionDomainV1(transactionDom),
 	})
 }
+
+func (c *TransactionHandler) Statement(ctx *gin.Context) {
+	// get authenticated user from context
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(jwt.JwtCustomClaim)
+
+	var statementQuery requests.TransactionStatementQueryRequest
+	if err := ctx.ShouldBindQuery(&statementQuery); err != nil {
+		NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	statementDom := statementQuery.ToDomain()
+	statementDom.UserId = userClaims.UserID
+
+	filename := fmt.Sprintf("statement_%s_%s", statementQuery.From.Format(constants.StatementDateLayout), statementQuery.To.Format(constants.StatementDateLayout))
+	writer := &statementResponseWriter{
+		ctx:      ctx,
+		encoder:  responses.NewStatementEncoder(statementQuery.Format, ctx.Writer, config.AppConfig.Currency),
+		filename: filename,
+	}
+
+	ctxx := ctx.Request.Context()
+	statusCode, err := c.transactionUsecase.Statement(ctxx, statementDom, writer)
+	if err != nil {
+		// setelah baris pertama terkirim, status dan header tidak bisa diganti lagi
+		if writer.started {
+			ctx.Error(err)
+			ctx.Abort()
+			return
+		}
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+}
+
+// statementResponseWriter menulis header download saat statement mulai dikirim,
+// lalu mem-flush response secara berkala agar client menerima baris secara bertahap
+type statementResponseWriter struct {
+	ctx      *gin.Context
+	encoder  responses.StatementEncoder
+	filename string
+	started  bool
+	entries  int
+}
+
+const statementFlushEvery = 100
+
+func (w *statementResponseWriter) Begin(statement V1Domains.StatementDomain) error {
+	w.ctx.Header("Content-Type", w.encoder.ContentType())
+	w.ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, w.filename, w.encoder.FileExtension()))
+	w.ctx.Status(http.StatusOK)
+	w.started = true
+
+	return w.encoder.Begin(statement)
+}
+
+func (w *statementResponseWriter) Entry(entry V1Domains.StatementEntryDomain) error {
+	if err := w.encoder.Entry(entry); err != nil {
+		return err
+	}
+
+	w.entries++
+	if w.entries%statementFlushEvery == 0 {
+		w.ctx.Writer.Flush()
+	}
+	return nil
+}
+
+func (w *statementResponseWriter) End(statement V1Domains.StatementDomain) error {
+	if err := w.encoder.End(statement); err != nil {
+		return err
+	}
+
+	w.ctx.Writer.Flush()
+	return nil
+}
+
+// isCacheableTransactionPage hanya meloloskan halaman pertama tanpa filter. Query string bebas diisi user,
+// sehingga menyimpan setiap variannya membuat cache tumbuh tanpa batas.
+func isCacheableTransactionPage(ctx *gin.Context) bool {
+	return ctx.Request.URL.RawQuery == ""
+}
@@ -0,0 +1,123 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/internal/http/datatransfers/requests"
+	"github.com/snykk/transaction-api/internal/http/datatransfers/responses"
+)
+
+// VoucherHandler khusus admin, tidak memakai cache karena voucher dibaca langsung saat pembelian
+type VoucherHandler struct {
+	voucherUsecase V1Domains.VoucherUsecase
+}
+
+func NewVoucherHandler(voucherUsecase V1Domains.VoucherUsecase) VoucherHandler {
+	return VoucherHandler{
+		voucherUsecase: voucherUsecase,
+	}
+}
+
+func (c *VoucherHandler) Store(ctx *gin.Context) {
+	var voucherRequest requests.VoucherRequest
+
+	if err := ctx.ShouldBindJSON(&voucherRequest); err != nil {
+		NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	voucherDom, statusCode, err := c.voucherUsecase.Store(ctxx, voucherRequest.ToDomain())
+	if err != nil {
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	NewSuccessResponse(ctx, statusCode, "voucher created successfully", map[string]interface{}{
+		"voucher": responses.FromVoucherDomainV1(voucherDom),
+	})
+}
+
+func (c *VoucherHandler) GetAll(ctx *gin.Context) {
+	ctxx := ctx.Request.Context()
+	voucherDoms, statusCode, err := c.voucherUsecase.GetAll(ctxx)
+	if err != nil {
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	voucherResponses := responses.ToVoucherResponseList(voucherDoms)
+	if voucherResponses == nil {
+		NewSuccessResponse(ctx, statusCode, "voucher data is empty", []int{})
+		return
+	}
+
+	NewSuccessResponse(ctx, statusCode, "vouchers fetched successfully", map[string]interface{}{
+		"vouchers": voucherResponses,
+	})
+}
+
+func (c *VoucherHandler) GetById(ctx *gin.Context) {
+	var uriRequest requests.VoucherUriRequest
+	if err := ctx.ShouldBindUri(&uriRequest); err != nil {
+		NewErrorResponse(ctx, http.StatusNotFound, "voucher not found")
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	voucherDom, statusCode, err := c.voucherUsecase.GetById(ctxx, uriRequest.Id)
+	if err != nil {
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	NewSuccessResponse(ctx, statusCode, "voucher fetched successfully", map[string]interface{}{
+		"voucher": responses.FromVoucherDomainV1(voucherDom),
+	})
+}
+
+func (c *VoucherHandler) Update(ctx *gin.Context) {
+	var uriRequest requests.VoucherUriRequest
+	var voucherRequest requests.VoucherRequest
+
+	if err := ctx.ShouldBindUri(&uriRequest); err != nil {
+		NewErrorResponse(ctx, http.StatusNotFound, "voucher not found")
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&voucherRequest); err != nil {
+		NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	voucherDom, statusCode, err := c.voucherUsecase.Update(ctxx, uriRequest.Id, voucherRequest.ToDomain())
+	if err != nil {
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	NewSuccessResponse(ctx, statusCode, "voucher updated successfully", map[string]interface{}{
+		"voucher": responses.FromVoucherDomainV1(voucherDom),
+	})
+}
+
+func (c *VoucherHandler) Delete(ctx *gin.Context) {
+	var uriRequest requests.VoucherUriRequest
+	if err := ctx.ShouldBindUri(&uriRequest); err != nil {
+		NewErrorResponse(ctx, http.StatusNotFound, "voucher not found")
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	statusCode, err := c.voucherUsecase.Delete(ctxx, uriRequest.Id)
+	if err != nil {
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	NewSuccessResponse(ctx, statusCode, fmt.Sprintf("voucher with id %d deleted successfully", uriRequest.Id), nil)
+}
@@ -0,0 +1,184 @@
+package v1_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	V1Usecases "github.com/snykk/transaction-api/internal/business/usecases/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+	PostgresRepo "github.com/snykk/transaction-api/internal/datasources/repositories/postgres/v1"
+	V1Handlers "github.com/snykk/transaction-api/internal/http/handlers/v1"
+	"github.com/snykk/transaction-api/internal/mocks"
+	"github.com/snykk/transaction-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	voucherRepoMock *mocks.VoucherRepository
+	voucherUsecase  V1Domains.VoucherUsecase
+	voucherHandler  V1Handlers.VoucherHandler
+	sVoucher        *gin.Engine
+)
+
+func setupVoucher(t *testing.T) {
+	// Initialize mock dependencies
+	voucherRepoMock = mocks.NewVoucherRepository(t)
+	voucherUsecase = V1Usecases.NewVoucherUsecase(voucherRepoMock)
+	voucherHandler = V1Handlers.NewVoucherHandler(voucherUsecase)
+
+	// Setup Gin engine, middleware admin diuji terpisah
+	sVoucher = gin.Default()
+	sVoucher.GET(constants.EndpointV1+"/vouchers", voucherHandler.GetAll)
+	sVoucher.POST(constants.EndpointV1+"/vouchers", voucherHandler.Store)
+	sVoucher.GET(constants.EndpointV1+"/vouchers/:id", voucherHandler.GetById)
+	sVoucher.PUT(constants.EndpointV1+"/vouchers/:id", voucherHandler.Update)
+	sVoucher.DELETE(constants.EndpointV1+"/vouchers/:id", voucherHandler.Delete)
+}
+
+func TestStoreVoucher(t *testing.T) {
+	setupVoucher(t)
+
+	t.Run("Success - Create Percentage Voucher", func(t *testing.T) {
+		reqBody := `{"code":"hemat10","discount_type":"percentage","rate_bps":1000,"max_discount":"20000.00","min_spend":"50000.00",
+			"ends_at":"2099-01-01T00:00:00Z","max_redemptions":100,"max_redemptions_per_user":1}`
+
+		rateBps := 1000
+		maxDiscount := money.FromMajor(20000)
+		endsAt := time.Date(2099, 1, 1, 0, 0, 0, 0, time.UTC)
+		maxRedemptions, maxPerUser := 100, 1
+		voucherFromDB := V1Domains.VoucherDomain{
+			Id:                    1,
+			Code:                  "HEMAT10",
+			DiscountType:          constants.VoucherDiscountPercentage,
+			RateBps:               &rateBps,
+			MaxDiscount:           &maxDiscount,
+			MinSpend:              money.FromMajor(50000),
+			StartsAt:              time.Now(),
+			EndsAt:                &endsAt,
+			MaxRedemptions:        &maxRedemptions,
+			MaxRedemptionsPerUser: &maxPerUser,
+			IsActive:              true,
+			CreatedAt:             time.Now(),
+			UpdatedAt:             time.Now(),
+		}
+
+		// Set up mock expectations
+		voucherRepoMock.Mock.On("Store", mock.Anything, mock.MatchedBy(func(v V1Domains.VoucherDomain) bool {
+			return v.Code == "HEMAT10" && v.IsActive && *v.MaxDiscount == maxDiscount && *v.MaxRedemptionsPerUser == 1
+		})).Return(voucherFromDB, nil).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, constants.EndpointV1+"/vouchers", strings.NewReader(reqBody))
+		r.Header.Set("Content-Type", "application/json")
+
+		// Serve request
+		sVoucher.ServeHTTP(w, r)
+		body := w.Body.String()
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusCreated, w.Result().StatusCode)
+		assert.Contains(t, body, "voucher created successfully")
+		assert.Contains(t, body, `"code":"HEMAT10"`)
+		assert.Contains(t, body, `"redemption_count":0`)
+	})
+
+	t.Run("Failure - Fixed Without Amount", func(t *testing.T) {
+		reqBody := `{"code":"POTONG5","discount_type":"fixed"}`
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, constants.EndpointV1+"/vouchers", strings.NewReader(reqBody))
+		r.Header.Set("Content-Type", "application/json")
+
+		// Serve request
+		sVoucher.ServeHTTP(w, r)
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), V1Usecases.ErrVoucherAmountRequired.Error())
+	})
+
+	t.Run("Failure - Code Already Taken", func(t *testing.T) {
+		reqBody := `{"code":"POTONG5","discount_type":"fixed","amount":"5000.00"}`
+
+		voucherRepoMock.Mock.On("Store", mock.Anything, mock.Anything).Return(V1Domains.VoucherDomain{}, PostgresRepo.ErrVoucherCodeTaken).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, constants.EndpointV1+"/vouchers", strings.NewReader(reqBody))
+		r.Header.Set("Content-Type", "application/json")
+
+		// Serve request
+		sVoucher.ServeHTTP(w, r)
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusConflict, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), PostgresRepo.ErrVoucherCodeTaken.Error())
+	})
+}
+
+func TestGetVoucher(t *testing.T) {
+	setupVoucher(t)
+
+	t.Run("Success - Get All Vouchers", func(t *testing.T) {
+		amount := money.FromMajor(5000)
+		voucherRepoMock.Mock.On("GetAll", mock.Anything).Return([]V1Domains.VoucherDomain{
+			{Id: 1, Code: "POTONG5", DiscountType: constants.VoucherDiscountFixed, Amount: &amount, RedemptionCount: 3, IsActive: true},
+		}, nil).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, constants.EndpointV1+"/vouchers", nil)
+
+		// Serve request
+		sVoucher.ServeHTTP(w, r)
+		body := w.Body.String()
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, "vouchers fetched successfully")
+		assert.Contains(t, body, `"amount":"5000.00"`)
+		assert.Contains(t, body, `"redemption_count":3`)
+	})
+
+	t.Run("Failure - Voucher Not Found", func(t *testing.T) {
+		voucherRepoMock.Mock.On("GetById", mock.Anything, 99).Return(V1Domains.VoucherDomain{}, PostgresRepo.ErrVoucherNotFound).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, constants.EndpointV1+"/vouchers/99", nil)
+
+		// Serve request
+		sVoucher.ServeHTTP(w, r)
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), "voucher not found")
+	})
+}
+
+func TestDeleteVoucher(t *testing.T) {
+	setupVoucher(t)
+
+	t.Run("Success - Delete Voucher", func(t *testing.T) {
+		voucherRepoMock.Mock.On("Delete", mock.Anything, 1).Return(nil).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodDelete, constants.EndpointV1+"/vouchers/1", nil)
+
+		// Serve request
+		sVoucher.ServeHTTP(w, r)
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), "voucher with id 1 deleted successfully")
+	})
+}
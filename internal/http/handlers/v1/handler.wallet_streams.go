@@ -0,0 +1,228 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+	"github.com/snykk/transaction-api/internal/datasources/streams"
+	"github.com/snykk/transaction-api/internal/http/datatransfers/responses"
+	"github.com/snykk/transaction-api/pkg/jwt"
+	"github.com/snykk/transaction-api/pkg/sse"
+	"github.com/snykk/transaction-api/pkg/websocket"
+)
+
+type WalletStreamHandler struct {
+	walletUsecase V1Domains.WalletUsecase
+	walletStream  streams.WalletStream
+	heartbeat     time.Duration
+}
+
+func NewWalletStreamHandler(walletUsecase V1Domains.WalletUsecase, walletStream streams.WalletStream, heartbeat time.Duration) WalletStreamHandler {
+	return WalletStreamHandler{
+		walletUsecase: walletUsecase,
+		walletStream:  walletStream,
+		heartbeat:     heartbeat,
+	}
+}
+
+// Stream mengirim saldo dan transaksi baru milik user sebagai Server-Sent Events
+func (c *WalletStreamHandler) Stream(ctx *gin.Context) {
+	// get authenticated user from context
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(jwt.JwtCustomClaim)
+
+	ctxx := ctx.Request.Context()
+	events, balance, ok := c.subscribe(ctx, ctxx, userClaims.UserID)
+	if !ok {
+		return
+	}
+
+	writer, err := sse.NewWriter(ctx.Writer, ctx.Request, constants.WalletStreamWriteTimeout)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.serve(ctxx, userClaims.UserID, events, balance, &sseStreamWriter{writer: writer})
+}
+
+// StreamWebSocket mengirim event yang sama dengan Stream lewat websocket
+func (c *WalletStreamHandler) StreamWebSocket(ctx *gin.Context) {
+	// get authenticated user from context
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(jwt.JwtCustomClaim)
+
+	if !websocket.IsUpgrade(ctx.Request) {
+		NewErrorResponse(ctx, http.StatusBadRequest, websocket.ErrNotWebSocket.Error())
+		return
+	}
+
+	// context request tidak lagi mengikuti koneksi setelah di-hijack,
+	// stream dihentikan saat pembacaan dari client gagal
+	ctxx, cancel := context.WithCancel(ctx.Request.Context())
+	defer cancel()
+
+	events, balance, ok := c.subscribe(ctx, ctxx, userClaims.UserID)
+	if !ok {
+		return
+	}
+
+	conn, err := websocket.Upgrade(ctx.Writer, ctx.Request)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	defer conn.Close(websocket.CloseGoingAway, "")
+
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	c.serve(ctxx, userClaims.UserID, events, balance, &webSocketStreamWriter{conn: conn})
+}
+
+// subscribe mendaftarkan koneksi sebelum mengambil saldo awal agar tidak ada transaksi yang terlewat.
+// Response error sudah ditulis jika ok bernilai false.
+func (c *WalletStreamHandler) subscribe(ctx *gin.Context, ctxx context.Context, userId string) (events <-chan streams.WalletEvent, balance []byte, ok bool) {
+	lastEventId := ctx.GetHeader(constants.HeaderLastEventId)
+	if lastEventId == "" {
+		lastEventId = ctx.Query(constants.QueryLastEventId)
+	}
+
+	events, err := c.walletStream.Subscribe(ctxx, userId, lastEventId)
+	if err != nil {
+		switch {
+		case errors.Is(err, streams.ErrInvalidLastEventId):
+			NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		case errors.Is(err, streams.ErrWalletStreamClosed):
+			NewErrorResponse(ctx, http.StatusServiceUnavailable, err.Error())
+		default:
+			NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		}
+		return nil, nil, false
+	}
+
+	balance, statusCode, err := c.balance(ctxx, userId)
+	if err != nil {
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return nil, nil, false
+	}
+
+	return events, balance, true
+}
+
+// serve menulis saldo awal, lalu setiap transaksi diikuti saldo terbaru sampai koneksi selesai.
+// Event saldo tidak memiliki id sehingga Last-Event-ID selalu menunjuk transaksi terakhir.
+func (c *WalletStreamHandler) serve(ctx context.Context, userId string, events <-chan streams.WalletEvent, balance []byte, writer walletStreamWriter) {
+	if err := writer.Event("", constants.WalletStreamEventBalance, balance); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(c.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-events:
+			// channel ditutup jika server berhenti atau client tertinggal, client reconnect dengan Last-Event-ID
+			if !ok {
+				return
+			}
+			if err := writer.Event(event.Id, event.Type, event.Data); err != nil {
+				return
+			}
+
+			// saldo cukup dikirim sekali setelah antrean event habis, misal saat replay
+			if len(events) > 0 {
+				continue
+			}
+
+			balance, _, err := c.balance(ctx, userId)
+			if err != nil {
+				return
+			}
+			if err := writer.Event("", constants.WalletStreamEventBalance, balance); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if err := writer.Heartbeat(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (c *WalletStreamHandler) balance(ctx context.Context, userId string) ([]byte, int, error) {
+	walletDoms, statusCode, err := c.walletUsecase.GetWalletsByUserId(ctx, userId)
+	if err != nil {
+		return nil, statusCode, err
+	}
+
+	walletResponseList := responses.ToWalletResponseList(walletDoms)
+	if walletResponseList == nil {
+		walletResponseList = []responses.WalletResponse{}
+	}
+
+	balance, err := json.Marshal(map[string]interface{}{
+		"wallets": walletResponseList,
+	})
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return balance, statusCode, nil
+}
+
+// walletStreamWriter menyamakan cara menulis event ke SSE dan websocket
+type walletStreamWriter interface {
+	Event(id string, event string, data []byte) error
+	Heartbeat() error
+}
+
+type sseStreamWriter struct {
+	writer *sse.Writer
+}
+
+func (w *sseStreamWriter) Event(id string, event string, data []byte) error {
+	return w.writer.Event(id, event, data)
+}
+
+func (w *sseStreamWriter) Heartbeat() error {
+	return w.writer.Comment("heartbeat")
+}
+
+// webSocketStreamMessage membungkus event menjadi satu pesan text,
+// id dikirim kembali sebagai query last_event_id saat reconnect
+type webSocketStreamMessage struct {
+	Id    string          `json:"id,omitempty"`
+	Event string          `json:"event"`
+	Data  json.RawMessage `json:"data"`
+}
+
+type webSocketStreamWriter struct {
+	conn *websocket.Conn
+}
+
+func (w *webSocketStreamWriter) Event(id string, event string, data []byte) error {
+	message, err := json.Marshal(webSocketStreamMessage{Id: id, Event: event, Data: data})
+	if err != nil {
+		return err
+	}
+
+	return w.conn.WriteText(message, constants.WalletStreamWriteTimeout)
+}
+
+func (w *webSocketStreamWriter) Heartbeat() error {
+	return w.conn.Ping(constants.WalletStreamWriteTimeout)
+}
@@ -0,0 +1,302 @@
+package v1_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	dgriJWT "github.com/dgrijalva/jwt-go"
+	"github.com/gin-gonic/gin"
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	V1Usecases "github.com/snykk/transaction-api/internal/business/usecases/v1"
+	"github.com/snykk/transaction-api/internal/config"
+	"github.com/snykk/transaction-api/internal/constants"
+	"github.com/snykk/transaction-api/internal/datasources/streams"
+	V1Handlers "github.com/snykk/transaction-api/internal/http/handlers/v1"
+	"github.com/snykk/transaction-api/internal/mocks"
+	"github.com/snykk/transaction-api/pkg/helpers"
+	"github.com/snykk/transaction-api/pkg/jwt"
+	"github.com/snykk/transaction-api/pkg/money"
+	"github.com/snykk/transaction-api/pkg/sse"
+	"github.com/snykk/transaction-api/pkg/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	walletStreamRepoMock     *mocks.WalletRepository
+	walletStreamMock         *mocks.WalletStream
+	walletStreamHandler      V1Handlers.WalletStreamHandler
+	sWalletStream            *gin.Engine
+	walletStreamServer       *httptest.Server
+	walletStreamWalletFromDB V1Domains.WalletDomain
+)
+
+func setupWalletStream(t *testing.T) {
+	// Initialize mock dependencies
+	walletStreamRepoMock = mocks.NewWalletRepository(t)
+	walletStreamMock = mocks.NewWalletStream(t)
+	walletStreamHandler = V1Handlers.NewWalletStreamHandler(V1Usecases.NewWalletUsecase(walletStreamRepoMock), walletStreamMock, 100*time.Millisecond)
+
+	currentTime := time.Now()
+	walletStreamWalletFromDB = V1Domains.WalletDomain{
+		Id:               "xxxx-yyyy-zzzz",
+		UserId:           "aaaa-bbbb-cccc",
+		Name:             constants.WalletDefaultName,
+		IsDefault:        true,
+		Balance:          money.FromMajor(500),
+		AvailableBalance: money.FromMajor(500),
+		CreatedAt:        currentTime,
+	}
+
+	// Setup Gin engine with middleware for authentication
+	sWalletStream = gin.Default()
+	sWalletStream.Use(lazyAuthCommonWalletStream)
+	sWalletStream.GET(constants.EndpointV1+"/wallets/stream", walletStreamHandler.Stream)
+	sWalletStream.GET(constants.EndpointV1+"/wallets/stream/ws", walletStreamHandler.StreamWebSocket)
+
+	// stream membutuhkan koneksi sungguhan agar event bisa dibaca saat handler masih berjalan
+	walletStreamServer = httptest.NewServer(sse.Handler(sWalletStream))
+	t.Cleanup(walletStreamServer.Close)
+}
+
+// Mock lazy authentication
+func lazyAuthCommonWalletStream(ctx *gin.Context) {
+	pass, _ := helpers.GenerateHash("sjdflakjsdfldks")
+	jwtClaims := jwt.JwtCustomClaim{
+		UserID:   walletStreamWalletFromDB.UserId,
+		IsAdmin:  false,
+		Email:    "patrick@gmail.com",
+		Password: pass,
+		StandardClaims: dgriJWT.StandardClaims{
+			ExpiresAt: time.Now().Add(time.Hour * time.Duration(config.AppConfig.JWTExpired)).Unix(),
+			Issuer:    "patrick",
+			IssuedAt:  time.Now().Unix(),
+		},
+	}
+	ctx.Set(constants.CtxAuthenticatedUserKey, jwtClaims)
+}
+
+func TestWalletStream(t *testing.T) {
+	setupWalletStream(t)
+
+	t.Run("Success - Balance Snapshot Then Transaction", func(t *testing.T) {
+		events := make(chan streams.WalletEvent, 1)
+		walletStreamMock.On("Subscribe", mock.Anything, walletStreamWalletFromDB.UserId, "").Return((<-chan streams.WalletEvent)(events), nil).Once()
+		walletStreamRepoMock.Mock.On("GetWalletsByUserId", mock.Anything, walletStreamWalletFromDB.UserId).Return([]V1Domains.WalletDomain{walletStreamWalletFromDB}, nil).Twice()
+
+		resp, err := http.Get(walletStreamServer.URL + constants.EndpointV1 + "/wallets/stream")
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		reader := bufio.NewReader(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+		snapshot := readServerSentEvent(t, reader)
+		assert.Equal(t, constants.WalletStreamEventBalance, snapshot["event"])
+		assert.Empty(t, snapshot["id"])
+		assert.Contains(t, snapshot["data"], `"balance":"500.00"`)
+
+		events <- streams.WalletEvent{Id: "1700000000000-0", Type: constants.WalletStreamEventTransaction, UserId: walletStreamWalletFromDB.UserId, Data: json.RawMessage(`{"id":"trx-1"}`)}
+
+		transaction := readServerSentEvent(t, reader)
+		assert.Equal(t, "1700000000000-0", transaction["id"])
+		assert.Equal(t, constants.WalletStreamEventTransaction, transaction["event"])
+		assert.Equal(t, `{"id":"trx-1"}`, transaction["data"])
+
+		// saldo terbaru dikirim setelah setiap transaksi
+		balance := readServerSentEvent(t, reader)
+		assert.Equal(t, constants.WalletStreamEventBalance, balance["event"])
+
+		close(events)
+	})
+
+	t.Run("Success - Heartbeat While Idle", func(t *testing.T) {
+		events := make(chan streams.WalletEvent)
+		walletStreamMock.On("Subscribe", mock.Anything, walletStreamWalletFromDB.UserId, "").Return((<-chan streams.WalletEvent)(events), nil).Once()
+		walletStreamRepoMock.Mock.On("GetWalletsByUserId", mock.Anything, walletStreamWalletFromDB.UserId).Return([]V1Domains.WalletDomain{walletStreamWalletFromDB}, nil).Once()
+
+		resp, err := http.Get(walletStreamServer.URL + constants.EndpointV1 + "/wallets/stream")
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		reader := bufio.NewReader(resp.Body)
+
+		readServerSentEvent(t, reader)
+		heartbeat, err := reader.ReadString('\n')
+		assert.NoError(t, err)
+		assert.Equal(t, ": heartbeat\n", heartbeat)
+
+		close(events)
+	})
+
+	t.Run("Success - Resume From Last-Event-ID Header", func(t *testing.T) {
+		events := make(chan streams.WalletEvent)
+		close(events)
+		walletStreamMock.On("Subscribe", mock.Anything, walletStreamWalletFromDB.UserId, "1700000000000-1").Return((<-chan streams.WalletEvent)(events), nil).Once()
+		walletStreamRepoMock.Mock.On("GetWalletsByUserId", mock.Anything, walletStreamWalletFromDB.UserId).Return([]V1Domains.WalletDomain{walletStreamWalletFromDB}, nil).Once()
+
+		req, _ := http.NewRequest(http.MethodGet, walletStreamServer.URL+constants.EndpointV1+"/wallets/stream", nil)
+		req.Header.Set(constants.HeaderLastEventId, "1700000000000-1")
+		resp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Contains(t, string(body), "event: balance")
+	})
+
+	t.Run("Success - Resume From Query", func(t *testing.T) {
+		events := make(chan streams.WalletEvent)
+		close(events)
+		walletStreamMock.On("Subscribe", mock.Anything, walletStreamWalletFromDB.UserId, "1700000000000-2").Return((<-chan streams.WalletEvent)(events), nil).Once()
+		walletStreamRepoMock.Mock.On("GetWalletsByUserId", mock.Anything, walletStreamWalletFromDB.UserId).Return([]V1Domains.WalletDomain{}, nil).Once()
+
+		resp, err := http.Get(walletStreamServer.URL + constants.EndpointV1 + "/wallets/stream?last_event_id=1700000000000-2")
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Contains(t, string(body), `data: {"wallets":[]}`)
+	})
+
+	t.Run("Failure - Invalid Last Event Id", func(t *testing.T) {
+		walletStreamMock.On("Subscribe", mock.Anything, walletStreamWalletFromDB.UserId, "latest").Return(nil, streams.ErrInvalidLastEventId).Once()
+
+		req, _ := http.NewRequest(http.MethodGet, walletStreamServer.URL+constants.EndpointV1+"/wallets/stream", nil)
+		req.Header.Set(constants.HeaderLastEventId, "latest")
+		resp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		assert.Contains(t, resp.Header.Get("Content-Type"), "application/json")
+		assert.Contains(t, string(body), streams.ErrInvalidLastEventId.Error())
+	})
+
+	t.Run("Failure - Stream Closed", func(t *testing.T) {
+		walletStreamMock.On("Subscribe", mock.Anything, walletStreamWalletFromDB.UserId, "").Return(nil, streams.ErrWalletStreamClosed).Once()
+
+		resp, err := http.Get(walletStreamServer.URL + constants.EndpointV1 + "/wallets/stream")
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	})
+
+	t.Run("Failure - Wallet Query Error", func(t *testing.T) {
+		events := make(chan streams.WalletEvent)
+		walletStreamMock.On("Subscribe", mock.Anything, walletStreamWalletFromDB.UserId, "").Return((<-chan streams.WalletEvent)(events), nil).Once()
+		walletStreamRepoMock.Mock.On("GetWalletsByUserId", mock.Anything, walletStreamWalletFromDB.UserId).Return(nil, errors.New("connection refused")).Once()
+
+		resp, err := http.Get(walletStreamServer.URL + constants.EndpointV1 + "/wallets/stream")
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		assert.Contains(t, resp.Header.Get("Content-Type"), "application/json")
+	})
+}
+
+func TestWalletStreamWebSocket(t *testing.T) {
+	setupWalletStream(t)
+
+	t.Run("Failure - Not A WebSocket Request", func(t *testing.T) {
+		resp, err := http.Get(walletStreamServer.URL + constants.EndpointV1 + "/wallets/stream/ws")
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		assert.Contains(t, string(body), websocket.ErrNotWebSocket.Error())
+	})
+
+	t.Run("Success - Balance Snapshot Then Transaction", func(t *testing.T) {
+		events := make(chan streams.WalletEvent, 1)
+		walletStreamMock.On("Subscribe", mock.Anything, walletStreamWalletFromDB.UserId, "1700000000000-0").Return((<-chan streams.WalletEvent)(events), nil).Once()
+		walletStreamRepoMock.Mock.On("GetWalletsByUserId", mock.Anything, walletStreamWalletFromDB.UserId).Return([]V1Domains.WalletDomain{walletStreamWalletFromDB}, nil).Twice()
+
+		conn, err := net.Dial("tcp", strings.TrimPrefix(walletStreamServer.URL, "http://"))
+		assert.NoError(t, err)
+		defer conn.Close()
+		conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+		key := "dGhlIHNhbXBsZSBub25jZQ=="
+		io.WriteString(conn, "GET "+constants.EndpointV1+"/wallets/stream/ws?last_event_id=1700000000000-0 HTTP/1.1\r\nHost: localhost\r\n"+
+			"Connection: Upgrade\r\nUpgrade: websocket\r\nSec-WebSocket-Version: 13\r\nSec-WebSocket-Key: "+key+"\r\n\r\n")
+
+		reader := bufio.NewReader(conn)
+		resp, err := http.ReadResponse(reader, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusSwitchingProtocols, resp.StatusCode)
+		assert.Equal(t, websocket.AcceptKey(key), resp.Header.Get("Sec-WebSocket-Accept"))
+
+		var snapshot map[string]interface{}
+		assert.NoError(t, json.Unmarshal(readWebSocketText(t, reader), &snapshot))
+		assert.Equal(t, constants.WalletStreamEventBalance, snapshot["event"])
+		assert.NotContains(t, snapshot, "id")
+
+		events <- streams.WalletEvent{Id: "1700000000000-1", Type: constants.WalletStreamEventTransaction, UserId: walletStreamWalletFromDB.UserId, Data: json.RawMessage(`{"id":"trx-2"}`)}
+
+		assert.JSONEq(t, `{"id":"1700000000000-1","event":"transaction","data":{"id":"trx-2"}}`, string(readWebSocketText(t, reader)))
+
+		var balance map[string]interface{}
+		assert.NoError(t, json.Unmarshal(readWebSocketText(t, reader), &balance))
+		assert.Equal(t, constants.WalletStreamEventBalance, balance["event"])
+
+		close(events)
+	})
+}
+
+// readServerSentEvent membaca satu event SSE sampai baris kosong
+func readServerSentEvent(t *testing.T, reader *bufio.Reader) map[string]string {
+	event := map[string]string{}
+	for {
+		line, err := reader.ReadString('\n')
+		if !assert.NoError(t, err) {
+			return event
+		}
+
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" {
+			return event
+		}
+
+		field, value, _ := strings.Cut(line, ": ")
+		event[field] = value
+	}
+}
+
+// readWebSocketText membaca frame text dari server, melewati frame ping heartbeat
+func readWebSocketText(t *testing.T, reader *bufio.Reader) []byte {
+	for {
+		head := make([]byte, 2)
+		if _, err := io.ReadFull(reader, head); !assert.NoError(t, err) {
+			return nil
+		}
+
+		length := int(head[1] & 0x7F)
+		if length == 126 {
+			extended := make([]byte, 2)
+			io.ReadFull(reader, extended)
+			length = int(extended[0])<<8 | int(extended[1])
+		}
+
+		payload := make([]byte, length)
+		io.ReadFull(reader, payload)
+
+		if head[0]&0x0F == websocket.OpText {
+			return payload
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
 	"github.com/snykk/transaction-api/internal/constants"
 	"github.com/snykk/transaction-api/internal/datasources/caches"
+	"github.com/snykk/transaction-api/internal/http/datatransfers/requests"
 	"github.com/snykk/transaction-api/internal/http/datatransfers/responses"
 	"github.com/snykk/transaction-api/pkg/jwt"
 )
@@ -96,3 +97,174 @@ func (c *WalletHandler) Info(ctx *gin.Context) {
 		"wallet": walletResponse,
 	})
 }
+
+// Create membuat pocket baru untuk user, pocket pertama otomatis menjadi default
+func (c *WalletHandler) Create(ctx *gin.Context) {
+	var walletRequest requests.WalletRequest
+
+	// get authenticated user from context
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(jwt.JwtCustomClaim)
+
+	if err := ctx.ShouldBindJSON(&walletRequest); err != nil {
+		NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	walletDom, statusCode, err := c.walletUsecase.Create(ctxx, userClaims.UserID, walletRequest.Name)
+	if err != nil {
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	c.invalidateWallet(walletDom)
+
+	NewSuccessResponse(ctx, statusCode, "wallet created successfully", map[string]interface{}{
+		"wallet": responses.FromWalletDomainV1(walletDom),
+	})
+}
+
+// Mine menampilkan semua pocket milik user, tidak di-cache karena saldo tiap pocket sering berubah
+func (c *WalletHandler) Mine(ctx *gin.Context) {
+	// get authenticated user from context
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(jwt.JwtCustomClaim)
+
+	ctxx := ctx.Request.Context()
+	walletDoms, statusCode, err := c.walletUsecase.GetWalletsByUserId(ctxx, userClaims.UserID)
+	if err != nil {
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	walletResponseList := responses.ToWalletResponseList(walletDoms)
+	if walletResponseList == nil {
+		NewSuccessResponse(ctx, statusCode, "wallet data is empty", []int{})
+		return
+	}
+
+	NewSuccessResponse(ctx, statusCode, "wallet data fetched successfully", map[string]interface{}{
+		"wallets": walletResponseList,
+	})
+}
+
+func (c *WalletHandler) SetDefault(ctx *gin.Context) {
+	var uriRequest requests.WalletUriRequest
+
+	// get authenticated user from context
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(jwt.JwtCustomClaim)
+
+	// id yang bukan uuid tidak mungkin ada di database
+	if err := ctx.ShouldBindUri(&uriRequest); err != nil {
+		NewErrorResponse(ctx, http.StatusNotFound, "wallet not found")
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	walletDom, statusCode, err := c.walletUsecase.SetDefault(ctxx, userClaims.UserID, uriRequest.WalletId)
+	if err != nil {
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	c.invalidateWallet(walletDom)
+
+	NewSuccessResponse(ctx, statusCode, "default wallet updated successfully", map[string]interface{}{
+		"wallet": responses.FromWalletDomainV1(walletDom),
+	})
+}
+
+func (c *WalletHandler) Freeze(ctx *gin.Context) {
+	var uriRequest requests.WalletUriRequest
+	var freezeRequest requests.WalletFreezeRequest
+
+	// get authenticated admin from context
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(jwt.JwtCustomClaim)
+
+	// id yang bukan uuid tidak mungkin ada di database
+	if err := ctx.ShouldBindUri(&uriRequest); err != nil {
+		NewErrorResponse(ctx, http.StatusNotFound, "wallet not found")
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&freezeRequest); err != nil {
+		NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	walletDom, statusCode, err := c.walletUsecase.Freeze(ctxx, uriRequest.WalletId, freezeRequest.Direction, freezeRequest.Reason, userClaims.UserID)
+	if err != nil {
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	c.invalidateWallet(walletDom)
+
+	NewSuccessResponse(ctx, statusCode, "wallet frozen successfully", map[string]interface{}{
+		"wallet": responses.FromWalletDomainV1(walletDom),
+	})
+}
+
+func (c *WalletHandler) Unfreeze(ctx *gin.Context) {
+	var uriRequest requests.WalletUriRequest
+	var unfreezeRequest requests.WalletUnfreezeRequest
+
+	// get authenticated admin from context
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(jwt.JwtCustomClaim)
+
+	// id yang bukan uuid tidak mungkin ada di database
+	if err := ctx.ShouldBindUri(&uriRequest); err != nil {
+		NewErrorResponse(ctx, http.StatusNotFound, "wallet not found")
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&unfreezeRequest); err != nil {
+		NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	walletDom, statusCode, err := c.walletUsecase.Unfreeze(ctxx, uriRequest.WalletId, unfreezeRequest.Reason, userClaims.UserID)
+	if err != nil {
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	c.invalidateWallet(walletDom)
+
+	NewSuccessResponse(ctx, statusCode, "wallet unfrozen successfully", map[string]interface{}{
+		"wallet": responses.FromWalletDomainV1(walletDom),
+	})
+}
+
+// StatusEvents menampilkan jejak audit freeze dan unfreeze, tidak di-cache agar selalu lengkap
+func (c *WalletHandler) StatusEvents(ctx *gin.Context) {
+	// id yang bukan uuid tidak mungkin ada di database
+	var uriRequest requests.WalletUriRequest
+	if err := ctx.ShouldBindUri(&uriRequest); err != nil {
+		NewErrorResponse(ctx, http.StatusNotFound, "wallet not found")
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	eventDoms, statusCode, err := c.walletUsecase.GetStatusEvents(ctxx, uriRequest.WalletId)
+	if err != nil {
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	eventResponses := responses.ToWalletStatusEventResponseList(eventDoms)
+	if eventResponses == nil {
+		NewSuccessResponse(ctx, statusCode, "wallet status event data is empty", []int{})
+		return
+	}
+
+	NewSuccessResponse(ctx, statusCode, "wallet status events fetched successfully", map[string]interface{}{
+		"events": eventResponses,
+	})
+}
+
+// invalidateWallet menghapus cache wallet yang status atau pocket default-nya berubah
+func (c *WalletHandler) invalidateWallet(walletDom V1Domains.WalletDomain) {
+	go c.ristrettoCache.Del("wallets", fmt.Sprintf("wallet/wallet_id:%s", walletDom.Id), fmt.Sprintf("wallet/user_id:%s", walletDom.UserId))
+}
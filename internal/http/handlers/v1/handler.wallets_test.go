@@ -16,6 +16,7 @@ import (
 	V1Usecases "github.com/snykk/transaction-api/internal/business/usecases/v1"
 	"github.com/snykk/transaction-api/internal/config"
 	"github.com/snykk/transaction-api/internal/constants"
+	PostgresRepo "github.com/snykk/transaction-api/internal/datasources/repositories/postgres/v1"
 	"github.com/snykk/transaction-api/internal/http/datatransfers/responses"
 	V1Handlers "github.com/snykk/transaction-api/internal/http/handlers/v1"
 	"github.com/snykk/transaction-api/internal/mocks"
@@ -314,3 +315,280 @@ func TestInfo(t *testing.T) {
 		assert.Contains(t, body, "database error")
 	})
 }
+
+func TestCreateWallet(t *testing.T) {
+	setupWallet(t)
+	sWallet.Use(lazyAuthCommonWallet)
+	// Define route
+	sWallet.POST(constants.EndpointV1+"/wallets", walletHandler.Create)
+
+	t.Run("Success - Create Pocket", func(t *testing.T) {
+		pocket := walletDataFromDB
+		pocket.Name = "savings"
+
+		walletRepoMock.Mock.On("CreateWallet", mock.Anything, walletDataFromDB.UserId, "savings").Return(pocket, nil).Once()
+		ristrettoWalletMock.On("Del", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string")).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, constants.EndpointV1+"/wallets", bytes.NewReader([]byte(`{"name":"savings"}`)))
+		r.Header.Set("Content-Type", "application/json")
+
+		sWallet.ServeHTTP(w, r)
+
+		body := w.Body.String()
+
+		// invalidasi cache berjalan di goroutine, beri waktu sebelum ekspektasi mock diperiksa
+		time.Sleep(50 * time.Millisecond)
+
+		assert.Equal(t, http.StatusCreated, w.Result().StatusCode)
+		assert.Contains(t, body, "wallet created successfully")
+		assert.Contains(t, body, `"name":"savings"`)
+	})
+
+	t.Run("Failure - Name Already Taken", func(t *testing.T) {
+		walletRepoMock.Mock.On("CreateWallet", mock.Anything, walletDataFromDB.UserId, "main").Return(V1Domains.WalletDomain{}, PostgresRepo.ErrWalletNameTaken).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, constants.EndpointV1+"/wallets", bytes.NewReader([]byte(`{"name":"main"}`)))
+		r.Header.Set("Content-Type", "application/json")
+
+		sWallet.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusConflict, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), "you already have a wallet with this name")
+	})
+
+	t.Run("Failure - Missing Name", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, constants.EndpointV1+"/wallets", bytes.NewReader([]byte(`{}`)))
+		r.Header.Set("Content-Type", "application/json")
+
+		sWallet.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), "Name")
+	})
+}
+
+func TestMine(t *testing.T) {
+	setupWallet(t)
+	sWallet.Use(lazyAuthCommonWallet)
+	// Define route
+	sWallet.GET(constants.EndpointV1+"/wallets/mine", walletHandler.Mine)
+
+	t.Run("When Success", func(t *testing.T) {
+		mainWallet := walletDataFromDB
+		mainWallet.Name = constants.WalletDefaultName
+		mainWallet.IsDefault = true
+		savings := walletDataFromDB
+		savings.Id = "zzzz-aaaa-bbbb"
+		savings.Name = "savings"
+
+		walletRepoMock.Mock.On("GetWalletsByUserId", mock.Anything, walletDataFromDB.UserId).Return([]V1Domains.WalletDomain{mainWallet, savings}, nil).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, constants.EndpointV1+"/wallets/mine", nil)
+
+		sWallet.ServeHTTP(w, r)
+
+		body := w.Body.String()
+
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, "wallet data fetched successfully")
+		assert.Contains(t, body, `"name":"main","is_default":true`)
+		assert.Contains(t, body, `"name":"savings","is_default":false`)
+	})
+
+	t.Run("When Empty", func(t *testing.T) {
+		walletRepoMock.Mock.On("GetWalletsByUserId", mock.Anything, walletDataFromDB.UserId).Return(nil, nil).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, constants.EndpointV1+"/wallets/mine", nil)
+
+		sWallet.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), "wallet data is empty")
+	})
+}
+
+func TestSetDefault(t *testing.T) {
+	setupWallet(t)
+	sWallet.Use(lazyAuthCommonWallet)
+	// Define route
+	sWallet.PUT(constants.EndpointV1+"/wallets/:id/default", walletHandler.SetDefault)
+
+	walletId := "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+
+	t.Run("Success - Set Default", func(t *testing.T) {
+		pocket := walletDataFromDB
+		pocket.Id = walletId
+		pocket.IsDefault = true
+
+		walletRepoMock.Mock.On("SetDefault", mock.Anything, walletDataFromDB.UserId, walletId).Return(pocket, nil).Once()
+		ristrettoWalletMock.On("Del", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string")).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPut, constants.EndpointV1+"/wallets/"+walletId+"/default", nil)
+
+		sWallet.ServeHTTP(w, r)
+
+		body := w.Body.String()
+
+		// invalidasi cache berjalan di goroutine, beri waktu sebelum ekspektasi mock diperiksa
+		time.Sleep(50 * time.Millisecond)
+
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, "default wallet updated successfully")
+		assert.Contains(t, body, `"is_default":true`)
+	})
+
+	t.Run("Failure - Wallet Of Another User", func(t *testing.T) {
+		walletRepoMock.Mock.On("SetDefault", mock.Anything, walletDataFromDB.UserId, walletId).Return(V1Domains.WalletDomain{}, PostgresRepo.ErrWalletNotFound).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPut, constants.EndpointV1+"/wallets/"+walletId+"/default", nil)
+
+		sWallet.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), "wallet not found")
+	})
+}
+
+func TestFreeze(t *testing.T) {
+	setupWallet(t)
+	sWallet.Use(lazyAuthAdminWallet)
+	// Define route
+	sWallet.POST(constants.EndpointV1+"/wallets/:id/freeze", walletHandler.Freeze)
+
+	walletId := "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+
+	t.Run("Success - Freeze Both Directions", func(t *testing.T) {
+		frozenWallet := walletDataFromDB
+		frozenWallet.Id = walletId
+		frozenWallet.Status = constants.WalletStatusFrozen
+
+		walletRepoMock.Mock.On("UpdateStatus", mock.Anything, mock.MatchedBy(func(e V1Domains.WalletStatusEventDomain) bool {
+			return e.WalletId == walletId && e.Status == constants.WalletStatusFrozen && *e.ActorId == "asdfsda"
+		})).Return(frozenWallet, nil).Once()
+		ristrettoWalletMock.On("Del", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string")).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, constants.EndpointV1+"/wallets/"+walletId+"/freeze", bytes.NewReader([]byte(`{"direction":"both","reason":"court order"}`)))
+		r.Header.Set("Content-Type", "application/json")
+
+		sWallet.ServeHTTP(w, r)
+
+		body := w.Body.String()
+
+		// invalidasi cache berjalan di goroutine, beri waktu sebelum ekspektasi mock diperiksa
+		time.Sleep(50 * time.Millisecond)
+
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, "wallet frozen successfully")
+		assert.Contains(t, body, `"status":"frozen"`)
+	})
+
+	t.Run("Failure - Invalid Direction", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, constants.EndpointV1+"/wallets/"+walletId+"/freeze", bytes.NewReader([]byte(`{"direction":"sideways","reason":"court order"}`)))
+		r.Header.Set("Content-Type", "application/json")
+
+		sWallet.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), "Direction")
+	})
+
+	t.Run("Failure - Non UUID Wallet Id", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, constants.EndpointV1+"/wallets/not-a-uuid/freeze", bytes.NewReader([]byte(`{"direction":"both","reason":"court order"}`)))
+		r.Header.Set("Content-Type", "application/json")
+
+		sWallet.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), "wallet not found")
+	})
+}
+
+func TestUnfreeze(t *testing.T) {
+	setupWallet(t)
+	sWallet.Use(lazyAuthAdminWallet)
+	// Define route
+	sWallet.POST(constants.EndpointV1+"/wallets/:id/unfreeze", walletHandler.Unfreeze)
+
+	t.Run("Failure - Wallet Is Not Frozen", func(t *testing.T) {
+		walletRepoMock.Mock.On("UpdateStatus", mock.Anything, mock.AnythingOfType("v1.WalletStatusEventDomain")).Return(V1Domains.WalletDomain{}, PostgresRepo.ErrWalletStatusUnchanged).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, constants.EndpointV1+"/wallets/7c9e6679-7425-40de-944b-e07fc1f90ae7/unfreeze", bytes.NewReader([]byte(`{"reason":"investigation closed"}`)))
+		r.Header.Set("Content-Type", "application/json")
+
+		sWallet.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusConflict, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), "wallet already has the requested status")
+	})
+
+	t.Run("Failure - Missing Reason", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, constants.EndpointV1+"/wallets/7c9e6679-7425-40de-944b-e07fc1f90ae7/unfreeze", bytes.NewReader([]byte(`{}`)))
+		r.Header.Set("Content-Type", "application/json")
+
+		sWallet.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), "Reason")
+	})
+}
+
+func TestStatusEvents(t *testing.T) {
+	setupWallet(t)
+	sWallet.Use(lazyAuthAdminWallet)
+	// Define route
+	sWallet.GET(constants.EndpointV1+"/wallets/:id/status-events", walletHandler.StatusEvents)
+
+	walletId := "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+
+	t.Run("When Success", func(t *testing.T) {
+		actorId := "asdfsda"
+		walletRepoMock.Mock.On("GetStatusEvents", mock.Anything, walletId).Return([]V1Domains.WalletStatusEventDomain{
+			{
+				Id:             "eeee-1111",
+				WalletId:       walletId,
+				Action:         constants.WalletStatusActionFreeze,
+				PreviousStatus: constants.WalletStatusActive,
+				Status:         constants.WalletStatusFrozenIncoming,
+				Reason:         "court order",
+				ActorId:        &actorId,
+				CreatedAt:      time.Now(),
+			},
+		}, nil).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, constants.EndpointV1+"/wallets/"+walletId+"/status-events", nil)
+
+		sWallet.ServeHTTP(w, r)
+
+		body := w.Body.String()
+
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, "wallet status events fetched successfully")
+		assert.Contains(t, body, `"actor_id":"asdfsda"`)
+		assert.Contains(t, body, `"reason":"court order"`)
+	})
+
+	t.Run("When Wallet Not Found", func(t *testing.T) {
+		walletRepoMock.Mock.On("GetStatusEvents", mock.Anything, walletId).Return(nil, PostgresRepo.ErrWalletNotFound).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, constants.EndpointV1+"/wallets/"+walletId+"/status-events", nil)
+
+		sWallet.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), "wallet not found")
+	})
+}
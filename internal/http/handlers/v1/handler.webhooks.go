@@ -0,0 +1,242 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+	"github.com/snykk/transaction-api/internal/http/datatransfers/requests"
+	"github.com/snykk/transaction-api/internal/http/datatransfers/responses"
+	"github.com/snykk/transaction-api/pkg/jwt"
+)
+
+// WebhookHandler melayani pendaftaran endpoint webhook, log pengiriman, kirim uji, dan kirim ulang
+type WebhookHandler struct {
+	webhookUsecase V1Domains.WebhookUsecase
+}
+
+func NewWebhookHandler(webhookUsecase V1Domains.WebhookUsecase) WebhookHandler {
+	return WebhookHandler{
+		webhookUsecase: webhookUsecase,
+	}
+}
+
+func (c *WebhookHandler) Store(ctx *gin.Context) {
+	var webhookRequest requests.WebhookRequest
+
+	// get authenticated user from context
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(jwt.JwtCustomClaim)
+
+	if err := ctx.ShouldBindJSON(&webhookRequest); err != nil {
+		NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	webhookDom, statusCode, err := c.webhookUsecase.Store(ctxx, webhookRequest.ToDomain(userClaims.UserID))
+	if err != nil {
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	// secret hanya ditampilkan sekali, receiver menyimpannya untuk memeriksa signature
+	NewSuccessResponse(ctx, statusCode, "webhook created successfully", map[string]interface{}{
+		"webhook": responses.FromWebhookDomainWithSecretV1(webhookDom),
+	})
+}
+
+func (c *WebhookHandler) GetAll(ctx *gin.Context) {
+	// get authenticated user from context
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(jwt.JwtCustomClaim)
+
+	ctxx := ctx.Request.Context()
+	webhookDoms, statusCode, err := c.webhookUsecase.GetAll(ctxx, userClaims.UserID)
+	if err != nil {
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	webhookResponses := responses.ToWebhookResponseList(webhookDoms)
+	if webhookResponses == nil {
+		NewSuccessResponse(ctx, statusCode, "webhook data is empty", []int{})
+		return
+	}
+
+	NewSuccessResponse(ctx, statusCode, "webhooks fetched successfully", map[string]interface{}{
+		"webhooks": webhookResponses,
+	})
+}
+
+func (c *WebhookHandler) GetById(ctx *gin.Context) {
+	// get authenticated user from context
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(jwt.JwtCustomClaim)
+
+	var uriRequest requests.WebhookUriRequest
+	if err := ctx.ShouldBindUri(&uriRequest); err != nil {
+		NewErrorResponse(ctx, http.StatusNotFound, "webhook not found")
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	webhookDom, statusCode, err := c.webhookUsecase.GetById(ctxx, userClaims.UserID, uriRequest.WebhookId)
+	if err != nil {
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	NewSuccessResponse(ctx, statusCode, "webhook fetched successfully", map[string]interface{}{
+		"webhook": responses.FromWebhookDomainV1(webhookDom),
+	})
+}
+
+func (c *WebhookHandler) Update(ctx *gin.Context) {
+	var uriRequest requests.WebhookUriRequest
+	var webhookRequest requests.WebhookRequest
+
+	// get authenticated user from context
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(jwt.JwtCustomClaim)
+
+	if err := ctx.ShouldBindUri(&uriRequest); err != nil {
+		NewErrorResponse(ctx, http.StatusNotFound, "webhook not found")
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&webhookRequest); err != nil {
+		NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	webhookDom, statusCode, err := c.webhookUsecase.Update(ctxx, userClaims.UserID, uriRequest.WebhookId, webhookRequest.ToDomain(userClaims.UserID))
+	if err != nil {
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	// secret baru ditampilkan sekali jika user menggantinya
+	webhookResponse := responses.FromWebhookDomainV1(webhookDom)
+	if webhookRequest.Secret != "" {
+		webhookResponse = responses.FromWebhookDomainWithSecretV1(webhookDom)
+	}
+
+	NewSuccessResponse(ctx, statusCode, "webhook updated successfully", map[string]interface{}{
+		"webhook": webhookResponse,
+	})
+}
+
+func (c *WebhookHandler) Delete(ctx *gin.Context) {
+	// get authenticated user from context
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(jwt.JwtCustomClaim)
+
+	var uriRequest requests.WebhookUriRequest
+	if err := ctx.ShouldBindUri(&uriRequest); err != nil {
+		NewErrorResponse(ctx, http.StatusNotFound, "webhook not found")
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	statusCode, err := c.webhookUsecase.Delete(ctxx, userClaims.UserID, uriRequest.WebhookId)
+	if err != nil {
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	NewSuccessResponse(ctx, statusCode, fmt.Sprintf("webhook with id %s deleted successfully", uriRequest.WebhookId), nil)
+}
+
+func (c *WebhookHandler) Test(ctx *gin.Context) {
+	// get authenticated user from context
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(jwt.JwtCustomClaim)
+
+	var uriRequest requests.WebhookUriRequest
+	if err := ctx.ShouldBindUri(&uriRequest); err != nil {
+		NewErrorResponse(ctx, http.StatusNotFound, "webhook not found")
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	deliveryDom, statusCode, err := c.webhookUsecase.Test(ctxx, userClaims.UserID, uriRequest.WebhookId)
+	if err != nil {
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	// receiver yang menolak tetap dilaporkan sebagai response sukses, hasilnya ada di status pengiriman
+	NewSuccessResponse(ctx, statusCode, fmt.Sprintf("test event %s", deliveryDom.Status), map[string]interface{}{
+		"delivery": responses.FromWebhookDeliveryDomainV1(deliveryDom),
+	})
+}
+
+func (c *WebhookHandler) GetDeliveries(ctx *gin.Context) {
+	// get authenticated user from context
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(jwt.JwtCustomClaim)
+
+	var uriRequest requests.WebhookUriRequest
+	if err := ctx.ShouldBindUri(&uriRequest); err != nil {
+		NewErrorResponse(ctx, http.StatusNotFound, "webhook not found")
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	deliveryDoms, statusCode, err := c.webhookUsecase.GetDeliveries(ctxx, userClaims.UserID, uriRequest.WebhookId)
+	if err != nil {
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	deliveryResponses := responses.ToWebhookDeliveryResponseList(deliveryDoms)
+	if deliveryResponses == nil {
+		NewSuccessResponse(ctx, statusCode, "webhook delivery data is empty", []int{})
+		return
+	}
+
+	NewSuccessResponse(ctx, statusCode, "webhook deliveries fetched successfully", map[string]interface{}{
+		"deliveries": deliveryResponses,
+	})
+}
+
+func (c *WebhookHandler) GetDeliveryById(ctx *gin.Context) {
+	// get authenticated user from context
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(jwt.JwtCustomClaim)
+
+	var uriRequest requests.WebhookDeliveryUriRequest
+	if err := ctx.ShouldBindUri(&uriRequest); err != nil {
+		NewErrorResponse(ctx, http.StatusNotFound, "webhook delivery not found")
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	deliveryDom, statusCode, err := c.webhookUsecase.GetDeliveryById(ctxx, userClaims.UserID, uriRequest.WebhookId, uriRequest.DeliveryId)
+	if err != nil {
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	NewSuccessResponse(ctx, statusCode, "webhook delivery fetched successfully", map[string]interface{}{
+		"delivery": responses.FromWebhookDeliveryDomainV1(deliveryDom),
+	})
+}
+
+func (c *WebhookHandler) Redeliver(ctx *gin.Context) {
+	// get authenticated user from context
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(jwt.JwtCustomClaim)
+
+	var uriRequest requests.WebhookDeliveryUriRequest
+	if err := ctx.ShouldBindUri(&uriRequest); err != nil {
+		NewErrorResponse(ctx, http.StatusNotFound, "webhook delivery not found")
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	deliveryDom, statusCode, err := c.webhookUsecase.Redeliver(ctxx, userClaims.UserID, uriRequest.WebhookId, uriRequest.DeliveryId)
+	if err != nil {
+		NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	NewSuccessResponse(ctx, statusCode, fmt.Sprintf("redelivery %s", deliveryDom.Status), map[string]interface{}{
+		"delivery": responses.FromWebhookDeliveryDomainV1(deliveryDom),
+	})
+}
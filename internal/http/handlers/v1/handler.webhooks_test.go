@@ -0,0 +1,246 @@
+package v1_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	dgriJWT "github.com/dgrijalva/jwt-go"
+	"github.com/gin-gonic/gin"
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	V1Usecases "github.com/snykk/transaction-api/internal/business/usecases/v1"
+	"github.com/snykk/transaction-api/internal/config"
+	"github.com/snykk/transaction-api/internal/constants"
+	PostgresRepo "github.com/snykk/transaction-api/internal/datasources/repositories/postgres/v1"
+	V1Handlers "github.com/snykk/transaction-api/internal/http/handlers/v1"
+	"github.com/snykk/transaction-api/internal/mocks"
+	"github.com/snykk/transaction-api/pkg/jwt"
+	"github.com/snykk/transaction-api/pkg/webhook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	webhookRepoMock *mocks.WebhookRepository
+	webhookUsecase  V1Domains.WebhookUsecase
+	webhookHandler  V1Handlers.WebhookHandler
+	webhookReceiver *httptest.Server
+	sWebhook        *gin.Engine
+)
+
+const (
+	webhookUserId = "aaaa-bbbb-cccc"
+	webhookId     = "0c0ef1a4-5d6f-4c1e-9a57-7d0b8f4e2b11"
+)
+
+func setupWebhook(t *testing.T) {
+	// receiver lokal yang selalu menerima webhook
+	webhookReceiver = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(webhookReceiver.Close)
+
+	// Initialize mock dependencies
+	webhookRepoMock = mocks.NewWebhookRepository(t)
+	webhookUsecase = V1Usecases.NewWebhookUsecase(webhookRepoMock, webhook.NewSender(time.Second, true), 3, 30*time.Second, time.Hour, 10*time.Minute)
+	webhookHandler = V1Handlers.NewWebhookHandler(webhookUsecase)
+
+	// Setup Gin engine with middleware for authentication
+	sWebhook = gin.Default()
+	sWebhook.Use(lazyAuthCommonWebhook)
+	sWebhook.GET(constants.EndpointV1+"/webhooks", webhookHandler.GetAll)
+	sWebhook.POST(constants.EndpointV1+"/webhooks", webhookHandler.Store)
+	sWebhook.GET(constants.EndpointV1+"/webhooks/:id", webhookHandler.GetById)
+	sWebhook.PUT(constants.EndpointV1+"/webhooks/:id", webhookHandler.Update)
+	sWebhook.DELETE(constants.EndpointV1+"/webhooks/:id", webhookHandler.Delete)
+	sWebhook.POST(constants.EndpointV1+"/webhooks/:id/test", webhookHandler.Test)
+	sWebhook.GET(constants.EndpointV1+"/webhooks/:id/deliveries", webhookHandler.GetDeliveries)
+	sWebhook.GET(constants.EndpointV1+"/webhooks/:id/deliveries/:delivery_id", webhookHandler.GetDeliveryById)
+	sWebhook.POST(constants.EndpointV1+"/webhooks/:id/deliveries/:delivery_id/redeliver", webhookHandler.Redeliver)
+}
+
+// Mock lazy authentication
+func lazyAuthCommonWebhook(ctx *gin.Context) {
+	jwtClaims := jwt.JwtCustomClaim{
+		UserID:  webhookUserId,
+		IsAdmin: false,
+		Email:   "patrick@gmail.com",
+		StandardClaims: dgriJWT.StandardClaims{
+			ExpiresAt: time.Now().Add(time.Hour * time.Duration(config.AppConfig.JWTExpired)).Unix(),
+			Issuer:    "patrick",
+			IssuedAt:  time.Now().Unix(),
+		},
+	}
+	ctx.Set(constants.CtxAuthenticatedUserKey, jwtClaims)
+}
+
+func TestStoreWebhook(t *testing.T) {
+	setupWebhook(t)
+
+	t.Run("Success - Create Webhook Returns Secret Once", func(t *testing.T) {
+		reqBody := `{"url":"https://partner.example.com/hooks","event_types":["transaction.deposit.completed","transaction.purchase.completed"]}`
+
+		webhookRepoMock.Mock.On("Store", mock.Anything, mock.MatchedBy(func(w V1Domains.WebhookEndpointDomain) bool {
+			return w.UserId == webhookUserId && w.IsActive && len(w.EventTypes) == 2
+		})).Return(func(ctx context.Context, w V1Domains.WebhookEndpointDomain) V1Domains.WebhookEndpointDomain {
+			w.Id = webhookId
+			return w
+		}, nil).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, constants.EndpointV1+"/webhooks", strings.NewReader(reqBody))
+		r.Header.Set("Content-Type", "application/json")
+
+		// Serve request
+		sWebhook.ServeHTTP(w, r)
+		body := w.Body.String()
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusCreated, w.Result().StatusCode)
+		assert.Contains(t, body, "webhook created successfully")
+		assert.Contains(t, body, `"secret":"whsec_`)
+	})
+
+	t.Run("Failure - Unsupported Event Type", func(t *testing.T) {
+		reqBody := `{"url":"https://partner.example.com/hooks","event_types":["user.created"]}`
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, constants.EndpointV1+"/webhooks", strings.NewReader(reqBody))
+		r.Header.Set("Content-Type", "application/json")
+
+		// Serve request
+		sWebhook.ServeHTTP(w, r)
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), V1Usecases.ErrWebhookEventTypeInvalid.Error())
+	})
+}
+
+func TestGetWebhook(t *testing.T) {
+	setupWebhook(t)
+
+	t.Run("Success - Get Webhook Without Secret", func(t *testing.T) {
+		webhookRepoMock.Mock.On("GetById", mock.Anything, webhookUserId, webhookId).Return(V1Domains.WebhookEndpointDomain{
+			Id:         webhookId,
+			UserId:     webhookUserId,
+			URL:        "https://partner.example.com/hooks",
+			EventTypes: []string{constants.EventTransactionDepositCompleted},
+			Secret:     "whsec_never_shown_again",
+			IsActive:   true,
+		}, nil).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, constants.EndpointV1+"/webhooks/"+webhookId, nil)
+
+		// Serve request
+		sWebhook.ServeHTTP(w, r)
+		body := w.Body.String()
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, "webhook fetched successfully")
+		assert.NotContains(t, body, "whsec_never_shown_again")
+	})
+
+	t.Run("Failure - Invalid Webhook Id", func(t *testing.T) {
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, constants.EndpointV1+"/webhooks/not-a-uuid", nil)
+
+		// Serve request
+		sWebhook.ServeHTTP(w, r)
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), "webhook not found")
+	})
+}
+
+func TestTestWebhook(t *testing.T) {
+	setupWebhook(t)
+
+	t.Run("Success - Test Event Delivered To Receiver", func(t *testing.T) {
+		webhookRepoMock.Mock.On("GetById", mock.Anything, webhookUserId, webhookId).Return(V1Domains.WebhookEndpointDomain{
+			Id:         webhookId,
+			UserId:     webhookUserId,
+			URL:        webhookReceiver.URL,
+			EventTypes: []string{constants.EventTransactionDepositCompleted},
+			Secret:     "whsec_local_receiver_secret",
+			IsActive:   true,
+		}, nil).Once()
+		webhookRepoMock.Mock.On("StoreDelivery", mock.Anything, mock.Anything).Return(func(ctx context.Context, d V1Domains.WebhookDeliveryDomain) V1Domains.WebhookDeliveryDomain {
+			d.Id = "dddd-eeee-0001"
+			return d
+		}, nil).Once()
+		webhookRepoMock.Mock.On("RecordAttempt", mock.Anything, mock.Anything, mock.Anything).Return(func(ctx context.Context, d V1Domains.WebhookDeliveryDomain, a V1Domains.WebhookAttemptDomain) V1Domains.WebhookDeliveryDomain {
+			return d
+		}, nil).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, constants.EndpointV1+"/webhooks/"+webhookId+"/test", nil)
+
+		// Serve request
+		sWebhook.ServeHTTP(w, r)
+		body := w.Body.String()
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, "test event delivered")
+		assert.Contains(t, body, `"last_status_code":204`)
+	})
+}
+
+func TestRedeliverWebhook(t *testing.T) {
+	setupWebhook(t)
+
+	t.Run("Failure - Delivery Not Found", func(t *testing.T) {
+		deliveryId := "5b9c2c8e-2f3d-4a8b-8f7e-1d2c3b4a5f60"
+		webhookRepoMock.Mock.On("GetDeliveryById", mock.Anything, webhookUserId, webhookId, deliveryId).Return(V1Domains.WebhookDeliveryDomain{}, PostgresRepo.ErrWebhookDeliveryNotFound).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, constants.EndpointV1+"/webhooks/"+webhookId+"/deliveries/"+deliveryId+"/redeliver", nil)
+
+		// Serve request
+		sWebhook.ServeHTTP(w, r)
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), PostgresRepo.ErrWebhookDeliveryNotFound.Error())
+	})
+}
+
+func TestGetWebhookDeliveries(t *testing.T) {
+	setupWebhook(t)
+
+	t.Run("Success - Get Delivery Log", func(t *testing.T) {
+		eventId := "eeee-vvvv-0001"
+		lastError := "receiver responded with status 500: oops"
+		statusCode := http.StatusInternalServerError
+		webhookRepoMock.Mock.On("GetDeliveries", mock.Anything, webhookUserId, webhookId, constants.WebhookDeliveryLogLimit).Return([]V1Domains.WebhookDeliveryDomain{
+			{Id: "dddd-eeee-0001", WebhookId: webhookId, EventId: &eventId, EventType: constants.EventTransactionDepositCompleted, Status: constants.WebhookDeliveryStatusPending, Attempts: 1, NextAttemptAt: time.Now().Add(time.Minute), LastStatusCode: &statusCode, LastError: &lastError},
+		}, nil).Once()
+
+		// Perform the HTTP request
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, constants.EndpointV1+"/webhooks/"+webhookId+"/deliveries", nil)
+
+		// Serve request
+		sWebhook.ServeHTTP(w, r)
+		body := w.Body.String()
+
+		// Assert the HTTP response
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, "webhook deliveries fetched successfully")
+		assert.Contains(t, body, `"next_attempt_at"`)
+		assert.Contains(t, body, `"last_status_code":500`)
+	})
+}
@@ -43,6 +43,19 @@ func TestCORSMiddleware(t *testing.T) {
 		assert.Equal(t, constants.AllowHeader, w.Header().Get("Access-Control-Allow-Headers"))
 		assert.Equal(t, "true", w.Header().Get("Access-Control-Allow-Credentials"))
 	})
+	t.Run("Test 1 | Use Stream Headers", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/test", nil)
+		req.Header.Set(constants.HeaderLastEventId, "1700000000000-0")
+		req.Header.Set("Connection", "Upgrade")
+		req.Header.Set("Upgrade", "websocket")
+		req.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+		req.Header.Set("Sec-WebSocket-Version", "13")
+		req.Header.Set("Sec-WebSocket-Extensions", "permessage-deflate")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
 	t.Run("Test 1 | Use Not Allowed Header", func(t *testing.T) {
 		w := httptest.NewRecorder()
 		req, _ := http.NewRequest("GET", "/test", nil)
@@ -0,0 +1,132 @@
+package middlewares
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+	V1Handler "github.com/snykk/transaction-api/internal/http/handlers/v1"
+	"github.com/snykk/transaction-api/pkg/jwt"
+	"github.com/snykk/transaction-api/pkg/logger"
+)
+
+type IdempotencyMiddleware struct {
+	store V1Domains.IdempotencyRepository
+	ttl   time.Duration
+}
+
+// NewIdempotencyMiddleware harus dipasang setelah auth middleware karena key disimpan per user
+func NewIdempotencyMiddleware(store V1Domains.IdempotencyRepository, ttl time.Duration) gin.HandlerFunc {
+	return (&IdempotencyMiddleware{
+		store: store,
+		ttl:   ttl,
+	}).Handle
+}
+
+// responseRecorder menyalin body response agar bisa disimpan setelah handler selesai
+type responseRecorder struct {
+	gin.ResponseWriter
+	body *bytes.Buffer
+}
+
+func (w *responseRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (m *IdempotencyMiddleware) Handle(ctx *gin.Context) {
+	key := ctx.GetHeader(constants.HeaderIdempotencyKey)
+	if key == "" {
+		ctx.Next()
+		return
+	}
+
+	if len(key) > constants.IdempotencyKeyMaxLength {
+		abortIdempotency(ctx, http.StatusBadRequest, "idempotency key is too long")
+		return
+	}
+
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(jwt.JwtCustomClaim)
+
+	body, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		abortIdempotency(ctx, http.StatusBadRequest, "failed to read request body")
+		return
+	}
+	ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	requestHash := hashRequest(ctx.Request.Method, ctx.FullPath(), body)
+	record, reserved, err := m.store.Reserve(ctx.Request.Context(), V1Domains.IdempotencyKeyDomain{
+		UserId:      userClaims.UserID,
+		Key:         key,
+		RequestHash: requestHash,
+		ExpiresAt:   time.Now().Add(m.ttl),
+	})
+	if err != nil {
+		abortIdempotency(ctx, http.StatusInternalServerError, "failed to process idempotency key")
+		return
+	}
+
+	if !reserved {
+		switch {
+		case record.RequestHash != requestHash:
+			abortIdempotency(ctx, http.StatusUnprocessableEntity, "idempotency key was already used for a different request")
+		case record.Status == constants.IdempotencyStatusProcessing:
+			abortIdempotency(ctx, http.StatusConflict, "a request with this idempotency key is still being processed")
+		default:
+			// replay response dari request pertama
+			ctx.Header(constants.HeaderIdempotentReplayed, "true")
+			ctx.Data(record.ResponseCode, "application/json; charset=utf-8", record.ResponseBody)
+			ctx.Abort()
+		}
+		return
+	}
+
+	recorder := &responseRecorder{ResponseWriter: ctx.Writer, body: &bytes.Buffer{}}
+	ctx.Writer = recorder
+
+	ctx.Next()
+
+	// simpan hasil meskipun client sudah memutus koneksi
+	storeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx.Request.Context()), 5*time.Second)
+	defer cancel()
+
+	// error server tidak disimpan supaya client boleh mencoba lagi dengan key yang sama
+	if recorder.Status() >= http.StatusInternalServerError {
+		err = m.store.Release(storeCtx, userClaims.UserID, key)
+	} else {
+		record.ResponseCode = recorder.Status()
+		record.ResponseBody = recorder.body.Bytes()
+		err = m.store.Complete(storeCtx, record)
+	}
+	if err != nil {
+		logger.ErrorF("failed to store idempotency key %s: %v", logrus.Fields{constants.LoggerCategory: constants.LoggerCategoryHTTP}, key, err)
+	}
+}
+
+func hashRequest(method string, path string, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(method + " " + path + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func abortIdempotency(ctx *gin.Context, statusCode int, message string) {
+	ctx.AbortWithStatusJSON(statusCode, V1Handler.BaseResponse{
+		Status:  false,
+		Message: message,
+	})
+}
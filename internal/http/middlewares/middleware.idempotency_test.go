@@ -0,0 +1,159 @@
+package middlewares_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+	"github.com/snykk/transaction-api/internal/http/middlewares"
+	"github.com/snykk/transaction-api/internal/mocks"
+	"github.com/snykk/transaction-api/pkg/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	idempotencyStoreMock *mocks.IdempotencyRepository
+	sIdempotency         *gin.Engine
+	handlerCalls         int
+)
+
+const (
+	idempotencyEndpoint = "/deposit"
+	idempotencyUserId   = "ddfcea5c-d919-4a8f-a631-4ace39337s3a"
+)
+
+func setupIdempotency(t *testing.T, handlerStatus int) {
+	idempotencyStoreMock = mocks.NewIdempotencyRepository(t)
+	handlerCalls = 0
+
+	sIdempotency = gin.New()
+	sIdempotency.POST(idempotencyEndpoint, func(ctx *gin.Context) {
+		ctx.Set(constants.CtxAuthenticatedUserKey, jwt.JwtCustomClaim{UserID: idempotencyUserId})
+	}, middlewares.NewIdempotencyMiddleware(idempotencyStoreMock, time.Hour), func(ctx *gin.Context) {
+		handlerCalls++
+		ctx.JSON(handlerStatus, map[string]interface{}{
+			"status":  handlerStatus < http.StatusBadRequest,
+			"message": "deposit completed successfully",
+		})
+	})
+}
+
+func newIdempotentRequest(key string, body string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, idempotencyEndpoint, bytes.NewReader([]byte(body)))
+	r.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		r.Header.Set(constants.HeaderIdempotencyKey, key)
+	}
+	return r
+}
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	t.Run("Without Header Request Is Passed Through", func(t *testing.T) {
+		setupIdempotency(t, http.StatusCreated)
+
+		w := httptest.NewRecorder()
+		sIdempotency.ServeHTTP(w, newIdempotentRequest("", `{"amount":200}`))
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, 1, handlerCalls)
+		idempotencyStoreMock.AssertNotCalled(t, "Reserve", mock.Anything, mock.Anything)
+	})
+
+	t.Run("First Request Stores The Response", func(t *testing.T) {
+		setupIdempotency(t, http.StatusCreated)
+
+		idempotencyStoreMock.On("Reserve", mock.Anything, mock.MatchedBy(func(in V1Domains.IdempotencyKeyDomain) bool {
+			return in.UserId == idempotencyUserId && in.Key == "key-1" && in.RequestHash != ""
+		})).Return(func(ctx context.Context, in V1Domains.IdempotencyKeyDomain) (V1Domains.IdempotencyKeyDomain, bool, error) {
+			in.Status = constants.IdempotencyStatusProcessing
+			return in, true, nil
+		}).Once()
+		idempotencyStoreMock.On("Complete", mock.Anything, mock.MatchedBy(func(in V1Domains.IdempotencyKeyDomain) bool {
+			return in.ResponseCode == http.StatusCreated && bytes.Contains(in.ResponseBody, []byte("deposit completed successfully"))
+		})).Return(nil).Once()
+
+		w := httptest.NewRecorder()
+		sIdempotency.ServeHTTP(w, newIdempotentRequest("key-1", `{"amount":200}`))
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, 1, handlerCalls)
+		assert.Empty(t, w.Header().Get(constants.HeaderIdempotentReplayed))
+	})
+
+	t.Run("Retry Replays The Stored Response", func(t *testing.T) {
+		setupIdempotency(t, http.StatusCreated)
+
+		storedBody := []byte(`{"status":true,"message":"deposit completed successfully"}`)
+		idempotencyStoreMock.On("Reserve", mock.Anything, mock.Anything).Return(func(ctx context.Context, in V1Domains.IdempotencyKeyDomain) (V1Domains.IdempotencyKeyDomain, bool, error) {
+			in.Status = constants.IdempotencyStatusCompleted
+			in.ResponseCode = http.StatusCreated
+			in.ResponseBody = storedBody
+			return in, false, nil
+		}).Once()
+
+		w := httptest.NewRecorder()
+		sIdempotency.ServeHTTP(w, newIdempotentRequest("key-1", `{"amount":200}`))
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, 0, handlerCalls)
+		assert.Equal(t, "true", w.Header().Get(constants.HeaderIdempotentReplayed))
+		assert.Equal(t, string(storedBody), w.Body.String())
+	})
+
+	t.Run("Concurrent Duplicate Gets Conflict", func(t *testing.T) {
+		setupIdempotency(t, http.StatusCreated)
+
+		idempotencyStoreMock.On("Reserve", mock.Anything, mock.Anything).Return(func(ctx context.Context, in V1Domains.IdempotencyKeyDomain) (V1Domains.IdempotencyKeyDomain, bool, error) {
+			in.Status = constants.IdempotencyStatusProcessing
+			return in, false, nil
+		}).Once()
+
+		w := httptest.NewRecorder()
+		sIdempotency.ServeHTTP(w, newIdempotentRequest("key-1", `{"amount":200}`))
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Equal(t, 0, handlerCalls)
+		assert.Contains(t, w.Body.String(), "still being processed")
+	})
+
+	t.Run("Reused Key With Different Body Is Rejected", func(t *testing.T) {
+		setupIdempotency(t, http.StatusCreated)
+
+		idempotencyStoreMock.On("Reserve", mock.Anything, mock.Anything).Return(V1Domains.IdempotencyKeyDomain{
+			UserId:       idempotencyUserId,
+			Key:          "key-1",
+			RequestHash:  "hash-of-another-body",
+			Status:       constants.IdempotencyStatusCompleted,
+			ResponseCode: http.StatusCreated,
+		}, false, nil).Once()
+
+		w := httptest.NewRecorder()
+		sIdempotency.ServeHTTP(w, newIdempotentRequest("key-1", `{"amount":500}`))
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		assert.Equal(t, 0, handlerCalls)
+		assert.Contains(t, w.Body.String(), "different request")
+	})
+
+	t.Run("Server Error Releases The Key", func(t *testing.T) {
+		setupIdempotency(t, http.StatusInternalServerError)
+
+		idempotencyStoreMock.On("Reserve", mock.Anything, mock.Anything).Return(func(ctx context.Context, in V1Domains.IdempotencyKeyDomain) (V1Domains.IdempotencyKeyDomain, bool, error) {
+			return in, true, nil
+		}).Once()
+		idempotencyStoreMock.On("Release", mock.Anything, idempotencyUserId, "key-1").Return(nil).Once()
+
+		w := httptest.NewRecorder()
+		sIdempotency.ServeHTTP(w, newIdempotentRequest("key-1", `{"amount":200}`))
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, 1, handlerCalls)
+	})
+}
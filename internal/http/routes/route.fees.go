@@ -0,0 +1,44 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+	V1Usecase "github.com/snykk/transaction-api/internal/business/usecases/v1"
+	V1PostgresRepository "github.com/snykk/transaction-api/internal/datasources/repositories/postgres/v1"
+	V1Handler "github.com/snykk/transaction-api/internal/http/handlers/v1"
+)
+
+type feeRuleRoutes struct {
+	v1Handler       V1Handler.FeeRuleHandler
+	router          *gin.RouterGroup
+	db              *sqlx.DB
+	authMiddleware  gin.HandlerFunc
+	adminMiddleware gin.HandlerFunc
+}
+
+func NewFeeRuleRoute(router *gin.RouterGroup, db *sqlx.DB, authMiddleware gin.HandlerFunc, adminMiddleware gin.HandlerFunc) *feeRuleRoutes {
+	V1FeeRuleRepository := V1PostgresRepository.NewFeeRuleRepository(db)
+	V1FeeRuleUsecase := V1Usecase.NewFeeRuleUsecase(V1FeeRuleRepository)
+	V1FeeRuleHandler := V1Handler.NewFeeRuleHandler(V1FeeRuleUsecase)
+
+	return &feeRuleRoutes{v1Handler: V1FeeRuleHandler, router: router, db: db, authMiddleware: authMiddleware, adminMiddleware: adminMiddleware}
+}
+
+func (r *feeRuleRoutes) Routes() {
+	// Routes V1
+	V1Route := r.router.Group("/v1")
+	{
+		feeRuleRoute := V1Route.Group("/fees")
+
+		// admin only
+		feeRuleRoute.Use(r.authMiddleware, r.adminMiddleware)
+		{
+			feeRuleRoute.GET("", r.v1Handler.GetAll)
+			feeRuleRoute.POST("", r.v1Handler.Store)
+			feeRuleRoute.GET("/:id", r.v1Handler.GetById)
+			feeRuleRoute.PUT("/:id", r.v1Handler.Update)
+			feeRuleRoute.DELETE("/:id", r.v1Handler.Delete)
+		}
+	}
+
+}
@@ -0,0 +1,44 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/internal/datasources/caches"
+	V1Handler "github.com/snykk/transaction-api/internal/http/handlers/v1"
+)
+
+type holdRoutes struct {
+	v1Handler             V1Handler.HoldHandler
+	router                *gin.RouterGroup
+	db                    *sqlx.DB
+	authMiddleware        gin.HandlerFunc
+	idempotencyMiddleware gin.HandlerFunc
+}
+
+// NewHoldRoute memakai usecase yang sama dengan sweeper hold di server.NewApp
+func NewHoldRoute(router *gin.RouterGroup, db *sqlx.DB, holdUsecase V1Domains.HoldUsecase, ristrettoCache caches.RistrettoCache, authMiddleware gin.HandlerFunc, idempotencyMiddleware gin.HandlerFunc) *holdRoutes {
+	V1HoldHandler := V1Handler.NewHoldHandler(holdUsecase, ristrettoCache)
+
+	return &holdRoutes{v1Handler: V1HoldHandler, router: router, db: db, authMiddleware: authMiddleware, idempotencyMiddleware: idempotencyMiddleware}
+}
+
+func (r *holdRoutes) Routes() {
+	// Routes V1
+	V1Route := r.router.Group("/v1")
+	{
+		holdRoute := V1Route.Group("/holds")
+
+		// authenticated user
+		holdRoute.Use(r.authMiddleware)
+		{
+			holdRoute.GET("/:id", r.v1Handler.GetById)
+
+			// money-moving endpoint menghormati header Idempotency-Key
+			holdRoute.POST("", r.idempotencyMiddleware, r.v1Handler.Store)
+			holdRoute.POST("/:id/capture", r.idempotencyMiddleware, r.v1Handler.Capture)
+			holdRoute.POST("/:id/void", r.idempotencyMiddleware, r.v1Handler.Void)
+		}
+	}
+
+}
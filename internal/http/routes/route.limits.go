@@ -0,0 +1,48 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	V1Usecase "github.com/snykk/transaction-api/internal/business/usecases/v1"
+	V1PostgresRepository "github.com/snykk/transaction-api/internal/datasources/repositories/postgres/v1"
+	V1Handler "github.com/snykk/transaction-api/internal/http/handlers/v1"
+)
+
+type limitRoutes struct {
+	v1Handler       V1Handler.LimitHandler
+	router          *gin.RouterGroup
+	db              *sqlx.DB
+	authMiddleware  gin.HandlerFunc
+	adminMiddleware gin.HandlerFunc
+}
+
+func NewLimitRoute(router *gin.RouterGroup, db *sqlx.DB, defaultLimits V1Domains.UserLimitDomain, authMiddleware gin.HandlerFunc, adminMiddleware gin.HandlerFunc) *limitRoutes {
+	V1LimitRepository := V1PostgresRepository.NewLimitRepository(db)
+	V1LimitUsecase := V1Usecase.NewLimitUsecase(V1LimitRepository, defaultLimits)
+	V1LimitHandler := V1Handler.NewLimitHandler(V1LimitUsecase)
+
+	return &limitRoutes{v1Handler: V1LimitHandler, router: router, db: db, authMiddleware: authMiddleware, adminMiddleware: adminMiddleware}
+}
+
+func (r *limitRoutes) Routes() {
+	// Routes V1
+	V1Route := r.router.Group("/v1")
+	{
+		limitRoute := V1Route.Group("/limits")
+
+		// authenticated user
+		limitRoute.Use(r.authMiddleware)
+		{
+			limitRoute.GET("", r.v1Handler.GetOwn)
+		}
+
+		// admin only
+		limitRoute.Use(r.adminMiddleware)
+		{
+			limitRoute.GET("/:user_id", r.v1Handler.GetByUserId)
+			limitRoute.PUT("/:user_id", r.v1Handler.Update)
+		}
+	}
+
+}
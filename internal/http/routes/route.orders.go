@@ -0,0 +1,50 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	V1Usecase "github.com/snykk/transaction-api/internal/business/usecases/v1"
+	"github.com/snykk/transaction-api/internal/datasources/caches"
+	V1PostgresRepository "github.com/snykk/transaction-api/internal/datasources/repositories/postgres/v1"
+	V1Handler "github.com/snykk/transaction-api/internal/http/handlers/v1"
+)
+
+type orderRoutes struct {
+	v1Handler             V1Handler.OrderHandler
+	router                *gin.RouterGroup
+	db                    *sqlx.DB
+	authMiddleware        gin.HandlerFunc
+	idempotencyMiddleware gin.HandlerFunc
+}
+
+func NewOrderRoute(router *gin.RouterGroup, db *sqlx.DB, ristrettoCache caches.RistrettoCache, defaultLimits V1Domains.UserLimitDomain, authMiddleware gin.HandlerFunc, idempotencyMiddleware gin.HandlerFunc) *orderRoutes {
+	V1OrderRepository := V1PostgresRepository.NewOrderRepository(db, defaultLimits)
+
+	V1FeeRuleRepository := V1PostgresRepository.NewFeeRuleRepository(db)
+	V1ProductRepository := V1PostgresRepository.NewProductRepository(db)
+
+	V1OrderUsecase := V1Usecase.NewOrderUsecase(V1OrderRepository, V1FeeRuleRepository, V1ProductRepository)
+	V1OrderHandler := V1Handler.NewOrderHandler(V1OrderUsecase, ristrettoCache)
+
+	return &orderRoutes{v1Handler: V1OrderHandler, router: router, db: db, authMiddleware: authMiddleware, idempotencyMiddleware: idempotencyMiddleware}
+}
+
+func (r *orderRoutes) Routes() {
+	// Routes V1
+	V1Route := r.router.Group("/v1")
+	{
+		orderRoute := V1Route.Group("/orders")
+
+		// authenticated user
+		orderRoute.Use(r.authMiddleware)
+		{
+			orderRoute.GET("", r.v1Handler.GetAll)
+			orderRoute.GET("/:id", r.v1Handler.GetById)
+
+			// money-moving endpoint menghormati header Idempotency-Key
+			orderRoute.POST("", r.idempotencyMiddleware, r.v1Handler.Store)
+		}
+	}
+
+}
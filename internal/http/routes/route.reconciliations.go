@@ -0,0 +1,40 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+	V1Usecase "github.com/snykk/transaction-api/internal/business/usecases/v1"
+	V1PostgresRepository "github.com/snykk/transaction-api/internal/datasources/repositories/postgres/v1"
+	V1Handler "github.com/snykk/transaction-api/internal/http/handlers/v1"
+)
+
+type reconciliationRoutes struct {
+	v1Handler       V1Handler.ReconciliationHandler
+	router          *gin.RouterGroup
+	db              *sqlx.DB
+	authMiddleware  gin.HandlerFunc
+	adminMiddleware gin.HandlerFunc
+}
+
+func NewReconciliationRoute(router *gin.RouterGroup, db *sqlx.DB, authMiddleware gin.HandlerFunc, adminMiddleware gin.HandlerFunc) *reconciliationRoutes {
+	V1ReconciliationRepository := V1PostgresRepository.NewReconciliationRepository(db)
+	V1ReconciliationUsecase := V1Usecase.NewReconciliationUsecase(V1ReconciliationRepository)
+	V1ReconciliationHandler := V1Handler.NewReconciliationHandler(V1ReconciliationUsecase)
+
+	return &reconciliationRoutes{v1Handler: V1ReconciliationHandler, router: router, db: db, authMiddleware: authMiddleware, adminMiddleware: adminMiddleware}
+}
+
+func (r *reconciliationRoutes) Routes() {
+	// Routes V1
+	V1Route := r.router.Group("/v1")
+	{
+		reconciliationRoute := V1Route.Group("/reconciliations")
+
+		// admin only
+		reconciliationRoute.Use(r.authMiddleware, r.adminMiddleware)
+		{
+			reconciliationRoute.GET("/latest", r.v1Handler.Latest)
+		}
+	}
+
+}
@@ -0,0 +1,55 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+	V1Usecase "github.com/snykk/transaction-api/internal/business/usecases/v1"
+	"github.com/snykk/transaction-api/internal/datasources/caches"
+	V1PostgresRepository "github.com/snykk/transaction-api/internal/datasources/repositories/postgres/v1"
+	V1Handler "github.com/snykk/transaction-api/internal/http/handlers/v1"
+)
+
+type rewardRoutes struct {
+	v1Handler             V1Handler.RewardHandler
+	router                *gin.RouterGroup
+	db                    *sqlx.DB
+	authMiddleware        gin.HandlerFunc
+	adminMiddleware       gin.HandlerFunc
+	idempotencyMiddleware gin.HandlerFunc
+}
+
+func NewRewardRoute(router *gin.RouterGroup, db *sqlx.DB, ristrettoCache caches.RistrettoCache, authMiddleware gin.HandlerFunc, adminMiddleware gin.HandlerFunc, idempotencyMiddleware gin.HandlerFunc) *rewardRoutes {
+	V1RewardRepository := V1PostgresRepository.NewRewardRepository(db)
+	V1RewardUsecase := V1Usecase.NewRewardUsecase(V1RewardRepository)
+	V1RewardHandler := V1Handler.NewRewardHandler(V1RewardUsecase, ristrettoCache)
+
+	return &rewardRoutes{v1Handler: V1RewardHandler, router: router, db: db, authMiddleware: authMiddleware, adminMiddleware: adminMiddleware, idempotencyMiddleware: idempotencyMiddleware}
+}
+
+func (r *rewardRoutes) Routes() {
+	// Routes V1
+	V1Route := r.router.Group("/v1")
+	{
+		rewardRoute := V1Route.Group("/rewards")
+
+		// authenticated user
+		pointRoute := rewardRoute.Group("/points", r.authMiddleware)
+		{
+			pointRoute.GET("", r.v1Handler.GetPointEntries)
+
+			// money-moving endpoint menghormati header Idempotency-Key
+			pointRoute.POST("/convert", r.idempotencyMiddleware, r.v1Handler.ConvertPoints)
+		}
+
+		// admin only
+		ruleRoute := rewardRoute.Group("/rules", r.authMiddleware, r.adminMiddleware)
+		{
+			ruleRoute.GET("", r.v1Handler.GetAllRules)
+			ruleRoute.POST("", r.v1Handler.StoreRule)
+			ruleRoute.GET("/:id", r.v1Handler.GetRuleById)
+			ruleRoute.PUT("/:id", r.v1Handler.UpdateRule)
+			ruleRoute.DELETE("/:id", r.v1Handler.DeleteRule)
+		}
+	}
+
+}
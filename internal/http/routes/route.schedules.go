@@ -0,0 +1,41 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	V1Handler "github.com/snykk/transaction-api/internal/http/handlers/v1"
+)
+
+type scheduleRoutes struct {
+	v1Handler      V1Handler.ScheduleHandler
+	router         *gin.RouterGroup
+	db             *sqlx.DB
+	authMiddleware gin.HandlerFunc
+}
+
+// NewScheduleRoute memakai usecase yang sama dengan scheduler di server.NewApp
+func NewScheduleRoute(router *gin.RouterGroup, db *sqlx.DB, scheduleUsecase V1Domains.ScheduleUsecase, authMiddleware gin.HandlerFunc) *scheduleRoutes {
+	V1ScheduleHandler := V1Handler.NewScheduleHandler(scheduleUsecase)
+
+	return &scheduleRoutes{v1Handler: V1ScheduleHandler, router: router, db: db, authMiddleware: authMiddleware}
+}
+
+func (r *scheduleRoutes) Routes() {
+	// Routes V1
+	V1Route := r.router.Group("/v1")
+	{
+		scheduleRoute := V1Route.Group("/schedules")
+
+		// authenticated user
+		scheduleRoute.Use(r.authMiddleware)
+		{
+			scheduleRoute.POST("", r.v1Handler.Store)
+			scheduleRoute.GET("", r.v1Handler.GetAll)
+			scheduleRoute.GET("/:id", r.v1Handler.GetById)
+			scheduleRoute.PUT("/:id", r.v1Handler.Update)
+			scheduleRoute.DELETE("/:id", r.v1Handler.Cancel)
+		}
+	}
+
+}
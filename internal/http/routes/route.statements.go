@@ -0,0 +1,42 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+	V1Usecase "github.com/snykk/transaction-api/internal/business/usecases/v1"
+	"github.com/snykk/transaction-api/internal/config"
+	V1PostgresRepository "github.com/snykk/transaction-api/internal/datasources/repositories/postgres/v1"
+	V1Handler "github.com/snykk/transaction-api/internal/http/handlers/v1"
+)
+
+type statementRoutes struct {
+	v1Handler      V1Handler.StatementHandler
+	router         *gin.RouterGroup
+	db             *sqlx.DB
+	authMiddleware gin.HandlerFunc
+}
+
+func NewStatementRoute(router *gin.RouterGroup, db *sqlx.DB, authMiddleware gin.HandlerFunc) *statementRoutes {
+	V1StatementRepository := V1PostgresRepository.NewStatementRepository(db)
+	// email statement hanya dikirim oleh job di cmd/cron
+	V1StatementUsecase := V1Usecase.NewStatementUsecase(V1StatementRepository, nil, config.AppConfig.Currency)
+	V1StatementHandler := V1Handler.NewStatementHandler(V1StatementUsecase)
+
+	return &statementRoutes{v1Handler: V1StatementHandler, router: router, db: db, authMiddleware: authMiddleware}
+}
+
+func (r *statementRoutes) Routes() {
+	// Routes V1
+	V1Route := r.router.Group("/v1")
+	{
+		statementRoute := V1Route.Group("/statements")
+
+		// authenticated user
+		statementRoute.Use(r.authMiddleware)
+		{
+			statementRoute.GET("", r.v1Handler.GetAll)
+			statementRoute.GET("/:id", r.v1Handler.GetById)
+		}
+	}
+
+}
@@ -3,6 +3,7 @@ package routes
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/jmoiron/sqlx"
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
 	V1Usecase "github.com/snykk/transaction-api/internal/business/usecases/v1"
 	"github.com/snykk/transaction-api/internal/datasources/caches"
 	V1PostgresRepository "github.com/snykk/transaction-api/internal/datasources/repositories/postgres/v1"
@@ -10,20 +11,24 @@ import (
 )
 
 type transactionRoutes struct {
-	v1Handler       V1Handler.TransactionHandler
-	router          *gin.RouterGroup
-	db              *sqlx.DB
-	authMiddleware  gin.HandlerFunc
-	adminMiddleware gin.HandlerFunc
+	v1Handler             V1Handler.TransactionHandler
+	router                *gin.RouterGroup
+	db                    *sqlx.DB
+	authMiddleware        gin.HandlerFunc
+	adminMiddleware       gin.HandlerFunc
+	idempotencyMiddleware gin.HandlerFunc
 }
 
-func NewTransactionRoute(router *gin.RouterGroup, db *sqlx.DB, ristrettoCache caches.RistrettoCache, authMiddleware gin.HandlerFunc, adminMiddleware gin.HandlerFunc) *transactionRoutes {
-	V1TransactionRepository := V1PostgresRepository.NewTransactionRepository(db)
+func NewTransactionRoute(router *gin.RouterGroup, db *sqlx.DB, ristrettoCache caches.RistrettoCache, defaultLimits V1Domains.UserLimitDomain, authMiddleware gin.HandlerFunc, adminMiddleware gin.HandlerFunc, idempotencyMiddleware gin.HandlerFunc) *transactionRoutes {
+	V1TransactionRepository := V1PostgresRepository.NewTransactionRepository(db, defaultLimits)
 
-	V1TransactionUsecase := V1Usecase.NewTransactionUsecase(V1TransactionRepository)
+	V1FeeRuleRepository := V1PostgresRepository.NewFeeRuleRepository(db)
+	V1ProductRepository := V1PostgresRepository.NewProductRepository(db)
+
+	V1TransactionUsecase := V1Usecase.NewTransactionUsecase(V1TransactionRepository, V1FeeRuleRepository, V1ProductRepository)
 	V1TransactionHandler := V1Handler.NewTransactionHandler(V1TransactionUsecase, ristrettoCache)
 
-	return &transactionRoutes{v1Handler: V1TransactionHandler, router: router, db: db, authMiddleware: authMiddleware, adminMiddleware: adminMiddleware}
+	return &transactionRoutes{v1Handler: V1TransactionHandler, router: router, db: db, authMiddleware: authMiddleware, adminMiddleware: adminMiddleware, idempotencyMiddleware: idempotencyMiddleware}
 }
 
 func (r *transactionRoutes) Routes() {
@@ -36,10 +41,14 @@ func (r *transactionRoutes) Routes() {
 		transactionRoute.Use(r.authMiddleware)
 		{
 			transactionRoute.GET("/history", r.v1Handler.History)
+			transactionRoute.GET("/statement", r.v1Handler.Statement)
+			transactionRoute.GET("/:id", r.v1Handler.GetById)
 
-			transactionRoute.POST("/deposit", r.v1Handler.Deposit)
-			transactionRoute.POST("/withdraw", r.v1Handler.Withdraw)
-			transactionRoute.POST("/purchase", r.v1Handler.Purchase)
+			// money-moving endpoint menghormati header Idempotency-Key
+			transactionRoute.POST("/deposit", r.idempotencyMiddleware, r.v1Handler.Deposit)
+			transactionRoute.POST("/withdraw", r.idempotencyMiddleware, r.v1Handler.Withdraw)
+			transactionRoute.POST("/purchase", r.idempotencyMiddleware, r.v1Handler.Purchase)
+			transactionRoute.POST("/transfer", r.idempotencyMiddleware, r.v1Handler.Transfer)
 		}
 
 		// admin only
@@ -47,6 +56,8 @@ func (r *transactionRoutes) Routes() {
 		{
 			// admin only
 			transactionRoute.GET("", r.v1Handler.GetAll)
+			transactionRoute.PUT("/:id/status", r.v1Handler.UpdateStatus)
+			transactionRoute.POST("/:id/refund", r.idempotencyMiddleware, r.v1Handler.Refund)
 			// ...
 		}
 	}
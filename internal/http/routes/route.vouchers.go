@@ -0,0 +1,44 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+	V1Usecase "github.com/snykk/transaction-api/internal/business/usecases/v1"
+	V1PostgresRepository "github.com/snykk/transaction-api/internal/datasources/repositories/postgres/v1"
+	V1Handler "github.com/snykk/transaction-api/internal/http/handlers/v1"
+)
+
+type voucherRoutes struct {
+	v1Handler       V1Handler.VoucherHandler
+	router          *gin.RouterGroup
+	db              *sqlx.DB
+	authMiddleware  gin.HandlerFunc
+	adminMiddleware gin.HandlerFunc
+}
+
+func NewVoucherRoute(router *gin.RouterGroup, db *sqlx.DB, authMiddleware gin.HandlerFunc, adminMiddleware gin.HandlerFunc) *voucherRoutes {
+	V1VoucherRepository := V1PostgresRepository.NewVoucherRepository(db)
+	V1VoucherUsecase := V1Usecase.NewVoucherUsecase(V1VoucherRepository)
+	V1VoucherHandler := V1Handler.NewVoucherHandler(V1VoucherUsecase)
+
+	return &voucherRoutes{v1Handler: V1VoucherHandler, router: router, db: db, authMiddleware: authMiddleware, adminMiddleware: adminMiddleware}
+}
+
+func (r *voucherRoutes) Routes() {
+	// Routes V1
+	V1Route := r.router.Group("/v1")
+	{
+		voucherRoute := V1Route.Group("/vouchers")
+
+		// admin only
+		voucherRoute.Use(r.authMiddleware, r.adminMiddleware)
+		{
+			voucherRoute.GET("", r.v1Handler.GetAll)
+			voucherRoute.POST("", r.v1Handler.Store)
+			voucherRoute.GET("/:id", r.v1Handler.GetById)
+			voucherRoute.PUT("/:id", r.v1Handler.Update)
+			voucherRoute.DELETE("/:id", r.v1Handler.Delete)
+		}
+	}
+
+}
@@ -1,28 +1,33 @@
 package routes
 
 import (
+	"time"
+
 	"github.com/gin-gonic/gin"
 	"github.com/jmoiron/sqlx"
 	V1Usecase "github.com/snykk/transaction-api/internal/business/usecases/v1"
 	"github.com/snykk/transaction-api/internal/datasources/caches"
 	V1PostgresRepository "github.com/snykk/transaction-api/internal/datasources/repositories/postgres/v1"
+	"github.com/snykk/transaction-api/internal/datasources/streams"
 	V1Handler "github.com/snykk/transaction-api/internal/http/handlers/v1"
 )
 
 type walletRoutes struct {
 	v1Handler       V1Handler.WalletHandler
+	v1StreamHandler V1Handler.WalletStreamHandler
 	router          *gin.RouterGroup
 	db              *sqlx.DB
 	authMiddleware  gin.HandlerFunc
 	adminMiddleware gin.HandlerFunc
 }
 
-func NewWalletRoute(router *gin.RouterGroup, db *sqlx.DB, ristrettoCache caches.RistrettoCache, authMiddleware gin.HandlerFunc, adminMiddleware gin.HandlerFunc) *walletRoutes {
+func NewWalletRoute(router *gin.RouterGroup, db *sqlx.DB, ristrettoCache caches.RistrettoCache, walletStream streams.WalletStream, streamHeartbeat time.Duration, authMiddleware gin.HandlerFunc, adminMiddleware gin.HandlerFunc) *walletRoutes {
 	V1WalletRepository := V1PostgresRepository.NewWalletRepository(db)
 	V1WalletUsecase := V1Usecase.NewWalletUsecase(V1WalletRepository)
 	V1WalletHandler := V1Handler.NewWalletHandler(V1WalletUsecase, ristrettoCache)
+	V1WalletStreamHandler := V1Handler.NewWalletStreamHandler(V1WalletUsecase, walletStream, streamHeartbeat)
 
-	return &walletRoutes{v1Handler: V1WalletHandler, router: router, db: db, authMiddleware: authMiddleware, adminMiddleware: adminMiddleware}
+	return &walletRoutes{v1Handler: V1WalletHandler, v1StreamHandler: V1WalletStreamHandler, router: router, db: db, authMiddleware: authMiddleware, adminMiddleware: adminMiddleware}
 }
 
 func (r *walletRoutes) Routes() {
@@ -36,6 +41,13 @@ func (r *walletRoutes) Routes() {
 		{
 			walletRoute.POST("/init", r.v1Handler.Init)
 			walletRoute.GET("/info", r.v1Handler.Info)
+			walletRoute.POST("", r.v1Handler.Create)
+			walletRoute.GET("/mine", r.v1Handler.Mine)
+			walletRoute.PUT("/:id/default", r.v1Handler.SetDefault)
+
+			// saldo dan transaksi real-time, SSE atau websocket
+			walletRoute.GET("/stream", r.v1StreamHandler.Stream)
+			walletRoute.GET("/stream/ws", r.v1StreamHandler.StreamWebSocket)
 		}
 
 		// admin only
@@ -43,7 +55,9 @@ func (r *walletRoutes) Routes() {
 		{
 			// admin only
 			walletRoute.GET("", r.v1Handler.GetAll)
-			// ...
+			walletRoute.POST("/:id/freeze", r.v1Handler.Freeze)
+			walletRoute.POST("/:id/unfreeze", r.v1Handler.Unfreeze)
+			walletRoute.GET("/:id/status-events", r.v1Handler.StatusEvents)
 		}
 	}
 
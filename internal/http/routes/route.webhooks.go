@@ -0,0 +1,43 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	V1Handler "github.com/snykk/transaction-api/internal/http/handlers/v1"
+)
+
+type webhookRoutes struct {
+	v1Handler      V1Handler.WebhookHandler
+	router         *gin.RouterGroup
+	db             *sqlx.DB
+	authMiddleware gin.HandlerFunc
+}
+
+// NewWebhookRoute memakai usecase yang sama dengan subscriber outbox dan dispatcher di server.NewApp
+func NewWebhookRoute(router *gin.RouterGroup, db *sqlx.DB, webhookUsecase V1Domains.WebhookUsecase, authMiddleware gin.HandlerFunc) *webhookRoutes {
+	V1WebhookHandler := V1Handler.NewWebhookHandler(webhookUsecase)
+
+	return &webhookRoutes{v1Handler: V1WebhookHandler, router: router, db: db, authMiddleware: authMiddleware}
+}
+
+func (r *webhookRoutes) Routes() {
+	// Routes V1
+	V1Route := r.router.Group("/v1")
+	{
+		// authenticated user, setiap user hanya melihat endpoint miliknya
+		webhookRoute := V1Route.Group("/webhooks", r.authMiddleware)
+		{
+			webhookRoute.GET("", r.v1Handler.GetAll)
+			webhookRoute.POST("", r.v1Handler.Store)
+			webhookRoute.GET("/:id", r.v1Handler.GetById)
+			webhookRoute.PUT("/:id", r.v1Handler.Update)
+			webhookRoute.DELETE("/:id", r.v1Handler.Delete)
+			webhookRoute.POST("/:id/test", r.v1Handler.Test)
+			webhookRoute.GET("/:id/deliveries", r.v1Handler.GetDeliveries)
+			webhookRoute.GET("/:id/deliveries/:delivery_id", r.v1Handler.GetDeliveryById)
+			webhookRoute.POST("/:id/deliveries/:delivery_id/redeliver", r.v1Handler.Redeliver)
+		}
+	}
+
+}
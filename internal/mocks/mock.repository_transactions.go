@@ -42,29 +42,27 @@ func (_m *TransactionRepository) Deposit(ctx context.Context, transactionDom v1.
 	return r0, r1
 }
 
-// GetAll provides a mock function with given fields: ctx
-func (_m *TransactionRepository) GetAll(ctx context.Context) ([]v1.TransactionDomain, error) {
-	ret := _m.Called(ctx)
+// GetAll provides a mock function with given fields: ctx, filter
+func (_m *TransactionRepository) GetAll(ctx context.Context, filter v1.TransactionFilterDomain) (v1.TransactionPageDomain, error) {
+	ret := _m.Called(ctx, filter)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAll")
 	}
 
-	var r0 []v1.TransactionDomain
+	var r0 v1.TransactionPageDomain
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]v1.TransactionDomain, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, v1.TransactionFilterDomain) (v1.TransactionPageDomain, error)); ok {
+		return rf(ctx, filter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []v1.TransactionDomain); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, v1.TransactionFilterDomain) v1.TransactionPageDomain); ok {
+		r0 = rf(ctx, filter)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]v1.TransactionDomain)
-		}
+		r0 = ret.Get(0).(v1.TransactionPageDomain)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, v1.TransactionFilterDomain) error); ok {
+		r1 = rf(ctx, filter)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -72,29 +70,55 @@ func (_m *TransactionRepository) GetAll(ctx context.Context) ([]v1.TransactionDo
 	return r0, r1
 }
 
-// GetByUserId provides a mock function with given fields: ctx, userId
-func (_m *TransactionRepository) GetByUserId(ctx context.Context, userId string) ([]v1.TransactionDomain, error) {
-	ret := _m.Called(ctx, userId)
+// GetById provides a mock function with given fields: ctx, transactionId, userId
+func (_m *TransactionRepository) GetById(ctx context.Context, transactionId string, userId string) (v1.TransactionDomain, error) {
+	ret := _m.Called(ctx, transactionId, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetById")
+	}
+
+	var r0 v1.TransactionDomain
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (v1.TransactionDomain, error)); ok {
+		return rf(ctx, transactionId, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) v1.TransactionDomain); ok {
+		r0 = rf(ctx, transactionId, userId)
+	} else {
+		r0 = ret.Get(0).(v1.TransactionDomain)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, transactionId, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByUserId provides a mock function with given fields: ctx, userId, filter
+func (_m *TransactionRepository) GetByUserId(ctx context.Context, userId string, filter v1.TransactionFilterDomain) (v1.TransactionPageDomain, error) {
+	ret := _m.Called(ctx, userId, filter)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetByUserId")
 	}
 
-	var r0 []v1.TransactionDomain
+	var r0 v1.TransactionPageDomain
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) ([]v1.TransactionDomain, error)); ok {
-		return rf(ctx, userId)
+	if rf, ok := ret.Get(0).(func(context.Context, string, v1.TransactionFilterDomain) (v1.TransactionPageDomain, error)); ok {
+		return rf(ctx, userId, filter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) []v1.TransactionDomain); ok {
-		r0 = rf(ctx, userId)
+	if rf, ok := ret.Get(0).(func(context.Context, string, v1.TransactionFilterDomain) v1.TransactionPageDomain); ok {
+		r0 = rf(ctx, userId, filter)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]v1.TransactionDomain)
-		}
+		r0 = ret.Get(0).(v1.TransactionPageDomain)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, userId)
+	if rf, ok := ret.Get(1).(func(context.Context, string, v1.TransactionFilterDomain) error); ok {
+		r1 = rf(ctx, userId, filter)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -130,6 +154,108 @@ func (_m *TransactionRepository) Purchase(ctx context.Context, trasanctionDom v1
 	return r0, r1
 }
 
+// Refund provides a mock function with given fields: ctx, transactionId, quantity
+func (_m *TransactionRepository) Refund(ctx context.Context, transactionId string, quantity int) (v1.TransactionDomain, error) {
+	ret := _m.Called(ctx, transactionId, quantity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Refund")
+	}
+
+	var r0 v1.TransactionDomain
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) (v1.TransactionDomain, error)); ok {
+		return rf(ctx, transactionId, quantity)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) v1.TransactionDomain); ok {
+		r0 = rf(ctx, transactionId, quantity)
+	} else {
+		r0 = ret.Get(0).(v1.TransactionDomain)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, transactionId, quantity)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StreamStatement provides a mock function with given fields: ctx, statementDom, onOpening, onEntry
+func (_m *TransactionRepository) StreamStatement(ctx context.Context, statementDom v1.StatementDomain, onOpening func(v1.StatementDomain) error, onEntry func(v1.StatementEntryDomain) error) error {
+	ret := _m.Called(ctx, statementDom, onOpening, onEntry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamStatement")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, v1.StatementDomain, func(v1.StatementDomain) error, func(v1.StatementEntryDomain) error) error); ok {
+		r0 = rf(ctx, statementDom, onOpening, onEntry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Transfer provides a mock function with given fields: ctx, transferDom
+func (_m *TransactionRepository) Transfer(ctx context.Context, transferDom v1.TransferDomain) (v1.TransferDomain, error) {
+	ret := _m.Called(ctx, transferDom)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Transfer")
+	}
+
+	var r0 v1.TransferDomain
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, v1.TransferDomain) (v1.TransferDomain, error)); ok {
+		return rf(ctx, transferDom)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, v1.TransferDomain) v1.TransferDomain); ok {
+		r0 = rf(ctx, transferDom)
+	} else {
+		r0 = ret.Get(0).(v1.TransferDomain)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, v1.TransferDomain) error); ok {
+		r1 = rf(ctx, transferDom)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateStatus provides a mock function with given fields: ctx, transactionId, status, reason
+func (_m *TransactionRepository) UpdateStatus(ctx context.Context, transactionId string, status string, reason string) (v1.TransactionDomain, error) {
+	ret := _m.Called(ctx, transactionId, status, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateStatus")
+	}
+
+	var r0 v1.TransactionDomain
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (v1.TransactionDomain, error)); ok {
+		return rf(ctx, transactionId, status, reason)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) v1.TransactionDomain); ok {
+		r0 = rf(ctx, transactionId, status, reason)
+	} else {
+		r0 = ret.Get(0).(v1.TransactionDomain)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, transactionId, status, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Withdraw provides a mock function with given fields: ctx, transactionDom
 func (_m *TransactionRepository) Withdraw(ctx context.Context, transactionDom v1.TransactionDomain) (v1.TransactionDomain, error) {
 	ret := _m.Called(ctx, transactionDom)
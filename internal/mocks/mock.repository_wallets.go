@@ -6,6 +6,8 @@ import (
 	context "context"
 
 	v1 "github.com/snykk/transaction-api/internal/business/domains/v1"
+	money "github.com/snykk/transaction-api/pkg/money"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -14,6 +16,34 @@ type WalletRepository struct {
 	mock.Mock
 }
 
+// CreateWallet provides a mock function with given fields: ctx, userId, name
+func (_m *WalletRepository) CreateWallet(ctx context.Context, userId string, name string) (v1.WalletDomain, error) {
+	ret := _m.Called(ctx, userId, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWallet")
+	}
+
+	var r0 v1.WalletDomain
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (v1.WalletDomain, error)); ok {
+		return rf(ctx, userId, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) v1.WalletDomain); ok {
+		r0 = rf(ctx, userId, name)
+	} else {
+		r0 = ret.Get(0).(v1.WalletDomain)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userId, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateWalletByUserId provides a mock function with given fields: ctx, userId
 func (_m *WalletRepository) CreateWalletByUserId(ctx context.Context, userId string) (v1.WalletDomain, error) {
 	ret := _m.Called(ctx, userId)
@@ -72,6 +102,64 @@ func (_m *WalletRepository) GetAllWallets(ctx context.Context) ([]v1.WalletDomai
 	return r0, r1
 }
 
+// GetLedgerBalance provides a mock function with given fields: ctx, walletId
+func (_m *WalletRepository) GetLedgerBalance(ctx context.Context, walletId string) (money.Money, error) {
+	ret := _m.Called(ctx, walletId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLedgerBalance")
+	}
+
+	var r0 money.Money
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (money.Money, error)); ok {
+		return rf(ctx, walletId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) money.Money); ok {
+		r0 = rf(ctx, walletId)
+	} else {
+		r0 = ret.Get(0).(money.Money)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, walletId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStatusEvents provides a mock function with given fields: ctx, walletId
+func (_m *WalletRepository) GetStatusEvents(ctx context.Context, walletId string) ([]v1.WalletStatusEventDomain, error) {
+	ret := _m.Called(ctx, walletId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStatusEvents")
+	}
+
+	var r0 []v1.WalletStatusEventDomain
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]v1.WalletStatusEventDomain, error)); ok {
+		return rf(ctx, walletId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []v1.WalletStatusEventDomain); ok {
+		r0 = rf(ctx, walletId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]v1.WalletStatusEventDomain)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, walletId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetWalletByUserId provides a mock function with given fields: ctx, userId
 func (_m *WalletRepository) GetWalletByUserId(ctx context.Context, userId string) (v1.WalletDomain, error) {
 	ret := _m.Called(ctx, userId)
@@ -100,6 +188,92 @@ func (_m *WalletRepository) GetWalletByUserId(ctx context.Context, userId string
 	return r0, r1
 }
 
+// GetWalletsByUserId provides a mock function with given fields: ctx, userId
+func (_m *WalletRepository) GetWalletsByUserId(ctx context.Context, userId string) ([]v1.WalletDomain, error) {
+	ret := _m.Called(ctx, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWalletsByUserId")
+	}
+
+	var r0 []v1.WalletDomain
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]v1.WalletDomain, error)); ok {
+		return rf(ctx, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []v1.WalletDomain); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]v1.WalletDomain)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetDefault provides a mock function with given fields: ctx, userId, walletId
+func (_m *WalletRepository) SetDefault(ctx context.Context, userId string, walletId string) (v1.WalletDomain, error) {
+	ret := _m.Called(ctx, userId, walletId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetDefault")
+	}
+
+	var r0 v1.WalletDomain
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (v1.WalletDomain, error)); ok {
+		return rf(ctx, userId, walletId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) v1.WalletDomain); ok {
+		r0 = rf(ctx, userId, walletId)
+	} else {
+		r0 = ret.Get(0).(v1.WalletDomain)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userId, walletId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateStatus provides a mock function with given fields: ctx, eventDom
+func (_m *WalletRepository) UpdateStatus(ctx context.Context, eventDom v1.WalletStatusEventDomain) (v1.WalletDomain, error) {
+	ret := _m.Called(ctx, eventDom)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateStatus")
+	}
+
+	var r0 v1.WalletDomain
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, v1.WalletStatusEventDomain) (v1.WalletDomain, error)); ok {
+		return rf(ctx, eventDom)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, v1.WalletStatusEventDomain) v1.WalletDomain); ok {
+		r0 = rf(ctx, eventDom)
+	} else {
+		r0 = ret.Get(0).(v1.WalletDomain)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, v1.WalletStatusEventDomain) error); ok {
+		r1 = rf(ctx, eventDom)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewWalletRepository creates a new instance of WalletRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewWalletRepository(t interface {
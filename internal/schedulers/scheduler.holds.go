@@ -0,0 +1,88 @@
+package schedulers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+	"github.com/snykk/transaction-api/internal/datasources/caches"
+	"github.com/snykk/transaction-api/pkg/logger"
+)
+
+// HoldExpiryScheduler melepas dana hold yang sudah kedaluwarsa secara berkala di dalam proses API.
+// Aman dijalankan di beberapa instance sekaligus karena setiap hold dikunci dengan FOR UPDATE SKIP LOCKED.
+type HoldExpiryScheduler struct {
+	holdUsecase    V1Domains.HoldUsecase
+	ristrettoCache caches.RistrettoCache
+	interval       time.Duration
+	cancel         context.CancelFunc
+	done           chan struct{}
+}
+
+func NewHoldExpiryScheduler(holdUsecase V1Domains.HoldUsecase, ristrettoCache caches.RistrettoCache, interval time.Duration) *HoldExpiryScheduler {
+	return &HoldExpiryScheduler{
+		holdUsecase:    holdUsecase,
+		ristrettoCache: ristrettoCache,
+		interval:       interval,
+	}
+}
+
+// Start menjalankan sweeper di goroutine terpisah sampai Stop dipanggil
+func (s *HoldExpiryScheduler) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.done = make(chan struct{})
+
+	go func() {
+		defer close(s.done)
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		logger.InfoF("hold expiry scheduler started, polling every %s", logrus.Fields{constants.LoggerCategory: constants.LoggerCategoryScheduler}, s.interval)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.tick(ctx)
+			}
+		}
+	}()
+}
+
+// Stop menghentikan sweeper dan menunggu batch yang sedang berjalan selesai
+func (s *HoldExpiryScheduler) Stop() {
+	if s.cancel == nil {
+		return
+	}
+
+	s.cancel()
+	<-s.done
+	logger.Info("hold expiry scheduler stopped", logrus.Fields{constants.LoggerCategory: constants.LoggerCategoryScheduler})
+}
+
+func (s *HoldExpiryScheduler) tick(ctx context.Context) {
+	for ctx.Err() == nil {
+		expired, err := s.holdUsecase.ExpireDue(ctx, time.Now())
+		if err != nil {
+			logger.ErrorF("failed to expire holds: %v", logrus.Fields{constants.LoggerCategory: constants.LoggerCategoryScheduler}, err)
+			return
+		}
+
+		for _, hold := range expired {
+			logger.InfoF("hold %s expired, %s released", logrus.Fields{constants.LoggerCategory: constants.LoggerCategoryScheduler}, hold.Id, hold.HeldAmount())
+
+			// saldo tersedia wallet berubah
+			s.ristrettoCache.Del("wallets", fmt.Sprintf("wallet/wallet_id:%s", hold.WalletId), fmt.Sprintf("wallet/user_id:%s", hold.UserId))
+		}
+
+		// Batch penuh berarti masih mungkin ada hold lain yang kedaluwarsa
+		if len(expired) < constants.HoldExpiryBatchSize {
+			return
+		}
+	}
+}
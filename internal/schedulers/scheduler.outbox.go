@@ -0,0 +1,80 @@
+package schedulers
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+	"github.com/snykk/transaction-api/pkg/logger"
+)
+
+// OutboxRelay meneruskan event outbox yang masih pending ke publisher secara berkala.
+// Aman dijalankan di beberapa instance sekaligus karena event diklaim dengan lease dan FOR UPDATE SKIP LOCKED.
+type OutboxRelay struct {
+	outboxUsecase V1Domains.OutboxUsecase
+	interval      time.Duration
+	cancel        context.CancelFunc
+	done          chan struct{}
+}
+
+func NewOutboxRelay(outboxUsecase V1Domains.OutboxUsecase, interval time.Duration) *OutboxRelay {
+	return &OutboxRelay{
+		outboxUsecase: outboxUsecase,
+		interval:      interval,
+	}
+}
+
+// Start menjalankan relay di goroutine terpisah sampai Stop dipanggil
+func (s *OutboxRelay) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.done = make(chan struct{})
+
+	go func() {
+		defer close(s.done)
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		logger.InfoF("outbox relay started, polling every %s", logrus.Fields{constants.LoggerCategory: constants.LoggerCategoryScheduler}, s.interval)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.tick(ctx)
+			}
+		}
+	}()
+}
+
+// Stop menghentikan relay dan menunggu batch yang sedang berjalan selesai
+func (s *OutboxRelay) Stop() {
+	if s.cancel == nil {
+		return
+	}
+
+	s.cancel()
+	<-s.done
+	logger.Info("outbox relay stopped", logrus.Fields{constants.LoggerCategory: constants.LoggerCategoryScheduler})
+}
+
+func (s *OutboxRelay) tick(ctx context.Context) {
+	for ctx.Err() == nil {
+		sent, failed, err := s.outboxUsecase.Relay(ctx, time.Now())
+		if err != nil {
+			logger.ErrorF("failed to relay outbox events: %v", logrus.Fields{constants.LoggerCategory: constants.LoggerCategoryScheduler}, err)
+			return
+		}
+		if failed > 0 {
+			logger.ErrorF("%d outbox events failed to publish and will be retried", logrus.Fields{constants.LoggerCategory: constants.LoggerCategoryScheduler}, failed)
+		}
+
+		// Batch penuh berarti masih mungkin ada event lain yang pending
+		if sent+failed < constants.OutboxRelayBatchSize {
+			return
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package schedulers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+	"github.com/snykk/transaction-api/internal/datasources/caches"
+	"github.com/snykk/transaction-api/pkg/logger"
+)
+
+// TransferScheduler mengeksekusi transfer terjadwal yang jatuh tempo secara berkala di dalam proses API.
+// Aman dijalankan di beberapa instance sekaligus karena setiap schedule dikunci dengan FOR UPDATE SKIP LOCKED.
+type TransferScheduler struct {
+	scheduleUsecase V1Domains.ScheduleUsecase
+	ristrettoCache  caches.RistrettoCache
+	interval        time.Duration
+	cancel          context.CancelFunc
+	done            chan struct{}
+}
+
+func NewTransferScheduler(scheduleUsecase V1Domains.ScheduleUsecase, ristrettoCache caches.RistrettoCache, interval time.Duration) *TransferScheduler {
+	return &TransferScheduler{
+		scheduleUsecase: scheduleUsecase,
+		ristrettoCache:  ristrettoCache,
+		interval:        interval,
+	}
+}
+
+// Start menjalankan scheduler di goroutine terpisah sampai Stop dipanggil
+func (s *TransferScheduler) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.done = make(chan struct{})
+
+	go func() {
+		defer close(s.done)
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		logger.InfoF("transfer scheduler started, polling every %s", logrus.Fields{constants.LoggerCategory: constants.LoggerCategoryScheduler}, s.interval)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.tick(ctx)
+			}
+		}
+	}()
+}
+
+// Stop menghentikan scheduler dan menunggu eksekusi yang sedang berjalan selesai
+func (s *TransferScheduler) Stop() {
+	if s.cancel == nil {
+		return
+	}
+
+	s.cancel()
+	<-s.done
+	logger.Info("transfer scheduler stopped", logrus.Fields{constants.LoggerCategory: constants.LoggerCategoryScheduler})
+}
+
+func (s *TransferScheduler) tick(ctx context.Context) {
+	for {
+		executed, err := s.scheduleUsecase.RunDue(ctx, time.Now(), s.onRun)
+		if err != nil {
+			logger.ErrorF("failed to run scheduled transfers: %v", logrus.Fields{constants.LoggerCategory: constants.LoggerCategoryScheduler}, err)
+			return
+		}
+
+		// Batch penuh berarti masih mungkin ada schedule lain yang jatuh tempo
+		if executed < constants.ScheduleBatchSize {
+			return
+		}
+	}
+}
+
+func (s *TransferScheduler) onRun(run V1Domains.ScheduleRunDomain) {
+	fields := logrus.Fields{constants.LoggerCategory: constants.LoggerCategoryScheduler, "schedule_id": run.ScheduleId}
+
+	if run.Status != constants.ScheduleRunStatusSucceeded {
+		logger.ErrorF("scheduled transfer occurrence %d attempt %d failed: %s", fields, run.Occurrence, run.Attempt, *run.Error)
+		return
+	}
+	logger.InfoF("scheduled transfer occurrence %d executed", fields, run.Occurrence)
+
+	// invalidate cache milik pengirim dan penerima
+	s.ristrettoCache.Del("transactions")
+	s.ristrettoCache.Del("wallets", fmt.Sprintf("wallet/user_id:%s", run.UserId), fmt.Sprintf("wallet/user_id:%s", run.RecipientUserId))
+	s.ristrettoCache.Del(fmt.Sprintf("transaction_history/user_id:%s", run.UserId), fmt.Sprintf("transaction_history/user_id:%s", run.RecipientUserId))
+}
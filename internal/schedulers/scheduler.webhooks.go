@@ -0,0 +1,80 @@
+package schedulers
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	V1Domains "github.com/snykk/transaction-api/internal/business/domains/v1"
+	"github.com/snykk/transaction-api/internal/constants"
+	"github.com/snykk/transaction-api/pkg/logger"
+)
+
+// WebhookDispatcher mengirim pengiriman webhook yang sudah waktunya secara berkala.
+// Aman dijalankan di beberapa instance sekaligus karena pengiriman diklaim dengan lease dan FOR UPDATE SKIP LOCKED.
+type WebhookDispatcher struct {
+	webhookUsecase V1Domains.WebhookUsecase
+	interval       time.Duration
+	cancel         context.CancelFunc
+	done           chan struct{}
+}
+
+func NewWebhookDispatcher(webhookUsecase V1Domains.WebhookUsecase, interval time.Duration) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		webhookUsecase: webhookUsecase,
+		interval:       interval,
+	}
+}
+
+// Start menjalankan dispatcher di goroutine terpisah sampai Stop dipanggil
+func (s *WebhookDispatcher) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.done = make(chan struct{})
+
+	go func() {
+		defer close(s.done)
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		logger.InfoF("webhook dispatcher started, polling every %s", logrus.Fields{constants.LoggerCategory: constants.LoggerCategoryScheduler}, s.interval)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.tick(ctx)
+			}
+		}
+	}()
+}
+
+// Stop menghentikan dispatcher dan menunggu batch yang sedang berjalan selesai
+func (s *WebhookDispatcher) Stop() {
+	if s.cancel == nil {
+		return
+	}
+
+	s.cancel()
+	<-s.done
+	logger.Info("webhook dispatcher stopped", logrus.Fields{constants.LoggerCategory: constants.LoggerCategoryScheduler})
+}
+
+func (s *WebhookDispatcher) tick(ctx context.Context) {
+	for ctx.Err() == nil {
+		delivered, failed, err := s.webhookUsecase.DispatchDue(ctx, time.Now())
+		if err != nil {
+			logger.ErrorF("failed to dispatch webhooks: %v", logrus.Fields{constants.LoggerCategory: constants.LoggerCategoryScheduler}, err)
+			return
+		}
+		if failed > 0 {
+			logger.ErrorF("%d webhook deliveries failed and were rescheduled or marked dead", logrus.Fields{constants.LoggerCategory: constants.LoggerCategoryScheduler}, failed)
+		}
+
+		// Batch penuh berarti masih mungkin ada pengiriman lain yang sudah waktunya
+		if delivered+failed < constants.WebhookDispatchBatchSize {
+			return
+		}
+	}
+}
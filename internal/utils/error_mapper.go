@@ -6,15 +6,12 @@ import (
 	"net/http"
 
 	postgresRepo "github.com/snykk/transaction-api/internal/datasources/repositories/postgres/v1"
-
-	"github.com/jackc/pgconn"
 )
 
 func MapDBError(err error) (int, error) {
-	// Periksa apakah error berasal dari database PostgreSQL
-	var pgErr *pgconn.PgError
-	if errors.As(err, &pgErr) {
-		switch pgErr.Code {
+	// Periksa apakah error berasal dari database PostgreSQL, baik lewat driver lib/pq maupun pgconn
+	if code := postgresRepo.SQLState(err); code != "" {
+		switch code {
 		case "42P01":
 			return http.StatusInternalServerError, errors.New("database error: table does not exist")
 		case "42703":
@@ -23,6 +20,8 @@ func MapDBError(err error) (int, error) {
 			return http.StatusConflict, errors.New("database error: unique constraint violation")
 		case "23503":
 			return http.StatusBadRequest, errors.New("database error: foreign key violation")
+		case "40001":
+			return http.StatusConflict, errors.New("database error: concurrent update conflict, please retry")
 		case "40P01":
 			return http.StatusConflict, errors.New("database error: deadlock detected")
 		case "57014":
@@ -40,10 +39,177 @@ func MapDBError(err error) (int, error) {
 		return http.StatusUnprocessableEntity, postgresRepo.ErrInsufficientBalance
 	}
 
+	// Limit transaksi user, pesan error menyebut limit yang terlampaui dan waktu reset-nya
+	var limitErr *postgresRepo.LimitExceededError
+	if errors.As(err, &limitErr) {
+		return http.StatusUnprocessableEntity, limitErr
+	}
+
 	if errors.Is(err, postgresRepo.ErrProductNotFound) {
 		return http.StatusNotFound, postgresRepo.ErrProductNotFound
 	}
 
+	// Error custom untuk transfer antar wallet
+	if errors.Is(err, postgresRepo.ErrRecipientNotFound) {
+		return http.StatusNotFound, postgresRepo.ErrRecipientNotFound
+	}
+	if errors.Is(err, postgresRepo.ErrRecipientWalletNotFound) {
+		return http.StatusNotFound, postgresRepo.ErrRecipientWalletNotFound
+	}
+	if errors.Is(err, postgresRepo.ErrSelfTransfer) {
+		return http.StatusBadRequest, postgresRepo.ErrSelfTransfer
+	}
+	if errors.Is(err, postgresRepo.ErrSameWalletTransfer) {
+		return http.StatusBadRequest, postgresRepo.ErrSameWalletTransfer
+	}
+
+	// Error custom untuk perubahan status transaksi
+	if errors.Is(err, postgresRepo.ErrTransactionNotFound) {
+		return http.StatusNotFound, postgresRepo.ErrTransactionNotFound
+	}
+	if errors.Is(err, postgresRepo.ErrInvalidStatusTransition) {
+		return http.StatusConflict, postgresRepo.ErrInvalidStatusTransition
+	}
+
+	// Error custom untuk refund pembelian
+	if errors.Is(err, postgresRepo.ErrTransactionNotRefundable) {
+		return http.StatusUnprocessableEntity, postgresRepo.ErrTransactionNotRefundable
+	}
+	if errors.Is(err, postgresRepo.ErrRefundQuantityExceeded) {
+		return http.StatusUnprocessableEntity, postgresRepo.ErrRefundQuantityExceeded
+	}
+	if errors.Is(err, postgresRepo.ErrTransactionAlreadyRefunded) {
+		return http.StatusConflict, postgresRepo.ErrTransactionAlreadyRefunded
+	}
+	if errors.Is(err, postgresRepo.ErrRefundAmountTooSmall) {
+		return http.StatusUnprocessableEntity, postgresRepo.ErrRefundAmountTooSmall
+	}
+	if errors.Is(err, postgresRepo.ErrMultiItemOrderNotRefundable) {
+		return http.StatusUnprocessableEntity, postgresRepo.ErrMultiItemOrderNotRefundable
+	}
+
+	// Error custom untuk statement bulanan
+	if errors.Is(err, postgresRepo.ErrStatementNotFound) {
+		return http.StatusNotFound, postgresRepo.ErrStatementNotFound
+	}
+	if errors.Is(err, postgresRepo.ErrStatementAlreadyExists) {
+		return http.StatusConflict, postgresRepo.ErrStatementAlreadyExists
+	}
+
+	// Error custom untuk transfer terjadwal
+	if errors.Is(err, postgresRepo.ErrScheduleNotFound) {
+		return http.StatusNotFound, postgresRepo.ErrScheduleNotFound
+	}
+
+	// Error custom untuk limit transaksi
+	if errors.Is(err, postgresRepo.ErrUserNotFound) {
+		return http.StatusNotFound, postgresRepo.ErrUserNotFound
+	}
+
+	// Error custom untuk fee transaksi
+	if errors.Is(err, postgresRepo.ErrFeeRuleNotFound) {
+		return http.StatusNotFound, postgresRepo.ErrFeeRuleNotFound
+	}
+	if errors.Is(err, postgresRepo.ErrProductPriceChanged) {
+		return http.StatusConflict, postgresRepo.ErrProductPriceChanged
+	}
+
+	// Error custom untuk hold dana
+	if errors.Is(err, postgresRepo.ErrHoldNotFound) {
+		return http.StatusNotFound, postgresRepo.ErrHoldNotFound
+	}
+	if errors.Is(err, postgresRepo.ErrHoldNotActive) {
+		return http.StatusConflict, postgresRepo.ErrHoldNotActive
+	}
+	if errors.Is(err, postgresRepo.ErrHoldExpired) {
+		return http.StatusConflict, postgresRepo.ErrHoldExpired
+	}
+
+	// Error custom untuk wallet yang dibekukan, wallet milik user sendiri terkunci (423)
+	// sedangkan wallet penerima yang dibekukan membuat transfer ditolak (403)
+	if errors.Is(err, postgresRepo.ErrWalletNotFound) {
+		return http.StatusNotFound, postgresRepo.ErrWalletNotFound
+	}
+	if errors.Is(err, postgresRepo.ErrWalletFrozenOutgoing) {
+		return http.StatusLocked, postgresRepo.ErrWalletFrozenOutgoing
+	}
+	if errors.Is(err, postgresRepo.ErrWalletFrozenIncoming) {
+		return http.StatusLocked, postgresRepo.ErrWalletFrozenIncoming
+	}
+	if errors.Is(err, postgresRepo.ErrRecipientWalletFrozen) {
+		return http.StatusForbidden, postgresRepo.ErrRecipientWalletFrozen
+	}
+	if errors.Is(err, postgresRepo.ErrWalletStatusUnchanged) {
+		return http.StatusConflict, postgresRepo.ErrWalletStatusUnchanged
+	}
+	if errors.Is(err, postgresRepo.ErrWalletNameTaken) {
+		return http.StatusConflict, postgresRepo.ErrWalletNameTaken
+	}
+
+	// Error custom untuk reference transaksi dari klien
+	if errors.Is(err, postgresRepo.ErrExternalReferenceTaken) {
+		return http.StatusConflict, postgresRepo.ErrExternalReferenceTaken
+	}
+
+	// Error custom untuk rekonsiliasi saldo
+	if errors.Is(err, postgresRepo.ErrReconciliationNotFound) {
+		return http.StatusNotFound, postgresRepo.ErrReconciliationNotFound
+	}
+
+	// Error custom untuk order
+	if errors.Is(err, postgresRepo.ErrOrderNotFound) {
+		return http.StatusNotFound, postgresRepo.ErrOrderNotFound
+	}
+
+	// Error custom untuk voucher, voucher yang tidak bisa dipakai pada pembelian ditolak dengan 422
+	// sedangkan batas pemakaian yang sudah habis adalah konflik (409)
+	if errors.Is(err, postgresRepo.ErrVoucherNotFound) {
+		return http.StatusNotFound, postgresRepo.ErrVoucherNotFound
+	}
+	if errors.Is(err, postgresRepo.ErrVoucherCodeTaken) {
+		return http.StatusConflict, postgresRepo.ErrVoucherCodeTaken
+	}
+	if errors.Is(err, postgresRepo.ErrVoucherCapBelowRedemptions) {
+		return http.StatusConflict, postgresRepo.ErrVoucherCapBelowRedemptions
+	}
+	if errors.Is(err, postgresRepo.ErrVoucherInvalid) {
+		return http.StatusUnprocessableEntity, postgresRepo.ErrVoucherInvalid
+	}
+	if errors.Is(err, postgresRepo.ErrVoucherNotApplicable) {
+		return http.StatusUnprocessableEntity, postgresRepo.ErrVoucherNotApplicable
+	}
+	if errors.Is(err, postgresRepo.ErrVoucherMinSpendNotMet) {
+		return http.StatusUnprocessableEntity, postgresRepo.ErrVoucherMinSpendNotMet
+	}
+	if errors.Is(err, postgresRepo.ErrVoucherCoversFullAmount) {
+		return http.StatusUnprocessableEntity, postgresRepo.ErrVoucherCoversFullAmount
+	}
+	if errors.Is(err, postgresRepo.ErrVoucherUserLimitReached) {
+		return http.StatusConflict, postgresRepo.ErrVoucherUserLimitReached
+	}
+	if errors.Is(err, postgresRepo.ErrVoucherFullyRedeemed) {
+		return http.StatusConflict, postgresRepo.ErrVoucherFullyRedeemed
+	}
+
+	// Error custom untuk reward dan poin loyalitas
+	if errors.Is(err, postgresRepo.ErrRewardRuleNotFound) {
+		return http.StatusNotFound, postgresRepo.ErrRewardRuleNotFound
+	}
+	if errors.Is(err, postgresRepo.ErrInsufficientPoints) {
+		return http.StatusUnprocessableEntity, postgresRepo.ErrInsufficientPoints
+	}
+	if errors.Is(err, postgresRepo.ErrPointsCoverFullAmount) {
+		return http.StatusUnprocessableEntity, postgresRepo.ErrPointsCoverFullAmount
+	}
+
+	// Error custom untuk webhook
+	if errors.Is(err, postgresRepo.ErrWebhookNotFound) {
+		return http.StatusNotFound, postgresRepo.ErrWebhookNotFound
+	}
+	if errors.Is(err, postgresRepo.ErrWebhookDeliveryNotFound) {
+		return http.StatusNotFound, postgresRepo.ErrWebhookDeliveryNotFound
+	}
+
 	// Periksa apakah error adalah sql.ErrNoRows
 	if errors.Is(err, sql.ErrNoRows) {
 		return http.StatusNotFound, errors.New("data not found")
@@ -0,0 +1,95 @@
+package mailer
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"time"
+
+	gomail "gopkg.in/mail.v2"
+)
+
+// StatementSummary adalah ringkasan statement bulanan yang dikirim ke user.
+// Nominal sudah diformat oleh pemanggil agar package ini tidak bergantung pada domain.
+type StatementSummary struct {
+	Username       string
+	Period         string
+	Currency       string
+	OpeningBalance string
+	ClosingBalance string
+	Totals         []StatementSummaryTotal
+}
+
+type StatementSummaryTotal struct {
+	TransactionType string
+	Count           int
+	TotalCredit     string
+	TotalDebit      string
+}
+
+type StatementMailer interface {
+	SendStatement(summary StatementSummary, receiver string) (err error)
+}
+
+type statementMailer struct {
+	email    string
+	password string
+}
+
+func NewStatementMailer(email, password string) StatementMailer {
+	return &statementMailer{
+		email:    email,
+		password: password,
+	}
+}
+
+var statementTemplate = template.Must(template.New("statement").Parse(
+	`<div style="font-family: Helvetica,Arial,sans-serif;min-width:1000px;overflow:auto;line-height:2">
+		<div style="margin:50px auto;width:70%;padding:20px 0">
+		<div style="border-bottom:1px solid #eee">
+			<a href="" style="font-size:1.4em;color: #00466a;text-decoration:none;font-weight:600">Go Rest boilerplate</a>
+		</div>
+		<p style="font-size:1.1em">Hi {{.Summary.Username}},</p>
+		<p>Here is your wallet statement for {{.Summary.Period}}.</p>
+		<p>Opening balance: <b>{{.Summary.Currency}} {{.Summary.OpeningBalance}}</b><br />Closing balance: <b>{{.Summary.Currency}} {{.Summary.ClosingBalance}}</b></p>
+		{{if .Summary.Totals}}
+		<table style="border-collapse:collapse;width:100%">
+			<tr style="background: #00466a;color: #fff"><th align="left">Type</th><th align="right">Count</th><th align="right">Credit</th><th align="right">Debit</th></tr>
+			{{range .Summary.Totals}}
+			<tr style="border-bottom:1px solid #eee"><td>{{.TransactionType}}</td><td align="right">{{.Count}}</td><td align="right">{{.TotalCredit}}</td><td align="right">{{.TotalDebit}}</td></tr>
+			{{end}}
+		</table>
+		{{else}}
+		<p>There were no transactions in this period.</p>
+		{{end}}
+		<p style="font-size:0.9em;">Regards,<br />Go Rest boilerplate</p>
+		<hr style="border:none;border-top:1px solid #eee" />
+		<div style="float:right;padding:8px 0;color:#aaa;font-size:0.8em;line-height:1;font-weight:300">
+			<p>Copyright &copy; Go Rest boilerplate {{.Year}}</p>
+			<p>East Java, Indonesia</p>
+		</div>
+		</div>
+	</div>
+	`))
+
+func (mailer *statementMailer) SendStatement(summary StatementSummary, receiver string) (err error) {
+	var body bytes.Buffer
+	err = statementTemplate.Execute(&body, map[string]interface{}{
+		"Summary": summary,
+		"Year":    time.Now().Year(),
+	})
+	if err != nil {
+		return err
+	}
+
+	configMessage := gomail.NewMessage()
+	configMessage.SetHeader("From", mailer.email)
+	configMessage.SetHeader("To", receiver)
+	configMessage.SetHeader("Subject", fmt.Sprintf("Wallet Statement %s", summary.Period))
+	configMessage.SetBody("text/html", body.String())
+
+	dialer := gomail.NewDialer("smtp.gmail.com", 587, mailer.email, mailer.password)
+
+	err = dialer.DialAndSend(configMessage)
+	return
+}
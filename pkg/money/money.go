@@ -0,0 +1,255 @@
+package money
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Scale adalah jumlah digit pecahan yang disimpan, sama dengan kolom DECIMAL(15, 2)
+const Scale = 2
+
+const minorPerMajor = 100
+
+var (
+	ErrInvalidAmount    = errors.New("invalid money amount")
+	ErrTooManyDecimals  = errors.New("money amount must have at most 2 decimal places")
+	ErrAmountOutOfRange = errors.New("money amount is out of range")
+)
+
+// Money menyimpan nominal uang dalam satuan terkecil (sen) sehingga
+// penjumlahan dan pengurangan selalu eksak.
+//
+// Aturan pembulatan:
+//   - input dari client (JSON) dengan lebih dari 2 digit pecahan ditolak
+//   - nilai dari database dengan lebih dari 2 digit pecahan dibulatkan half away from zero
+//   - pembagian proporsional (MulDiv) dibulatkan half away from zero
+//
+// Di JSON, Money selalu dikirim sebagai string, misal "1250.50".
+type Money int64
+
+func FromMinorUnits(minor int64) Money {
+	return Money(minor)
+}
+
+// FromMajor membuat Money dari nominal bulat, misal FromMajor(10) = "10.00"
+func FromMajor(major int64) Money {
+	return Money(major * minorPerMajor)
+}
+
+// Parse mengubah string desimal seperti "12", "12.5" atau "-0.25" menjadi Money.
+// Lebih dari 2 digit pecahan dianggap error.
+func Parse(s string) (Money, error) {
+	return parse(s, false)
+}
+
+// MustParse sama seperti Parse namun panic jika input tidak valid, cocok untuk konstanta dan seeder
+func MustParse(s string) Money {
+	m, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+func parse(s string, round bool) (Money, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, ErrInvalidAmount
+	}
+
+	negative := false
+	switch s[0] {
+	case '-':
+		negative = true
+		s = s[1:]
+	case '+':
+		s = s[1:]
+	}
+
+	intPart, fracPart, _ := strings.Cut(s, ".")
+	if intPart == "" && fracPart == "" {
+		return 0, ErrInvalidAmount
+	}
+	if !isDigits(intPart) || !isDigits(fracPart) {
+		return 0, ErrInvalidAmount
+	}
+
+	// Digit setelah skala hanya boleh ada jika pembulatan diizinkan
+	roundUp := false
+	if len(fracPart) > Scale {
+		if !round {
+			if strings.Trim(fracPart[Scale:], "0") != "" {
+				return 0, ErrTooManyDecimals
+			}
+		} else {
+			roundUp = fracPart[Scale] >= '5'
+		}
+		fracPart = fracPart[:Scale]
+	}
+	fracPart += strings.Repeat("0", Scale-len(fracPart))
+
+	if intPart == "" {
+		intPart = "0"
+	}
+	major, err := strconv.ParseInt(intPart, 10, 64)
+	if err != nil || major > math.MaxInt64/minorPerMajor-1 {
+		return 0, ErrAmountOutOfRange
+	}
+	minor, _ := strconv.ParseInt(fracPart, 10, 64)
+
+	amount := major*minorPerMajor + minor
+	if roundUp {
+		amount++
+	}
+	if negative {
+		amount = -amount
+	}
+
+	return Money(amount), nil
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func (m Money) MinorUnits() int64 {
+	return int64(m)
+}
+
+// MulInt mengalikan nominal dengan bilangan bulat, misal harga satuan * quantity
+func (m Money) MulInt(n int) Money {
+	return m * Money(n)
+}
+
+// MulDiv menghitung m * num / den dengan pembulatan half away from zero.
+// Dipakai untuk pembagian proporsional seperti refund sebagian.
+func (m Money) MulDiv(num, den int64) Money {
+	if den == 0 {
+		panic("money: division by zero")
+	}
+
+	product := int64(m) * num
+	quotient, remainder := product/den, product%den
+	if remainder != 0 && abs(remainder)*2 >= abs(den) {
+		if (product < 0) != (den < 0) {
+			quotient--
+		} else {
+			quotient++
+		}
+	}
+
+	return Money(quotient)
+}
+
+// Share menghitung bagian proporsional untuk part dari whole unit, misal nominal refund sebagian.
+// Jika part menghabiskan sisa unit (remaining), hasilnya adalah sisa nominal setelah taken
+// sehingga total semua bagian selalu sama dengan nominal awal walaupun ada pembulatan.
+func (m Money) Share(part, remaining, whole int, taken Money) Money {
+	if part == remaining {
+		return m - taken
+	}
+
+	return m.MulDiv(int64(part), int64(whole))
+}
+
+func abs(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func (m Money) IsZero() bool {
+	return m == 0
+}
+
+func (m Money) IsPositive() bool {
+	return m > 0
+}
+
+func (m Money) IsNegative() bool {
+	return m < 0
+}
+
+// String mengembalikan representasi desimal dengan tepat 2 digit pecahan
+func (m Money) String() string {
+	minor := int64(m)
+	sign := ""
+	if minor < 0 {
+		sign = "-"
+		minor = -minor
+	}
+
+	return fmt.Sprintf("%s%d.%02d", sign, minor/minorPerMajor, minor%minorPerMajor)
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(m.String())), nil
+}
+
+// UnmarshalJSON menerima string ("12.50") maupun angka JSON (12.50) tanpa melewati float64
+func (m *Money) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+
+	if strings.HasPrefix(s, `"`) {
+		unquoted, err := strconv.Unquote(s)
+		if err != nil {
+			return ErrInvalidAmount
+		}
+		s = unquoted
+	}
+
+	parsed, err := Parse(s)
+	if err != nil {
+		return err
+	}
+
+	*m = parsed
+	return nil
+}
+
+// Scan membaca kolom DECIMAL dari database. lib/pq mengirim NUMERIC sebagai []byte.
+func (m *Money) Scan(src interface{}) error {
+	var (
+		parsed Money
+		err    error
+	)
+
+	switch v := src.(type) {
+	case nil:
+		parsed = 0
+	case []byte:
+		parsed, err = parse(string(v), true)
+	case string:
+		parsed, err = parse(v, true)
+	case int64:
+		parsed = FromMajor(v)
+	case float64:
+		parsed, err = parse(strconv.FormatFloat(v, 'f', -1, 64), true)
+	default:
+		return fmt.Errorf("money: cannot scan %T", src)
+	}
+	if err != nil {
+		return err
+	}
+
+	*m = parsed
+	return nil
+}
+
+// Value mengirim nominal ke database sebagai string desimal agar presisi terjaga
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
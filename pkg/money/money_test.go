@@ -0,0 +1,152 @@
+package money_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/snykk/transaction-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		expected    money.Money
+		expectedErr error
+	}{
+		{"Integer", "12", money.FromMinorUnits(1200), nil},
+		{"One Decimal", "12.5", money.FromMinorUnits(1250), nil},
+		{"Two Decimals", "0.01", money.FromMinorUnits(1), nil},
+		{"Negative", "-0.25", money.FromMinorUnits(-25), nil},
+		{"Trailing Zeros", "1.500", money.FromMinorUnits(150), nil},
+		{"Too Many Decimals", "1.005", 0, money.ErrTooManyDecimals},
+		{"Empty", "", 0, money.ErrInvalidAmount},
+		{"Not A Number", "abc", 0, money.ErrInvalidAmount},
+		{"Exponent", "1e3", 0, money.ErrInvalidAmount},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := money.Parse(test.input)
+
+			assert.Equal(t, test.expectedErr, err)
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    money.Money
+		expected string
+	}{
+		{"Zero", 0, "0.00"},
+		{"Cents", money.FromMinorUnits(5), "0.05"},
+		{"Major", money.FromMajor(1250), "1250.00"},
+		{"Negative", money.FromMinorUnits(-150), "-1.50"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.input.String())
+		})
+	}
+}
+
+func TestMulDiv(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    money.Money
+		num      int64
+		den      int64
+		expected money.Money
+	}{
+		{"Exact", money.FromMinorUnits(300), 1, 3, money.FromMinorUnits(100)},
+		{"Round Down", money.FromMinorUnits(1000), 1, 3, money.FromMinorUnits(333)},
+		{"Round Half Up", money.FromMinorUnits(1000), 2, 3, money.FromMinorUnits(667)},
+		{"Half Away From Zero", money.FromMinorUnits(-5), 1, 2, money.FromMinorUnits(-3)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.input.MulDiv(test.num, test.den))
+		})
+	}
+}
+
+func TestShare(t *testing.T) {
+	total := money.FromMinorUnits(1000)
+
+	t.Run("Proportional Part", func(t *testing.T) {
+		assert.Equal(t, money.FromMinorUnits(333), total.Share(1, 3, 3, 0))
+	})
+
+	t.Run("Last Part Takes Remainder", func(t *testing.T) {
+		first := total.Share(1, 3, 3, 0)
+		second := total.Share(1, 2, 3, first)
+		last := total.Share(1, 1, 3, first+second)
+
+		assert.Equal(t, money.FromMinorUnits(334), last)
+		assert.Equal(t, total, first+second+last)
+	})
+
+	t.Run("Zero Total", func(t *testing.T) {
+		assert.True(t, money.Money(0).Share(1, 2, 2, 0).IsZero())
+	})
+}
+
+func TestJSON(t *testing.T) {
+	t.Run("Marshal As String", func(t *testing.T) {
+		result, err := json.Marshal(map[string]money.Money{"amount": money.FromMinorUnits(1050)})
+
+		assert.Nil(t, err)
+		assert.Equal(t, `{"amount":"10.50"}`, string(result))
+	})
+
+	t.Run("Unmarshal String And Number", func(t *testing.T) {
+		var payload struct {
+			FromString money.Money `json:"from_string"`
+			FromNumber money.Money `json:"from_number"`
+		}
+
+		err := json.Unmarshal([]byte(`{"from_string":"0.10","from_number":0.20}`), &payload)
+
+		assert.Nil(t, err)
+		assert.Equal(t, money.FromMinorUnits(10), payload.FromString)
+		assert.Equal(t, money.FromMinorUnits(20), payload.FromNumber)
+	})
+
+	t.Run("Unmarshal Too Many Decimals", func(t *testing.T) {
+		var amount money.Money
+
+		err := json.Unmarshal([]byte(`"0.001"`), &amount)
+
+		assert.Equal(t, money.ErrTooManyDecimals, err)
+	})
+}
+
+func TestScan(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    interface{}
+		expected money.Money
+	}{
+		{"Bytes From Numeric", []byte("1234.56"), money.FromMinorUnits(123456)},
+		{"String", "0.10", money.FromMinorUnits(10)},
+		{"Round Extra Precision", []byte("0.125"), money.FromMinorUnits(13)},
+		{"Integer", int64(7), money.FromMajor(7)},
+		{"Null", nil, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var result money.Money
+			err := result.Scan(test.input)
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
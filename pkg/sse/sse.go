@@ -0,0 +1,112 @@
+package sse
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+var ErrStreamingUnsupported = errors.New("sse: response writer does not support flushing")
+
+type controllerKey struct{}
+
+// Handler menyimpan http.ResponseController milik server di context request.
+// Router seperti gin membungkus ResponseWriter tanpa Unwrap, sehingga tanpa ini
+// WriteTimeout server akan memutus stream yang berjalan lebih lama dari batasnya.
+func Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), controllerKey{}, http.NewResponseController(w))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// Writer menulis response text/event-stream. Setiap tulis memperpanjang deadline
+// sebesar writeTimeout sehingga client yang berhenti membaca tetap terputus.
+type Writer struct {
+	w            http.ResponseWriter
+	flusher      http.Flusher
+	controller   *http.ResponseController
+	writeTimeout time.Duration
+}
+
+// NewWriter mengirim header event stream ke client, header lain seperti CORS
+// harus sudah diset sebelum dipanggil
+func NewWriter(w http.ResponseWriter, r *http.Request, writeTimeout time.Duration) (*Writer, error) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return nil, ErrStreamingUnsupported
+	}
+
+	controller, ok := r.Context().Value(controllerKey{}).(*http.ResponseController)
+	if !ok {
+		controller = http.NewResponseController(w)
+	}
+
+	header := w.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	// reverse proxy seperti nginx tidak boleh menahan event di buffer
+	header.Set("X-Accel-Buffering", "no")
+
+	writer := &Writer{
+		w:            w,
+		flusher:      flusher,
+		controller:   controller,
+		writeTimeout: writeTimeout,
+	}
+
+	if err := writer.extendDeadline(); err != nil {
+		return nil, err
+	}
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	return writer, nil
+}
+
+// Event mengirim satu event, id kosong berarti event tidak mengubah Last-Event-ID client
+func (w *Writer) Event(id string, event string, data []byte) error {
+	var buf bytes.Buffer
+	if id != "" {
+		fmt.Fprintf(&buf, "id: %s\n", id)
+	}
+	if event != "" {
+		fmt.Fprintf(&buf, "event: %s\n", event)
+	}
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		fmt.Fprintf(&buf, "data: %s\n", line)
+	}
+	buf.WriteString("\n")
+
+	return w.write(buf.Bytes())
+}
+
+// Comment mengirim baris komentar yang diabaikan client, dipakai sebagai heartbeat
+// agar proxy tidak menutup koneksi yang diam
+func (w *Writer) Comment(text string) error {
+	return w.write([]byte(fmt.Sprintf(": %s\n\n", text)))
+}
+
+func (w *Writer) write(p []byte) error {
+	if err := w.extendDeadline(); err != nil {
+		return err
+	}
+	if _, err := w.w.Write(p); err != nil {
+		return err
+	}
+
+	w.flusher.Flush()
+	return nil
+}
+
+func (w *Writer) extendDeadline() error {
+	err := w.controller.SetWriteDeadline(time.Now().Add(w.writeTimeout))
+	if err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
+}
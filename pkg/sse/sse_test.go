@@ -0,0 +1,69 @@
+package sse_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/snykk/transaction-api/pkg/sse"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriter(t *testing.T) {
+	t.Run("With Event And Comment", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/stream", nil)
+
+		writer, err := sse.NewWriter(w, r, time.Second)
+		assert.NoError(t, err)
+		assert.NoError(t, writer.Event("1700000000000-0", "transaction", []byte(`{"id":"1"}`)))
+		assert.NoError(t, writer.Event("", "balance", []byte("line 1\nline 2")))
+		assert.NoError(t, writer.Comment("heartbeat"))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+		assert.Equal(t, "no-cache", w.Header().Get("Cache-Control"))
+		assert.Equal(t, "id: 1700000000000-0\nevent: transaction\ndata: {\"id\":\"1\"}\n\n"+
+			"event: balance\ndata: line 1\ndata: line 2\n\n"+
+			": heartbeat\n\n", w.Body.String())
+	})
+}
+
+type wrappedWriter struct {
+	http.ResponseWriter
+	http.Flusher
+}
+
+func TestHandler(t *testing.T) {
+	t.Run("With Stream Longer Than Server Write Timeout", func(t *testing.T) {
+		server := httptest.NewUnstartedServer(sse.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// router membungkus ResponseWriter tanpa Unwrap
+			writer, err := sse.NewWriter(wrappedWriter{ResponseWriter: w, Flusher: w.(http.Flusher)}, r, time.Second)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+
+			for i := 0; i < 4; i++ {
+				time.Sleep(100 * time.Millisecond)
+				if err := writer.Comment("heartbeat"); err != nil {
+					return
+				}
+			}
+		})))
+		server.Config.WriteTimeout = 150 * time.Millisecond
+		server.Start()
+		defer server.Close()
+
+		resp, err := http.Get(server.URL)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, 4, strings.Count(string(body), ": heartbeat\n\n"))
+	})
+}
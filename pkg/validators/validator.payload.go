@@ -15,6 +15,7 @@ var mapHelepr = map[string]string{
 	"lowercase": "must contain at least one lowercase letter",
 	"uppercase": "must contain at least one uppercase letter",
 	"numeric":   "must contain at least one digit",
+	"timezone":  "is not a valid IANA time zone",
 }
 
 var needParam = []string{"min", "max", "containsany"}
@@ -0,0 +1,231 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// Header yang dikirim bersama setiap webhook. Signature adalah HMAC-SHA256 dari "<timestamp>.<body>"
+// dengan secret endpoint, receiver wajib memeriksa timestamp untuk menolak replay.
+const (
+	HeaderId        = "X-Webhook-Id"
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+
+	signatureVersion = "v1"
+	secretPrefix     = "whsec_"
+
+	// batas body response receiver yang dibaca agar koneksi bisa dipakai ulang, isinya tidak disimpan
+	responseDrainLength = 4096
+)
+
+var (
+	ErrInvalidSignature      = errors.New("webhook signature is invalid")
+	ErrTimestampExpired      = errors.New("webhook timestamp is outside the tolerance window")
+	ErrDestinationNotAllowed = errors.New("webhook destination resolves to a loopback, private, link-local, or unspecified address")
+)
+
+// blockedNetworks adalah rentang yang tidak tercakup method net.IP tetapi tetap tidak boleh dituju webhook
+var blockedNetworks = []*net.IPNet{
+	mustParseCIDR("0.0.0.0/8"),     // "this network"
+	mustParseCIDR("100.64.0.0/10"), // shared address space (CGNAT), dipakai metadata service sebagian cloud
+}
+
+// Message adalah satu pengiriman webhook, Id tetap sama di setiap percobaan ulang
+// sehingga receiver bisa membuang pengiriman ganda
+type Message struct {
+	Id    string
+	Event string
+	Body  []byte
+}
+
+// Result menjelaskan response receiver, StatusCode 0 berarti receiver tidak bisa dihubungi
+type Result struct {
+	StatusCode int
+	Duration   time.Duration
+}
+
+type Sender interface {
+	// ValidateDestination me-resolve host URL dan menolak jika salah satu alamatnya tidak boleh dituju
+	ValidateDestination(ctx context.Context, url string) error
+	Send(ctx context.Context, url string, secret string, message Message) (Result, error)
+}
+
+type sender struct {
+	client              *http.Client
+	allowPrivateNetwork bool
+}
+
+// NewSender membuat sender yang hanya mengirim ke alamat publik. allowPrivateNetwork hanya untuk
+// pengembangan lokal, karena URL webhook ditentukan user dan bisa diarahkan ke layanan internal.
+func NewSender(timeout time.Duration, allowPrivateNetwork bool) Sender {
+	s := &sender{allowPrivateNetwork: allowPrivateNetwork}
+
+	// alamat diperiksa lagi saat dial karena DNS bisa berubah setelah URL divalidasi (DNS rebinding)
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network string, address string, conn syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if !s.isAllowed(net.ParseIP(host)) {
+				return ErrDestinationNotAllowed
+			}
+			return nil
+		},
+	}
+
+	s.client = &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			// proxy dari environment tidak dipakai agar alamat yang diperiksa adalah alamat receiver
+			Proxy:               nil,
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: timeout,
+			MaxIdleConns:        100,
+			IdleConnTimeout:     90 * time.Second,
+		},
+		// redirect dianggap gagal, receiver harus mendaftarkan URL akhirnya
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	return s
+}
+
+func (s *sender) ValidateDestination(ctx context.Context, rawURL string) error {
+	if s.allowPrivateNetwork {
+		return nil
+	}
+
+	target, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+
+	addresses, err := net.DefaultResolver.LookupIPAddr(ctx, target.Hostname())
+	if err != nil {
+		return err
+	}
+	for _, address := range addresses {
+		if !s.isAllowed(address.IP) {
+			return ErrDestinationNotAllowed
+		}
+	}
+
+	return nil
+}
+
+// isAllowed bernilai false untuk alamat loopback, private, link-local, unspecified, dan multicast
+func (s *sender) isAllowed(ip net.IP) bool {
+	if s.allowPrivateNetwork {
+		return true
+	}
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsMulticast() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() {
+		return false
+	}
+	for _, network := range blockedNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Send mengirim message dengan header signature. Response selain 2xx dikembalikan sebagai error.
+func (s *sender) Send(ctx context.Context, url string, secret string, message Message) (result Result, err error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(message.Body))
+	if err != nil {
+		return Result{}, err
+	}
+
+	timestamp := time.Now().Unix()
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "transaction-api-webhook/1.0")
+	request.Header.Set(HeaderId, message.Id)
+	request.Header.Set(HeaderEvent, message.Event)
+	request.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	request.Header.Set(HeaderSignature, Sign(secret, timestamp, message.Body))
+
+	start := time.Now()
+	response, err := s.client.Do(request)
+	result.Duration = time.Since(start)
+	if err != nil {
+		return result, err
+	}
+	defer response.Body.Close()
+
+	// body response tidak ikut disimpan, isinya bisa dilihat user lewat log pengiriman
+	result.StatusCode = response.StatusCode
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, responseDrainLength))
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return result, fmt.Errorf("receiver responded with status %d", response.StatusCode)
+	}
+
+	return result, nil
+}
+
+// GenerateSecret membuat secret acak 32 byte untuk endpoint yang tidak menentukan secret sendiri
+func GenerateSecret() (string, error) {
+	buffer := make([]byte, 32)
+	if _, err := rand.Read(buffer); err != nil {
+		return "", err
+	}
+
+	return secretPrefix + hex.EncodeToString(buffer), nil
+}
+
+// Sign menghasilkan nilai header signature, misal v1=5257a869...
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return signatureVersion + "=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify dipakai receiver untuk memeriksa signature dan umur timestamp sebuah webhook
+func Verify(secret string, signature string, timestamp string, body []byte, tolerance time.Duration, now time.Time) error {
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	age := now.Sub(time.Unix(unix, 0))
+	if age > tolerance || age < -tolerance {
+		return ErrTimestampExpired
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(Sign(secret, unix, body))) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+func mustParseCIDR(cidr string) *net.IPNet {
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		panic(err)
+	}
+	return network
+}
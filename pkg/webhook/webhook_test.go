@@ -0,0 +1,149 @@
+package webhook_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/snykk/transaction-api/pkg/webhook"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSign(t *testing.T) {
+	t.Run("With Same Input", func(t *testing.T) {
+		body := []byte(`{"id":"1"}`)
+
+		assert.Equal(t, webhook.Sign("whsec_test", 1700000000, body), webhook.Sign("whsec_test", 1700000000, body))
+		assert.Contains(t, webhook.Sign("whsec_test", 1700000000, body), "v1=")
+	})
+	t.Run("With Different Secret Or Timestamp", func(t *testing.T) {
+		body := []byte(`{"id":"1"}`)
+
+		assert.NotEqual(t, webhook.Sign("whsec_test", 1700000000, body), webhook.Sign("whsec_other", 1700000000, body))
+		assert.NotEqual(t, webhook.Sign("whsec_test", 1700000000, body), webhook.Sign("whsec_test", 1700000001, body))
+	})
+}
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"id":"1"}`)
+	now := time.Unix(1700000000, 0)
+	signature := webhook.Sign("whsec_test", now.Unix(), body)
+
+	t.Run("With Valid Signature", func(t *testing.T) {
+		err := webhook.Verify("whsec_test", signature, strconv.FormatInt(now.Unix(), 10), body, 5*time.Minute, now.Add(time.Minute))
+		assert.NoError(t, err)
+	})
+	t.Run("With Tampered Body", func(t *testing.T) {
+		err := webhook.Verify("whsec_test", signature, strconv.FormatInt(now.Unix(), 10), []byte(`{"id":"2"}`), 5*time.Minute, now)
+		assert.ErrorIs(t, err, webhook.ErrInvalidSignature)
+	})
+	t.Run("With Expired Timestamp", func(t *testing.T) {
+		err := webhook.Verify("whsec_test", signature, strconv.FormatInt(now.Unix(), 10), body, 5*time.Minute, now.Add(10*time.Minute))
+		assert.ErrorIs(t, err, webhook.ErrTimestampExpired)
+	})
+}
+
+func TestSend(t *testing.T) {
+	t.Run("With Receiver Accepting Signed Request", func(t *testing.T) {
+		var received *http.Request
+		var receivedBody []byte
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received = r
+			receivedBody, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer receiver.Close()
+
+		message := webhook.Message{Id: "dddd-eeee-1111", Event: "transaction.deposit.completed", Body: []byte(`{"id":"dddd-eeee-1111"}`)}
+		result, err := webhook.NewSender(time.Second, true).Send(context.Background(), receiver.URL, "whsec_test", message)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, result.StatusCode)
+		assert.Equal(t, message.Body, receivedBody)
+		assert.Equal(t, message.Id, received.Header.Get(webhook.HeaderId))
+		assert.Equal(t, message.Event, received.Header.Get(webhook.HeaderEvent))
+		assert.NoError(t, webhook.Verify("whsec_test", received.Header.Get(webhook.HeaderSignature), received.Header.Get(webhook.HeaderTimestamp), receivedBody, time.Minute, time.Now()))
+	})
+	t.Run("With Receiver Returning Server Error", func(t *testing.T) {
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("database is down"))
+		}))
+		defer receiver.Close()
+
+		result, err := webhook.NewSender(time.Second, true).Send(context.Background(), receiver.URL, "whsec_test", webhook.Message{Id: "1", Event: "webhook.test", Body: []byte(`{}`)})
+
+		// body receiver tidak ikut dalam pesan error karena pesan tersebut bisa dibaca user
+		assert.Error(t, err)
+		assert.NotContains(t, err.Error(), "database is down")
+		assert.Equal(t, http.StatusInternalServerError, result.StatusCode)
+	})
+	t.Run("With Receiver Redirecting", func(t *testing.T) {
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, "/elsewhere", http.StatusFound)
+		}))
+		defer receiver.Close()
+
+		result, err := webhook.NewSender(time.Second, true).Send(context.Background(), receiver.URL, "whsec_test", webhook.Message{Id: "1", Event: "webhook.test", Body: []byte(`{}`)})
+
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusFound, result.StatusCode)
+	})
+	t.Run("With Unreachable Receiver", func(t *testing.T) {
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		receiver.Close()
+
+		result, err := webhook.NewSender(time.Second, true).Send(context.Background(), receiver.URL, "whsec_test", webhook.Message{Id: "1", Event: "webhook.test", Body: []byte(`{}`)})
+
+		assert.Error(t, err)
+		assert.Equal(t, 0, result.StatusCode)
+	})
+}
+
+func TestValidateDestination(t *testing.T) {
+	sender := webhook.NewSender(time.Second, false)
+
+	t.Run("With Internal Addresses", func(t *testing.T) {
+		for _, url := range []string{
+			"http://127.0.0.1:8080/hooks",
+			"http://localhost/hooks",
+			"http://10.1.2.3/hooks",
+			"http://192.168.0.10/hooks",
+			"http://169.254.169.254/latest/meta-data",
+			"http://100.100.100.200/hooks",
+			"http://0.0.0.0/hooks",
+			"http://[::1]/hooks",
+			"http://[fd00::1]/hooks",
+			"http://[::ffff:127.0.0.1]/hooks",
+		} {
+			assert.ErrorIs(t, sender.ValidateDestination(context.Background(), url), webhook.ErrDestinationNotAllowed, url)
+		}
+	})
+	t.Run("With Public Address", func(t *testing.T) {
+		assert.NoError(t, sender.ValidateDestination(context.Background(), "https://93.184.216.34/hooks"))
+	})
+	t.Run("With Private Network Allowed", func(t *testing.T) {
+		assert.NoError(t, webhook.NewSender(time.Second, true).ValidateDestination(context.Background(), "http://127.0.0.1:8080/hooks"))
+	})
+}
+
+func TestSendToInternalAddress(t *testing.T) {
+	t.Run("With Receiver On Loopback", func(t *testing.T) {
+		// alamat diperiksa lagi saat dial walaupun URL lolos validasi sebelumnya
+		called := false
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+		}))
+		defer receiver.Close()
+
+		result, err := webhook.NewSender(time.Second, false).Send(context.Background(), receiver.URL, "whsec_test", webhook.Message{Id: "1", Event: "webhook.test", Body: []byte(`{}`)})
+
+		assert.ErrorIs(t, err, webhook.ErrDestinationNotAllowed)
+		assert.Equal(t, 0, result.StatusCode)
+		assert.False(t, called)
+	})
+}
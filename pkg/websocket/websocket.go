@@ -0,0 +1,294 @@
+package websocket
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// implementasi minimal RFC 6455 untuk server yang mendorong pesan ke client:
+// tanpa extension, tanpa subprotocol, dan pesan client hanya dibaca untuk ping dan close
+
+const (
+	OpText   = 0x1
+	OpBinary = 0x2
+	OpClose  = 0x8
+	OpPing   = 0x9
+	OpPong   = 0xA
+
+	CloseNormal        = 1000
+	CloseGoingAway     = 1001
+	CloseProtocolError = 1002
+	CloseMessageTooBig = 1009
+
+	// batas payload pesan dari client, server ini tidak mengharapkan pesan besar
+	MaxReadPayload = 64 * 1024
+
+	acceptGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+)
+
+var (
+	ErrNotWebSocket    = errors.New("websocket: request is not a websocket upgrade")
+	ErrBadVersion      = errors.New("websocket: unsupported version, expected 13")
+	ErrBadKey          = errors.New("websocket: invalid Sec-WebSocket-Key")
+	ErrHijackFailed    = errors.New("websocket: response writer does not support hijacking")
+	ErrProtocol        = errors.New("websocket: protocol error")
+	ErrMessageTooBig   = errors.New("websocket: message too big")
+	ErrConnectionClose = errors.New("websocket: connection closed by peer")
+)
+
+// Conn adalah koneksi websocket sisi server, aman ditulis dari beberapa goroutine
+// tetapi hanya boleh dibaca oleh satu goroutine
+type Conn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+
+	writeMu sync.Mutex
+	closed  bool
+}
+
+// IsUpgrade memeriksa apakah request meminta upgrade ke websocket
+func IsUpgrade(r *http.Request) bool {
+	return headerContainsToken(r.Header, "Connection", "upgrade") && headerContainsToken(r.Header, "Upgrade", "websocket")
+}
+
+// Upgrade memvalidasi handshake lalu mengambil alih koneksi. Jika error dikembalikan
+// sebelum hijack, response masih bisa ditulis oleh pemanggil.
+func Upgrade(w http.ResponseWriter, r *http.Request) (*Conn, error) {
+	if r.Method != http.MethodGet || !IsUpgrade(r) {
+		return nil, ErrNotWebSocket
+	}
+	if r.Header.Get("Sec-WebSocket-Version") != "13" {
+		return nil, ErrBadVersion
+	}
+
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if decoded, err := base64.StdEncoding.DecodeString(key); err != nil || len(decoded) != 16 {
+		return nil, ErrBadKey
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		return nil, ErrHijackFailed
+	}
+
+	conn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return nil, err
+	}
+
+	// deadline dari ReadTimeout dan WriteTimeout server tidak berlaku untuk koneksi yang berumur panjang
+	conn.SetDeadline(time.Time{})
+
+	handshake := "HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + AcceptKey(key) + "\r\n\r\n"
+	if _, err := rw.WriteString(handshake); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if err := rw.Flush(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return &Conn{conn: conn, reader: rw.Reader}, nil
+}
+
+// AcceptKey menghitung nilai Sec-WebSocket-Accept dari Sec-WebSocket-Key client
+func AcceptKey(key string) string {
+	sum := sha1.Sum([]byte(key + acceptGUID))
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// WriteText mengirim satu pesan text, koneksi diputus jika client tidak membaca dalam timeout
+func (c *Conn) WriteText(data []byte, timeout time.Duration) error {
+	return c.writeFrame(OpText, data, timeout)
+}
+
+// Ping mengirim frame ping sebagai heartbeat, client wajib membalas dengan pong
+func (c *Conn) Ping(timeout time.Duration) error {
+	return c.writeFrame(OpPing, nil, timeout)
+}
+
+// ReadMessage membaca pesan data berikutnya dari client. Ping dibalas otomatis,
+// pong diabaikan, dan frame close dibalas lalu dikembalikan sebagai ErrConnectionClose.
+func (c *Conn) ReadMessage() (byte, []byte, error) {
+	var message []byte
+	var messageOpcode byte
+
+	for {
+		fin, opcode, payload, err := c.readFrame()
+		if err != nil {
+			return 0, nil, err
+		}
+
+		switch opcode {
+		case OpPing:
+			if err := c.writeFrame(OpPong, payload, time.Second); err != nil {
+				return 0, nil, err
+			}
+			continue
+		case OpPong:
+			continue
+		case OpClose:
+			// balas dengan kode yang sama lalu tutup koneksi
+			c.close(payload)
+			return 0, nil, ErrConnectionClose
+		case OpText, OpBinary:
+			if messageOpcode != 0 {
+				return 0, nil, c.fail(CloseProtocolError, ErrProtocol)
+			}
+			messageOpcode = opcode
+		case 0x0:
+			// frame lanjutan tanpa frame pembuka
+			if messageOpcode == 0 {
+				return 0, nil, c.fail(CloseProtocolError, ErrProtocol)
+			}
+		default:
+			return 0, nil, c.fail(CloseProtocolError, ErrProtocol)
+		}
+
+		if len(message)+len(payload) > MaxReadPayload {
+			return 0, nil, c.fail(CloseMessageTooBig, ErrMessageTooBig)
+		}
+		message = append(message, payload...)
+
+		if fin {
+			return messageOpcode, message, nil
+		}
+	}
+}
+
+// Close mengirim frame close dengan kode dan alasan lalu menutup koneksi
+func (c *Conn) Close(code int, reason string) error {
+	payload := make([]byte, 2+len(reason))
+	binary.BigEndian.PutUint16(payload, uint16(code))
+	copy(payload[2:], reason)
+
+	return c.close(payload)
+}
+
+func (c *Conn) close(payload []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	if c.closed {
+		return nil
+	}
+
+	c.writeFrameLocked(OpClose, payload, time.Second)
+	c.closed = true
+	return c.conn.Close()
+}
+
+func (c *Conn) fail(code int, err error) error {
+	c.Close(code, err.Error())
+	return err
+}
+
+// writeFrame menulis frame tunggal tanpa masking, frame dari server tidak boleh di-mask
+func (c *Conn) writeFrame(opcode byte, payload []byte, timeout time.Duration) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	if c.closed {
+		return net.ErrClosed
+	}
+	return c.writeFrameLocked(opcode, payload, timeout)
+}
+
+func (c *Conn) writeFrameLocked(opcode byte, payload []byte, timeout time.Duration) error {
+	header := []byte{0x80 | opcode}
+	switch length := len(payload); {
+	case length <= 125:
+		header = append(header, byte(length))
+	case length <= 0xFFFF:
+		header = append(header, 126, 0, 0)
+		binary.BigEndian.PutUint16(header[2:], uint16(length))
+	default:
+		header = append(header, 127, 0, 0, 0, 0, 0, 0, 0, 0)
+		binary.BigEndian.PutUint64(header[2:], uint64(length))
+	}
+
+	if err := c.conn.SetWriteDeadline(time.Now().Add(timeout)); err != nil {
+		return err
+	}
+	if _, err := c.conn.Write(append(header, payload...)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// readFrame membaca satu frame dari client, frame client wajib di-mask
+func (c *Conn) readFrame() (fin bool, opcode byte, payload []byte, err error) {
+	var head [2]byte
+	if _, err := io.ReadFull(c.reader, head[:]); err != nil {
+		return false, 0, nil, err
+	}
+
+	fin = head[0]&0x80 != 0
+	opcode = head[0] & 0x0F
+	if head[0]&0x70 != 0 || head[1]&0x80 == 0 {
+		return false, 0, nil, c.fail(CloseProtocolError, ErrProtocol)
+	}
+
+	length := uint64(head[1] & 0x7F)
+	switch length {
+	case 126:
+		var extended [2]byte
+		if _, err := io.ReadFull(c.reader, extended[:]); err != nil {
+			return false, 0, nil, err
+		}
+		length = uint64(binary.BigEndian.Uint16(extended[:]))
+	case 127:
+		var extended [8]byte
+		if _, err := io.ReadFull(c.reader, extended[:]); err != nil {
+			return false, 0, nil, err
+		}
+		length = binary.BigEndian.Uint64(extended[:])
+	}
+
+	// frame kontrol tidak boleh terpecah dan payloadnya maksimal 125 byte
+	if opcode >= OpClose && (!fin || length > 125) {
+		return false, 0, nil, c.fail(CloseProtocolError, ErrProtocol)
+	}
+	if length > MaxReadPayload {
+		return false, 0, nil, c.fail(CloseMessageTooBig, ErrMessageTooBig)
+	}
+
+	var mask [4]byte
+	if _, err := io.ReadFull(c.reader, mask[:]); err != nil {
+		return false, 0, nil, err
+	}
+
+	payload = make([]byte, length)
+	if _, err := io.ReadFull(c.reader, payload); err != nil {
+		return false, 0, nil, err
+	}
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+
+	return fin, opcode, payload, nil
+}
+
+func headerContainsToken(header http.Header, name string, token string) bool {
+	for _, value := range header.Values(name) {
+		for _, part := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(part), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,164 @@
+package websocket_test
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/snykk/transaction-api/pkg/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+const testKey = "dGhlIHNhbXBsZSBub25jZQ=="
+
+func TestAcceptKey(t *testing.T) {
+	// contoh dari RFC 6455 bagian 1.3
+	assert.Equal(t, "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=", websocket.AcceptKey(testKey))
+}
+
+func TestUpgrade(t *testing.T) {
+	t.Run("With Plain Request", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/ws", nil)
+
+		_, err := websocket.Upgrade(httptest.NewRecorder(), r)
+		assert.ErrorIs(t, err, websocket.ErrNotWebSocket)
+	})
+	t.Run("With Unsupported Version", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/ws", nil)
+		r.Header.Set("Connection", "keep-alive, Upgrade")
+		r.Header.Set("Upgrade", "websocket")
+		r.Header.Set("Sec-WebSocket-Version", "8")
+		r.Header.Set("Sec-WebSocket-Key", testKey)
+
+		_, err := websocket.Upgrade(httptest.NewRecorder(), r)
+		assert.ErrorIs(t, err, websocket.ErrBadVersion)
+	})
+	t.Run("With Invalid Key", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/ws", nil)
+		r.Header.Set("Connection", "Upgrade")
+		r.Header.Set("Upgrade", "websocket")
+		r.Header.Set("Sec-WebSocket-Version", "13")
+		r.Header.Set("Sec-WebSocket-Key", "not-a-key")
+
+		_, err := websocket.Upgrade(httptest.NewRecorder(), r)
+		assert.ErrorIs(t, err, websocket.ErrBadKey)
+	})
+}
+
+func TestConn(t *testing.T) {
+	type received struct {
+		opcode  byte
+		payload []byte
+		err     error
+	}
+	results := make(chan received, 2)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.Upgrade(w, r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		conn.WriteText([]byte("hello"), time.Second)
+		conn.Ping(time.Second)
+
+		for {
+			opcode, payload, err := conn.ReadMessage()
+			results <- received{opcode: opcode, payload: payload, err: err}
+			if err != nil {
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	conn, reader := dial(t, server.URL)
+	defer conn.Close()
+
+	t.Run("Server Sends Unmasked Text And Ping", func(t *testing.T) {
+		opcode, payload := readFrame(t, reader)
+		assert.Equal(t, byte(websocket.OpText), opcode)
+		assert.Equal(t, "hello", string(payload))
+
+		opcode, _ = readFrame(t, reader)
+		assert.Equal(t, byte(websocket.OpPing), opcode)
+	})
+	t.Run("Server Answers Ping With Pong", func(t *testing.T) {
+		writeFrame(t, conn, websocket.OpPing, []byte("are you there"))
+
+		opcode, payload := readFrame(t, reader)
+		assert.Equal(t, byte(websocket.OpPong), opcode)
+		assert.Equal(t, "are you there", string(payload))
+	})
+	t.Run("Server Reads Masked Text", func(t *testing.T) {
+		writeFrame(t, conn, websocket.OpText, []byte("hi"))
+
+		result := <-results
+		assert.NoError(t, result.err)
+		assert.Equal(t, byte(websocket.OpText), result.opcode)
+		assert.Equal(t, "hi", string(result.payload))
+	})
+	t.Run("Server Echoes Close", func(t *testing.T) {
+		closePayload := make([]byte, 2)
+		binary.BigEndian.PutUint16(closePayload, websocket.CloseNormal)
+		writeFrame(t, conn, websocket.OpClose, closePayload)
+
+		result := <-results
+		assert.ErrorIs(t, result.err, websocket.ErrConnectionClose)
+
+		opcode, payload := readFrame(t, reader)
+		assert.Equal(t, byte(websocket.OpClose), opcode)
+		assert.Equal(t, closePayload, payload)
+	})
+}
+
+func dial(t *testing.T, url string) (net.Conn, *bufio.Reader) {
+	conn, err := net.Dial("tcp", strings.TrimPrefix(url, "http://"))
+	assert.NoError(t, err)
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	io.WriteString(conn, "GET /ws HTTP/1.1\r\nHost: localhost\r\nConnection: Upgrade\r\nUpgrade: websocket\r\n"+
+		"Sec-WebSocket-Version: 13\r\nSec-WebSocket-Key: "+testKey+"\r\n\r\n")
+
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusSwitchingProtocols, resp.StatusCode)
+	assert.Equal(t, websocket.AcceptKey(testKey), resp.Header.Get("Sec-WebSocket-Accept"))
+
+	return conn, reader
+}
+
+// frame dari server tidak di-mask dan payload di test selalu pendek
+func readFrame(t *testing.T, reader *bufio.Reader) (byte, []byte) {
+	head := make([]byte, 2)
+	_, err := io.ReadFull(reader, head)
+	assert.NoError(t, err)
+	assert.Zero(t, head[1]&0x80)
+
+	payload := make([]byte, head[1]&0x7F)
+	_, err = io.ReadFull(reader, payload)
+	assert.NoError(t, err)
+
+	return head[0] & 0x0F, payload
+}
+
+// frame dari client wajib di-mask
+func writeFrame(t *testing.T, conn net.Conn, opcode byte, payload []byte) {
+	mask := []byte{0x12, 0x34, 0x56, 0x78}
+	frame := []byte{0x80 | opcode, 0x80 | byte(len(payload))}
+	frame = append(frame, mask...)
+	for i, b := range payload {
+		frame = append(frame, b^mask[i%4])
+	}
+
+	_, err := conn.Write(frame)
+	assert.NoError(t, err)
+}